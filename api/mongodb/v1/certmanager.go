@@ -0,0 +1,42 @@
+package v1
+
+const (
+	CertManagerGroup             = "cert-manager.io"
+	CertManagerIssuerKind        = "Issuer"
+	CertManagerClusterIssuerKind = "ClusterIssuer"
+)
+
+// CertManagerIssuerRef references the cert-manager issuer signing the certificates requested by the Operator.
+type CertManagerIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+	// Kind of the issuer, for example Issuer or ClusterIssuer. Defaults to Issuer, which has to be in the namespace of
+	// the resource.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer. Defaults to cert-manager.io, can be changed to use an external issuer.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+func (i CertManagerIssuerRef) GetKind() string {
+	if i.Kind == "" {
+		return CertManagerIssuerKind
+	}
+	return i.Kind
+}
+
+func (i CertManagerIssuerRef) GetGroup() string {
+	if i.Group == "" {
+		return CertManagerGroup
+	}
+	return i.Group
+}
+
+// CertManagerConfig makes the Operator request the TLS certificates of a resource from cert-manager, instead of
+// expecting the certificate Secrets to be created beforehand. The Certificates are valid for all the hostnames of
+// the resource and are updated when it's scaled.
+type CertManagerConfig struct {
+	// IssuerRef is the issuer signing the certificates.
+	IssuerRef CertManagerIssuerRef `json:"issuerRef"`
+}
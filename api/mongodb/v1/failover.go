@@ -0,0 +1,36 @@
+package v1
+
+// FailoverPolicy is what the Operator does with the members of a resource deployed to a member cluster that failed
+// its health check.
+type FailoverPolicy string
+
+const (
+	// FailoverPolicyAnnotate only adds the member cluster to the "failedClusters" annotation of the resource. The
+	// Operator skips the member cluster during the reconciliation, and removes it from the annotation once it is
+	// healthy again.
+	FailoverPolicyAnnotate FailoverPolicy = "Annotate"
+	// FailoverPolicyRedistributeMembers also spreads the members of the failed member cluster over the healthy ones.
+	// The member cluster stays in the "failedClusters" annotation until it is removed manually.
+	FailoverPolicyRedistributeMembers FailoverPolicy = "RedistributeMembers"
+	// FailoverPolicyReduceVotes also removes the votes and the priority of the members in the failed member cluster,
+	// so that the replica sets keep a majority of their voting members. The votes are restored once the member
+	// cluster is healthy again.
+	FailoverPolicyReduceVotes FailoverPolicy = "ReduceVotes"
+)
+
+// FailoverConfig configures the reaction of the Operator to the failure of a member cluster of a MultiCluster resource.
+type FailoverConfig struct {
+	// Policy defaults to Annotate. MongoDBMultiCluster resources default to RedistributeMembers if the Operator
+	// performs automated failovers (PERFORM_FAILOVER=true).
+	// +kubebuilder:validation:Enum=Annotate;RedistributeMembers;ReduceVotes
+	// +optional
+	Policy FailoverPolicy `json:"policy,omitempty"`
+}
+
+// GetPolicy returns the configured policy, or an empty policy if the default applies.
+func (f *FailoverConfig) GetPolicy() FailoverPolicy {
+	if f == nil {
+		return ""
+	}
+	return f.Policy
+}
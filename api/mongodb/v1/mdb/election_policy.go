@@ -0,0 +1,126 @@
+package mdb
+
+import (
+	"slices"
+	"strconv"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+)
+
+// maxVotingMembers is the maximum number of voting members of a replica set.
+const maxVotingMembers = 7
+
+// ElectionPolicy computes the votes and priorities of the members of a multi-cluster replica set from the member
+// cluster they are deployed to, instead of listing them in the memberConfig of each member cluster.
+type ElectionPolicy struct {
+	// PreferredClusters orders the member clusters by preference for hosting the primary. The voting members of the
+	// first member cluster get the highest priority, the voting members of the member clusters not listed get the
+	// lowest one.
+	// +optional
+	PreferredClusters []string `json:"preferredClusters,omitempty"`
+	// MaxVotingMembersPerCluster limits the number of voting members in each member cluster, the other members are
+	// non-voting. A replica set has at most 7 voting members, the members beyond are always non-voting.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxVotingMembersPerCluster *int `json:"maxVotingMembersPerCluster,omitempty"`
+}
+
+// Apply returns a copy of the cluster spec list with the votes and priorities of all the members computed by the
+// policy. The votes are handed out one member cluster at a time, starting with the preferred member clusters, so that
+// they are spread as evenly as possible. The tags of the members are kept. The list is returned unchanged if there
+// is no policy.
+func (p *ElectionPolicy) Apply(clusterSpecList ClusterSpecList) ClusterSpecList {
+	if p == nil {
+		return clusterSpecList
+	}
+
+	applied := clusterSpecList.DeepCopy()
+	votes := make([]int, len(applied))
+	totalVotes := 0
+	for granted := true; granted && totalVotes < maxVotingMembers; {
+		granted = false
+		for _, i := range p.clusterOrder(applied) {
+			if totalVotes == maxVotingMembers {
+				break
+			}
+			if votes[i] < p.maxVotingMembers(applied[i]) {
+				votes[i]++
+				totalVotes++
+				granted = true
+			}
+		}
+	}
+
+	for i := range applied {
+		priority := strconv.Itoa(p.priority(applied[i].ClusterName))
+		memberConfig := make([]automationconfig.MemberOptions, applied[i].Members)
+		for j := range memberConfig {
+			if j < len(applied[i].MemberConfig) {
+				memberConfig[j].Tags = applied[i].MemberConfig[j].Tags
+			}
+			if j < votes[i] {
+				memberConfig[j].Votes = ptr.To(1)
+				memberConfig[j].Priority = ptr.To(priority)
+			} else {
+				memberConfig[j].Votes = ptr.To(0)
+				memberConfig[j].Priority = ptr.To("0")
+			}
+		}
+		applied[i].MemberConfig = memberConfig
+	}
+	return applied
+}
+
+// clusterOrder returns the indexes of the member clusters, the preferred member clusters first.
+func (p *ElectionPolicy) clusterOrder(clusterSpecList ClusterSpecList) []int {
+	var order []int
+	for _, clusterName := range p.PreferredClusters {
+		if i := slices.IndexFunc(clusterSpecList, func(item ClusterSpecItem) bool { return item.ClusterName == clusterName }); i >= 0 {
+			order = append(order, i)
+		}
+	}
+	for i := range clusterSpecList {
+		if !slices.Contains(order, i) {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+func (p *ElectionPolicy) maxVotingMembers(item ClusterSpecItem) int {
+	if p.MaxVotingMembersPerCluster != nil {
+		return min(item.Members, *p.MaxVotingMembersPerCluster)
+	}
+	return item.Members
+}
+
+// priority returns the priority of the voting members of the member cluster: 1 if it is not a preferred member
+// cluster, and up to len(PreferredClusters)+1 for the first preferred member cluster.
+func (p *ElectionPolicy) priority(clusterName string) int {
+	i := slices.Index(p.PreferredClusters, clusterName)
+	if i < 0 {
+		return 1
+	}
+	return len(p.PreferredClusters) - i + 1
+}
+
+// ClustersBreakingMajority returns the member clusters whose loss leaves the replica set without a majority of its
+// voting members.
+func ClustersBreakingMajority(clusterSpecList ClusterSpecList) []string {
+	votingMembers := make([]int, len(clusterSpecList))
+	totalVotingMembers := 0
+	for i, item := range clusterSpecList {
+		votingMembers[i] = automationconfig.VotingMembers(item.Members, item.MemberConfig)
+		totalVotingMembers += votingMembers[i]
+	}
+
+	var clusterNames []string
+	for i, item := range clusterSpecList {
+		if votingMembers[i] > 0 && (totalVotingMembers-votingMembers[i])*2 <= totalVotingMembers {
+			clusterNames = append(clusterNames, item.ClusterName)
+		}
+	}
+	return clusterNames
+}
@@ -0,0 +1,97 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+)
+
+func votesAndPriorities(item ClusterSpecItem) ([]int, []string) {
+	var votes []int
+	var priorities []string
+	for _, o := range item.MemberConfig {
+		votes = append(votes, *o.Votes)
+		priorities = append(priorities, *o.Priority)
+	}
+	return votes, priorities
+}
+
+func TestElectionPolicy_PrefersClustersInOrder(t *testing.T) {
+	policy := &ElectionPolicy{PreferredClusters: []string{"primary", "secondary"}}
+	clusters := ClusterSpecList{
+		{ClusterName: "dr", Members: 2, MemberConfig: []automationconfig.MemberOptions{{Tags: map[string]string{"region": "dr"}}}},
+		{ClusterName: "secondary", Members: 2},
+		{ClusterName: "primary", Members: 3},
+	}
+
+	applied := policy.Apply(clusters)
+
+	votes, priorities := votesAndPriorities(applied[2])
+	assert.Equal(t, []int{1, 1, 1}, votes)
+	assert.Equal(t, []string{"3", "3", "3"}, priorities)
+	votes, priorities = votesAndPriorities(applied[1])
+	assert.Equal(t, []int{1, 1}, votes)
+	assert.Equal(t, []string{"2", "2"}, priorities)
+	votes, priorities = votesAndPriorities(applied[0])
+	assert.Equal(t, []int{1, 1}, votes)
+	assert.Equal(t, []string{"1", "1"}, priorities)
+
+	assert.Equal(t, map[string]string{"region": "dr"}, applied[0].MemberConfig[0].Tags)
+	assert.Nil(t, clusters[1].MemberConfig, "the cluster spec list must not be modified")
+}
+
+func TestElectionPolicy_LimitsVotingMembers(t *testing.T) {
+	t.Run("at most 7 voting members, spread over the clusters", func(t *testing.T) {
+		policy := &ElectionPolicy{PreferredClusters: []string{"b"}}
+		applied := policy.Apply(ClusterSpecList{
+			{ClusterName: "a", Members: 5},
+			{ClusterName: "b", Members: 5},
+		})
+
+		votes, priorities := votesAndPriorities(applied[1])
+		assert.Equal(t, []int{1, 1, 1, 1, 0}, votes)
+		assert.Equal(t, []string{"2", "2", "2", "2", "0"}, priorities)
+		votes, _ = votesAndPriorities(applied[0])
+		assert.Equal(t, []int{1, 1, 1, 0, 0}, votes)
+	})
+	t.Run("max voting members per cluster", func(t *testing.T) {
+		policy := &ElectionPolicy{MaxVotingMembersPerCluster: ptr.To(1)}
+		applied := policy.Apply(ClusterSpecList{
+			{ClusterName: "a", Members: 3},
+			{ClusterName: "b", Members: 1},
+			{ClusterName: "c", Members: 0},
+		})
+
+		votes, _ := votesAndPriorities(applied[0])
+		assert.Equal(t, []int{1, 0, 0}, votes)
+		votes, _ = votesAndPriorities(applied[1])
+		assert.Equal(t, []int{1}, votes)
+		assert.Empty(t, applied[2].MemberConfig)
+	})
+}
+
+func TestElectionPolicy_Nil(t *testing.T) {
+	var policy *ElectionPolicy
+	clusters := ClusterSpecList{{ClusterName: "a", Members: 3}}
+	assert.Equal(t, clusters, policy.Apply(clusters))
+}
+
+func TestClustersBreakingMajority(t *testing.T) {
+	assert.Empty(t, ClustersBreakingMajority(ClusterSpecList{
+		{ClusterName: "a", Members: 2},
+		{ClusterName: "b", Members: 2},
+		{ClusterName: "c", Members: 1},
+	}))
+	assert.Equal(t, []string{"a"}, ClustersBreakingMajority(ClusterSpecList{
+		{ClusterName: "a", Members: 3},
+		{ClusterName: "b", Members: 2},
+	}))
+	assert.Equal(t, []string{"a", "b"}, ClustersBreakingMajority(ClusterSpecList{
+		{ClusterName: "a", Members: 2},
+		{ClusterName: "b", Members: 2},
+		{ClusterName: "c", Members: 1, MemberConfig: []automationconfig.MemberOptions{{Votes: ptr.To(0)}}},
+	}))
+}
@@ -0,0 +1,67 @@
+package mdb
+
+import (
+	"time"
+)
+
+const hoursInWeek = 7 * 24
+
+// MaintenanceWindow is a weekly recurring time window during which the Operator is allowed to apply
+// changes that require the MongoDB processes to be restarted (version upgrades, TLS mode changes and
+// mongod configuration changes). Changes that don't restart the processes are applied immediately.
+type MaintenanceWindow struct {
+	// DayOfWeek is the day of the week (UTC) on which the maintenance window opens.
+	// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+	// +kubebuilder:validation:Required
+	DayOfWeek string `json:"dayOfWeek"`
+	// StartHour is the hour of the day (UTC) at which the maintenance window opens.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	StartHour int `json:"startHour"`
+	// DurationHours is the length of the maintenance window in hours.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=168
+	// +kubebuilder:validation:Required
+	DurationHours int `json:"durationHours"`
+}
+
+// IsOpen returns true if the given time falls into the maintenance window.
+// A nil window is always open: without a maintenance window all changes are applied immediately.
+func (w *MaintenanceWindow) IsOpen(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	return w.sinceLastOpening(t) < time.Duration(w.DurationHours)*time.Hour
+}
+
+// NextOpening returns the time at which the maintenance window opens next after the given time.
+func (w *MaintenanceWindow) NextOpening(t time.Time) time.Time {
+	t = t.UTC()
+	if w == nil {
+		return t
+	}
+	return t.Add(hoursInWeek*time.Hour - w.sinceLastOpening(t))
+}
+
+// sinceLastOpening returns the time passed since the most recent opening of the window.
+func (w *MaintenanceWindow) sinceLastOpening(t time.Time) time.Duration {
+	t = t.UTC()
+	startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	startOfWeek := startOfDay.AddDate(0, 0, -int(t.Weekday()))
+	opening := startOfWeek.Add(time.Duration(int(w.weekday())*24+w.StartHour) * time.Hour)
+
+	since := t.Sub(opening) % (hoursInWeek * time.Hour)
+	if since < 0 {
+		since += hoursInWeek * time.Hour
+	}
+	return since
+}
+
+func (w *MaintenanceWindow) weekday() time.Weekday {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if d.String() == w.DayOfWeek {
+			return d
+		}
+	}
+	return time.Sunday
+}
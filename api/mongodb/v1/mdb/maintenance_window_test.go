@@ -0,0 +1,53 @@
+package mdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindow_IsOpen(t *testing.T) {
+	// Saturday 22:00 UTC to Sunday 02:00 UTC
+	window := &MaintenanceWindow{DayOfWeek: "Saturday", StartHour: 22, DurationHours: 4}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{name: "Before opening", now: time.Date(2024, 6, 1, 21, 59, 0, 0, time.UTC), expected: false},
+		{name: "At opening", now: time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC), expected: true},
+		{name: "Wraps to the next week", now: time.Date(2024, 6, 2, 1, 30, 0, 0, time.UTC), expected: true},
+		{name: "At closing", now: time.Date(2024, 6, 2, 2, 0, 0, 0, time.UTC), expected: false},
+		{name: "Middle of the week", now: time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC), expected: false},
+		{name: "Non UTC time zone", now: time.Date(2024, 6, 2, 0, 30, 0, 0, time.FixedZone("CEST", 2*60*60)), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, window.IsOpen(tt.now))
+		})
+	}
+}
+
+func TestMaintenanceWindow_NilIsAlwaysOpen(t *testing.T) {
+	var window *MaintenanceWindow
+	assert.True(t, window.IsOpen(time.Now()))
+}
+
+func TestMaintenanceWindow_NextOpening(t *testing.T) {
+	window := &MaintenanceWindow{DayOfWeek: "Monday", StartHour: 3, DurationHours: 2}
+
+	// Wednesday
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 6, 10, 3, 0, 0, 0, time.UTC), window.NextOpening(now))
+
+	// Monday, before opening
+	now = time.Date(2024, 6, 10, 1, 15, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 6, 10, 3, 0, 0, 0, time.UTC), window.NextOpening(now))
+
+	// Monday, window is open: the next opening is a week later
+	now = time.Date(2024, 6, 10, 4, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 6, 17, 3, 0, 0, 0, time.UTC), window.NextOpening(now))
+}
@@ -376,6 +376,10 @@ type MongoDbStatus struct {
 	ProjectId                              string                                     `json:"projectId,omitempty"`
 	FeatureCompatibilityVersion            string                                     `json:"featureCompatibilityVersion,omitempty"`
 	Warnings                               []status.Warning                           `json:"warnings,omitempty"`
+	// StorageExpansions records the last expansion of each volume by storage autoscaling.
+	StorageExpansions status.StorageExpansions `json:"storageExpansions,omitempty"`
+	// Zones records the index and the members of each zone of spec.zonePlacement.
+	Zones []status.ZoneStatusItem `json:"zones,omitempty"`
 }
 
 type BackupMode string
@@ -441,6 +445,25 @@ type DbCommonSpec struct {
 	// +kubebuilder:validation:Enum=SingleCluster;MultiCluster
 	// +optional
 	Topology string `json:"topology,omitempty"`
+
+	// Failover configures what the Operator does when a member cluster of a MultiCluster resource fails its health check.
+	// +optional
+	Failover *v1.FailoverConfig `json:"failover,omitempty"`
+
+	// MaintenanceWindow restricts changes requiring a restart of the MongoDB processes to a weekly time window.
+	// Changes that don't require a restart are applied immediately.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudgets created for each of the StatefulSets. By default, the
+	// Operator allows as many Pods to be evicted as the replica set can lose while keeping a majority of its voting members.
+	// +optional
+	PodDisruptionBudget *v1.PodDisruptionBudgetConfiguration `json:"podDisruptionBudget,omitempty"`
+
+	// TopologySpread spreads the Pods of each of the StatefulSets across zones and nodes. The members of a replica set,
+	// of each shard and of the config server replica set can't be spread so that a single zone holds a majority of them.
+	// +optional
+	TopologySpread *v1.TopologySpread `json:"topologySpread,omitempty"`
 }
 
 type MongoDbSpec struct {
@@ -460,6 +483,14 @@ type MongoDbSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
 	MemberConfig []automationconfig.MemberOptions `json:"memberConfig,omitempty"`
+
+	// RestoreFromSnapshot creates the volumes of the replica set or standalone from a MongoDBVolumeSnapshot.
+	// +optional
+	RestoreFromSnapshot *v1.RestoreFromSnapshot `json:"restoreFromSnapshot,omitempty"`
+
+	// ZonePlacement deploys the members of a replica set to the given zones, with one StatefulSet per zone.
+	// +optional
+	ZonePlacement *ZonePlacement `json:"zonePlacement,omitempty"`
 }
 
 func (m *MongoDbSpec) GetExternalDomain() *string {
@@ -1000,7 +1031,8 @@ type MongoDBRoleRef struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 
-	// +kubebuilder:validation:Enum=ClusterMongoDBRole
+	// Kind of the referenced role. A MongoDBRole must be in the same namespace as the referencing resource.
+	// +kubebuilder:validation:Enum=ClusterMongoDBRole;MongoDBRole
 	// +kubebuilder:validation:Required
 	Kind string `json:"kind"`
 }
@@ -1171,6 +1203,18 @@ type TLSConfig struct {
 	// CA corresponds to a ConfigMap containing an entry for the CA certificate (ca.pem)
 	// used to validate the certificates created already.
 	CA string `json:"ca,omitempty"`
+
+	// CertManager makes the Operator request the certificates of the members from cert-manager. TLS still needs to be
+	// enabled with `security.certsSecretPrefix`, the certificates are stored in the Secrets the Operator would
+	// otherwise expect to exist.
+	// +optional
+	CertManager *v1.CertManagerConfig `json:"certManager,omitempty"`
+
+	// VaultPKI makes the Operator request the certificates of the members, agents and internal cluster
+	// authentication from the Vault PKI secrets engine. It requires the Vault secret backend, the certificates are
+	// written to the Vault paths the Operator would otherwise expect them in.
+	// +optional
+	VaultPKI *v1.VaultPKIConfig `json:"vaultPKI,omitempty"`
 }
 
 func (m *MongoDbSpec) GetTLSConfig() *TLSConfig {
@@ -1313,11 +1357,20 @@ func (m *MongoDB) UpdateStatus(phase status.Phase, statusOptions ...status.Optio
 	if option, exists := status.GetOption(statusOptions, status.ProjectIdOption{}); exists {
 		m.Status.ProjectId = option.(status.ProjectIdOption).ProjectId
 	}
+	if option, exists := status.GetOption(statusOptions, status.StorageExpansionsOption{}); exists {
+		for _, expansion := range option.(status.StorageExpansionsOption).Expansions {
+			m.Status.StorageExpansions = m.Status.StorageExpansions.Merge(expansion)
+		}
+	}
 	switch m.Spec.ResourceType {
 	case ReplicaSet:
 		if option, exists := status.GetOption(statusOptions, status.ReplicaSetMembersOption{}); exists {
 			m.Status.Members = option.(status.ReplicaSetMembersOption).Members
 		}
+		if option, exists := status.GetOption(statusOptions, status.ReplicaSetZonesOption{}); exists {
+			m.Status.Members = option.(status.ReplicaSetZonesOption).Members
+			m.Status.Zones = option.(status.ReplicaSetZonesOption).Zones
+		}
 	case ShardedCluster:
 		if option, exists := status.GetOption(statusOptions, status.ShardedClusterSizeConfigOption{}); exists {
 			if sizeConfig := option.(status.ShardedClusterSizeConfigOption).SizeConfig; sizeConfig != nil {
@@ -1518,6 +1571,24 @@ type MongoDbPodSpec struct {
 	Persistence *v1.Persistence `json:"persistence,omitempty"`
 }
 
+// GetPersistence returns the persistence config, or nil if the pod spec is not set.
+func (m *MongoDbPodSpec) GetPersistence() *v1.Persistence {
+	if m == nil {
+		return nil
+	}
+	return m.Persistence
+}
+
+// HasStorageAutoscale returns true if the persistent volumes of any of the pod specs are autoscaled.
+func (m *MongoDbSpec) HasStorageAutoscale() bool {
+	for _, podSpec := range []*MongoDbPodSpec{m.PodSpec, m.ShardPodSpec, m.ConfigSrvPodSpec} {
+		if persistence := podSpec.GetPersistence(); persistence != nil && persistence.Autoscale != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *MongoDbPodSpec) IsAgentImageOverridden() bool {
 	if m.PodTemplateWrapper.PodTemplate != nil && isAgentImageOverriden(m.PodTemplateWrapper.PodTemplate.Spec.Containers) {
 		return true
@@ -1782,6 +1853,15 @@ func (m *MongoDBConnectionStringBuilder) BuildConnectionString(username, passwor
 		name = m.MongosRsName()
 	}
 
+	hostnames := m.hostnames
+	if len(hostnames) == 0 && m.Spec.ZonePlacement != nil {
+		// the members of the zones are deployed by StatefulSets which aren't named after the resource
+		hostnames, _ = m.ZoneDNSNames(m.zoneTargetMembers())
+		for i, h := range hostnames {
+			hostnames[i] = fmt.Sprintf("%s:%d", h, m.Spec.GetAdditionalMongodConfig().GetPortOrDefault())
+		}
+	}
+
 	builder := connectionstring.Builder().
 		SetName(name).
 		SetNamespace(m.Namespace).
@@ -1798,7 +1878,7 @@ func (m *MongoDBConnectionStringBuilder) BuildConnectionString(username, passwor
 		SetIsTLSEnabled(m.Spec.IsSecurityTLSConfigEnabled()).
 		SetConnectionParams(connectionParams).
 		SetScheme(scheme).
-		SetHostnames(m.hostnames)
+		SetHostnames(hostnames)
 
 	return builder.Build()
 }
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/strings/slices"
@@ -372,6 +373,162 @@ func ldapGroupDnIsSetIfLdapAuthzIsEnabledAndAgentsAreExternal(d DbCommonSpec) v1
 	return v1.ValidationSuccess()
 }
 
+// failoverRequiresMultiCluster rejects spec.failover for resources which are not deployed to member clusters.
+func failoverRequiresMultiCluster(ms MongoDbSpec) v1.ValidationResult {
+	if ms.Failover != nil && !ms.IsMultiCluster() {
+		return v1.ValidationError("spec.failover can only be set for the MultiCluster topology")
+	}
+	return v1.ValidationSuccess()
+}
+
+// restoreFromSnapshotValidation checks that the volumes can be restored from a snapshot, which is only taken of a
+// single replica set.
+func restoreFromSnapshotValidation(ms MongoDbSpec) v1.ValidationResult {
+	if ms.RestoreFromSnapshot == nil {
+		return v1.ValidationSuccess()
+	}
+	if ms.ResourceType == ShardedCluster {
+		return v1.ValidationError("spec.restoreFromSnapshot is not supported for sharded clusters")
+	}
+	if ms.Persistent != nil && !*ms.Persistent {
+		return v1.ValidationError("spec.restoreFromSnapshot requires spec.persistent to be true")
+	}
+	return v1.ValidationSuccess()
+}
+
+// topologySpreadMembersValidation checks that the zone constraint of spec.topologySpread can't put a majority of the
+// members of a replica set in a single zone. The members of a MultiCluster resource are spread in each member cluster
+// on their own, so only single cluster resources are checked.
+func topologySpreadMembersValidation(ms MongoDbSpec) v1.ValidationResult {
+	if ms.TopologySpread == nil || ms.IsMultiCluster() {
+		return v1.ValidationSuccess()
+	}
+	type replicaSetMembers struct {
+		name    string
+		members int
+	}
+	var replicaSets []replicaSetMembers
+	switch ms.ResourceType {
+	case ReplicaSet:
+		replicaSets = append(replicaSets, replicaSetMembers{"replica set", ms.Members})
+	case ShardedCluster:
+		replicaSets = append(replicaSets, replicaSetMembers{"shards", ms.MongodsPerShardCount}, replicaSetMembers{"config servers", ms.ConfigServerCount})
+		for _, shardOverride := range ms.ShardOverrides {
+			if shardOverride.Members != nil {
+				replicaSets = append(replicaSets, replicaSetMembers{"shards " + strings.Join(shardOverride.ShardNames, ", "), *shardOverride.Members})
+			}
+		}
+	}
+	for _, rs := range replicaSets {
+		if err := ms.TopologySpread.ValidateMembers("spec.topologySpread", rs.members); err != nil {
+			return v1.ValidationError("%s: %s", rs.name, err)
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
+// zonePlacementValidation checks that the zones of spec.zonePlacement hold all the members of a single cluster replica
+// set. The members of the zones are reached through the headless service only, as the external services and the
+// horizons are configured for the Pods of the StatefulSet named after the resource.
+func zonePlacementValidation(ms MongoDbSpec) v1.ValidationResult {
+	placement := ms.ZonePlacement
+	if placement == nil {
+		return v1.ValidationSuccess()
+	}
+	if ms.ResourceType != ReplicaSet || ms.IsMultiCluster() {
+		return v1.ValidationError("spec.zonePlacement is only supported for single cluster replica sets")
+	}
+	if ms.ExternalAccessConfiguration != nil {
+		return v1.ValidationError("spec.zonePlacement can't be used with spec.externalAccess")
+	}
+	if ms.Connectivity != nil && len(ms.Connectivity.ReplicaSetHorizons) > 0 {
+		return v1.ValidationError("spec.zonePlacement can't be used with spec.connectivity.replicaSetHorizons")
+	}
+	if ms.TopologySpread != nil && ms.TopologySpread.Zone != nil {
+		return v1.ValidationError("spec.topologySpread.zone can't be used with spec.zonePlacement, which already pins the members to their zone")
+	}
+
+	members := 0
+	zones := map[string]bool{}
+	for _, zone := range placement.Zones {
+		if zones[zone.Zone] {
+			return v1.ValidationError("spec.zonePlacement.zones has more than one zone %s", zone.Zone)
+		}
+		zones[zone.Zone] = true
+		if errs := validation.IsValidLabelValue(zone.Zone); len(errs) > 0 {
+			return v1.ValidationError("spec.zonePlacement.zones: %s is not a valid zone name: %s", zone.Zone, strings.Join(errs, ", "))
+		}
+		members += zone.Members
+	}
+	if members != ms.Members {
+		return v1.ValidationError("the members of spec.zonePlacement.zones add up to %d, but spec.members is %d", members, ms.Members)
+	}
+
+	for _, zone := range placement.Zones {
+		if ms.Members > 1 && zone.Members > ms.Members/2 {
+			return v1.ValidationWarning("zone %s holds %d of the %d members, which is a majority: the replica set has no primary while the zone is unavailable", zone.Zone, zone.Members, ms.Members)
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
+// storageAutoscaleValidation checks the storage autoscaling of the pod specs which have persistent volumes.
+func storageAutoscaleValidation(ms MongoDbSpec) v1.ValidationResult {
+	podSpecs := []struct {
+		field   string
+		podSpec *MongoDbPodSpec
+	}{
+		{"spec.podSpec", ms.PodSpec},
+		{"spec.shardPodSpec", ms.ShardPodSpec},
+		{"spec.configSrvPodSpec", ms.ConfigSrvPodSpec},
+	}
+	for _, p := range podSpecs {
+		if res := ValidateStorageAutoscale(p.field, ms.Persistent, p.podSpec.GetPersistence()); res.Level == v1.ErrorLevel {
+			return res
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
+// ValidateStorageAutoscale checks the storage autoscaling of the persistence config of the pod spec at field. It is
+// shared by the MongoDB and the MongoDBMultiCluster resources.
+func ValidateStorageAutoscale(field string, persistent *bool, persistence *v1.Persistence) v1.ValidationResult {
+	if persistence == nil || persistence.Autoscale == nil {
+		return v1.ValidationSuccess()
+	}
+	if persistent != nil && !*persistent {
+		return v1.ValidationError("%s.persistence.autoscale requires spec.persistent to be true", field)
+	}
+	maxStorage, err := resource.ParseQuantity(persistence.Autoscale.MaxStorage)
+	if err != nil {
+		return v1.ValidationError("%s.persistence.autoscale.maxStorage is invalid: %s", field, err)
+	}
+	if _, err := persistence.Autoscale.ExpandedStorage(resource.Quantity{}); err != nil {
+		return v1.ValidationError("%s.persistence.autoscale: %s", field, err)
+	}
+	for _, storage := range persistenceStorages(persistence) {
+		if quantity, err := resource.ParseQuantity(storage); err == nil && quantity.Cmp(maxStorage) > 0 {
+			return v1.ValidationError("%s.persistence.autoscale.maxStorage %s is lower than the storage %s", field, persistence.Autoscale.MaxStorage, storage)
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
+func persistenceStorages(persistence *v1.Persistence) []string {
+	var storages []string
+	if persistence.SingleConfig != nil {
+		storages = append(storages, persistence.SingleConfig.Storage)
+	}
+	if persistence.MultipleConfig != nil {
+		for _, config := range []*v1.PersistenceConfig{persistence.MultipleConfig.Data, persistence.MultipleConfig.Journal, persistence.MultipleConfig.Logs} {
+			if config != nil {
+				storages = append(storages, config.Storage)
+			}
+		}
+	}
+	return storages
+}
+
 func resourceTypeImmutable(newObj, oldObj MongoDbSpec) v1.ValidationResult {
 	if newObj.ResourceType != oldObj.ResourceType {
 		return v1.ValidationError("'resourceType' cannot be changed once created")
@@ -387,6 +544,15 @@ func noTopologyMigration(newObj, oldObj MongoDbSpec) v1.ValidationResult {
 	return v1.ValidationSuccess()
 }
 
+// zonePlacementImmutable prevents moving the members of an existing replica set between the StatefulSet named after
+// the resource and the StatefulSets of the zones, which would recreate all of them.
+func zonePlacementImmutable(newObj, oldObj MongoDbSpec) v1.ValidationResult {
+	if (newObj.ZonePlacement == nil) != (oldObj.ZonePlacement == nil) {
+		return v1.ValidationError("spec.zonePlacement can't be added to or removed from an existing replica set")
+	}
+	return v1.ValidationSuccess()
+}
+
 func noSimultaneousTLSDisablingAndScaling(newObj, oldObj MongoDbSpec) v1.ValidationResult {
 	if newObj.ResourceType != ReplicaSet {
 		return v1.ValidationSuccess()
@@ -436,6 +602,9 @@ func CommonValidators(db DbCommonSpec) []func(d DbCommonSpec) v1.ValidationResul
 		ldapGroupDnIsSetIfLdapAuthzIsEnabledAndAgentsAreExternal,
 		specWithExactlyOneSchema,
 		featureCompatibilityVersionValidation,
+		podDisruptionBudgetValidation,
+		topologySpreadValidation,
+		singleCertificateIssuer,
 	}
 
 	validators = append(validators, oidcAuthValidators(db)...)
@@ -443,6 +612,30 @@ func CommonValidators(db DbCommonSpec) []func(d DbCommonSpec) v1.ValidationResul
 	return validators
 }
 
+func podDisruptionBudgetValidation(d DbCommonSpec) v1.ValidationResult {
+	if d.PodDisruptionBudget != nil && d.PodDisruptionBudget.MinAvailable != nil && d.PodDisruptionBudget.MaxUnavailable != nil {
+		return v1.ValidationError("only one of 'minAvailable' and 'maxUnavailable' can be specified in spec.podDisruptionBudget")
+	}
+	return v1.ValidationSuccess()
+}
+
+func topologySpreadValidation(d DbCommonSpec) v1.ValidationResult {
+	if err := d.TopologySpread.Validate("spec.topologySpread"); err != nil {
+		return v1.ValidationError("%s", err)
+	}
+	if d.TopologySpread.IsBestEffort() {
+		return v1.ValidationWarning("spec.topologySpread uses whenUnsatisfiable ScheduleAnyway, which lets a single zone or node hold a majority of the members if the Pods can't be spread")
+	}
+	return v1.ValidationSuccess()
+}
+
+func singleCertificateIssuer(d DbCommonSpec) v1.ValidationResult {
+	if d.Security != nil && d.Security.TLSConfig != nil && d.Security.TLSConfig.CertManager != nil && d.Security.TLSConfig.VaultPKI != nil {
+		return v1.ValidationError("only one of 'certManager' and 'vaultPKI' can be specified in spec.security.tls")
+	}
+	return v1.ValidationSuccess()
+}
+
 func featureCompatibilityVersionValidation(d DbCommonSpec) v1.ValidationResult {
 	fcv := d.FeatureCompatibilityVersion
 	return ValidateFCV(fcv)
@@ -476,12 +669,18 @@ func (m *MongoDB) RunValidations(old *MongoDB) []v1.ValidationResult {
 		horizonDomainNamesMustBeValid,
 		additionalMongodConfig,
 		replicasetMemberIsSpecified,
+		failoverRequiresMultiCluster,
+		storageAutoscaleValidation,
+		restoreFromSnapshotValidation,
+		topologySpreadMembersValidation,
+		zonePlacementValidation,
 	}
 
 	updateValidators := []func(newObj MongoDbSpec, oldObj MongoDbSpec) v1.ValidationResult{
 		resourceTypeImmutable,
 		noTopologyMigration,
 		noSimultaneousTLSDisablingAndScaling,
+		zonePlacementImmutable,
 	}
 
 	var validationResults []v1.ValidationResult
@@ -6,8 +6,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	corev1 "k8s.io/api/core/v1"
+
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
@@ -282,6 +285,34 @@ func TestReplicasetMemberIsSpecified(t *testing.T) {
 	require.NoError(t, rs.ProcessValidationsOnReconcile(nil))
 }
 
+func TestPodDisruptionBudgetValidation(t *testing.T) {
+	rs := NewReplicaSetBuilder().Build()
+	rs.Spec.CloudManagerConfig = &PrivateCloudConfig{
+		ConfigMapRef: ConfigMapRef{Name: "cloud-manager"},
+	}
+	rs.Spec.PodDisruptionBudget = &v1.PodDisruptionBudgetConfiguration{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+	require.NoError(t, rs.ProcessValidationsOnReconcile(nil))
+
+	rs.Spec.PodDisruptionBudget.MinAvailable = ptr.To(intstr.FromString("50%"))
+	err := rs.ProcessValidationsOnReconcile(nil)
+	require.Error(t, err)
+	assert.EqualError(t, err, "only one of 'minAvailable' and 'maxUnavailable' can be specified in spec.podDisruptionBudget")
+}
+
+func TestSingleCertificateIssuerValidation(t *testing.T) {
+	rs := NewReplicaSetBuilder().SetSecurityTLSEnabled().Build()
+	rs.Spec.CloudManagerConfig = &PrivateCloudConfig{
+		ConfigMapRef: ConfigMapRef{Name: "cloud-manager"},
+	}
+	rs.Spec.Security.TLSConfig.VaultPKI = &v1.VaultPKIConfig{Role: "mongodb"}
+	require.NoError(t, rs.ProcessValidationsOnReconcile(nil))
+
+	rs.Spec.Security.TLSConfig.CertManager = &v1.CertManagerConfig{IssuerRef: v1.CertManagerIssuerRef{Name: "my-issuer"}}
+	err := rs.ProcessValidationsOnReconcile(nil)
+	require.Error(t, err)
+	assert.EqualError(t, err, "only one of 'certManager' and 'vaultPKI' can be specified in spec.security.tls")
+}
+
 func TestReplicasetFCV(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -728,3 +759,102 @@ func TestOIDCProviderConfigUniqueIssuerURIValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestFailoverRequiresMultiCluster(t *testing.T) {
+	rs := NewReplicaSetBuilder().Build()
+	rs.Spec.Failover = &v1.FailoverConfig{Policy: v1.FailoverPolicyAnnotate}
+	res := failoverRequiresMultiCluster(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.failover can only be set for the MultiCluster topology", res.Msg)
+
+	sc := NewDefaultMultiShardedClusterBuilder().Build()
+	sc.Spec.Failover = &v1.FailoverConfig{Policy: v1.FailoverPolicyReduceVotes}
+	assert.Equal(t, v1.SuccessLevel, failoverRequiresMultiCluster(sc.Spec).Level)
+}
+
+func TestStorageAutoscaleValidation(t *testing.T) {
+	newReplicaSet := func(storage string, autoscale *v1.StorageAutoscale) *MongoDB {
+		rs := NewReplicaSetBuilder().Build()
+		rs.Spec.PodSpec = &MongoDbPodSpec{Persistence: &v1.Persistence{
+			SingleConfig: &v1.PersistenceConfig{Storage: storage},
+			Autoscale:    autoscale,
+		}}
+		return rs
+	}
+
+	rs := newReplicaSet("10Gi", &v1.StorageAutoscale{MaxStorage: "100Gi"})
+	assert.Equal(t, v1.SuccessLevel, storageAutoscaleValidation(rs.Spec).Level)
+
+	rs = newReplicaSet("10Gi", &v1.StorageAutoscale{MaxStorage: "5Gi"})
+	res := storageAutoscaleValidation(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.podSpec.persistence.autoscale.maxStorage 5Gi is lower than the storage 10Gi", res.Msg)
+
+	rs = newReplicaSet("10Gi", &v1.StorageAutoscale{MaxStorage: "lots"})
+	assert.Equal(t, v1.ErrorLevel, storageAutoscaleValidation(rs.Spec).Level)
+
+	rs = newReplicaSet("10Gi", &v1.StorageAutoscale{Increase: "0%", MaxStorage: "100Gi"})
+	assert.Equal(t, v1.ErrorLevel, storageAutoscaleValidation(rs.Spec).Level)
+
+	rs = newReplicaSet("10Gi", &v1.StorageAutoscale{MaxStorage: "100Gi"})
+	rs.Spec.Persistent = ptr.To(false)
+	res = storageAutoscaleValidation(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.podSpec.persistence.autoscale requires spec.persistent to be true", res.Msg)
+
+	sc := NewDefaultShardedClusterBuilder().Build()
+	sc.Spec.ShardPodSpec = &MongoDbPodSpec{Persistence: &v1.Persistence{Autoscale: &v1.StorageAutoscale{Increase: "10Gi", MaxStorage: "1Ti"}}}
+	assert.Equal(t, v1.SuccessLevel, storageAutoscaleValidation(sc.Spec).Level)
+}
+
+func TestRestoreFromSnapshotValidation(t *testing.T) {
+	restore := &v1.RestoreFromSnapshot{MongoDBVolumeSnapshotName: "my-rs-snapshot"}
+
+	rs := NewReplicaSetBuilder().Build()
+	rs.Spec.RestoreFromSnapshot = restore
+	assert.Equal(t, v1.SuccessLevel, restoreFromSnapshotValidation(rs.Spec).Level)
+
+	rs.Spec.Persistent = ptr.To(false)
+	res := restoreFromSnapshotValidation(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.restoreFromSnapshot requires spec.persistent to be true", res.Msg)
+
+	sc := NewDefaultShardedClusterBuilder().Build()
+	sc.Spec.RestoreFromSnapshot = restore
+	res = restoreFromSnapshotValidation(sc.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.restoreFromSnapshot is not supported for sharded clusters", res.Msg)
+}
+
+func TestTopologySpreadValidation(t *testing.T) {
+	rs := NewReplicaSetBuilder().SetMembers(3).Build()
+	rs.Spec.TopologySpread = &v1.TopologySpread{Zone: &v1.TopologySpreadConstraint{MinDomains: ptr.To(int32(3))}}
+	assert.Equal(t, v1.SuccessLevel, topologySpreadMembersValidation(rs.Spec).Level)
+	assert.Equal(t, v1.SuccessLevel, topologySpreadValidation(rs.Spec.DbCommonSpec).Level)
+
+	rs.Spec.TopologySpread.Zone.MinDomains = nil
+	res := topologySpreadMembersValidation(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "replica set: spec.topologySpread.zone allows 3 of the 3 members in a single zone, which is a majority: set spec.topologySpread.zone.minDomains to at least 3", res.Msg)
+
+	rs.Spec.TopologySpread.Zone.WhenUnsatisfiable = corev1.ScheduleAnyway
+	assert.Equal(t, v1.SuccessLevel, topologySpreadMembersValidation(rs.Spec).Level)
+	assert.Equal(t, v1.WarningLevel, topologySpreadValidation(rs.Spec.DbCommonSpec).Level)
+
+	sc := NewDefaultShardedClusterBuilder().Build()
+	sc.Spec.TopologySpread = &v1.TopologySpread{Zone: &v1.TopologySpreadConstraint{MinDomains: ptr.To(int32(3))}}
+	assert.Equal(t, v1.SuccessLevel, topologySpreadMembersValidation(sc.Spec).Level)
+
+	sc.Spec.TopologySpread.Zone.MinDomains = nil
+	res = topologySpreadMembersValidation(sc.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.True(t, strings.HasPrefix(res.Msg, "shards: "))
+
+	// a single member is not spread, but the shards with more members are checked
+	sc.Spec.MongodsPerShardCount = 1
+	sc.Spec.ConfigServerCount = 1
+	sc.Spec.ShardOverrides = []ShardOverride{{ShardNames: []string{"sh-0", "sh-1"}, Members: ptr.To(2)}}
+	res = topologySpreadMembersValidation(sc.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "shards sh-0, sh-1: spec.topologySpread.zone allows 2 of the 2 members in a single zone, which is a majority: set spec.topologySpread.zone.minDomains to at least 2", res.Msg)
+}
@@ -0,0 +1,144 @@
+package mdb
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+)
+
+// ZonePlacement pins the members of a single cluster replica set to zones. A StatefulSet shares one Pod template
+// between all its Pods, so the members of each zone are deployed by their own StatefulSet, named after the index of
+// the zone like the StatefulSets of the member clusters of a MultiCluster resource, whose Pods can only be scheduled
+// on the nodes of the zone.
+type ZonePlacement struct {
+	// TopologyKey is the label of the nodes holding the name of their zone. Defaults to topology.kubernetes.io/zone.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// Zones lists the zones and the number of members deployed to each of them. The members of all the zones must
+	// add up to spec.members, and spec.memberConfig lists them zone by zone in this order.
+	// +kubebuilder:validation:MinItems=1
+	Zones []ZoneSpecItem `json:"zones"`
+}
+
+type ZoneSpecItem struct {
+	// Zone is the value of the topology key on the nodes of the zone.
+	// +kubebuilder:validation:MinLength=1
+	Zone string `json:"zone"`
+	// Members is the number of members deployed to the zone.
+	// +kubebuilder:validation:Minimum=0
+	Members int `json:"members"`
+	// NodeSelector restricts the members to the nodes of the zone with these labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+func (z *ZonePlacement) GetTopologyKey() string {
+	if z.TopologyKey == "" {
+		return corev1.LabelTopologyZone
+	}
+	return z.TopologyKey
+}
+
+// GetZone returns the zone with the given name. A zone removed from the spec has no members.
+func (z *ZonePlacement) GetZone(name string) ZoneSpecItem {
+	for _, zone := range z.Zones {
+		if zone.Zone == name {
+			return zone
+		}
+	}
+	return ZoneSpecItem{Zone: name}
+}
+
+// ClusterSpecList returns the zones as a cluster spec list, so that they are scaled one member at a time by the
+// scalers of the multi-cluster replica sets.
+func (z *ZonePlacement) ClusterSpecList() ClusterSpecList {
+	clusterSpecList := make(ClusterSpecList, 0, len(z.Zones))
+	for _, zone := range z.Zones {
+		clusterSpecList = append(clusterSpecList, ClusterSpecItem{ClusterName: zone.Zone, Members: zone.Members})
+	}
+	return clusterSpecList
+}
+
+// ZoneMembers returns the zones of spec.zonePlacement and the zones removed from it which are still in the status,
+// ordered by their index, with the number of members they have reached. The new zones are given the next free index.
+func (m *MongoDB) ZoneMembers() []multicluster.MemberCluster {
+	if m.Spec.ZonePlacement == nil {
+		return nil
+	}
+
+	existingMapping := map[string]int{}
+	replicas := map[string]int{}
+	for _, zone := range m.Status.Zones {
+		existingMapping[zone.Zone] = zone.Index
+		replicas[zone.Zone] = zone.Members
+	}
+	var zoneNames []string
+	for _, zone := range m.Spec.ZonePlacement.Zones {
+		zoneNames = append(zoneNames, zone.Zone)
+	}
+
+	var zones []multicluster.MemberCluster
+	for name, index := range multicluster.AssignIndexesForMemberClusterNames(existingMapping, zoneNames) {
+		zones = append(zones, multicluster.MemberCluster{Name: name, Index: index, Replicas: replicas[name], Active: true, Healthy: true})
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Index < zones[j].Index
+	})
+	return zones
+}
+
+// ZoneStatefulSetName returns the name of the StatefulSet of the zone with the given index.
+func (m *MongoDB) ZoneStatefulSetName(zoneNum int) string {
+	return dns.GetMultiStatefulSetName(m.Name, zoneNum)
+}
+
+// ZoneDNSNames returns the hostnames and the names of the Pods of the zone StatefulSets, with the number of replicas
+// of each zone, ordered like the zones.
+func (m *MongoDB) ZoneDNSNames(zones []multicluster.MemberCluster) (hostnames, names []string) {
+	for _, zone := range zones {
+		zoneHostnames, zoneNames := dns.GetDNSNames(m.ZoneStatefulSetName(zone.Index), m.ServiceName(), m.Namespace, m.Spec.GetClusterDomain(), zone.Replicas, nil)
+		hostnames = append(hostnames, zoneHostnames...)
+		names = append(names, zoneNames...)
+	}
+	return hostnames, names
+}
+
+// ZoneMemberOptions returns the options of the members of the zones, ordered like ZoneDNSNames. spec.memberConfig
+// lists the members zone by zone, in the order of spec.zonePlacement.zones, so that the options of a member don't
+// change when members are added to or removed from the zones before it. The members a zone is scaled down from, and
+// the members of the zones removed from the spec, have the default options.
+func (m *MongoDB) ZoneMemberOptions(zones []multicluster.MemberCluster) []automationconfig.MemberOptions {
+	memberConfig := m.Spec.GetMemberOptions()
+	offsets := map[string]int{}
+	offset := 0
+	for _, zone := range m.Spec.ZonePlacement.Zones {
+		offsets[zone.Zone] = offset
+		offset += zone.Members
+	}
+
+	var options []automationconfig.MemberOptions
+	for _, zone := range zones {
+		target := m.Spec.ZonePlacement.GetZone(zone.Name).Members
+		for ordinal := 0; ordinal < zone.Replicas; ordinal++ {
+			var memberOptions automationconfig.MemberOptions
+			if idx := offsets[zone.Name] + ordinal; ordinal < target && idx < len(memberConfig) {
+				memberOptions = memberConfig[idx]
+			}
+			options = append(options, memberOptions)
+		}
+	}
+	return options
+}
+
+// zoneTargetMembers returns the zones with the members they have in the spec.
+func (m *MongoDB) zoneTargetMembers() []multicluster.MemberCluster {
+	zones := m.ZoneMembers()
+	for i := range zones {
+		zones[i].Replicas = m.Spec.ZonePlacement.GetZone(zones[i].Name).Members
+	}
+	return zones
+}
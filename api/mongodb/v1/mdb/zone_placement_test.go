@@ -0,0 +1,98 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+)
+
+func TestZonePlacementValidation(t *testing.T) {
+	rs := NewReplicaSetBuilder().SetMembers(3).Build()
+	rs.Spec.ZonePlacement = &ZonePlacement{Zones: []ZoneSpecItem{{Zone: "zone-a", Members: 1}, {Zone: "zone-b", Members: 1}, {Zone: "zone-c", Members: 1}}}
+	assert.Equal(t, v1.SuccessLevel, zonePlacementValidation(rs.Spec).Level)
+
+	rs.Spec.ZonePlacement.Zones[2].Members = 0
+	res := zonePlacementValidation(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "the members of spec.zonePlacement.zones add up to 2, but spec.members is 3", res.Msg)
+
+	rs.Spec.ZonePlacement.Zones[0].Members = 2
+	res = zonePlacementValidation(rs.Spec)
+	assert.Equal(t, v1.WarningLevel, res.Level)
+	assert.Equal(t, "zone zone-a holds 2 of the 3 members, which is a majority: the replica set has no primary while the zone is unavailable", res.Msg)
+
+	rs.Spec.ZonePlacement.Zones[1].Zone = "zone-a"
+	res = zonePlacementValidation(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.zonePlacement.zones has more than one zone zone-a", res.Msg)
+
+	rs.Spec.ZonePlacement.Zones[1].Zone = "zone b"
+	assert.Equal(t, v1.ErrorLevel, zonePlacementValidation(rs.Spec).Level)
+
+	rs.Spec.ZonePlacement.Zones[1].Zone = "zone-b"
+	rs.Spec.TopologySpread = &v1.TopologySpread{Zone: &v1.TopologySpreadConstraint{}}
+	res = zonePlacementValidation(rs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.topologySpread.zone can't be used with spec.zonePlacement, which already pins the members to their zone", res.Msg)
+
+	sc := NewDefaultShardedClusterBuilder().Build()
+	sc.Spec.ZonePlacement = rs.Spec.ZonePlacement
+	res = zonePlacementValidation(sc.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.zonePlacement is only supported for single cluster replica sets", res.Msg)
+}
+
+func TestZonePlacementImmutable(t *testing.T) {
+	oldRs := NewReplicaSetBuilder().SetMembers(1).Build()
+	newRs := NewReplicaSetBuilder().SetMembers(1).Build()
+	newRs.Spec.ZonePlacement = &ZonePlacement{Zones: []ZoneSpecItem{{Zone: "zone-a", Members: 1}}}
+	assert.Equal(t, v1.ErrorLevel, zonePlacementImmutable(newRs.Spec, oldRs.Spec).Level)
+	assert.Equal(t, v1.ErrorLevel, zonePlacementImmutable(oldRs.Spec, newRs.Spec).Level)
+	assert.Equal(t, v1.SuccessLevel, zonePlacementImmutable(newRs.Spec, newRs.Spec).Level)
+}
+
+func TestZoneMembers_KeepIndexesOfRemovedZones(t *testing.T) {
+	rs := NewReplicaSetBuilder().SetMembers(3).Build()
+	rs.Spec.ZonePlacement = &ZonePlacement{Zones: []ZoneSpecItem{{Zone: "zone-c", Members: 2}, {Zone: "zone-d", Members: 1}}}
+	rs.Status.Zones = []status.ZoneStatusItem{{Zone: "zone-a", Index: 0, Members: 1}, {Zone: "zone-b", Index: 1, Members: 0}, {Zone: "zone-c", Index: 2, Members: 2}}
+
+	zones := rs.ZoneMembers()
+	var names []string
+	var indexes []int
+	var replicas []int
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+		indexes = append(indexes, zone.Index)
+		replicas = append(replicas, zone.Replicas)
+	}
+	assert.Equal(t, []string{"zone-a", "zone-b", "zone-c", "zone-d"}, names)
+	assert.Equal(t, []int{0, 1, 2, 3}, indexes)
+	assert.Equal(t, []int{1, 0, 2, 0}, replicas)
+
+	hostnames, _ := rs.ZoneDNSNames(rs.zoneTargetMembers())
+	assert.Equal(t, []string{
+		"test-mdb-2-0.test-mdb-svc.testNS.svc.cluster.local",
+		"test-mdb-2-1.test-mdb-svc.testNS.svc.cluster.local",
+		"test-mdb-3-0.test-mdb-svc.testNS.svc.cluster.local",
+	}, hostnames)
+}
+
+func TestZoneMemberOptions(t *testing.T) {
+	rs := NewReplicaSetBuilder().SetMembers(3).Build()
+	rs.Spec.ZonePlacement = &ZonePlacement{Zones: []ZoneSpecItem{{Zone: "zone-c", Members: 2}, {Zone: "zone-a", Members: 1}}}
+	rs.Spec.MemberConfig = []automationconfig.MemberOptions{{Priority: ptr.To("3")}, {Priority: ptr.To("2")}, {Priority: ptr.To("1")}}
+
+	// zone-a has its member, zone-b is removed from the spec and zone-c is being scaled up
+	zones := []multicluster.MemberCluster{{Name: "zone-a", Index: 0, Replicas: 1}, {Name: "zone-b", Index: 1, Replicas: 1}, {Name: "zone-c", Index: 2, Replicas: 1}}
+	assert.Equal(t, []automationconfig.MemberOptions{{Priority: ptr.To("1")}, {}, {Priority: ptr.To("3")}}, rs.ZoneMemberOptions(zones))
+
+	zones[1].Replicas = 0
+	zones[2].Replicas = 2
+	assert.Equal(t, []automationconfig.MemberOptions{{Priority: ptr.To("1")}, {Priority: ptr.To("3")}, {Priority: ptr.To("2")}}, rs.ZoneMemberOptions(zones))
+}
@@ -441,6 +441,26 @@ func (in *DbCommonSpec) DeepCopyInto(out *DbCommonSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(v1.FailoverConfig)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(v1.PodDisruptionBudgetConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpread != nil {
+		in, out := &in.TopologySpread, &out.TopologySpread
+		*out = new(v1.TopologySpread)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DbCommonSpec.
@@ -453,6 +473,31 @@ func (in *DbCommonSpec) DeepCopy() *DbCommonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectionPolicy) DeepCopyInto(out *ElectionPolicy) {
+	*out = *in
+	if in.PreferredClusters != nil {
+		in, out := &in.PreferredClusters, &out.PreferredClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxVotingMembersPerCluster != nil {
+		in, out := &in.MaxVotingMembersPerCluster, &out.MaxVotingMembersPerCluster
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectionPolicy.
+func (in *ElectionPolicy) DeepCopy() *ElectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Encryption) DeepCopyInto(out *Encryption) {
 	*out = *in
@@ -623,6 +668,21 @@ func (in *LogRotateForBackupAndMonitoring) DeepCopy() *LogRotateForBackupAndMoni
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDB) DeepCopyInto(out *MongoDB) {
 	*out = *in
@@ -876,6 +936,16 @@ func (in *MongoDbSpec) DeepCopyInto(out *MongoDbSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RestoreFromSnapshot != nil {
+		in, out := &in.RestoreFromSnapshot, &out.RestoreFromSnapshot
+		*out = new(v1.RestoreFromSnapshot)
+		**out = **in
+	}
+	if in.ZonePlacement != nil {
+		in, out := &in.ZonePlacement, &out.ZonePlacement
+		*out = new(ZonePlacement)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDbSpec.
@@ -908,6 +978,16 @@ func (in *MongoDbStatus) DeepCopyInto(out *MongoDbStatus) {
 		*out = make([]status.Warning, len(*in))
 		copy(*out, *in)
 	}
+	if in.StorageExpansions != nil {
+		in, out := &in.StorageExpansions, &out.StorageExpansions
+		*out = make(status.StorageExpansions, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]status.ZoneStatusItem, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDbStatus.
@@ -1502,6 +1582,16 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(v1.CertManagerConfig)
+		**out = **in
+	}
+	if in.VaultPKI != nil {
+		in, out := &in.VaultPKI, &out.VaultPKI
+		*out = new(v1.VaultPKIConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
@@ -1513,3 +1603,47 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZonePlacement) DeepCopyInto(out *ZonePlacement) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneSpecItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZonePlacement.
+func (in *ZonePlacement) DeepCopy() *ZonePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ZonePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSpecItem) DeepCopyInto(out *ZoneSpecItem) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSpecItem.
+func (in *ZoneSpecItem) DeepCopy() *ZoneSpecItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSpecItem)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -227,6 +227,8 @@ type MongoDBMultiStatus struct {
 	Link                        string              `json:"link,omitempty"`
 	FeatureCompatibilityVersion string              `json:"featureCompatibilityVersion,omitempty"`
 	Warnings                    []status.Warning    `json:"warnings,omitempty"`
+	// StorageExpansions records the last expansion of each volume by storage autoscaling.
+	StorageExpansions status.StorageExpansions `json:"storageExpansions,omitempty"`
 }
 
 type MongoDBMultiSpec struct {
@@ -235,6 +237,11 @@ type MongoDBMultiSpec struct {
 
 	ClusterSpecList mdbv1.ClusterSpecList `json:"clusterSpecList,omitempty"`
 
+	// ElectionPolicy computes the votes and priorities of the members from their member clusters. The memberConfig of
+	// the member clusters can only set tags when it is specified.
+	// +optional
+	ElectionPolicy *mdbv1.ElectionPolicy `json:"electionPolicy,omitempty"`
+
 	// Mapping stores the deterministic index for a given cluster-name.
 	Mapping map[string]int `json:"-"`
 }
@@ -243,6 +250,16 @@ func (m *MongoDBMultiSpec) GetAgentConfig() mdbv1.AgentConfig {
 	return m.Agent
 }
 
+// HasStorageAutoscale returns true if the persistent volumes of any of the member clusters are autoscaled.
+func (m *MongoDBMultiSpec) HasStorageAutoscale() bool {
+	for _, item := range m.ClusterSpecList {
+		if persistence := item.PodSpec.GetPersistence(); persistence != nil && persistence.Autoscale != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *MongoDBMultiCluster) GetStatus(...status.Option) interface{} {
 	return m.Status
 }
@@ -259,6 +276,10 @@ func (m *MongoDBMultiCluster) SetWarnings(warnings []status.Warning, _ ...status
 	m.Status.Warnings = warnings
 }
 
+func (m *MongoDBMultiCluster) GetStatusWarnings() []status.Warning {
+	return m.Status.Warnings
+}
+
 func (m *MongoDBMultiCluster) UpdateStatus(phase status.Phase, statusOptions ...status.Option) {
 	m.Status.UpdateCommonFields(phase, m.GetGeneration(), statusOptions...)
 
@@ -269,6 +290,12 @@ func (m *MongoDBMultiCluster) UpdateStatus(phase status.Phase, statusOptions ...
 		m.Status.BackupStatus.StatusName = option.(status.BackupStatusOption).Value().(string)
 	}
 
+	if option, exists := status.GetOption(statusOptions, status.StorageExpansionsOption{}); exists {
+		for _, expansion := range option.(status.StorageExpansionsOption).Expansions {
+			m.Status.StorageExpansions = m.Status.StorageExpansions.Merge(expansion)
+		}
+	}
+
 	if phase == status.PhaseRunning {
 		m.Status.FeatureCompatibilityVersion = m.CalculateFeatureCompatibilityVersion()
 	}
@@ -531,7 +558,7 @@ func (m *MongoDBMultiSpec) GetHorizonConfig() []mdbv1.MongoDBHorizonConfig {
 }
 
 func (m *MongoDBMultiSpec) GetMemberOptions() []automationconfig.MemberOptions {
-	specList := m.GetClusterSpecList()
+	specList := m.ElectionPolicy.Apply(m.GetClusterSpecList())
 	var options []automationconfig.MemberOptions
 	for _, item := range specList {
 		options = append(options, item.MemberConfig...)
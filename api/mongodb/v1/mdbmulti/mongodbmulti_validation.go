@@ -3,6 +3,8 @@ package mdbmulti
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -44,6 +46,9 @@ func (m *MongoDBMultiCluster) ProcessValidationsOnReconcile(old *MongoDBMultiClu
 func (m *MongoDBMultiCluster) RunValidations(old *MongoDBMultiCluster) []v1.ValidationResult {
 	multiClusterValidators := []func(ms MongoDBMultiSpec) v1.ValidationResult{
 		validateUniqueExternalDomains,
+		validateFailoverPolicy,
+		validateElectionPolicy,
+		validateStorageAutoscale,
 	}
 
 	// shared validators between MongoDBMulti and AppDB
@@ -79,6 +84,56 @@ func (m *MongoDBMultiCluster) RunValidations(old *MongoDBMultiCluster) []v1.Vali
 	return validationResults
 }
 
+// validateStorageAutoscale checks the storage autoscaling of the StatefulSet of each member cluster.
+func validateStorageAutoscale(ms MongoDBMultiSpec) v1.ValidationResult {
+	for i, item := range ms.ClusterSpecList {
+		if res := mdbv1.ValidateStorageAutoscale(fmt.Sprintf("spec.clusterSpecList[%d].podSpec", i), ms.Persistent, item.PodSpec.GetPersistence()); res.Level == v1.ErrorLevel {
+			return res
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
+// validateFailoverPolicy rejects the ReduceVotes policy, the members of a MongoDBMultiCluster are either kept in the
+// failed member cluster or redistributed.
+func validateFailoverPolicy(ms MongoDBMultiSpec) v1.ValidationResult {
+	if ms.Failover.GetPolicy() == v1.FailoverPolicyReduceVotes {
+		return v1.ValidationError("spec.failover.policy %s is not supported for MongoDBMultiCluster", v1.FailoverPolicyReduceVotes)
+	}
+	return v1.ValidationSuccess()
+}
+
+// validateElectionPolicy makes sure the election policy only refers to the member clusters of the resource and is not
+// contradicted by the memberConfig. It warns if the replica set loses the majority of its voting members when one
+// member cluster is lost.
+func validateElectionPolicy(ms MongoDBMultiSpec) v1.ValidationResult {
+	if ms.ElectionPolicy == nil {
+		return v1.ValidationSuccess()
+	}
+
+	for i, clusterName := range ms.ElectionPolicy.PreferredClusters {
+		if !slices.ContainsFunc(ms.ClusterSpecList, func(item mdbv1.ClusterSpecItem) bool { return item.ClusterName == clusterName }) {
+			return v1.ValidationError("spec.electionPolicy.preferredClusters contains %s which is not in spec.clusterSpecList", clusterName)
+		}
+		if slices.Contains(ms.ElectionPolicy.PreferredClusters[:i], clusterName) {
+			return v1.ValidationError("spec.electionPolicy.preferredClusters contains %s more than once", clusterName)
+		}
+	}
+
+	for _, item := range ms.ClusterSpecList {
+		for _, memberOptions := range item.MemberConfig {
+			if memberOptions.Votes != nil || memberOptions.Priority != nil {
+				return v1.ValidationError("the votes and priority of the members of %s can't be set in memberConfig when spec.electionPolicy is specified", item.ClusterName)
+			}
+		}
+	}
+
+	if clusterNames := mdbv1.ClustersBreakingMajority(ms.ElectionPolicy.Apply(ms.ClusterSpecList)); len(clusterNames) > 0 {
+		return v1.ValidationWarning("The replica set loses the majority of its voting members if one of the member clusters %v is lost", clusterNames)
+	}
+	return v1.ValidationSuccess()
+}
+
 // validateUniqueExternalDomains validates uniqueness of the domains if they are provided.
 // External domain might be specified at the top level in spec.externalAccess.externalDomain or in every member cluster.
 // We make sure that if external domains are used, every member cluster has unique external domain defined.
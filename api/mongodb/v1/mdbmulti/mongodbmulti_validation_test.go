@@ -9,7 +9,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/utils/ptr"
 
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 )
 
@@ -156,3 +159,79 @@ users:
 
 	return file
 }
+
+func TestFailoverPolicyReduceVotesIsNotSupported(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.Failover = &v1.FailoverConfig{Policy: v1.FailoverPolicyReduceVotes}
+	res := validateFailoverPolicy(mrs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.failover.policy ReduceVotes is not supported for MongoDBMultiCluster", res.Msg)
+
+	mrs.Spec.Failover.Policy = v1.FailoverPolicyRedistributeMembers
+	assert.Equal(t, v1.SuccessLevel, validateFailoverPolicy(mrs.Spec).Level)
+}
+
+func TestStorageAutoscaleValidation(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.Persistent = ptr.To(true)
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "a", Members: 3},
+		{ClusterName: "b", Members: 2, PodSpec: &mdbv1.MongoDbPodSpec{Persistence: &v1.Persistence{Autoscale: &v1.StorageAutoscale{MaxStorage: "100Gi"}}}},
+	}
+	assert.Equal(t, v1.SuccessLevel, validateStorageAutoscale(mrs.Spec).Level)
+
+	mrs.Spec.ClusterSpecList[1].PodSpec.Persistence.Autoscale.MaxStorage = "lots"
+	res := validateStorageAutoscale(mrs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Contains(t, res.Msg, "spec.clusterSpecList[1].podSpec.persistence.autoscale.maxStorage is invalid")
+
+	mrs.Spec.ClusterSpecList[1].PodSpec.Persistence.Autoscale.MaxStorage = "100Gi"
+	mrs.Spec.Persistent = ptr.To(false)
+	res = validateStorageAutoscale(mrs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.clusterSpecList[1].podSpec.persistence.autoscale requires spec.persistent to be true", res.Msg)
+}
+
+func TestElectionPolicyValidation(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "primary", Members: 3},
+		{ClusterName: "secondary", Members: 2},
+		{ClusterName: "dr", Members: 2},
+	}
+	mrs.Spec.ElectionPolicy = &mdbv1.ElectionPolicy{PreferredClusters: []string{"primary", "secondary"}}
+	assert.Equal(t, v1.SuccessLevel, validateElectionPolicy(mrs.Spec).Level)
+
+	mrs.Spec.ElectionPolicy.PreferredClusters = []string{"primary", "unknown"}
+	res := validateElectionPolicy(mrs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.electionPolicy.preferredClusters contains unknown which is not in spec.clusterSpecList", res.Msg)
+
+	mrs.Spec.ElectionPolicy.PreferredClusters = []string{"primary", "primary"}
+	assert.Equal(t, v1.ErrorLevel, validateElectionPolicy(mrs.Spec).Level)
+
+	mrs.Spec.ElectionPolicy.PreferredClusters = []string{"primary"}
+	mrs.Spec.ClusterSpecList[2].MemberConfig = []automationconfig.MemberOptions{{Votes: ptr.To(0)}}
+	res = validateElectionPolicy(mrs.Spec)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "the votes and priority of the members of dr can't be set in memberConfig when spec.electionPolicy is specified", res.Msg)
+
+	mrs.Spec.ClusterSpecList[2].MemberConfig = []automationconfig.MemberOptions{{Tags: map[string]string{"region": "dr"}}}
+	assert.Equal(t, v1.SuccessLevel, validateElectionPolicy(mrs.Spec).Level)
+}
+
+func TestElectionPolicyWarnsWhenMajorityDoesNotSurviveClusterLoss(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "primary", Members: 3},
+		{ClusterName: "dr", Members: 2},
+	}
+	mrs.Spec.ElectionPolicy = &mdbv1.ElectionPolicy{PreferredClusters: []string{"primary"}}
+
+	res := validateElectionPolicy(mrs.Spec)
+	assert.Equal(t, v1.WarningLevel, res.Level)
+	assert.Equal(t, "The replica set loses the majority of its voting members if one of the member clusters [primary] is lost", res.Msg)
+
+	assert.NoError(t, mrs.ProcessValidationsOnReconcile(nil))
+	assert.Contains(t, mrs.Status.Warnings, status.Warning(res.Msg))
+}
@@ -154,6 +154,11 @@ func (in *MongoDBMultiSpec) DeepCopyInto(out *MongoDBMultiSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ElectionPolicy != nil {
+		in, out := &in.ElectionPolicy, &out.ElectionPolicy
+		*out = new(mdb.ElectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Mapping != nil {
 		in, out := &in.Mapping, &out.Mapping
 		*out = make(map[string]int, len(*in))
@@ -188,6 +193,11 @@ func (in *MongoDBMultiStatus) DeepCopyInto(out *MongoDBMultiStatus) {
 		*out = make([]status.Warning, len(*in))
 		copy(*out, *in)
 	}
+	if in.StorageExpansions != nil {
+		in, out := &in.StorageExpansions, &out.StorageExpansions
+		*out = make(status.StorageExpansions, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBMultiStatus.
@@ -100,6 +100,25 @@ type AppDBSpec struct {
 	Topology string `json:"topology,omitempty"`
 	// +optional
 	ClusterSpecList mdbv1.ClusterSpecList `json:"clusterSpecList,omitempty"`
+
+	// MaintenanceWindow restricts changes requiring a restart of the AppDB processes to a weekly time window.
+	// Changes that don't require a restart are applied immediately.
+	// +optional
+	MaintenanceWindow *mdbv1.MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget created for the AppDB StatefulSet in each member cluster.
+	// By default at most one AppDB Pod in a member cluster can be evicted at a time.
+	// +optional
+	PodDisruptionBudget *v1.PodDisruptionBudgetConfiguration `json:"podDisruptionBudget,omitempty"`
+
+	// TopologySpread spreads the AppDB Pods of each member cluster across zones and nodes.
+	// +optional
+	TopologySpread *v1.TopologySpread `json:"topologySpread,omitempty"`
+
+	// RestoreFromSnapshot creates the volumes of the AppDB from a MongoDBVolumeSnapshot of the AppDB of a
+	// MongoDBOpsManager with the same name.
+	// +optional
+	RestoreFromSnapshot *v1.RestoreFromSnapshot `json:"restoreFromSnapshot,omitempty"`
 }
 
 func (m *AppDBSpec) GetAgentConfig() mdbv1.AgentConfig {
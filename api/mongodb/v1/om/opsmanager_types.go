@@ -10,9 +10,11 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -157,10 +159,91 @@ type MongoDBOpsManagerSpec struct {
 	// +optional
 	ClusterSpecList []ClusterSpecOMItem `json:"clusterSpecList,omitempty"`
 
+	// Failover configures what the Operator does when a member cluster of Ops Manager or of the Application Database
+	// fails its health check. The ReduceVotes policy applies to the Application Database members only, the
+	// RedistributeMembers policy isn't supported.
+	// +optional
+	Failover *v1.FailoverConfig `json:"failover,omitempty"`
+
 	// OpsManagerURL specified the URL with which the operator and AppDB monitoring agent should access Ops Manager instance (or instances).
 	// When not set, the operator is using FQDN of Ops Manager's headless service `{name}-svc.{namespace}.svc.cluster.local` to connect to the instance. If that URL cannot be used, then URL in this field should be provided for the operator to connect to Ops Manager instances.
 	// +optional
 	OpsManagerURL string `json:"opsManagerURL,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget created for the Ops Manager StatefulSet in each member cluster.
+	// By default at most one Ops Manager Pod in a member cluster can be evicted at a time.
+	// +optional
+	PodDisruptionBudget *v1.PodDisruptionBudgetConfiguration `json:"podDisruptionBudget,omitempty"`
+
+	// Autoscaling configures a HorizontalPodAutoscaler for the Ops Manager StatefulSet in each member cluster.
+	// While it is set the Operator doesn't change the number of Ops Manager replicas.
+	// +optional
+	Autoscaling *OpsManagerAutoscaling `json:"autoscaling,omitempty"`
+
+	// Upgrade configures the order in which the components are upgraded when spec.version changes.
+	// +optional
+	Upgrade *OpsManagerUpgrade `json:"upgrade,omitempty"`
+}
+
+// OpsManagerUpgrade configures the upgrades of Ops Manager.
+type OpsManagerUpgrade struct {
+	// AppDBFirst upgrades the Application Database before Ops Manager and the Backup Daemons when
+	// spec.applicationDatabase.version is changed together with spec.version. By default, the Application Database
+	// keeps its version until Ops Manager and the Backup Daemons have been upgraded.
+	// +optional
+	AppDBFirst bool `json:"appDBFirst,omitempty"`
+}
+
+// OpsManagerAutoscaling is the configuration of the HorizontalPodAutoscaler for the Ops Manager Pods.
+type OpsManagerAutoscaling struct {
+	// MinReplicas is the lower limit for the number of Ops Manager Pods in a member cluster.
+	// Defaults to spec.replicas (or clusterSpecList[*].members in MultiCluster topology).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of Ops Manager Pods in a member cluster.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Metrics are the metrics used to compute the desired number of Pods.
+	// Defaults to 80% average CPU utilization.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+	// Behavior configures the scaling behavior in both Up and Down directions.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// defaultAutoscalingCPUUtilization is the average CPU utilization targeted by the HorizontalPodAutoscaler
+// if no metrics are specified.
+const defaultAutoscalingCPUUtilization = 80
+
+// MinReplicasOrDefault returns the lower limit for the number of Ops Manager Pods, falling back to the
+// number of Pods specified for the member cluster.
+func (a *OpsManagerAutoscaling) MinReplicasOrDefault(members int) int32 {
+	if a.MinReplicas != nil {
+		return *a.MinReplicas
+	}
+	//nolint:gosec // the number of members is validated by the CRD
+	return int32(members)
+}
+
+// MetricsOrDefault returns the metrics used to compute the desired number of Pods.
+func (a *OpsManagerAutoscaling) MetricsOrDefault() []autoscalingv2.MetricSpec {
+	if len(a.Metrics) > 0 {
+		return a.Metrics
+	}
+	return []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To[int32](defaultAutoscalingCPUUtilization),
+				},
+			},
+		},
+	}
 }
 
 type Logging struct {
@@ -250,6 +333,10 @@ type MongoDBOpsManagerTLS struct {
 	SecretRef TLSSecretRef `json:"secretRef"`
 	// +optional
 	CA string `json:"ca"`
+	// CertManager makes the Operator request the Ops Manager certificate from cert-manager. The certificate is stored
+	// in the Secret referenced by `secretRef` or derived from `certsSecretPrefix`.
+	// +optional
+	CertManager *v1.CertManagerConfig `json:"certManager,omitempty"`
 }
 
 type TLSSecretRef struct {
@@ -264,6 +351,11 @@ func (ms MongoDBOpsManagerSpec) IsKmipEnabled() bool {
 	return true
 }
 
+// IsAppDBUpgradedFirst returns true if the Application Database is upgraded before Ops Manager.
+func (ms MongoDBOpsManagerSpec) IsAppDBUpgradedFirst() bool {
+	return ms.Upgrade != nil && ms.Upgrade.AppDBFirst
+}
+
 func (ms MongoDBOpsManagerSpec) GetClusterDomain() string {
 	if ms.ClusterDomain != "" {
 		return ms.ClusterDomain
@@ -421,6 +513,14 @@ type MongoDBOpsManagerBackup struct {
 	FileSystemStoreConfigs   []FileSystemStoreConfig      `json:"fileSystemStores,omitempty"`
 	StatefulSetConfiguration *v1.StatefulSetConfiguration `json:"statefulSet,omitempty"`
 
+	// GCSConfigs describes the list of Google Cloud Storage snapshot store configs used for backup.
+	// +optional
+	GCSConfigs []GCSConfig `json:"gcsStores,omitempty"`
+
+	// AzureBlobConfigs describes the list of Azure Blob Storage snapshot store configs used for backup.
+	// +optional
+	AzureBlobConfigs []AzureBlobConfig `json:"azureBlobStores,omitempty"`
+
 	// QueryableBackupSecretRef references the secret which contains the pem file which is used
 	// for queryable backup. This will be mounted into the Ops Manager pod.
 	// +optional
@@ -431,6 +531,11 @@ type MongoDBOpsManagerBackup struct {
 	Encryption *Encryption `json:"encryption,omitempty"`
 
 	Logging *Logging `json:"logging,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget created for the Backup Daemon StatefulSet in each member cluster.
+	// By default at most one Backup Daemon Pod in a member cluster can be evicted at a time.
+	// +optional
+	PodDisruptionBudget *v1.PodDisruptionBudgetConfiguration `json:"podDisruptionBudget,omitempty"`
 }
 
 // MongoDBOpsManagerBackupClusterSpecItem backup structure for overriding top-level backup definition in Ops Manager's clusterSpecList.
@@ -479,6 +584,62 @@ type OpsManagerStatus struct {
 	Url               string                       `json:"url,omitempty"`
 	Warnings          []status.Warning             `json:"warnings,omitempty"`
 	ClusterStatusList []status.OMClusterStatusItem `json:"clusterStatusList,omitempty"`
+	// Upgrade records the progress of the latest Ops Manager version upgrade
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+}
+
+type UpgradeStepName string
+
+const (
+	UpgradeStepPreflightChecks UpgradeStepName = "PreflightChecks"
+	UpgradeStepAppDB           UpgradeStepName = "AppDB"
+	UpgradeStepOpsManager      UpgradeStepName = "OpsManager"
+	UpgradeStepBackupDaemon    UpgradeStepName = "BackupDaemon"
+)
+
+type UpgradeStepState string
+
+const (
+	UpgradeStepPending    UpgradeStepState = "Pending"
+	UpgradeStepInProgress UpgradeStepState = "InProgress"
+	UpgradeStepCompleted  UpgradeStepState = "Completed"
+	UpgradeStepFailed     UpgradeStepState = "Failed"
+)
+
+// UpgradeStatus describes the upgrade of Ops Manager from one version to another. The steps are performed in order
+// and the upgrade stops at the first failed step.
+type UpgradeStatus struct {
+	FromVersion string        `json:"fromVersion"`
+	ToVersion   string        `json:"toVersion"`
+	Steps       []UpgradeStep `json:"steps,omitempty"`
+}
+
+type UpgradeStep struct {
+	Name    UpgradeStepName  `json:"name"`
+	State   UpgradeStepState `json:"state"`
+	Message string           `json:"message,omitempty"`
+	// LastTransitionTime is the time (RFC3339) of the last change of the step state
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// IsFinished returns true if all the steps of the upgrade have been completed.
+func (u *UpgradeStatus) IsFinished() bool {
+	for _, step := range u.Steps {
+		if step.State != UpgradeStepCompleted {
+			return false
+		}
+	}
+	return true
+}
+
+// Step returns the step with the given name or nil if the upgrade doesn't include it.
+func (u *UpgradeStatus) Step(name UpgradeStepName) *UpgradeStep {
+	for i := range u.Steps {
+		if u.Steps[i].Name == name {
+			return &u.Steps[i]
+		}
+	}
+	return nil
 }
 
 type AgentVersion struct {
@@ -591,6 +752,103 @@ func (s S3Config) MongodbUserObjectKey(defaultNamespace string) client.ObjectKey
 	return client.ObjectKey{Name: s.MongoDBUserRef.Name, Namespace: ns}
 }
 
+// GCSConfig is the configuration of a Google Cloud Storage snapshot store.
+type GCSConfig struct {
+	MongoDBResourceRef *userv1.MongoDBResourceRef `json:"mongodbResourceRef,omitempty"`
+	MongoDBUserRef     *MongoDBUserRef            `json:"mongodbUserRef,omitempty"`
+	// GCSSecretRef is the secret that contains the service account key (JSON) used to access the bucket.
+	// It is optional because the credentials can be provided via GKE Workload Identity
+	// +optional
+	GCSSecretRef *SecretRef `json:"gcsSecretRef,omitempty"`
+	Name         string     `json:"name"`
+	BucketName   string     `json:"bucketName"`
+	// Endpoint overrides the default Google Cloud Storage endpoint, e.g. to use a local emulator
+	// such as fake-gcs-server.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// This is only set to "true" when a user is running in GKE and is using Workload Identity to access
+	// the bucket. For more details refer this: https://cloud.google.com/kubernetes-engine/docs/how-to/workload-identity
+	// +optional
+	WorkloadIdentityEnabled bool `json:"workloadIdentityEnabled"`
+	// Assignment Labels set in the Ops Manager
+	// +optional
+	AssignmentLabels []string `json:"assignmentLabels,omitempty"`
+}
+
+func (g GCSConfig) Identifier() interface{} {
+	return g.Name
+}
+
+// MongodbResourceObjectKey returns the object key of the metadata database. It is empty if the mongodb resource is
+// not specified (the AppDB is used then).
+func (g GCSConfig) MongodbResourceObjectKey(opsManager *MongoDBOpsManager) client.ObjectKey {
+	return blobStoreMongodbResourceObjectKey(g.MongoDBResourceRef, opsManager.Namespace)
+}
+
+func (g GCSConfig) MongodbUserObjectKey(defaultNamespace string) client.ObjectKey {
+	return blobStoreMongodbUserObjectKey(g.MongoDBResourceRef, g.MongoDBUserRef, defaultNamespace)
+}
+
+// AzureBlobConfig is the configuration of an Azure Blob Storage snapshot store.
+type AzureBlobConfig struct {
+	MongoDBResourceRef *userv1.MongoDBResourceRef `json:"mongodbResourceRef,omitempty"`
+	MongoDBUserRef     *MongoDBUserRef            `json:"mongodbUserRef,omitempty"`
+	// AzureSecretRef is the secret that contains the storage account key used to access the container.
+	// It is optional because the credentials can be provided via AKS Workload Identity
+	// +optional
+	AzureSecretRef     *SecretRef `json:"azureSecretRef,omitempty"`
+	Name               string     `json:"name"`
+	StorageAccountName string     `json:"storageAccountName"`
+	ContainerName      string     `json:"containerName"`
+	// Endpoint overrides the default blob service endpoint (https://<storageAccountName>.blob.core.windows.net),
+	// e.g. to use a local emulator such as Azurite.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// This is only set to "true" when a user is running in AKS and is using Workload Identity to access
+	// the container. For more details refer this: https://learn.microsoft.com/en-us/azure/aks/workload-identity-overview
+	// +optional
+	WorkloadIdentityEnabled bool `json:"workloadIdentityEnabled"`
+	// Assignment Labels set in the Ops Manager
+	// +optional
+	AssignmentLabels []string `json:"assignmentLabels,omitempty"`
+}
+
+func (a AzureBlobConfig) Identifier() interface{} {
+	return a.Name
+}
+
+// MongodbResourceObjectKey returns the object key of the metadata database. It is empty if the mongodb resource is
+// not specified (the AppDB is used then).
+func (a AzureBlobConfig) MongodbResourceObjectKey(opsManager *MongoDBOpsManager) client.ObjectKey {
+	return blobStoreMongodbResourceObjectKey(a.MongoDBResourceRef, opsManager.Namespace)
+}
+
+func (a AzureBlobConfig) MongodbUserObjectKey(defaultNamespace string) client.ObjectKey {
+	return blobStoreMongodbUserObjectKey(a.MongoDBResourceRef, a.MongoDBUserRef, defaultNamespace)
+}
+
+func blobStoreMongodbResourceObjectKey(resourceRef *userv1.MongoDBResourceRef, defaultNamespace string) client.ObjectKey {
+	if resourceRef == nil {
+		return client.ObjectKey{}
+	}
+	ns := defaultNamespace
+	if resourceRef.Namespace != "" {
+		ns = resourceRef.Namespace
+	}
+	return client.ObjectKey{Name: resourceRef.Name, Namespace: ns}
+}
+
+func blobStoreMongodbUserObjectKey(resourceRef *userv1.MongoDBResourceRef, userRef *MongoDBUserRef, defaultNamespace string) client.ObjectKey {
+	if resourceRef == nil || userRef == nil {
+		return client.ObjectKey{}
+	}
+	ns := defaultNamespace
+	if resourceRef.Namespace != "" {
+		ns = resourceRef.Namespace
+	}
+	return client.ObjectKey{Name: userRef.Name, Namespace: ns}
+}
+
 // MongodbResourceObjectKey returns the object key for the mongodb resource referenced by the dataStoreConfig.
 // It uses the "parent" object namespace if it is not overriden by 'MongoDBResourceRef.namespace'
 func (f *DataStoreConfig) MongodbResourceObjectKey(defaultNamespace string) client.ObjectKey {
@@ -1015,6 +1273,16 @@ func (om *MongoDBOpsManager) GetSecretsMountedIntoPod() []string {
 				secretNames = append(secretNames, config.S3SecretRef.Name)
 			}
 		}
+		for _, config := range om.Spec.Backup.GCSConfigs {
+			if config.GCSSecretRef != nil && config.GCSSecretRef.Name != "" {
+				secretNames = append(secretNames, config.GCSSecretRef.Name)
+			}
+		}
+		for _, config := range om.Spec.Backup.AzureBlobConfigs {
+			if config.AzureSecretRef != nil && config.AzureSecretRef.Name != "" {
+				secretNames = append(secretNames, config.AzureSecretRef.Name)
+			}
+		}
 	}
 
 	return secretNames
@@ -93,9 +93,9 @@ func cloudManagerConfigIsNotConfigurable(os MongoDBOpsManagerSpec) v1.Validation
 	return v1.ValidationSuccess()
 }
 
-// onlyFileSystemStoreIsEnabled checks if only FileSystemSnapshotStore is configured and not S3Store/Blockstore
+// onlyFileSystemStoreIsEnabled checks if only FileSystemSnapshotStore is configured and not S3Store/Blockstore/GCS/Azure Blob store
 func onlyFileSystemStoreIsEnabled(bp MongoDBOpsManagerBackup) bool {
-	if len(bp.BlockStoreConfigs) == 0 && len(bp.S3Configs) == 0 && len(bp.FileSystemStoreConfigs) > 0 {
+	if len(bp.BlockStoreConfigs) == 0 && len(bp.S3Configs) == 0 && len(bp.GCSConfigs) == 0 && len(bp.AzureBlobConfigs) == 0 && len(bp.FileSystemStoreConfigs) > 0 {
 		return true
 	}
 	return false
@@ -263,6 +263,140 @@ func validateBackupS3Stores(os MongoDBOpsManagerSpec) v1.ValidationResult {
 	return v1.ValidationSuccess()
 }
 
+// validateBackupBlobStores validates the GCS and Azure Blob snapshot stores. Same as for S3 stores, the credentials
+// Secret must be specified unless Workload Identity is used.
+func validateBackupBlobStores(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	backup := os.Backup
+	if backup == nil || !backup.Enabled {
+		return v1.ValidationSuccess()
+	}
+
+	for _, config := range backup.GCSConfigs {
+		if config.MongoDBUserRef != nil && config.MongoDBResourceRef == nil {
+			return v1.OpsManagerResourceValidationError("'mongodbResourceRef' must be specified if 'mongodbUserRef' is configured (GCS Store: %s)", status.OpsManager, config.Name)
+		}
+		if config.WorkloadIdentityEnabled {
+			if config.GCSSecretRef != nil {
+				return v1.OpsManagerResourceValidationWarning("'gcsSecretRef' must not be specified if using Workload Identity (GCS Store: %s)", status.OpsManager, config.Name)
+			}
+		} else if config.GCSSecretRef == nil || config.GCSSecretRef.Name == "" {
+			return v1.OpsManagerResourceValidationError("'gcsSecretRef' must be specified if not using Workload Identity (GCS Store: %s)", status.OpsManager, config.Name)
+		}
+	}
+
+	for _, config := range backup.AzureBlobConfigs {
+		if config.MongoDBUserRef != nil && config.MongoDBResourceRef == nil {
+			return v1.OpsManagerResourceValidationError("'mongodbResourceRef' must be specified if 'mongodbUserRef' is configured (Azure Blob Store: %s)", status.OpsManager, config.Name)
+		}
+		if config.WorkloadIdentityEnabled {
+			if config.AzureSecretRef != nil {
+				return v1.OpsManagerResourceValidationWarning("'azureSecretRef' must not be specified if using Workload Identity (Azure Blob Store: %s)", status.OpsManager, config.Name)
+			}
+		} else if config.AzureSecretRef == nil || config.AzureSecretRef.Name == "" {
+			return v1.OpsManagerResourceValidationError("'azureSecretRef' must be specified if not using Workload Identity (Azure Blob Store: %s)", status.OpsManager, config.Name)
+		}
+	}
+
+	return v1.ValidationSuccess()
+}
+
+func validatePodDisruptionBudgets(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	if os.PodDisruptionBudget != nil && os.PodDisruptionBudget.MinAvailable != nil && os.PodDisruptionBudget.MaxUnavailable != nil {
+		return v1.OpsManagerResourceValidationError("only one of 'minAvailable' and 'maxUnavailable' can be specified in spec.podDisruptionBudget", status.OpsManager)
+	}
+	if os.Backup != nil && os.Backup.PodDisruptionBudget != nil && os.Backup.PodDisruptionBudget.MinAvailable != nil && os.Backup.PodDisruptionBudget.MaxUnavailable != nil {
+		return v1.OpsManagerResourceValidationError("only one of 'minAvailable' and 'maxUnavailable' can be specified in spec.backup.podDisruptionBudget", status.Backup)
+	}
+	if os.AppDB.PodDisruptionBudget != nil && os.AppDB.PodDisruptionBudget.MinAvailable != nil && os.AppDB.PodDisruptionBudget.MaxUnavailable != nil {
+		return v1.OpsManagerResourceValidationError("only one of 'minAvailable' and 'maxUnavailable' can be specified in spec.applicationDatabase.podDisruptionBudget", status.AppDb)
+	}
+	return v1.ValidationSuccess()
+}
+
+// validateAppDBTopologySpread checks that the zone constraint of spec.applicationDatabase.topologySpread can't put a
+// majority of the AppDB members in a single zone. The AppDB of a MultiCluster Ops Manager is spread in each member
+// cluster on its own, so only the members of a single cluster AppDB are checked.
+func validateAppDBTopologySpread(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	spread := os.AppDB.TopologySpread
+	if err := spread.Validate("spec.applicationDatabase.topologySpread"); err != nil {
+		return v1.OpsManagerResourceValidationError("%s", status.AppDb, err)
+	}
+	if !os.AppDB.IsMultiCluster() {
+		if err := spread.ValidateMembers("spec.applicationDatabase.topologySpread", os.AppDB.Members); err != nil {
+			return v1.OpsManagerResourceValidationError("%s", status.AppDb, err)
+		}
+	}
+	if spread.IsBestEffort() {
+		return v1.OpsManagerResourceValidationWarning("spec.applicationDatabase.topologySpread uses whenUnsatisfiable ScheduleAnyway, which lets a single zone or node hold a majority of the AppDB members if the Pods can't be spread", status.AppDb)
+	}
+	return v1.ValidationSuccess()
+}
+
+// validateAppDBRestoreFromSnapshot checks that the AppDB can be restored from a snapshot, which is only taken of a single
+// cluster AppDB.
+func validateAppDBRestoreFromSnapshot(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	if os.AppDB.RestoreFromSnapshot == nil {
+		return v1.ValidationSuccess()
+	}
+	if os.AppDB.IsMultiCluster() {
+		return v1.OpsManagerResourceValidationError("spec.applicationDatabase.restoreFromSnapshot is not supported for the MultiCluster topology", status.AppDb)
+	}
+	return v1.ValidationSuccess()
+}
+
+// validateAppDBStorageAutoscale rejects storage autoscaling, the volumes of the AppDB are not autoscaled.
+func validateAppDBStorageAutoscale(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	if persistence := os.AppDB.PodSpec.GetPersistence(); persistence != nil && persistence.Autoscale != nil {
+		return v1.OpsManagerResourceValidationError("spec.applicationDatabase.podSpec.persistence.autoscale is not supported for the Application Database", status.AppDb)
+	}
+	for i, item := range os.AppDB.ClusterSpecList {
+		if persistence := item.PodSpec.GetPersistence(); persistence != nil && persistence.Autoscale != nil {
+			return v1.OpsManagerResourceValidationError("spec.applicationDatabase.clusterSpecList[%d].podSpec.persistence.autoscale is not supported for the Application Database", status.AppDb, i)
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
+// validateFailoverPolicy rejects the RedistributeMembers policy, the members of Ops Manager and the Application Database
+// are not moved to other member clusters.
+func validateFailoverPolicy(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	if os.Failover.GetPolicy() == v1.FailoverPolicyRedistributeMembers {
+		return v1.OpsManagerResourceValidationError(fmt.Sprintf("spec.failover.policy %s is not supported for MongoDBOpsManager", v1.FailoverPolicyRedistributeMembers), status.OpsManager)
+	}
+	if os.Failover != nil && !os.IsMultiCluster() && !os.AppDB.IsMultiCluster() {
+		return v1.OpsManagerResourceValidationError("spec.failover can only be set if Ops Manager or the Application Database use the MultiCluster topology", status.OpsManager)
+	}
+	return v1.ValidationSuccess()
+}
+
+func validateAutoscaling(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	autoscaling := os.Autoscaling
+	if autoscaling == nil {
+		return v1.ValidationSuccess()
+	}
+
+	if autoscaling.MinReplicas != nil {
+		if *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			return v1.OpsManagerResourceValidationError("spec.autoscaling.minReplicas (%d) must not be greater than spec.autoscaling.maxReplicas (%d)", status.OpsManager, *autoscaling.MinReplicas, autoscaling.MaxReplicas)
+		}
+		return v1.ValidationSuccess()
+	}
+
+	members := []int{os.Replicas}
+	if os.IsMultiCluster() {
+		members = nil
+		for _, clusterSpec := range os.ClusterSpecList {
+			members = append(members, clusterSpec.Members)
+		}
+	}
+	for _, m := range members {
+		if autoscaling.MinReplicasOrDefault(m) > autoscaling.MaxReplicas {
+			return v1.OpsManagerResourceValidationError("spec.autoscaling.maxReplicas (%d) must not be lower than the number of Ops Manager replicas (%d) if spec.autoscaling.minReplicas is not specified", status.OpsManager, autoscaling.MaxReplicas, m)
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
 func warnMonitoringAgentStartupParameters(os MongoDBOpsManagerSpec) v1.ValidationResult {
 	if len(os.AppDB.MonitoringAgent.StartupParameters) > 0 {
 		return v1.OpsManagerResourceValidationWarning("spec.appDB.monitoringAgent.startupOptions is deprecated and has no effect; the monitoring agent now runs inside the automation agent. Configure agent options, including log level and rotation, via spec.appDB.agent and remove spec.appDB.monitoringAgent from your configuration", status.AppDb)
@@ -296,6 +430,13 @@ func (om *MongoDBOpsManager) RunValidations() []v1.ValidationResult {
 		validateTopologyIsSpecified,
 		validateClusterSpecList,
 		validateBackupS3Stores,
+		validateBackupBlobStores,
+		validatePodDisruptionBudgets,
+		validateAppDBTopologySpread,
+		validateAppDBRestoreFromSnapshot,
+		validateAppDBStorageAutoscale,
+		validateAutoscaling,
+		validateFailoverPolicy,
 		featureCompatibilityVersionValidation,
 		validateAppDBUniqueExternalDomains,
 		warnMonitoringAgentStartupParameters,
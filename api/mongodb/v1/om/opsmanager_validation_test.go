@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
@@ -208,6 +209,53 @@ func TestOpsManagerValidation(t *testing.T) {
 			expectedWarningMessage: "'s3SecretRef' must not be specified if using IRSA (S3 OpLog Store: test)",
 			expectedPart:           status.OpsManager,
 		},
+		"Invalid GCS Store config - missing gcsSecretRef": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddGCSSnapshotStore(GCSConfig{Name: "test"}).
+				Build(),
+			expectedErrorMessage: "'gcsSecretRef' must be specified if not using Workload Identity (GCS Store: test)",
+			expectedPart:         status.OpsManager,
+		},
+		"Valid GCS Store config - no gcsSecretRef if workloadIdentityEnabled": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddGCSSnapshotStore(GCSConfig{Name: "test", WorkloadIdentityEnabled: true}).
+				Build(),
+			expectedPart: status.None,
+		},
+		"Valid GCS Store config with warning - gcsSecretRef present when workloadIdentityEnabled": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddGCSSnapshotStore(GCSConfig{Name: "test", GCSSecretRef: &SecretRef{}, WorkloadIdentityEnabled: true}).
+				Build(),
+			expectedWarningMessage: "'gcsSecretRef' must not be specified if using Workload Identity (GCS Store: test)",
+			expectedPart:           status.OpsManager,
+		},
+		"Invalid GCS Store config - mongodbUserRef without mongodbResourceRef": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddGCSSnapshotStore(GCSConfig{Name: "test", GCSSecretRef: &SecretRef{Name: "test"}, MongoDBUserRef: &MongoDBUserRef{Name: "user"}}).
+				Build(),
+			expectedErrorMessage: "'mongodbResourceRef' must be specified if 'mongodbUserRef' is configured (GCS Store: test)",
+			expectedPart:         status.OpsManager,
+		},
+		"Invalid Azure Blob Store config - missing azureSecretRef.Name": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddAzureBlobSnapshotStore(AzureBlobConfig{Name: "test", AzureSecretRef: &SecretRef{}}).
+				Build(),
+			expectedErrorMessage: "'azureSecretRef' must be specified if not using Workload Identity (Azure Blob Store: test)",
+			expectedPart:         status.OpsManager,
+		},
+		"Valid Azure Blob Store config - no azureSecretRef if workloadIdentityEnabled": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddAzureBlobSnapshotStore(AzureBlobConfig{Name: "test", WorkloadIdentityEnabled: true}).
+				Build(),
+			expectedPart: status.None,
+		},
+		"Valid Azure Blob Store config with warning - azureSecretRef present when workloadIdentityEnabled": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddAzureBlobSnapshotStore(AzureBlobConfig{Name: "test", AzureSecretRef: &SecretRef{}, WorkloadIdentityEnabled: true}).
+				Build(),
+			expectedWarningMessage: "'azureSecretRef' must not be specified if using Workload Identity (Azure Blob Store: test)",
+			expectedPart:           status.OpsManager,
+		},
 		"Invalid OpsManager version": {
 			testedOm: NewOpsManagerBuilderDefault().
 				SetVersion("4.4").
@@ -272,6 +320,64 @@ func TestOpsManagerValidation(t *testing.T) {
 			expectedPart:         status.OpsManager,
 			expectedErrorMessage: "Topology 'MultiCluster' must be specified while setting a not empty spec.clusterSpecList",
 		},
+		"Failover policy RedistributeMembers is not supported": {
+			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
+				SetAppDBTopology(ClusterTopologyMultiCluster).
+				SetAppDBClusterSpecList([]mdbv1.ClusterSpecItem{{ClusterName: "cluster1", Members: 3}}).
+				SetFailover(&v1.FailoverConfig{Policy: v1.FailoverPolicyRedistributeMembers}).
+				Build(),
+			expectedPart:         status.OpsManager,
+			expectedErrorMessage: "spec.failover.policy RedistributeMembers is not supported for MongoDBOpsManager",
+		},
+		"AppDB restore from a snapshot is not supported for multi cluster AppDB": {
+			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
+				SetAppDBTopology(ClusterTopologyMultiCluster).
+				SetAppDBClusterSpecList([]mdbv1.ClusterSpecItem{{ClusterName: "cluster1", Members: 3}}).
+				SetAppDBRestoreFromSnapshot(&v1.RestoreFromSnapshot{MongoDBVolumeSnapshotName: "appdb-snapshot"}).
+				Build(),
+			expectedPart:         status.AppDb,
+			expectedErrorMessage: "spec.applicationDatabase.restoreFromSnapshot is not supported for the MultiCluster topology",
+		},
+		"AppDB restore from a snapshot": {
+			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
+				SetAppDBRestoreFromSnapshot(&v1.RestoreFromSnapshot{MongoDBVolumeSnapshotName: "appdb-snapshot"}).
+				Build(),
+			expectedPart: status.None,
+		},
+		"AppDB storage autoscaling is not supported": {
+			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
+				SetAppDbPodSpec(mdbv1.MongoDbPodSpec{Persistence: &v1.Persistence{Autoscale: &v1.StorageAutoscale{MaxStorage: "100Gi"}}}).
+				Build(),
+			expectedPart:         status.AppDb,
+			expectedErrorMessage: "spec.applicationDatabase.podSpec.persistence.autoscale is not supported for the Application Database",
+		},
+		"AppDB storage autoscaling is not supported for multi cluster AppDB": {
+			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
+				SetAppDBTopology(ClusterTopologyMultiCluster).
+				SetAppDBClusterSpecList([]mdbv1.ClusterSpecItem{{
+					ClusterName: "cluster1",
+					Members:     3,
+					PodSpec:     &mdbv1.MongoDbPodSpec{Persistence: &v1.Persistence{Autoscale: &v1.StorageAutoscale{MaxStorage: "100Gi"}}},
+				}}).
+				Build(),
+			expectedPart:         status.AppDb,
+			expectedErrorMessage: "spec.applicationDatabase.clusterSpecList[0].podSpec.persistence.autoscale is not supported for the Application Database",
+		},
+		"Failover requires a multi cluster Ops Manager or AppDB": {
+			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
+				SetFailover(&v1.FailoverConfig{Policy: v1.FailoverPolicyAnnotate}).
+				Build(),
+			expectedPart:         status.OpsManager,
+			expectedErrorMessage: "spec.failover can only be set if Ops Manager or the Application Database use the MultiCluster topology",
+		},
+		"Failover policy ReduceVotes for multi cluster AppDB": {
+			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
+				SetAppDBTopology(ClusterTopologyMultiCluster).
+				SetAppDBClusterSpecList([]mdbv1.ClusterSpecItem{{ClusterName: "cluster1", Members: 3}}).
+				SetFailover(&v1.FailoverConfig{Policy: v1.FailoverPolicyReduceVotes}).
+				Build(),
+			expectedPart: status.None,
+		},
 		"Uniform externalDomain can be overwritten multi cluster AppDB": {
 			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
 				SetAppDBTopology(ClusterTopologyMultiCluster).
@@ -330,6 +436,44 @@ func TestOpsManagerValidation(t *testing.T) {
 			expectedErrorMessage: "Multiple member clusters with the same externalDomain (test) are not allowed. " +
 				"Check if all spec.applicationDatabase.clusterSpecList[*].externalAccess.externalDomain fields are defined and are unique.",
 		},
+		"Valid PodDisruptionBudget configuration": {
+			testedOm: NewOpsManagerBuilderDefault().SetPodDisruptionBudget(&v1.PodDisruptionBudgetConfiguration{
+				MinAvailable: ptr.To(intstr.FromString("50%")),
+			}).Build(),
+			expectedPart: status.None,
+		},
+		"PodDisruptionBudget with both minAvailable and maxUnavailable": {
+			testedOm: NewOpsManagerBuilderDefault().SetPodDisruptionBudget(&v1.PodDisruptionBudgetConfiguration{
+				MinAvailable:   ptr.To(intstr.FromInt32(1)),
+				MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+			}).Build(),
+			expectedPart:         status.OpsManager,
+			expectedErrorMessage: "only one of 'minAvailable' and 'maxUnavailable' can be specified in spec.podDisruptionBudget",
+		},
+		"Valid autoscaling configuration": {
+			testedOm: NewOpsManagerBuilderDefault().SetAutoscaling(&OpsManagerAutoscaling{
+				MinReplicas: ptr.To[int32](2),
+				MaxReplicas: 5,
+			}).Build(),
+			expectedPart: status.None,
+		},
+		"Autoscaling minReplicas greater than maxReplicas": {
+			testedOm: NewOpsManagerBuilderDefault().SetAutoscaling(&OpsManagerAutoscaling{
+				MinReplicas: ptr.To[int32](4),
+				MaxReplicas: 3,
+			}).Build(),
+			expectedPart:         status.OpsManager,
+			expectedErrorMessage: "spec.autoscaling.minReplicas (4) must not be greater than spec.autoscaling.maxReplicas (3)",
+		},
+		"Autoscaling maxReplicas lower than the number of replicas": {
+			testedOm: func() *MongoDBOpsManager {
+				om := NewOpsManagerBuilderDefault().SetAutoscaling(&OpsManagerAutoscaling{MaxReplicas: 2}).Build()
+				om.Spec.Replicas = 3
+				return om
+			}(),
+			expectedPart:         status.OpsManager,
+			expectedErrorMessage: "spec.autoscaling.maxReplicas (2) must not be lower than the number of Ops Manager replicas (3) if spec.autoscaling.minReplicas is not specified",
+		},
 		"Multiple member clusters with the same externalDomain are not allowed": {
 			testedOm: NewOpsManagerBuilderDefault().SetVersion("4.5.0-ent").
 				SetAppDBTopology(ClusterTopologyMultiCluster).
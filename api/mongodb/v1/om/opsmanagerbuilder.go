@@ -236,6 +236,32 @@ func (b *OpsManagerBuilder) AddS3SnapshotStore(config S3Config) *OpsManagerBuild
 	return b
 }
 
+func (b *OpsManagerBuilder) AddGCSSnapshotStore(config GCSConfig) *OpsManagerBuilder {
+	if b.om.Spec.Backup == nil {
+		b.om.Spec.Backup = newBackup()
+	}
+	b.om.Spec.Backup.GCSConfigs = append(b.om.Spec.Backup.GCSConfigs, config)
+	return b
+}
+
+func (b *OpsManagerBuilder) AddAzureBlobSnapshotStore(config AzureBlobConfig) *OpsManagerBuilder {
+	if b.om.Spec.Backup == nil {
+		b.om.Spec.Backup = newBackup()
+	}
+	b.om.Spec.Backup.AzureBlobConfigs = append(b.om.Spec.Backup.AzureBlobConfigs, config)
+	return b
+}
+
+func (b *OpsManagerBuilder) SetPodDisruptionBudget(config *v1.PodDisruptionBudgetConfiguration) *OpsManagerBuilder {
+	b.om.Spec.PodDisruptionBudget = config
+	return b
+}
+
+func (b *OpsManagerBuilder) SetAutoscaling(autoscaling *OpsManagerAutoscaling) *OpsManagerBuilder {
+	b.om.Spec.Autoscaling = autoscaling
+	return b
+}
+
 func (b *OpsManagerBuilder) SetOMStatusVersion(version string) *OpsManagerBuilder {
 	b.om.Status.OpsManagerStatus.Version = version
 	return b
@@ -256,6 +282,16 @@ func (b *OpsManagerBuilder) SetAppDBTopology(topology string) *OpsManagerBuilder
 	return b
 }
 
+func (b *OpsManagerBuilder) SetFailover(failover *v1.FailoverConfig) *OpsManagerBuilder {
+	b.om.Spec.Failover = failover
+	return b
+}
+
+func (b *OpsManagerBuilder) SetAppDBRestoreFromSnapshot(restore *v1.RestoreFromSnapshot) *OpsManagerBuilder {
+	b.om.Spec.AppDB.RestoreFromSnapshot = restore
+	return b
+}
+
 func (b *OpsManagerBuilder) SetOpsManagerTopology(topology string) *OpsManagerBuilder {
 	b.om.Spec.Topology = topology
 	return b
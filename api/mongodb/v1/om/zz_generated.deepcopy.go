@@ -26,6 +26,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -126,6 +127,26 @@ func (in *AppDBSpec) DeepCopyInto(out *AppDBSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(mdb.MaintenanceWindow)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(v1.PodDisruptionBudgetConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpread != nil {
+		in, out := &in.TopologySpread, &out.TopologySpread
+		*out = new(v1.TopologySpread)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFromSnapshot != nil {
+		in, out := &in.RestoreFromSnapshot, &out.RestoreFromSnapshot
+		*out = new(v1.RestoreFromSnapshot)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppDBSpec.
@@ -179,6 +200,41 @@ func (in *AppDbStatus) DeepCopy() *AppDbStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureBlobConfig) DeepCopyInto(out *AzureBlobConfig) {
+	*out = *in
+	if in.MongoDBResourceRef != nil {
+		in, out := &in.MongoDBResourceRef, &out.MongoDBResourceRef
+		*out = new(user.MongoDBResourceRef)
+		**out = **in
+	}
+	if in.MongoDBUserRef != nil {
+		in, out := &in.MongoDBUserRef, &out.MongoDBUserRef
+		*out = new(MongoDBUserRef)
+		**out = **in
+	}
+	if in.AzureSecretRef != nil {
+		in, out := &in.AzureSecretRef, &out.AzureSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.AssignmentLabels != nil {
+		in, out := &in.AssignmentLabels, &out.AssignmentLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBlobConfig.
+func (in *AzureBlobConfig) DeepCopy() *AzureBlobConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureBlobConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	*out = *in
@@ -323,6 +379,41 @@ func (in *FileSystemStoreConfig) DeepCopy() *FileSystemStoreConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSConfig) DeepCopyInto(out *GCSConfig) {
+	*out = *in
+	if in.MongoDBResourceRef != nil {
+		in, out := &in.MongoDBResourceRef, &out.MongoDBResourceRef
+		*out = new(user.MongoDBResourceRef)
+		**out = **in
+	}
+	if in.MongoDBUserRef != nil {
+		in, out := &in.MongoDBUserRef, &out.MongoDBUserRef
+		*out = new(MongoDBUserRef)
+		**out = **in
+	}
+	if in.GCSSecretRef != nil {
+		in, out := &in.GCSSecretRef, &out.GCSSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.AssignmentLabels != nil {
+		in, out := &in.AssignmentLabels, &out.AssignmentLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSConfig.
+func (in *GCSConfig) DeepCopy() *GCSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KmipConfig) DeepCopyInto(out *KmipConfig) {
 	*out = *in
@@ -451,6 +542,20 @@ func (in *MongoDBOpsManagerBackup) DeepCopyInto(out *MongoDBOpsManagerBackup) {
 		in, out := &in.StatefulSetConfiguration, &out.StatefulSetConfiguration
 		*out = (*in).DeepCopy()
 	}
+	if in.GCSConfigs != nil {
+		in, out := &in.GCSConfigs, &out.GCSConfigs
+		*out = make([]GCSConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AzureBlobConfigs != nil {
+		in, out := &in.AzureBlobConfigs, &out.AzureBlobConfigs
+		*out = make([]AzureBlobConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.QueryableBackupSecretRef = in.QueryableBackupSecretRef
 	if in.Encryption != nil {
 		in, out := &in.Encryption, &out.Encryption
@@ -462,6 +567,11 @@ func (in *MongoDBOpsManagerBackup) DeepCopyInto(out *MongoDBOpsManagerBackup) {
 		*out = new(Logging)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(v1.PodDisruptionBudgetConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBOpsManagerBackup.
@@ -543,7 +653,7 @@ func (in *MongoDBOpsManagerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDBOpsManagerSecurity) DeepCopyInto(out *MongoDBOpsManagerSecurity) {
 	*out = *in
-	out.TLS = in.TLS
+	in.TLS.DeepCopyInto(&out.TLS)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBOpsManagerSecurity.
@@ -622,7 +732,7 @@ func (in *MongoDBOpsManagerSpec) DeepCopyInto(out *MongoDBOpsManagerSpec) {
 	if in.Security != nil {
 		in, out := &in.Security, &out.Security
 		*out = new(MongoDBOpsManagerSecurity)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.StatefulSetConfiguration != nil {
 		in, out := &in.StatefulSetConfiguration, &out.StatefulSetConfiguration
@@ -635,6 +745,26 @@ func (in *MongoDBOpsManagerSpec) DeepCopyInto(out *MongoDBOpsManagerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(v1.FailoverConfig)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(v1.PodDisruptionBudgetConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(OpsManagerAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(OpsManagerUpgrade)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBOpsManagerSpec.
@@ -669,6 +799,11 @@ func (in *MongoDBOpsManagerStatus) DeepCopy() *MongoDBOpsManagerStatus {
 func (in *MongoDBOpsManagerTLS) DeepCopyInto(out *MongoDBOpsManagerTLS) {
 	*out = *in
 	out.SecretRef = in.SecretRef
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(v1.CertManagerConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBOpsManagerTLS.
@@ -711,6 +846,38 @@ func (in *MongoDBUserRef) DeepCopy() *MongoDBUserRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerAutoscaling) DeepCopyInto(out *OpsManagerAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerAutoscaling.
+func (in *OpsManagerAutoscaling) DeepCopy() *OpsManagerAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsManagerBuilder) DeepCopyInto(out *OpsManagerBuilder) {
 	*out = *in
@@ -741,6 +908,11 @@ func (in *OpsManagerStatus) DeepCopyInto(out *OpsManagerStatus) {
 		*out = make([]status.OMClusterStatusItem, len(*in))
 		copy(*out, *in)
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerStatus.
@@ -753,6 +925,21 @@ func (in *OpsManagerStatus) DeepCopy() *OpsManagerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerUpgrade) DeepCopyInto(out *OpsManagerUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerUpgrade.
+func (in *OpsManagerUpgrade) DeepCopy() *OpsManagerUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsManagerVersionMapping) DeepCopyInto(out *OpsManagerVersionMapping) {
 	*out = *in
@@ -851,3 +1038,38 @@ func (in *TLSSecretRef) DeepCopy() *TLSSecretRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]UpgradeStep, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStep) DeepCopyInto(out *UpgradeStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStep.
+func (in *UpgradeStep) DeepCopy() *UpgradeStep {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStep)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,8 +1,20 @@
 package v1
 
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
 type Persistence struct {
 	SingleConfig   *PersistenceConfig         `json:"single,omitempty"`
 	MultipleConfig *MultiplePersistenceConfig `json:"multiple,omitempty"`
+	// Autoscale expands the persistent volumes of the database pods when their usage crosses a threshold. It is only
+	// supported by the MongoDB and MongoDBMultiCluster resources.
+	// +optional
+	Autoscale *StorageAutoscale `json:"autoscale,omitempty"`
 }
 
 type MultiplePersistenceConfig struct {
@@ -18,3 +30,73 @@ type PersistenceConfig struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	LabelSelector *LabelSelectorWrapper `json:"labelSelector,omitempty"`
 }
+
+// StorageAutoscale configures the expansion of the persistent volumes. The volumes of a StatefulSet are expanded
+// together, using the PVC resize flow, when the usage of one of them crosses the threshold. The storage class must
+// allow volume expansion.
+type StorageAutoscale struct {
+	// UsageThresholdPercent is the usage of a volume, in percent of its capacity, above which the volume is expanded.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	UsageThresholdPercent *int `json:"usageThresholdPercent,omitempty"`
+	// Increase is the growth step of a volume, either a percentage of its current size ("20%") or a quantity ("10Gi").
+	// +kubebuilder:validation:Pattern=`^([0-9]+%|[0-9]+(\.[0-9]+)?(Ki|Mi|Gi|Ti|Pi|Ei|k|M|G|T|P|E)?)$`
+	// +optional
+	Increase string `json:"increase,omitempty"`
+	// MaxStorage is the size the volumes are never expanded beyond.
+	// +kubebuilder:validation:Required
+	MaxStorage string `json:"maxStorage"`
+}
+
+const (
+	DefaultStorageAutoscaleUsageThresholdPercent = 80
+	DefaultStorageAutoscaleIncrease              = "20%"
+)
+
+// GetUsageThresholdPercent returns the usage threshold, 80% by default.
+func (s *StorageAutoscale) GetUsageThresholdPercent() int {
+	if s.UsageThresholdPercent == nil {
+		return DefaultStorageAutoscaleUsageThresholdPercent
+	}
+	return *s.UsageThresholdPercent
+}
+
+// GetIncrease returns the growth step, 20% by default.
+func (s *StorageAutoscale) GetIncrease() string {
+	if s.Increase == "" {
+		return DefaultStorageAutoscaleIncrease
+	}
+	return s.Increase
+}
+
+// ExpandedStorage returns the size a volume of the current size is expanded to: the current size grown by the
+// increase, rounded up to a whole mebibyte and capped to the maximum storage.
+func (s *StorageAutoscale) ExpandedStorage(current resource.Quantity) (resource.Quantity, error) {
+	maxStorage, err := resource.ParseQuantity(s.MaxStorage)
+	if err != nil {
+		return resource.Quantity{}, xerrors.Errorf("invalid maxStorage %q: %w", s.MaxStorage, err)
+	}
+
+	expanded := current.Value()
+	if percent, ok := strings.CutSuffix(s.GetIncrease(), "%"); ok {
+		p, err := strconv.Atoi(percent)
+		if err != nil || p <= 0 {
+			return resource.Quantity{}, xerrors.Errorf("invalid increase %q: must be a positive percentage or quantity", s.GetIncrease())
+		}
+		expanded += current.Value() * int64(p) / 100
+	} else {
+		increase, err := resource.ParseQuantity(s.GetIncrease())
+		if err != nil || increase.Sign() <= 0 {
+			return resource.Quantity{}, xerrors.Errorf("invalid increase %q: must be a positive percentage or quantity", s.GetIncrease())
+		}
+		expanded += increase.Value()
+	}
+
+	const mebibyte = 1024 * 1024
+	expanded = (expanded + mebibyte - 1) / mebibyte * mebibyte
+	if expanded >= maxStorage.Value() {
+		return maxStorage, nil
+	}
+	return *resource.NewQuantity(expanded, resource.BinarySI), nil
+}
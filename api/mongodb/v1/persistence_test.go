@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestStorageAutoscale_ExpandedStorage(t *testing.T) {
+	tests := []struct {
+		autoscale StorageAutoscale
+		current   string
+		expected  string
+	}{
+		{autoscale: StorageAutoscale{MaxStorage: "100Gi"}, current: "10Gi", expected: "12Gi"},
+		{autoscale: StorageAutoscale{Increase: "50%", MaxStorage: "100Gi"}, current: "10Gi", expected: "15Gi"},
+		{autoscale: StorageAutoscale{Increase: "5Gi", MaxStorage: "100Gi"}, current: "10Gi", expected: "15Gi"},
+		{autoscale: StorageAutoscale{Increase: "50%", MaxStorage: "12Gi"}, current: "10Gi", expected: "12Gi"},
+		// rounded up to a whole mebibyte
+		{autoscale: StorageAutoscale{Increase: "10%", MaxStorage: "100Gi"}, current: "1Gi", expected: "1127Mi"},
+	}
+	for _, tt := range tests {
+		expanded, err := tt.autoscale.ExpandedStorage(resource.MustParse(tt.current))
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, expanded.String())
+	}
+}
@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// PodDisruptionBudgetConfiguration configures the PodDisruptionBudget created by the Operator for
+// the Pods of a StatefulSet. At most one of MinAvailable and MaxUnavailable can be specified; if none of
+// them is specified the Operator picks a default suitable for the StatefulSet.
+type PodDisruptionBudgetConfiguration struct {
+	// Enabled controls whether the PodDisruptionBudget is created. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// MinAvailable is the number or percentage of Pods that must stay available during voluntary disruptions.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of Pods that can be unavailable during voluntary disruptions.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// IsEnabled returns true if the PodDisruptionBudget should be created. A nil configuration means
+// the PodDisruptionBudget is created with the default settings.
+func (p *PodDisruptionBudgetConfiguration) IsEnabled() bool {
+	return p == nil || p.Enabled == nil || *p.Enabled
+}
+
+// IsCustomized returns true if the user has specified either MinAvailable or MaxUnavailable.
+func (p *PodDisruptionBudgetConfiguration) IsCustomized() bool {
+	return p != nil && (p.MinAvailable != nil || p.MaxUnavailable != nil)
+}
+
+// WithDefault returns the configuration to apply: 'defaultConfig' is used if the PodDisruptionBudget is enabled
+// but neither MinAvailable nor MaxUnavailable has been specified.
+func (p *PodDisruptionBudgetConfiguration) WithDefault(defaultConfig PodDisruptionBudgetConfiguration) *PodDisruptionBudgetConfiguration {
+	if p.IsEnabled() && !p.IsCustomized() {
+		return &defaultConfig
+	}
+	return p
+}
+
+// VotingMajorityPodDisruptionBudget returns the configuration allowing as many Pods to be evicted as a replica set
+// with 'votingMembers' voting members can lose while keeping a majority of them available.
+// The PodDisruptionBudget can't tell voting and non-voting members apart, so every Pod is treated as a voting one.
+// If the replica set can't lose any of its voting members the PodDisruptionBudget is disabled, as it would block
+// the drain of the nodes indefinitely.
+func VotingMajorityPodDisruptionBudget(votingMembers int) PodDisruptionBudgetConfiguration {
+	return VotingMajorityPodDisruptionBudgets([]int{votingMembers})[0]
+}
+
+// VotingMajorityPodDisruptionBudgets returns the configuration of the PodDisruptionBudget of each member cluster of a
+// replica set with votingMembers[i] voting members in the member cluster i. The PodDisruptionBudgets of the member
+// clusters don't know about each other, so the number of Pods the replica set can lose while keeping a majority of
+// its voting members is split between them. Each member cluster with voting members gets one Pod if there are enough
+// of them, the rest is split proportionally to their voting members. As for a single cluster, the PodDisruptionBudget
+// of a member cluster that gets no part of it is disabled instead of blocking the drain of its nodes, and a member
+// cluster without voting members isn't protected.
+func VotingMajorityPodDisruptionBudgets(votingMembers []int) []PodDisruptionBudgetConfiguration {
+	total := 0
+	votingClusters := 0
+	for _, v := range votingMembers {
+		total += v
+		if v > 0 {
+			votingClusters++
+		}
+	}
+	tolerated := total - (total/2 + 1)
+
+	var shares []int
+	if tolerated > 0 && tolerated >= votingClusters {
+		shares = largestRemainderShares(tolerated-votingClusters, votingMembers)
+		for i, v := range votingMembers {
+			if v > 0 {
+				shares[i]++
+			}
+		}
+	} else {
+		shares = largestRemainderShares(max(tolerated, 0), votingMembers)
+	}
+
+	configs := make([]PodDisruptionBudgetConfiguration, len(votingMembers))
+	for i, share := range shares {
+		if share == 0 {
+			configs[i] = PodDisruptionBudgetConfiguration{Enabled: ptr.To(false)}
+			continue
+		}
+		//nolint:gosec // suppressing integer overflow warning for int32(share)
+		configs[i] = PodDisruptionBudgetConfiguration{MaxUnavailable: ptr.To(intstr.FromInt32(int32(share)))}
+	}
+	return configs
+}
+
+// largestRemainderShares splits 'count' proportionally to the weights. The largest remainder method keeps the sum of
+// the shares equal to 'count', ties are broken by the order of the weights.
+func largestRemainderShares(count int, weights []int) []int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	shares := make([]int, len(weights))
+	if count == 0 || total == 0 {
+		return shares
+	}
+
+	remaining := count
+	for i, w := range weights {
+		shares[i] = count * w / total
+		remaining -= shares[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return count*weights[order[a]]%total > count*weights[order[b]]%total
+	})
+	for _, i := range order[:remaining] {
+		shares[i]++
+	}
+	return shares
+}
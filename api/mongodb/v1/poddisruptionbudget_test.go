@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func maxUnavailable(n int32) PodDisruptionBudgetConfiguration {
+	return PodDisruptionBudgetConfiguration{MaxUnavailable: ptr.To(intstr.FromInt32(n))}
+}
+
+var disabledPodDisruptionBudget = PodDisruptionBudgetConfiguration{Enabled: ptr.To(false)}
+
+func TestVotingMajorityPodDisruptionBudget(t *testing.T) {
+	assert.Equal(t, disabledPodDisruptionBudget, VotingMajorityPodDisruptionBudget(1))
+	assert.Equal(t, disabledPodDisruptionBudget, VotingMajorityPodDisruptionBudget(2))
+	assert.Equal(t, maxUnavailable(1), VotingMajorityPodDisruptionBudget(3))
+	assert.Equal(t, maxUnavailable(2), VotingMajorityPodDisruptionBudget(5))
+}
+
+func TestVotingMajorityPodDisruptionBudgets(t *testing.T) {
+	// the replica set can't lose any member
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{disabledPodDisruptionBudget, disabledPodDisruptionBudget},
+		VotingMajorityPodDisruptionBudgets([]int{1, 1}))
+
+	// a single Pod can be evicted, in the member cluster with the most voting members, the PodDisruptionBudgets of the
+	// other member clusters would block the drain of their nodes
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{disabledPodDisruptionBudget, maxUnavailable(1), disabledPodDisruptionBudget},
+		VotingMajorityPodDisruptionBudgets([]int{1, 2, 1}))
+
+	// the two Pods are split between the member clusters
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{maxUnavailable(1), maxUnavailable(1), disabledPodDisruptionBudget},
+		VotingMajorityPodDisruptionBudgets([]int{2, 2, 1}))
+
+	// ties are broken by the order of the member clusters
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{maxUnavailable(1), disabledPodDisruptionBudget, disabledPodDisruptionBudget},
+		VotingMajorityPodDisruptionBudgets([]int{1, 1, 1}))
+
+	// every member cluster gets a Pod when there are enough of them, the rest is split proportionally
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{maxUnavailable(1), maxUnavailable(1)},
+		VotingMajorityPodDisruptionBudgets([]int{5, 1}))
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{maxUnavailable(1), maxUnavailable(1), maxUnavailable(1)},
+		VotingMajorityPodDisruptionBudgets([]int{3, 3, 1}))
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{maxUnavailable(3), maxUnavailable(1)},
+		VotingMajorityPodDisruptionBudgets([]int{7, 2}))
+
+	// the member clusters without voting members aren't protected
+	assert.Equal(t, []PodDisruptionBudgetConfiguration{maxUnavailable(1), disabledPodDisruptionBudget},
+		VotingMajorityPodDisruptionBudgets([]int{3, 0}))
+}
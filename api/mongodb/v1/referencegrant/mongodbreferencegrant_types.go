@@ -0,0 +1,144 @@
+package referencegrant
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+)
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBReferenceGrant{}, &MongoDBReferenceGrantList{})
+}
+
+type Kind string
+
+const (
+	KindMongoDB             Kind = "MongoDB"
+	KindMongoDBMultiCluster Kind = "MongoDBMultiCluster"
+	KindMongoDBCommunity    Kind = "MongoDBCommunity"
+	KindMongoDBUser         Kind = "MongoDBUser"
+	KindMongoDBSearch       Kind = "MongoDBSearch"
+	KindMongoDBOpsManager   Kind = "MongoDBOpsManager"
+	KindSecret              Kind = "Secret"
+)
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Namespaced,shortName=mdbrg
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDB Reference Grant resource was created."
+
+// MongoDBReferenceGrant allows the resources of other namespaces to reference the resources of its namespace. The
+// resources of one namespace can always reference each other, a reference to a resource in another namespace is only
+// followed by the operator when a MongoDBReferenceGrant in the namespace of the referenced resource allows it.
+type MongoDBReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBReferenceGrantSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// MongoDBReferenceGrantList contains a list of MongoDBReferenceGrant.
+type MongoDBReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MongoDBReferenceGrant `json:"items"`
+}
+
+// MongoDBReferenceGrantSpec allows every resource in From to reference every resource in To.
+type MongoDBReferenceGrantSpec struct {
+	// From are the resources of other namespaces which are allowed to reference the resources in To.
+	// +kubebuilder:validation:MinItems=1
+	From []ReferenceGrantFrom `json:"from"`
+	// To are the resources of the namespace of the grant which can be referenced.
+	// +kubebuilder:validation:MinItems=1
+	To []ReferenceGrantTo `json:"to"`
+}
+
+type ReferenceGrantFrom struct {
+	// +kubebuilder:validation:Enum=MongoDBUser;MongoDBSearch;MongoDBOpsManager
+	Kind Kind `json:"kind"`
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+type ReferenceGrantTo struct {
+	// +kubebuilder:validation:Enum=MongoDB;MongoDBMultiCluster;MongoDBCommunity;MongoDBUser;Secret
+	Kind Kind `json:"kind"`
+	// Name restricts the grant to the resource of the kind with this name. All the resources of the kind can be
+	// referenced when it is not set.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// Reference is one end of a reference between two resources.
+// +kubebuilder:object:generate=false
+type Reference struct {
+	Kind      Kind
+	Namespace string
+	Name      string
+}
+
+func (r Reference) String() string {
+	return string(r.Kind) + " " + r.Namespace + "/" + r.Name
+}
+
+// Permits returns true if the grant allows the "from" resource to reference the "to" resource. It only considers
+// its own spec, the grant must be in the namespace of the "to" resource.
+func (g *MongoDBReferenceGrant) Permits(from Reference, to Reference) bool {
+	if g.Namespace != to.Namespace {
+		return false
+	}
+	fromPermitted := false
+	for _, f := range g.Spec.From {
+		if f.Kind == from.Kind && f.Namespace == from.Namespace {
+			fromPermitted = true
+			break
+		}
+	}
+	if !fromPermitted {
+		return false
+	}
+	for _, t := range g.Spec.To {
+		if t.Kind == to.Kind && (t.Name == "" || t.Name == to.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromNamespaces returns the namespaces whose resources of the given kind are allowed to reference the namespace of
+// the grant.
+func (g *MongoDBReferenceGrant) FromNamespaces(kind Kind) []string {
+	var namespaces []string
+	for _, f := range g.Spec.From {
+		if f.Kind == kind {
+			namespaces = append(namespaces, f.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// IsReferenceAllowed returns true if the "from" resource may reference the "to" resource: either both are in the same
+// namespace, or a MongoDBReferenceGrant in the namespace of the "to" resource permits the reference.
+func IsReferenceAllowed(ctx context.Context, reader client.Reader, from Reference, to Reference) (bool, error) {
+	if from.Namespace == to.Namespace {
+		return true, nil
+	}
+	grants := &MongoDBReferenceGrantList{}
+	if err := reader.List(ctx, grants, client.InNamespace(to.Namespace)); err != nil {
+		return false, xerrors.Errorf("error listing the MongoDBReferenceGrants in namespace %s: %w", to.Namespace, err)
+	}
+	for i := range grants.Items {
+		if grants.Items[i].Permits(from, to) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
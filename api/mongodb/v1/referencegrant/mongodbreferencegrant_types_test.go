@@ -0,0 +1,103 @@
+package referencegrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+)
+
+func newGrant() *MongoDBReferenceGrant {
+	return &MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "backup", Name: "ops-manager"},
+		Spec: MongoDBReferenceGrantSpec{
+			From: []ReferenceGrantFrom{{Kind: KindMongoDBOpsManager, Namespace: "ops-manager"}},
+			To: []ReferenceGrantTo{
+				{Kind: KindMongoDB, Name: "oplog-store"},
+				{Kind: KindMongoDBUser},
+			},
+		},
+	}
+}
+
+func TestPermits(t *testing.T) {
+	opsManager := Reference{Kind: KindMongoDBOpsManager, Namespace: "ops-manager", Name: "om"}
+
+	tests := []struct {
+		name     string
+		from     Reference
+		to       Reference
+		expected bool
+	}{
+		{
+			name:     "referenced resource is granted by name",
+			from:     opsManager,
+			to:       Reference{Kind: KindMongoDB, Namespace: "backup", Name: "oplog-store"},
+			expected: true,
+		},
+		{
+			name:     "all resources of the kind are granted",
+			from:     opsManager,
+			to:       Reference{Kind: KindMongoDBUser, Namespace: "backup", Name: "any-user"},
+			expected: true,
+		},
+		{
+			name: "other resource of a kind granted by name",
+			from: opsManager,
+			to:   Reference{Kind: KindMongoDB, Namespace: "backup", Name: "blockstore"},
+		},
+		{
+			name: "kind isn't granted",
+			from: opsManager,
+			to:   Reference{Kind: KindSecret, Namespace: "backup", Name: "oplog-store"},
+		},
+		{
+			name: "referencing kind isn't granted",
+			from: Reference{Kind: KindMongoDBUser, Namespace: "ops-manager", Name: "user"},
+			to:   Reference{Kind: KindMongoDB, Namespace: "backup", Name: "oplog-store"},
+		},
+		{
+			name: "referencing namespace isn't granted",
+			from: Reference{Kind: KindMongoDBOpsManager, Namespace: "other", Name: "om"},
+			to:   Reference{Kind: KindMongoDB, Namespace: "backup", Name: "oplog-store"},
+		},
+		{
+			name: "referenced resource is in another namespace than the grant",
+			from: opsManager,
+			to:   Reference{Kind: KindMongoDB, Namespace: "other", Name: "oplog-store"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newGrant().Permits(tt.from, tt.to))
+		})
+	}
+}
+
+func TestIsReferenceAllowed(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newGrant()).Build()
+
+	opsManager := Reference{Kind: KindMongoDBOpsManager, Namespace: "ops-manager", Name: "om"}
+
+	allowed, err := IsReferenceAllowed(ctx, reader, opsManager, Reference{Kind: KindSecret, Namespace: "ops-manager", Name: "any"})
+	require.NoError(t, err)
+	assert.True(t, allowed, "resources of the same namespace don't need a grant")
+
+	allowed, err = IsReferenceAllowed(ctx, reader, opsManager, Reference{Kind: KindMongoDB, Namespace: "backup", Name: "oplog-store"})
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = IsReferenceAllowed(ctx, reader, opsManager, Reference{Kind: KindSecret, Namespace: "backup", Name: "password"})
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
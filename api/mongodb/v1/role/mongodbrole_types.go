@@ -0,0 +1,41 @@
+package role
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+)
+
+// MongoDBRoleSpec defines the desired state of MongoDBRole.
+type MongoDBRoleSpec struct {
+	// +kubebuilder:pruning:PreserveUnknownFields
+	mdbv1.MongoDBRole `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Namespaced,shortName=mdbr
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDB Custom Role resource was created."
+
+// MongoDBRole is the Schema for the mongodbroles API. Unlike ClusterMongoDBRole, it can only be referenced by the
+// resources in its own namespace.
+type MongoDBRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBRoleSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MongoDBRoleList contains a list of MongoDBRole.
+type MongoDBRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MongoDBRole `json:"items"`
+}
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBRole{}, &MongoDBRoleList{})
+}
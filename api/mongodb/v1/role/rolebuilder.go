@@ -65,3 +65,44 @@ func (b *ClusterMongoDBRoleBuilder) Build() *ClusterMongoDBRole {
 		},
 	}
 }
+
+type MongoDBRoleBuilder struct {
+	name        string
+	namespace   string
+	mongoDBRole mdb.MongoDBRole
+}
+
+func DefaultMongoDBRoleBuilder() *MongoDBRoleBuilder {
+	return &MongoDBRoleBuilder{
+		name:        "default-role",
+		namespace:   "my-namespace",
+		mongoDBRole: DefaultClusterMongoDBRoleBuilder().mongoDBRole,
+	}
+}
+
+func (b *MongoDBRoleBuilder) SetName(name string) *MongoDBRoleBuilder {
+	b.name = name
+	return b
+}
+
+func (b *MongoDBRoleBuilder) SetNamespace(namespace string) *MongoDBRoleBuilder {
+	b.namespace = namespace
+	return b
+}
+
+func (b *MongoDBRoleBuilder) SetMongoDBRole(role mdb.MongoDBRole) *MongoDBRoleBuilder {
+	b.mongoDBRole = role
+	return b
+}
+
+func (b *MongoDBRoleBuilder) Build() *MongoDBRole {
+	return &MongoDBRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.name,
+			Namespace: b.namespace,
+		},
+		Spec: MongoDBRoleSpec{
+			MongoDBRole: b.mongoDBRole,
+		},
+	}
+}
@@ -125,3 +125,93 @@ func (in *ClusterMongoDBRoleSpec) DeepCopy() *ClusterMongoDBRoleSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBRole) DeepCopyInto(out *MongoDBRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBRole.
+func (in *MongoDBRole) DeepCopy() *MongoDBRole {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBRoleBuilder) DeepCopyInto(out *MongoDBRoleBuilder) {
+	*out = *in
+	in.mongoDBRole.DeepCopyInto(&out.mongoDBRole)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBRoleBuilder.
+func (in *MongoDBRoleBuilder) DeepCopy() *MongoDBRoleBuilder {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBRoleBuilder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBRoleList) DeepCopyInto(out *MongoDBRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MongoDBRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBRoleList.
+func (in *MongoDBRoleList) DeepCopy() *MongoDBRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBRoleSpec) DeepCopyInto(out *MongoDBRoleSpec) {
+	*out = *in
+	in.MongoDBRole.DeepCopyInto(&out.MongoDBRole)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBRoleSpec.
+func (in *MongoDBRoleSpec) DeepCopy() *MongoDBRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
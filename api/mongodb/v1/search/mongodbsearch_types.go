@@ -204,6 +204,11 @@ type MongoDBSearchSpec struct {
 	// +kubebuilder:validation:XValidation:rule="size(self) <= 1 || self.all(c, has(c.index))",message="clusters[].index is required on every entry when more than one cluster is specified"
 	// +kubebuilder:validation:XValidation:rule="self.all(c, oldSelf.all(o, (has(o.index) ? o.index : 0) != (has(c.index) ? c.index : 0) || (has(o.name) ? o.name : '') == '' || (has(o.name) ? o.name : '') == (has(c.name) ? c.name : '')))",message="clusters[].name is immutable for an existing cluster index; remove and re-add the entry to change it"
 	Clusters []ClusterSpec `json:"clusters"`
+
+	// Failover configures what the Operator does when a member cluster in spec.clusters fails its health check.
+	// Only the Annotate policy is supported: the mongot pods of the failed cluster are skipped until it is healthy again.
+	// +optional
+	Failover *v1.FailoverConfig `json:"failover,omitempty"`
 }
 
 // AdvancedMongotConfigs wraps free-form mongot configuration. The CRD generator
@@ -454,7 +459,8 @@ type FeatureFlags struct {
 }
 
 type MongoDBSource struct {
-	// MongoDBResourceRef points to an operator-managed MongoDB resource to sync from.
+	// MongoDBResourceRef points to an operator-managed MongoDB resource to sync from. A resource in another
+	// namespace can only be referenced when a MongoDBReferenceGrant in that namespace allows it.
 	// Mutually exclusive with External.
 	// +optional
 	MongoDBResourceRef *userv1.MongoDBResourceRef `json:"mongodbResourceRef,omitempty"`
@@ -583,6 +589,10 @@ type TLS struct {
 	// decrypts the password-encrypted server private key. Omit when the key is not encrypted.
 	// +optional
 	KeyFilePasswordSecret corev1.LocalObjectReference `json:"keyFilePasswordSecretRef,omitempty"`
+	// CertManager makes the Operator request the mongot certificates from cert-manager. The certificates are stored
+	// in the Secrets the Operator would otherwise expect to exist.
+	// +optional
+	CertManager *v1.CertManagerConfig `json:"certManager,omitempty"`
 }
 
 // LoadBalancerStatus reports the state of the operator-managed load balancer (Envoy).
@@ -872,6 +882,9 @@ func (s *MongoDBSearch) GetMongoDBResourceRef() *userv1.MongoDBResourceRef {
 	if s.Spec.Source != nil && s.Spec.Source.MongoDBResourceRef != nil && s.Spec.Source.MongoDBResourceRef.Name != "" {
 		mdbResourceRef.Name = s.Spec.Source.MongoDBResourceRef.Name
 	}
+	if s.Spec.Source != nil && s.Spec.Source.MongoDBResourceRef != nil && s.Spec.Source.MongoDBResourceRef.Namespace != "" {
+		mdbResourceRef.Namespace = s.Spec.Source.MongoDBResourceRef.Namespace
+	}
 
 	return &mdbResourceRef
 }
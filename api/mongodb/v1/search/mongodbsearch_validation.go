@@ -86,6 +86,8 @@ func commonValidators() []func(*MongoDBSearch) v1.ValidationResult {
 		validateLBConfig,
 		validateMultipleReplicasRequireLB,
 		validateShardOverrides,
+		validateFailoverPolicy,
+		validatePersistenceAutoscale,
 	}
 }
 
@@ -141,6 +143,16 @@ func validateClustersNonEmpty(s *MongoDBSearch) v1.ValidationResult {
 	return v1.ValidationSuccess()
 }
 
+// validateFailoverPolicy rejects the policies other than Annotate: the mongot
+// replicas of a failed member cluster are skipped until it is healthy again,
+// they are neither moved nor hold any votes.
+func validateFailoverPolicy(s *MongoDBSearch) v1.ValidationResult {
+	if policy := s.Spec.Failover.GetPolicy(); policy != "" && policy != v1.FailoverPolicyAnnotate {
+		return v1.ValidationError("spec.failover.policy %s is not supported for MongoDBSearch, only %s is", policy, v1.FailoverPolicyAnnotate)
+	}
+	return v1.ValidationSuccess()
+}
+
 // validateMultipleReplicasRequireLB rejects a spec that runs more than one
 // mongot replica in any cluster without a load balancer to distribute traffic
 // across the replicas. It depends only on spec fields, so it lives in the
@@ -745,6 +757,21 @@ func externalSource(s *MongoDBSearch) *ExternalMongoDBSource {
 	return s.Spec.Source.ExternalMongoDBSource
 }
 
+// validatePersistenceAutoscale rejects storage autoscaling, the mongot volumes are not autoscaled.
+func validatePersistenceAutoscale(s *MongoDBSearch) v1.ValidationResult {
+	for ci, c := range s.Spec.Clusters {
+		if c.Persistence != nil && c.Persistence.Autoscale != nil {
+			return v1.ValidationError("spec.clusters[%d].persistence.autoscale is not supported for MongoDBSearch", ci)
+		}
+		for oi, o := range c.ShardOverrides {
+			if o.Persistence != nil && o.Persistence.Autoscale != nil {
+				return v1.ValidationError("spec.clusters[%d].shardOverrides[%d].persistence.autoscale is not supported for MongoDBSearch", ci, oi)
+			}
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
 // validateShardOverrides enforces the per-shard override rules:
 //   - shardOverrides may only be set when the source is an external sharded cluster;
 //   - every referenced shardName must exist in the declared shard set;
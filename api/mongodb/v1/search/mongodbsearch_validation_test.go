@@ -277,6 +277,26 @@ func TestValidateClustersUniqueClusterName(t *testing.T) {
 	}
 }
 
+func TestValidatePersistenceAutoscale(t *testing.T) {
+	autoscaled := &v1.Persistence{Autoscale: &v1.StorageAutoscale{MaxStorage: "100Gi"}}
+	s := &MongoDBSearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "ns"},
+		Spec:       MongoDBSearchSpec{Clusters: []ClusterSpec{{Persistence: &v1.Persistence{}}}},
+	}
+	assert.Equal(t, v1.SuccessLevel, validatePersistenceAutoscale(s).Level)
+
+	s.Spec.Clusters[0].Persistence = autoscaled
+	res := validatePersistenceAutoscale(s)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.clusters[0].persistence.autoscale is not supported for MongoDBSearch", res.Msg)
+
+	s.Spec.Clusters[0].Persistence = nil
+	s.Spec.Clusters[0].ShardOverrides = []ShardOverride{{ShardNames: []string{"shard-0"}, Persistence: autoscaled}}
+	res = validatePersistenceAutoscale(s)
+	assert.Equal(t, v1.ErrorLevel, res.Level)
+	assert.Equal(t, "spec.clusters[0].shardOverrides[0].persistence.autoscale is not supported for MongoDBSearch", res.Msg)
+}
+
 func TestValidateMCExternalHostnames(t *testing.T) {
 	mkSearch := func(hostnames []string, sharded bool) *MongoDBSearch {
 		clusters := make([]ClusterSpec, 0, len(hostnames))
@@ -1079,3 +1099,40 @@ func TestValidateShardOverrides(t *testing.T) {
 		assert.Equal(t, v1.SuccessLevel, validateShardOverrides(s).Level)
 	})
 }
+
+func TestValidateFailoverPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		failover      *v1.FailoverConfig
+		errorContains string
+	}{
+		{name: "no failover configuration"},
+		{name: "default policy", failover: &v1.FailoverConfig{}},
+		{name: "annotate", failover: &v1.FailoverConfig{Policy: v1.FailoverPolicyAnnotate}},
+		{
+			name:          "redistribute members",
+			failover:      &v1.FailoverConfig{Policy: v1.FailoverPolicyRedistributeMembers},
+			errorContains: "spec.failover.policy RedistributeMembers is not supported for MongoDBSearch",
+		},
+		{
+			name:          "reduce votes",
+			failover:      &v1.FailoverConfig{Policy: v1.FailoverPolicyReduceVotes},
+			errorContains: "spec.failover.policy ReduceVotes is not supported for MongoDBSearch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &MongoDBSearch{
+				ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "ns"},
+				Spec:       MongoDBSearchSpec{Failover: tt.failover},
+			}
+			res := validateFailoverPolicy(s)
+			if tt.errorContains != "" {
+				assert.Equal(t, v1.ErrorLevel, res.Level)
+				assert.Contains(t, res.Msg, tt.errorContains)
+			} else {
+				assert.Equal(t, v1.SuccessLevel, res.Level)
+			}
+		})
+	}
+}
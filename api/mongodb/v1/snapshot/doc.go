@@ -0,0 +1,6 @@
+// +kubebuilder:object:generate=true
+// +groupName=mongodb.com
+package snapshot
+
+// +k8s:deepcopy-gen=package
+// +versionName=v1
@@ -0,0 +1,219 @@
+package snapshot
+
+import (
+	"time"
+
+	"golang.org/x/xerrors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+)
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBVolumeSnapshot{}, &MongoDBVolumeSnapshotList{})
+}
+
+type SourceKind string
+
+const (
+	SourceKindMongoDB          SourceKind = "MongoDB"
+	SourceKindMongoDBCommunity SourceKind = "MongoDBCommunity"
+	// SourceKindAppDB snapshots the Application Database of the MongoDBOpsManager resource named in the source.
+	SourceKindAppDB SourceKind = "AppDB"
+)
+
+type Consistency string
+
+const (
+	// ConsistencyFsyncLock flushes the writes of the member and blocks new ones with fsyncLock for as long as the
+	// snapshots of its volumes are being cut. The member is locked as its MongoDB Agent, with the credentials of the
+	// automation config.
+	ConsistencyFsyncLock Consistency = "FsyncLock"
+	// ConsistencyCrashConsistent snapshots the volumes of the member without locking it. The snapshot is restored the
+	// same way as after a crash of the member, which is only safe when the journal is on the data volume.
+	ConsistencyCrashConsistent Consistency = "CrashConsistent"
+)
+
+// The MongoDBVolumeSnapshot resource takes CSI VolumeSnapshots of the data and journal volumes of one member of a
+// replica set. The snapshots are kept until the resource is deleted or spec.retention expires.
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=mdbvs
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current state of the MongoDB volume snapshot."
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.name",description="The name of the resource whose volumes are snapshotted."
+// +kubebuilder:printcolumn:name="Member",type="string",JSONPath=".status.member",description="The pod whose volumes are snapshotted."
+// +kubebuilder:printcolumn:name="Expires At",type="string",JSONPath=".status.expiresAt",description="The time at which the snapshots are deleted."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDB volume snapshot resource was created."
+type MongoDBVolumeSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBVolumeSnapshotSpec `json:"spec"`
+	// +optional
+	Status MongoDBVolumeSnapshotStatus `json:"status"`
+}
+
+// +kubebuilder:object:root=true
+
+// MongoDBVolumeSnapshotList contains a list of MongoDBVolumeSnapshot.
+type MongoDBVolumeSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MongoDBVolumeSnapshot `json:"items"`
+}
+
+type MongoDBVolumeSnapshotSpec struct {
+	// Source is the replica set whose volumes are snapshotted. It must be in the namespace of the resource.
+	Source SnapshotSource `json:"source"`
+
+	// Member is the name of the pod whose volumes are snapshotted. By default, the secondary with the highest ordinal
+	// is used. With the FsyncLock consistency the member must not be the primary; a hidden member is the best choice.
+	// +optional
+	Member string `json:"member,omitempty"`
+
+	// Consistency chooses how the member is kept consistent while its volumes are snapshotted.
+	// +kubebuilder:validation:Enum=FsyncLock;CrashConsistent
+	// +kubebuilder:default:=FsyncLock
+	// +optional
+	Consistency Consistency `json:"consistency,omitempty"`
+
+	// VolumeSnapshotClassName is the VolumeSnapshotClass of the created VolumeSnapshots. The default class of the CSI
+	// driver is used when it is not set.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+
+	// Retention is how long the snapshots are kept once they are ready to use. When it expires the resource is
+	// deleted together with its VolumeSnapshots. The snapshots are kept until the resource is deleted when it is not
+	// set.
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+}
+
+type SnapshotSource struct {
+	// +kubebuilder:validation:Enum=MongoDB;MongoDBCommunity;AppDB
+	Kind SourceKind `json:"kind"`
+	// Name is the name of the MongoDB or MongoDBCommunity resource, or of the MongoDBOpsManager resource for AppDB.
+	Name string `json:"name"`
+}
+
+type MongoDBVolumeSnapshotStatus struct {
+	status.Common `json:",inline"`
+	// Member is the pod whose volumes are snapshotted.
+	Member string `json:"member,omitempty"`
+	// LockedAt is the time at which the member was locked with fsyncLock. It is cleared once the member is unlocked.
+	LockedAt string `json:"lockedAt,omitempty"`
+	// Volumes are the VolumeSnapshots taken of the volumes of the member.
+	Volumes []VolumeSnapshotStatus `json:"volumes,omitempty"`
+	// CompletedAt is the time at which all VolumeSnapshots became ready to use.
+	CompletedAt string `json:"completedAt,omitempty"`
+	// ExpiresAt is the time at which the resource and its VolumeSnapshots are deleted.
+	ExpiresAt string           `json:"expiresAt,omitempty"`
+	Warnings  []status.Warning `json:"warnings,omitempty"`
+}
+
+type VolumeSnapshotStatus struct {
+	// VolumeName is the name of the volume claim template of the StatefulSet, for example "data" or "journal".
+	VolumeName                string `json:"volumeName"`
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+	VolumeSnapshotName        string `json:"volumeSnapshotName"`
+	ReadyToUse                bool   `json:"readyToUse"`
+}
+
+// Validate returns an error if the spec can't be snapshotted.
+func (s *MongoDBVolumeSnapshot) Validate() error {
+	if s.Spec.Source.Name == "" {
+		return xerrors.Errorf("spec.source.name must be set")
+	}
+	if s.Spec.Retention != nil && s.Spec.Retention.Duration <= 0 {
+		return xerrors.Errorf("spec.retention must be positive")
+	}
+	return nil
+}
+
+func (s *MongoDBVolumeSnapshot) GetConsistency() Consistency {
+	if s.Spec.Consistency == "" {
+		return ConsistencyFsyncLock
+	}
+	return s.Spec.Consistency
+}
+
+// IsCompleted returns true once all VolumeSnapshots are ready to use.
+func (s *MongoDBVolumeSnapshot) IsCompleted() bool {
+	return s.Status.CompletedAt != ""
+}
+
+// VolumeSnapshotNames returns the names of the VolumeSnapshots by the volume claim template they were taken of.
+func (s *MongoDBVolumeSnapshot) VolumeSnapshotNames() map[string]string {
+	names := map[string]string{}
+	for _, volume := range s.Status.Volumes {
+		names[volume.VolumeName] = volume.VolumeSnapshotName
+	}
+	return names
+}
+
+// VolumeSnapshotName returns the name of the VolumeSnapshot taken of the given volume claim template.
+func (s *MongoDBVolumeSnapshot) VolumeSnapshotName(volumeName string) string {
+	return s.Name + "-" + volumeName
+}
+
+func (s *MongoDBVolumeSnapshot) UpdateStatus(phase status.Phase, statusOptions ...status.Option) {
+	s.Status.UpdateCommonFields(phase, s.GetGeneration(), statusOptions...)
+	if option, exists := status.GetOption(statusOptions, status.WarningsOption{}); exists {
+		s.Status.Warnings = append(s.Status.Warnings, option.(status.WarningsOption).Warnings...)
+	}
+	if option, exists := status.GetOption(statusOptions, SnapshotProgressOption{}); exists {
+		progress := option.(SnapshotProgressOption)
+		s.Status.Member = progress.Member
+		s.Status.LockedAt = progress.LockedAt
+		s.Status.Volumes = progress.Volumes
+	}
+	if option, exists := status.GetOption(statusOptions, SnapshotCompletedOption{}); exists {
+		completedAt := option.(SnapshotCompletedOption).CompletedAt
+		s.Status.CompletedAt = completedAt.Format(time.RFC3339)
+		if s.Spec.Retention != nil {
+			s.Status.ExpiresAt = completedAt.Add(s.Spec.Retention.Duration).Format(time.RFC3339)
+		}
+	}
+}
+
+func (s *MongoDBVolumeSnapshot) SetWarnings(warnings []status.Warning, _ ...status.Option) {
+	s.Status.Warnings = warnings
+}
+
+func (s *MongoDBVolumeSnapshot) GetCommonStatus(...status.Option) *status.Common {
+	return &s.Status.Common
+}
+
+func (s *MongoDBVolumeSnapshot) GetStatus(...status.Option) interface{} {
+	return s.Status
+}
+
+func (s *MongoDBVolumeSnapshot) GetStatusPath(...status.Option) string {
+	return "/status"
+}
+
+// SnapshotProgressOption records the member, its lock and the VolumeSnapshots taken so far.
+// +kubebuilder:object:generate=false
+type SnapshotProgressOption struct {
+	Member   string
+	LockedAt string
+	Volumes  []VolumeSnapshotStatus
+}
+
+func (o SnapshotProgressOption) Value() interface{} {
+	return o
+}
+
+// SnapshotCompletedOption records the time at which all VolumeSnapshots became ready to use.
+// +kubebuilder:object:generate=false
+type SnapshotCompletedOption struct {
+	CompletedAt time.Time
+}
+
+func (o SnapshotCompletedOption) Value() interface{} {
+	return o.CompletedAt
+}
@@ -0,0 +1,10 @@
+package v1
+
+// RestoreFromSnapshot provisions the volumes of a new StatefulSet from the VolumeSnapshots of a MongoDBVolumeSnapshot.
+// Every member starts from the same snapshot. The snapshot contains the replica set configuration of its source, so
+// the restored resource must keep the name and the replica set name of the source. It only applies when the
+// StatefulSet is created; the volumes of an existing StatefulSet are never replaced.
+type RestoreFromSnapshot struct {
+	// MongoDBVolumeSnapshotName is the name of a completed MongoDBVolumeSnapshot in the namespace of the resource.
+	MongoDBVolumeSnapshotName string `json:"mongodbVolumeSnapshotName"`
+}
@@ -24,6 +24,21 @@ func AppDBMemberOptions(appDBScalers ...interfaces.MultiClusterReplicaSetScaler)
 	return MultiReplicaSetMemberOption{Members: members, ClusterStatusList: clusterStatusList}
 }
 
+// ZoneMembersOption records the members of the StatefulSet of each zone of a replica set with spec.zonePlacement.
+func ZoneMembersOption(zoneScalers ...interfaces.MultiClusterReplicaSetScaler) Option {
+	members := 0
+	zones := []ZoneStatusItem{}
+	for _, scaler := range zoneScalers {
+		members += scale.ReplicasThisReconciliation(scaler)
+		zones = append(zones, ZoneStatusItem{
+			Zone:    scaler.MemberClusterName(),
+			Index:   scaler.MemberClusterNum(),
+			Members: scale.ReplicasThisReconciliation(scaler),
+		})
+	}
+	return ReplicaSetZonesOption{Members: members, Zones: zones}
+}
+
 // ReplicaSetMembersOption is required in order to ensure that the status of a resource
 // is only updated one member at a time. The logic which scales incrementally relies
 // on the current status of the resource to be accurate.
@@ -49,6 +64,23 @@ type ClusterStatusList struct {
 	ClusterStatuses []ClusterStatusItem `json:"clusterStatuses,omitempty"`
 }
 
+// ZoneStatusItem is the state of the StatefulSet of one zone of a replica set with spec.zonePlacement. The zones
+// removed from the spec are kept with their index, so that it isn't given to another zone while their volumes exist.
+type ZoneStatusItem struct {
+	Zone    string `json:"zone"`
+	Index   int    `json:"index"`
+	Members int    `json:"members"`
+}
+
+type ReplicaSetZonesOption struct {
+	Members int
+	Zones   []ZoneStatusItem
+}
+
+func (o ReplicaSetZonesOption) Value() interface{} {
+	return o.Zones
+}
+
 type MultiReplicaSetMemberOption struct {
 	Members           int
 	ClusterStatusList []ClusterStatusItem
@@ -43,3 +43,14 @@ func Test_RefreshingLastTransitionTime(t *testing.T) {
 	// then
 	assert.NotEqual(t, testTime, timeAfterTheTest)
 }
+
+func TestWarnings_RemoveIf(t *testing.T) {
+	warnings := Warnings{}.AddIfNotExists("first").AddIfNotExists("second").AddIfNotExists("third")
+	isThird := func(warning Warning) bool { return warning == "third" }
+	isSecond := func(warning Warning) bool { return warning == "second" }
+
+	assert.Equal(t, Warnings{"first;", "second"}, warnings.RemoveIf(isThird))
+	assert.Equal(t, Warnings{"first;", "third"}, warnings.RemoveIf(isSecond))
+	assert.Equal(t, Warnings{"first;", "second;", "third"}, warnings)
+	assert.Empty(t, Warnings{"third"}.RemoveIf(isThird))
+}
@@ -0,0 +1,39 @@
+package status
+
+// StorageExpansion records the last expansion of a volume of a StatefulSet by storage autoscaling.
+// +kubebuilder:object:generate:=true
+type StorageExpansion struct {
+	StatefulsetName string `json:"statefulsetName"`
+	VolumeName      string `json:"volumeName"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	// UsagePercent is the usage of the fullest volume when the expansion was triggered.
+	UsagePercent int    `json:"usagePercent"`
+	Time         string `json:"time"`
+}
+
+type StorageExpansions []StorageExpansion
+
+// Merge replaces the expansion of the same volume of the same StatefulSet, or adds the expansion.
+func (s StorageExpansions) Merge(expansion StorageExpansion) StorageExpansions {
+	for i := range s {
+		if s[i].StatefulsetName == expansion.StatefulsetName && s[i].VolumeName == expansion.VolumeName {
+			s[i] = expansion
+			return s
+		}
+	}
+	return append(s, expansion)
+}
+
+// StorageExpansionsOption describes the volume expansions triggered by storage autoscaling.
+type StorageExpansionsOption struct {
+	Expansions []StorageExpansion
+}
+
+func NewStorageExpansionsOption(expansions []StorageExpansion) StorageExpansionsOption {
+	return StorageExpansionsOption{Expansions: expansions}
+}
+
+func (o StorageExpansionsOption) Value() interface{} {
+	return o.Expansions
+}
@@ -1,5 +1,7 @@
 package status
 
+import "strings"
+
 type Warning string
 
 type Warnings []Warning
@@ -25,3 +27,20 @@ func (m Warnings) AddIfNotExists(warning Warning) Warnings {
 
 	return append(m, warning)
 }
+
+// RemoveIf returns the warnings without the ones matching the predicate, the warnings are matched without their
+// separator. The receiver is left unchanged.
+func (m Warnings) RemoveIf(matches func(Warning) bool) Warnings {
+	var warnings Warnings
+	for _, warning := range m {
+		trimmed := Warning(strings.TrimSuffix(string(warning), string(SEP)))
+		if !matches(trimmed) {
+			warnings = append(warnings, warning)
+		}
+	}
+	if len(warnings) > 0 {
+		last := len(warnings) - 1
+		warnings[last] = Warning(strings.TrimSuffix(string(warnings[last]), string(SEP)))
+	}
+	return warnings
+}
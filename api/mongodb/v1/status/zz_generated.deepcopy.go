@@ -152,3 +152,18 @@ func (in *ResourceNotReady) DeepCopy() *ResourceNotReady {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageExpansion) DeepCopyInto(out *StorageExpansion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageExpansion.
+func (in *StorageExpansion) DeepCopy() *StorageExpansion {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageExpansion)
+	in.DeepCopyInto(out)
+	return out
+}
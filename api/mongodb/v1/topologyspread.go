@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"golang.org/x/xerrors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const DefaultTopologySpreadMaxSkew = 1
+
+// TopologySpread spreads the Pods of each StatefulSet created by the Operator across zones and nodes with topology
+// spread constraints. The constraints only count the Pods of the same replica set, so every shard is spread on its own.
+type TopologySpread struct {
+	// Zone spreads the Pods across the zones set by the topology.kubernetes.io/zone label of the nodes.
+	// +optional
+	Zone *TopologySpreadConstraint `json:"zone,omitempty"`
+	// Hostname spreads the Pods across the nodes.
+	// +optional
+	Hostname *TopologySpreadConstraint `json:"hostname,omitempty"`
+}
+
+type TopologySpreadConstraint struct {
+	// MaxSkew is the largest allowed difference between the number of Pods in two zones or nodes. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+	// MinDomains is the number of zones or nodes the Pods are spread across at least. When fewer are eligible, the
+	// missing ones count as having no Pods, which keeps the Pods that would break maxSkew pending. It requires
+	// whenUnsatisfiable to be DoNotSchedule.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinDomains *int32 `json:"minDomains,omitempty"`
+	// WhenUnsatisfiable is what the scheduler does with a Pod that would break maxSkew. Defaults to DoNotSchedule.
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	// +optional
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
+
+func (c *TopologySpreadConstraint) GetMaxSkew() int32 {
+	if c.MaxSkew == nil {
+		return DefaultTopologySpreadMaxSkew
+	}
+	return *c.MaxSkew
+}
+
+func (c *TopologySpreadConstraint) GetMinDomains() int32 {
+	if c.MinDomains == nil {
+		return 1
+	}
+	return *c.MinDomains
+}
+
+func (c *TopologySpreadConstraint) GetWhenUnsatisfiable() corev1.UnsatisfiableConstraintAction {
+	if c.WhenUnsatisfiable == "" {
+		return corev1.DoNotSchedule
+	}
+	return c.WhenUnsatisfiable
+}
+
+func (c *TopologySpreadConstraint) build(topologyKey string, podLabels map[string]string) corev1.TopologySpreadConstraint {
+	constraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           c.GetMaxSkew(),
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: c.GetWhenUnsatisfiable(),
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: podLabels},
+	}
+	if c.MinDomains != nil {
+		constraint.MinDomains = c.MinDomains
+	}
+	return constraint
+}
+
+// Constraints returns the topology spread constraints of the Pods selected by podLabels.
+func (t *TopologySpread) Constraints(podLabels map[string]string) []corev1.TopologySpreadConstraint {
+	if t == nil {
+		return nil
+	}
+	var constraints []corev1.TopologySpreadConstraint
+	if t.Zone != nil {
+		constraints = append(constraints, t.Zone.build(corev1.LabelTopologyZone, podLabels))
+	}
+	if t.Hostname != nil {
+		constraints = append(constraints, t.Hostname.build(corev1.LabelHostname, podLabels))
+	}
+	return constraints
+}
+
+// Validate returns an error if a constraint sets minDomains without DoNotSchedule, which Kubernetes rejects.
+func (t *TopologySpread) Validate(field string) error {
+	if t == nil {
+		return nil
+	}
+	if err := t.Zone.validate(field + ".zone"); err != nil {
+		return err
+	}
+	return t.Hostname.validate(field + ".hostname")
+}
+
+func (c *TopologySpreadConstraint) validate(field string) error {
+	if c != nil && c.MinDomains != nil && c.GetWhenUnsatisfiable() != corev1.DoNotSchedule {
+		return xerrors.Errorf("%s.minDomains can only be set if whenUnsatisfiable is DoNotSchedule", field)
+	}
+	return nil
+}
+
+// IsBestEffort returns true if a constraint lets the scheduler break maxSkew when it can't be satisfied.
+func (t *TopologySpread) IsBestEffort() bool {
+	if t == nil {
+		return false
+	}
+	return (t.Zone != nil && t.Zone.GetWhenUnsatisfiable() == corev1.ScheduleAnyway) ||
+		(t.Hostname != nil && t.Hostname.GetWhenUnsatisfiable() == corev1.ScheduleAnyway)
+}
+
+// MaxMembersPerZone returns how many of the members the zone constraint lets the scheduler put in a single zone.
+func (t *TopologySpread) MaxMembersPerZone(members int) int {
+	if t == nil || t.Zone == nil || t.Zone.GetWhenUnsatisfiable() != corev1.DoNotSchedule {
+		return members
+	}
+	return maxMembersPerZone(members, int(t.Zone.GetMaxSkew()), int(t.Zone.GetMinDomains()))
+}
+
+// maxMembersPerZone is the size of the largest zone when the members are spread across as few zones as allowed: the
+// skew is the difference between the largest and the smallest zone, so fewer zones leave more members in the largest.
+func maxMembersPerZone(members, maxSkew, zones int) int {
+	return min(members, (members+maxSkew*(zones-1))/zones)
+}
+
+// ValidateMembers returns an error if the zone constraint lets the scheduler put a majority of the members of a
+// replica set in a single zone, so that losing the zone would leave the replica set without a primary.
+func (t *TopologySpread) ValidateMembers(field string, members int) error {
+	if t == nil || t.Zone == nil || t.Zone.GetWhenUnsatisfiable() != corev1.DoNotSchedule || members < 2 {
+		return nil
+	}
+	majority := members/2 + 1
+	if t.MaxMembersPerZone(members) < majority {
+		return nil
+	}
+
+	maxSkew := int(t.Zone.GetMaxSkew())
+	for zones := int(t.Zone.GetMinDomains()) + 1; zones <= members; zones++ {
+		if maxMembersPerZone(members, maxSkew, zones) < majority {
+			return xerrors.Errorf("%s.zone allows %d of the %d members in a single zone, which is a majority: set %s.zone.minDomains to at least %d", field, t.MaxMembersPerZone(members), members, field, zones)
+		}
+	}
+	return xerrors.Errorf("%s.zone allows a majority of the %d members in a single zone: lower %s.zone.maxSkew", field, members, field)
+}
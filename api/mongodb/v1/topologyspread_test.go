@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTopologySpread_Constraints(t *testing.T) {
+	spread := &TopologySpread{
+		Zone:     &TopologySpreadConstraint{MinDomains: ptr.To(int32(3))},
+		Hostname: &TopologySpreadConstraint{MaxSkew: ptr.To(int32(2)), WhenUnsatisfiable: corev1.ScheduleAnyway},
+	}
+	constraints := spread.Constraints(map[string]string{"app": "my-rs"})
+
+	assert.Len(t, constraints, 2)
+	assert.Equal(t, corev1.LabelTopologyZone, constraints[0].TopologyKey)
+	assert.Equal(t, int32(1), constraints[0].MaxSkew)
+	assert.Equal(t, ptr.To(int32(3)), constraints[0].MinDomains)
+	assert.Equal(t, corev1.DoNotSchedule, constraints[0].WhenUnsatisfiable)
+	assert.Equal(t, map[string]string{"app": "my-rs"}, constraints[0].LabelSelector.MatchLabels)
+
+	assert.Equal(t, corev1.LabelHostname, constraints[1].TopologyKey)
+	assert.Equal(t, int32(2), constraints[1].MaxSkew)
+	assert.Nil(t, constraints[1].MinDomains)
+	assert.Equal(t, corev1.ScheduleAnyway, constraints[1].WhenUnsatisfiable)
+
+	assert.Nil(t, (*TopologySpread)(nil).Constraints(map[string]string{"app": "my-rs"}))
+}
+
+func TestTopologySpread_ValidateMembers(t *testing.T) {
+	tests := []struct {
+		name          string
+		zone          *TopologySpreadConstraint
+		members       int
+		expectedError string
+	}{
+		{
+			name:          "Two zones can hold two of three members",
+			zone:          &TopologySpreadConstraint{},
+			members:       3,
+			expectedError: "spec.topologySpread.zone allows 3 of the 3 members in a single zone, which is a majority: set spec.topologySpread.zone.minDomains to at least 3",
+		},
+		{
+			name:    "Three zones hold one member each",
+			zone:    &TopologySpreadConstraint{MinDomains: ptr.To(int32(3))},
+			members: 3,
+		},
+		{
+			name:          "A max skew of two lets a zone hold three of five members",
+			zone:          &TopologySpreadConstraint{MaxSkew: ptr.To(int32(2)), MinDomains: ptr.To(int32(3))},
+			members:       5,
+			expectedError: "spec.topologySpread.zone allows 3 of the 5 members in a single zone, which is a majority: set spec.topologySpread.zone.minDomains to at least 4",
+		},
+		{
+			name:          "No number of zones is enough with a large max skew",
+			zone:          &TopologySpreadConstraint{MaxSkew: ptr.To(int32(5))},
+			members:       3,
+			expectedError: "spec.topologySpread.zone allows a majority of the 3 members in a single zone: lower spec.topologySpread.zone.maxSkew",
+		},
+		{
+			name:    "A single member can't be spread",
+			zone:    &TopologySpreadConstraint{},
+			members: 1,
+		},
+		{
+			name:    "ScheduleAnyway is not checked",
+			zone:    &TopologySpreadConstraint{WhenUnsatisfiable: corev1.ScheduleAnyway},
+			members: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&TopologySpread{Zone: tt.zone}).ValidateMembers("spec.topologySpread", tt.members)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestTopologySpread_Validate(t *testing.T) {
+	spread := &TopologySpread{Hostname: &TopologySpreadConstraint{MinDomains: ptr.To(int32(2)), WhenUnsatisfiable: corev1.ScheduleAnyway}}
+	assert.EqualError(t, spread.Validate("spec.topologySpread"), "spec.topologySpread.hostname.minDomains can only be set if whenUnsatisfiable is DoNotSchedule")
+
+	spread.Hostname.WhenUnsatisfiable = corev1.DoNotSchedule
+	assert.NoError(t, spread.Validate("spec.topologySpread"))
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
@@ -64,7 +66,7 @@ func (u MongoDBUser) GetPassword(ctx context.Context, secretClient secrets.Secre
 		return "", xerrors.Errorf("could not retrieve user password secret: %w", err)
 	}
 
-	passwordBytes, passwordIsSet := secretData[u.Spec.PasswordSecretKeyRef.Key]
+	passwordBytes, passwordIsSet := secretData[u.GetPasswordSecretKey()]
 	if !passwordIsSet {
 		return "", xerrors.Errorf("passwordSecretKeyRef.key is not set in password secret %v", nsName)
 	}
@@ -95,6 +97,133 @@ type MongoDBUserSpec struct {
 	PasswordSecretKeyRef SecretKeyRef `json:"passwordSecretKeyRef"`
 	// +optional
 	ConnectionStringSecretName string `json:"connectionStringSecretName"`
+	// PasswordPolicy makes the Operator generate and rotate the password stored in the Secret referenced by
+	// passwordSecretKeyRef.
+	// +optional
+	PasswordPolicy *PasswordPolicy `json:"passwordPolicy,omitempty"`
+	// X509 configures the client certificate of an X.509 user. Can only be specified for users in the $external
+	// database.
+	// +optional
+	X509 *X509 `json:"x509,omitempty"`
+}
+
+type X509 struct {
+	// Issue makes the Operator issue and renew the client certificate of the user, with username as its subject.
+	// +optional
+	Issue *X509Issue `json:"issue,omitempty"`
+}
+
+// X509Issue configures how the client certificate of the user is issued. Exactly one of caSecretRef and issuerRef
+// has to be specified. The certificate, its private key and the CA certificate are stored in the tls.crt, tls.key
+// and ca.crt keys of the certificate Secret.
+type X509Issue struct {
+	// CASecretRef references a Secret with the certificate (tls.crt) and the private key (tls.key) of the CA the
+	// Operator signs the client certificate with.
+	// +optional
+	CASecretRef *corev1.LocalObjectReference `json:"caSecretRef,omitempty"`
+	// IssuerRef references the cert-manager issuer the client certificate is requested from.
+	// +optional
+	IssuerRef *v1.CertManagerIssuerRef `json:"issuerRef,omitempty"`
+	// SecretName is the name of the Secret the certificate is stored in. Defaults to <name>-x509-cert.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// Duration is how long the certificate is valid for. Defaults to 2160h (90 days).
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// RenewBefore is how long before its expiry the certificate is renewed. Defaults to a third of the duration.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+const (
+	DefaultX509CertificateDuration = 90 * 24 * time.Hour
+	x509CertificateSecretSuffix    = "-x509-cert"
+)
+
+func (x *X509) IsIssueEnabled() bool {
+	return x != nil && x.Issue != nil
+}
+
+func (i *X509Issue) GetDuration() time.Duration {
+	if i == nil || i.Duration == nil {
+		return DefaultX509CertificateDuration
+	}
+	return i.Duration.Duration
+}
+
+func (i *X509Issue) GetRenewBefore() time.Duration {
+	if i == nil || i.RenewBefore == nil {
+		return i.GetDuration() / 3
+	}
+	return i.RenewBefore.Duration
+}
+
+type PasswordCharset string
+
+const (
+	PasswordCharsetAlphanumeric            PasswordCharset = "Alphanumeric"
+	PasswordCharsetAlphanumericWithSymbols PasswordCharset = "AlphanumericWithSymbols"
+
+	DefaultPasswordLength = 32
+	// DefaultPasswordRotationGracePeriod is how long the previous password keeps working after a rotation
+	DefaultPasswordRotationGracePeriod = time.Hour
+	// DefaultPasswordSecretKey is the key the generated password is stored under if passwordSecretKeyRef.key is not set
+	DefaultPasswordSecretKey = "password"
+	// shadowUserSuffix is appended to the username to get the name of the user the password is rotated to
+	shadowUserSuffix = "-shadow"
+)
+
+type PasswordPolicy struct {
+	// Generate makes the Operator generate the password if the Secret referenced by passwordSecretKeyRef, or the key in
+	// it, doesn't exist.
+	// +optional
+	Generate bool `json:"generate,omitempty"`
+	// Length of the generated passwords. Defaults to 32.
+	// +kubebuilder:validation:Minimum=12
+	// +kubebuilder:validation:Maximum=256
+	// +optional
+	Length int `json:"length,omitempty"`
+	// Charset is the set of characters the generated passwords consist of. Defaults to Alphanumeric.
+	// +kubebuilder:validation:Enum=Alphanumeric;AlphanumericWithSymbols
+	// +optional
+	Charset PasswordCharset `json:"charset,omitempty"`
+	// RotationInterval is how often the Operator generates a new password, for example "720h". The password is
+	// rotated to a shadow user having the same roles, so the previous password keeps working for the grace period.
+	// Requires generate to be enabled.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+	// GracePeriod is how long the previous password keeps working after a rotation. Defaults to 1h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+func (p *PasswordPolicy) ShouldGenerate() bool {
+	return p != nil && p.Generate
+}
+
+func (p *PasswordPolicy) IsRotationEnabled() bool {
+	return p.ShouldGenerate() && p.RotationInterval != nil && p.RotationInterval.Duration > 0
+}
+
+func (p *PasswordPolicy) GetLength() int {
+	if p == nil || p.Length == 0 {
+		return DefaultPasswordLength
+	}
+	return p.Length
+}
+
+func (p *PasswordPolicy) GetCharset() PasswordCharset {
+	if p == nil || p.Charset == "" {
+		return PasswordCharsetAlphanumeric
+	}
+	return p.Charset
+}
+
+func (p *PasswordPolicy) GetGracePeriod() time.Duration {
+	if p == nil || p.GracePeriod == nil {
+		return DefaultPasswordRotationGracePeriod
+	}
+	return p.GracePeriod.Duration
 }
 
 type MongoDBUserStatus struct {
@@ -105,6 +234,39 @@ type MongoDBUserStatus struct {
 	Project       string           `json:"project"`
 	ProjectId     string           `json:"projectId,omitempty"`
 	Warnings      []status.Warning `json:"warnings,omitempty"`
+	// LastRotated is the time the password was last generated by the Operator.
+	// +optional
+	LastRotated string `json:"lastRotated,omitempty"`
+	// PasswordRotation describes the password rotation in progress.
+	// +optional
+	PasswordRotation *PasswordRotationStatus `json:"passwordRotation,omitempty"`
+	// Certificate describes the client certificate issued by the Operator.
+	// +optional
+	Certificate *CertificateStatus `json:"certificate,omitempty"`
+}
+
+// CertificateStatus describes the client certificate issued for an X.509 user.
+type CertificateStatus struct {
+	// SecretName is the name of the Secret the certificate is stored in.
+	SecretName string `json:"secretName"`
+	// Subject of the certificate, which is the name of the user in the $external database.
+	Subject string `json:"subject"`
+	// NotAfter is the time the certificate expires.
+	NotAfter string `json:"notAfter"`
+}
+
+// PasswordRotationStatus keeps track of the users involved in a password rotation. The password alternates between
+// the user and its shadow user: the new password is set for the user that is not in use, the connection string Secret
+// is updated to point at it and the previous user is removed once the grace period has passed.
+type PasswordRotationStatus struct {
+	// ActiveUsername is the name of the user the connection string Secret points at.
+	ActiveUsername string `json:"activeUsername"`
+	// RetiringUsername is the name of the user holding the previous password.
+	// +optional
+	RetiringUsername string `json:"retiringUsername,omitempty"`
+	// RetireAfter is the time after which the retiring user is removed.
+	// +optional
+	RetireAfter string `json:"retireAfter,omitempty"`
 }
 
 type Role struct {
@@ -147,6 +309,44 @@ func (u *MongoDBUser) UpdateStatus(phase status.Phase, statusOptions ...status.O
 	}
 }
 
+// ShadowUsername returns the name of the user the password is rotated to, when the user with the spec.username name
+// holds the previous password.
+func (u MongoDBUser) ShadowUsername() string {
+	return u.Spec.Username + shadowUserSuffix
+}
+
+// PreviousUsernames returns the names of the users created for the username recorded in the status, which have to
+// be removed when the username or the database changes.
+func (u MongoDBUser) PreviousUsernames() []string {
+	return []string{u.Status.Username, u.Status.Username + shadowUserSuffix}
+}
+
+// ActiveUsername returns the name of the user the connection string Secret points at. It's spec.username unless the
+// password has been rotated to the shadow user.
+func (u MongoDBUser) ActiveUsername() string {
+	if u.Status.PasswordRotation != nil && u.Status.PasswordRotation.ActiveUsername == u.ShadowUsername() {
+		return u.ShadowUsername()
+	}
+	return u.Spec.Username
+}
+
+// GetX509CertificateSecretName returns the name of the Secret the client certificate issued by the Operator is
+// stored in.
+func (u MongoDBUser) GetX509CertificateSecretName() string {
+	if u.Spec.X509.IsIssueEnabled() && u.Spec.X509.Issue.SecretName != "" {
+		return u.Spec.X509.Issue.SecretName
+	}
+	return u.Name + x509CertificateSecretSuffix
+}
+
+// GetPasswordSecretKey returns the key the password is stored under in the password Secret.
+func (u MongoDBUser) GetPasswordSecretKey() string {
+	if u.Spec.PasswordSecretKeyRef.Key == "" && u.Spec.PasswordPolicy.ShouldGenerate() {
+		return DefaultPasswordSecretKey
+	}
+	return u.Spec.PasswordSecretKeyRef.Key
+}
+
 func (u MongoDBUser) GetConnectionStringSecretName() string {
 	if u.Spec.ConnectionStringSecretName != "" {
 		return u.Spec.ConnectionStringSecretName
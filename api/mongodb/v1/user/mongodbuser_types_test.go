@@ -54,3 +54,27 @@ func TestMongoDBUser_UpdateStatus_DoesNotSetProjectIdWhenOptionAbsent(t *testing
 	u.UpdateStatus(status.PhaseRunning)
 	assert.Equal(t, "existing-id", u.Status.ProjectId)
 }
+
+func TestMongoDBUser_ActiveUsername(t *testing.T) {
+	u := MongoDBUser{Spec: MongoDBUserSpec{Username: "my-user"}}
+	assert.Equal(t, "my-user", u.ActiveUsername())
+	assert.Equal(t, "my-user-shadow", u.ShadowUsername())
+
+	u.Status.PasswordRotation = &PasswordRotationStatus{ActiveUsername: "my-user-shadow", RetiringUsername: "my-user"}
+	assert.Equal(t, "my-user-shadow", u.ActiveUsername())
+
+	// the rotation was done for a previous username
+	u.Spec.Username = "new-user"
+	assert.Equal(t, "new-user", u.ActiveUsername())
+}
+
+func TestMongoDBUser_GetPasswordSecretKey(t *testing.T) {
+	u := MongoDBUser{Spec: MongoDBUserSpec{PasswordSecretKeyRef: SecretKeyRef{Name: "my-secret"}}}
+	assert.Equal(t, "", u.GetPasswordSecretKey())
+
+	u.Spec.PasswordPolicy = &PasswordPolicy{Generate: true}
+	assert.Equal(t, DefaultPasswordSecretKey, u.GetPasswordSecretKey())
+
+	u.Spec.PasswordSecretKeyRef.Key = "pwd"
+	assert.Equal(t, "pwd", u.GetPasswordSecretKey())
+}
@@ -0,0 +1,26 @@
+package v1
+
+const DefaultVaultPKIMount = "pki"
+
+// VaultPKIConfig makes the Operator request the TLS certificates of a resource from a role of the Vault PKI secrets
+// engine, instead of expecting them to be written to Vault beforehand. It requires the Vault secret backend. The
+// certificates are valid for all the hostnames of the resource and are reissued before they expire.
+type VaultPKIConfig struct {
+	// Mount is the path the PKI secrets engine is mounted at. Defaults to pki.
+	// +optional
+	Mount string `json:"mount,omitempty"`
+	// Role is the name of the PKI role issuing the certificates. The role has to allow the hostnames of the resource
+	// and issue certificates usable for both server and client authentication.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// TTL of the issued certificates, for example 720h. Defaults to the TTL of the role.
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+}
+
+func (c VaultPKIConfig) GetMount() string {
+	if c.Mount == "" {
+		return DefaultVaultPKIMount
+	}
+	return c.Mount
+}
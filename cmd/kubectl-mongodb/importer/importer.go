@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kubectl-mongodb/importer"
+)
+
+type flags struct {
+	baseURL                    string
+	projectID                  string
+	publicKey                  string
+	privateKey                 string
+	allowInvalidSSLCertificate bool
+	output                     string
+	importer.Options
+}
+
+var importFlags = flags{}
+
+func init() {
+	ImportCmd.Flags().StringVar(&importFlags.baseURL, "ops-manager-url", "", "Base URL of Ops Manager. [required]")
+	ImportCmd.Flags().StringVar(&importFlags.projectID, "project-id", "", "ID of the Ops Manager project of the replica set. [required]")
+	ImportCmd.Flags().StringVar(&importFlags.publicKey, "public-key", "", "Public key of the Ops Manager API key. [required]")
+	ImportCmd.Flags().StringVar(&importFlags.privateKey, "private-key", "", "Private key of the Ops Manager API key. [required]")
+	ImportCmd.Flags().BoolVar(&importFlags.allowInvalidSSLCertificate, "allow-invalid-ssl-certificate", false, "Don't validate the TLS certificate of Ops Manager. [optional default: false]")
+	ImportCmd.Flags().StringVar(&importFlags.ReplicaSetName, "replica-set", "", "Name of the replica set to import, it is used as the name of the MongoDB resource. [required]")
+	ImportCmd.Flags().StringVar(&importFlags.Namespace, "namespace", "", "Namespace of the MongoDB resource. [required]")
+	ImportCmd.Flags().IntVar(&importFlags.Members, "members", 0, "Number of Kubernetes members. [optional, default: the number of members of the replica set]")
+	ImportCmd.Flags().StringVar(&importFlags.ProjectConfigMap, "project-config-map", "", "Name of the ConfigMap with the Ops Manager project configuration. [required]")
+	ImportCmd.Flags().StringVar(&importFlags.Credentials, "credentials", "", "Name of the Secret with the Ops Manager API key. [required]")
+	ImportCmd.Flags().StringVar(&importFlags.CertsSecretPrefix, "certs-secret-prefix", "", "Prefix of the Secrets with the TLS certificates of the members. [required if the replica set has TLS enabled]")
+	ImportCmd.Flags().StringVar(&importFlags.CAConfigMap, "ca-config-map", "", "Name of the ConfigMap with the CA of the TLS certificates. [optional]")
+	ImportCmd.Flags().BoolVar(&importFlags.LiveMigration, "live-migration", false, "Keep the existing members in the replica set while the Kubernetes members join it. [optional default: false]")
+	ImportCmd.Flags().StringVar(&importFlags.output, "output", "", "File the MongoDB resource is written to. [optional, default: stdout]")
+}
+
+// ImportCmd represents the import command
+var ImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Generate the MongoDB resource of a replica set deployed by Ops Manager",
+	Long: `'import' reads the automation config of an Ops Manager project and generates the MongoDB resource matching
+one of its replica sets: version, feature compatibility version, mongod options, member options, authentication, TLS
+and custom roles.
+
+With --live-migration the resource is annotated with the existing members of the replica set. The Kubernetes members
+join the replica set next to them, once they are in sync remove the "mongodb.com/v1.migrationExternalMembers" annotation
+to remove the existing members from the replica set.
+
+Example:
+
+kubectl-mongodb import --ops-manager-url="https://om.example.com:8443" --project-id="5f1a..." --public-key="abcdefgh" --private-key="..." --replica-set="rs0" --namespace="mongodb" --project-config-map="my-project" --credentials="my-credentials" --certs-secret-prefix="mdb" --live-migration --output="rs0.yaml"
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := parseImportFlags(); err != nil {
+			fmt.Printf("error parsing flags: %s\n", err)
+			os.Exit(1)
+		}
+
+		conn := om.NewOpsManagerConnection(&om.OMContext{
+			BaseURL:                    importFlags.baseURL,
+			GroupID:                    importFlags.projectID,
+			PublicKey:                  importFlags.publicKey,
+			PrivateKey:                 importFlags.privateKey,
+			AllowInvalidSSLCertificate: importFlags.allowInvalidSSLCertificate,
+		})
+		deployment, err := conn.ReadDeployment()
+		if err != nil {
+			fmt.Printf("failed to read the deployment of project %s: %s\n", importFlags.projectID, err)
+			os.Exit(1)
+		}
+
+		mdb, warnings, err := importer.ReplicaSetToMongoDB(deployment, importFlags.Options)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+		}
+
+		bytes, err := importer.ToYAML(mdb)
+		if err != nil {
+			fmt.Printf("failed to serialize the MongoDB resource: %s\n", err)
+			os.Exit(1)
+		}
+		if importFlags.output == "" {
+			fmt.Print(string(bytes))
+			return
+		}
+		if err := os.WriteFile(importFlags.output, bytes, 0o600); err != nil {
+			fmt.Printf("failed to write the MongoDB resource to %s: %s\n", importFlags.output, err)
+			os.Exit(1)
+		}
+	},
+}
+
+func parseImportFlags() error {
+	if slices.Contains([]string{importFlags.baseURL, importFlags.projectID, importFlags.publicKey, importFlags.privateKey, importFlags.ReplicaSetName, importFlags.Namespace, importFlags.ProjectConfigMap, importFlags.Credentials}, "") {
+		return xerrors.Errorf("non empty values are required for [ops-manager-url, project-id, public-key, private-key, replica-set, namespace, project-config-map, credentials]")
+	}
+	if importFlags.Members < 0 {
+		return xerrors.Errorf("members can't be negative")
+	}
+	return nil
+}
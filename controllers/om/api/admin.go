@@ -63,6 +63,34 @@ type S3StoreBlockStoreAdmin interface {
 	DeleteS3Config(id string) error
 }
 
+type GCSStoreBlockStoreAdmin interface {
+	// CreateGCSConfig creates the given GCSConfig
+	CreateGCSConfig(gcsConfig backup.GCSConfig) error
+
+	// UpdateGCSConfig updates the given GCSConfig
+	UpdateGCSConfig(gcsConfig backup.GCSConfig) error
+
+	// ReadGCSConfigs returns a list of all GCSConfigs
+	ReadGCSConfigs() ([]backup.GCSConfig, error)
+
+	// DeleteGCSConfig removes a GCSConfig by id
+	DeleteGCSConfig(id string) error
+}
+
+type AzureBlobStoreBlockStoreAdmin interface {
+	// CreateAzureBlobConfig creates the given AzureBlobConfig
+	CreateAzureBlobConfig(azureConfig backup.AzureBlobConfig) error
+
+	// UpdateAzureBlobConfig updates the given AzureBlobConfig
+	UpdateAzureBlobConfig(azureConfig backup.AzureBlobConfig) error
+
+	// ReadAzureBlobConfigs returns a list of all AzureBlobConfigs
+	ReadAzureBlobConfigs() ([]backup.AzureBlobConfig, error)
+
+	// DeleteAzureBlobConfig removes an AzureBlobConfig by id
+	DeleteAzureBlobConfig(id string) error
+}
+
 type BlockStoreAdmin interface {
 	// ReadBlockStoreConfigs returns all Block stores registered in Ops Manager
 	ReadBlockStoreConfigs() ([]backup.DataStoreConfig, error)
@@ -97,6 +125,8 @@ type OplogStoreAdmin interface {
 type OpsManagerAdmin interface {
 	S3OplogStoreAdmin
 	S3StoreBlockStoreAdmin
+	GCSStoreBlockStoreAdmin
+	AzureBlobStoreBlockStoreAdmin
 	BlockStoreAdmin
 	OplogStoreAdmin
 	// ReadDaemonConfig returns the daemon config by hostname and head db path
@@ -297,6 +327,62 @@ func (a *DefaultOmAdmin) DeleteS3Config(id string) error {
 	return a.delete("admin/backup/snapshot/s3Configs/%s", id)
 }
 
+// GCS related methods
+func (a *DefaultOmAdmin) CreateGCSConfig(gcsConfig backup.GCSConfig) error {
+	_, _, err := a.post("admin/backup/snapshot/gcsConfigs", gcsConfig)
+	return err
+}
+
+func (a *DefaultOmAdmin) UpdateGCSConfig(gcsConfig backup.GCSConfig) error {
+	_, _, err := a.put("admin/backup/snapshot/gcsConfigs/%s", gcsConfig, gcsConfig.Id)
+	return err
+}
+
+func (a *DefaultOmAdmin) ReadGCSConfigs() ([]backup.GCSConfig, error) {
+	res, _, err := a.get("admin/backup/snapshot/gcsConfigs")
+	if err != nil {
+		return nil, apierror.New(err)
+	}
+	gcsConfigResponse := &backup.GCSConfigResponse{}
+	if err = json.Unmarshal(res, gcsConfigResponse); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return gcsConfigResponse.GCSConfigs, nil
+}
+
+func (a *DefaultOmAdmin) DeleteGCSConfig(id string) error {
+	return a.delete("admin/backup/snapshot/gcsConfigs/%s", id)
+}
+
+// Azure Blob related methods
+func (a *DefaultOmAdmin) CreateAzureBlobConfig(azureConfig backup.AzureBlobConfig) error {
+	_, _, err := a.post("admin/backup/snapshot/azureBlobConfigs", azureConfig)
+	return err
+}
+
+func (a *DefaultOmAdmin) UpdateAzureBlobConfig(azureConfig backup.AzureBlobConfig) error {
+	_, _, err := a.put("admin/backup/snapshot/azureBlobConfigs/%s", azureConfig, azureConfig.Id)
+	return err
+}
+
+func (a *DefaultOmAdmin) ReadAzureBlobConfigs() ([]backup.AzureBlobConfig, error) {
+	res, _, err := a.get("admin/backup/snapshot/azureBlobConfigs")
+	if err != nil {
+		return nil, apierror.New(err)
+	}
+	azureConfigResponse := &backup.AzureBlobConfigResponse{}
+	if err = json.Unmarshal(res, azureConfigResponse); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return azureConfigResponse.AzureBlobConfigs, nil
+}
+
+func (a *DefaultOmAdmin) DeleteAzureBlobConfig(id string) error {
+	return a.delete("admin/backup/snapshot/azureBlobConfigs/%s", id)
+}
+
 func (a *DefaultOmAdmin) ReadFileSystemStoreConfigs() ([]backup.DataStoreConfig, error) {
 	res, _, err := a.get("admin/backup/snapshot/fileSystemConfigs/")
 	if err != nil {
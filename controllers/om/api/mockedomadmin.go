@@ -30,6 +30,8 @@ type MockedOmAdmin struct {
 	daemonConfigs          []backup.DaemonConfig
 	s3Configs              map[string]backup.S3Config
 	s3OpLogConfigs         map[string]backup.S3Config
+	gcsConfigs             map[string]backup.GCSConfig
+	azureBlobConfigs       map[string]backup.AzureBlobConfig
 	oplogConfigs           map[string]backup.DataStoreConfig
 	blockStoreConfigs      map[string]backup.DataStoreConfig
 	fileSystemStoreConfigs map[string]backup.DataStoreConfig
@@ -58,6 +60,8 @@ func NewMockedAdminProvider(baseUrl, publicApiKey, privateApiKey string, withSin
 	mockedAdmin.daemonConfigs = make([]backup.DaemonConfig, 0)
 	mockedAdmin.s3Configs = make(map[string]backup.S3Config)
 	mockedAdmin.s3OpLogConfigs = make(map[string]backup.S3Config)
+	mockedAdmin.gcsConfigs = make(map[string]backup.GCSConfig)
+	mockedAdmin.azureBlobConfigs = make(map[string]backup.AzureBlobConfig)
 	mockedAdmin.oplogConfigs = make(map[string]backup.DataStoreConfig)
 	mockedAdmin.blockStoreConfigs = make(map[string]backup.DataStoreConfig)
 	mockedAdmin.apiKeys = []Key{{
@@ -133,6 +137,66 @@ func (a *MockedOmAdmin) UpdateS3Config(s3Config backup.S3Config) error {
 	return a.CreateS3Config(s3Config)
 }
 
+func (a *MockedOmAdmin) ReadGCSConfigs() ([]backup.GCSConfig, error) {
+	allConfigs := make([]backup.GCSConfig, 0)
+	for _, v := range a.gcsConfigs {
+		allConfigs = append(allConfigs, v)
+	}
+
+	sort.SliceStable(allConfigs, func(i, j int) bool {
+		return allConfigs[i].Id < allConfigs[j].Id
+	})
+
+	return allConfigs, nil
+}
+
+func (a *MockedOmAdmin) DeleteGCSConfig(id string) error {
+	if _, ok := a.gcsConfigs[id]; !ok {
+		return errors.New("failed to remove as the gcs config doesn't exist")
+	}
+	delete(a.gcsConfigs, id)
+	return nil
+}
+
+func (a *MockedOmAdmin) CreateGCSConfig(gcsConfig backup.GCSConfig) error {
+	a.gcsConfigs[gcsConfig.Id] = gcsConfig
+	return nil
+}
+
+func (a *MockedOmAdmin) UpdateGCSConfig(gcsConfig backup.GCSConfig) error {
+	return a.CreateGCSConfig(gcsConfig)
+}
+
+func (a *MockedOmAdmin) ReadAzureBlobConfigs() ([]backup.AzureBlobConfig, error) {
+	allConfigs := make([]backup.AzureBlobConfig, 0)
+	for _, v := range a.azureBlobConfigs {
+		allConfigs = append(allConfigs, v)
+	}
+
+	sort.SliceStable(allConfigs, func(i, j int) bool {
+		return allConfigs[i].Id < allConfigs[j].Id
+	})
+
+	return allConfigs, nil
+}
+
+func (a *MockedOmAdmin) DeleteAzureBlobConfig(id string) error {
+	if _, ok := a.azureBlobConfigs[id]; !ok {
+		return errors.New("failed to remove as the azure blob config doesn't exist")
+	}
+	delete(a.azureBlobConfigs, id)
+	return nil
+}
+
+func (a *MockedOmAdmin) CreateAzureBlobConfig(azureConfig backup.AzureBlobConfig) error {
+	a.azureBlobConfigs[azureConfig.Id] = azureConfig
+	return nil
+}
+
+func (a *MockedOmAdmin) UpdateAzureBlobConfig(azureConfig backup.AzureBlobConfig) error {
+	return a.CreateAzureBlobConfig(azureConfig)
+}
+
 func (a *MockedOmAdmin) ReadOplogStoreConfigs() ([]backup.DataStoreConfig, error) {
 	allConfigs := make([]backup.DataStoreConfig, 0)
 	for _, v := range a.oplogConfigs {
@@ -0,0 +1,91 @@
+package backup
+
+import (
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+)
+
+type azureAuthMethod string
+
+const (
+	AzureSharedKey        azureAuthMethod = "SHARED_KEY"
+	AzureWorkloadIdentity azureAuthMethod = "WORKLOAD_IDENTITY"
+)
+
+type AzureBlobConfigResponse struct {
+	AzureBlobConfigs []AzureBlobConfig `json:"results"`
+}
+
+// AzureBlobConfig is the Ops Manager representation of an Azure Blob Storage snapshot store
+type AzureBlobConfig struct {
+	// Unique name that labels this Azure Blob Snapshot Store.
+	Id string `json:"id"`
+
+	// Name of the storage account that hosts the container.
+	StorageAccountName string `json:"storageAccountName"`
+
+	// Name of the container that hosts the snapshot store.
+	ContainerName string `json:"containerName"`
+
+	// Custom blob service endpoint. Empty for the default https://<storageAccountName>.blob.core.windows.net endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Method used to authorize access to the container: either the storage account key or AKS Workload Identity.
+	AuthMethod string `json:"authMethod"`
+
+	// Storage account key that can access the container specified in containerName.
+	AccountKey string `json:"accountKey,omitempty"`
+
+	// Flag indicating whether you can assign backup jobs to this data store.
+	AssignmentEnabled bool `json:"assignmentEnabled"`
+
+	// Comma-separated list of hosts in the <hostname:port> format that can access the metadata database.
+	Uri string `json:"uri"`
+
+	// Fields the operator will not configure. All of these can be changed via the UI and the operator
+	// will not reset their values on reconciliation
+	EncryptedCredentials bool     `json:"encryptedCredentials"`
+	Labels               []string `json:"labels"`
+	LoadFactor           int      `json:"loadFactor,omitempty"`
+	WriteConcern         string   `json:"writeConcern,omitempty"`
+}
+
+// NewAzureBlobConfig builds the Ops Manager Azure Blob snapshot store config. The account key is nil if Workload
+// Identity is used.
+func NewAzureBlobConfig(azureConfig omv1.AzureBlobConfig, uri string, accountKey *string) AzureBlobConfig {
+	config := AzureBlobConfig{
+		Id:                   azureConfig.Name,
+		StorageAccountName:   azureConfig.StorageAccountName,
+		ContainerName:        azureConfig.ContainerName,
+		Endpoint:             azureConfig.Endpoint,
+		AuthMethod:           string(AzureWorkloadIdentity),
+		AssignmentEnabled:    true, // defaults to true. This will not be overridden on merge, so it can be manually disabled in UI.
+		Uri:                  uri,
+		Labels:               azureConfig.AssignmentLabels,
+		EncryptedCredentials: false,
+	}
+
+	if accountKey != nil {
+		config.AuthMethod = string(AzureSharedKey)
+		config.AccountKey = *accountKey
+	}
+
+	return config
+}
+
+func (a AzureBlobConfig) Identifier() interface{} {
+	return a.Id
+}
+
+// MergeIntoOpsManagerConfig performs the merge operation of the Operator config view ('a') into the OM owned one
+// ('opsManagerAzureConfig')
+func (a AzureBlobConfig) MergeIntoOpsManagerConfig(opsManagerAzureConfig AzureBlobConfig) AzureBlobConfig {
+	opsManagerAzureConfig.Id = a.Id
+	opsManagerAzureConfig.StorageAccountName = a.StorageAccountName
+	opsManagerAzureConfig.ContainerName = a.ContainerName
+	opsManagerAzureConfig.Endpoint = a.Endpoint
+	opsManagerAzureConfig.AuthMethod = a.AuthMethod
+	opsManagerAzureConfig.AccountKey = a.AccountKey
+	opsManagerAzureConfig.Uri = a.Uri
+	opsManagerAzureConfig.Labels = a.Labels
+	return opsManagerAzureConfig
+}
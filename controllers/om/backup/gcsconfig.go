@@ -0,0 +1,86 @@
+package backup
+
+import (
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+)
+
+type gcsAuthMethod string
+
+const (
+	GCSServiceAccountKey gcsAuthMethod = "SERVICE_ACCOUNT_KEY"
+	GCSWorkloadIdentity  gcsAuthMethod = "WORKLOAD_IDENTITY"
+)
+
+type GCSConfigResponse struct {
+	GCSConfigs []GCSConfig `json:"results"`
+}
+
+// GCSConfig is the Ops Manager representation of a Google Cloud Storage snapshot store
+type GCSConfig struct {
+	// Unique name that labels this GCS Snapshot Store.
+	Id string `json:"id"`
+
+	// Name of the GCS bucket that hosts the snapshot store.
+	BucketName string `json:"bucketName"`
+
+	// Custom endpoint used to access the bucket. Empty for the default Google Cloud Storage endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Method used to authorize access to the bucket: either the service account key or GKE Workload Identity.
+	AuthMethod string `json:"authMethod"`
+
+	// Service account key (JSON) that can access the bucket specified in bucketName.
+	ServiceAccountKey string `json:"serviceAccountKey,omitempty"`
+
+	// Flag indicating whether you can assign backup jobs to this data store.
+	AssignmentEnabled bool `json:"assignmentEnabled"`
+
+	// Comma-separated list of hosts in the <hostname:port> format that can access the metadata database.
+	Uri string `json:"uri"`
+
+	// Fields the operator will not configure. All of these can be changed via the UI and the operator
+	// will not reset their values on reconciliation
+	EncryptedCredentials bool     `json:"encryptedCredentials"`
+	Labels               []string `json:"labels"`
+	LoadFactor           int      `json:"loadFactor,omitempty"`
+	WriteConcern         string   `json:"writeConcern,omitempty"`
+}
+
+// NewGCSConfig builds the Ops Manager GCS snapshot store config. The service account key is nil if Workload Identity
+// is used.
+func NewGCSConfig(gcsConfig omv1.GCSConfig, uri string, serviceAccountKey *string) GCSConfig {
+	config := GCSConfig{
+		Id:                   gcsConfig.Name,
+		BucketName:           gcsConfig.BucketName,
+		Endpoint:             gcsConfig.Endpoint,
+		AuthMethod:           string(GCSWorkloadIdentity),
+		AssignmentEnabled:    true, // defaults to true. This will not be overridden on merge, so it can be manually disabled in UI.
+		Uri:                  uri,
+		Labels:               gcsConfig.AssignmentLabels,
+		EncryptedCredentials: false,
+	}
+
+	if serviceAccountKey != nil {
+		config.AuthMethod = string(GCSServiceAccountKey)
+		config.ServiceAccountKey = *serviceAccountKey
+	}
+
+	return config
+}
+
+func (g GCSConfig) Identifier() interface{} {
+	return g.Id
+}
+
+// MergeIntoOpsManagerConfig performs the merge operation of the Operator config view ('g') into the OM owned one
+// ('opsManagerGCSConfig')
+func (g GCSConfig) MergeIntoOpsManagerConfig(opsManagerGCSConfig GCSConfig) GCSConfig {
+	opsManagerGCSConfig.Id = g.Id
+	opsManagerGCSConfig.BucketName = g.BucketName
+	opsManagerGCSConfig.Endpoint = g.Endpoint
+	opsManagerGCSConfig.AuthMethod = g.AuthMethod
+	opsManagerGCSConfig.ServiceAccountKey = g.ServiceAccountKey
+	opsManagerGCSConfig.Uri = g.Uri
+	opsManagerGCSConfig.Labels = g.Labels
+	return opsManagerGCSConfig
+}
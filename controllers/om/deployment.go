@@ -7,11 +7,13 @@ import (
 	"math"
 	"regexp"
 	"slices"
+	"sort"
 
 	"github.com/blang/semver"
 	"github.com/spf13/cast"
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/equality"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
@@ -147,14 +149,21 @@ func (d Deployment) MergeReplicaSet(operatorRs ReplicaSetWithProcesses, specArgs
 	log := l.With("replicaSet", operatorRs.Rs.Name())
 
 	r := d.getReplicaSetByName(operatorRs.Rs.Name())
-	// If the new replica set is bigger than old one - we need to copy first member to positions of new members so that
-	// they were merged with operator replica sets on next step
+	// If the new replica set has processes the old one doesn't have - we need to copy first member to positions of new
+	// members so that they were merged with operator replica sets on next step
 	// (in case OM made any changes to existing processes - these changes must be propagated to new members).
-	if r != nil && len(operatorRs.Rs.Members()) > len(r.Members()) {
-		if err := d.copyFirstProcessToNewPositions(operatorRs.Processes, len(r.Members()), l); err != nil {
-			// I guess this error is not so serious to fail the whole process - RS will be scaled up anyway
-			log.Error("Failed to copy first process (so new replica set processes may miss Ops Manager changes done to "+
-				"existing replica set processes): %s", err)
+	// The first new process is looked up by name as the old replica set may have members which are not operator
+	// processes (see KeepExternalReplicaSetMembers)
+	if r != nil {
+		idxOfFirstNewMember := slices.IndexFunc(operatorRs.Processes, func(p Process) bool {
+			return r.findMemberByName(p.Name()) == nil
+		})
+		if idxOfFirstNewMember != -1 {
+			if err := d.copyFirstProcessToNewPositions(operatorRs.Processes, idxOfFirstNewMember, l); err != nil {
+				// I guess this error is not so serious to fail the whole process - RS will be scaled up anyway
+				log.Error("Failed to copy first process (so new replica set processes may miss Ops Manager changes done to "+
+					"existing replica set processes): %s", err)
+			}
 		}
 	}
 
@@ -183,6 +192,54 @@ func (d Deployment) MergeReplicaSet(operatorRs ReplicaSetWithProcesses, specArgs
 	d.limitVotingMembers(operatorRs.Rs.Name())
 }
 
+// KeepExternalReplicaSetMembers adds the members of the existing replica set whose processes are listed in
+// "externalProcessNames" to the "operator" replica set, so that MergeReplicaSet doesn't remove them. This lets the
+// Kubernetes members join a replica set whose other members are managed outside of Kubernetes (e.g. on VMs) during a
+// live migration. The members already present in the existing replica set keep their "_id", the new ones get an "_id"
+// higher than any existing one so that they never collide with the external members. Without external processes
+// "operatorRs" is returned unchanged.
+func (d Deployment) KeepExternalReplicaSetMembers(operatorRs ReplicaSetWithProcesses, externalProcessNames []string) ReplicaSetWithProcesses {
+	r := d.getReplicaSetByName(operatorRs.Rs.Name())
+	if r == nil || len(externalProcessNames) == 0 {
+		return operatorRs
+	}
+
+	existingIds := r.MemberIds()
+	newId := determineNextProcessIdStartingPoint(operatorRs.Processes, existingIds)
+	members := make([]ReplicaSetMember, 0, len(operatorRs.Rs.Members())+len(externalProcessNames))
+	for _, m := range operatorRs.Rs.Members() {
+		if existingId, ok := existingIds[m.Name()]; ok {
+			m["_id"] = existingId
+		} else {
+			m["_id"] = newId
+			newId++
+		}
+		members = append(members, m)
+	}
+
+	for _, m := range r.Members() {
+		if !stringutil.Contains(externalProcessNames, m.Name()) || operatorRs.Rs.findMemberByName(m.Name()) != nil {
+			continue
+		}
+		external := ReplicaSetMember{}
+		for k, v := range m {
+			external[k] = v
+		}
+		// the members read from Ops Manager don't have the types the operator members have
+		external.setVotes(m.Votes()).setPriority(m.Priority()).setTags(cast.ToStringMapString(m["tags"]))
+		if horizons, ok := m["horizons"]; ok {
+			external["horizons"] = mdbv1.MongoDBHorizonConfig(cast.ToStringMapString(horizons))
+		}
+		members = append(members, external)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Id() < members[j].Id()
+	})
+	operatorRs.Rs.setMembers(members)
+	return operatorRs
+}
+
 // ConfigurePrometheus adds Prometheus configuration to `Deployment` resource.
 //
 // If basic auth is enabled, then `hash` and `salt` need to be calculated by caller and passed in.
@@ -542,6 +599,52 @@ func (d Deployment) ExistingProcessesHaveInternalClusterAuthentication(processes
 	return d.processesHaveInternalClusterAuthentication(deploymentProcesses)
 }
 
+// disruptiveProcessAttributes are the attributes of the processes that cannot be changed without restarting them, or
+// that can only follow such a change (the feature compatibility version follows the MongoDB version).
+var disruptiveProcessAttributes = []string{"version", "featureCompatibilityVersion", "args2_6"}
+
+// RevertDisruptiveProcessChanges restores the attributes of the processes that cannot be changed without restarting
+// the process (the MongoDB version, the feature compatibility version and the mongod arguments, which include the TLS
+// configuration) to the values they have in the 'original' deployment. Processes that don't exist in the 'original' deployment are new and are left
+// untouched. Returns the names of the processes for which the changes were reverted.
+func (d Deployment) RevertDisruptiveProcessChanges(original Deployment) ([]string, error) {
+	var reverted []string
+	for _, p := range d.getProcesses() {
+		originalProcess := original.getProcessByName(p.Name())
+		if originalProcess == nil {
+			continue
+		}
+
+		// the processes modified by the Operator may contain types that don't exist in the deserialized
+		// json (e.g. int vs float64), so both are compared in their canonical form
+		current, err := maputil.StructToMap(p)
+		if err != nil {
+			return nil, err
+		}
+		previous, err := maputil.StructToMap(*originalProcess)
+		if err != nil {
+			return nil, err
+		}
+
+		changed := false
+		for _, attribute := range disruptiveProcessAttributes {
+			if equality.Semantic.DeepEqual(current[attribute], previous[attribute]) {
+				continue
+			}
+			if value, ok := previous[attribute]; ok {
+				p[attribute] = value
+			} else {
+				delete(p, attribute)
+			}
+			changed = true
+		}
+		if changed {
+			reverted = append(reverted, p.Name())
+		}
+	}
+	return reverted, nil
+}
+
 func (d Deployment) Serialize() ([]byte, error) {
 	return json.Marshal(d)
 }
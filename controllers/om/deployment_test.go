@@ -141,6 +141,41 @@ func TestMergeReplicaSet_MergeFirstProcess(t *testing.T) {
 	checkReplicaSet(t, d, expectedRs)
 }
 
+func TestMergeReplicaSet_KeepExternalMembers(t *testing.T) {
+	d := NewDeployment()
+	mergeReplicaSet(d, "rs0", createReplicaSetProcessesCount(3, "vm"))
+	// the replica set managed outside of Kubernetes is read from Ops Manager
+	bytes, err := d.Serialize()
+	assert.NoError(t, err)
+	d, err = BuildDeploymentFromBytes(bytes)
+	assert.NoError(t, err)
+
+	rs := buildRsByProcesses("rs0", createReplicaSetProcessesCount(2, "rs0"))
+	d.MergeReplicaSet(d.KeepExternalReplicaSetMembers(rs, []string{"vm-0", "vm-1", "vm-2"}), nil, nil, zap.S())
+
+	assert.Len(t, d.getProcesses(), 5)
+	assert.Equal(t, map[string]int{"vm-0": 0, "vm-1": 1, "vm-2": 2, "rs0-0": 3, "rs0-1": 4}, d.GetReplicaSetByName("rs0").MemberIds())
+
+	// the new Kubernetes members get the changes done by Ops Manager to the existing Kubernetes members
+	(*d.getProcessByName("rs0-0"))["logRotate"] = map[string]int{"sizeThresholdMB": 3000, "timeThresholdHrs": 12}
+	rs = buildRsByProcesses("rs0", createReplicaSetProcessesCount(3, "rs0"))
+	d.MergeReplicaSet(d.KeepExternalReplicaSetMembers(rs, []string{"vm-0", "vm-1", "vm-2"}), nil, nil, zap.S())
+
+	assert.Len(t, d.getProcesses(), 6)
+	assert.Equal(t, map[string]int{"vm-0": 0, "vm-1": 1, "vm-2": 2, "rs0-0": 3, "rs0-1": 4, "rs0-2": 5}, d.GetReplicaSetByName("rs0").MemberIds())
+	assert.Equal(t, map[string]int{"sizeThresholdMB": 3000, "timeThresholdHrs": 12}, (*d.getProcessByName("rs0-2"))["logRotate"])
+
+	// without external members the replica set is left unchanged
+	rs = NewMultiClusterReplicaSetWithProcesses(NewReplicaSet("rs0", "7.0.0"), createReplicaSetProcessesCount(3, "rs0"), nil, d.GetReplicaSetByName("rs0").MemberIds(), nil)
+	assert.Equal(t, rs, d.KeepExternalReplicaSetMembers(rs, nil))
+
+	// once the external members aren't kept anymore they are removed
+	d.MergeReplicaSet(rs, nil, nil, zap.S())
+
+	assert.Len(t, d.getProcesses(), 3)
+	assert.Equal(t, map[string]int{"rs0-0": 3, "rs0-1": 4, "rs0-2": 5}, d.GetReplicaSetByName("rs0").MemberIds())
+}
+
 func TestConfigureSSL_Deployment(t *testing.T) {
 	d := Deployment{}
 	d.ConfigureTLS(&mdbv1.Security{TLSConfig: &mdbv1.TLSConfig{Enabled: true}}, util.CAFilePathInContainer)
@@ -719,6 +754,63 @@ func checkNumberOfVotingMembers(t *testing.T, rs ReplicaSetWithProcesses, expect
 	assert.Len(t, rs.Rs.Members(), totalNumberOfMembers)
 }
 
+func TestRevertDisruptiveProcessChanges(t *testing.T) {
+	original := NewDeployment()
+	mergeReplicaSet(original, "fooRs", createReplicaSetProcessesCount(2, "fooRs"))
+	original = original.ToCanonicalForm()
+
+	d := original.deepCopy()
+	mergeReplicaSet(d, "fooRs", createReplicaSetProcessesCount(3, "fooRs"))
+	d.getProcesses()[0]["version"] = "4.0.0"
+	d.getProcesses()[0].EnsureNetConfig()["port"] = 30000
+	d.getProcesses()[1].ConfigureTLS("requireTLS", "/mongodb-automation/server.pem")
+	d.getProcesses()[1]["featureCompatibilityVersion"] = "4.0"
+	d.getProcesses()[2]["featureCompatibilityVersion"] = "4.0"
+
+	reverted, err := d.RevertDisruptiveProcessChanges(original)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fooRs-0", "fooRs-1"}, reverted)
+
+	assert.Equal(t, "3.6.3", d.getProcesses()[0].Version())
+	assert.EqualValues(t, util.MongoDbDefaultPort, d.getProcesses()[0].EnsureNetConfig()["port"])
+	assert.False(t, d.getProcesses()[1].IsTLSEnabled())
+	assert.Equal(t, original.getProcesses()[1].FeatureCompatibilityVersion(), d.getProcesses()[1].FeatureCompatibilityVersion())
+	// new processes are not touched
+	assert.Equal(t, "4.0", d.getProcesses()[2].FeatureCompatibilityVersion())
+	assert.Len(t, d.getProcesses(), 3)
+	assert.Len(t, d.GetReplicaSets()[0].Members(), 3)
+}
+
+func TestRevertDisruptiveProcessChanges_FeatureCompatibilityVersion(t *testing.T) {
+	original := NewDeployment()
+	mergeReplicaSet(original, "fooRs", createReplicaSetProcessesCount(2, "fooRs"))
+	original.getProcesses()[0]["featureCompatibilityVersion"] = "3.6"
+	original = original.ToCanonicalForm()
+
+	d := original.deepCopy()
+	d.getProcesses()[0]["featureCompatibilityVersion"] = "4.0"
+	d.getProcesses()[1]["featureCompatibilityVersion"] = "4.0"
+
+	reverted, err := d.RevertDisruptiveProcessChanges(original)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fooRs-0", "fooRs-1"}, reverted)
+
+	assert.Equal(t, "3.6", d.getProcesses()[0].FeatureCompatibilityVersion())
+	assert.Equal(t, "", d.getProcesses()[1].FeatureCompatibilityVersion())
+}
+
+func TestRevertDisruptiveProcessChanges_NoChanges(t *testing.T) {
+	original := NewDeployment()
+	mergeReplicaSet(original, "fooRs", createReplicaSetProcesses("fooRs"))
+
+	d := original.ToCanonicalForm()
+	mergeReplicaSet(d, "fooRs", createReplicaSetProcesses("fooRs"))
+
+	reverted, err := d.RevertDisruptiveProcessChanges(original)
+	require.NoError(t, err)
+	assert.Empty(t, reverted)
+}
+
 func createShards(name string) []ReplicaSetWithProcesses {
 	return createSpecificNumberOfShards(3, name)
 }
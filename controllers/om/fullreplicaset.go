@@ -34,6 +34,27 @@ func NewReplicaSetWithProcesses(
 	return ReplicaSetWithProcesses{rs, processes}
 }
 
+// NewReplicaSetWithProcessIds is like NewReplicaSetWithProcesses, but the members keep their _id in existingProcessIds
+// and the new members get an _id higher than any existing one.
+func NewReplicaSetWithProcessIds(
+	rs ReplicaSet,
+	processes []Process,
+	memberOptions []automationconfig.MemberOptions,
+	existingProcessIds map[string]int,
+) ReplicaSetWithProcesses {
+	fullRs := NewReplicaSetWithProcesses(rs, processes, memberOptions)
+	newId := determineNextProcessIdStartingPoint(processes, existingProcessIds)
+	for _, m := range fullRs.Rs.Members() {
+		if existingId, ok := existingProcessIds[m.Name()]; ok {
+			m["_id"] = existingId
+		} else {
+			m["_id"] = newId
+			newId++
+		}
+	}
+	return fullRs
+}
+
 // determineNextProcessIdStartingPoint returns the number which should be used as a starting
 // point for generating new _ids.
 func determineNextProcessIdStartingPoint(desiredProcesses []Process, existingProcessIds map[string]int) int {
@@ -280,3 +280,17 @@ func TestNewMultiClusterReplicaSetWithProcesses(t *testing.T) {
 		})
 	}
 }
+
+func TestNewReplicaSetWithProcessIds(t *testing.T) {
+	processes := []Process{{"name": "p-0"}, {"name": "p-1"}, {"name": "p-2"}}
+
+	rs := NewReplicaSetWithProcessIds(NewReplicaSet("rs", "7.0.0"), processes, nil, nil)
+	assert.Equal(t, map[string]int{"p-0": 0, "p-1": 1, "p-2": 2}, rs.Rs.MemberIds())
+
+	// p-1 is new, p-0 and p-2 keep their _id
+	rs = NewReplicaSetWithProcessIds(NewReplicaSet("rs", "7.0.0"), processes, nil, map[string]int{"p-0": 0, "p-2": 1})
+	assert.Equal(t, map[string]int{"p-0": 0, "p-1": 2, "p-2": 1}, rs.Rs.MemberIds())
+	for _, m := range rs.Rs.Members() {
+		assert.IsType(t, 0, m["_id"])
+	}
+}
@@ -26,6 +26,12 @@ func CreateMongodProcessesWithLimit(mongoDBImage string, forceEnterprise bool, s
 // CreateMongodProcessesFromMongoDB creates mongod processes directly from MongoDB resource without StatefulSet
 func CreateMongodProcessesFromMongoDB(mongoDBImage string, forceEnterprise bool, mdb *mdbv1.MongoDB, limit int, fcv string, tlsCertPath string, defaultArchitecture architectures.DefaultArchitecture) []om.Process {
 	hostnames, names := dns.GetDNSNames(mdb.Name, mdb.ServiceName(), mdb.Namespace, mdb.Spec.GetClusterDomain(), limit, mdb.Spec.DbCommonSpec.GetExternalDomain())
+	return CreateMongodProcessesFromHostnames(mongoDBImage, forceEnterprise, mdb, hostnames, names, fcv, tlsCertPath, defaultArchitecture)
+}
+
+// CreateMongodProcessesFromHostnames creates the mongod processes of a MongoDB resource whose Pods aren't all
+// deployed by the StatefulSet named after it, like the ones of a replica set placed in zones.
+func CreateMongodProcessesFromHostnames(mongoDBImage string, forceEnterprise bool, mdb *mdbv1.MongoDB, hostnames, names []string, fcv string, tlsCertPath string, defaultArchitecture architectures.DefaultArchitecture) []om.Process {
 	processes := make([]om.Process, len(hostnames))
 
 	for idx, hostname := range hostnames {
@@ -6,6 +6,7 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/process"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
 )
 
@@ -27,11 +28,24 @@ func BuildFromStatefulSetWithReplicas(mongoDBImage string, forceEnterprise bool,
 }
 
 // BuildFromMongoDBWithReplicas returns a replica set that can be set in the Automation Config
-// based on the given MongoDB resource directly without requiring a StatefulSet.
-func BuildFromMongoDBWithReplicas(mongoDBImage string, forceEnterprise bool, mdb *mdbv1.MongoDB, replicas int, fcv string, tlsCertPath string, defaultArchitecture architectures.DefaultArchitecture) om.ReplicaSetWithProcesses {
+// based on the given MongoDB resource directly without requiring a StatefulSet. The members keep their _id in
+// existingProcessIds, which differs from their position once the replica set was migrated with external members.
+func BuildFromMongoDBWithReplicas(mongoDBImage string, forceEnterprise bool, mdb *mdbv1.MongoDB, replicas int, existingProcessIds map[string]int, fcv string, tlsCertPath string, defaultArchitecture architectures.DefaultArchitecture) om.ReplicaSetWithProcesses {
 	members := process.CreateMongodProcessesFromMongoDB(mongoDBImage, forceEnterprise, mdb, replicas, fcv, tlsCertPath, defaultArchitecture)
+	return buildFromMongoDBWithProcesses(mdb, members, mdb.Spec.GetMemberOptions(), existingProcessIds)
+}
+
+// BuildFromMongoDBWithHostnames returns a replica set that can be set in the Automation Config, with a member for
+// each of the given hostnames and Pod names. The members keep their _id in existingProcessIds, as the position of a
+// member doesn't identify it when the members before it change, and the new members get _ids higher than the existing ones.
+func BuildFromMongoDBWithHostnames(mongoDBImage string, forceEnterprise bool, mdb *mdbv1.MongoDB, hostnames, names []string, memberOptions []automationconfig.MemberOptions, existingProcessIds map[string]int, fcv string, tlsCertPath string, defaultArchitecture architectures.DefaultArchitecture) om.ReplicaSetWithProcesses {
+	members := process.CreateMongodProcessesFromHostnames(mongoDBImage, forceEnterprise, mdb, hostnames, names, fcv, tlsCertPath, defaultArchitecture)
+	return buildFromMongoDBWithProcesses(mdb, members, memberOptions, existingProcessIds)
+}
+
+func buildFromMongoDBWithProcesses(mdb *mdbv1.MongoDB, members []om.Process, memberOptions []automationconfig.MemberOptions, existingProcessIds map[string]int) om.ReplicaSetWithProcesses {
 	replicaSet := om.NewReplicaSet(mdb.Name, mdb.Spec.GetMongoDBVersion())
-	rsWithProcesses := om.NewReplicaSetWithProcesses(replicaSet, members, mdb.Spec.GetMemberOptions())
+	rsWithProcesses := om.NewReplicaSetWithProcessIds(replicaSet, members, memberOptions, existingProcessIds)
 	rsWithProcesses.SetHorizons(mdb.Spec.GetHorizonConfig())
 	return rsWithProcesses
 }
@@ -50,6 +50,7 @@ func TestBuildFromMongoDBWithReplicas(t *testing.T) {
 		false,
 		mdb,
 		replicas,
+		nil,
 		"7.0",
 		"",
 		architectures.NonStatic,
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/objx"
@@ -55,6 +56,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/service"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/placeholders"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/tls"
@@ -119,6 +121,12 @@ type ReconcileAppDbReplicaSet struct {
 	initDatabaseVersion string
 
 	defaultArchitecture architectures.DefaultArchitecture
+
+	// maintenanceWindow tracks the changes deferred until the AppDB maintenance window opens
+	maintenanceWindow *maintenanceWindowGuard
+
+	// holdBackVersionChange keeps the AppDB on the last applied MongoDB version while Ops Manager is upgraded before it
+	holdBackVersionChange bool
 }
 
 func NewAppDBReplicaSetReconciler(ctx context.Context, imageUrls images.ImageUrls, initDatabaseVersion string, opsManager *omv1.MongoDBOpsManager, commonController *ReconcileCommonController, omConnectionFactory om.ConnectionFactory, globalMemberClustersMap map[string]client.Client, defaultArchitecture architectures.DefaultArchitecture, log *zap.SugaredLogger) (*ReconcileAppDbReplicaSet, error) {
@@ -149,6 +157,8 @@ type AppDBReconcilerHelper struct {
 	// readOnly skips all state writes during construction (migration write, cluster-mapping save,
 	// legacy ConfigMap writes) - used for deletion cleanup.
 	readOnly bool
+	// failedClusterNames are the member clusters in the failedClusters annotation of the MongoDBOpsManager resource
+	failedClusterNames []string
 }
 
 func NewAppDBReconcilerHelper(ctx context.Context, opsManager *omv1.MongoDBOpsManager, commonController *ReconcileCommonController, globalMemberClustersMap map[string]client.Client, log *zap.SugaredLogger) (*AppDBReconcilerHelper, error) {
@@ -171,6 +181,14 @@ func newAppDBReconcilerHelper(ctx context.Context, opsManager *omv1.MongoDBOpsMa
 
 	appDBSpec := opsManager.Spec.AppDB
 
+	failedClusterNames, err := failedcluster.ReadFailedClusterNames(opsManager.Annotations)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read the %s annotation: %w", failedcluster.FailedClusterAnnotation, err)
+	}
+	helper.failedClusterNames = failedClusterNames
+	// the failed member clusters are skipped the same way as the member clusters the operator has no client for
+	globalMemberClustersMap = failedcluster.WithoutFailedClusters(globalMemberClustersMap, failedClusterNames)
+
 	if err := helper.initializeStateStore(ctx, appDBSpec, opsManager.Annotations, log); err != nil {
 		return nil, xerrors.Errorf("failed to initialize appdb state store: %w", err)
 	}
@@ -662,10 +680,7 @@ func (r *ReconcileAppDbReplicaSet) ReconcileAppDB(ctx context.Context, opsManage
 		return r.updateStatus(ctx, opsManager, workflow.OK(), log, appDbStatusOption)
 	}
 
-	var appdbSecretPath string
-	if r.VaultClient != nil {
-		appdbSecretPath = r.VaultClient.AppDBSecretPath()
-	}
+	appdbSecretPath := r.AppDBSecretPath()
 
 	agentCertSecretName := opsManager.Spec.AppDB.GetSecurity().AgentClientCertificateSecretName(opsManager.Spec.AppDB.GetName())
 	_, agentCertPath := r.agentCertHashAndPath(ctx, log, opsManager.Namespace, agentCertSecretName, appdbSecretPath)
@@ -699,9 +714,20 @@ func (r *ReconcileAppDbReplicaSet) ReconcileAppDB(ctx context.Context, opsManage
 		}
 	}
 
+	r.maintenanceWindow = newMaintenanceWindowGuard(rs.MaintenanceWindow, time.Now())
+	appDBVersion := r.mongoDBVersion(opsManager)
+	if r.isChangingVersion(opsManager) && !r.maintenanceWindow.isOpen() {
+		// the mongod container image is pinned to the running version, the version change in the automation config
+		// is held back in deployAutomationConfig
+		appDBVersion = r.helper.deploymentState.LastAppliedMongoDBVersion
+		for _, p := range r.generateProcessList(opsManager) {
+			r.maintenanceWindow.deferChange(p.Name)
+		}
+	}
+
 	appdbOpts := construct.AppDBStatefulSetOptions{
 		InitAppDBImage: images.ContainerImage(r.imageUrls, util.InitDatabaseImageUrlEnv, r.initDatabaseVersion),
-		MongodbImage:   images.GetOfficialImage(r.imageUrls, opsManager.Spec.AppDB.Version, opsManager.GetAnnotations(), r.defaultArchitecture),
+		MongodbImage:   images.GetOfficialImage(r.imageUrls, appDBVersion, opsManager.GetAnnotations(), r.defaultArchitecture),
 		CustomAgentURL: r.customAgentURL,
 	}
 	if architectures.IsRunningStaticArchitecture(opsManager.Annotations, r.defaultArchitecture) {
@@ -777,11 +803,15 @@ func (r *ReconcileAppDbReplicaSet) ReconcileAppDB(ctx context.Context, opsManage
 	// We keep updating annotations for backward compatibility (e.g operator downgrade), so we write the
 	// lastAppliedMongoDBVersion both in the state and in annotations below
 	// here it doesn't matter for which cluster we'll generate the name - only AppDB's MongoDB version is used there, which is the same in all clusters
-	versionedImplForMemberCluster := opsManager.GetVersionedImplForMemberCluster(r.helper.getMemberClusterIndex(r.helper.getNameOfFirstMemberCluster()))
-	log.Debugf("Storing LastAppliedMongoDBVersion %s in annotations and deployment state", versionedImplForMemberCluster.GetMongoDBVersionForAnnotation())
-	r.helper.deploymentState.LastAppliedMongoDBVersion = versionedImplForMemberCluster.GetMongoDBVersionForAnnotation()
-	if err := annotations.UpdateLastAppliedMongoDBVersion(ctx, versionedImplForMemberCluster, r.helper.centralClient); err != nil {
-		return r.updateStatus(ctx, opsManager, workflow.Failed(xerrors.Errorf("Could not save current state as an annotation: %w", err)), log, omStatusOption)
+	// The version is not stored while there are changes waiting for the maintenance window as it might not have been applied yet,
+	// nor while the version change is held back during an Ops Manager upgrade
+	if !r.maintenanceWindow.hasDeferredChanges() && r.mongoDBVersion(opsManager) == opsManager.Spec.AppDB.GetMongoDBVersion() {
+		versionedImplForMemberCluster := opsManager.GetVersionedImplForMemberCluster(r.helper.getMemberClusterIndex(r.helper.getNameOfFirstMemberCluster()))
+		log.Debugf("Storing LastAppliedMongoDBVersion %s in annotations and deployment state", versionedImplForMemberCluster.GetMongoDBVersionForAnnotation())
+		r.helper.deploymentState.LastAppliedMongoDBVersion = versionedImplForMemberCluster.GetMongoDBVersionForAnnotation()
+		if err := annotations.UpdateLastAppliedMongoDBVersion(ctx, versionedImplForMemberCluster, r.helper.centralClient); err != nil {
+			return r.updateStatus(ctx, opsManager, workflow.Failed(xerrors.Errorf("Could not save current state as an annotation: %w", err)), log, omStatusOption)
+		}
 	}
 
 	appDBScalers := []interfaces.MultiClusterReplicaSetScaler{}
@@ -821,6 +851,10 @@ func (r *ReconcileAppDbReplicaSet) ReconcileAppDB(ctx context.Context, opsManage
 		return r.updateStatus(ctx, opsManager, workflow.Pending("Continuing scaling operation on AppDB %d", 1), log, appDbStatusOption, status.AppDBMemberOptions(appDBScalers...))
 	}
 
+	if workflowStatus := r.maintenanceWindow.status(); !workflowStatus.IsOK() {
+		return r.updateStatus(ctx, opsManager, workflowStatus, log, appDbStatusOption, status.AppDBMemberOptions(appDBScalers...))
+	}
+
 	// set the annotation to AppDB that forced reconfigure is performed to indicate to customers
 	if opsManager.Annotations == nil {
 		opsManager.Annotations = map[string]string{}
@@ -1022,7 +1056,16 @@ func (r *ReconcileAppDbReplicaSet) publishAutomationConfigFirst(opsManager *omv1
 
 func (r *ReconcileAppDbReplicaSet) isChangingVersion(opsManager *omv1.MongoDBOpsManager) bool {
 	prevVersion := r.helper.deploymentState.LastAppliedMongoDBVersion
-	return prevVersion != "" && prevVersion != opsManager.Spec.AppDB.Version
+	return prevVersion != "" && prevVersion != r.mongoDBVersion(opsManager)
+}
+
+// mongoDBVersion returns the MongoDB version the AppDB is deployed with, which is the last applied version while the
+// version change is held back.
+func (r *ReconcileAppDbReplicaSet) mongoDBVersion(opsManager *omv1.MongoDBOpsManager) string {
+	if r.holdBackVersionChange && r.helper.deploymentState.LastAppliedMongoDBVersion != "" {
+		return r.helper.deploymentState.LastAppliedMongoDBVersion
+	}
+	return opsManager.Spec.AppDB.GetMongoDBVersion()
 }
 
 func getDomain(service, namespace, clusterName string) string {
@@ -1032,6 +1075,23 @@ func getDomain(service, namespace, clusterName string) string {
 	return fmt.Sprintf("%s.%s.svc.%s", service, namespace, clusterName)
 }
 
+// ensureIssuedCertificate requests the AppDB certificate from cert-manager or issues it from Vault, if enabled. In
+// multi-cluster mode the certificate is shared by all the member clusters.
+func (r *ReconcileAppDbReplicaSet) ensureIssuedCertificate(ctx context.Context, om *omv1.MongoDBOpsManager, log *zap.SugaredLogger) workflow.Status {
+	var opts []certs.Options
+	if om.Spec.AppDB.IsMultiCluster() {
+		for _, memberCluster := range r.helper.memberClusters {
+			opts = append(opts, certs.AppDBMultiClusterReplicaSetConfig(om, scalers.GetAppDBScaler(om, memberCluster.Name, r.helper.getMemberClusterIndex(memberCluster.Name), r.helper.memberClusters)))
+		}
+	} else {
+		opts = append(opts, certs.AppDBReplicaSetConfig(om))
+	}
+	if status := certs.EnsureCertManagerCertificateForStatefulSets(ctx, r.client, *om.Spec.AppDB.GetSecurity(), kube.BaseOwnerReference(om), opts...); !status.IsOK() {
+		return status
+	}
+	return certs.EnsureVaultPKICertificateForStatefulSets(ctx, r.SecretClient, *om.Spec.AppDB.GetSecurity(), certs.AppDB, log, opts...)
+}
+
 // ensureTLSSecretAndCreatePEMIfNeeded checks that the needed TLS secrets are present, and creates the concatenated PEM if needed.
 // This means that the secret referenced can either already contain a concatenation of certificate and private key
 // or it can be of type kubernetes.io/tls. In this case the operator will read the tls.crt and tls.key entries, and it will
@@ -1043,6 +1103,10 @@ func (r *ReconcileAppDbReplicaSet) ensureTLSSecretAndCreatePEMIfNeeded(ctx conte
 	}
 	secretName := rs.Security.MemberCertificateSecretName(rs.Name())
 
+	if status := r.ensureIssuedCertificate(ctx, om, log); !status.IsOK() {
+		return status
+	}
+
 	needToCreatePEM := false
 	var err error
 	var secretData map[string][]byte
@@ -1084,10 +1148,7 @@ func (r *ReconcileAppDbReplicaSet) ensureTLSSecretAndCreatePEMIfNeeded(ctx conte
 			}
 		}
 
-		var appdbSecretPath string
-		if r.VaultClient != nil {
-			appdbSecretPath = r.VaultClient.AppDBSecretPath()
-		}
+		appdbSecretPath := r.AppDBSecretPath()
 
 		secretHash := enterprisepem.ReadHashFromSecret(ctx, r.SecretClient, om.Namespace, secretName, appdbSecretPath, log)
 
@@ -1214,10 +1275,7 @@ func (r *ReconcileAppDbReplicaSet) buildAppDbAutomationConfig(ctx context.Contex
 	fcVersion := opsManager.CalculateFeatureCompatibilityVersion()
 
 	tlsSecretName := opsManager.Spec.AppDB.GetSecurity().MemberCertificateSecretName(opsManager.Spec.AppDB.Name())
-	var appdbSecretPath string
-	if r.VaultClient != nil {
-		appdbSecretPath = r.VaultClient.AppDBSecretPath()
-	}
+	appdbSecretPath := r.AppDBSecretPath()
 	certHash := enterprisepem.ReadHashFromSecret(ctx, r.SecretClient, opsManager.Namespace, tlsSecretName, appdbSecretPath, log)
 
 	prometheusModification, err := buildPrometheusModification(ctx, r.SecretClient, opsManager, prometheusCertHash)
@@ -1244,7 +1302,7 @@ func (r *ReconcileAppDbReplicaSet) buildAppDbAutomationConfig(ctx context.Contex
 		SetFCV(fcVersion).
 		AddVersions(existingAutomationConfig.Versions).
 		IsEnterprise(construct.IsEnterprise()).
-		SetMongoDBVersion(rs.GetMongoDBVersion()).
+		SetMongoDBVersion(r.mongoDBVersion(opsManager)).
 		SetOptions(automationconfig.Options{DownloadBase: util.AgentDownloadsDir}).
 		SetPreviousAutomationConfig(existingAutomationConfig).
 		SetTLSConfig(
@@ -1430,14 +1488,20 @@ func (r *ReconcileAppDbReplicaSet) generateProcessList(opsManager *omv1.MongoDBO
 }
 
 func (r *ReconcileAppDbReplicaSet) generateMemberOptions(opsManager *omv1.MongoDBOpsManager, previousMembers map[string]automationconfig.ReplicaSetMember) []automationconfig.MemberOptions {
+	clusterSpecList := opsManager.Spec.AppDB.GetClusterSpecList()
+	if failedcluster.EffectivePolicy(opsManager.Spec.Failover) == v1.FailoverPolicyReduceVotes {
+		clusterSpecList = failedcluster.ReduceVotes(clusterSpecList, r.helper.failedClusterNames)
+	}
+
 	var memberOptionsList []automationconfig.MemberOptions
 	for _, memberCluster := range r.helper.getAllMemberClusters() {
 		hostnames := r.generateProcessHostnamesForCluster(opsManager, memberCluster)
 		memberConfig := make([]automationconfig.MemberOptions, 0)
 		if memberCluster.Active {
-			memberConfigForCluster := opsManager.Spec.AppDB.GetMemberClusterSpecByName(memberCluster.Name).MemberConfig
-			if memberConfigForCluster != nil {
-				memberConfig = append(memberConfig, memberConfigForCluster...)
+			for _, clusterSpecItem := range clusterSpecList {
+				if clusterSpecItem.ClusterName == memberCluster.Name {
+					memberConfig = append(memberConfig, clusterSpecItem.MemberConfig...)
+				}
 			}
 		}
 		for idx, hostname := range hostnames {
@@ -1487,10 +1551,9 @@ func buildPrometheusModification(ctx context.Context, sClient secrets.SecretClie
 	prometheus := om.Spec.AppDB.Prometheus
 
 	secretName := prometheus.PasswordSecretRef.Name
-	if vault.IsVaultSecretBackend() {
-		operatorSecretPath := sClient.VaultClient.OperatorSecretPath()
-		passwordString := fmt.Sprintf("%s/%s/%s", operatorSecretPath, om.GetNamespace(), secretName)
-		keyedPassword, err := sClient.VaultClient.ReadSecretString(passwordString)
+	secretNamespacedName := types.NamespacedName{Name: secretName, Namespace: om.Namespace}
+	if !secrets.IsKubernetesSecretBackend() {
+		keyedPassword, err := sClient.ReadSecret(ctx, secretNamespacedName, sClient.OperatorSecretPath())
 		if err != nil {
 			return automationconfig.NOOP(), err
 		}
@@ -1498,11 +1561,10 @@ func buildPrometheusModification(ctx context.Context, sClient secrets.SecretClie
 		var ok bool
 		password, ok = keyedPassword[prometheus.GetPasswordKey()]
 		if !ok {
-			errMsg := fmt.Sprintf("Prometheus password %s not in Secret %s", prometheus.GetPasswordKey(), passwordString)
+			errMsg := fmt.Sprintf("Prometheus password %s not in Secret %s", prometheus.GetPasswordKey(), secretNamespacedName)
 			return automationconfig.NOOP(), xerrors.Errorf(errMsg)
 		}
 	} else {
-		secretNamespacedName := types.NamespacedName{Name: secretName, Namespace: om.Namespace}
 		password, err = secret.ReadKey(ctx, sClient, prometheus.GetPasswordKey(), secretNamespacedName)
 		if err != nil {
 			return automationconfig.NOOP(), err
@@ -1776,10 +1838,7 @@ func (r *ReconcileAppDbReplicaSet) ensureAppDbPassword(ctx context.Context, opsM
 
 // ensureAppDbAgentApiKey makes sure there is an agent API key for the AppDB automation agent
 func (r *ReconcileAppDbReplicaSet) ensureAppDbAgentApiKey(ctx context.Context, opsManager *omv1.MongoDBOpsManager, conn om.Connection, projectID string, log *zap.SugaredLogger) (string, error) {
-	var appdbSecretPath string
-	if r.VaultClient != nil {
-		appdbSecretPath = r.VaultClient.AppDBSecretPath()
-	}
+	appdbSecretPath := r.AppDBSecretPath()
 
 	agentKey := ""
 	for _, memberCluster := range r.helper.GetHealthyMemberClusters() {
@@ -1796,10 +1855,7 @@ func (r *ReconcileAppDbReplicaSet) ensureAppDbAgentApiKey(ctx context.Context, o
 // tryConfigureMonitoringInOpsManager attempts to configure monitoring in Ops Manager. This might not be possible if Ops Manager
 // has not been created yet, if that is the case, an empty PodVars will be returned.
 func (r *ReconcileAppDbReplicaSet) tryConfigureMonitoringInOpsManager(ctx context.Context, opsManager *omv1.MongoDBOpsManager, opsManagerUserPassword string, agentCertPath string, log *zap.SugaredLogger) (env.PodEnvVars, error) {
-	var operatorVaultSecretPath string
-	if r.VaultClient != nil {
-		operatorVaultSecretPath = r.VaultClient.OperatorSecretPath()
-	}
+	operatorVaultSecretPath := r.OperatorSecretPath()
 
 	APIKeySecretName, err := opsManager.APIKeySecretName(ctx, r.SecretClient, operatorVaultSecretPath)
 	if err != nil {
@@ -1944,10 +2000,7 @@ func (r *ReconcileAppDbReplicaSet) readExistingPodVars(ctx context.Context, om *
 		return env.PodEnvVars{}, xerrors.Errorf("ConfigMap %s did not have the key %s", om.Spec.AppDB.ProjectIDConfigMapName(), util.AppDbProjectIdKey)
 	}
 
-	var operatorVaultSecretPath string
-	if r.VaultClient != nil {
-		operatorVaultSecretPath = r.VaultClient.OperatorSecretPath()
-	}
+	operatorVaultSecretPath := r.OperatorSecretPath()
 	APISecretName, err := om.APIKeySecretName(ctx, r.SecretClient, operatorVaultSecretPath)
 	if err != nil {
 		return env.PodEnvVars{}, xerrors.Errorf("error getting ops-manager API secret name: %w", err)
@@ -1966,10 +2019,7 @@ func (r *ReconcileAppDbReplicaSet) readExistingPodVars(ctx context.Context, om *
 		},
 	}
 
-	var appdbSecretPath string
-	if r.VaultClient != nil {
-		appdbSecretPath = r.VaultClient.AppDBSecretPath()
-	}
+	appdbSecretPath := r.AppDBSecretPath()
 
 	agentAPIKey, err := r.helper.getMemberCluster(r.helper.getNameOfFirstMemberCluster()).SecretClient.ReadSecretKey(
 		ctx, kube.ObjectKey(om.Namespace, agents.ApiKeySecretName(projectId)), appdbSecretPath, util.OmAgentApiKey)
@@ -2005,6 +2055,16 @@ func (r *ReconcileAppDbReplicaSet) deployAutomationConfig(ctx context.Context, o
 	if err != nil {
 		return 0, workflow.Failed(err)
 	}
+
+	if !r.maintenanceWindow.isOpen() {
+		currentAc, err := r.getExistingAutomationConfig(ctx, opsManager, rs.AutomationConfigSecretName())
+		if err != nil {
+			return 0, workflow.Failed(err)
+		}
+		if err := r.maintenanceWindow.revertAutomationConfigChanges(currentAc, &config, log); err != nil {
+			return 0, workflow.Failed(err)
+		}
+	}
 	var configVersion int
 	if configVersion, err = r.publishAutomationConfig(ctx, opsManager, config, rs.AutomationConfigSecretName(), memberCluster.SecretClient); err != nil {
 		return 0, workflow.Failed(err)
@@ -2057,6 +2117,10 @@ func (r *ReconcileAppDbReplicaSet) deployStatefulSet(ctx context.Context, opsMan
 			return workflow.Failed(xerrors.Errorf("can't construct AppDB Statefulset: %w", err))
 		}
 
+		if restoreStatus := applySnapshotRestore(ctx, memberCluster.Client, opsManager.Spec.AppDB.RestoreFromSnapshot, &appDbSts); !restoreStatus.IsOK() {
+			return restoreStatus
+		}
+
 		mutatedSts, deployStatus := r.deployStatefulSetInMemberCluster(ctx, opsManager, appDbSts, memberCluster.Name, log)
 		if !deployStatus.IsOK() {
 			return deployStatus
@@ -40,6 +40,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/configmap"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
 )
@@ -2236,3 +2237,48 @@ func TestReconcileAppDBBlockNonEmptyClusterSpecItemRemovalIntegration(t *testing
 		})
 	}
 }
+
+func TestAppDBMultiCluster_ReduceVotesOfFailedCluster(t *testing.T) {
+	ctx := context.Background()
+	memberClusterName := "member-cluster-1"
+	memberClusterName2 := "member-cluster-2"
+	failedClusters, err := json.Marshal([]failedcluster.FailedCluster{{ClusterName: memberClusterName2, Members: 1}})
+	require.NoError(t, err)
+
+	opsManager := DefaultOpsManagerBuilder().
+		SetName("om").
+		SetNamespace("ns").
+		SetAppDBClusterSpecList(mdbv1.ClusterSpecList{
+			{ClusterName: memberClusterName, Members: 2},
+			{ClusterName: memberClusterName2, Members: 1},
+		}).
+		SetAppDbMembers(0).
+		SetAppDBTopology(mdbv1.ClusterTopologyMultiCluster).
+		SetFailover(&v1.FailoverConfig{Policy: v1.FailoverPolicyReduceVotes}).
+		Build()
+	opsManager.Annotations = map[string]string{failedcluster.FailedClusterAnnotation: string(failedClusters)}
+	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient(opsManager)
+	globalClusterMap := getFakeMultiClusterMapWithClusters([]string{memberClusterName, memberClusterName2}, omConnectionFactory)
+
+	reconciler, err := newAppDbMultiReconciler(ctx, kubeClient, opsManager, globalClusterMap, zap.S(), omConnectionFactory.GetConnectionFunc)
+	require.NoError(t, err)
+
+	assert.True(t, reconciler.helper.getMemberCluster(memberClusterName).Healthy)
+	assert.False(t, reconciler.helper.getMemberCluster(memberClusterName2).Healthy)
+
+	memberOptions := reconciler.generateMemberOptions(opsManager, nil)
+	require.Len(t, memberOptions, 3)
+	for i, expectedVotes := range []int{1, 1, 0} {
+		assert.Equal(t, expectedVotes, *memberOptions[i].Votes)
+	}
+	assert.Equal(t, "0", *memberOptions[2].Priority)
+
+	t.Run("votes are kept without the ReduceVotes policy", func(t *testing.T) {
+		opsManager.Spec.Failover.Policy = v1.FailoverPolicyAnnotate
+		memberOptions := reconciler.generateMemberOptions(opsManager, nil)
+		require.Len(t, memberOptions, 3)
+		for _, options := range memberOptions {
+			assert.Equal(t, 1, *options.Votes)
+		}
+	})
+}
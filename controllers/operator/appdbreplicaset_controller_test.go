@@ -22,11 +22,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/snapshot"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status/pvc"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/agents"
@@ -44,6 +46,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumesnapshot"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
@@ -1427,6 +1430,39 @@ func TestAppDbPortIsConfigurable_WithAdditionalMongoConfig(t *testing.T) {
 	assert.Equal(t, int32(30000), appdbSvc.Spec.Ports[0].Port)
 }
 
+func TestAppDBRestoreFromSnapshot(t *testing.T) {
+	ctx := context.Background()
+	opsManager := DefaultOpsManagerBuilder().
+		SetBackup(omv1.MongoDBOpsManagerBackup{Enabled: false}).
+		SetAppDbMembers(3).
+		SetAppDBRestoreFromSnapshot(&v1.RestoreFromSnapshot{MongoDBVolumeSnapshotName: "appdb-snapshot"}).
+		Build()
+	omConnectionFactory := om.NewCachedOMConnectionFactory(om.NewEmptyMockedOmConnection)
+	omReconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", opsManager, nil, omConnectionFactory, architectures.NonStatic)
+
+	volumeSnapshot := &snapshot.MongoDBVolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "appdb-snapshot", Namespace: opsManager.Namespace},
+		Spec:       snapshot.MongoDBVolumeSnapshotSpec{Source: snapshot.SnapshotSource{Kind: snapshot.SourceKindAppDB, Name: opsManager.Name}},
+	}
+	require.NoError(t, client.Create(ctx, volumeSnapshot))
+	volumeSnapshot.Status.Volumes = []snapshot.VolumeSnapshotStatus{{VolumeName: opsManager.Spec.AppDB.DataVolumeName(), VolumeSnapshotName: "appdb-snapshot-data"}}
+	require.NoError(t, client.Status().Update(ctx, volumeSnapshot))
+
+	// the AppDB StatefulSet is not created before the snapshot is completed
+	checkOMReconciliationPending(ctx, t, omReconciler, opsManager)
+	_, err := client.GetStatefulSet(ctx, kube.ObjectKey(opsManager.Namespace, opsManager.Spec.AppDB.Name()))
+	assert.True(t, apiErrors.IsNotFound(err))
+
+	volumeSnapshot.Status.CompletedAt = time.Now().Format(time.RFC3339)
+	require.NoError(t, client.Status().Update(ctx, volumeSnapshot))
+	checkOMReconciliationSuccessful(ctx, t, omReconciler, opsManager, client)
+
+	sts, err := client.GetStatefulSet(ctx, kube.ObjectKey(opsManager.Namespace, opsManager.Spec.AppDB.Name()))
+	require.NoError(t, err)
+	assert.Equal(t, opsManager.Spec.AppDB.DataVolumeName(), sts.Spec.VolumeClaimTemplates[0].Name)
+	assert.Equal(t, volumesnapshot.DataSource("appdb-snapshot-data"), sts.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+}
+
 func TestAppDBSkipsReconciliation_IfAnyProcessesAreDisabled(t *testing.T) {
 	ctx := context.Background()
 	createReconcilerWithAllRequiredSecrets := func(opsManager *omv1.MongoDBOpsManager, createAutomationConfig bool) *ReconcileAppDbReplicaSet {
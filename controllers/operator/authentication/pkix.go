@@ -77,6 +77,16 @@ func GetCertificateSubject(certPEM string) (subject string, unknownOIDs []string
 	return enc.String(), unknownOIDs, nil
 }
 
+// DistinguishedName returns the RFC 2253 string representation of the RDN sequence, with the short names of the
+// attribute types that MongoDB uses for the names of the X.509 users (e.g. DC and UID instead of their OIDs).
+func DistinguishedName(rdns pkix.RDNSequence) (string, error) {
+	var enc encodeState
+	if _, err := enc.writeDistinguishedName(rdns); err != nil {
+		return "", err
+	}
+	return enc.String(), nil
+}
+
 func (enc *encodeState) writeDistinguishedName(subject pkix.RDNSequence) (allUnknownOIDs []string, err error) {
 	// Section 2.1. Converting the RDNSequence
 	//
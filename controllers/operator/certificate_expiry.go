@@ -0,0 +1,139 @@
+package operator
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+const (
+	CertificateExpiringReason = "CertificateExpiring"
+	CertificateExpiredReason  = "CertificateExpired"
+)
+
+// certificateExpiryResource is a database resource which reports the certificates expiring soon in its status.
+type certificateExpiryResource interface {
+	WatcherResource
+	client.Object
+	GetKind() string
+	GetStatusWarnings() []status.Warning
+	SetWarnings(warnings []status.Warning, options ...status.Option)
+}
+
+// certificateExpiryNotification identifies a certificate for which an Event was recorded, a renewed certificate is
+// identified by its new expiry.
+type certificateExpiryNotification struct {
+	namespace  string
+	secretName string
+	notAfter   time.Time
+}
+
+// readCertificateExpiryThresholds reads the thresholds at which the operator warns about the certificates expiring,
+// the default ones are used if the configuration is not valid.
+func readCertificateExpiryThresholds() []time.Duration {
+	thresholds, err := certs.ParseCertificateExpiryThresholds(env.ReadOrDefault(util.CertificateExpiryWarningDaysEnv, "")) // nolint:forbidigo
+	if err != nil {
+		zap.S().Warnf("Using the default certificate expiry thresholds: %s", err)
+		return certs.DefaultCertificateExpiryThresholds
+	}
+	return thresholds
+}
+
+// checkCertificatesExpiry records the expiry of the TLS, agent and internal cluster authentication certificates of the
+// database resource, and warns about the ones expiring soon both in its status and with Events. The secret names are
+// resolved the same way as in SetupCommonWatchers.
+func (r *ReconcileCommonController) checkCertificatesExpiry(ctx context.Context, resource certificateExpiryResource, getTLSSecretNames func() []string, getInternalAuthSecretNames func() []string, resourceNameForSecret string, log *zap.SugaredLogger) {
+	security := resource.GetSecurity()
+	secretNames := append(tlsSecretNames(security, getTLSSecretNames, resourceNameForSecret), internalAuthSecretNames(security, getInternalAuthSecretNames, resourceNameForSecret)...)
+
+	expiries, err := certs.ReadCertificatesExpiry(ctx, r.SecretClient, resource.GetNamespace(), secretNames, certs.Database)
+	if err != nil {
+		log.Warnf("Failed to check the expiry of the certificates: %s", err)
+	}
+	certs.SetCertificateExpiries(resource.GetNamespace(), resource.GetKind(), resource.GetName(), expiries)
+
+	warnings := r.certificateExpiryWarnings(resource, expiries, time.Now())
+	resource.SetWarnings(replaceCertificateExpiryWarnings(resource.GetStatusWarnings(), warnings))
+}
+
+// checkOpsManagerCertificatesExpiry records the expiry of the Ops Manager and AppDB certificates, and warns about the
+// ones expiring soon in the status of the matching part of the resource and with Events.
+func (r *ReconcileCommonController) checkOpsManagerCertificatesExpiry(ctx context.Context, opsManager *omv1.MongoDBOpsManager, log *zap.SugaredLogger) {
+	appDB := opsManager.Spec.AppDB
+	appDBSecretNames := append(tlsSecretNames(appDB.GetSecurity(), nil, appDB.GetName()), internalAuthSecretNames(appDB.GetSecurity(), nil, appDB.GetName())...)
+	appDBExpiries, err := certs.ReadCertificatesExpiry(ctx, r.SecretClient, opsManager.Namespace, appDBSecretNames, certs.AppDB)
+	if err != nil {
+		log.Warnf("Failed to check the expiry of the AppDB certificates: %s", err)
+	}
+
+	var opsManagerSecretNames []string
+	if opsManager.IsTLSEnabled() {
+		opsManagerSecretNames = []string{opsManager.TLSCertificateSecretName()}
+	}
+	opsManagerExpiries, err := certs.ReadCertificatesExpiry(ctx, r.SecretClient, opsManager.Namespace, opsManagerSecretNames, certs.OpsManager)
+	if err != nil {
+		log.Warnf("Failed to check the expiry of the Ops Manager certificates: %s", err)
+	}
+
+	expiries := maps.Clone(appDBExpiries)
+	maps.Copy(expiries, opsManagerExpiries)
+	certs.SetCertificateExpiries(opsManager.Namespace, opsManager.GetKind(), opsManager.Name, expiries)
+
+	now := time.Now()
+	appDBWarnings := r.certificateExpiryWarnings(opsManager, appDBExpiries, now)
+	opsManager.SetWarnings(replaceCertificateExpiryWarnings(opsManager.GetStatusWarnings(status.AppDb), appDBWarnings), status.NewOMPartOption(status.AppDb))
+	opsManagerWarnings := r.certificateExpiryWarnings(opsManager, opsManagerExpiries, now)
+	opsManager.SetWarnings(replaceCertificateExpiryWarnings(opsManager.GetStatusWarnings(status.OpsManager), opsManagerWarnings), status.NewOMPartOption(status.OpsManager))
+}
+
+// replaceCertificateExpiryWarnings replaces the certificate expiry warnings of a previous check with the current ones,
+// so that the warnings about a certificate which expired or was renewed since don't pile up in the status.
+func replaceCertificateExpiryWarnings(existing []status.Warning, warnings []status.Warning) []status.Warning {
+	replaced := status.Warnings(existing).RemoveIf(certs.IsCertificateExpiryWarning)
+	for _, warning := range warnings {
+		replaced = replaced.AddIfNotExists(warning)
+	}
+	return replaced
+}
+
+// certificateExpiryWarnings returns the warnings about the certificates which reached one of the expiry thresholds.
+// An Event is recorded on the resource each time a certificate reaches a closer threshold.
+func (r *ReconcileCommonController) certificateExpiryWarnings(resource client.Object, expiries map[string]time.Time, now time.Time) []status.Warning {
+	var warnings []status.Warning
+	for _, secretName := range slices.Sorted(maps.Keys(expiries)) {
+		notAfter := expiries[secretName]
+		notification := certificateExpiryNotification{namespace: resource.GetNamespace(), secretName: secretName, notAfter: notAfter}
+		threshold, reached := certs.CertificateExpiryThreshold(notAfter, now, r.certificateExpiryThresholds)
+		if !reached {
+			r.certificateExpiryNotifications.Delete(notification)
+			continue
+		}
+		message := certs.FormatCertificateExpiry(secretName, notAfter, now)
+		warnings = append(warnings, status.Warning(message))
+
+		if previous, notified := r.certificateExpiryNotifications.Load(notification); notified && previous.(time.Duration) <= threshold {
+			continue
+		}
+		r.certificateExpiryNotifications.Store(notification, threshold)
+		if r.recorder != nil {
+			reason := CertificateExpiringReason
+			if threshold == 0 {
+				reason = CertificateExpiredReason
+			}
+			r.recorder.Event(resource, corev1.EventTypeWarning, reason, message)
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,86 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
+)
+
+func TestReplicaSet_CertificateExpiryWarnings(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetMembers(2).EnableTLS().SetTLSCA("custom-ca").Build()
+	rs.Spec.Security.TLSConfig.CertManager = &v1.CertManagerConfig{IssuerRef: v1.CertManagerIssuerRef{Name: "my-issuer"}}
+	certSecretName := fmt.Sprintf("%s-cert", rs.Name)
+
+	reconciler, client, _ := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+	recorder := record.NewFakeRecorder(10)
+	reconciler.recorder = recorder
+	checkReconcilePending(ctx, t, reconciler, rs, fmt.Sprintf("Waiting for cert-manager to issue the certificate %s", certSecretName), client, 10)
+
+	dnsNames := certManagerCertificateDNSNames(ctx, t, client, certSecretName)
+	issueExpiringServerCertificate(ctx, t, client, certSecretName, dnsNames, time.Now().Add(5*24*time.Hour))
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	require.NoError(t, client.Get(ctx, rs.ObjectKey(), rs))
+	require.Len(t, rs.Status.Warnings, 1)
+	assert.Contains(t, string(rs.Status.Warnings[0]), fmt.Sprintf("The certificate in the secret %s expires on", certSecretName))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning CertificateExpiring")
+
+	t.Run("Events are recorded once per threshold", func(t *testing.T) {
+		checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+		assert.Empty(t, recorder.Events)
+
+		issueExpiringServerCertificate(ctx, t, client, certSecretName, dnsNames, time.Now().Add(-time.Hour))
+		checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "Warning CertificateExpired")
+	})
+	t.Run("Warnings of a previous check are replaced", func(t *testing.T) {
+		require.NoError(t, client.Get(ctx, rs.ObjectKey(), rs))
+		rs.Status.Warnings = []status.Warning{"The certificate in the secret my-rs-cert expires in 30 day(s), on 2026-01-01T00:00:00Z;", "Some other warning"}
+		reconciler.checkCertificatesExpiry(ctx, rs, nil, nil, rs.Name, zap.S())
+
+		require.Len(t, rs.Status.Warnings, 2)
+		assert.Equal(t, status.Warning("Some other warning;"), rs.Status.Warnings[0])
+		assert.Contains(t, string(rs.Status.Warnings[1]), fmt.Sprintf("The certificate in the secret %s expired on", certSecretName))
+	})
+	t.Run("Warnings are removed once the certificate is renewed", func(t *testing.T) {
+		issueExpiringServerCertificate(ctx, t, client, certSecretName, dnsNames, time.Now().AddDate(1, 0, 0))
+		checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+		require.NoError(t, client.Get(ctx, rs.ObjectKey(), rs))
+		assert.Empty(t, rs.Status.Warnings)
+		assert.Empty(t, recorder.Events)
+	})
+}
+
+// issueExpiringServerCertificate writes a server certificate Secret expiring at notAfter.
+func issueExpiringServerCertificate(ctx context.Context, t *testing.T, c client.Client, secretName string, dnsNames []string, notAfter time.Time) {
+	certPEM, keyPEM, err := mock.CreateTestServerCertificateExpiringAt(dnsNames, notAfter)
+	require.NoError(t, err)
+
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: mock.TestNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+	if err := c.Update(ctx, certSecret); err != nil {
+		require.NoError(t, c.Create(ctx, certSecret))
+	}
+}
@@ -34,7 +34,7 @@ type ReplicaSetX509CertConfigurator struct {
 var _ X509CertConfigurator = &ReplicaSetX509CertConfigurator{}
 
 func (rs ReplicaSetX509CertConfigurator) GetCertOptions() []Options {
-	return []Options{ReplicaSetConfig(*rs.MongoDB)}
+	return ReplicaSetConfigs(*rs.MongoDB)
 }
 
 func (rs ReplicaSetX509CertConfigurator) GetSecretReadClient() secrets.SecretClient {
@@ -185,6 +185,29 @@ func ReplicaSetConfig(mdb mdbv1.MongoDB) Options {
 	}
 }
 
+// ReplicaSetZoneConfig returns the configuration options of the StatefulSet of one zone of a Replica Set with
+// spec.zonePlacement. The certificates are shared by all the zones.
+func ReplicaSetZoneConfig(mdb mdbv1.MongoDB, scaler interfaces.MultiClusterReplicaSetScaler) Options {
+	opts := ReplicaSetConfig(mdb)
+	opts.ResourceName = mdb.ZoneStatefulSetName(scaler.MemberClusterNum())
+	opts.Replicas = scale.ReplicasThisReconciliation(scaler)
+	return opts
+}
+
+// ReplicaSetConfigs returns the configuration options of all the StatefulSets of the given Replica Set: one for each
+// zone if the members are placed in zones.
+func ReplicaSetConfigs(mdb mdbv1.MongoDB) []Options {
+	if mdb.Spec.ZonePlacement == nil {
+		return []Options{ReplicaSetConfig(mdb)}
+	}
+
+	var opts []Options
+	for _, scaler := range scalers.GetReplicaSetZoneScalers(&mdb) {
+		opts = append(opts, ReplicaSetZoneConfig(mdb, scaler))
+	}
+	return opts
+}
+
 func AppDBReplicaSetConfig(om *omv1.MongoDBOpsManager) Options {
 	mdb := om.Spec.AppDB
 	opts := Options{
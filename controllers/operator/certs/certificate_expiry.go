@@ -0,0 +1,219 @@
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+const (
+	CertificateExpiryMetricName = "mongodb_operator_certificate_expiry_seconds"
+
+	certificateExpiryWarningPrefix = "The certificate in the secret "
+)
+
+// DefaultCertificateExpiryThresholds are the thresholds used when util.CertificateExpiryWarningDaysEnv is not set.
+var DefaultCertificateExpiryThresholds = []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour}
+
+// certificateExpiryKey identifies a certificate consumed by the operator for a resource.
+type certificateExpiryKey struct {
+	namespace string
+	kind      string
+	resource  string
+	secret    string
+}
+
+// certificateExpiryCollector exposes the number of seconds left before the certificates consumed by the operator
+// expire. The value is computed when the metric is scraped, so it keeps decreasing between two reconciliations.
+type certificateExpiryCollector struct {
+	mu       sync.RWMutex
+	desc     *prometheus.Desc
+	expiries map[certificateExpiryKey]time.Time
+}
+
+var certificateExpiry = &certificateExpiryCollector{
+	desc: prometheus.NewDesc(CertificateExpiryMetricName,
+		"Number of seconds before the certificate expires, partitioned by namespace, kind, resource and secret.",
+		[]string{"namespace", "kind", "resource", "secret"}, nil),
+	expiries: map[certificateExpiryKey]time.Time{},
+}
+
+func init() {
+	metrics.Registry.MustRegister(certificateExpiry)
+}
+
+func (c *certificateExpiryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *certificateExpiryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for key, notAfter := range c.expiries {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Until(notAfter).Seconds(), key.namespace, key.kind, key.resource, key.secret)
+	}
+}
+
+// SetCertificateExpiries replaces the expiries recorded for the certificates of the resource, the certificates no
+// longer consumed by the resource stop being exposed.
+func SetCertificateExpiries(namespace, kind, resource string, expiries map[string]time.Time) {
+	certificateExpiry.mu.Lock()
+	defer certificateExpiry.mu.Unlock()
+	certificateExpiry.forget(namespace, kind, resource)
+	for secretName, notAfter := range expiries {
+		certificateExpiry.expiries[certificateExpiryKey{namespace: namespace, kind: kind, resource: resource, secret: secretName}] = notAfter
+	}
+}
+
+// ForgetCertificateExpiries stops exposing the expiries of the certificates of the resource, it is called when the
+// resource is removed.
+func ForgetCertificateExpiries(namespace, kind, resource string) {
+	certificateExpiry.mu.Lock()
+	defer certificateExpiry.mu.Unlock()
+	certificateExpiry.forget(namespace, kind, resource)
+}
+
+func (c *certificateExpiryCollector) forget(namespace, kind, resource string) {
+	for key := range c.expiries {
+		if key.namespace == namespace && key.kind == kind && key.resource == resource {
+			delete(c.expiries, key)
+		}
+	}
+}
+
+// ReadCertificateExpiry returns the earliest expiry of the certificates stored in the secret. Both the
+// kubernetes.io/tls secrets and the secrets containing one PEM file per entry are supported.
+func ReadCertificateExpiry(ctx context.Context, secretClient secrets.SecretClient, secretName types.NamespacedName, podType certDestination) (time.Time, error) {
+	basePath, err := getSecretBasePath(secretClient, podType)
+	if err != nil {
+		return time.Time{}, err
+	}
+	secretData, err := secretClient.ReadBinarySecret(ctx, secretName, basePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return earliestCertificateExpiry(secretData)
+}
+
+// ReadCertificatesExpiry returns the earliest expiry of the certificates stored in each of the secrets. The secrets which
+// don't exist yet are ignored, as they are reported when the certificates are validated.
+func ReadCertificatesExpiry(ctx context.Context, secretClient secrets.SecretClient, namespace string, secretNames []string, podType certDestination) (map[string]time.Time, error) {
+	expiries := map[string]time.Time{}
+	var errs error
+	for _, secretName := range secretNames {
+		notAfter, err := ReadCertificateExpiry(ctx, secretClient, kube.ObjectKey(namespace, secretName), podType)
+		if err != nil {
+			if !secret.SecretNotExist(err) {
+				errs = multierror.Append(errs, xerrors.Errorf("can't read the expiry of the certificate in the secret %s: %w", secretName, err))
+			}
+			continue
+		}
+		expiries[secretName] = notAfter
+	}
+	return expiries, errs
+}
+
+// earliestCertificateExpiry returns the earliest NotAfter of all the certificates in the secret data. All the
+// certificates of the chain are considered, as any of them expiring makes the chain invalid.
+func earliestCertificateExpiry(secretData map[string][]byte) (time.Time, error) {
+	entries := map[string][]byte{}
+	if crt, ok := secretData[corev1.TLSCertKey]; ok {
+		entries[corev1.TLSCertKey] = crt
+	} else {
+		for key, value := range secretData {
+			if key == util.LatestHashSecretKey || key == util.PreviousHashSecretKey {
+				continue
+			}
+			entries[key] = value
+		}
+	}
+
+	var earliest time.Time
+	for key, value := range entries {
+		for block, rest := pem.Decode(value); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return time.Time{}, xerrors.Errorf("can't parse the certificate in the entry %s: %w", key, err)
+			}
+			if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+				earliest = cert.NotAfter
+			}
+		}
+	}
+	if earliest.IsZero() {
+		return time.Time{}, xerrors.Errorf("no certificate found in the secret")
+	}
+	return earliest, nil
+}
+
+// ParseCertificateExpiryThresholds parses the comma separated list of days configured with
+// util.CertificateExpiryWarningDaysEnv.
+func ParseCertificateExpiryThresholds(value string) ([]time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultCertificateExpiryThresholds, nil
+	}
+	var thresholds []time.Duration
+	for _, days := range strings.Split(value, ",") {
+		d, err := strconv.Atoi(strings.TrimSpace(days))
+		if err != nil || d <= 0 {
+			return nil, xerrors.Errorf("invalid number of days %q in %s, expected a comma separated list of positive integers", days, util.CertificateExpiryWarningDaysEnv)
+		}
+		thresholds = append(thresholds, time.Duration(d)*24*time.Hour)
+	}
+	return thresholds, nil
+}
+
+// CertificateExpiryThreshold returns the closest threshold the certificate expiring at notAfter has reached, or 0 if
+// it has already expired, and false if it hasn't reached any.
+func CertificateExpiryThreshold(notAfter, now time.Time, thresholds []time.Duration) (time.Duration, bool) {
+	remaining := notAfter.Sub(now)
+	if remaining <= 0 {
+		return 0, true
+	}
+	reached := false
+	var threshold time.Duration
+	for _, t := range thresholds {
+		if remaining <= t && (!reached || t < threshold) {
+			threshold = t
+			reached = true
+		}
+	}
+	return threshold, reached
+}
+
+// FormatCertificateExpiry describes when the certificate stored in the secret expires, for the warnings raised by the
+// operator. The message only changes when the certificate expires or is renewed, so that the status of the resource
+// isn't updated every day.
+func FormatCertificateExpiry(secretName string, notAfter, now time.Time) string {
+	if !notAfter.After(now) {
+		return fmt.Sprintf("%s%s expired on %s", certificateExpiryWarningPrefix, secretName, notAfter.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s%s expires on %s", certificateExpiryWarningPrefix, secretName, notAfter.UTC().Format(time.RFC3339))
+}
+
+// IsCertificateExpiryWarning returns true if the warning was built by FormatCertificateExpiry.
+func IsCertificateExpiryWarning(warning status.Warning) bool {
+	return strings.HasPrefix(string(warning), certificateExpiryWarningPrefix)
+}
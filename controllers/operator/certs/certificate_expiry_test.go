@@ -0,0 +1,136 @@
+package certs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func TestEarliestCertificateExpiry(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	leaf, key, err := mock.CreateTestServerCertificateExpiringAt([]string{"my-rs-0"}, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	intermediate, _, err := mock.CreateTestServerCertificateExpiringAt(nil, now.Add(24*time.Hour))
+	require.NoError(t, err)
+
+	t.Run("The earliest expiry of the chain is returned", func(t *testing.T) {
+		chain := append(append([]byte{}, leaf...), intermediate...)
+		notAfter, err := earliestCertificateExpiry(map[string][]byte{corev1.TLSCertKey: chain, corev1.TLSPrivateKeyKey: key})
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(24*time.Hour).UTC(), notAfter)
+	})
+	t.Run("Secrets with one PEM file per entry are supported", func(t *testing.T) {
+		notAfter, err := earliestCertificateExpiry(map[string][]byte{
+			"my-rs-0-pem":              append(append([]byte{}, leaf...), key...),
+			util.LatestHashSecretKey:   []byte("hash"),
+			util.PreviousHashSecretKey: []byte("hash"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(48*time.Hour).UTC(), notAfter)
+	})
+	t.Run("Secrets without certificates are rejected", func(t *testing.T) {
+		_, err := earliestCertificateExpiry(map[string][]byte{corev1.TLSPrivateKeyKey: key})
+		assert.Error(t, err)
+	})
+}
+
+func TestReadCertificatesExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := mock.NewEmptyFakeClientBuilder().Build()
+	notAfter := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	cert, key, err := mock.CreateTestServerCertificateExpiringAt([]string{"my-rs-0"}, notAfter)
+	require.NoError(t, err)
+	require.NoError(t, c.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-rs-cert", Namespace: mock.TestNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key},
+	}))
+
+	// the secrets which don't exist yet are ignored
+	expiries, err := ReadCertificatesExpiry(ctx, secrets.SecretClient{KubeClient: kubernetesClient.NewClient(c)}, mock.TestNamespace, []string{"my-rs-cert", "my-rs-clusterfile"}, Database)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"my-rs-cert": notAfter.UTC()}, expiries)
+}
+
+func TestParseCertificateExpiryThresholds(t *testing.T) {
+	thresholds, err := ParseCertificateExpiryThresholds("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCertificateExpiryThresholds, thresholds)
+
+	thresholds, err = ParseCertificateExpiryThresholds("14, 3")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{14 * 24 * time.Hour, 3 * 24 * time.Hour}, thresholds)
+
+	for _, value := range []string{"30,seven", "30,0", "30,-1", "30,"} {
+		_, err := ParseCertificateExpiryThresholds(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCertificateExpiryThreshold(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	tests := []struct {
+		remaining time.Duration
+		threshold time.Duration
+		reached   bool
+	}{
+		{remaining: 60 * day},
+		{remaining: 30 * day, threshold: 30 * day, reached: true},
+		{remaining: 10 * day, threshold: 30 * day, reached: true},
+		{remaining: 5 * day, threshold: 7 * day, reached: true},
+		{remaining: time.Hour, threshold: day, reached: true},
+		{remaining: -time.Hour, threshold: 0, reached: true},
+	}
+	for _, tt := range tests {
+		threshold, reached := CertificateExpiryThreshold(now.Add(tt.remaining), now, DefaultCertificateExpiryThresholds)
+		assert.Equal(t, tt.reached, reached, tt.remaining)
+		assert.Equal(t, tt.threshold, threshold, tt.remaining)
+	}
+}
+
+func TestSetCertificateExpiries(t *testing.T) {
+	notAfter := time.Now().Add(time.Hour)
+	SetCertificateExpiries("my-namespace", "MongoDB", "my-rs", map[string]time.Time{"my-rs-cert": notAfter, "my-rs-clusterfile": notAfter})
+	SetCertificateExpiries("my-namespace", "MongoDB", "my-other-rs", map[string]time.Time{"my-other-rs-cert": notAfter})
+	// an Ops Manager resource with the same name doesn't replace the certificates of the MongoDB resource
+	SetCertificateExpiries("my-namespace", "MongoDBOpsManager", "my-rs", map[string]time.Time{"my-rs-db-cert": notAfter})
+
+	// the certificates no longer consumed stop being exposed
+	SetCertificateExpiries("my-namespace", "MongoDB", "my-rs", map[string]time.Time{"my-rs-cert": notAfter})
+	assert.Contains(t, certificateExpiry.expiries, certificateExpiryKey{namespace: "my-namespace", kind: "MongoDB", resource: "my-rs", secret: "my-rs-cert"})
+	assert.NotContains(t, certificateExpiry.expiries, certificateExpiryKey{namespace: "my-namespace", kind: "MongoDB", resource: "my-rs", secret: "my-rs-clusterfile"})
+	assert.Contains(t, certificateExpiry.expiries, certificateExpiryKey{namespace: "my-namespace", kind: "MongoDBOpsManager", resource: "my-rs", secret: "my-rs-db-cert"})
+
+	ForgetCertificateExpiries("my-namespace", "MongoDB", "my-rs")
+	assert.NotContains(t, certificateExpiry.expiries, certificateExpiryKey{namespace: "my-namespace", kind: "MongoDB", resource: "my-rs", secret: "my-rs-cert"})
+	assert.Contains(t, certificateExpiry.expiries, certificateExpiryKey{namespace: "my-namespace", kind: "MongoDB", resource: "my-other-rs", secret: "my-other-rs-cert"})
+	assert.Contains(t, certificateExpiry.expiries, certificateExpiryKey{namespace: "my-namespace", kind: "MongoDBOpsManager", resource: "my-rs", secret: "my-rs-db-cert"})
+}
+
+func TestFormatCertificateExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	expiring := FormatCertificateExpiry("my-rs-cert", now.Add(5*24*time.Hour), now)
+	assert.Equal(t, "The certificate in the secret my-rs-cert expires on 2026-10-23T12:00:00Z", expiring)
+	// the message doesn't change from one day to the next
+	assert.Equal(t, expiring, FormatCertificateExpiry("my-rs-cert", now.Add(5*24*time.Hour), now.Add(48*time.Hour)))
+	assert.True(t, IsCertificateExpiryWarning(status.Warning(expiring)))
+
+	expired := FormatCertificateExpiry("my-rs-cert", now.Add(-time.Hour), now)
+	assert.Equal(t, "The certificate in the secret my-rs-cert expired on 2026-10-18T11:00:00Z", expired)
+	assert.True(t, IsCertificateExpiryWarning(status.Warning(expired)))
+
+	assert.False(t, IsCertificateExpiryWarning("The resource is not ready"))
+}
@@ -24,7 +24,6 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/stringutil"
-	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
 )
 
 type certDestination string
@@ -41,7 +40,7 @@ const (
 // CreateOrUpdatePEMSecretWithPreviousCert creates a PEM secret from the original secretName.
 // Additionally, this method verifies if there already exists a PEM secret, and it will merge them to be able to keep the newest and the previous certificate.
 func CreateOrUpdatePEMSecretWithPreviousCert(ctx context.Context, secretClient secrets.SecretClient, secretNamespacedName types.NamespacedName, certificateKey string, certificateValue string, ownerReferences []metav1.OwnerReference, podType certDestination) error {
-	path, err := getVaultBasePath(secretClient, podType)
+	path, err := getSecretBasePath(secretClient, podType)
 	if err != nil {
 		return err
 	}
@@ -58,7 +57,7 @@ func CreateOrUpdatePEMSecretWithPreviousCert(ctx context.Context, secretClient s
 func CreateOrUpdatePEMSecret(ctx context.Context, secretClient secrets.SecretClient, secretNamespacedName types.NamespacedName, secretData map[string]string, ownerReferences []metav1.OwnerReference, podType certDestination) error {
 	operatorGeneratedSecret := getOperatorGeneratedSecret(secretNamespacedName)
 
-	path, err := getVaultBasePath(secretClient, podType)
+	path, err := getSecretBasePath(secretClient, podType)
 	if err != nil {
 		return err
 	}
@@ -111,17 +110,17 @@ func updateSecretDataWithPreviousCert(ctx context.Context, secretClient secrets.
 	return newData, nil
 }
 
-// getVaultBasePath returns the path to secrets in the vault
-func getVaultBasePath(secretClient secrets.SecretClient, podType certDestination) (string, error) {
+// getSecretBasePath returns the path to the secrets of the pod type in the secret backend
+func getSecretBasePath(secretClient secrets.SecretClient, podType certDestination) (string, error) {
 	var path string
-	if vault.IsVaultSecretBackend() && podType != Unused {
+	if !secrets.IsKubernetesSecretBackend() && podType != Unused {
 		switch podType {
 		case Database:
-			path = secretClient.VaultClient.DatabaseSecretPath()
+			path = secretClient.DatabaseSecretPath()
 		case OpsManager:
-			path = secretClient.VaultClient.OpsManagerSecretPath()
+			path = secretClient.OpsManagerSecretPath()
 		case AppDB:
-			path = secretClient.VaultClient.AppDBSecretPath()
+			path = secretClient.AppDBSecretPath()
 		default:
 			return "", xerrors.Errorf("unexpected pod type got: %s", podType)
 		}
@@ -178,9 +177,12 @@ func VerifyAndEnsureCertificatesForStatefulSet(ctx context.Context, secretReadCl
 	var s corev1.Secret
 	var databaseSecretPath string
 
-	if vault.IsVaultSecretBackend() {
-		databaseSecretPath = secretReadClient.VaultClient.DatabaseSecretPath()
-		secretData, err = secretReadClient.VaultClient.ReadSecretBytes(fmt.Sprintf("%s/%s/%s", databaseSecretPath, opts.Namespace, secretName))
+	if !secrets.IsKubernetesSecretBackend() {
+		databaseSecretPath, err = getSecretBasePath(secretReadClient, Database)
+		if err != nil {
+			return err
+		}
+		secretData, err = secretReadClient.ReadBinarySecret(ctx, kube.ObjectKey(opts.Namespace, secretName), databaseSecretPath)
 		if err != nil {
 			return err
 		}
@@ -305,9 +307,12 @@ func VerifyAndEnsureClientCertificatesForAgentsAndTLSType(ctx context.Context, s
 	var err error
 	var databaseSecretPath string
 
-	if vault.IsVaultSecretBackend() {
-		databaseSecretPath = secretReadClient.VaultClient.DatabaseSecretPath()
-		secretData, err = secretReadClient.VaultClient.ReadSecretBytes(fmt.Sprintf("%s/%s/%s", secretReadClient.VaultClient.DatabaseSecretPath(), secret.Namespace, secret.Name))
+	if !secrets.IsKubernetesSecretBackend() {
+		databaseSecretPath, err = getSecretBasePath(secretReadClient, Database)
+		if err != nil {
+			return err
+		}
+		secretData, err = secretReadClient.ReadBinarySecret(ctx, secret, databaseSecretPath)
 		if err != nil {
 			return err
 		}
@@ -359,16 +364,15 @@ func EnsureTLSCertsForPrometheus(ctx context.Context, secretClient secrets.Secre
 	var err error
 
 	var secretPath string
-	if vault.IsVaultSecretBackend() {
+	if !secrets.IsKubernetesSecretBackend() {
 		// TODO: This is calculated twice, can this be done better?
 		// This "calculation" is used in ReadHashFromSecret but calculated again in `CreateOrUpdatePEMSecretWithPreviousCert`
-		if podType == Database {
-			secretPath = secretClient.VaultClient.DatabaseSecretPath()
-		} else if podType == AppDB {
-			secretPath = secretClient.VaultClient.AppDBSecretPath()
+		secretPath, err = getSecretBasePath(secretClient, podType)
+		if err != nil {
+			return "", err
 		}
 
-		secretData, err = secretClient.VaultClient.ReadSecretBytes(fmt.Sprintf("%s/%s/%s", secretPath, namespace, prom.TLSSecretRef.Name))
+		secretData, err = secretClient.ReadBinarySecret(ctx, kube.ObjectKey(namespace, prom.TLSSecretRef.Name), secretPath)
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,207 @@
+package certs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/stringutil"
+)
+
+// CertManagerCertificateGVK is the kind of the cert-manager Certificates. cert-manager is an optional dependency, so
+// the Certificates are managed as unstructured objects.
+var CertManagerCertificateGVK = schema.GroupVersionKind{Group: v1.CertManagerGroup, Version: "v1", Kind: "Certificate"}
+
+const (
+	CertManagerUsageClientAuth       = "client auth"
+	CertManagerUsageServerAuth       = "server auth"
+	CertManagerUsageDigitalSignature = "digital signature"
+	CertManagerUsageKeyEncipherment  = "key encipherment"
+)
+
+// CertManagerCertificate is the cert-manager Certificate requested by the Operator. cert-manager stores the issued
+// certificate in the tls.crt, tls.key and ca.crt keys of the Secret and renews it before it expires.
+type CertManagerCertificate struct {
+	Name            string
+	Namespace       string
+	SecretName      string
+	IssuerRef       v1.CertManagerIssuerRef
+	LiteralSubject  string
+	CommonName      string
+	DNSNames        []string
+	Usages          []string
+	Duration        time.Duration
+	RenewBefore     time.Duration
+	OwnerReferences []metav1.OwnerReference
+}
+
+func (c CertManagerCertificate) toUnstructured() *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"secretName": c.SecretName,
+		"issuerRef": map[string]interface{}{
+			"name":  c.IssuerRef.Name,
+			"kind":  c.IssuerRef.GetKind(),
+			"group": c.IssuerRef.GetGroup(),
+		},
+		"usages": toInterfaceSlice(c.Usages),
+		"privateKey": map[string]interface{}{
+			// the private key is recreated on each renewal
+			"rotationPolicy": "Always",
+		},
+	}
+	if c.LiteralSubject != "" {
+		spec["literalSubject"] = c.LiteralSubject
+	}
+	if c.CommonName != "" {
+		spec["commonName"] = c.CommonName
+	}
+	if len(c.DNSNames) > 0 {
+		spec["dnsNames"] = toInterfaceSlice(c.DNSNames)
+	}
+	if c.Duration > 0 {
+		spec["duration"] = c.Duration.String()
+	}
+	if c.RenewBefore > 0 {
+		spec["renewBefore"] = c.RenewBefore.String()
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	certificate.SetGroupVersionKind(CertManagerCertificateGVK)
+	certificate.SetName(c.Name)
+	certificate.SetNamespace(c.Namespace)
+	certificate.SetOwnerReferences(c.OwnerReferences)
+	return certificate
+}
+
+// CreateOrUpdateCertManagerCertificate creates the cert-manager Certificate, or updates its spec if it already exists.
+func CreateOrUpdateCertManagerCertificate(ctx context.Context, c client.Client, certificate CertManagerCertificate) error {
+	desired := certificate.toUnstructured()
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(CertManagerCertificateGVK)
+	err := c.Get(ctx, kube.ObjectKey(certificate.Namespace, certificate.Name), existing)
+	if apiErrors.IsNotFound(err) {
+		if err := c.Create(ctx, desired); err != nil {
+			return xerrors.Errorf("failed to create cert-manager Certificate %s/%s: %w", certificate.Namespace, certificate.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("failed to get cert-manager Certificate %s/%s, check that cert-manager is installed: %w", certificate.Namespace, certificate.Name, err)
+	}
+
+	existing.Object["spec"] = desired.Object["spec"]
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+	if err := c.Update(ctx, existing); err != nil {
+		return xerrors.Errorf("failed to update cert-manager Certificate %s/%s: %w", certificate.Namespace, certificate.Name, err)
+	}
+	return nil
+}
+
+// EnsureCertManagerCertificateForStatefulSets requests from cert-manager the member certificate shared by the
+// StatefulSets described by opts, if enabled in the security settings. In multi-cluster deployments opts contains the
+// options of every member cluster, as the certificate has to be valid for the members of all of them.
+func EnsureCertManagerCertificateForStatefulSets(ctx context.Context, c client.Client, ms mdbv1.Security, ownerReferences []metav1.OwnerReference, opts ...Options) workflow.Status {
+	if !ms.IsTLSEnabled() || ms.TLSConfig == nil || ms.TLSConfig.CertManager == nil || len(opts) == 0 {
+		return workflow.OK()
+	}
+
+	var hostnames []string
+	for _, o := range opts {
+		hostnames = append(hostnames, GetCertificateHostnames(o)...)
+	}
+	return EnsureCertManagerCertificate(ctx, c, *ms.TLSConfig.CertManager, kube.ObjectKey(opts[0].Namespace, opts[0].CertSecretName), hostnames, ownerReferences)
+}
+
+// GetCertificateHostnames returns the hostnames the certificate of the members of the StatefulSet described by opts
+// has to be valid for: the hostnames of the pods, the additional certificate domains, the horizons and the external
+// domain.
+func GetCertificateHostnames(opts Options) []string {
+	hostnames, podNames := GetDNSNames(opts)
+	if opts.Topology == mdbv1.ClusterTopologyMultiCluster {
+		// in multi-cluster deployments every pod is reached through its own service
+		for i := range podNames {
+			hostnames[i] = fmt.Sprintf("%s-svc.%s", podNames[i], dns.GetServiceDomain(opts.Namespace, strings.TrimPrefix(opts.ClusterDomain, "."), nil))
+		}
+	}
+
+	for i := range podNames {
+		hostnames = append(hostnames, GetAdditionalCertDomainsForMember(opts, i)...)
+	}
+	if opts.ExternalDomain != nil && *opts.ExternalDomain != "" {
+		hostnames = append(hostnames, "*."+*opts.ExternalDomain)
+	}
+	return hostnames
+}
+
+// EnsureCertManagerCertificate requests from cert-manager the certificate stored in the Secret secretName, valid for
+// all the hostnames. Returns Pending until cert-manager has issued a certificate valid for all of them, which is the
+// case after the hostnames have changed, for example when the resource is scaled.
+func EnsureCertManagerCertificate(ctx context.Context, c client.Client, config v1.CertManagerConfig, secretName types.NamespacedName, hostnames []string, ownerReferences []metav1.OwnerReference) workflow.Status {
+	if !secrets.IsKubernetesSecretBackend() {
+		return workflow.Invalid("certificates can't be requested from cert-manager when the secrets are not stored in Kubernetes")
+	}
+
+	dnsNames := slices.Clone(hostnames)
+	slices.Sort(dnsNames)
+	dnsNames = slices.Compact(dnsNames)
+
+	certificate := CertManagerCertificate{
+		Name:            secretName.Name,
+		Namespace:       secretName.Namespace,
+		SecretName:      secretName.Name,
+		IssuerRef:       config.IssuerRef,
+		DNSNames:        dnsNames,
+		Usages:          []string{CertManagerUsageServerAuth, CertManagerUsageClientAuth, CertManagerUsageDigitalSignature, CertManagerUsageKeyEncipherment},
+		OwnerReferences: ownerReferences,
+	}
+	if err := CreateOrUpdateCertManagerCertificate(ctx, c, certificate); err != nil {
+		return workflow.Failed(err)
+	}
+
+	certSecret := &corev1.Secret{}
+	err := c.Get(ctx, secretName, certSecret)
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return workflow.Failed(xerrors.Errorf("failed to read certificate secret %s: %w", secretName.Name, err))
+	}
+	if apiErrors.IsNotFound(err) || len(certSecret.Data[corev1.TLSCertKey]) == 0 {
+		return workflow.Pending("Waiting for cert-manager to issue the certificate %s", secretName.Name).WithRetry(10)
+	}
+
+	cert, err := ParseCertificatePEM(certSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to read the certificate in secret %s: %w", secretName.Name, err))
+	}
+	for _, hostname := range dnsNames {
+		if !stringutil.CheckCertificateAddresses(cert.DNSNames, hostname) {
+			return workflow.Pending("Waiting for cert-manager to reissue the certificate %s for the hostname %s", secretName.Name, hostname).WithRetry(10)
+		}
+	}
+	return workflow.OK()
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package certs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+)
+
+func TestGetCertificateHostnames(t *testing.T) {
+	t.Run("Single cluster", func(t *testing.T) {
+		opts := Options{
+			ResourceName:                 "my-rs",
+			ServiceName:                  "my-rs-svc",
+			Namespace:                    "my-namespace",
+			Replicas:                     2,
+			additionalCertificateDomains: []string{"example.com"},
+			ExternalDomain:               ptr.To("ext.example.com"),
+		}
+		assert.Equal(t, []string{
+			"my-rs-0.my-rs-svc.my-namespace.svc.cluster.local",
+			"my-rs-1.my-rs-svc.my-namespace.svc.cluster.local",
+			"my-rs-0.example.com",
+			"my-rs-1.example.com",
+			"*.ext.example.com",
+		}, GetCertificateHostnames(opts))
+	})
+	t.Run("Multi cluster", func(t *testing.T) {
+		opts := Options{
+			ResourceName:  "my-rs-1",
+			Namespace:     "my-namespace",
+			Replicas:      2,
+			ClusterDomain: "custom.domain",
+			Topology:      mdbv1.ClusterTopologyMultiCluster,
+		}
+		assert.Equal(t, []string{
+			"my-rs-1-0-svc.my-namespace.svc.custom.domain",
+			"my-rs-1-1-svc.my-namespace.svc.custom.domain",
+		}, GetCertificateHostnames(opts))
+	})
+}
+
+func TestEnsureCertManagerCertificate(t *testing.T) {
+	ctx := context.Background()
+	c := mock.NewEmptyFakeClientBuilder().Build()
+	config := v1.CertManagerConfig{IssuerRef: v1.CertManagerIssuerRef{Name: "my-issuer"}}
+	secretName := kube.ObjectKey(mock.TestNamespace, "my-rs-cert")
+	hostnames := []string{"my-rs-1.my-rs-svc", "my-rs-0.my-rs-svc", "my-rs-0.my-rs-svc"}
+
+	status := EnsureCertManagerCertificate(ctx, c, config, secretName, hostnames, nil)
+	assert.Equal(t, workflow.Pending("Waiting for cert-manager to issue the certificate my-rs-cert"), status)
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertManagerCertificateGVK)
+	require.NoError(t, c.Get(ctx, secretName, certificate))
+	dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"my-rs-0.my-rs-svc", "my-rs-1.my-rs-svc"}, dnsNames)
+	usages, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "usages")
+	assert.Contains(t, usages, CertManagerUsageServerAuth)
+	assert.Contains(t, usages, CertManagerUsageClientAuth)
+
+	t.Run("Pending until the certificate is valid for all the hostnames", func(t *testing.T) {
+		createCertManagerSecret(ctx, t, c, secretName.Name, []string{"my-rs-0.my-rs-svc"})
+
+		status := EnsureCertManagerCertificate(ctx, c, config, secretName, hostnames, nil)
+		assert.Equal(t, workflow.Pending("Waiting for cert-manager to reissue the certificate my-rs-cert for the hostname my-rs-1.my-rs-svc"), status)
+	})
+	t.Run("Certificate is valid", func(t *testing.T) {
+		createCertManagerSecret(ctx, t, c, secretName.Name, []string{"my-rs-0.my-rs-svc", "my-rs-1.my-rs-svc"})
+
+		status := EnsureCertManagerCertificate(ctx, c, config, secretName, hostnames, nil)
+		assert.True(t, status.IsOK())
+	})
+}
+
+// createCertManagerSecret writes the certificate Secret the way cert-manager does.
+func createCertManagerSecret(ctx context.Context, t *testing.T, c client.Client, name string, dnsNames []string) {
+	certPEM, keyPEM, err := mock.CreateTestServerCertificate(dnsNames)
+	require.NoError(t, err)
+
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: mock.TestNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+	if err := c.Update(ctx, certSecret); err != nil {
+		require.NoError(t, c.Create(ctx, certSecret))
+	}
+}
@@ -0,0 +1,180 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
+)
+
+// distinguishedNameAttributes maps the attribute types allowed in a distinguished name to their OIDs.
+var distinguishedNameAttributes = map[string]asn1.ObjectIdentifier{
+	"CN":           {2, 5, 4, 3},
+	"SERIALNUMBER": {2, 5, 4, 5},
+	"C":            {2, 5, 4, 6},
+	"L":            {2, 5, 4, 7},
+	"ST":           {2, 5, 4, 8},
+	"STREET":       {2, 5, 4, 9},
+	"O":            {2, 5, 4, 10},
+	"OU":           {2, 5, 4, 11},
+	"POSTALCODE":   {2, 5, 4, 17},
+	"UID":          {0, 9, 2342, 19200300, 100, 1, 1},
+	"DC":           {0, 9, 2342, 19200300, 100, 1, 25},
+}
+
+// ParseDistinguishedName parses an RFC 4514 distinguished name, as used for the names of the X.509 users, into the
+// sequence of RDNs of a certificate subject. The order of the RDNs is reversed, as the string representation starts
+// with the last RDN of the sequence.
+func ParseDistinguishedName(dn string) (pkix.RDNSequence, error) {
+	if strings.TrimSpace(dn) == "" {
+		return nil, xerrors.Errorf("distinguished name is empty")
+	}
+
+	var sequence pkix.RDNSequence
+	for _, rdn := range splitUnescaped(dn, ',') {
+		var set pkix.RelativeDistinguishedNameSET
+		for _, attribute := range splitUnescaped(rdn, '+') {
+			attributeType, value, found := strings.Cut(attribute, "=")
+			if !found {
+				return nil, xerrors.Errorf("invalid attribute %q in distinguished name %q", attribute, dn)
+			}
+			oid, ok := distinguishedNameAttributes[strings.ToUpper(strings.TrimSpace(attributeType))]
+			if !ok {
+				return nil, xerrors.Errorf("unsupported attribute type %q in distinguished name %q", attributeType, dn)
+			}
+			unescaped, err := unescapeAttributeValue(strings.TrimSpace(value))
+			if err != nil {
+				return nil, xerrors.Errorf("invalid value of attribute %q in distinguished name %q: %w", attributeType, dn, err)
+			}
+			set = append(set, pkix.AttributeTypeAndValue{Type: oid, Value: unescaped})
+		}
+		sequence = append(pkix.RDNSequence{set}, sequence...)
+	}
+	return sequence, nil
+}
+
+// splitUnescaped splits the string on the separators not escaped with a backslash.
+func splitUnescaped(s string, separator byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case separator:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescapeAttributeValue removes the escaping of special characters, either "\," or the hex form "\2C".
+func unescapeAttributeValue(value string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			sb.WriteByte(value[i])
+			continue
+		}
+		if i+2 < len(value) && isHexPair(value[i+1:i+3]) {
+			decoded, _ := hex.DecodeString(value[i+1 : i+3])
+			sb.Write(decoded)
+			i += 2
+			continue
+		}
+		if i+1 >= len(value) {
+			return "", xerrors.Errorf("value ends with an escape character")
+		}
+		sb.WriteByte(value[i+1])
+		i++
+	}
+	return sb.String(), nil
+}
+
+func isHexPair(s string) bool {
+	_, err := hex.DecodeString(s)
+	return len(s) == 2 && err == nil
+}
+
+// IssueClientCertificate issues a client certificate with the given subject, signed by the CA. Returns the PEM
+// encoded certificate and private key.
+func IssueClientCertificate(caCertPEM, caKeyPEM []byte, subject pkix.RDNSequence, notBefore time.Time, duration time.Duration) (string, string, error) {
+	ca, err := tls.X509KeyPair(caCertPEM, caKeyPEM)
+	if err != nil {
+		return "", "", xerrors.Errorf("failed to read the CA certificate and key: %w", err)
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	privBytes, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", "", err
+	}
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return "", "", err
+	}
+
+	rawSubject, err := asn1.Marshal(subject)
+	if err != nil {
+		return "", "", err
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		RawSubject:            rawSubject,
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(duration),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, ca.Leaf, &priv.PublicKey, ca.PrivateKey)
+	if err != nil {
+		return "", "", xerrors.Errorf("failed to sign the certificate: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes})
+	return string(certPEM), string(keyPEM), nil
+}
+
+// ParseCertificatePEM returns the first certificate of the PEM data.
+func ParseCertificatePEM(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, xerrors.Errorf("no certificate found in the PEM data")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// CertificateSubject returns the RFC 2253 string representation of the subject of the certificate, keeping the
+// order of its RDNs. This is the name of the X.509 user authenticating with the certificate.
+func CertificateSubject(cert *x509.Certificate) (string, error) {
+	var subject pkix.RDNSequence
+	if _, err := asn1.Unmarshal(cert.RawSubject, &subject); err != nil {
+		return "", xerrors.Errorf("failed to read the subject of the certificate: %w", err)
+	}
+	name, err := authentication.DistinguishedName(subject)
+	if err != nil {
+		return "", xerrors.Errorf("failed to read the subject of the certificate: %w", err)
+	}
+	return name, nil
+}
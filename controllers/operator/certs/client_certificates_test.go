@@ -0,0 +1,89 @@
+package certs
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+)
+
+func TestParseDistinguishedName(t *testing.T) {
+	t.Run("RDNs are kept in order", func(t *testing.T) {
+		subject, err := ParseDistinguishedName("CN=my-user,OU=engineering,O=MongoDB,C=US")
+		require.NoError(t, err)
+		require.Len(t, subject, 4)
+		assert.Equal(t, "US", subject[0][0].Value)
+		assert.Equal(t, "my-user", subject[3][0].Value)
+		assert.Equal(t, "CN=my-user,OU=engineering,O=MongoDB,C=US", subject.String())
+	})
+	t.Run("Escaped characters are unescaped", func(t *testing.T) {
+		subject, err := ParseDistinguishedName(`CN=Doe\, John,O=Mongo\2CDB`)
+		require.NoError(t, err)
+		require.Len(t, subject, 2)
+		assert.Equal(t, "Doe, John", subject[1][0].Value)
+		assert.Equal(t, "Mongo,DB", subject[0][0].Value)
+	})
+	t.Run("Multi-valued RDNs are supported", func(t *testing.T) {
+		subject, err := ParseDistinguishedName("CN=my-user+UID=1234,O=MongoDB")
+		require.NoError(t, err)
+		require.Len(t, subject, 2)
+		assert.Len(t, subject[1], 2)
+	})
+	t.Run("Invalid distinguished names are rejected", func(t *testing.T) {
+		for _, dn := range []string{"", "my-user", "CN=my-user,", "XX=my-user", `CN=my-user\`} {
+			_, err := ParseDistinguishedName(dn)
+			assert.Error(t, err, dn)
+		}
+	})
+}
+
+func TestIssueClientCertificate(t *testing.T) {
+	caCertPEM, caKeyPEM, err := mock.CreateTestCA()
+	require.NoError(t, err)
+	caCert, err := ParseCertificatePEM(caCertPEM)
+	require.NoError(t, err)
+
+	subject, err := ParseDistinguishedName("CN=my-user,OU=engineering,O=MongoDB")
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	certPEM, keyPEM, err := IssueClientCertificate(caCertPEM, caKeyPEM, subject, now, 24*time.Hour)
+	require.NoError(t, err)
+	assert.Contains(t, keyPEM, "PRIVATE KEY")
+
+	cert, err := ParseCertificatePEM([]byte(certPEM))
+	require.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.Equal(t, now.Add(24*time.Hour).UTC(), cert.NotAfter)
+
+	// the subject keeps the order requested, as it's the name of the user
+	actualSubject, err := CertificateSubject(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "CN=my-user,OU=engineering,O=MongoDB", actualSubject)
+
+	_, _, err = IssueClientCertificate([]byte("invalid"), caKeyPEM, subject, now, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestCertificateSubject_UsesShortNames(t *testing.T) {
+	caCertPEM, caKeyPEM, err := mock.CreateTestCA()
+	require.NoError(t, err)
+
+	// pkix renders the attribute types without a short name in Go, such as DC and UID, as OIDs
+	subject, err := ParseDistinguishedName("UID=jdoe,CN=John Doe\\, Jr.,OU=engineering,DC=example,DC=com")
+	require.NoError(t, err)
+
+	certPEM, _, err := IssueClientCertificate(caCertPEM, caKeyPEM, subject, time.Now(), time.Hour)
+	require.NoError(t, err)
+	cert, err := ParseCertificatePEM([]byte(certPEM))
+	require.NoError(t, err)
+
+	actualSubject, err := CertificateSubject(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "UID=jdoe,CN=John Doe\\, Jr.,OU=engineering,DC=example,DC=com", actualSubject)
+}
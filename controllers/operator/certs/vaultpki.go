@@ -0,0 +1,178 @@
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/stringutil"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
+)
+
+// vaultPKIRenewalRatio is the fraction of the validity of a certificate issued from Vault after which it is reissued,
+// which is also the default of cert-manager.
+const vaultPKIRenewalRatio = 2.0 / 3.0
+
+// vaultPKICAKey is the key the CA chain of the issued certificate is stored under, as in the cert-manager Secrets.
+const vaultPKICAKey = "ca.crt"
+
+// IsVaultPKIEnabled returns true if the certificates of the resource are issued from the Vault PKI secrets engine.
+func IsVaultPKIEnabled(ms mdbv1.Security) bool {
+	return ms.IsTLSEnabled() && ms.TLSConfig != nil && ms.TLSConfig.VaultPKI != nil
+}
+
+// EnsureVaultPKICertificateForStatefulSets issues from Vault the member certificate shared by the StatefulSets
+// described by opts, if enabled in the security settings. In multi-cluster deployments opts contains the options of
+// every member cluster, as the certificate has to be valid for the members of all of them.
+func EnsureVaultPKICertificateForStatefulSets(ctx context.Context, secretClient secrets.SecretClient, ms mdbv1.Security, podType certDestination, log *zap.SugaredLogger, opts ...Options) workflow.Status {
+	if !IsVaultPKIEnabled(ms) || len(opts) == 0 {
+		return workflow.OK()
+	}
+
+	var hostnames []string
+	for _, o := range opts {
+		hostnames = append(hostnames, GetCertificateHostnames(o)...)
+	}
+	return EnsureVaultPKICertificate(ctx, secretClient, *ms.TLSConfig.VaultPKI, types.NamespacedName{Namespace: opts[0].Namespace, Name: opts[0].CertSecretName}, hostnames, podType, log)
+}
+
+// EnsureVaultPKIInternalClusterCertificates issues from Vault the internal cluster authentication certificates of the
+// StatefulSets described by opts, if enabled in the security settings. The options sharing the same certificate, e.g.
+// the ones of the member clusters of a multi-cluster deployment, are issued a single certificate valid for all of them.
+func EnsureVaultPKIInternalClusterCertificates(ctx context.Context, secretClient secrets.SecretClient, ms mdbv1.Security, log *zap.SugaredLogger, opts ...Options) workflow.Status {
+	if !IsVaultPKIEnabled(ms) || ms.GetInternalClusterAuthenticationMode() != util.X509 {
+		return workflow.OK()
+	}
+
+	hostnamesBySecret := map[types.NamespacedName][]string{}
+	var secretNames []types.NamespacedName
+	for _, o := range opts {
+		secretName := types.NamespacedName{Namespace: o.Namespace, Name: o.InternalClusterSecretName}
+		if _, ok := hostnamesBySecret[secretName]; !ok {
+			secretNames = append(secretNames, secretName)
+		}
+		hostnamesBySecret[secretName] = append(hostnamesBySecret[secretName], GetCertificateHostnames(o)...)
+	}
+
+	var workflowStatus workflow.Status = workflow.OK()
+	for _, secretName := range secretNames {
+		workflowStatus = workflowStatus.Merge(EnsureVaultPKICertificate(ctx, secretClient, *ms.TLSConfig.VaultPKI, secretName, hostnamesBySecret[secretName], Database, log))
+	}
+	return workflowStatus
+}
+
+// EnsureVaultPKIAgentCertificate issues from Vault the client certificate the agents authenticate with, if enabled in
+// the security settings.
+func EnsureVaultPKIAgentCertificate(ctx context.Context, secretClient secrets.SecretClient, ms mdbv1.Security, secretName types.NamespacedName, log *zap.SugaredLogger) workflow.Status {
+	if !IsVaultPKIEnabled(ms) {
+		return workflow.OK()
+	}
+	return ensureVaultPKICertificate(ctx, secretClient, *ms.TLSConfig.VaultPKI, secretName, util.AutomationAgentName, nil, Database, log)
+}
+
+// EnsureVaultPKICertificate issues from the Vault PKI role the certificate stored in Vault under secretName, valid for
+// all the hostnames. The certificate is reissued when the hostnames change, for example when the resource is scaled,
+// and before it expires. The new certificate changes the hash of the PEM secret created from it, which makes the
+// pods restart with the new certificate injected by the Vault Agent.
+func EnsureVaultPKICertificate(ctx context.Context, secretClient secrets.SecretClient, config v1.VaultPKIConfig, secretName types.NamespacedName, hostnames []string, podType certDestination, log *zap.SugaredLogger) workflow.Status {
+	dnsNames := slices.Clone(hostnames)
+	slices.Sort(dnsNames)
+	dnsNames = slices.Compact(dnsNames)
+	if len(dnsNames) == 0 {
+		return workflow.Failed(xerrors.Errorf("no hostname to issue the certificate %s for", secretName.Name))
+	}
+	return ensureVaultPKICertificate(ctx, secretClient, config, secretName, dnsNames[0], dnsNames, podType, log)
+}
+
+func ensureVaultPKICertificate(ctx context.Context, secretClient secrets.SecretClient, config v1.VaultPKIConfig, secretName types.NamespacedName, commonName string, dnsNames []string, podType certDestination, log *zap.SugaredLogger) workflow.Status {
+	if !vault.IsVaultSecretBackend() {
+		return workflow.Invalid("certificates can only be issued from the Vault PKI secrets engine when the Vault secret backend is used")
+	}
+
+	basePath, err := getSecretBasePath(secretClient, podType)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+
+	secretData, err := secretClient.ReadBinarySecret(ctx, secretName, basePath)
+	if err != nil && !secret.SecretNotExist(err) {
+		return workflow.Failed(xerrors.Errorf("failed to read certificate secret %s: %w", secretName.Name, err))
+	}
+	reason := vaultPKICertificateReissueReason(secretData, dnsNames, time.Now())
+	if reason == "" {
+		return workflow.OK()
+	}
+
+	log.Infof("Issuing the certificate %s from the Vault PKI role %s: %s", secretName.Name, config.Role, reason)
+	params := map[string]interface{}{
+		"common_name":          commonName,
+		"exclude_cn_from_sans": len(dnsNames) == 0,
+	}
+	if len(dnsNames) > 0 {
+		params["alt_names"] = strings.Join(dnsNames, ",")
+	}
+	if config.TTL != "" {
+		params["ttl"] = config.TTL
+	}
+	issued, err := secretClient.VaultClient.IssueCertificate(config.GetMount(), config.Role, params)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+
+	ca := issued.IssuingCA
+	if len(issued.CAChain) > 0 {
+		ca = strings.Join(issued.CAChain, "\n")
+	}
+	certSecret := secret.Builder().
+		SetName(secretName.Name).
+		SetNamespace(secretName.Namespace).
+		SetDataType(corev1.SecretTypeTLS).
+		SetField(corev1.TLSCertKey, issued.Certificate).
+		SetField(corev1.TLSPrivateKeyKey, issued.PrivateKey).
+		SetField(vaultPKICAKey, ca).
+		Build()
+	if err := secretClient.PutSecret(ctx, certSecret, basePath); err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to write certificate secret %s: %w", secretName.Name, err))
+	}
+	return workflow.OK()
+}
+
+// vaultPKICertificateReissueReason returns why the certificate in the secret data needs to be issued again, or an
+// empty string if it is still valid for all the hostnames and far enough from its expiry.
+func vaultPKICertificateReissueReason(secretData map[string][]byte, dnsNames []string, now time.Time) string {
+	if len(secretData[corev1.TLSCertKey]) == 0 {
+		return "the certificate doesn't exist yet"
+	}
+	cert, err := ParseCertificatePEM(secretData[corev1.TLSCertKey])
+	if err != nil {
+		return "the existing certificate can't be parsed"
+	}
+	for _, hostname := range dnsNames {
+		if !stringutil.CheckCertificateAddresses(cert.DNSNames, hostname) {
+			return "the existing certificate is not valid for the hostname " + hostname
+		}
+	}
+	if now.After(vaultPKIRenewalTime(cert)) {
+		return "the existing certificate expires on " + cert.NotAfter.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+func vaultPKIRenewalTime(cert *x509.Certificate) time.Time {
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotBefore.Add(time.Duration(float64(validity) * vaultPKIRenewalRatio))
+}
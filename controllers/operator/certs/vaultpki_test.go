@@ -0,0 +1,30 @@
+package certs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+)
+
+func TestVaultPKICertificateReissueReason(t *testing.T) {
+	dnsNames := []string{"my-rs-0.my-rs-svc.my-namespace.svc.cluster.local", "my-rs-1.my-rs-svc.my-namespace.svc.cluster.local"}
+	// the certificate is reissued once two thirds of its validity have elapsed, after about 60 days
+	now := time.Now()
+	cert, key, err := mock.CreateTestServerCertificateExpiringAt(dnsNames, now.Add(90*24*time.Hour))
+	require.NoError(t, err)
+	secretData := map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key}
+
+	assert.Empty(t, vaultPKICertificateReissueReason(secretData, dnsNames, now))
+	assert.Empty(t, vaultPKICertificateReissueReason(secretData, dnsNames[:1], now))
+
+	assert.Equal(t, "the certificate doesn't exist yet", vaultPKICertificateReissueReason(nil, dnsNames, now))
+	assert.Equal(t, "the existing certificate is not valid for the hostname my-rs-2.my-rs-svc.my-namespace.svc.cluster.local",
+		vaultPKICertificateReissueReason(secretData, append(dnsNames, "my-rs-2.my-rs-svc.my-namespace.svc.cluster.local"), now))
+	assert.Contains(t, vaultPKICertificateReissueReason(secretData, dnsNames, now.Add(61*24*time.Hour)), "the existing certificate expires on")
+}
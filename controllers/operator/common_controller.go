@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blang/semver"
@@ -17,12 +18,15 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
@@ -39,6 +43,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/pkg/agentVersionManagement"
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
@@ -67,6 +72,14 @@ type ReconcileCommonController struct {
 	resourceWatcher *watch.ResourceWatcher
 
 	customAgentURL string
+
+	// recorder records the Events about the reconciled resources, it is nil in unit tests.
+	recorder                    record.EventRecorder
+	certificateExpiryThresholds []time.Duration
+	// certificateExpiryNotifications keeps the closest expiry threshold an Event was recorded for, per certificate.
+	certificateExpiryNotifications *sync.Map
+	// volumeStatsReaders reads the usage of the volumes for storage autoscaling, it is nil in unit tests.
+	volumeStatsReaders VolumeStatsReaders
 }
 
 func NewReconcileCommonController(ctx context.Context, client client.Client) *ReconcileCommonController {
@@ -89,9 +102,25 @@ func NewReconcileCommonController(ctx context.Context, client client.Client) *Re
 		if err != nil {
 			panic(fmt.Sprintf("Can not initialize vault client: %s", err))
 		}
-		if err := vaultClient.Login(); err != nil {
+		if err := vaultClient.Login(ctx); err != nil {
 			panic(xerrors.Errorf("unable to log in with vault client: %w", err))
 		}
+		go vaultClient.RenewToken(ctx, zap.S())
+	}
+	var awsClient *awssecrets.Client
+	if awssecrets.IsSecretsManagerBackend() {
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			panic(err.Error())
+		}
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			panic(err.Error())
+		}
+		awsClient, err = awssecrets.InitClient(ctx, clientset)
+		if err != nil {
+			panic(fmt.Sprintf("Can not initialize AWS Secrets Manager client: %s", err))
+		}
 	}
 	customAgentURL := env.ReadOrDefault(util.EnvVarCustomAgentURL, "") // nolint:forbidigo
 
@@ -99,11 +128,22 @@ func NewReconcileCommonController(ctx context.Context, client client.Client) *Re
 		client: newClient,
 		SecretClient: secrets.SecretClient{
 			VaultClient: vaultClient,
+			AWSClient:   awsClient,
 			KubeClient:  newClient,
 		},
-		resourceWatcher: watch.NewResourceWatcher(),
-		customAgentURL:  customAgentURL,
+		resourceWatcher:                watch.NewResourceWatcher(),
+		customAgentURL:                 customAgentURL,
+		certificateExpiryThresholds:    readCertificateExpiryThresholds(),
+		certificateExpiryNotifications: &sync.Map{},
+	}
+}
+
+// awsSecretsConfig returns the configuration of AWS Secrets Manager, which is empty if it is not the secret backend.
+func (r *ReconcileCommonController) awsSecretsConfig() awssecrets.Configuration {
+	if r.SecretClient.AWSClient == nil {
+		return awssecrets.Configuration{}
 	}
+	return r.SecretClient.AWSClient.Config
 }
 
 func (r *ReconcileCommonController) getRoleAnnotation(ctx context.Context, db mdbv1.DbCommonSpec, enableClusterMongoDBRoles bool, mongodbResourceNsName types.NamespacedName) (map[string]string, []string, error) {
@@ -146,11 +186,8 @@ func (r *ReconcileCommonController) getRoles(ctx context.Context, db mdbv1.DbCom
 
 	var roles []mdbv1.MongoDBRole
 	if len(roleRefs) > 0 {
-		if !enableClusterMongoDBRoles {
-			return nil, xerrors.Errorf("RoleRefs are not supported when ClusterMongoDBRoles are disabled. Please enable ClusterMongoDBRoles in the operator configuration. This can be done by setting the operator.enableClusterMongoDBRoles to true in the helm values file, which will automatically installed the necessary RBAC. Alternatively, it can be enabled by adding -watch-resource=clustermongodbroles flag to the operator deployment, and manually creating the necessary RBAC.")
-		}
 		var err error
-		roles, err = r.getRoleRefs(ctx, roleRefs, mongodbResourceNsName, db.Version)
+		roles, err = r.getRoleRefs(ctx, roleRefs, enableClusterMongoDBRoles, mongodbResourceNsName, db.Version)
 		if err != nil {
 			return nil, err
 		}
@@ -265,9 +302,9 @@ func mergeRoles(deployed []mdbv1.MongoDBRole, current []mdbv1.MongoDBRole, previ
 
 // getRoleRefs retrieves the roles from the referenced resources. It will return an error if any of the referenced resources are not found.
 // It will also add the referenced resources to the resource watcher, so that they are watched for changes.
-// The referenced resources are expected to be of kind ClusterMongoDBRole.
-// This implementation is prepared for a future namespaced variant of ClusterMongoDBRole.
-func (r *ReconcileCommonController) getRoleRefs(ctx context.Context, roleRefs []mdbv1.MongoDBRoleRef, mongodbResourceNsName types.NamespacedName, mdbVersion string) ([]mdbv1.MongoDBRole, error) {
+// The referenced resources are expected to be of kind ClusterMongoDBRole, or MongoDBRole in the namespace of the
+// MongoDB resource.
+func (r *ReconcileCommonController) getRoleRefs(ctx context.Context, roleRefs []mdbv1.MongoDBRoleRef, enableClusterMongoDBRoles bool, mongodbResourceNsName types.NamespacedName, mdbVersion string) ([]mdbv1.MongoDBRole, error) {
 	roles := make([]mdbv1.MongoDBRole, len(roleRefs))
 
 	for idx, ref := range roleRefs {
@@ -275,6 +312,9 @@ func (r *ReconcileCommonController) getRoleRefs(ctx context.Context, roleRefs []
 		switch ref.Kind {
 
 		case util.ClusterMongoDBRoleKind:
+			if !enableClusterMongoDBRoles {
+				return nil, xerrors.Errorf("RoleRefs are not supported when ClusterMongoDBRoles are disabled. Please enable ClusterMongoDBRoles in the operator configuration. This can be done by setting the operator.enableClusterMongoDBRoles to true in the helm values file, which will automatically installed the necessary RBAC. Alternatively, it can be enabled by adding -watch-resource=clustermongodbroles flag to the operator deployment, and manually creating the necessary RBAC. Namespaced MongoDBRoles can be referenced without enabling ClusterMongoDBRoles.")
+			}
 			customRole := &rolev1.ClusterMongoDBRole{}
 
 			err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name}, customRole)
@@ -292,8 +332,26 @@ func (r *ReconcileCommonController) getRoleRefs(ctx context.Context, roleRefs []
 			r.resourceWatcher.AddWatchedResourceIfNotAdded(ref.Name, "", watch.ClusterMongoDBRole, mongodbResourceNsName)
 			role = customRole.Spec.MongoDBRole
 
+		case util.MongoDBRoleKind:
+			customRole := &rolev1.MongoDBRole{}
+
+			err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: mongodbResourceNsName.Namespace}, customRole)
+			if err != nil {
+				if apiErrors.IsNotFound(err) {
+					return nil, xerrors.Errorf("MongoDBRole '%s' not found in namespace %s. If the resource was deleted, the role is still present in MongoDB. To correctly remove a role from MongoDB, please remove the reference from spec.security.roleRefs.", ref.Name, mongodbResourceNsName.Namespace)
+				}
+				return nil, xerrors.Errorf("Failed to retrieve MongoDBRole '%s': %w", ref.Name, err)
+			}
+
+			if res := mdbv1.RoleIsCorrectlyConfigured(customRole.Spec.MongoDBRole, mdbVersion); res.Level == v1.ErrorLevel {
+				return nil, xerrors.Errorf("Error validating role '%s' - %s", ref.Name, res.Msg)
+			}
+
+			r.resourceWatcher.AddWatchedResourceIfNotAdded(ref.Name, mongodbResourceNsName.Namespace, watch.MongoDBRole, mongodbResourceNsName)
+			role = customRole.Spec.MongoDBRole
+
 		default:
-			return nil, xerrors.Errorf("Invalid value %s for roleRef.kind. It must be %s.", ref.Kind, util.ClusterMongoDBRoleKind)
+			return nil, xerrors.Errorf("Invalid value %s for roleRef.kind. It must be %s or %s.", ref.Kind, util.ClusterMongoDBRoleKind, util.MongoDBRoleKind)
 		}
 
 		roles[idx] = role
@@ -335,36 +393,47 @@ func (r *ReconcileCommonController) SetupCommonWatchers(watcherResource WatcherR
 	security := watcherResource.GetSecurity()
 	// And TLS if needed
 	if security.IsTLSEnabled() {
-		var secretNames []string
-		if getTLSSecretNames != nil {
-			secretNames = getTLSSecretNames()
-		} else {
-			secretNames = []string{security.MemberCertificateSecretName(resourceNameForSecret)}
-			if security.ShouldUseX509("") {
-				secretNames = append(secretNames, security.AgentClientCertificateSecretName(resourceNameForSecret))
-			}
-		}
 		// TLSConfig may be nil if TLS is enabled via CertificatesSecretsPrefix only
 		var ca string
 		if security.TLSConfig != nil {
 			ca = security.TLSConfig.CA
 		}
-		r.resourceWatcher.RegisterWatchedTLSResources(objectToReconcile, ca, secretNames)
+		r.resourceWatcher.RegisterWatchedTLSResources(objectToReconcile, ca, tlsSecretNames(security, getTLSSecretNames, resourceNameForSecret))
 	}
 
-	if security.GetInternalClusterAuthenticationMode() == util.X509 {
-		var secretNames []string
-		if getInternalAuthSecretNames != nil {
-			secretNames = getInternalAuthSecretNames()
-		} else {
-			secretNames = []string{security.InternalClusterAuthSecretName(resourceNameForSecret)}
-		}
-		for _, secretName := range secretNames {
-			r.resourceWatcher.AddWatchedResourceIfNotAdded(secretName, objectToReconcile.Namespace, watch.Secret, objectToReconcile)
-		}
+	for _, secretName := range internalAuthSecretNames(security, getInternalAuthSecretNames, resourceNameForSecret) {
+		r.resourceWatcher.AddWatchedResourceIfNotAdded(secretName, objectToReconcile.Namespace, watch.Secret, objectToReconcile)
 	}
 }
 
+// tlsSecretNames returns the names of the secrets containing the member and agent certificates, in case
+// getTLSSecretNames func is nil, we will default to common mechanism to get the secret names.
+func tlsSecretNames(security *mdbv1.Security, getTLSSecretNames func() []string, resourceNameForSecret string) []string {
+	if !security.IsTLSEnabled() {
+		return nil
+	}
+	if getTLSSecretNames != nil {
+		return getTLSSecretNames()
+	}
+	secretNames := []string{security.MemberCertificateSecretName(resourceNameForSecret)}
+	if security.ShouldUseX509("") {
+		secretNames = append(secretNames, security.AgentClientCertificateSecretName(resourceNameForSecret))
+	}
+	return secretNames
+}
+
+// internalAuthSecretNames returns the names of the secrets containing the x509 internal cluster authentication
+// certificates, in case getInternalAuthSecretNames func is nil, we will default to common mechanism to get the secret names.
+func internalAuthSecretNames(security *mdbv1.Security, getInternalAuthSecretNames func() []string, resourceNameForSecret string) []string {
+	if security.GetInternalClusterAuthenticationMode() != util.X509 {
+		return nil
+	}
+	if getInternalAuthSecretNames != nil {
+		return getInternalAuthSecretNames()
+	}
+	return []string{security.InternalClusterAuthSecretName(resourceNameForSecret)}
+}
+
 // GetResource populates the provided runtime.Object with some additional error handling
 func (r *ReconcileCommonController) GetResource(ctx context.Context, request reconcile.Request, resource v1.CustomResourceReadWriter, log *zap.SugaredLogger) (reconcile.Result, error) {
 	return commoncontroller.GetResource(ctx, r.client, request, resource, log)
@@ -567,10 +636,7 @@ func (r *ReconcileCommonController) updateOmAuthentication(ctx context.Context,
 		CAFilePath:         caFilepath,
 		MongoDBResource:    types.NamespacedName{Namespace: ar.GetNamespace(), Name: ar.GetName()},
 	}
-	var databaseSecretPath string
-	if r.VaultClient != nil {
-		databaseSecretPath = r.VaultClient.DatabaseSecretPath()
-	}
+	databaseSecretPath := r.DatabaseSecretPath()
 	if ar.IsLDAPEnabled() {
 		bindUserPassword, err := r.ReadSecretKey(ctx, kube.ObjectKey(ar.GetNamespace(), ar.GetSecurity().Authentication.Ldap.BindQuerySecretRef.Name), databaseSecretPath, "password")
 		if err != nil {
@@ -669,10 +735,7 @@ func (r *ReconcileCommonController) configureAgentSubjects(ctx context.Context,
 func (r *ReconcileCommonController) readAgentSubjectsFromSecret(ctx context.Context, namespace string, secretKeySelector corev1.SecretKeySelector, log *zap.SugaredLogger) (authentication.UserOptions, error) {
 	userOpts := authentication.UserOptions{}
 
-	var databaseSecretPath string
-	if r.VaultClient != nil {
-		databaseSecretPath = r.VaultClient.DatabaseSecretPath()
-	}
+	databaseSecretPath := r.DatabaseSecretPath()
 	agentCerts, err := r.ReadSecret(ctx, kube.ObjectKey(namespace, secretKeySelector.Name), databaseSecretPath)
 	if err != nil {
 		return userOpts, err
@@ -729,6 +792,9 @@ func (r *ReconcileCommonController) ensureX509SecretAndCheckTLSType(ctx context.
 			return workflow.Failed(xerrors.Errorf("Authentication mode for project is x509 but this MDB resource is not TLS enabled"))
 		}
 		agentSecretName := security.AgentClientCertificateSecretName(configurator.GetName())
+		if status := certs.EnsureVaultPKIAgentCertificate(ctx, configurator.GetSecretWriteClient(), *security, kube.ObjectKey(configurator.GetNamespace(), agentSecretName), log); !status.IsOK() {
+			return status
+		}
 		err := certs.VerifyAndEnsureClientCertificatesForAgentsAndTLSType(ctx, configurator.GetSecretReadClient(), configurator.GetSecretWriteClient(), kube.ObjectKey(configurator.GetNamespace(), agentSecretName), log)
 		if err != nil {
 			return workflow.Failed(err)
@@ -736,6 +802,9 @@ func (r *ReconcileCommonController) ensureX509SecretAndCheckTLSType(ctx context.
 	}
 
 	if security.GetInternalClusterAuthenticationMode() == util.X509 {
+		if status := certs.EnsureVaultPKIInternalClusterCertificates(ctx, configurator.GetSecretWriteClient(), *security, log, configurator.GetCertOptions()...); !status.IsOK() {
+			return status
+		}
 		errors := make([]error, 0)
 		for _, certOption := range configurator.GetCertOptions() {
 			err := r.validateInternalClusterCertsAndCheckTLSType(ctx, configurator, certOption, log)
@@ -804,6 +873,7 @@ func (r *ReconcileCommonController) deleteClusterResources(ctx context.Context,
 	errs := deleteOwnedClusterResources(ctx, client, clusterName, resourceOwner, log)
 
 	r.resourceWatcher.RemoveDependentWatchedResources(resourceOwner.ObjectKey())
+	certs.ForgetCertificateExpiries(resourceOwner.ObjectKey().Namespace, resourceOwner.GetKind(), resourceOwner.ObjectKey().Name)
 
 	return errs
 }
@@ -831,6 +901,18 @@ func deleteOwnedClusterResources(ctx context.Context, client kubernetesClient.Cl
 		log.Infof("Removed StatefulSets associated with %s in cluster %s", objectKey, clusterName)
 	}
 
+	if err := client.DeleteAllOf(ctx, &policyv1.PodDisruptionBudget{}, &cleanupOptions); err != nil {
+		errs = multierror.Append(errs, err)
+	} else {
+		log.Infof("Removed PodDisruptionBudgets associated with %s in cluster %s", objectKey, clusterName)
+	}
+
+	if err := client.DeleteAllOf(ctx, &autoscalingv2.HorizontalPodAutoscaler{}, &cleanupOptions); err != nil {
+		errs = multierror.Append(errs, err)
+	} else {
+		log.Infof("Removed HorizontalPodAutoscalers associated with %s in cluster %s", objectKey, clusterName)
+	}
+
 	if err := client.DeleteAllOf(ctx, &corev1.ConfigMap{}, &cleanupOptions); err != nil {
 		errs = multierror.Append(errs, err)
 	} else {
@@ -885,10 +967,9 @@ func UpdatePrometheus(ctx context.Context, d *om.Deployment, conn om.Connection,
 	var password string
 
 	secretName := prometheus.PasswordSecretRef.Name
-	if vault.IsVaultSecretBackend() {
-		operatorSecretPath := sClient.VaultClient.OperatorSecretPath()
-		passwordString := fmt.Sprintf("%s/%s/%s", operatorSecretPath, namespace, secretName)
-		keyedPassword, err := sClient.VaultClient.ReadSecretString(passwordString)
+	secretNamespacedName := types.NamespacedName{Name: secretName, Namespace: namespace}
+	if !secrets.IsKubernetesSecretBackend() {
+		keyedPassword, err := sClient.ReadSecret(ctx, secretNamespacedName, sClient.OperatorSecretPath())
 		if err != nil {
 			log.Infof("Prometheus can't be enabled, %s", err)
 			return err
@@ -897,12 +978,11 @@ func UpdatePrometheus(ctx context.Context, d *om.Deployment, conn om.Connection,
 		var ok bool
 		password, ok = keyedPassword[prometheus.GetPasswordKey()]
 		if !ok {
-			errMsg := fmt.Sprintf("Prometheus password %s not in Secret %s", prometheus.GetPasswordKey(), passwordString)
+			errMsg := fmt.Sprintf("Prometheus password %s not in Secret %s", prometheus.GetPasswordKey(), secretNamespacedName)
 			log.Info(errMsg)
 			return xerrors.Errorf(errMsg)
 		}
 	} else {
-		secretNamespacedName := types.NamespacedName{Name: secretName, Namespace: namespace}
 		password, err = secret.ReadKey(ctx, sClient, prometheus.GetPasswordKey(), secretNamespacedName)
 		if err != nil {
 			log.Infof("Prometheus can't be enabled, %s", err)
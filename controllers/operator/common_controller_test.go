@@ -410,6 +410,85 @@ func TestErrorWhenRoleDoesNotExist(t *testing.T) {
 	assert.Empty(t, roles)
 }
 
+func TestNamespacedRoleRefsAreAdded(t *testing.T) {
+	ctx := context.Background()
+	rs := mdbv1.NewDefaultReplicaSetBuilder().Build()
+	roleResource := role.DefaultMongoDBRoleBuilder().SetNamespace(rs.Namespace).Build()
+	rs.Spec.Security.RoleRefs = []mdbv1.MongoDBRoleRef{
+		{
+			Name: roleResource.Name,
+			Kind: util.MongoDBRoleKind,
+		},
+	}
+
+	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient()
+	controller := NewReconcileCommonController(ctx, kubeClient)
+	mockOm, _ := prepareConnection(ctx, controller, omConnectionFactory.GetConnectionFunc, t)
+
+	require.NoError(t, kubeClient.Create(ctx, roleResource))
+
+	// MongoDBRoles don't require ClusterMongoDBRoles to be enabled
+	result := controller.ensureRoles(ctx, rs.Spec.DbCommonSpec, false, mockOm, kube.ObjectKeyFromApiObject(rs), nil, zap.S())
+	assert.True(t, result.IsOK())
+
+	ac, err := mockOm.ReadAutomationConfig()
+	assert.NoError(t, err)
+	roles, ok := ac.Deployment["roles"].([]mdbv1.MongoDBRole)
+	assert.True(t, ok)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, roleResource.Spec.Role, roles[0].Role)
+}
+
+func TestErrorWhenNamespacedRoleIsInAnotherNamespace(t *testing.T) {
+	ctx := context.Background()
+	roleResource := role.DefaultMongoDBRoleBuilder().SetNamespace("other-namespace").Build()
+	roleRefs := []mdbv1.MongoDBRoleRef{
+		{
+			Name: roleResource.Name,
+			Kind: util.MongoDBRoleKind,
+		},
+	}
+	rs := mdbv1.NewDefaultReplicaSetBuilder().SetRoleRefs(roleRefs).Build()
+
+	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient()
+	controller := NewReconcileCommonController(ctx, kubeClient)
+	mockOm, _ := prepareConnection(ctx, controller, omConnectionFactory.GetConnectionFunc, t)
+
+	_ = kubeClient.Create(ctx, roleResource)
+
+	result := controller.ensureRoles(ctx, rs.Spec.DbCommonSpec, true, mockOm, kube.ObjectKeyFromApiObject(rs), nil, zap.S())
+	assert.False(t, result.IsOK())
+	assert.Equal(t, status.PhaseFailed, result.Phase())
+
+	ac, err := mockOm.ReadAutomationConfig()
+	assert.NoError(t, err)
+	roles, ok := ac.Deployment["roles"].([]mdbv1.MongoDBRole)
+	assert.False(t, ok)
+	assert.Empty(t, roles)
+}
+
+func TestErrorWhenClusterRoleRefIsUsedWithClusterRolesDisabled(t *testing.T) {
+	ctx := context.Background()
+	roleResource := role.DefaultClusterMongoDBRoleBuilder().Build()
+	roleRefs := []mdbv1.MongoDBRoleRef{
+		{
+			Name: roleResource.Name,
+			Kind: util.ClusterMongoDBRoleKind,
+		},
+	}
+	rs := mdbv1.NewDefaultReplicaSetBuilder().SetRoleRefs(roleRefs).Build()
+
+	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient()
+	controller := NewReconcileCommonController(ctx, kubeClient)
+	mockOm, _ := prepareConnection(ctx, controller, omConnectionFactory.GetConnectionFunc, t)
+
+	_ = kubeClient.Create(ctx, roleResource)
+
+	result := controller.ensureRoles(ctx, rs.Spec.DbCommonSpec, false, mockOm, kube.ObjectKeyFromApiObject(rs), nil, zap.S())
+	assert.False(t, result.IsOK())
+	assert.Equal(t, status.PhaseFailed, result.Phase())
+}
+
 func TestDontSendNilPrivileges(t *testing.T) {
 	ctx := context.Background()
 	customRole := mdbv1.MongoDBRole{
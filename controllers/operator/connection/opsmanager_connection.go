@@ -48,10 +48,7 @@ func PrepareOpsManagerConnection(ctx context.Context, client secrets.SecretClien
 		}
 	}
 
-	var databaseSecretPath string
-	if client.VaultClient != nil {
-		databaseSecretPath = client.VaultClient.DatabaseSecretPath()
-	}
+	databaseSecretPath := client.DatabaseSecretPath()
 	if agentAPIKey, err := agents.EnsureAgentKeySecretExists(ctx, client, conn, namespace, omProject.AgentAPIKey, conn.GroupID(), databaseSecretPath, log); err != nil {
 		return nil, "", err
 	} else {
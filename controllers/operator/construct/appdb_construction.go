@@ -505,6 +505,7 @@ func AppDbStatefulSet(opsManager om.MongoDBOpsManager, podVars *env.PodEnvVars,
 				),
 				vaultModification(*appDb, podVars, opts),
 				appDbPodSpec(opts.InitAppDBImage, opsManager, defaultArchitecture),
+				podtemplatespec.WithTopologySpreadConstraints(appDb.TopologySpread.Constraints(map[string]string{PodAntiAffinityLabelKey: appDb.NameForCluster(scaler.MemberClusterNum())})),
 				tlsVolumes(*appDb, podVars, log),
 			),
 		),
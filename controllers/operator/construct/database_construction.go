@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -17,6 +18,9 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/agents"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/container"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/persistentvolumeclaim"
@@ -55,6 +59,9 @@ const (
 	// the same topology key
 	PodAntiAffinityLabelKey = "pod-anti-affinity"
 
+	// ZoneLabelKey is the label holding the zone of the Pods of a replica set with spec.zonePlacement.
+	ZoneLabelKey = "zone"
+
 	// AGENT_API_KEY secret path
 	AgentAPIKeySecretPath = "/mongodb-automation/agent-api-key" //nolint
 	AgentAPIKeyVolumeName = "agent-api-key"                     //nolint
@@ -107,11 +114,12 @@ type DatabaseStatefulSetOptions struct {
 	AgentImage             string
 	CustomAgentURL         string
 
-	Annotations map[string]string
-	VaultConfig vault.VaultConfiguration
-	ExtraEnvs   []corev1.EnvVar
-	Labels      map[string]string
-	StsLabels   map[string]string
+	Annotations      map[string]string
+	VaultConfig      vault.VaultConfiguration
+	AWSSecretsConfig awssecrets.Configuration
+	ExtraEnvs        []corev1.EnvVar
+	Labels           map[string]string
+	StsLabels        map[string]string
 
 	// These fields are only relevant for multi-cluster
 	MultiClusterMode bool // should always be "false" in single-cluster
@@ -124,6 +132,17 @@ type DatabaseStatefulSetOptions struct {
 	AgentDebug          bool
 	AgentDebugImage     string
 	DefaultArchitecture architectures.DefaultArchitecture
+
+	// PodDisruptionBudget is the PodDisruptionBudget configuration specified by the user, DefaultPodDisruptionBudget
+	// is used instead if the user hasn't set neither minAvailable nor maxUnavailable.
+	PodDisruptionBudget        *v1.PodDisruptionBudgetConfiguration
+	DefaultPodDisruptionBudget v1.PodDisruptionBudgetConfiguration
+
+	TopologySpread *v1.TopologySpread
+
+	// Zone pins the Pods to the nodes of one zone of spec.zonePlacement, whose name is held by the ZoneTopologyKey label.
+	Zone            *mdbv1.ZoneSpecItem
+	ZoneTopologyKey string
 }
 
 func WithDefaultArchitecture(defaultArchitecture architectures.DefaultArchitecture) func(options *DatabaseStatefulSetOptions) {
@@ -176,6 +195,10 @@ func StandaloneOptions(additionalOpts ...func(options *DatabaseStatefulSetOption
 			StatefulSetSpecOverride: stsSpec,
 			MultiClusterMode:        mdb.Spec.IsMultiCluster(),
 			StsType:                 Standalone,
+			PodDisruptionBudget:     mdb.Spec.PodDisruptionBudget,
+			TopologySpread:          mdb.Spec.TopologySpread,
+			// a standalone can't lose its only member, so it's not protected by default
+			DefaultPodDisruptionBudget: v1.VotingMajorityPodDisruptionBudget(1),
 		}
 
 		for _, opt := range additionalOpts {
@@ -208,6 +231,10 @@ func ReplicaSetOptions(additionalOpts ...func(options *DatabaseStatefulSetOption
 			Labels:                  mdb.Labels,
 			MultiClusterMode:        mdb.Spec.IsMultiCluster(),
 			StsType:                 ReplicaSet,
+			PodDisruptionBudget:     mdb.Spec.PodDisruptionBudget,
+			TopologySpread:          mdb.Spec.TopologySpread,
+			DefaultPodDisruptionBudget: v1.VotingMajorityPodDisruptionBudget(
+				automationconfig.VotingMembers(mdb.Spec.Members, mdb.Spec.GetMemberOptions())),
 		}
 
 		if mdb.Spec.DbCommonSpec.GetExternalDomain() != nil {
@@ -254,6 +281,16 @@ func shardedOptions(cfg shardedOptionCfg, additionalOpts ...func(options *Databa
 		MultiClusterMode:        cfg.mdb.Spec.IsMultiCluster(),
 		Persistent:              cfg.persistent,
 		StsType:                 cfg.stsType,
+		PodDisruptionBudget:     cfg.mdb.Spec.PodDisruptionBudget,
+		TopologySpread:          cfg.mdb.Spec.TopologySpread,
+	}
+
+	if cfg.stsType == Mongos {
+		// mongos are stateless, evicting them one at a time is enough to keep the cluster reachable
+		opts.DefaultPodDisruptionBudget = v1.PodDisruptionBudgetConfiguration{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+	} else {
+		// the majority is computed over all the members of the replica set, including the ones in other member clusters
+		opts.DefaultPodDisruptionBudget = MemberClusterPodDisruptionBudget(cfg.componentSpec.ClusterSpecList, cfg.memberClusterName)
 	}
 
 	if cfg.mdb.Spec.IsMultiCluster() {
@@ -357,9 +394,88 @@ func DatabaseStatefulSet(mdb mdbv1.MongoDB, stsOptFunc func(mdb mdbv1.MongoDB) D
 		dbSts.Spec = merge.StatefulSetSpecs(dbSts.Spec, *stsOptions.StatefulSetSpecOverride)
 	}
 
+	// the zone is enforced after the pod template of the user is merged, which can't move the Pods out of the zone
+	if stsOptions.Zone != nil {
+		pinToZone(&dbSts.Spec.Template, stsOptions.ZoneTopologyKey, *stsOptions.Zone)
+	}
+
 	return dbSts
 }
 
+// MemberClusterPodDisruptionBudget returns the default PodDisruptionBudget of the StatefulSet in the member cluster
+// of a replica set deployed to the member clusters of 'clusterSpecList'. The PodDisruptionBudget only allows its
+// share of the Pods the whole replica set can lose to be evicted.
+func MemberClusterPodDisruptionBudget(clusterSpecList mdbv1.ClusterSpecList, memberClusterName string) v1.PodDisruptionBudgetConfiguration {
+	votingMembers := make([]int, len(clusterSpecList))
+	for i, item := range clusterSpecList {
+		votingMembers[i] = automationconfig.VotingMembers(item.Members, item.MemberConfig)
+	}
+	budgets := v1.VotingMajorityPodDisruptionBudgets(votingMembers)
+	for i, item := range clusterSpecList {
+		if item.ClusterName == memberClusterName {
+			return budgets[i]
+		}
+	}
+	// the StatefulSet is being removed from the member cluster, its Pods can't be evicted until then
+	return v1.PodDisruptionBudgetConfiguration{MaxUnavailable: ptr.To(intstr.FromInt32(0))}
+}
+
+// ReplicaSetZoneOptions returns the options of the StatefulSet of one zone of a replica set with spec.zonePlacement:
+// the StatefulSet is named after the index of the zone and scaled to 'replicas'. Its Pods are protected by the
+// PodDisruptionBudget shared by all the zones, built by create.ZonedReplicaSetPodDisruptionBudget.
+func ReplicaSetZoneOptions(rsOptions func(mdb mdbv1.MongoDB) DatabaseStatefulSetOptions, zoneName string, zoneNum, replicas int) func(mdb mdbv1.MongoDB) DatabaseStatefulSetOptions {
+	return func(mdb mdbv1.MongoDB) DatabaseStatefulSetOptions {
+		opts := rsOptions(mdb)
+		zone := mdb.Spec.ZonePlacement.GetZone(zoneName)
+		opts.StatefulSetNameOverride = mdb.ZoneStatefulSetName(zoneNum)
+		opts.Replicas = replicas
+		opts.Zone = &zone
+		opts.ZoneTopologyKey = mdb.Spec.ZonePlacement.GetTopologyKey()
+		opts.PodDisruptionBudget = &v1.PodDisruptionBudgetConfiguration{Enabled: ptr.To(false)}
+		return opts
+	}
+}
+
+// pinToZone requires the Pods to be scheduled on the nodes of the zone. The zone is added to every term of the
+// required node affinity, as a node matching any of the terms is eligible.
+func pinToZone(podTemplate *corev1.PodTemplateSpec, topologyKey string, zone mdbv1.ZoneSpecItem) {
+	requirement := corev1.NodeSelectorRequirement{Key: topologyKey, Operator: corev1.NodeSelectorOpIn, Values: []string{zone.Zone}}
+
+	if podTemplate.Spec.Affinity == nil {
+		podTemplate.Spec.Affinity = &corev1.Affinity{}
+	}
+	if podTemplate.Spec.Affinity.NodeAffinity == nil {
+		podTemplate.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podTemplate.Spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil || len(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{}}}
+	}
+	terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for i := range terms {
+		terms[i].MatchExpressions = append(terms[i].MatchExpressions, requirement)
+	}
+
+	if len(zone.NodeSelector) > 0 {
+		podTemplate.Spec.NodeSelector = merge.StringToStringMap(podTemplate.Spec.NodeSelector, zone.NodeSelector)
+	}
+}
+
+// DatabasePodLabels returns the labels of the Pods of the database StatefulSet built with 'opts', which are also the
+// labels the StatefulSet selects its Pods with. The Pods of the zones of a replica set also have the label of their
+// zone, so that the StatefulSets of the zones don't select each other's Pods.
+func DatabasePodLabels(opts DatabaseStatefulSetOptions) map[string]string {
+	podLabels := map[string]string{
+		appLabelKey:             opts.ServiceName,
+		util.OperatorLabelName:  util.OperatorLabelValue,
+		PodAntiAffinityLabelKey: opts.Name,
+	}
+	if opts.Zone != nil {
+		podLabels[ZoneLabelKey] = opts.Zone.Zone
+	}
+	return podLabels
+}
+
 func DatabaseStatefulSetHelper(mdb databaseStatefulSetSource, stsOpts *DatabaseStatefulSetOptions, log *zap.SugaredLogger) appsv1.StatefulSet {
 	allSources := getAllMongoDBVolumeSources(mdb, *stsOpts, log)
 
@@ -417,11 +533,7 @@ func buildVaultDatabaseSecretsToInject(mdb databaseStatefulSetSource, opts Datab
 
 // buildDatabaseStatefulSetConfigurationFunction returns the function that will modify the StatefulSet
 func buildDatabaseStatefulSetConfigurationFunction(mdb databaseStatefulSetSource, podTemplateSpecFunc podtemplatespec.Modification, opts DatabaseStatefulSetOptions, log *zap.SugaredLogger) statefulset.Modification {
-	podLabels := map[string]string{
-		appLabelKey:             opts.ServiceName,
-		util.OperatorLabelName:  util.OperatorLabelValue,
-		PodAntiAffinityLabelKey: opts.Name,
-	}
+	podLabels := DatabasePodLabels(opts)
 
 	configurePodSpecSecurityContext, configureContainerSecurityContext := podtemplatespec.WithDefaultSecurityContextsModifications()
 
@@ -433,6 +545,9 @@ func buildDatabaseStatefulSetConfigurationFunction(mdb databaseStatefulSetSource
 
 	secretsToInject := buildVaultDatabaseSecretsToInject(mdb, opts)
 	volumes, volumeMounts := getVolumesAndVolumeMounts(mdb, opts, secretsToInject.AgentCerts, secretsToInject.InternalClusterAuth)
+	awsVolumes, awsVolumeMounts := getAWSSecretsVolumeAndVolumeMounts(secretsToInject, databaseStatefulSetName(opts))
+	volumes = append(volumes, awsVolumes...)
+	volumeMounts = append(volumeMounts, awsVolumeMounts...)
 
 	allSources := getAllMongoDBVolumeSources(mdb, opts, log)
 	for _, source := range allSources {
@@ -481,10 +596,9 @@ func buildDatabaseStatefulSetConfigurationFunction(mdb databaseStatefulSetSource
 		podTemplateAnnotationFunc = podtemplatespec.Apply(podTemplateAnnotationFunc, podtemplatespec.WithAnnotations(secretsToInject.DatabaseAnnotations(mdb.GetNamespace())))
 	}
 
-	stsName := opts.GetStatefulSetName()
+	stsName := databaseStatefulSetName(opts)
 	podAffinity := mdb.GetName()
 	if opts.StatefulSetNameOverride != "" {
-		stsName = opts.StatefulSetNameOverride
 		podAffinity = opts.StatefulSetNameOverride
 	}
 
@@ -533,6 +647,7 @@ func buildDatabaseStatefulSetConfigurationFunction(mdb databaseStatefulSetSource
 	podTemplateModifications := []podtemplatespec.Modification{
 		podTemplateAnnotationFunc,
 		podtemplatespec.WithAffinity(podAffinity, PodAntiAffinityLabelKey, 100),
+		podtemplatespec.WithTopologySpreadConstraints(opts.TopologySpread.Constraints(map[string]string{PodAntiAffinityLabelKey: opts.Name})),
 		podtemplatespec.WithTerminationGracePeriodSeconds(util.DefaultPodTerminationPeriodSeconds),
 		podtemplatespec.WithPodLabels(podLabels),
 		podtemplatespec.WithContainerByIndex(0, sharedDatabaseContainerFunc(databaseImage, *opts.PodSpec, volumeMounts, configureContainerSecurityContext, opts.ServicePort)),
@@ -560,6 +675,14 @@ func buildDatabaseStatefulSetConfigurationFunction(mdb databaseStatefulSetSource
 	)
 }
 
+// databaseStatefulSetName returns the name of the StatefulSet built from the options.
+func databaseStatefulSetName(opts DatabaseStatefulSetOptions) string {
+	if opts.StatefulSetNameOverride != "" {
+		return opts.StatefulSetNameOverride
+	}
+	return opts.GetStatefulSetName()
+}
+
 func buildPersistentVolumeClaimsFuncs(opts DatabaseStatefulSetOptions) (map[string]persistentvolumeclaim.Modification, []corev1.VolumeMount) {
 	var claims map[string]persistentvolumeclaim.Modification
 	var mounts []corev1.VolumeMount
@@ -617,7 +740,7 @@ func getTLSPrometheusVolumeAndVolumeMount(prom *v1.Prometheus) ([]corev1.Volume,
 	volumes := []corev1.Volume{}
 	volumeMounts := []corev1.VolumeMount{}
 
-	if prom == nil || vault.IsVaultSecretBackend() {
+	if prom == nil || !secrets.IsKubernetesSecretBackend() {
 		return volumes, volumeMounts
 	}
 
@@ -665,7 +788,7 @@ func getVolumesAndVolumeMounts(mdb databaseStatefulSetSource, databaseOpts Datab
 	volumesToAdd = append(volumesToAdd, prometheusVolumes...)
 	volumeMounts = append(volumeMounts, prometheusVolumeMounts...)
 
-	if !vault.IsVaultSecretBackend() && mdb.GetSecurity().ShouldUseX509(databaseOpts.CurrentAgentAuthMode) || mdb.GetSecurity().ShouldUseClientCertificates() {
+	if secrets.IsKubernetesSecretBackend() && mdb.GetSecurity().ShouldUseX509(databaseOpts.CurrentAgentAuthMode) || mdb.GetSecurity().ShouldUseClientCertificates() {
 		agentSecretVolume := statefulset.CreateVolumeFromSecret(util.AgentSecretName, agentCertsSecretName)
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			MountPath: util.AgentCertMountPath,
@@ -676,7 +799,7 @@ func getVolumesAndVolumeMounts(mdb databaseStatefulSetSource, databaseOpts Datab
 	}
 
 	// add volume for x509 cert used in internal cluster authentication
-	if !vault.IsVaultSecretBackend() && mdb.GetSecurity().GetInternalClusterAuthenticationMode() == util.X509 {
+	if secrets.IsKubernetesSecretBackend() && mdb.GetSecurity().GetInternalClusterAuthenticationMode() == util.X509 {
 		internalClusterAuthVolume := statefulset.CreateVolumeFromSecret(util.ClusterFileName, internalClusterAuthSecretName)
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			MountPath: util.InternalClusterAuthMountPath,
@@ -686,7 +809,7 @@ func getVolumesAndVolumeMounts(mdb databaseStatefulSetSource, databaseOpts Datab
 		volumesToAdd = append(volumesToAdd, internalClusterAuthVolume)
 	}
 
-	if !vault.IsVaultSecretBackend() {
+	if secrets.IsKubernetesSecretBackend() {
 		volumesToAdd = append(volumesToAdd, statefulset.CreateVolumeFromSecret(AgentAPIKeyVolumeName, agents.ApiKeySecretName(databaseOpts.PodVars.ProjectID)))
 		volumeMounts = append(volumeMounts, statefulset.CreateVolumeMount(AgentAPIKeyVolumeName, AgentAPIKeySecretPath))
 	}
@@ -854,7 +977,7 @@ func sharedDatabaseConfiguration(opts DatabaseStatefulSetOptions) podtemplatespe
 	}
 
 	return podtemplatespec.Apply(
-		podtemplatespec.WithPodLabels(defaultPodLabels(opts.ServiceName, opts.Name)),
+		podtemplatespec.WithPodLabels(DatabasePodLabels(opts)),
 		podtemplatespec.WithTerminationGracePeriodSeconds(util.DefaultPodTerminationPeriodSeconds),
 		pullSecretsConfigurationFunc,
 		configurePodSpecSecurityContext,
@@ -11,8 +11,10 @@ import (
 	"go.uber.org/zap"
 	"k8s.io/utils/ptr"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
@@ -217,6 +219,90 @@ func TestLabelsAndAnotations(t *testing.T) {
 	assert.Equal(t, labels, sts.Labels)
 }
 
+func TestTopologySpreadConstraints(t *testing.T) {
+	mdb := mdbv1.NewReplicaSetBuilder().Build()
+	mdb.Spec.TopologySpread = &v1.TopologySpread{
+		Zone:     &v1.TopologySpreadConstraint{MinDomains: ptr.To(int32(3))},
+		Hostname: &v1.TopologySpreadConstraint{WhenUnsatisfiable: corev1.ScheduleAnyway},
+	}
+	sts := DatabaseStatefulSet(*mdb, ReplicaSetOptions(GetPodEnvOptions()), zap.S())
+
+	constraints := sts.Spec.Template.Spec.TopologySpreadConstraints
+	require.Len(t, constraints, 2)
+	assert.Equal(t, corev1.LabelTopologyZone, constraints[0].TopologyKey)
+	assert.Equal(t, ptr.To(int32(3)), constraints[0].MinDomains)
+	assert.Equal(t, corev1.LabelHostname, constraints[1].TopologyKey)
+	assert.Equal(t, corev1.ScheduleAnyway, constraints[1].WhenUnsatisfiable)
+	// only the Pods of the StatefulSet are counted
+	for _, constraint := range constraints {
+		assert.Equal(t, map[string]string{PodAntiAffinityLabelKey: mdb.Name}, constraint.LabelSelector.MatchLabels)
+	}
+
+	mdb.Spec.TopologySpread = nil
+	sts = DatabaseStatefulSet(*mdb, ReplicaSetOptions(GetPodEnvOptions()), zap.S())
+	assert.Empty(t, sts.Spec.Template.Spec.TopologySpreadConstraints)
+}
+
+func TestTopologySpreadConstraints_MultiClusterShardedCluster(t *testing.T) {
+	sc := mdbv1.NewClusterBuilder().SetMultiClusterTopology().Build()
+	sc.Spec.TopologySpread = &v1.TopologySpread{Zone: &v1.TopologySpreadConstraint{}}
+	clusterSpecList := mdbv1.ClusterSpecList{{ClusterName: "cluster-1", Members: 3}}
+	shardSpec := &mdbv1.ShardedClusterComponentSpec{ClusterSpecList: clusterSpecList}
+	configSrvSpec := &mdbv1.ShardedClusterComponentSpec{ClusterSpecList: clusterSpecList}
+
+	// the StatefulSets of a multi-cluster sharded cluster are named after the index of the member cluster, but their
+	// Pods are labelled with the name of the replica set
+	stsName := func(name string) func(options *DatabaseStatefulSetOptions) {
+		return func(options *DatabaseStatefulSetOptions) {
+			options.StatefulSetNameOverride = name
+		}
+	}
+	for _, sts := range []appsv1.StatefulSet{
+		DatabaseStatefulSet(*sc, ShardOptions(0, shardSpec, "cluster-1", GetPodEnvOptions(), stsName(sc.MultiShardRsName(1, 0))), zap.S()),
+		DatabaseStatefulSet(*sc, ConfigServerOptions(configSrvSpec, "cluster-1", GetPodEnvOptions(), stsName(sc.MultiConfigRsName(1))), zap.S()),
+	} {
+		constraints := sts.Spec.Template.Spec.TopologySpreadConstraints
+		require.Len(t, constraints, 1)
+		selector := constraints[0].LabelSelector.MatchLabels
+		assert.NotEmpty(t, selector)
+		for key, value := range selector {
+			assert.Equal(t, value, sts.Spec.Template.Labels[key], "the constraint of %s must select its Pods", sts.Name)
+		}
+	}
+}
+
+func TestReplicaSetZoneOptions(t *testing.T) {
+	mdb := mdbv1.NewReplicaSetBuilder().SetMembers(3).Build()
+	mdb.Spec.ZonePlacement = &mdbv1.ZonePlacement{
+		TopologyKey: "example.com/rack",
+		Zones:       []mdbv1.ZoneSpecItem{{Zone: "rack-1", Members: 2, NodeSelector: map[string]string{"disktype": "ssd"}}, {Zone: "rack-2", Members: 1}},
+	}
+	mdb.Spec.PodSpec.PodTemplateWrapper = v1.PodTemplateSpecWrapper{PodTemplate: &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}}}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"}}}},
+			}},
+		}}},
+	}}
+
+	sts := DatabaseStatefulSet(*mdb, ReplicaSetZoneOptions(ReplicaSetOptions(GetPodEnvOptions()), "rack-1", 0, 2), zap.S())
+	assert.Equal(t, mdb.Name+"-0", sts.Name)
+	assert.Equal(t, int32(2), *sts.Spec.Replicas)
+	assert.Equal(t, "rack-1", sts.Spec.Selector.MatchLabels[ZoneLabelKey])
+	assert.Equal(t, "rack-1", sts.Spec.Template.Labels[ZoneLabelKey])
+	assert.Equal(t, map[string]string{"disktype": "ssd"}, sts.Spec.Template.Spec.NodeSelector)
+
+	// the zone is added to every term of the podTemplate, which are ORed
+	zone := corev1.NodeSelectorRequirement{Key: "example.com/rack", Operator: corev1.NodeSelectorOpIn, Values: []string{"rack-1"}}
+	terms := sts.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 2)
+	for _, term := range terms {
+		assert.Len(t, term.MatchExpressions, 2)
+		assert.Equal(t, zone, term.MatchExpressions[1])
+	}
+}
+
 func TestLogConfigurationToEnvVars(t *testing.T) {
 	var parameters mdbv1.StartupParameters = map[string]string{
 		"a":       "1",
@@ -377,3 +463,27 @@ func TestDatabaseStatefulSet_StaticContainersEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseStatefulSet_AWSSecretsManagerBackend(t *testing.T) {
+	t.Setenv("SECRET_BACKEND", "AWS_SECRETS_MANAGER_BACKEND")
+	rs := mdbv1.NewReplicaSetBuilder().Build()
+
+	sts := DatabaseStatefulSet(*rs, ReplicaSetOptions(GetPodEnvOptions()), zap.S())
+	podSpec := sts.Spec.Template.Spec
+
+	var secretsStoreVolume *corev1.Volume
+	for i := range podSpec.Volumes {
+		assert.NotEqual(t, AgentAPIKeyVolumeName, podSpec.Volumes[i].Name, "the agent API key must not be mounted from a Kubernetes secret")
+		if podSpec.Volumes[i].Name == SecretsStoreVolumeName {
+			secretsStoreVolume = &podSpec.Volumes[i]
+		}
+	}
+	require.NotNil(t, secretsStoreVolume)
+	require.NotNil(t, secretsStoreVolume.CSI)
+	assert.Equal(t, DatabaseSecretProviderClassName(sts.Name), secretsStoreVolume.CSI.VolumeAttributes["secretProviderClass"])
+
+	mounts := podSpec.Containers[0].VolumeMounts
+	assert.True(t, slices.ContainsFunc(mounts, func(m corev1.VolumeMount) bool {
+		return m.Name == SecretsStoreVolumeName && m.MountPath == path.Join(AgentAPIKeySecretPath, util.OmAgentApiKey)
+	}))
+}
@@ -0,0 +1,66 @@
+package construct
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
+)
+
+// SecretsStoreVolumeName is the volume of the Secrets Store CSI driver the secrets stored in AWS Secrets Manager are
+// mounted from.
+const SecretsStoreVolumeName = "secrets-store"
+
+// DatabaseSecretProviderClassName returns the name of the SecretProviderClass used by the pods of the StatefulSet.
+func DatabaseSecretProviderClassName(stsName string) string {
+	return stsName + "-secrets"
+}
+
+// DatabaseSecretProviderClass returns the SecretProviderClass making the CSI driver mount in the database pods the
+// same files as the Vault Agent injects.
+func DatabaseSecretProviderClass(mdb databaseStatefulSetSource, opts DatabaseStatefulSetOptions, stsName string, ownerReferences []metav1.OwnerReference) (*unstructured.Unstructured, error) {
+	files := buildAWSDatabaseSecretFiles(buildVaultDatabaseSecretsToInject(mdb, opts))
+	return opts.AWSSecretsConfig.SecretProviderClass(DatabaseSecretProviderClassName(stsName), mdb.GetNamespace(), awssecrets.DatabaseSecretPath, files, ownerReferences)
+}
+
+// getAWSSecretsVolumeAndVolumeMounts returns the CSI volume the secrets stored in AWS Secrets Manager are mounted from.
+func getAWSSecretsVolumeAndVolumeMounts(secretsToInject vault.DatabaseSecretsToInject, stsName string) ([]corev1.Volume, []corev1.VolumeMount) {
+	if !awssecrets.IsSecretsManagerBackend() {
+		return nil, nil
+	}
+	volume, mounts := awssecrets.CSIVolumeAndMounts(SecretsStoreVolumeName, DatabaseSecretProviderClassName(stsName), buildAWSDatabaseSecretFiles(secretsToInject))
+	return []corev1.Volume{volume}, mounts
+}
+
+// buildAWSDatabaseSecretFiles returns the files the CSI driver writes in the database pods. The PEM secrets are only
+// mounted once their hash is known, as the CSI driver fails to mount keys which don't exist.
+func buildAWSDatabaseSecretFiles(secretsToInject vault.DatabaseSecretsToInject) []awssecrets.SecretFile {
+	files := []awssecrets.SecretFile{{
+		SecretName: secretsToInject.AgentApiKey,
+		Key:        util.OmAgentApiKey,
+		MountPath:  AgentAPIKeySecretPath,
+		FileName:   util.OmAgentApiKey,
+	}}
+
+	pemFiles := []struct {
+		secretName string
+		hash       string
+		mountPath  string
+	}{
+		{secretsToInject.AgentCerts, secretsToInject.AgentCertsHash, util.AgentCertMountPath},
+		{secretsToInject.InternalClusterAuth, secretsToInject.InternalClusterHash, util.InternalClusterAuthMountPath},
+		{secretsToInject.MemberClusterAuth, secretsToInject.MemberClusterHash, util.TLSCertMountPath},
+		{secretsToInject.Prometheus, secretsToInject.PrometheusTLSCertHash, util.SecretVolumeMountPathPrometheus},
+	}
+	for _, f := range pemFiles {
+		if f.secretName == "" || f.hash == "" {
+			continue
+		}
+		files = append(files, awssecrets.SecretFile{SecretName: f.secretName, Key: f.hash, MountPath: f.mountPath, FileName: f.hash})
+	}
+	return files
+}
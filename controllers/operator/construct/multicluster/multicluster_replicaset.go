@@ -32,7 +32,10 @@ func MultiClusterReplicaSetOptions(additionalOpts ...func(options *construct.Dat
 			StatefulSetSpecOverride:       &stsSpec,
 			StsType:                       construct.MultiReplicaSet,
 			Annotations:                   handler.MultiClusterStatefulSetAnnotations(mdbm.Name),
+			PodDisruptionBudget:           mdbm.Spec.PodDisruptionBudget,
+			TopologySpread:                mdbm.Spec.TopologySpread,
 		}
+
 		for _, opt := range additionalOpts {
 			opt(&opts)
 		}
@@ -41,6 +44,14 @@ func MultiClusterReplicaSetOptions(additionalOpts ...func(options *construct.Dat
 	}
 }
 
+// WithMemberClusterPodDisruptionBudget sets the default PodDisruptionBudget of the StatefulSet in the member cluster.
+// The majority is computed over all the members of the replica set, including the ones in other member clusters.
+func WithMemberClusterPodDisruptionBudget(mdbm mdbmultiv1.MongoDBMultiCluster, clusterName string) func(options *construct.DatabaseStatefulSetOptions) {
+	return func(options *construct.DatabaseStatefulSetOptions) {
+		options.DefaultPodDisruptionBudget = construct.MemberClusterPodDisruptionBudget(mdbm.Spec.ElectionPolicy.Apply(mdbm.Spec.ClusterSpecList), clusterName)
+	}
+}
+
 func WithServiceName(serviceName string) func(options *construct.DatabaseStatefulSetOptions) {
 	return func(options *construct.DatabaseStatefulSetOptions) {
 		options.ServiceName = serviceName
@@ -311,3 +311,23 @@ func TestMultiClusterStatefulSet_StaticContainersEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiClusterReplicaSetOptions_PodDisruptionBudgetIsSplitBetweenMemberClusters(t *testing.T) {
+	mdbm := getMultiClusterMongoDB()
+	mdbm.Spec.ClusterSpecList = mdb.ClusterSpecList{
+		{ClusterName: "foo", Members: 2},
+		{ClusterName: "bar", Members: 2},
+		{ClusterName: "baz", Members: 1},
+	}
+
+	// the replica set of 5 members can lose 2 of them, one in each of the two largest member clusters
+	for clusterName, expected := range map[string]int{"foo": 1, "bar": 1} {
+		opts := MultiClusterReplicaSetOptions(WithMemberClusterPodDisruptionBudget(mdbm, clusterName))(mdbm)
+		require.NotNil(t, opts.DefaultPodDisruptionBudget.MaxUnavailable, clusterName)
+		assert.Equal(t, expected, opts.DefaultPodDisruptionBudget.MaxUnavailable.IntValue(), clusterName)
+	}
+
+	// the PodDisruptionBudget of the member cluster that gets no Pod would block the drain of its nodes
+	opts := MultiClusterReplicaSetOptions(WithMemberClusterPodDisruptionBudget(mdbm, "baz"))(mdbm)
+	assert.False(t, opts.DefaultPodDisruptionBudget.IsEnabled())
+}
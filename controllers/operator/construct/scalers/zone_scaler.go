@@ -0,0 +1,22 @@
+package scalers
+
+import (
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+)
+
+// GetReplicaSetZoneScalers returns a scaler for the StatefulSet of each zone of a replica set with spec.zonePlacement,
+// ordered by the index of the zone, so that the members are moved between the zones one at a time. The zones removed
+// from the spec are scaled down to zero.
+func GetReplicaSetZoneScalers(mdb *mdbv1.MongoDB) []*MultiClusterReplicaSetScaler {
+	if mdb.Spec.ZonePlacement == nil {
+		return nil
+	}
+
+	zones := mdb.ZoneMembers()
+	clusterSpecList := mdb.Spec.ZonePlacement.ClusterSpecList()
+	zoneScalers := make([]*MultiClusterReplicaSetScaler, 0, len(zones))
+	for _, zone := range zones {
+		zoneScalers = append(zoneScalers, NewMultiClusterReplicaSetScaler("zone", clusterSpecList, zone.Name, zone.Index, zones))
+	}
+	return zoneScalers
+}
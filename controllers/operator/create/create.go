@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 
@@ -27,6 +26,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status/pvc"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
 	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
@@ -49,11 +49,26 @@ var (
 // It returns any errors coming from Kubernetes API.
 func DatabaseInKubernetes(ctx context.Context, client kubernetesClient.Client, mdb mdbv1.MongoDB, sts appsv1.StatefulSet, config func(mdb mdbv1.MongoDB) construct.DatabaseStatefulSetOptions, log *zap.SugaredLogger) (*appsv1.StatefulSet, error) {
 	opts := config(mdb)
+	if awssecrets.IsSecretsManagerBackend() {
+		// the class is updated before the StatefulSet, so that the restarted pods mount the new certificates
+		class, err := construct.DatabaseSecretProviderClass(&mdb, opts, sts.Name, mdb.OwnerReferenceForMemberCluster())
+		if err != nil {
+			return nil, err
+		}
+		if err := awssecrets.CreateOrUpdateSecretProviderClass(ctx, client, class); err != nil {
+			return nil, err
+		}
+	}
+
 	set, err := enterprisests.CreateOrUpdateStatefulset(ctx, client, mdb.Namespace, log, &sts)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := DatabasePodDisruptionBudget(ctx, client, *set, opts, mdb.GetOwnerLabels(), mdb.OwnerReferenceForMemberCluster()); err != nil {
+		return set, err
+	}
+
 	// For mc-sharded, we create external services in the ShardedClusterReconcileHelper.reconcileServices method.
 	if mdb.Spec.IsMultiCluster() && mdb.IsShardedCluster() {
 		return set, nil
@@ -128,11 +143,11 @@ func HandlePVCResize(ctx context.Context, memberClient kubernetesClient.Client,
 		}
 
 		log.Infof("Detected PVC size expansion; patching all pvcs and increasing the size for sts: %s", desiredSts.Name)
-		if err := resizePVCsStorage(ctx, memberClient, desiredSts, log); err != nil {
+		if err := enterprisests.ResizePVCs(ctx, memberClient, desiredSts, log); err != nil {
 			return workflow.Failed(xerrors.Errorf("can't resize pvc, err: %s", err))
 		}
 
-		finishedResizing, err := hasFinishedResizing(ctx, memberClient, desiredSts)
+		finishedResizing, err := enterprisests.HasFinishedResizingPVCs(ctx, memberClient, desiredSts)
 		if err != nil {
 			return workflow.Failed(err)
 		}
@@ -146,7 +161,7 @@ func HandlePVCResize(ctx context.Context, memberClient kubernetesClient.Client,
 				return workflow.Failed(xerrors.Errorf("error deleting sts, err: %s", err))
 			}
 
-			deletedIsStatefulset := checkStatefulsetIsDeleted(ctx, memberClient, desiredSts, 1*time.Second, log)
+			deletedIsStatefulset := enterprisests.IsDeleted(ctx, memberClient, desiredSts, 1*time.Second, log)
 
 			if !deletedIsStatefulset {
 				log.Info("deletion has not been reflected in kube yet, restarting the reconcile")
@@ -164,78 +179,6 @@ func HandlePVCResize(ctx context.Context, memberClient kubernetesClient.Client,
 	return workflow.OK()
 }
 
-func checkStatefulsetIsDeleted(ctx context.Context, memberClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet, sleepDuration time.Duration, log *zap.SugaredLogger) bool {
-	// After deleting the statefulset it can take seconds to be reflected in kubernetes.
-	// In case it is still not reflected
-	deletedIsStatefulset := false
-	for i := 0; i < 3; i++ {
-		time.Sleep(sleepDuration)
-		_, stsErr := memberClient.GetStatefulSet(ctx, kube.ObjectKey(desiredSts.Namespace, desiredSts.Name))
-		if apiErrors.IsNotFound(stsErr) {
-			deletedIsStatefulset = true
-			break
-		} else {
-			log.Info("Statefulset still exists, attempting again")
-		}
-	}
-	return deletedIsStatefulset
-}
-
-func hasFinishedResizing(ctx context.Context, memberClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet) (bool, error) {
-	pvcList := corev1.PersistentVolumeClaimList{}
-	if err := memberClient.List(ctx, &pvcList, client.InNamespace(desiredSts.Namespace)); err != nil {
-		return false, err
-	}
-
-	finishedResizing := true
-	for _, currentPVC := range pvcList.Items {
-		if template, index := getMatchingPVCTemplateFromSTS(desiredSts, &currentPVC); template != nil {
-			if currentPVC.Status.Capacity.Storage().Cmp(*desiredSts.Spec.VolumeClaimTemplates[index].Spec.Resources.Requests.Storage()) != 0 {
-				finishedResizing = false
-			}
-		}
-	}
-	return finishedResizing, nil
-}
-
-// resizePVCsStorage takes the sts we want to create and update all matching pvc with the new storage
-func resizePVCsStorage(ctx context.Context, kubeClient kubernetesClient.Client, statefulSetToCreate *appsv1.StatefulSet, log *zap.SugaredLogger) error {
-	// this is to ensure that requests to a potentially not allowed resource is not blocking the operator until the end
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
-	defer cancel()
-
-	pvcList := corev1.PersistentVolumeClaimList{}
-	if err := kubeClient.List(ctx, &pvcList, client.InNamespace(statefulSetToCreate.Namespace)); err != nil {
-		return err
-	}
-
-	for _, existingPVC := range pvcList.Items {
-		if template, _ := getMatchingPVCTemplateFromSTS(statefulSetToCreate, &existingPVC); template != nil {
-			currentSize := existingPVC.Spec.Resources.Requests[corev1.ResourceStorage]
-			targetSize := *template.Spec.Resources.Requests.Storage()
-			log.Infof("Resizing PVC %s/%s from %s to %s", existingPVC.GetNamespace(), existingPVC.GetName(), currentSize.String(), targetSize.String())
-			existingPVC.Spec.Resources.Requests[corev1.ResourceStorage] = targetSize
-			if err := kubeClient.Update(ctx, &existingPVC); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-func getMatchingPVCTemplateFromSTS(statefulSet *appsv1.StatefulSet, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, int) {
-	for i, claimTemplate := range statefulSet.Spec.VolumeClaimTemplates {
-		expectedPrefix := fmt.Sprintf("%s-%s", claimTemplate.Name, statefulSet.Name)
-
-		// Regex to match expectedPrefix followed by a dash and a number (ordinal)
-		regexPattern := fmt.Sprintf("^%s-[0-9]+$", regexp.QuoteMeta(expectedPrefix))
-		if matched, _ := regexp.MatchString(regexPattern, pvc.Name); matched {
-			return &claimTemplate, i
-		}
-	}
-	return nil, -1
-}
-
 // createExternalServices creates the external services.
 // For sharded clusters: services are only created for mongos.
 func createExternalServices(ctx context.Context, client kubernetesClient.Client, mdb mdbv1.MongoDB, opts construct.DatabaseStatefulSetOptions, namespacedName client.ObjectKey, set *appsv1.StatefulSet, podNum int, log *zap.SugaredLogger) error {
@@ -371,7 +314,15 @@ func AppDBInKubernetes(ctx context.Context, client kubernetesClient.Client, opsM
 		internalService.Spec.Ports = append(internalService.Spec.Ports, corev1.ServicePort{Port: int32(prom.GetPort()), Name: "prometheus"})
 	}
 
-	return set, service.CreateOrUpdateService(ctx, client, internalService)
+	if err := service.CreateOrUpdateService(ctx, client, internalService); err != nil {
+		return nil, err
+	}
+
+	if err := PodDisruptionBudgetForStatefulSet(ctx, client, *set, opsManager.Spec.AppDB.PodDisruptionBudget, opsManagerPodDisruptionBudget, opsManager.Spec.AppDB.GetOwnerLabels(), opsManager.AppDBOwnerReferenceForMemberCluster()); err != nil {
+		return nil, xerrors.Errorf("failed to create or update AppDB PodDisruptionBudget: %w", err)
+	}
+
+	return set, nil
 }
 
 type StatefulSetIsRecreating struct {
@@ -405,7 +356,15 @@ func BackupDaemonInKubernetes(ctx context.Context, client kubernetesClient.Clien
 	internalService.OwnerReferences = opsManager.OwnerReferenceForMemberCluster()
 	internalService.Spec.PublishNotReadyAddresses = false
 
-	return set, service.CreateOrUpdateService(ctx, client, internalService)
+	if err := service.CreateOrUpdateService(ctx, client, internalService); err != nil {
+		return nil, err
+	}
+
+	if err := PodDisruptionBudgetForStatefulSet(ctx, client, *set, opsManager.Spec.Backup.PodDisruptionBudget, opsManagerPodDisruptionBudget, opsManager.GetOwnerLabels(), opsManager.OwnerReferenceForMemberCluster()); err != nil {
+		return nil, xerrors.Errorf("failed to create or update Backup Daemon PodDisruptionBudget: %w", err)
+	}
+
+	return set, nil
 }
 
 // OpsManagerInKubernetes creates all the required Kubernetes resources for Ops Manager.
@@ -456,6 +415,14 @@ func OpsManagerInKubernetes(ctx context.Context, memberCluster multicluster.Memb
 		}
 	}
 
+	if err := PodDisruptionBudgetForStatefulSet(ctx, memberCluster.Client, *set, opsManager.Spec.PodDisruptionBudget, opsManagerPodDisruptionBudget, opsManager.GetOwnerLabels(), opsManager.OwnerReferenceForMemberCluster()); err != nil {
+		return nil, xerrors.Errorf("failed to create or update Ops Manager PodDisruptionBudget: %w", err)
+	}
+
+	if err := opsManagerHorizontalPodAutoscaler(ctx, memberCluster.Client, opsManager, *set, memberCluster.Replicas); err != nil {
+		return nil, xerrors.Errorf("failed to create or update Ops Manager HorizontalPodAutoscaler: %w", err)
+	}
+
 	return set, nil
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	enterprisests "github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
 )
@@ -948,7 +949,7 @@ func TestResizePVCsStorage(t *testing.T) {
 	}
 
 	// We are resizing only initialSts PVCs here and otherSts PVCs should remain unchanged
-	err = resizePVCsStorage(context.TODO(), fakeClient, createStatefulSet(testStsName, testStsNamespace, "30Gi", "30Gi", "20Gi"), zap.S())
+	err = enterprisests.ResizePVCs(context.TODO(), fakeClient, createStatefulSet(testStsName, testStsNamespace, "30Gi", "30Gi", "20Gi"), zap.S())
 	assert.NoError(t, err)
 
 	pvcList := corev1.PersistentVolumeClaimList{}
@@ -1206,7 +1207,7 @@ func TestHasFinishedResizing(t *testing.T) {
 		err = fakeClient.Create(ctx, pvc2InDifferentNamespace)
 		assert.NoError(t, err)
 
-		finished, err := hasFinishedResizing(ctx, fakeClient, sts)
+		finished, err := enterprisests.HasFinishedResizingPVCs(ctx, fakeClient, sts)
 		assert.NoError(t, err)
 		assert.True(t, finished, "PVCs should be finished resizing")
 	}
@@ -1218,7 +1219,7 @@ func TestHasFinishedResizing(t *testing.T) {
 		err := fakeClient.Create(ctx, pvc2Incomplete)
 		assert.NoError(t, err)
 
-		finished, err := hasFinishedResizing(ctx, fakeClient, sts)
+		finished, err := enterprisests.HasFinishedResizingPVCs(ctx, fakeClient, sts)
 		assert.NoError(t, err)
 		assert.False(t, finished, "PVCs should not be finished resizing")
 	}
@@ -1345,7 +1346,7 @@ func TestGetMatchingPVCTemplateFromSTS(t *testing.T) {
 				},
 			}
 
-			template, index := getMatchingPVCTemplateFromSTS(statefulSet, p)
+			template, index := enterprisests.MatchingPVCTemplate(statefulSet, p)
 
 			if tt.expectedTemplate == nil {
 				assert.Nil(t, template, "Expected no matching PVC template")
@@ -1573,7 +1574,7 @@ func TestCheckStatefulsetIsDeleted(t *testing.T) {
 			err = fakeClient.DeleteStatefulSet(ctx, kube.ObjectKey(desiredSts.Namespace, desiredSts.Name))
 			assert.NoError(t, err)
 
-			result := checkStatefulsetIsDeleted(ctx, fakeClient, desiredSts, sleepDuration, log)
+			result := enterprisests.IsDeleted(ctx, fakeClient, desiredSts, sleepDuration, log)
 
 			assert.True(t, result, "StatefulSet should be detected as deleted")
 		})
@@ -1586,7 +1587,7 @@ func TestCheckStatefulsetIsDeleted(t *testing.T) {
 			assert.NoError(t, err)
 
 			// Don't delete - should return false after exhausting retries
-			result := checkStatefulsetIsDeleted(ctx, fakeClient, desiredSts, sleepDuration, log)
+			result := enterprisests.IsDeleted(ctx, fakeClient, desiredSts, sleepDuration, log)
 
 			assert.False(t, result, "StatefulSet should not be detected as deleted")
 		})
@@ -1604,7 +1605,7 @@ func TestCheckStatefulsetIsDeleted(t *testing.T) {
 				_ = fakeClient.DeleteStatefulSet(ctx, kube.ObjectKey(desiredSts.Namespace, desiredSts.Name))
 			}()
 
-			result := checkStatefulsetIsDeleted(ctx, fakeClient, desiredSts, sleepDuration, log)
+			result := enterprisests.IsDeleted(ctx, fakeClient, desiredSts, sleepDuration, log)
 
 			assert.True(t, result, "StatefulSet should be detected as deleted")
 		})
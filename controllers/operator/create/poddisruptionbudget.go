@@ -0,0 +1,69 @@
+package create
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/horizontalpodautoscaler"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/poddisruptionbudget"
+)
+
+// opsManagerPodDisruptionBudget is used for the Ops Manager, Backup Daemon and AppDB StatefulSets if the user hasn't
+// configured the PodDisruptionBudget: at most one Pod in a member cluster can be evicted at a time.
+var opsManagerPodDisruptionBudget = v1.PodDisruptionBudgetConfiguration{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+
+// PodDisruptionBudgetForStatefulSet creates or updates the PodDisruptionBudget protecting the Pods of the StatefulSet.
+// 'defaultConfig' is used if the user hasn't specified neither minAvailable nor maxUnavailable.
+// The PodDisruptionBudget is removed if it's disabled (either by the user or by the default configuration)
+// or if the StatefulSet has been scaled down to zero.
+func PodDisruptionBudgetForStatefulSet(ctx context.Context, c client.Client, sts appsv1.StatefulSet, config *v1.PodDisruptionBudgetConfiguration, defaultConfig v1.PodDisruptionBudgetConfiguration, labels map[string]string, ownerReferences []metav1.OwnerReference) error {
+	config = config.WithDefault(defaultConfig)
+	if !config.IsEnabled() || ptr.Deref(sts.Spec.Replicas, 1) == 0 {
+		return poddisruptionbudget.DeleteIfExists(ctx, c, kube.ObjectKey(sts.Namespace, sts.Name))
+	}
+	pdb := poddisruptionbudget.BuildForStatefulSet(sts, config.MinAvailable, config.MaxUnavailable, labels, ownerReferences)
+	return poddisruptionbudget.CreateOrUpdate(ctx, c, pdb)
+}
+
+// opsManagerHorizontalPodAutoscaler creates or updates the HorizontalPodAutoscaler for the Ops Manager StatefulSet
+// if autoscaling is configured, and removes it otherwise.
+func opsManagerHorizontalPodAutoscaler(ctx context.Context, c client.Client, opsManager *omv1.MongoDBOpsManager, sts appsv1.StatefulSet, members int) error {
+	autoscaling := opsManager.Spec.Autoscaling
+	if autoscaling == nil || members == 0 {
+		return horizontalpodautoscaler.DeleteIfExists(ctx, c, kube.ObjectKey(sts.Namespace, sts.Name))
+	}
+
+	hpa := horizontalpodautoscaler.BuildForStatefulSet(sts, ptr.To(autoscaling.MinReplicasOrDefault(members)), autoscaling.MaxReplicas,
+		autoscaling.MetricsOrDefault(), autoscaling.Behavior, opsManager.GetOwnerLabels(), opsManager.OwnerReferenceForMemberCluster())
+	return horizontalpodautoscaler.CreateOrUpdate(ctx, c, hpa)
+}
+
+// DatabasePodDisruptionBudget creates or updates the PodDisruptionBudget of a database StatefulSet built with 'opts'.
+func DatabasePodDisruptionBudget(ctx context.Context, c client.Client, sts appsv1.StatefulSet, opts construct.DatabaseStatefulSetOptions, labels map[string]string, ownerReferences []metav1.OwnerReference) error {
+	return PodDisruptionBudgetForStatefulSet(ctx, c, sts, opts.PodDisruptionBudget, opts.DefaultPodDisruptionBudget, labels, ownerReferences)
+}
+
+// ZonedReplicaSetPodDisruptionBudget creates or updates the PodDisruptionBudget of a replica set with spec.zonePlacement.
+// A single PodDisruptionBudget, named after the replica set, protects the Pods of all the zones, as they share the
+// majority of voting members that has to stay available. 'opts' are the options of the replica set, not of a zone.
+func ZonedReplicaSetPodDisruptionBudget(ctx context.Context, c client.Client, mdb mdbv1.MongoDB, opts construct.DatabaseStatefulSetOptions, members int) error {
+	sts := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: mdb.Name, Namespace: mdb.Namespace},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(members)), //nolint:gosec
+			Selector: &metav1.LabelSelector{MatchLabels: construct.DatabasePodLabels(opts)},
+		},
+	}
+	return PodDisruptionBudgetForStatefulSet(ctx, c, sts, opts.PodDisruptionBudget, opts.DefaultPodDisruptionBudget, mdb.GetOwnerLabels(), mdb.OwnerReferenceForMemberCluster())
+}
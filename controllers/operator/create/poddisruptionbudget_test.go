@@ -0,0 +1,138 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
+)
+
+func TestPodDisruptionBudgetForStatefulSet(t *testing.T) {
+	ctx := context.Background()
+	sts := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-sts", Namespace: mock.TestNamespace},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To[int32](3),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-sts-svc"}},
+		},
+	}
+	labels := map[string]string{"owner": "me"}
+	key := kube.ObjectKey(sts.Namespace, sts.Name)
+
+	t.Run("Default configuration is used if not customized", func(t *testing.T) {
+		fakeClient := mock.NewEmptyFakeClientWithInterceptor(nil)
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, nil, opsManagerPodDisruptionBudget, labels, nil))
+
+		pdb := policyv1.PodDisruptionBudget{}
+		require.NoError(t, fakeClient.Get(ctx, key, &pdb))
+		assert.Equal(t, labels, pdb.Labels)
+		assert.Equal(t, sts.Spec.Selector, pdb.Spec.Selector)
+		assert.Equal(t, ptr.To(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+	})
+	t.Run("Custom configuration replaces the default one", func(t *testing.T) {
+		fakeClient := mock.NewEmptyFakeClientWithInterceptor(nil)
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, nil, opsManagerPodDisruptionBudget, labels, nil))
+
+		config := &v1.PodDisruptionBudgetConfiguration{MinAvailable: ptr.To(intstr.FromString("50%"))}
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, config, opsManagerPodDisruptionBudget, labels, nil))
+
+		pdb := policyv1.PodDisruptionBudget{}
+		require.NoError(t, fakeClient.Get(ctx, key, &pdb))
+		assert.Equal(t, ptr.To(intstr.FromString("50%")), pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+	})
+	t.Run("PodDisruptionBudget is removed when disabled", func(t *testing.T) {
+		fakeClient := mock.NewEmptyFakeClientWithInterceptor(nil)
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, nil, opsManagerPodDisruptionBudget, labels, nil))
+
+		config := &v1.PodDisruptionBudgetConfiguration{Enabled: ptr.To(false)}
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, config, opsManagerPodDisruptionBudget, labels, nil))
+
+		err := fakeClient.Get(ctx, key, &policyv1.PodDisruptionBudget{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+	t.Run("PodDisruptionBudget is removed when the default configuration is disabled", func(t *testing.T) {
+		fakeClient := mock.NewEmptyFakeClientWithInterceptor(nil)
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, nil, v1.VotingMajorityPodDisruptionBudget(3), labels, nil))
+
+		// two voting members can't lose any of them
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, nil, v1.VotingMajorityPodDisruptionBudget(2), labels, nil))
+		err := fakeClient.Get(ctx, key, &policyv1.PodDisruptionBudget{})
+		assert.True(t, errors.IsNotFound(err))
+
+		// the user configuration takes precedence
+		config := &v1.PodDisruptionBudgetConfiguration{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, config, v1.VotingMajorityPodDisruptionBudget(2), labels, nil))
+		require.NoError(t, fakeClient.Get(ctx, key, &policyv1.PodDisruptionBudget{}))
+	})
+	t.Run("PodDisruptionBudget is removed when scaled down to zero", func(t *testing.T) {
+		fakeClient := mock.NewEmptyFakeClientWithInterceptor(nil)
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, sts, nil, opsManagerPodDisruptionBudget, labels, nil))
+
+		scaledDown := *sts.DeepCopy()
+		scaledDown.Spec.Replicas = ptr.To[int32](0)
+		require.NoError(t, PodDisruptionBudgetForStatefulSet(ctx, fakeClient, scaledDown, nil, opsManagerPodDisruptionBudget, labels, nil))
+
+		err := fakeClient.Get(ctx, key, &policyv1.PodDisruptionBudget{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
+func TestOpsManagerInKubernetes_HorizontalPodAutoscaler(t *testing.T) {
+	ctx := context.Background()
+	testOm := omv1.NewOpsManagerBuilderDefault().
+		SetName("test-om").
+		SetAppDBPassword("my-secret", "password").
+		SetAutoscaling(&omv1.OpsManagerAutoscaling{MaxReplicas: 4}).
+		Build()
+
+	fakeClient, _ := mock.NewDefaultFakeClient()
+	secretsClient := secrets.SecretClient{VaultClient: &vault.VaultClient{}, KubeClient: fakeClient}
+	memberCluster := multicluster.GetLegacyCentralMemberCluster(testOm.Spec.Replicas, 0, fakeClient, secretsClient)
+
+	sts, err := construct.OpsManagerStatefulSet(ctx, secretsClient, testOm, memberCluster, zap.S())
+	require.NoError(t, err)
+	_, err = OpsManagerInKubernetes(ctx, memberCluster, testOm, sts, zap.S())
+	require.NoError(t, err)
+
+	hpa := autoscalingv2.HorizontalPodAutoscaler{}
+	require.NoError(t, fakeClient.Get(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name), &hpa))
+	assert.Equal(t, "StatefulSet", hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, testOm.Name, hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, ptr.To[int32](1), hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(4), hpa.Spec.MaxReplicas)
+	require.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, ptr.To[int32](80), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Len(t, hpa.OwnerReferences, 1)
+
+	pdb := policyv1.PodDisruptionBudget{}
+	require.NoError(t, fakeClient.Get(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name), &pdb))
+
+	// the HorizontalPodAutoscaler is removed when autoscaling is disabled
+	testOm.Spec.Autoscaling = nil
+	_, err = OpsManagerInKubernetes(ctx, memberCluster, testOm, sts, zap.S())
+	require.NoError(t, err)
+
+	err = fakeClient.Get(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name), &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, errors.IsNotFound(err))
+}
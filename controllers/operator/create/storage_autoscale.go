@@ -0,0 +1,142 @@
+package create
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumestats"
+	enterprisests "github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/timeutil"
+)
+
+// AutoscaleStorage sets the storage of the volume claim templates of the desired StatefulSet when storage autoscaling
+// is enabled, so that HandlePVCResize expands the PVCs:
+//   - the storage is never lower than the storage of the existing PVCs, which may have been expanded before
+//   - the storage is grown by the configured increase when the usage of one of the PVCs of the template crosses the
+//     threshold
+//
+// The expansions are returned to be recorded in the status. Nothing is expanded while a resize is in progress, as the
+// capacity reported by the kubelet is the one before the resize.
+func AutoscaleStorage(ctx context.Context, memberClient kubernetesClient.Client, reader volumestats.Reader, autoscale *v1.StorageAutoscale, desiredSts *appsv1.StatefulSet, log *zap.SugaredLogger) ([]status.StorageExpansion, error) {
+	if autoscale == nil || len(desiredSts.Spec.VolumeClaimTemplates) == 0 {
+		return nil, nil
+	}
+
+	pvcList := corev1.PersistentVolumeClaimList{}
+	if err := memberClient.List(ctx, &pvcList, client.InNamespace(desiredSts.Namespace)); err != nil {
+		return nil, xerrors.Errorf("failed to list the PVCs of statefulset %s: %w", desiredSts.Name, err)
+	}
+	pvcsByTemplate := make([][]corev1.PersistentVolumeClaim, len(desiredSts.Spec.VolumeClaimTemplates))
+	resizing := false
+	for _, existingPVC := range pvcList.Items {
+		if template, index := enterprisests.MatchingPVCTemplate(desiredSts, &existingPVC); template != nil {
+			pvcsByTemplate[index] = append(pvcsByTemplate[index], existingPVC)
+			requested := existingPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+			if existingPVC.Status.Capacity.Storage().Cmp(requested) < 0 {
+				resizing = true
+			}
+		}
+	}
+
+	for i, pvcs := range pvcsByTemplate {
+		template := &desiredSts.Spec.VolumeClaimTemplates[i]
+		for _, existingPVC := range pvcs {
+			if requested := existingPVC.Spec.Resources.Requests[corev1.ResourceStorage]; requested.Cmp(*template.Spec.Resources.Requests.Storage()) > 0 {
+				template.Spec.Resources.Requests[corev1.ResourceStorage] = requested
+			}
+		}
+	}
+
+	if resizing {
+		log.Debugf("PVCs of statefulset %s are resizing, not checking their usage", desiredSts.Name)
+		return nil, nil
+	}
+	if reader == nil {
+		return nil, nil
+	}
+
+	usage, err := readPVCsUsage(ctx, memberClient, reader, desiredSts, pvcsByTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var expansions []status.StorageExpansion
+	for i, pvcs := range pvcsByTemplate {
+		template := &desiredSts.Spec.VolumeClaimTemplates[i]
+		usagePercent := 0
+		for _, existingPVC := range pvcs {
+			usagePercent = max(usagePercent, usage[kube.ObjectKey(existingPVC.Namespace, existingPVC.Name)].Percent())
+		}
+		if usagePercent < autoscale.GetUsageThresholdPercent() {
+			continue
+		}
+
+		current := *template.Spec.Resources.Requests.Storage()
+		expanded, err := autoscale.ExpandedStorage(current)
+		if err != nil {
+			return nil, err
+		}
+		if expanded.Cmp(current) <= 0 {
+			log.Warnf("Volume %s of statefulset %s is %d%% full but has reached the maximum storage %s", template.Name, desiredSts.Name, usagePercent, autoscale.MaxStorage)
+			continue
+		}
+
+		log.Infof("Volume %s of statefulset %s is %d%% full, expanding it from %s to %s", template.Name, desiredSts.Name, usagePercent, current.String(), expanded.String())
+		template.Spec.Resources.Requests[corev1.ResourceStorage] = expanded
+		expansions = append(expansions, status.StorageExpansion{
+			StatefulsetName: desiredSts.Name,
+			VolumeName:      template.Name,
+			From:            current.String(),
+			To:              expanded.String(),
+			UsagePercent:    usagePercent,
+			Time:            timeutil.Now(),
+		})
+	}
+	return expansions, nil
+}
+
+// readPVCsUsage reads the usage of the PVCs from the nodes their pods are running on. The PVCs whose pod is not
+// running are ignored.
+func readPVCsUsage(ctx context.Context, memberClient kubernetesClient.Client, reader volumestats.Reader, sts *appsv1.StatefulSet, pvcsByTemplate [][]corev1.PersistentVolumeClaim) (map[types.NamespacedName]volumestats.Usage, error) {
+	nodeNames := map[string]struct{}{}
+	for i, pvcs := range pvcsByTemplate {
+		for _, existingPVC := range pvcs {
+			// the PVCs of a statefulset are named <template>-<pod>
+			podName := strings.TrimPrefix(existingPVC.Name, sts.Spec.VolumeClaimTemplates[i].Name+"-")
+			pod, err := memberClient.GetPod(ctx, kube.ObjectKey(sts.Namespace, podName))
+			if err != nil {
+				if apiErrors.IsNotFound(err) {
+					continue
+				}
+				return nil, xerrors.Errorf("failed to get pod %s: %w", podName, err)
+			}
+			if pod.Spec.NodeName != "" {
+				nodeNames[pod.Spec.NodeName] = struct{}{}
+			}
+		}
+	}
+
+	usage := map[types.NamespacedName]volumestats.Usage{}
+	for nodeName := range nodeNames {
+		nodeUsage, err := reader.NodeVolumeUsage(ctx, nodeName)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(usage, nodeUsage)
+	}
+	return usage, nil
+}
@@ -0,0 +1,162 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumestats"
+)
+
+type fakeVolumeStatsReader map[string]map[types.NamespacedName]volumestats.Usage
+
+func (r fakeVolumeStatsReader) NodeVolumeUsage(_ context.Context, nodeName string) (map[types.NamespacedName]volumestats.Usage, error) {
+	return r[nodeName], nil
+}
+
+func autoscaleTestStatefulSet(storage string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-rs", Namespace: "ns"},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(storage)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newAutoscaleTestClient creates the pods my-rs-0 and my-rs-1, on node-1 and node-2, and their data PVCs.
+func newAutoscaleTestClient(t *testing.T, pvcStorage ...string) kubernetesClient.Client {
+	ctx := context.Background()
+	fakeClient, _ := mock.NewDefaultFakeClient()
+	for i, storage := range pvcStorage {
+		podName := []string{"my-rs-0", "my-rs-1"}[i]
+		require.NoError(t, fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "ns"},
+			Spec:       corev1.PodSpec{NodeName: []string{"node-1", "node-2"}[i]},
+		}))
+		require.NoError(t, fakeClient.Create(ctx, createPVCWithCapacity("data-"+podName, "ns", storage)))
+	}
+	return fakeClient
+}
+
+func usageOf(pvcName string, usedGi, capacityGi int64) map[types.NamespacedName]volumestats.Usage {
+	return map[types.NamespacedName]volumestats.Usage{
+		{Namespace: "ns", Name: pvcName}: {UsedBytes: usedGi << 30, CapacityBytes: capacityGi << 30},
+	}
+}
+
+func TestAutoscaleStorage_ExpandsWhenThresholdIsCrossed(t *testing.T) {
+	c := newAutoscaleTestClient(t, "10Gi", "10Gi")
+	reader := fakeVolumeStatsReader{
+		"node-1": usageOf("data-my-rs-0", 5, 10),
+		"node-2": usageOf("data-my-rs-1", 9, 10),
+	}
+	sts := autoscaleTestStatefulSet("10Gi")
+
+	expansions, err := AutoscaleStorage(context.Background(), c, reader, &v1.StorageAutoscale{MaxStorage: "100Gi"}, sts, zap.S())
+	require.NoError(t, err)
+
+	assert.Equal(t, "12Gi", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+	require.Len(t, expansions, 1)
+	assert.Equal(t, "my-rs", expansions[0].StatefulsetName)
+	assert.Equal(t, "data", expansions[0].VolumeName)
+	assert.Equal(t, "10Gi", expansions[0].From)
+	assert.Equal(t, "12Gi", expansions[0].To)
+	assert.Equal(t, 90, expansions[0].UsagePercent)
+}
+
+func TestAutoscaleStorage_BelowThreshold(t *testing.T) {
+	c := newAutoscaleTestClient(t, "10Gi")
+	reader := fakeVolumeStatsReader{"node-1": usageOf("data-my-rs-0", 8, 10)}
+	sts := autoscaleTestStatefulSet("10Gi")
+
+	expansions, err := AutoscaleStorage(context.Background(), c, reader, &v1.StorageAutoscale{UsageThresholdPercent: ptr.To(90), MaxStorage: "100Gi"}, sts, zap.S())
+	require.NoError(t, err)
+
+	assert.Empty(t, expansions)
+	assert.Equal(t, "10Gi", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+}
+
+func TestAutoscaleStorage_KeepsExpandedStorage(t *testing.T) {
+	// the PVCs have been expanded before, the storage in the spec is lower
+	c := newAutoscaleTestClient(t, "12Gi", "12Gi")
+	reader := fakeVolumeStatsReader{}
+	sts := autoscaleTestStatefulSet("10Gi")
+
+	expansions, err := AutoscaleStorage(context.Background(), c, reader, &v1.StorageAutoscale{MaxStorage: "100Gi"}, sts, zap.S())
+	require.NoError(t, err)
+
+	assert.Empty(t, expansions)
+	assert.Equal(t, "12Gi", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+}
+
+func TestAutoscaleStorage_CappedToMaxStorage(t *testing.T) {
+	c := newAutoscaleTestClient(t, "10Gi")
+	reader := fakeVolumeStatsReader{"node-1": usageOf("data-my-rs-0", 9, 10)}
+	sts := autoscaleTestStatefulSet("10Gi")
+	autoscale := &v1.StorageAutoscale{Increase: "5Gi", MaxStorage: "11Gi"}
+
+	expansions, err := AutoscaleStorage(context.Background(), c, reader, autoscale, sts, zap.S())
+	require.NoError(t, err)
+	require.Len(t, expansions, 1)
+	assert.Equal(t, "11Gi", expansions[0].To)
+
+	// once the maximum storage is reached the volume is not expanded anymore
+	c = newAutoscaleTestClient(t, "11Gi")
+	reader = fakeVolumeStatsReader{"node-1": usageOf("data-my-rs-0", 10, 11)}
+	sts = autoscaleTestStatefulSet("10Gi")
+
+	expansions, err = AutoscaleStorage(context.Background(), c, reader, autoscale, sts, zap.S())
+	require.NoError(t, err)
+	assert.Empty(t, expansions)
+	assert.Equal(t, "11Gi", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+}
+
+func TestAutoscaleStorage_NotDuringResize(t *testing.T) {
+	ctx := context.Background()
+	c := newAutoscaleTestClient(t)
+	pvc := createPVCWithCapacity("data-my-rs-0", "ns", "10Gi")
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("12Gi")
+	require.NoError(t, c.Create(ctx, pvc))
+	require.NoError(t, c.Create(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-rs-0", Namespace: "ns"}, Spec: corev1.PodSpec{NodeName: "node-1"}}))
+	reader := fakeVolumeStatsReader{"node-1": usageOf("data-my-rs-0", 9, 10)}
+	sts := autoscaleTestStatefulSet("10Gi")
+
+	expansions, err := AutoscaleStorage(ctx, c, reader, &v1.StorageAutoscale{MaxStorage: "100Gi"}, sts, zap.S())
+	require.NoError(t, err)
+
+	assert.Empty(t, expansions)
+	assert.Equal(t, "12Gi", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+}
+
+func TestAutoscaleStorage_Disabled(t *testing.T) {
+	c := newAutoscaleTestClient(t, "12Gi")
+	sts := autoscaleTestStatefulSet("10Gi")
+
+	expansions, err := AutoscaleStorage(context.Background(), c, fakeVolumeStatsReader{}, nil, sts, zap.S())
+	require.NoError(t, err)
+
+	assert.Empty(t, expansions)
+	assert.Equal(t, "10Gi", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+}
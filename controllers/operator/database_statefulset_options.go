@@ -3,6 +3,7 @@ package operator
 import (
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
@@ -96,6 +97,13 @@ func WithVaultConfig(config vault.VaultConfiguration) func(options *construct.Da
 	}
 }
 
+// WithAWSSecretsConfig sets the AWS Secrets Manager configuration the secrets are mounted into the pods with.
+func WithAWSSecretsConfig(config awssecrets.Configuration) func(options *construct.DatabaseStatefulSetOptions) {
+	return func(options *construct.DatabaseStatefulSetOptions) {
+		options.AWSSecretsConfig = config
+	}
+}
+
 func WithAdditionalMongodConfig(additionalMongodConfig *mdbv1.AdditionalMongodConfig) func(options *construct.DatabaseStatefulSetOptions) {
 	return func(options *construct.DatabaseStatefulSetOptions) {
 		options.AdditionalMongodConfig = additionalMongodConfig
@@ -0,0 +1,163 @@
+package operator
+
+import (
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/maputil"
+)
+
+const (
+	// maintenanceWindowMinRetrySeconds and maintenanceWindowMaxRetrySeconds bound the requeue period of a resource
+	// waiting for its maintenance window.
+	maintenanceWindowMinRetrySeconds = 10
+	maintenanceWindowMaxRetrySeconds = 3600
+)
+
+// maintenanceWindowGuard keeps track of the changes that were deferred during a single reconciliation because they
+// require a restart of the processes and the maintenance window of the resource is closed.
+// Non-disruptive changes are not affected and are applied immediately.
+type maintenanceWindowGuard struct {
+	window *mdbv1.MaintenanceWindow
+	now    time.Time
+	// deferredProcesses contains the names of the processes which have changes waiting for the maintenance window
+	deferredProcesses []string
+}
+
+func newMaintenanceWindowGuard(window *mdbv1.MaintenanceWindow, now time.Time) *maintenanceWindowGuard {
+	return &maintenanceWindowGuard{window: window, now: now}
+}
+
+// isOpen returns true if disruptive changes can be applied right now.
+func (g *maintenanceWindowGuard) isOpen() bool {
+	return g == nil || g.window.IsOpen(g.now)
+}
+
+// wrap returns the deployment modification function that applies 'changeDeploymentFunc' and, if the maintenance
+// window is closed, reverts the changes to the processes that would restart them. The result is meant to be passed
+// to om.Connection.ReadUpdateDeployment.
+func (g *maintenanceWindowGuard) wrap(changeDeploymentFunc func(om.Deployment) error, log *zap.SugaredLogger) func(om.Deployment) error {
+	return func(d om.Deployment) error {
+		if g.isOpen() {
+			return changeDeploymentFunc(d)
+		}
+
+		original, err := util.MapDeepCopy(d)
+		if err != nil {
+			return err
+		}
+		if err := changeDeploymentFunc(d); err != nil {
+			return err
+		}
+
+		reverted, err := d.RevertDisruptiveProcessChanges(original)
+		if err != nil {
+			return err
+		}
+		for _, name := range reverted {
+			g.deferChange(name)
+		}
+		if len(reverted) > 0 {
+			log.Infof("Maintenance window is closed, deferring changes requiring a restart of processes %v", reverted)
+		}
+		return nil
+	}
+}
+
+// revertAutomationConfigChanges is the equivalent of wrap for the automation configs published by the Operator
+// directly (AppDB). If the maintenance window is closed, the version, feature compatibility version and mongod
+// arguments of the processes existing in 'current' are restored in 'desired'.
+func (g *maintenanceWindowGuard) revertAutomationConfigChanges(current automationconfig.AutomationConfig, desired *automationconfig.AutomationConfig, log *zap.SugaredLogger) error {
+	if g.isOpen() {
+		return nil
+	}
+
+	currentProcesses := map[string]automationconfig.Process{}
+	for _, p := range current.Processes {
+		currentProcesses[p.Name] = p
+	}
+
+	var reverted []string
+	for i := range desired.Processes {
+		desiredProcess := &desired.Processes[i]
+		currentProcess, ok := currentProcesses[desiredProcess.Name]
+		if !ok {
+			continue
+		}
+
+		// the args read from the Secret and the ones built by the Operator differ in types (e.g. float64 vs int)
+		currentArgs, err := maputil.StructToMap(currentProcess.Args26)
+		if err != nil {
+			return err
+		}
+		desiredArgs, err := maputil.StructToMap(desiredProcess.Args26)
+		if err != nil {
+			return err
+		}
+
+		if currentProcess.Version == desiredProcess.Version && currentProcess.FeatureCompatibilityVersion == desiredProcess.FeatureCompatibilityVersion &&
+			equality.Semantic.DeepEqual(currentArgs, desiredArgs) {
+			continue
+		}
+
+		desiredProcess.Version = currentProcess.Version
+		desiredProcess.FeatureCompatibilityVersion = currentProcess.FeatureCompatibilityVersion
+		desiredProcess.Args26 = currentProcess.Args26
+		reverted = append(reverted, desiredProcess.Name)
+		g.deferChange(desiredProcess.Name)
+	}
+
+	if len(reverted) > 0 {
+		log.Infof("Maintenance window is closed, deferring changes requiring a restart of processes %v", reverted)
+	}
+	return nil
+}
+
+// mongodbImageVersion returns the MongoDB version of the image of the database Pods. Changing the image of the static
+// architecture restarts the Pods, so while the maintenance window is closed the image keeps the last achieved version,
+// as the version of the processes in the automation config does.
+func mongodbImageVersion(window *mdbv1.MaintenanceWindow, version, lastAchievedVersion string) string {
+	if lastAchievedVersion == "" || window.IsOpen(time.Now()) {
+		return version
+	}
+	return lastAchievedVersion
+}
+
+// deferChange records that a change to the process was held back until the window opens.
+func (g *maintenanceWindowGuard) deferChange(processName string) {
+	if !slices.Contains(g.deferredProcesses, processName) {
+		g.deferredProcesses = append(g.deferredProcesses, processName)
+	}
+}
+
+func (g *maintenanceWindowGuard) hasDeferredChanges() bool {
+	return g != nil && len(g.deferredProcesses) > 0
+}
+
+// status returns the Pending status if any changes have been deferred, so that the resource is reconciled again
+// once the maintenance window opens. Returns OK otherwise.
+func (g *maintenanceWindowGuard) status() workflow.Status {
+	if !g.hasDeferredChanges() {
+		return workflow.OK()
+	}
+
+	nextOpening := g.window.NextOpening(g.now)
+	retryInSeconds := int(nextOpening.Sub(g.now).Seconds())
+	if retryInSeconds < maintenanceWindowMinRetrySeconds {
+		retryInSeconds = maintenanceWindowMinRetrySeconds
+	}
+	if retryInSeconds > maintenanceWindowMaxRetrySeconds {
+		retryInSeconds = maintenanceWindowMaxRetrySeconds
+	}
+
+	return workflow.Pending("Waiting for maintenance window (opens at %s) to apply changes requiring a restart of processes %v",
+		nextOpening.Format(time.RFC3339), g.deferredProcesses).WithRetry(retryInSeconds)
+}
@@ -0,0 +1,167 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/images"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
+)
+
+// closedMaintenanceWindow returns a window which opens in three days.
+func closedMaintenanceWindow(now time.Time) *mdbv1.MaintenanceWindow {
+	return &mdbv1.MaintenanceWindow{DayOfWeek: now.UTC().AddDate(0, 0, 3).Weekday().String(), StartHour: 0, DurationHours: 1}
+}
+
+// openMaintenanceWindow returns a window which is open for the next hour at least.
+func openMaintenanceWindow(now time.Time) *mdbv1.MaintenanceWindow {
+	return &mdbv1.MaintenanceWindow{DayOfWeek: now.UTC().Weekday().String(), StartHour: now.UTC().Hour(), DurationHours: 2}
+}
+
+func TestReplicaSetVersionChangeIsDeferredUntilMaintenanceWindow(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetVersion("4.0.0").Build()
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	rs.Spec.Version = "4.2.0"
+	rs.Spec.MaintenanceWindow = closedMaintenanceWindow(time.Now())
+	require.NoError(t, client.Update(ctx, rs))
+
+	checkReconcilePending(ctx, t, reconciler, rs, "Waiting for maintenance window", client, maintenanceWindowMaxRetrySeconds)
+
+	processes := omConnectionFactory.GetConnection().(*om.MockedOmConnection).GetDeployment().ProcessesCopy()
+	require.Len(t, processes, 3)
+	for _, p := range processes {
+		assert.Equal(t, "4.0.0", p.Version())
+	}
+
+	rs.Spec.MaintenanceWindow = openMaintenanceWindow(time.Now())
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	processes = omConnectionFactory.GetConnection().(*om.MockedOmConnection).GetDeployment().ProcessesCopy()
+	for _, p := range processes {
+		assert.Equal(t, "4.2.0", p.Version())
+	}
+}
+
+func TestReplicaSetStaticImageIsKeptUntilMaintenanceWindow(t *testing.T) {
+	ctx := context.Background()
+	imageUrlsMock := images.ImageUrls{
+		util.AgentImageUrlEnv: "quay.io/mongodb/mongodb-agent",
+		util.MongodbImageEnv:  "quay.io/mongodb/mongodb-enterprise-server",
+	}
+	rs := DefaultReplicaSetBuilder().SetVersion("8.0.0").Build()
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, imageUrlsMock, "", "", rs, architectures.Static)
+	omConnectionFactory.SetPostCreateHook(func(connection om.Connection) {
+		connection.(*om.MockedOmConnection).SetAgentVersion("12.0.30.7791-1", "")
+	})
+
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	mongodbImage := func() string {
+		sts, err := client.GetStatefulSet(ctx, kube.ObjectKey(rs.Namespace, rs.Name))
+		require.NoError(t, err)
+		return findContainerImage(sts.Spec.Template.Spec.Containers, util.DatabaseContainerName)
+	}
+	assert.Equal(t, "quay.io/mongodb/mongodb-enterprise-server:8.0.0-ubi9", mongodbImage())
+
+	rs.Spec.Version = "8.0.4"
+	rs.Spec.MaintenanceWindow = closedMaintenanceWindow(time.Now())
+	require.NoError(t, client.Update(ctx, rs))
+
+	// changing the image would restart the Pods
+	checkReconcilePending(ctx, t, reconciler, rs, "Waiting for maintenance window", client, maintenanceWindowMaxRetrySeconds)
+	assert.Equal(t, "quay.io/mongodb/mongodb-enterprise-server:8.0.0-ubi9", mongodbImage())
+
+	rs.Spec.MaintenanceWindow = openMaintenanceWindow(time.Now())
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+	assert.Equal(t, "quay.io/mongodb/mongodb-enterprise-server:8.0.4-ubi9", mongodbImage())
+}
+
+func TestReplicaSetNonDisruptiveChangesAreAppliedOutsideMaintenanceWindow(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetVersion("4.0.0").Build()
+	rs.Spec.MaintenanceWindow = closedMaintenanceWindow(time.Now())
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+
+	// the initial deployment doesn't restart anything, so it isn't deferred
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	rs.Spec.MemberConfig = []automationconfig.MemberOptions{{Votes: ptr.To(0), Priority: ptr.To("0")}, {}, {}}
+	require.NoError(t, client.Update(ctx, rs))
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	rsInOM := omConnectionFactory.GetConnection().(*om.MockedOmConnection).GetDeployment().GetReplicaSetByName(rs.Name)
+	assert.Equal(t, 0, rsInOM.Members()[0].Votes())
+	assert.Equal(t, status.PhaseRunning, rs.Status.Phase)
+}
+
+func TestReplicaSetScaleDownIsAppliedOutsideMaintenanceWindow(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetVersion("4.0.0").SetMembers(5).Build()
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	// the automation config is published first on a scale down, the deferred version change must not block the
+	// StatefulSet
+	rs.Spec.Members = 4
+	rs.Spec.Version = "4.2.0"
+	rs.Spec.MaintenanceWindow = closedMaintenanceWindow(time.Now())
+	require.NoError(t, client.Update(ctx, rs))
+
+	checkReconcilePending(ctx, t, reconciler, rs, "Waiting for maintenance window", client, maintenanceWindowMaxRetrySeconds)
+
+	sts, err := client.GetStatefulSet(ctx, kube.ObjectKey(rs.Namespace, rs.Name))
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), *sts.Spec.Replicas)
+
+	processes := omConnectionFactory.GetConnection().(*om.MockedOmConnection).GetDeployment().ProcessesCopy()
+	require.Len(t, processes, 4)
+	for _, p := range processes {
+		assert.Equal(t, "4.0.0", p.Version())
+	}
+}
+
+func TestMaintenanceWindowGuardStatus(t *testing.T) {
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+
+	guard := newMaintenanceWindowGuard(&mdbv1.MaintenanceWindow{DayOfWeek: "Wednesday", StartHour: 12, DurationHours: 1}, now)
+	assert.True(t, guard.isOpen())
+	assert.True(t, guard.status().IsOK())
+
+	guard = newMaintenanceWindowGuard(&mdbv1.MaintenanceWindow{DayOfWeek: "Wednesday", StartHour: 13, DurationHours: 1}, now)
+	assert.False(t, guard.isOpen())
+	assert.True(t, guard.status().IsOK(), "nothing was deferred")
+
+	guard.deferChange("my-rs-0")
+	guard.deferChange("my-rs-0")
+	assert.Equal(t, []string{"my-rs-0"}, guard.deferredProcesses)
+
+	result, err := guard.status().ReconcileResult()
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, result.RequeueAfter)
+	assert.Equal(t, status.PhasePending, guard.status().Phase())
+}
+
+func TestMongodbImageVersion(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, "8.0.4", mongodbImageVersion(nil, "8.0.4", "8.0.0"))
+	assert.Equal(t, "8.0.4", mongodbImageVersion(openMaintenanceWindow(now), "8.0.4", "8.0.0"))
+	assert.Equal(t, "8.0.0", mongodbImageVersion(closedMaintenanceWindow(now), "8.0.4", "8.0.0"))
+	// the resource has never been deployed
+	assert.Equal(t, "8.0.4", mongodbImageVersion(closedMaintenanceWindow(now), "8.0.4", ""))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-multierror"
@@ -51,6 +52,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
 	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
 	khandler "github.com/mongodb/mongodb-kubernetes/pkg/handler"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
@@ -59,6 +61,7 @@ import (
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/configmap"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/container"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/poddisruptionbudget"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/service"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
@@ -78,6 +81,7 @@ type ReconcileMongoDbMultiReplicaSet struct {
 	omConnectionFactory           om.ConnectionFactory
 	memberClusterClientsMap       map[string]kubernetesClient.Client // holds the client for each of the memberclusters(where the MongoDB ReplicaSet is deployed)
 	memberClusterSecretClientsMap map[string]secrets.SecretClient
+	memberClusterRegistry         *multicluster.MemberClusterRegistry
 	forceEnterprise               bool
 	enableClusterMongoDBRoles     bool
 
@@ -92,6 +96,28 @@ type ReconcileMongoDbMultiReplicaSet struct {
 
 var _ reconcile.Reconciler = &ReconcileMongoDbMultiReplicaSet{}
 
+// getMemberClusterClientsMap returns the clients of the member clusters currently registered with the operator.
+func (r *ReconcileMongoDbMultiReplicaSet) getMemberClusterClientsMap() map[string]kubernetesClient.Client {
+	if r.memberClusterRegistry != nil {
+		return r.memberClusterRegistry.KubeClients()
+	}
+	return r.memberClusterClientsMap
+}
+
+func (r *ReconcileMongoDbMultiReplicaSet) getMemberClusterSecretClientsMap() map[string]secrets.SecretClient {
+	if r.memberClusterRegistry == nil {
+		return r.memberClusterSecretClientsMap
+	}
+	secretClientsMap := make(map[string]secrets.SecretClient)
+	for k, v := range r.memberClusterRegistry.KubeClients() {
+		secretClientsMap[k] = secrets.SecretClient{
+			VaultClient: nil, // Vault is not supported yet on multicluster
+			KubeClient:  v,
+		}
+	}
+	return secretClientsMap
+}
+
 func newMultiClusterReplicaSetReconciler(ctx context.Context, kubeClient client.Client, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, omFunc om.ConnectionFactory, memberClustersMap map[string]client.Client) *ReconcileMongoDbMultiReplicaSet {
 	clientsMap := make(map[string]kubernetesClient.Client)
 	secretClientsMap := make(map[string]secrets.SecretClient)
@@ -148,6 +174,10 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 		return r.updateStatus(ctx, &mrs, workflow.Invalid("%s", err.Error()), log)
 	}
 
+	if awssecrets.IsSecretsManagerBackend() {
+		return r.updateStatus(ctx, &mrs, workflow.Unsupported("multi-cluster resources are not supported with the AWS Secrets Manager secret backend"), log)
+	}
+
 	projectConfig, credsConfig, err := project.ReadConfigAndCredentials(ctx, r.client, r.SecretClient, &mrs, log)
 	if err != nil {
 		return r.updateStatus(ctx, &mrs, workflow.Failed(xerrors.Errorf("Error reading project config and credentials: %w", err)), log)
@@ -170,6 +200,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 	}
 
 	r.SetupCommonWatchers(&mrs, nil, nil, mrs.Name)
+	r.checkCertificatesExpiry(ctx, &mrs, nil, nil, mrs.Name, log)
 
 	// If tls is enabled we need to configure the "processes" array in opsManager/Cloud Manager with the
 	// correct tlsCertPath, with the new tls design, this path has the certHash in it(so that cert can be rotated
@@ -200,7 +231,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 	// See CLOUDP-189433 and CLOUDP-229222 for more details.
 	if recovery.ShouldTriggerRecovery(mrs.Status.Phase != mdbstatus.PhaseRunning, mrs.Status.LastTransition) {
 		log.Warnf("Triggering Automatic Recovery. The MongoDB resource %s/%s is in %s state since %s", mrs.Namespace, mrs.Name, mrs.Status.Phase, mrs.Status.LastTransition)
-		automationConfigError := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, true, log)
+		automationConfigError := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, newMaintenanceWindowGuard(mrs.Spec.MaintenanceWindow, time.Now()), true, log)
 		reconcileStatus := r.reconcileMemberResources(ctx, &mrs, log, conn, projectConfig, agentCertHash)
 		if !reconcileStatus.IsOK() {
 			log.Errorf("Recovery failed because of reconcile errors, %v", reconcileStatus)
@@ -215,9 +246,10 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 		return r.updateStatus(ctx, &mrs, workflow.Failed(err), log)
 	}
 
+	maintenanceWindow := newMaintenanceWindowGuard(mrs.Spec.MaintenanceWindow, time.Now())
 	status := workflow.RunInGivenOrder(publishAutomationConfigFirst,
 		func() workflow.Status {
-			if err := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, false, log); err != nil {
+			if err := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, maintenanceWindow, false, log); err != nil {
 				return workflow.Failed(err)
 			}
 			return workflow.OK()
@@ -225,6 +257,8 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 		func() workflow.Status {
 			return r.reconcileMemberResources(ctx, &mrs, log, conn, projectConfig, agentCertHash)
 		})
+	// the deferred changes don't stop the member resources from being reconciled, they only keep the resource pending
+	status = status.Merge(maintenanceWindow.status())
 
 	if !status.IsOK() {
 		return r.updateStatus(ctx, &mrs, status, log)
@@ -252,7 +286,8 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 	}
 
 	log.Infow("Finished reconciliation for MultiReplicaSet", "Spec", mrs.Spec, "Status", mrs.Status)
-	return r.updateStatus(ctx, &mrs, workflow.OK(), log, mdbstatus.NewPVCsStatusOptionEmptyStatus())
+	result, err := r.updateStatus(ctx, &mrs, workflow.OK(), log, mdbstatus.NewPVCsStatusOptionEmptyStatus())
+	return requeueForStorageAutoscale(mrs.Spec.HasStorageAutoscale(), result, err)
 }
 
 // publishAutomationConfigFirstMultiCluster returns a boolean indicating whether Ops Manager
@@ -340,8 +375,9 @@ func (r *ReconcileMongoDbMultiReplicaSet) firstStatefulSet(ctx context.Context,
 	var firstMemberClient kubernetesClient.Client
 	var firstMemberIdx int
 	foundOne := false
+	memberClusterClientsMap := r.getMemberClusterClientsMap()
 	for idx, item := range items {
-		client, ok := r.memberClusterClientsMap[item.ClusterName]
+		client, ok := memberClusterClientsMap[item.ClusterName]
 		if ok {
 			firstMemberClient = client
 			firstMemberIdx = idx
@@ -400,6 +436,29 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileMemberResources(ctx context.C
 	return r.reconcileStatefulSets(ctx, mrs, log, conn, projectConfig, agentCertHash)
 }
 
+// ensureIssuedCertificate requests the member certificate from cert-manager or issues it from Vault, if enabled. The
+// certificate is shared by the member clusters, including the failed ones, and has to be valid for the members of
+// all of them.
+func (r *ReconcileMongoDbMultiReplicaSet) ensureIssuedCertificate(ctx context.Context, mrs *mdbmultiv1.MongoDBMultiCluster, clusterSpecList mdb.ClusterSpecList, log *zap.SugaredLogger) workflow.Status {
+	security := mrs.Spec.GetSecurity()
+	if security.TLSConfig == nil || (security.TLSConfig.CertManager == nil && security.TLSConfig.VaultPKI == nil) {
+		return workflow.OK()
+	}
+
+	var opts []certs.Options
+	for _, item := range clusterSpecList {
+		replicasThisReconciliation, err := getMembersForClusterSpecItemThisReconciliation(mrs, item)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		opts = append(opts, certs.MultiReplicaSetConfig(*mrs, mrs.ClusterNum(item.ClusterName), item.ClusterName, replicasThisReconciliation))
+	}
+	if status := certs.EnsureCertManagerCertificateForStatefulSets(ctx, r.client, *security, kube.BaseOwnerReference(mrs), opts...); !status.IsOK() {
+		return status
+	}
+	return certs.EnsureVaultPKICertificateForStatefulSets(ctx, r.SecretClient, *security, certs.Database, log, opts...)
+}
+
 func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Context, mrs *mdbmultiv1.MongoDBMultiCluster, log *zap.SugaredLogger, conn om.Connection, projectConfig mdb.ProjectConfig, agentCertHash string) workflow.Status {
 	clusterSpecList, err := mrs.GetClusterSpecItems()
 	if err != nil {
@@ -420,19 +479,30 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 	// stateful-sets in parallel.
 	scalingFirstTime := len(processes) == 0
 
+	if status := r.ensureIssuedCertificate(ctx, mrs, clusterSpecList, log); !status.IsOK() {
+		return status
+	}
+
+	lastAchievedVersion := ""
+	if lastSpec, err := mrs.ReadLastAchievedSpec(); err == nil && lastSpec != nil {
+		lastAchievedVersion = lastSpec.Version
+	}
+
 	var workflowStatus workflow.Status = workflow.OK()
+	memberClusterClientsMap := r.getMemberClusterClientsMap()
+	memberClusterSecretClientsMap := r.getMemberClusterSecretClientsMap()
 	for _, item := range clusterSpecList {
 		if stringutil.Contains(failedClusterNames, item.ClusterName) {
 			log.Warnf(fmt.Sprintf("failed to reconcile statefulset: cluster %s is marked as failed", item.ClusterName))
 			continue
 		}
 
-		memberClient, ok := r.memberClusterClientsMap[item.ClusterName]
+		memberClient, ok := memberClusterClientsMap[item.ClusterName]
 		if !ok {
 			log.Warnf(fmt.Sprintf("failed to reconcile statefulset: cluster %s missing from client map", item.ClusterName))
 			continue
 		}
-		secretMemberClient := r.memberClusterSecretClientsMap[item.ClusterName]
+		secretMemberClient := memberClusterSecretClientsMap[item.ClusterName]
 		replicasThisReconciliation, err := getMembersForClusterSpecItemThisReconciliation(mrs, item)
 		clusterNum := mrs.ClusterNum(item.ClusterName)
 		if err != nil {
@@ -515,6 +585,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 
 		opts := mconstruct.MultiClusterReplicaSetOptions(
 			mconstruct.WithClusterNum(clusterNum),
+			mconstruct.WithMemberClusterPodDisruptionBudget(*mrs, item.ClusterName),
 			Replicas(replicasThisReconciliation),
 			mconstruct.WithStsOverride(&stsOverride),
 			mconstruct.WithServiceName(mrs.MultiHeadlessServiceName(clusterNum)),
@@ -530,7 +601,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 			WithAgentImage(images.ContainerImage(r.imageUrls, util.AgentImageUrlEnv, automationAgentVersion)),
 			WithCustomAgentURL(r.customAgentURL),
 
-			WithMongodbImage(images.GetOfficialImage(r.imageUrls, mrs.Spec.Version, mrs.GetAnnotations(), r.defaultArchitecture)),
+			WithMongodbImage(images.GetOfficialImage(r.imageUrls, mongodbImageVersion(mrs.Spec.MaintenanceWindow, mrs.Spec.Version, lastAchievedVersion), mrs.GetAnnotations(), r.defaultArchitecture)),
 			WithAgentDebug(r.agentDebug),
 			WithAgentDebugImage(r.agentDebugImage),
 			WithDefaultArchitecture(r.defaultArchitecture),
@@ -546,9 +617,22 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 			if err := memberClient.Delete(ctx, &sts); err != nil && !apiErrors.IsNotFound(err) {
 				return workflow.Failed(xerrors.Errorf("failed to delete StatefulSet in cluster: %s, err: %w", item.ClusterName, err))
 			}
+			if err := poddisruptionbudget.DeleteIfExists(ctx, memberClient, kube.ObjectKey(sts.Namespace, sts.Name)); err != nil {
+				return workflow.Failed(xerrors.Errorf("failed to delete PodDisruptionBudget in cluster: %s, err: %w", item.ClusterName, err))
+			}
 			continue
 		}
 
+		expansionOptions, err := r.autoscaleStorage(ctx, mrs, item.ClusterName, memberClient, item.PodSpec.GetPersistence(), &sts, log)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		if len(expansionOptions) > 0 {
+			if err := r.updateStatusFromInnerMethod(ctx, mrs, log, workflow.Pending("").WithAdditionalOptions(expansionOptions...)); err != nil {
+				return workflow.Failed(xerrors.Errorf("error updating status: %w", err))
+			}
+		}
+
 		pvcResizeStatus := create.HandlePVCResize(ctx, memberClient, &sts, log)
 		if !pvcResizeStatus.IsOK() {
 			return pvcResizeStatus
@@ -563,6 +647,10 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 			return workflow.Failed(xerrors.Errorf("failed to create/update StatefulSet in cluster: %s, err: %w", item.ClusterName, err))
 		}
 
+		if err := create.DatabasePodDisruptionBudget(ctx, memberClient, *mutatedSts, opts(*mrs), mrs.GetOwnerLabels(), nil); err != nil {
+			return workflow.Failed(xerrors.Errorf("failed to create/update PodDisruptionBudget in cluster: %s, err: %w", item.ClusterName, err))
+		}
+
 		expectedGeneration := mutatedSts.GetGeneration()
 		statefulsetStatus := statefulset.GetStatefulSetStatus(ctx, sts.Namespace, sts.Name, expectedGeneration, memberClient)
 
@@ -588,8 +676,9 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 // updateStatusFromInnerMethod ensures to only update the status if it has been updated.
 // Since spec.Mapping is just a cache, it would be replaced; therefore, we need to cache it
 func (r *ReconcileMongoDbMultiReplicaSet) updateStatusFromInnerMethod(ctx context.Context, mrs *mdbmultiv1.MongoDBMultiCluster, log *zap.SugaredLogger, workflowStatus workflow.Status) error {
-	// if there are no pvc changes, then we don't need to update the status
-	if !workflow.ContainsPVCOption(workflowStatus.StatusOptions()) {
+	// if there are no pvc changes or volume expansions, then we don't need to update the status
+	_, expanded := mdbstatus.GetOption(workflowStatus.StatusOptions(), mdbstatus.StorageExpansionsOption{})
+	if !workflow.ContainsPVCOption(workflowStatus.StatusOptions()) && !expanded {
 		return nil
 	}
 	tmpMapping := mrs.Spec.Mapping
@@ -698,7 +787,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) saveLastAchievedSpec(ctx context.Conte
 
 // updateOmDeploymentRs performs OM registration operation for the replicaset. So the changes will be finally propagated
 // to automation agents in containers
-func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Context, conn om.Connection, mrs mdbmultiv1.MongoDBMultiCluster, agentCertPath, tlsCertPath, internalClusterCertPath string, isRecovering bool, log *zap.SugaredLogger) error {
+func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Context, conn om.Connection, mrs mdbmultiv1.MongoDBMultiCluster, agentCertPath, tlsCertPath, internalClusterCertPath string, maintenanceWindow *maintenanceWindowGuard, isRecovering bool, log *zap.SugaredLogger) error {
 	reachableHostnames := make([]string, 0)
 
 	clusterSpecList, err := mrs.GetClusterSpecItems()
@@ -751,10 +840,19 @@ func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Conte
 		return err
 	}
 
-	if len(processes) != len(mrs.Spec.GetMemberOptions()) {
-		log.Warnf("the number of member options is different than the number of mongod processes to be created: %d processes - %d replica set member options", len(processes), len(mrs.Spec.GetMemberOptions()))
+	memberOptions := mrs.Spec.GetMemberOptions()
+	if mrs.Spec.ElectionPolicy != nil {
+		// the votes computed by the election policy depend on the members of this reconciliation, which are scaled
+		// one at a time
+		memberOptions = nil
+		for _, item := range mrs.Spec.ElectionPolicy.Apply(clusterSpecList) {
+			memberOptions = append(memberOptions, item.MemberConfig...)
+		}
+	}
+	if len(processes) != len(memberOptions) {
+		log.Warnf("the number of member options is different than the number of mongod processes to be created: %d processes - %d replica set member options", len(processes), len(memberOptions))
 	}
-	rs := om.NewMultiClusterReplicaSetWithProcesses(om.NewReplicaSet(mrs.Name, mrs.Spec.Version), processes, mrs.Spec.GetMemberOptions(), processIds, mrs.Spec.Connectivity)
+	rs := om.NewMultiClusterReplicaSetWithProcesses(om.NewReplicaSet(mrs.Name, mrs.Spec.Version), processes, memberOptions, processIds, mrs.Spec.Connectivity)
 
 	caFilePath := fmt.Sprintf("%s/ca-pem", util.TLSCaMountPath)
 
@@ -766,9 +864,9 @@ func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Conte
 	lastMongodbConfig := mrs.GetLastAdditionalMongodConfig()
 
 	err = conn.ReadUpdateDeployment(
-		func(d om.Deployment) error {
+		maintenanceWindow.wrap(func(d om.Deployment) error {
 			return ReconcileReplicaSetAC(ctx, d, mrs.Spec.DbCommonSpec, lastMongodbConfig, mrs.Name, rs, caFilePath, internalClusterCertPath, nil, log)
-		},
+		}, log),
 		log,
 	)
 	if err != nil && !isRecovering {
@@ -923,13 +1021,14 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileServices(ctx context.Context,
 		log.Errorf("failed retrieving list of failed clusters: %s", err.Error())
 	}
 
+	memberClusterClientsMap := r.getMemberClusterClientsMap()
 	for _, e := range clusterSpecList {
 		if stringutil.Contains(failedClusterNames, e.ClusterName) {
 			log.Warnf(fmt.Sprintf("cluster %s is marked as failed", e.ClusterName))
 			continue
 		}
 
-		client, ok := r.memberClusterClientsMap[e.ClusterName]
+		client, ok := memberClusterClientsMap[e.ClusterName]
 		if !ok {
 			log.Warnf(fmt.Sprintf("cluster %s missing from client map", e.ClusterName))
 			continue
@@ -959,7 +1058,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileServices(ctx context.Context,
 
 	// by default, we would create the duplicate services
 	shouldCreateDuplicates := mrs.Spec.DuplicateServiceObjects == nil || *mrs.Spec.DuplicateServiceObjects
-	for memberClusterName, memberClusterClient := range r.memberClusterClientsMap {
+	for memberClusterName, memberClusterClient := range r.getMemberClusterClientsMap() {
 		if stringutil.Contains(failedClusterNames, memberClusterName) {
 			log.Warnf(fmt.Sprintf("cluster %s is marked as failed, skipping creation of services", memberClusterName))
 			continue
@@ -1065,13 +1164,14 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileHostnameOverrideConfigMap(ctx
 		log.Warnf("failed retrieving list of failed clusters: %s", err.Error())
 	}
 
+	memberClusterClientsMap := r.getMemberClusterClientsMap()
 	for i, e := range clusterSpecList {
 		if stringutil.Contains(failedClusterNames, e.ClusterName) {
 			log.Warnf(fmt.Sprintf("failed to create configmap: cluster %s is marked as failed", e.ClusterName))
 			continue
 		}
 
-		client, ok := r.memberClusterClientsMap[e.ClusterName]
+		client, ok := memberClusterClientsMap[e.ClusterName]
 		if !ok {
 			log.Warnf(fmt.Sprintf("failed to create configmap: cluster %s is missing from client map", e.ClusterName))
 			continue
@@ -1102,12 +1202,13 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileOMCAConfigMap(ctx context.Con
 	if err != nil {
 		return err
 	}
+	memberClusterClientsMap := r.getMemberClusterClientsMap()
 	for _, clusterSpecItem := range clusterSpecList {
 		if stringutil.Contains(failedClusterNames, clusterSpecItem.ClusterName) {
 			log.Warnf("failed to create configmap %s: cluster %s is marked as failed", configMapName, clusterSpecItem.ClusterName)
 			continue
 		}
-		client := r.memberClusterClientsMap[clusterSpecItem.ClusterName]
+		client := memberClusterClientsMap[clusterSpecItem.ClusterName]
 		memberCm := configmap.Builder().SetName(configMapName).SetNamespace(mrs.Namespace).SetData(cm.Data).Build()
 		err := configmap.CreateOrUpdate(ctx, client, memberCm)
 		if err != nil && !apiErrors.IsAlreadyExists(err) {
@@ -1120,9 +1221,16 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileOMCAConfigMap(ctx context.Con
 
 // AddMultiReplicaSetController creates a new MongoDbMultiReplicaset Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func AddMultiReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, requiredHealthyStreak int, memberClustersMap map[string]cluster.Cluster) error {
+func AddMultiReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker, memberClusterRegistry *multicluster.MemberClusterRegistry) error {
 	// Create a new controller
-	reconciler := newMultiClusterReplicaSetReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, om.NewOpsManagerConnection, multicluster.ClustersMapToClientMap(memberClustersMap))
+	reconciler := newMultiClusterReplicaSetReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, om.NewOpsManagerConnection, memberClusterRegistry.Clients())
+	reconciler.memberClusterRegistry = memberClusterRegistry
+	reconciler.recorder = mgr.GetEventRecorderFor(util.OperatorName)
+	volumeStatsReaders, err := newVolumeStatsReaders(mgr.GetConfig(), memberClusterRegistry)
+	if err != nil {
+		return err
+	}
+	reconciler.volumeStatsReaders = volumeStatsReaders
 	c, err := controller.New(util.MongoDbMultiClusterController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
@@ -1169,38 +1277,30 @@ func AddMultiReplicaSetController(ctx context.Context, mgr manager.Manager, imag
 		}
 	}
 
-	for clusterName, memberCluster := range memberClustersMap {
-		err = c.Watch(source.Kind[client.Object](memberCluster.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet()))
+	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &rolev1.MongoDBRole{},
+		&watch.ResourcesHandler{ResourceType: watch.MongoDBRole, ResourceWatcher: reconciler.resourceWatcher}))
+	if err != nil {
+		return err
+	}
+
+	// the member clusters added to the member list later are watched as soon as the operator connects to them
+	err = memberClusterRegistry.OnClusterAdded(func(clusterName string, memberCluster cluster.Cluster) error {
+		err := c.Watch(source.Kind[client.Object](memberCluster.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet()))
 		if err != nil {
 			return xerrors.Errorf("failed to set StatefulSet watch on member cluster %s: %w", clusterName, err)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// the operator watches the member clusters' API servers to determine whether the clusters are healthy or not
-	eventChannel := make(chan event.GenericEvent)
-	memberClusterHealthChecker := memberwatch.MemberClusterHealthChecker{
-		Cache:                 make(map[string]memberwatch.ClusterHealthChecker),
-		HealthyStreak:         make(map[string]int),
-		RequiredHealthyStreak: requiredHealthyStreak,
-	}
-	go memberClusterHealthChecker.WatchMemberClusterHealth(ctx, zap.S(), eventChannel, reconciler.client, memberClustersMap)
-
-	err = c.Watch(source.Channel[client.Object](eventChannel, &handler.EnqueueRequestForObject{}))
+	err = memberClusterHealthChecker.WatchResources(c, memberwatch.MongoDBMultiClusterResources)
 	if err != nil {
 		zap.S().Errorf("failed to watch for member cluster healthcheck: %s", err)
 	}
 
-	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &corev1.ConfigMap{},
-		watch.ConfigMapEventHandler{
-			ConfigMapName:      util.MemberListConfigMapName,
-			ConfigMapNamespace: env.ReadOrPanic(util.CurrentNamespace), // nolint:forbidigo
-		},
-		predicate.ResourceVersionChangedPredicate{},
-	))
-	if err != nil {
-		return err
-	}
-
 	zap.S().Infof("Registered controller %s", util.MongoDbMultiReplicaSetController)
 	return err
 }
@@ -1288,9 +1388,10 @@ func (r *ReconcileMongoDbMultiReplicaSet) deleteManagedResources(ctx context.Con
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	} else {
+		memberClusterClientsMap := r.getMemberClusterClientsMap()
 		for _, item := range clusterSpecList {
 			clusterName := item.ClusterName
-			clusterClient := r.memberClusterClientsMap[clusterName]
+			clusterClient := memberClusterClientsMap[clusterName]
 			if err := r.deleteClusterResources(ctx, clusterClient, clusterName, &mrs, log); err != nil {
 				errs = multierror.Append(errs, xerrors.Errorf("failed deleting dependant resources in cluster %s: %w", clusterName, err))
 			}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 	"testing/synctest"
 
@@ -38,6 +39,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumestats"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/memberwatch"
@@ -296,6 +298,63 @@ func TestReconcilePVCResizeMultiCluster(t *testing.T) {
 	})
 }
 
+func TestMultiReplicaSetReconciler_StorageAutoscale(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := context.Background()
+		mrs := mdbmulti.DefaultMultiReplicaSetBuilder().SetClusterSpecList(clusters).Build()
+		mrs.Spec.Persistent = ptr.To(true)
+		mrs.Spec.StatefulSetConfiguration = &v1.StatefulSetConfiguration{SpecWrapper: v1.StatefulSetSpecWrapper{Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}},
+				},
+			}},
+		}}}
+		// only the volumes of the first member cluster are autoscaled
+		mrs.Spec.ClusterSpecList[0].PodSpec = &mdb.MongoDbPodSpec{Persistence: &v1.Persistence{
+			Autoscale: &v1.StorageAutoscale{Increase: "1Gi", MaxStorage: "10Gi"},
+		}}
+
+		reconciler, c, clusterMap, _ := defaultMultiReplicaSetReconciler(ctx, nil, "", "", mrs, architectures.NonStatic)
+		checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, c, true)
+
+		usage := fakeVolumeStatsReader{}
+		createdPVCs := getPVCsMulti(t, ctx, mrs, clusterMap)
+		for i, item := range mrs.Spec.ClusterSpecList {
+			memberClient := createdPVCs[i].client
+			for _, p := range createdPVCs[i].persistentVolumeClaims {
+				// the PVCs are bound
+				p.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}
+				require.NoError(t, memberClient.SubResource("status").Update(ctx, &p))
+				podName := strings.TrimPrefix(p.Name, "data-")
+				require.NoError(t, memberClient.Create(ctx, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: mrs.Namespace},
+					Spec:       corev1.PodSpec{NodeName: item.ClusterName + "-node"},
+				}))
+				usage[kube.ObjectKey(mrs.Namespace, p.Name)] = volumestats.Usage{UsedBytes: 90, CapacityBytes: 100}
+			}
+		}
+		reconciler.volumeStatsReaders = func(string) volumestats.Reader { return usage }
+
+		_, err := reconciler.Reconcile(ctx, requestFromObject(mrs))
+		require.NoError(t, err)
+		require.NoError(t, c.Get(ctx, kube.ObjectKeyFromApiObject(mrs), mrs))
+
+		require.Len(t, mrs.Status.StorageExpansions, 1)
+		assert.Equal(t, mrs.MultiStatefulsetName(0), mrs.Status.StorageExpansions[0].StatefulsetName)
+		assert.Equal(t, "2Gi", mrs.Status.StorageExpansions[0].To)
+		testMDBStatusMulti(t, c, ctx, mrs, status.PhasePending, status.PVCS{{Phase: pvc.PhasePVCResize, StatefulsetName: mrs.MultiStatefulsetName(0)}})
+
+		for i, expected := range []string{"2Gi", "1Gi", "1Gi"} {
+			for _, p := range createdPVCs[i].persistentVolumeClaims {
+				require.NoError(t, createdPVCs[i].client.Get(ctx, kube.ObjectKey(p.Namespace, p.Name), &p))
+				assert.Equal(t, expected, p.Spec.Resources.Requests.Storage().String())
+			}
+		}
+	})
+}
+
 type pvcClient struct {
 	persistentVolumeClaims []corev1.PersistentVolumeClaim
 	client                 client.Client
@@ -1620,3 +1679,42 @@ func getFakeMultiClusterMapWithoutInterceptor(clusters []string) map[string]clie
 	}
 	return clientMap
 }
+
+func TestMultiReplicaSetElectionPolicy(t *testing.T) {
+	ctx := context.Background()
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().SetClusterSpecList(clusters).Build()
+	mrs.Spec.ClusterSpecList[0].Members = 4
+	mrs.Spec.ClusterSpecList[1].Members = 4
+	mrs.Spec.ClusterSpecList[2].Members = 1
+	mrs.Spec.ElectionPolicy = &mdb.ElectionPolicy{
+		PreferredClusters:          []string{clusters[1]},
+		MaxVotingMembersPerCluster: ptr.To(3),
+	}
+	reconciler, client, _, omConnectionFactory := defaultMultiReplicaSetReconciler(ctx, nil, "", "", mrs, architectures.NonStatic)
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, false)
+
+	dep, err := omConnectionFactory.GetConnection().ReadDeployment()
+	require.NoError(t, err)
+	replicaSets := dep.GetReplicaSets()
+	require.Len(t, replicaSets, 1)
+
+	votes := map[string]int{}
+	priorities := map[string]float32{}
+	for _, m := range replicaSets[0].Members() {
+		votes[m.Name()] = m.Votes()
+		priorities[m.Name()] = m.Priority()
+	}
+
+	expectedVotes := map[string]int{
+		"temple-0-0": 1, "temple-0-1": 1, "temple-0-2": 1, "temple-0-3": 0,
+		"temple-1-0": 1, "temple-1-1": 1, "temple-1-2": 1, "temple-1-3": 0,
+		"temple-2-0": 1,
+	}
+	expectedPriorities := map[string]float32{
+		"temple-0-0": 1, "temple-0-1": 1, "temple-0-2": 1, "temple-0-3": 0,
+		"temple-1-0": 2, "temple-1-1": 2, "temple-1-2": 2, "temple-1-3": 0,
+		"temple-2-0": 1,
+	}
+	assert.Equal(t, expectedVotes, votes)
+	assert.Equal(t, expectedPriorities, priorities)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"slices"
 	"syscall"
@@ -34,6 +35,7 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdbmulti"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
@@ -41,6 +43,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/agents"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connectionstring"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
@@ -48,6 +51,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
 	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
 	khandler "github.com/mongodb/mongodb-kubernetes/pkg/handler"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
@@ -57,6 +61,8 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/configmap"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/memberwatch"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -74,6 +80,8 @@ var OmUpdateChannel chan event.GenericEvent
 const (
 	oldestSupportedOpsManagerVersion = "5.0.0"
 	programmaticKeyVersion           = "5.0.0"
+	// blobSnapshotStoresVersion is the first Ops Manager version supporting the GCS and Azure Blob snapshot stores
+	blobSnapshotStoresVersion = "8.0.0"
 )
 
 type S3ConfigGetter interface {
@@ -82,6 +90,13 @@ type S3ConfigGetter interface {
 	BuildConnectionString(username, password string, scheme connectionstring.Scheme, connectionParams map[string]string) string
 }
 
+// metadataDatabaseRef is implemented by the snapshot store configs which reference the MongoDB resource (and user)
+// used as the metadata database of the store.
+type metadataDatabaseRef interface {
+	MongodbResourceObjectKey(opsManager *omv1.MongoDBOpsManager) client.ObjectKey
+	MongodbUserObjectKey(defaultNamespace string) client.ObjectKey
+}
+
 // OpsManagerReconciler is a controller implementation.
 // It's Reconciler function is called by Controller Runtime.
 // WARNING: do not put any mutable state into this class. Controller runtime uses and shares a single instance of it.
@@ -93,7 +108,8 @@ type OpsManagerReconciler struct {
 	oldestSupportedVersion semver.Version
 	programmaticKeyVersion semver.Version
 
-	memberClustersMap map[string]client.Client
+	memberClustersMap     map[string]client.Client
+	memberClusterRegistry *multicluster.MemberClusterRegistry
 
 	imageUrls                  images.ImageUrls
 	initDatabaseVersion        string
@@ -119,6 +135,14 @@ func NewOpsManagerReconciler(ctx context.Context, kubeClient client.Client, memb
 	}
 }
 
+// getMemberClustersMap returns the clients of the member clusters currently registered with the operator.
+func (r *OpsManagerReconciler) getMemberClustersMap() map[string]client.Client {
+	if r.memberClusterRegistry != nil {
+		return r.memberClusterRegistry.Clients()
+	}
+	return r.memberClustersMap
+}
+
 type OMDeploymentState struct {
 	CommonDeploymentState `json:",inline"`
 }
@@ -151,6 +175,13 @@ func NewOpsManagerReconcilerHelper(ctx context.Context, opsManagerReconciler *Op
 		return nil, xerrors.Errorf("member clusters have to be initialized for MultiCluster OpsManager topology")
 	}
 
+	failedClusterNames, err := failedcluster.ReadFailedClusterNames(opsManager.Annotations)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read the %s annotation: %w", failedcluster.FailedClusterAnnotation, err)
+	}
+	// the failed member clusters are skipped the same way as the member clusters the operator has no client for
+	globalMemberClustersMap = failedcluster.WithoutFailedClusters(globalMemberClustersMap, failedClusterNames)
+
 	// here we access ClusterSpecList directly, as we have to check what's been defined in yaml
 	if len(opsManager.Spec.ClusterSpecList) == 0 {
 		return nil, xerrors.Errorf("for MongoDBOpsManager.spec.Topology = MultiCluster, clusterSpecList has to be non empty")
@@ -417,10 +448,22 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 		return r.updateStatus(ctx, opsManager, workflow.Invalid("%s", err.Error()), log, mdbstatus.NewOMPartOption(part))
 	}
 
+	if awssecrets.IsSecretsManagerBackend() {
+		return r.updateStatus(ctx, opsManager, workflow.Unsupported("Ops Manager resources are not supported with the AWS Secrets Manager secret backend"), log, opsManagerExtraStatusParams)
+	}
+
 	if err := ensureSharedGlobalResources(ctx, r.client, opsManager); err != nil {
 		return r.updateStatus(ctx, opsManager, workflow.Failed(xerrors.Errorf("Error ensuring shared global resources %w", err)), log, opsManagerExtraStatusParams)
 	}
 
+	// Version upgrades are checked before any of the components is changed. Then Ops Manager, Backup Daemons and AppDB
+	// are upgraded in this order (the AppDB first if spec.upgrade.appDBFirst is set), each of them only after the
+	// previous one has finished.
+	upgrade := newOpsManagerUpgrade(opsManager)
+	if status := r.ensureUpgradePreflightChecks(opsManager, upgrade, log); !status.IsOK() {
+		return r.updateStatus(ctx, opsManager, status, log, opsManagerExtraStatusParams)
+	}
+
 	// 1. Reconcile AppDB
 	emptyResult, _ := workflow.OK().ReconcileResult()
 	retryResult := reconcile.Result{RequeueAfter: time.Second}
@@ -435,6 +478,8 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 	if opsManager.IsTLSEnabled() {
 		r.resourceWatcher.RegisterWatchedTLSResources(opsManager.ObjectKey(), opsManager.Spec.GetOpsManagerCA(), []string{opsManager.TLSCertificateSecretName()})
 	}
+	r.checkOpsManagerCertificatesExpiry(ctx, opsManager, log)
+
 	// register backup
 	r.watchMongoDBResourcesReferencedByBackup(ctx, opsManager, log)
 
@@ -443,17 +488,31 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 		return r.updateStatus(ctx, opsManager, workflow.Failed(xerrors.Errorf("Error initializing AppDB reconciler: %w", err)), log, opsManagerExtraStatusParams)
 	}
 
+	if err := r.startUpgradeStep(ctx, opsManager, upgrade, omv1.UpgradeStepAppDB, log); err != nil {
+		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log, opsManagerExtraStatusParams)
+	}
+
+	// the AppDB keeps its version until its upgrade step can start
+	appDbReconciler.holdBackVersionChange = isUpgradeStepWaiting(upgrade, omv1.UpgradeStepAppDB)
 	result, err := appDbReconciler.ReconcileAppDB(ctx, opsManager)
+	appDBStatus := opsManager.Status.AppDbStatus
+	if appDBStatus.Phase == mdbstatus.PhaseFailed && failUpgradeStepWithMessage(upgrade, omv1.UpgradeStepAppDB, appDBStatus.Message) {
+		return r.updateStatus(ctx, opsManager, workflow.Failed(xerrors.Errorf("Ops Manager upgrade from %s to %s failed to upgrade the Application Database: %s", upgrade.FromVersion, upgrade.ToVersion, appDBStatus.Message)), log, opsManagerExtraStatusParams)
+	}
 	if err != nil || (result != emptyResult && result != retryResult) {
 		return result, err
 	}
+	// the AppDB upgrade is finished only once the AppDB is fully reconciled, including its monitoring
+	if result == emptyResult {
+		completeUpgradeStep(upgrade, omv1.UpgradeStepAppDB, log)
+	}
 
 	appDBConnectionString, err := appDbReconciler.BuildAppDBConnectionURL(ctx, opsManager, log)
 	if err != nil {
 		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log, opsManagerExtraStatusParams)
 	}
 
-	opsManagerReconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.memberClustersMap, log)
+	opsManagerReconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.getMemberClustersMap(), log)
 	if err != nil {
 		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log, opsManagerExtraStatusParams)
 	}
@@ -468,8 +527,13 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 	opsManagerImage := images.ContainerImage(r.imageUrls, util.OpsManagerImageUrl, opsManager.Spec.Version)
 
 	// 2. Reconcile Ops Manager
-	status, omAdmin := r.reconcileOpsManager(ctx, opsManagerReconcilerHelper, opsManager, appDBConnectionString, initOpsManagerImage, opsManagerImage, log)
+	if err := r.startUpgradeStep(ctx, opsManager, upgrade, omv1.UpgradeStepOpsManager, log); err != nil {
+		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log, opsManagerExtraStatusParams)
+	}
+
+	status, omAdmin := r.reconcileOpsManager(ctx, opsManagerReconcilerHelper, opsManager, upgrade, appDBConnectionString, initOpsManagerImage, opsManagerImage, log)
 	if !status.IsOK() {
+		failUpgradeStep(upgrade, omv1.UpgradeStepOpsManager, status)
 		return r.updateStatus(ctx, opsManager, status, log, opsManagerExtraStatusParams, mdbstatus.NewBaseUrlOption(opsManager.CentralURL()))
 	}
 
@@ -481,10 +545,32 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	// 3. Reconcile Backup Daemon
+	if err := r.startUpgradeStep(ctx, opsManager, upgrade, omv1.UpgradeStepBackupDaemon, log); err != nil {
+		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log, mdbstatus.NewOMPartOption(mdbstatus.Backup))
+	}
+
 	if status := r.reconcileBackupDaemon(ctx, opsManagerReconcilerHelper, opsManager, omAdmin, appDBConnectionString, initOpsManagerImage, opsManagerImage, log); !status.IsOK() {
+		if failUpgradeStep(upgrade, omv1.UpgradeStepBackupDaemon, status) {
+			upgradeStatus := workflow.Failed(xerrors.Errorf("Ops Manager upgrade from %s to %s failed to upgrade the Backup Daemons: %s", upgrade.FromVersion, upgrade.ToVersion, upgrade.Step(omv1.UpgradeStepBackupDaemon).Message))
+			if _, err := r.updateStatus(ctx, opsManager, upgradeStatus, log, opsManagerExtraStatusParams); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
 		return r.updateStatus(ctx, opsManager, status, log, mdbstatus.NewOMPartOption(mdbstatus.Backup))
 	}
 
+	if completeUpgradeStep(upgrade, omv1.UpgradeStepBackupDaemon, log) {
+		if _, err := r.updateStatus(ctx, opsManager, workflow.OK(), log, opsManagerExtraStatusParams, mdbstatus.NewBaseUrlOption(opsManager.CentralURL())); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// the AppDB is upgraded last, now that Ops Manager and the Backup Daemons have been upgraded
+	if isUpgradeStepPending(upgrade, omv1.UpgradeStepAppDB) {
+		log.Infof("Ops Manager upgrade from %s to %s: requeuing to upgrade the Application Database", upgrade.FromVersion, upgrade.ToVersion)
+		return retryResult, nil
+	}
+
 	annotationsToAdd, err := getAnnotationsForOpsManagerResource(opsManager)
 	if err != nil {
 		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log)
@@ -555,7 +641,7 @@ func createOrUpdateSecretIfNotFound(ctx context.Context, secretGetUpdaterCreator
 	return nil
 }
 
-func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, opsManager *omv1.MongoDBOpsManager, appDBConnectionString, initOpsManagerImage, opsManagerImage string, log *zap.SugaredLogger) (workflow.Status, api.OpsManagerAdmin) {
+func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, opsManager *omv1.MongoDBOpsManager, upgrade *omv1.UpgradeStatus, appDBConnectionString, initOpsManagerImage, opsManagerImage string, log *zap.SugaredLogger) (workflow.Status, api.OpsManagerAdmin) {
 	var genKeySecretMap map[string][]byte
 	var err error
 	if genKeySecretMap, err = r.ensureGenKeyInOperatorCluster(ctx, opsManager, log); err != nil {
@@ -570,6 +656,10 @@ func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcil
 		return workflow.Failed(xerrors.Errorf("error in replicateTLSCAInMemberClusters: %w", err)), nil
 	}
 
+	if status := r.ensureCertManagerCertificate(ctx, opsManager); !status.IsOK() {
+		return status, nil
+	}
+
 	if err := r.replicateAppDBTLSCAInMemberClusters(ctx, reconcilerHelper); err != nil {
 		return workflow.Failed(xerrors.Errorf("error in replicateAppDBTLSCAInMemberClusters: %w", err)), nil
 	}
@@ -586,7 +676,14 @@ func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcil
 		return workflow.Failed(xerrors.Errorf("error in replicateQueryableBackupTLSSecretInMemberClusters: %w", err)), nil
 	}
 
-	// Prepare Ops Manager StatefulSets in parallel in all member clusters
+	// Stop backup daemon if necessary, so that it never runs a different version than Ops Manager
+	if err := r.stopBackupDaemonIfNeeded(ctx, reconcilerHelper); err != nil {
+		return workflow.Failed(err), nil
+	}
+
+	// Prepare Ops Manager StatefulSets in parallel in all member clusters. During an upgrade the member clusters are
+	// upgraded one by one: the Ops Manager pods are rolled one at a time (each of them must report healthy on
+	// /monitor/health) and the next member cluster is upgraded only when all the pods in the previous one are ready.
 	var workflowStatus workflow.Status = workflow.OK()
 	for _, memberCluster := range reconcilerHelper.getHealthyMemberClusters() {
 		mutatedSts, err := r.createOpsManagerStatefulsetInMemberCluster(ctx, reconcilerHelper, appDBConnectionString, memberCluster, initOpsManagerImage, opsManagerImage, log)
@@ -596,6 +693,9 @@ func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcil
 
 		expectedGeneration := mutatedSts.GetGeneration()
 		statefulsetStatus := statefulset.GetStatefulSetStatus(ctx, opsManager.Namespace, reconcilerHelper.OpsManagerStatefulSetNameForMemberCluster(memberCluster), expectedGeneration, memberCluster.Client)
+		if !isUpgradeStepCompleted(upgrade, omv1.UpgradeStepOpsManager) && !statefulsetStatus.IsOK() {
+			return statefulsetStatus, nil
+		}
 		workflowStatus = workflowStatus.Merge(statefulsetStatus)
 	}
 
@@ -618,10 +718,7 @@ func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcil
 		log.Warn("Not triggering an Ops Manager version changed event: %s", err)
 	}
 
-	// 5. Stop backup daemon if necessary
-	if err := r.stopBackupDaemonIfNeeded(ctx, reconcilerHelper); err != nil {
-		return workflow.Failed(err), nil
-	}
+	completeUpgradeStep(upgrade, omv1.UpgradeStepOpsManager, log)
 
 	statusOptions := []mdbstatus.Option{mdbstatus.NewOMPartOption(mdbstatus.OpsManager), mdbstatus.NewBaseUrlOption(opsManagerURL)}
 	if _, err := r.updateStatus(ctx, opsManager, workflow.OK(), log, statusOptions...); err != nil {
@@ -781,10 +878,7 @@ func (r *OpsManagerReconciler) readOpsManagerResource(ctx context.Context, reque
 
 // ensureAppDBConnectionString ensures that the AppDB Connection String exists in a secret.
 func (r *OpsManagerReconciler) ensureAppDBConnectionStringInMemberCluster(ctx context.Context, opsManager *omv1.MongoDBOpsManager, computedConnectionString string, memberCluster multicluster.MemberCluster, log *zap.SugaredLogger) error {
-	var opsManagerSecretPath string
-	if r.VaultClient != nil {
-		opsManagerSecretPath = r.VaultClient.OpsManagerSecretPath()
-	}
+	opsManagerSecretPath := r.OpsManagerSecretPath()
 
 	_, err := memberCluster.SecretClient.ReadSecret(ctx, kube.ObjectKey(opsManager.Namespace, opsManager.AppDBMongoConnectionStringSecretName()), opsManagerSecretPath)
 	if err != nil {
@@ -836,6 +930,11 @@ func (r *OpsManagerReconciler) createOpsManagerStatefulsetInMemberCluster(ctx co
 		log.Debugf("Error while retrieving debug port for Ops Manager: %s", err)
 	}
 
+	replicas, err := r.opsManagerReplicasInMemberCluster(ctx, reconcilerHelper, memberCluster)
+	if err != nil {
+		return nil, err
+	}
+
 	clusterSpecItem := reconcilerHelper.getClusterSpecOMItem(memberCluster.Name)
 	sts, err := construct.OpsManagerStatefulSet(ctx, r.SecretClient, opsManager, memberCluster, log,
 		construct.WithInitOpsManagerImage(initOpsManagerImage),
@@ -844,7 +943,7 @@ func (r *OpsManagerReconciler) createOpsManagerStatefulsetInMemberCluster(ctx co
 		construct.WithVaultConfig(vaultConfig),
 		construct.WithKmipConfig(ctx, opsManager, r.client, log),
 		construct.WithStsOverride(clusterSpecItem.GetStatefulSetSpecOverride()),
-		construct.WithReplicas(reconcilerHelper.OpsManagerMembersForMemberCluster(memberCluster)),
+		construct.WithReplicas(replicas),
 		construct.WithDebugPort(debugPort),
 		construct.WithOMDefaultArchitecture(r.defaultArchitecture),
 	)
@@ -855,8 +954,32 @@ func (r *OpsManagerReconciler) createOpsManagerStatefulsetInMemberCluster(ctx co
 	return create.OpsManagerInKubernetes(ctx, memberCluster, opsManager, sts, log)
 }
 
-func AddOpsManagerController(ctx context.Context, mgr manager.Manager, memberClustersMap map[string]cluster.Cluster, imageUrls images.ImageUrls, initDatabaseVersion, initOpsManagerImageVersion string, defaultArchitecture architectures.DefaultArchitecture) error {
-	reconciler := NewOpsManagerReconciler(ctx, mgr.GetClient(), multicluster.ClustersMapToClientMap(memberClustersMap), imageUrls, initDatabaseVersion, initOpsManagerImageVersion, defaultArchitecture, om.NewOpsManagerConnection, &api.DefaultInitializer{}, api.NewOmAdmin)
+// opsManagerReplicasInMemberCluster returns the number of Ops Manager replicas in the member cluster. If autoscaling is
+// enabled the replicas are managed by the HorizontalPodAutoscaler, so the current number of replicas of the existing
+// StatefulSet is kept.
+func (r *OpsManagerReconciler) opsManagerReplicasInMemberCluster(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, memberCluster multicluster.MemberCluster) (int, error) {
+	members := reconcilerHelper.OpsManagerMembersForMemberCluster(memberCluster)
+	autoscaling := reconcilerHelper.opsManager.Spec.Autoscaling
+	if autoscaling == nil || members == 0 {
+		return members, nil
+	}
+
+	stsName := reconcilerHelper.OpsManagerStatefulSetNameForMemberCluster(memberCluster)
+	sts, err := memberCluster.Client.GetStatefulSet(ctx, kube.ObjectKey(reconcilerHelper.opsManager.Namespace, stsName))
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			return int(autoscaling.MinReplicasOrDefault(members)), nil
+		}
+		return 0, xerrors.Errorf("error reading Ops Manager statefulset %s in member cluster %s: %w", stsName, memberCluster.Name, err)
+	}
+
+	return int(ptr.Deref(sts.Spec.Replicas, autoscaling.MinReplicasOrDefault(members))), nil
+}
+
+func AddOpsManagerController(ctx context.Context, mgr manager.Manager, memberClusterRegistry *multicluster.MemberClusterRegistry, memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker, imageUrls images.ImageUrls, initDatabaseVersion, initOpsManagerImageVersion string, defaultArchitecture architectures.DefaultArchitecture) error {
+	reconciler := NewOpsManagerReconciler(ctx, mgr.GetClient(), memberClusterRegistry.Clients(), imageUrls, initDatabaseVersion, initOpsManagerImageVersion, defaultArchitecture, om.NewOpsManagerConnection, &api.DefaultInitializer{}, api.NewOmAdmin)
+	reconciler.memberClusterRegistry = memberClusterRegistry
+	reconciler.recorder = mgr.GetEventRecorderFor(util.OperatorName)
 	c, err := controller.New(util.MongoDbOpsManagerController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
@@ -888,6 +1011,12 @@ func AddOpsManagerController(ctx context.Context, mgr manager.Manager, memberClu
 		return err
 	}
 
+	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &referencegrant.MongoDBReferenceGrant{},
+		enqueueGrantedReferrers(mgr.GetClient(), referencegrant.KindMongoDBOpsManager, func() client.ObjectList { return &omv1.MongoDBOpsManagerList{} })))
+	if err != nil {
+		return err
+	}
+
 	// if vault secret backend is enabled watch for Vault secret change and trigger reconcile
 	if vault.IsVaultSecretBackend() {
 		eventChannel := make(chan event.GenericEvent)
@@ -898,11 +1027,19 @@ func AddOpsManagerController(ctx context.Context, mgr manager.Manager, memberClu
 			zap.S().Errorf("Failed to watch for vault secret changes: %v", err)
 		}
 	}
-	for clusterName, memberCluster := range memberClustersMap {
-		err = c.Watch(source.Kind[client.Object](memberCluster.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet()))
+	err = memberClusterRegistry.OnClusterAdded(func(clusterName string, memberCluster cluster.Cluster) error {
+		err := c.Watch(source.Kind[client.Object](memberCluster.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet()))
 		if err != nil {
 			return xerrors.Errorf("failed to set AppDB StatefulSet watch on member cluster %s: %w", clusterName, err)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := memberClusterHealthChecker.WatchResources(c, memberwatch.OpsManagerResources); err != nil {
+		zap.S().Errorf("failed to watch for member cluster healthcheck: %s", err)
 	}
 
 	err = c.Watch(
@@ -1082,10 +1219,7 @@ func setConfigProperty(opsManager *omv1.MongoDBOpsManager, key, value string, lo
 
 func (r *OpsManagerReconciler) ensureGenKeyInOperatorCluster(ctx context.Context, om *omv1.MongoDBOpsManager, log *zap.SugaredLogger) (map[string][]byte, error) {
 	objectKey := kube.ObjectKey(om.Namespace, om.Name+"-gen-key")
-	var opsManagerSecretPath string
-	if r.VaultClient != nil {
-		opsManagerSecretPath = r.VaultClient.OpsManagerSecretPath()
-	}
+	opsManagerSecretPath := r.OpsManagerSecretPath()
 	genKeySecretMap, err := r.ReadBinarySecret(ctx, objectKey, opsManagerSecretPath)
 	if err == nil {
 		return genKeySecretMap, nil
@@ -1123,10 +1257,7 @@ func (r *OpsManagerReconciler) replicateGenKeyInMemberClusters(ctx context.Conte
 		return nil
 	}
 	objectKey := kube.ObjectKey(reconcileHelper.opsManager.Namespace, reconcileHelper.opsManager.Name+"-gen-key")
-	var opsManagerSecretPath string
-	if r.VaultClient != nil {
-		opsManagerSecretPath = r.VaultClient.OpsManagerSecretPath()
-	}
+	opsManagerSecretPath := r.OpsManagerSecretPath()
 
 	genKeySecret := secret.Builder().
 		SetName(objectKey.Name).
@@ -1157,10 +1288,7 @@ func (r *OpsManagerReconciler) replicateQueryableBackupTLSSecretInMemberClusters
 
 func (r *OpsManagerReconciler) replicateSecretInMemberClusters(ctx context.Context, reconcileHelper *OpsManagerReconcilerHelper, namespace string, secretName string) error {
 	objectKey := kube.ObjectKey(namespace, secretName)
-	var opsManagerSecretPath string
-	if r.VaultClient != nil {
-		opsManagerSecretPath = r.VaultClient.OpsManagerSecretPath()
-	}
+	opsManagerSecretPath := r.OpsManagerSecretPath()
 	secretMap, err := r.ReadSecret(ctx, objectKey, opsManagerSecretPath)
 	if err != nil {
 		return xerrors.Errorf("failed to read secret %s: %w", secretName, err)
@@ -1182,6 +1310,31 @@ func (r *OpsManagerReconciler) replicateSecretInMemberClusters(ctx context.Conte
 	return nil
 }
 
+// ensureCertManagerCertificate requests the Ops Manager certificate from cert-manager, if enabled. The certificate is
+// valid for the Ops Manager services and pods, and for the host of spec.opsManagerURL.
+func (r *OpsManagerReconciler) ensureCertManagerCertificate(ctx context.Context, opsManager *omv1.MongoDBOpsManager) workflow.Status {
+	if !opsManager.IsTLSEnabled() || opsManager.Spec.Security.TLS.CertManager == nil {
+		return workflow.OK()
+	}
+
+	serviceDomain := dns.GetServiceDomain(opsManager.Namespace, opsManager.Spec.GetClusterDomain(), nil)
+	hostnames := []string{
+		fmt.Sprintf("%s.%s", opsManager.SvcName(), serviceDomain),
+		fmt.Sprintf("*.%s.%s", opsManager.SvcName(), serviceDomain),
+		fmt.Sprintf("%s.%s", opsManager.ExternalSvcName(), serviceDomain),
+	}
+	if opsManager.Spec.OpsManagerURL != "" {
+		opsManagerURL, err := url.Parse(opsManager.Spec.OpsManagerURL)
+		if err != nil {
+			return workflow.Invalid("spec.opsManagerURL is not a valid URL: %s", err)
+		}
+		hostnames = append(hostnames, opsManagerURL.Hostname())
+	}
+
+	secretName := kube.ObjectKey(opsManager.Namespace, opsManager.TLSCertificateSecretName())
+	return certs.EnsureCertManagerCertificate(ctx, r.client, *opsManager.Spec.Security.TLS.CertManager, secretName, hostnames, kube.BaseOwnerReference(opsManager))
+}
+
 func (r *OpsManagerReconciler) replicateTLSCAInMemberClusters(ctx context.Context, reconcileHelper *OpsManagerReconcilerHelper) error {
 	if !reconcileHelper.opsManager.Spec.IsMultiCluster() || reconcileHelper.opsManager.Spec.GetOpsManagerCA() == "" {
 		return nil
@@ -1267,10 +1420,7 @@ func (r *OpsManagerReconciler) replicateConfigMapInMemberClusters(ctx context.Co
 }
 
 func (r *OpsManagerReconciler) getOpsManagerAPIKeySecretName(ctx context.Context, opsManager *omv1.MongoDBOpsManager) (string, workflow.Status) {
-	var operatorVaultSecretPath string
-	if r.VaultClient != nil {
-		operatorVaultSecretPath = r.VaultClient.OperatorSecretPath()
-	}
+	operatorVaultSecretPath := r.OperatorSecretPath()
 	APISecretName, err := opsManager.APIKeySecretName(ctx, r.SecretClient, operatorVaultSecretPath)
 	if err != nil {
 		return "", workflow.Failed(xerrors.Errorf("failed to get ops-manager API key secret name: %w", err)).WithRetry(10)
@@ -1296,10 +1446,7 @@ func (r *OpsManagerReconciler) prepareOpsManager(ctx context.Context, opsManager
 	// We won't support cross-namespace secrets until CLOUDP-46636 is resolved
 	adminObjectKey := kube.ObjectKey(opsManager.Namespace, opsManager.Spec.AdminSecret)
 
-	var operatorVaultPath string
-	if r.VaultClient != nil {
-		operatorVaultPath = r.VaultClient.OperatorSecretPath()
-	}
+	operatorVaultPath := r.OperatorSecretPath()
 
 	// 1. Read the admin secret
 	userData, err := r.ReadSecret(ctx, adminObjectKey, operatorVaultPath)
@@ -1453,13 +1600,20 @@ func (r *OpsManagerReconciler) prepareBackupInOpsManager(ctx context.Context, re
 	// 4. S3 Configs
 	status = status.Merge(r.ensureS3ConfigurationInOpsManager(ctx, opsManager, omAdmin, appDBConnectionString, log))
 
-	// 5. Block store configs
+	// 5. GCS Configs
+	status = status.Merge(r.ensureGCSConfigurationInOpsManager(ctx, opsManager, omAdmin, appDBConnectionString, log))
+
+	// 6. Azure Blob Configs
+	status = status.Merge(r.ensureAzureBlobConfigurationInOpsManager(ctx, opsManager, omAdmin, appDBConnectionString, log))
+
+	// 7. Block store configs
 	status = status.Merge(r.ensureBlockStoresInOpsManager(ctx, opsManager, omAdmin, log))
 
-	// 6. FileSystem store configs
+	// 8. FileSystem store configs
 	status = status.Merge(r.ensureFileSystemStoreConfigurationInOpsManager(opsManager, omAdmin))
-	if len(opsManager.Spec.Backup.S3Configs) == 0 && len(opsManager.Spec.Backup.BlockStoreConfigs) == 0 && len(opsManager.Spec.Backup.FileSystemStoreConfigs) == 0 {
-		return status.Merge(workflow.Invalid("Either S3 or Blockstore or FileSystem Snapshot configuration is required for backup").WithTargetPhase(mdbstatus.PhasePending))
+	if len(opsManager.Spec.Backup.S3Configs) == 0 && len(opsManager.Spec.Backup.GCSConfigs) == 0 && len(opsManager.Spec.Backup.AzureBlobConfigs) == 0 &&
+		len(opsManager.Spec.Backup.BlockStoreConfigs) == 0 && len(opsManager.Spec.Backup.FileSystemStoreConfigs) == 0 {
+		return status.Merge(workflow.Invalid("Either S3, GCS, Azure Blob, Blockstore or FileSystem Snapshot configuration is required for backup").WithTargetPhase(mdbstatus.PhasePending))
 	}
 
 	return status
@@ -1474,6 +1628,7 @@ func (r *OpsManagerReconciler) ensureOplogStoresInOpsManager(ctx context.Context
 		return workflow.OK()
 	}
 
+	var revoked workflow.Status
 	opsManagerOplogConfigs, err := omAdmin.ReadOplogStoreConfigs()
 	if err != nil {
 		return workflow.Failed(xerrors.New(err.Error()))
@@ -1500,6 +1655,14 @@ func (r *OpsManagerReconciler) ensureOplogStoresInOpsManager(ctx context.Context
 		omConfig := v[0].(backup.DataStoreConfig)
 		operatorConfig := v[1].(omv1.DataStoreConfig)
 		operatorView, status := r.buildOMDatastoreConfig(ctx, opsManager, operatorConfig)
+		if isReferenceNotAllowed(status) {
+			log.Infof("The reference of the Oplog Store %s is no longer allowed, removing it from Ops Manager", omConfig.Identifier())
+			if err = omAdmin.DeleteOplogStoreConfig(omConfig.Identifier().(string)); err != nil {
+				return workflow.Failed(xerrors.New(err.Error()))
+			}
+			revoked = status
+			continue
+		}
 		if !status.IsOK() {
 			return status
 		}
@@ -1522,6 +1685,10 @@ func (r *OpsManagerReconciler) ensureOplogStoresInOpsManager(ctx context.Context
 		}
 	}
 
+	if revoked != nil {
+		return revoked
+	}
+
 	operatorS3OplogConfigs := opsManager.Spec.Backup.S3OplogStoreConfigs
 	if len(operatorOplogConfigs) == 0 && len(operatorS3OplogConfigs) == 0 {
 		return workflow.Invalid("Oplog Store configuration is required for backup").WithTargetPhase(mdbstatus.PhasePending)
@@ -1534,6 +1701,7 @@ func (r *OpsManagerReconciler) ensureS3OplogStoresInOpsManager(ctx context.Conte
 		return workflow.OK()
 	}
 
+	var revoked workflow.Status
 	opsManagerS3OpLogConfigs, err := s3OplogAdmin.ReadS3OplogStoreConfigs()
 	if err != nil {
 		return workflow.Failed(xerrors.New(err.Error()))
@@ -1560,6 +1728,14 @@ func (r *OpsManagerReconciler) ensureS3OplogStoresInOpsManager(ctx context.Conte
 		omConfig := v[0].(backup.S3Config)
 		operatorConfig := v[1].(omv1.S3Config)
 		operatorView, status := r.buildOMS3Config(ctx, opsManager, operatorConfig, true, appDBConnectionString)
+		if isReferenceNotAllowed(status) {
+			log.Infof("The reference of the S3 Oplog Store %s is no longer allowed, removing it from Ops Manager", omConfig.Identifier())
+			if err = s3OplogAdmin.DeleteS3OplogStoreConfig(omConfig.Identifier().(string)); err != nil {
+				return workflow.Failed(xerrors.New(err.Error()))
+			}
+			revoked = status
+			continue
+		}
 		if !status.IsOK() {
 			return status
 		}
@@ -1582,6 +1758,10 @@ func (r *OpsManagerReconciler) ensureS3OplogStoresInOpsManager(ctx context.Conte
 		}
 	}
 
+	if revoked != nil {
+		return revoked
+	}
+
 	operatorOplogConfigs := opsManager.Spec.Backup.OplogStoreConfigs
 	if len(operatorOplogConfigs) == 0 && len(s3OperatorOplogConfigs) == 0 {
 		return workflow.Invalid("Oplog Store configuration is required for backup").WithTargetPhase(mdbstatus.PhasePending)
@@ -1598,6 +1778,7 @@ func (r *OpsManagerReconciler) ensureBlockStoresInOpsManager(ctx context.Context
 		return workflow.OK()
 	}
 
+	var revoked workflow.Status
 	opsManagerBlockStoreConfigs, err := omAdmin.ReadBlockStoreConfigs()
 	if err != nil {
 		return workflow.Failed(xerrors.New(err.Error()))
@@ -1624,6 +1805,14 @@ func (r *OpsManagerReconciler) ensureBlockStoresInOpsManager(ctx context.Context
 		omConfig := v[0].(backup.DataStoreConfig)
 		operatorConfig := v[1].(omv1.DataStoreConfig)
 		operatorView, status := r.buildOMDatastoreConfig(ctx, opsManager, operatorConfig)
+		if isReferenceNotAllowed(status) {
+			log.Infof("The reference of the Block Store %s is no longer allowed, removing it from Ops Manager", omConfig.Identifier())
+			if err = omAdmin.DeleteBlockStoreConfig(omConfig.Identifier().(string)); err != nil {
+				return workflow.Failed(xerrors.New(err.Error()))
+			}
+			revoked = status
+			continue
+		}
 		if !status.IsOK() {
 			return status
 		}
@@ -1645,6 +1834,9 @@ func (r *OpsManagerReconciler) ensureBlockStoresInOpsManager(ctx context.Context
 			return workflow.Failed(xerrors.New(err.Error()))
 		}
 	}
+	if revoked != nil {
+		return revoked
+	}
 	return workflow.OK()
 }
 
@@ -1653,6 +1845,7 @@ func (r *OpsManagerReconciler) ensureS3ConfigurationInOpsManager(ctx context.Con
 		return workflow.OK()
 	}
 
+	var revoked workflow.Status
 	opsManagerS3Configs, err := omAdmin.ReadS3Configs()
 	if err != nil {
 		return workflow.Failed(xerrors.New(err.Error()))
@@ -1679,6 +1872,14 @@ func (r *OpsManagerReconciler) ensureS3ConfigurationInOpsManager(ctx context.Con
 		omConfig := v[0].(backup.S3Config)
 		operatorConfig := v[1].(omv1.S3Config)
 		operatorView, status := r.buildOMS3Config(ctx, opsManager, operatorConfig, false, appDBConnectionString)
+		if isReferenceNotAllowed(status) {
+			log.Infof("The reference of the S3Config %s is no longer allowed, removing it from Ops Manager", omConfig.Identifier())
+			if err = omAdmin.DeleteS3Config(omConfig.Identifier().(string)); err != nil {
+				return workflow.Failed(xerrors.New(err.Error()))
+			}
+			revoked = status
+			continue
+		}
 		if !status.IsOK() {
 			return status
 		}
@@ -1700,16 +1901,215 @@ func (r *OpsManagerReconciler) ensureS3ConfigurationInOpsManager(ctx context.Con
 		}
 	}
 
+	if revoked != nil {
+		return revoked
+	}
+
+	return workflow.OK()
+}
+
+func (r *OpsManagerReconciler) ensureGCSConfigurationInOpsManager(ctx context.Context, opsManager *omv1.MongoDBOpsManager, omAdmin api.GCSStoreBlockStoreAdmin, appDBConnectionString string, log *zap.SugaredLogger) workflow.Status {
+	storeAdmin := snapshotStoreAdmin[backup.GCSConfig]{
+		read:   omAdmin.ReadGCSConfigs,
+		create: omAdmin.CreateGCSConfig,
+		update: omAdmin.UpdateGCSConfig,
+		delete: omAdmin.DeleteGCSConfig,
+	}
+	return ensureBlobSnapshotStoresInOpsManager(opsManager, "GCS", opsManager.Spec.Backup.GCSConfigs, storeAdmin, func(config omv1.GCSConfig) (backup.GCSConfig, workflow.Status) {
+		return r.buildOMGCSConfig(ctx, opsManager, config, appDBConnectionString)
+	}, log)
+}
+
+func (r *OpsManagerReconciler) ensureAzureBlobConfigurationInOpsManager(ctx context.Context, opsManager *omv1.MongoDBOpsManager, omAdmin api.AzureBlobStoreBlockStoreAdmin, appDBConnectionString string, log *zap.SugaredLogger) workflow.Status {
+	storeAdmin := snapshotStoreAdmin[backup.AzureBlobConfig]{
+		read:   omAdmin.ReadAzureBlobConfigs,
+		create: omAdmin.CreateAzureBlobConfig,
+		update: omAdmin.UpdateAzureBlobConfig,
+		delete: omAdmin.DeleteAzureBlobConfig,
+	}
+	return ensureBlobSnapshotStoresInOpsManager(opsManager, "Azure Blob", opsManager.Spec.Backup.AzureBlobConfigs, storeAdmin, func(config omv1.AzureBlobConfig) (backup.AzureBlobConfig, workflow.Status) {
+		return r.buildOMAzureBlobConfig(ctx, opsManager, config, appDBConnectionString)
+	}, log)
+}
+
+// snapshotStoreAdmin is the part of the Ops Manager admin API managing one kind of snapshot store.
+type snapshotStoreAdmin[T any] struct {
+	read   func() ([]T, error)
+	create func(config T) error
+	update func(config T) error
+	delete func(id string) error
+}
+
+// opsManagerSnapshotStore is the Ops Manager representation of a snapshot store, the Operator view of the store is
+// merged into the one read from Ops Manager so that the fields it doesn't own are preserved.
+type opsManagerSnapshotStore[T any] interface {
+	identifiable.Identifiable
+	MergeIntoOpsManagerConfig(opsManagerConfig T) T
+}
+
+// ensureBlobSnapshotStoresInOpsManager aligns the GCS or Azure Blob snapshot stores in Ops Manager with the Operator
+// spec. These stores are only supported since Ops Manager blobSnapshotStoresVersion, so they are not read from the
+// older versions, which fail to serve them.
+func ensureBlobSnapshotStoresInOpsManager[C any, T opsManagerSnapshotStore[T]](opsManager *omv1.MongoDBOpsManager, storeType string, operatorConfigs []C, storeAdmin snapshotStoreAdmin[T], buildConfig func(config C) (T, workflow.Status), log *zap.SugaredLogger) workflow.Status {
+	if !opsManager.Spec.Backup.Enabled {
+		return workflow.OK()
+	}
+
+	version, err := versionutil.StringToSemverVersion(opsManager.Spec.Version)
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to parse Ops Manager version %s: %w", opsManager.Spec.Version, err))
+	}
+	if version.LT(semver.MustParse(blobSnapshotStoresVersion)) {
+		if len(operatorConfigs) > 0 {
+			return workflow.Invalid("%s snapshot stores require Ops Manager %s or later", storeType, blobSnapshotStoresVersion)
+		}
+		return workflow.OK()
+	}
+
+	var revoked workflow.Status
+	opsManagerConfigs, err := storeAdmin.read()
+	if err != nil {
+		return workflow.Failed(xerrors.New(err.Error()))
+	}
+
+	configsToCreate := identifiable.SetDifferenceGeneric(operatorConfigs, opsManagerConfigs)
+	for _, config := range configsToCreate {
+		omConfig, status := buildConfig(config.(C))
+		if !status.IsOK() {
+			return status
+		}
+
+		log.Infow(fmt.Sprintf("Creating %s snapshot store in Ops Manager", storeType), "id", omConfig.Identifier())
+		if err := storeAdmin.create(omConfig); err != nil {
+			return workflow.Failed(xerrors.New(err.Error()))
+		}
+	}
+
+	// Updating existing configs. It intersects the OM API configs with Operator spec configs and returns pairs
+	//["omConfig", "operatorConfig"].
+	configsToUpdate := identifiable.SetIntersectionGeneric(opsManagerConfigs, operatorConfigs)
+	for _, v := range configsToUpdate {
+		omConfig := v[0].(T)
+		operatorView, status := buildConfig(v[1].(C))
+		if isReferenceNotAllowed(status) {
+			log.Infof("The reference of the %s snapshot store %s is no longer allowed, removing it from Ops Manager", storeType, omConfig.Identifier())
+			if err = storeAdmin.delete(omConfig.Identifier().(string)); err != nil {
+				return workflow.Failed(xerrors.New(err.Error()))
+			}
+			revoked = status
+			continue
+		}
+		if !status.IsOK() {
+			return status
+		}
+
+		configToUpdate := operatorView.MergeIntoOpsManagerConfig(omConfig)
+		log.Infow(fmt.Sprintf("Updating %s snapshot store in Ops Manager", storeType), "id", configToUpdate.Identifier())
+		if err = storeAdmin.update(configToUpdate); err != nil {
+			return workflow.Failed(xerrors.New(err.Error()))
+		}
+	}
+
+	configsToRemove := identifiable.SetDifferenceGeneric(opsManagerConfigs, operatorConfigs)
+	for _, config := range configsToRemove {
+		log.Infof("Removing %s snapshot store %s from Ops Manager", storeType, config.Identifier())
+		if err := storeAdmin.delete(config.Identifier().(string)); err != nil {
+			return workflow.Failed(xerrors.New(err.Error()))
+		}
+	}
+
+	if revoked != nil {
+		return revoked
+	}
+
 	return workflow.OK()
 }
 
+// buildOMGCSConfig builds the OM API GCS config from the Operator OM CR configuration. The service account key is
+// not read if GKE Workload Identity is used.
+func (r *OpsManagerReconciler) buildOMGCSConfig(ctx context.Context, opsManager *omv1.MongoDBOpsManager, config omv1.GCSConfig, appDBConnectionString string) (backup.GCSConfig, workflow.Status) {
+	uri, status := r.buildMetadataDatabaseUri(ctx, opsManager, config, config.MongoDBUserRef, "GCS metadata database", appDBConnectionString)
+	if !status.IsOK() {
+		return backup.GCSConfig{}, status
+	}
+
+	var serviceAccountKey *string
+	if !config.WorkloadIdentityEnabled {
+		key, err := r.readBlobStoreCredential(ctx, config.GCSSecretRef.Name, opsManager.Namespace, util.GCSServiceAccountKey)
+		if err != nil {
+			return backup.GCSConfig{}, workflow.Failed(err)
+		}
+		serviceAccountKey = &key
+	}
+
+	return backup.NewGCSConfig(config, uri, serviceAccountKey), workflow.OK()
+}
+
+// buildOMAzureBlobConfig builds the OM API Azure Blob config from the Operator OM CR configuration. The account key is
+// not read if AKS Workload Identity is used.
+func (r *OpsManagerReconciler) buildOMAzureBlobConfig(ctx context.Context, opsManager *omv1.MongoDBOpsManager, config omv1.AzureBlobConfig, appDBConnectionString string) (backup.AzureBlobConfig, workflow.Status) {
+	uri, status := r.buildMetadataDatabaseUri(ctx, opsManager, config, config.MongoDBUserRef, "Azure Blob metadata database", appDBConnectionString)
+	if !status.IsOK() {
+		return backup.AzureBlobConfig{}, status
+	}
+
+	var accountKey *string
+	if !config.WorkloadIdentityEnabled {
+		key, err := r.readBlobStoreCredential(ctx, config.AzureSecretRef.Name, opsManager.Namespace, util.AzureAccountKey)
+		if err != nil {
+			return backup.AzureBlobConfig{}, workflow.Failed(err)
+		}
+		accountKey = &key
+	}
+
+	return backup.NewAzureBlobConfig(config, uri, accountKey), workflow.OK()
+}
+
+// buildMetadataDatabaseUri returns the connection string of the metadata database of a snapshot store: either the
+// referenced MongoDB resource or the AppDB if no resource is referenced.
+func (r *OpsManagerReconciler) buildMetadataDatabaseUri(ctx context.Context, opsManager *omv1.MongoDBOpsManager, config metadataDatabaseRef, userRef *omv1.MongoDBUserRef, description string, appDBConnectionString string) (string, workflow.Status) {
+	if config.MongodbResourceObjectKey(opsManager).Name == "" {
+		return appDBConnectionString, workflow.OK()
+	}
+
+	mongodb, status := r.getMongoDbForS3Config(ctx, opsManager, config)
+	if !status.IsOK() {
+		return "", status
+	}
+
+	if status := validateConfig(mongodb.GetAuthenticationModes(), mongodb.GetResourceName(), userRef, description); !status.IsOK() {
+		return "", status
+	}
+
+	userName, password, status := r.getS3MongoDbUserNameAndPassword(ctx, mongodb.GetAuthenticationModes(), opsManager, config)
+	if !status.IsOK() {
+		return "", status
+	}
+
+	return mongodb.BuildConnectionString(userName, password, connectionstring.SchemeMongoDB, map[string]string{}), workflow.OK()
+}
+
+// readBlobStoreCredential reads the credential stored under 'key' in the secret referenced by a GCS or Azure Blob
+// snapshot store
+func (r *OpsManagerReconciler) readBlobStoreCredential(ctx context.Context, secretName, namespace, key string) (string, error) {
+	operatorSecretPath := r.OperatorSecretPath()
+
+	secretData, err := r.ReadSecret(ctx, kube.ObjectKey(namespace, secretName), operatorSecretPath)
+	if err != nil {
+		return "", xerrors.New(err.Error())
+	}
+
+	value, ok := secretData[key]
+	if !ok {
+		return "", xerrors.Errorf("key %s was not present in the secret %s", key, secretName)
+	}
+	return value, nil
+}
+
 // readS3Credentials reads the access and secret keys from the awsCredentials secret specified
 // in the resource
 func (r *OpsManagerReconciler) readS3Credentials(ctx context.Context, s3SecretName, namespace string) (*backup.S3Credentials, error) {
-	var operatorSecretPath string
-	if r.VaultClient != nil {
-		operatorSecretPath = r.VaultClient.OperatorSecretPath()
-	}
+	operatorSecretPath := r.OperatorSecretPath()
 
 	s3SecretData, err := r.ReadSecret(ctx, kube.ObjectKey(namespace, s3SecretName), operatorSecretPath)
 	if err != nil {
@@ -1802,7 +2202,7 @@ func (r *OpsManagerReconciler) buildMongoDbOMS3Config(ctx context.Context, opsMa
 		return backup.S3Config{}, status
 	}
 
-	userName, password, status := r.getS3MongoDbUserNameAndPassword(ctx, mongodb.GetAuthenticationModes(), opsManager.Namespace, config)
+	userName, password, status := r.getS3MongoDbUserNameAndPassword(ctx, mongodb.GetAuthenticationModes(), opsManager, config)
 	if !status.IsOK() {
 		return backup.S3Config{}, status
 	}
@@ -1892,7 +2292,7 @@ func (r *OpsManagerReconciler) buildOMS3Config(ctx context.Context, opsManager *
 }
 
 // getMongoDbForS3Config returns the referenced MongoDB resource which should be used when configuring the backup config.
-func (r *OpsManagerReconciler) getMongoDbForS3Config(ctx context.Context, opsManager *omv1.MongoDBOpsManager, config omv1.S3Config) (S3ConfigGetter, workflow.Status) {
+func (r *OpsManagerReconciler) getMongoDbForS3Config(ctx context.Context, opsManager *omv1.MongoDBOpsManager, config metadataDatabaseRef) (S3ConfigGetter, workflow.Status) {
 	mongodb, mongodbMulti := &mdbv1.MongoDB{}, &mdbmulti.MongoDBMultiCluster{}
 	mongodbObjectKey := config.MongodbResourceObjectKey(opsManager)
 
@@ -1909,24 +2309,36 @@ func (r *OpsManagerReconciler) getMongoDbForS3Config(ctx context.Context, opsMan
 				}
 				return nil, workflow.Failed(xerrors.New(err.Error()))
 			}
+			if status := r.validateBackupReference(ctx, opsManager, referencegrant.KindMongoDBMultiCluster, mongodbObjectKey); !status.IsOK() {
+				return nil, status
+			}
 			return mongodbMulti, workflow.OK()
 		}
 
 		return nil, workflow.Failed(err)
 	}
 
+	if status := r.validateBackupReference(ctx, opsManager, referencegrant.KindMongoDB, mongodbObjectKey); !status.IsOK() {
+		return nil, status
+	}
 	return mongodb, workflow.OK()
 }
 
-// getS3MongoDbUserNameAndPassword returns userName and password if MongoDB resource has scram-sha enabled.
-// Note, that we don't worry if the 'mongodbUserRef' is specified but SCRAM-SHA is not enabled - we just ignore the
-// user.
-func (r *OpsManagerReconciler) getS3MongoDbUserNameAndPassword(ctx context.Context, modes []string, namespace string, config omv1.S3Config) (string, string, workflow.Status) {
-	if !stringutil.Contains(modes, util.SCRAM) {
-		return "", "", workflow.OK()
+// validateBackupReference returns an Invalid status if a backup database, its user or the Secret with the password
+// of the user is in another namespace than the Ops Manager and no MongoDBReferenceGrant allows referencing it.
+func (r *OpsManagerReconciler) validateBackupReference(ctx context.Context, opsManager *omv1.MongoDBOpsManager, kind referencegrant.Kind, objectKey client.ObjectKey) workflow.Status {
+	from := referencegrant.Reference{Kind: referencegrant.KindMongoDBOpsManager, Namespace: opsManager.Namespace, Name: opsManager.Name}
+	to := referencegrant.Reference{Kind: kind, Namespace: objectKey.Namespace, Name: objectKey.Name}
+	return validateReference(ctx, r.client, from, to)
+}
+
+// getBackupUserNameAndPassword returns the user name and the password of the MongoDBUser used by Ops Manager to
+// connect to a backup database.
+func (r *OpsManagerReconciler) getBackupUserNameAndPassword(ctx context.Context, opsManager *omv1.MongoDBOpsManager, mongodbUserObjectKey client.ObjectKey) (string, string, workflow.Status) {
+	if status := r.validateBackupReference(ctx, opsManager, referencegrant.KindMongoDBUser, mongodbUserObjectKey); !status.IsOK() {
+		return "", "", status
 	}
 	mongodbUser := &user.MongoDBUser{}
-	mongodbUserObjectKey := config.MongodbUserObjectKey(namespace)
 	err := r.client.Get(ctx, mongodbUserObjectKey, mongodbUser)
 	if secret.SecretNotExist(err) {
 		return "", "", workflow.Pending("The MongoDBUser object %s doesn't exist", mongodbUserObjectKey)
@@ -1934,12 +2346,27 @@ func (r *OpsManagerReconciler) getS3MongoDbUserNameAndPassword(ctx context.Conte
 	if err != nil {
 		return "", "", workflow.Failed(xerrors.Errorf("Failed to fetch the user %s: %w", mongodbUserObjectKey, err))
 	}
-	userName := mongodbUser.Spec.Username
+	if mongodbUser.Spec.PasswordSecretKeyRef.Name != "" {
+		passwordSecretObjectKey := client.ObjectKey{Namespace: mongodbUser.Namespace, Name: mongodbUser.Spec.PasswordSecretKeyRef.Name}
+		if status := r.validateBackupReference(ctx, opsManager, referencegrant.KindSecret, passwordSecretObjectKey); !status.IsOK() {
+			return "", "", status
+		}
+	}
 	password, err := mongodbUser.GetPassword(ctx, r.SecretClient)
 	if err != nil {
 		return "", "", workflow.Failed(xerrors.Errorf("Failed to read password for the user %s: %w", mongodbUserObjectKey, err))
 	}
-	return userName, password, workflow.OK()
+	return mongodbUser.Spec.Username, password, workflow.OK()
+}
+
+// getS3MongoDbUserNameAndPassword returns userName and password if MongoDB resource has scram-sha enabled.
+// Note, that we don't worry if the 'mongodbUserRef' is specified but SCRAM-SHA is not enabled - we just ignore the
+// user.
+func (r *OpsManagerReconciler) getS3MongoDbUserNameAndPassword(ctx context.Context, modes []string, opsManager *omv1.MongoDBOpsManager, config metadataDatabaseRef) (string, string, workflow.Status) {
+	if !stringutil.Contains(modes, util.SCRAM) {
+		return "", "", workflow.OK()
+	}
+	return r.getBackupUserNameAndPassword(ctx, opsManager, config.MongodbUserObjectKey(opsManager.Namespace))
 }
 
 // buildOMDatastoreConfig builds the OM API datastore config based on the Kubernetes OM resource one.
@@ -1956,6 +2383,9 @@ func (r *OpsManagerReconciler) buildOMDatastoreConfig(ctx context.Context, opsMa
 		}
 		return backup.DataStoreConfig{}, workflow.Failed(xerrors.New(err.Error()))
 	}
+	if status := r.validateBackupReference(ctx, opsManager, referencegrant.KindMongoDB, mongodbObjectKey); !status.IsOK() {
+		return backup.DataStoreConfig{}, status
+	}
 
 	status := validateDataStoreConfig(mongodb.Spec.Security.Authentication.GetModes(), mongodb.Name, operatorConfig)
 	if !status.IsOK() {
@@ -1967,19 +2397,9 @@ func (r *OpsManagerReconciler) buildOMDatastoreConfig(ctx context.Context, opsMa
 	// user
 	var userName, password string
 	if stringutil.Contains(mongodb.Spec.Security.Authentication.GetModes(), util.SCRAM) {
-		mongodbUser := &user.MongoDBUser{}
-		mongodbUserObjectKey := operatorConfig.MongodbUserObjectKey(opsManager.Namespace)
-		err := r.client.Get(ctx, mongodbUserObjectKey, mongodbUser)
-		if secret.SecretNotExist(err) {
-			return backup.DataStoreConfig{}, workflow.Pending("The MongoDBUser object %s doesn't exist", operatorConfig.MongodbResourceObjectKey(opsManager.Namespace))
-		}
-		if err != nil {
-			return backup.DataStoreConfig{}, workflow.Failed(xerrors.Errorf("Failed to fetch the user %s: %w", operatorConfig.MongodbResourceObjectKey(opsManager.Namespace), err))
-		}
-		userName = mongodbUser.Spec.Username
-		password, err = mongodbUser.GetPassword(ctx, r.SecretClient)
-		if err != nil {
-			return backup.DataStoreConfig{}, workflow.Failed(xerrors.Errorf("Failed to read password for the user %s: %w", mongodbUserObjectKey, err))
+		userName, password, status = r.getBackupUserNameAndPassword(ctx, opsManager, operatorConfig.MongodbUserObjectKey(opsManager.Namespace))
+		if !status.IsOK() {
+			return backup.DataStoreConfig{}, status
 		}
 	}
 
@@ -2031,7 +2451,7 @@ func newUserFromSecret(data map[string]string) (api.User, error) {
 // it's used in MongoDBOpsManagerEventHandler
 func (r *OpsManagerReconciler) OnDelete(ctx context.Context, obj interface{}, log *zap.SugaredLogger) {
 	opsManager := obj.(*omv1.MongoDBOpsManager)
-	helper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.memberClustersMap, log)
+	helper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.getMemberClustersMap(), log)
 	if err != nil {
 		log.Errorf("Error initializing OM reconciler helper: %s", err)
 		return
@@ -2048,7 +2468,7 @@ func (r *OpsManagerReconciler) OnDelete(ctx context.Context, obj interface{}, lo
 	}
 
 	if opsManager.Spec.AppDB.IsMultiCluster() {
-		appDbHelper, err := NewReadOnlyAppDBReconcilerHelper(ctx, opsManager, r.ReconcileCommonController, r.memberClustersMap, log)
+		appDbHelper, err := NewReadOnlyAppDBReconcilerHelper(ctx, opsManager, r.ReconcileCommonController, r.getMemberClustersMap(), log)
 		if err != nil {
 			log.Errorf("Error initializing AppDB reconciler helper: %s", err)
 			return
@@ -2061,12 +2481,13 @@ func (r *OpsManagerReconciler) OnDelete(ctx context.Context, obj interface{}, lo
 	}
 
 	r.resourceWatcher.RemoveDependentWatchedResources(opsManager.ObjectKey())
+	certs.ForgetCertificateExpiries(opsManager.Namespace, opsManager.GetKind(), opsManager.Name)
 
 	log.Info("Cleaned up Ops Manager related resources.")
 }
 
 func (r *OpsManagerReconciler) createNewAppDBReconciler(ctx context.Context, opsManager *omv1.MongoDBOpsManager, log *zap.SugaredLogger) (*ReconcileAppDbReplicaSet, error) {
-	return NewAppDBReplicaSetReconciler(ctx, r.imageUrls, r.initDatabaseVersion, opsManager, r.ReconcileCommonController, r.omConnectionFactory, r.memberClustersMap, r.defaultArchitecture, log)
+	return NewAppDBReplicaSetReconciler(ctx, r.imageUrls, r.initDatabaseVersion, opsManager, r.ReconcileCommonController, r.omConnectionFactory, r.getMemberClustersMap(), r.defaultArchitecture, log)
 }
 
 // getAnnotationsForOpsManagerResource returns all the annotations that should be applied to the resource
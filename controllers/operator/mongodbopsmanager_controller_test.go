@@ -10,24 +10,31 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/api"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/agents"
 	operatorConstruct "github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
@@ -554,6 +561,59 @@ func TestOpsManagerPodTemplateSpec_IsAnnotatedWithHash(t *testing.T) {
 		"Changing version should not change connection string and so the hash should stay the same")
 }
 
+func TestOpsManagerReconcile_PodDisruptionBudgetsAndAutoscaling(t *testing.T) {
+	ctx := context.Background()
+	testOm := DefaultOpsManagerBuilder().
+		AddOplogStoreConfig("oplog-store-2", "my-user", types.NamespacedName{Name: "config-0-mdb", Namespace: mock.TestNamespace}).
+		AddBlockStoreConfig("block-store-config-0", "my-user", types.NamespacedName{Name: "config-0-mdb", Namespace: mock.TestNamespace}).
+		SetAutoscaling(&omv1.OpsManagerAutoscaling{MaxReplicas: 5}).
+		Build()
+
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory, architectures.NonStatic)
+	configureBackupResources(ctx, client, testOm)
+
+	checkOMReconciliationSuccessful(ctx, t, reconciler, testOm, reconciler.client)
+
+	for _, stsName := range []string{testOm.Name, testOm.BackupDaemonStatefulSetName(), testOm.Spec.AppDB.Name()} {
+		pdb := policyv1.PodDisruptionBudget{}
+		require.NoError(t, client.Get(ctx, kube.ObjectKey(testOm.Namespace, stsName), &pdb), "PodDisruptionBudget for %s should exist", stsName)
+		assert.Equal(t, ptr.To(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable)
+	}
+
+	hpa := autoscalingv2.HorizontalPodAutoscaler{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name), &hpa))
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+
+	// the HorizontalPodAutoscaler scales Ops Manager up, the Operator must not revert it
+	sts, err := client.GetStatefulSet(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name))
+	require.NoError(t, err)
+	sts.Spec.Replicas = ptr.To[int32](4)
+	_, err = client.UpdateStatefulSet(ctx, sts)
+	require.NoError(t, err)
+
+	res, err := reconciler.Reconcile(ctx, requestFromObject(testOm))
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: util.TWENTY_FOUR_HOURS}, res)
+
+	sts, err = client.GetStatefulSet(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name))
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), *sts.Spec.Replicas)
+
+	// without autoscaling the number of replicas from the spec is used again
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name), testOm))
+	testOm.Spec.Autoscaling = nil
+	require.NoError(t, client.Update(ctx, testOm))
+
+	_, err = reconciler.Reconcile(ctx, requestFromObject(testOm))
+	require.NoError(t, err)
+
+	sts, err = client.GetStatefulSet(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *sts.Spec.Replicas)
+	assert.True(t, apiErrors.IsNotFound(client.Get(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name), &autoscalingv2.HorizontalPodAutoscaler{})))
+}
+
 func TestOpsManagerReconcileContainerImages(t *testing.T) {
 	initOpsManagerRelatedImageEnv := fmt.Sprintf("RELATED_IMAGE_%s_1_2_3", util.InitOpsManagerImageUrl)
 	opsManagerRelatedImageEnv := fmt.Sprintf("RELATED_IMAGE_%s_8_0_0", util.OpsManagerImageUrl)
@@ -820,6 +880,91 @@ func TestOpsManagerBackupAssignmentLabels(t *testing.T) {
 	assert.Equal(t, assignmentLabels, daemonConfigs[0].Labels)
 }
 
+func TestOpsManagerBackupOplogStoreInAnotherNamespace_RequiresReferenceGrant(t *testing.T) {
+	ctx := context.Background()
+	testOm := DefaultOpsManagerBuilder().
+		AddOplogStoreConfig("oplog-store", "my-user", types.NamespacedName{Name: "oplog-mdb", Namespace: "backup"}).
+		Build()
+
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory, architectures.NonStatic)
+	// the oplog store, its user and the Secret with the password of the user are all in the "backup" namespace
+	backupResources := testOm.DeepCopy()
+	backupResources.Namespace = "backup"
+	configureBackupResources(ctx, client, backupResources)
+
+	assertMessage := func(expected string) {
+		_, reconcileStatus := reconciler.buildOMDatastoreConfig(ctx, testOm, testOm.Spec.Backup.OplogStoreConfigs[0])
+		assert.Equal(t, status.PhaseFailed, reconcileStatus.Phase())
+		option, exists := status.GetOption(reconcileStatus.StatusOptions(), status.MessageOption{})
+		require.True(t, exists)
+		assert.Contains(t, option.(status.MessageOption).Message, expected)
+	}
+	assertMessage("MongoDBOpsManager my-namespace/test-om can't reference MongoDB backup/oplog-mdb")
+
+	grant := &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops-manager", Namespace: "backup"},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBOpsManager, Namespace: testOm.Namespace}},
+			To: []referencegrant.ReferenceGrantTo{
+				{Kind: referencegrant.KindMongoDB, Name: "oplog-mdb"},
+				{Kind: referencegrant.KindMongoDBUser, Name: "my-user"},
+			},
+		},
+	}
+	require.NoError(t, client.Create(ctx, grant))
+	assertMessage("MongoDBOpsManager my-namespace/test-om can't reference Secret backup/password-secret")
+
+	grant.Spec.To = append(grant.Spec.To, referencegrant.ReferenceGrantTo{Kind: referencegrant.KindSecret, Name: "password-secret"})
+	require.NoError(t, client.Update(ctx, grant))
+	config, reconcileStatus := reconciler.buildOMDatastoreConfig(ctx, testOm, testOm.Spec.Backup.OplogStoreConfigs[0])
+	require.True(t, reconcileStatus.IsOK())
+	assert.Contains(t, config.Uri, "oplog-mdb-0.oplog-mdb-svc.backup.svc.cluster.local")
+}
+
+func TestOpsManagerBackupOplogStore_RemovedWhenReferenceGrantIsRevoked(t *testing.T) {
+	ctx := context.Background()
+	testOm := DefaultOpsManagerBuilder().
+		AddOplogStoreConfig("oplog-store", "my-user", types.NamespacedName{Name: "oplog-mdb", Namespace: "backup"}).
+		Build()
+
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory, architectures.NonStatic)
+	backupResources := testOm.DeepCopy()
+	backupResources.Namespace = "backup"
+	configureBackupResources(ctx, client, backupResources)
+
+	grant := &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops-manager", Namespace: "backup"},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBOpsManager, Namespace: testOm.Namespace}},
+			To: []referencegrant.ReferenceGrantTo{
+				{Kind: referencegrant.KindMongoDB, Name: "oplog-mdb"},
+				{Kind: referencegrant.KindMongoDBUser, Name: "my-user"},
+				{Kind: referencegrant.KindSecret, Name: "password-secret"},
+			},
+		},
+	}
+	require.NoError(t, client.Create(ctx, grant))
+
+	mockedAdmin := api.NewMockedAdminProvider("testUrl", "publicApiKey", "privateApiKey", true)
+	defer mockedAdmin.(*api.MockedOmAdmin).Reset()
+
+	require.True(t, reconciler.ensureOplogStoresInOpsManager(ctx, testOm, mockedAdmin, zap.S()).IsOK())
+	oplogConfigs, _ := mockedAdmin.ReadOplogStoreConfigs()
+	require.Len(t, oplogConfigs, 1)
+
+	require.NoError(t, client.Delete(ctx, grant))
+	reconcileStatus := reconciler.ensureOplogStoresInOpsManager(ctx, testOm, mockedAdmin, zap.S())
+
+	assert.Equal(t, status.PhaseFailed, reconcileStatus.Phase())
+	option, exists := status.GetOption(reconcileStatus.StatusOptions(), status.MessageOption{})
+	require.True(t, exists)
+	assert.Contains(t, option.(status.MessageOption).Message, "MongoDBOpsManager my-namespace/test-om can't reference MongoDB backup/oplog-mdb")
+	oplogConfigs, _ = mockedAdmin.ReadOplogStoreConfigs()
+	assert.Empty(t, oplogConfigs)
+}
+
 func TestOpsManagerBackupObjectLock(t *testing.T) {
 	ctx := context.Background()
 
@@ -872,6 +1017,110 @@ func TestOpsManagerBackupObjectLockNotSentWhenUnset(t *testing.T) {
 	assert.Nil(t, s3Configs[0].ObjectLockEnabled)
 }
 
+func TestOpsManagerBackupGCSAndAzureBlobStores(t *testing.T) {
+	ctx := context.Background()
+
+	testOm := DefaultOpsManagerBuilder().
+		SetVersion("8.0.0").
+		AddOplogStoreConfig("oplog-store-2", "my-user", types.NamespacedName{Name: "config-0-mdb", Namespace: mock.TestNamespace}).
+		AddGCSSnapshotStore(omv1.GCSConfig{Name: "gcs-config", BucketName: "gcs-bucket", Endpoint: "http://fake-gcs-server:4443", GCSSecretRef: &omv1.SecretRef{Name: "gcs-secret"}}).
+		AddGCSSnapshotStore(omv1.GCSConfig{Name: "gcs-config-wi", BucketName: "gcs-bucket-wi", WorkloadIdentityEnabled: true}).
+		AddAzureBlobSnapshotStore(omv1.AzureBlobConfig{Name: "azure-config", StorageAccountName: "devstoreaccount1", ContainerName: "snapshots", Endpoint: "http://azurite:10000/devstoreaccount1", AzureSecretRef: &omv1.SecretRef{Name: "azure-secret"}}).
+		Build()
+
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory, architectures.NonStatic)
+	configureBackupResources(ctx, client, testOm)
+
+	mockedAdmin := api.NewMockedAdminProvider("testUrl", "publicApiKey", "privateApiKey", true)
+	defer mockedAdmin.(*api.MockedOmAdmin).Reset()
+
+	reconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, reconciler, testOm, nil, zap.S())
+	require.NoError(t, err)
+
+	// when
+	reconciler.prepareBackupInOpsManager(ctx, reconcilerHelper, testOm, mockedAdmin, "appdb-connection-string", zap.S())
+	gcsConfigs, _ := mockedAdmin.ReadGCSConfigs()
+	azureConfigs, _ := mockedAdmin.ReadAzureBlobConfigs()
+
+	// then
+	require.Len(t, gcsConfigs, 2)
+	assert.Equal(t, "gcs-config", gcsConfigs[0].Id)
+	assert.Equal(t, "http://fake-gcs-server:4443", gcsConfigs[0].Endpoint)
+	assert.Equal(t, string(backup.GCSServiceAccountKey), gcsConfigs[0].AuthMethod)
+	assert.Equal(t, "gcsServiceAccountKey", gcsConfigs[0].ServiceAccountKey)
+	assert.Equal(t, "appdb-connection-string", gcsConfigs[0].Uri)
+	assert.Equal(t, "gcs-config-wi", gcsConfigs[1].Id)
+	assert.Equal(t, string(backup.GCSWorkloadIdentity), gcsConfigs[1].AuthMethod)
+	assert.Empty(t, gcsConfigs[1].ServiceAccountKey)
+
+	require.Len(t, azureConfigs, 1)
+	assert.Equal(t, "azure-config", azureConfigs[0].Id)
+	assert.Equal(t, "devstoreaccount1", azureConfigs[0].StorageAccountName)
+	assert.Equal(t, "snapshots", azureConfigs[0].ContainerName)
+	assert.Equal(t, string(backup.AzureSharedKey), azureConfigs[0].AuthMethod)
+	assert.Equal(t, "azureAccountKey", azureConfigs[0].AccountKey)
+
+	// removing the stores from the spec removes them from Ops Manager
+	testOm.Spec.Backup.GCSConfigs = testOm.Spec.Backup.GCSConfigs[:1]
+	testOm.Spec.Backup.AzureBlobConfigs = nil
+	reconciler.prepareBackupInOpsManager(ctx, reconcilerHelper, testOm, mockedAdmin, "appdb-connection-string", zap.S())
+	gcsConfigs, _ = mockedAdmin.ReadGCSConfigs()
+	azureConfigs, _ = mockedAdmin.ReadAzureBlobConfigs()
+
+	require.Len(t, gcsConfigs, 1)
+	assert.Equal(t, "gcs-config", gcsConfigs[0].Id)
+	assert.Empty(t, azureConfigs)
+}
+
+// blobStoresUnsupportedAdmin is an Ops Manager admin API of a version which doesn't support the GCS and Azure Blob
+// snapshot stores.
+type blobStoresUnsupportedAdmin struct {
+	*api.MockedOmAdmin
+}
+
+func (a blobStoresUnsupportedAdmin) ReadGCSConfigs() ([]backup.GCSConfig, error) {
+	return nil, xerrors.New("Status: 404 (Not Found)")
+}
+
+func (a blobStoresUnsupportedAdmin) ReadAzureBlobConfigs() ([]backup.AzureBlobConfig, error) {
+	return nil, xerrors.New("Status: 404 (Not Found)")
+}
+
+func TestOpsManagerBackupBlobStores_OlderOpsManager(t *testing.T) {
+	ctx := context.Background()
+
+	testOm := DefaultOpsManagerBuilder().
+		SetVersion("7.0.12").
+		AddS3Config("s3-config", "s3-secret").
+		AddOplogStoreConfig("oplog-store-2", "my-user", types.NamespacedName{Name: "config-0-mdb", Namespace: mock.TestNamespace}).
+		Build()
+
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory, architectures.NonStatic)
+	configureBackupResources(ctx, client, testOm)
+
+	mockedAdmin := api.NewMockedAdminProvider("testUrl", "publicApiKey", "privateApiKey", true)
+	defer mockedAdmin.(*api.MockedOmAdmin).Reset()
+	omAdmin := blobStoresUnsupportedAdmin{MockedOmAdmin: mockedAdmin.(*api.MockedOmAdmin)}
+
+	reconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, reconciler, testOm, nil, zap.S())
+	require.NoError(t, err)
+
+	t.Run("The blob stores are not read if they are not supported", func(t *testing.T) {
+		reconcileStatus := reconciler.prepareBackupInOpsManager(ctx, reconcilerHelper, testOm, omAdmin, "appdb-connection-string", zap.S())
+		assert.True(t, reconcileStatus.IsOK())
+	})
+	t.Run("Configuring a blob store is invalid", func(t *testing.T) {
+		testOm.Spec.Backup.GCSConfigs = []omv1.GCSConfig{{Name: "gcs-config", BucketName: "gcs-bucket", WorkloadIdentityEnabled: true}}
+		reconcileStatus := reconciler.prepareBackupInOpsManager(ctx, reconcilerHelper, testOm, omAdmin, "appdb-connection-string", zap.S())
+		assert.Equal(t, status.PhaseFailed, reconcileStatus.Phase())
+		option, exists := status.GetOption(reconcileStatus.StatusOptions(), status.MessageOption{})
+		require.True(t, exists)
+		assert.Contains(t, option.(status.MessageOption).Message, "GCS snapshot stores require Ops Manager 8.0.0 or later")
+	})
+}
+
 func TestTriggerOmChangedEventIfNeeded(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Om changed event got triggered, major version update", func(t *testing.T) {
@@ -1226,6 +1475,32 @@ func configureBackupResources(ctx context.Context, m kubernetesClient.Client, te
 		_ = m.CreateSecret(ctx, s3Creds)
 	}
 
+	for _, gcsConfig := range testOm.Spec.Backup.GCSConfigs {
+		if gcsConfig.GCSSecretRef == nil {
+			continue
+		}
+
+		gcsCreds := secret.Builder().
+			SetName(gcsConfig.GCSSecretRef.Name).
+			SetNamespace(testOm.Namespace).
+			SetField(util.GCSServiceAccountKey, "gcsServiceAccountKey").
+			Build()
+		_ = m.CreateSecret(ctx, gcsCreds)
+	}
+
+	for _, azureConfig := range testOm.Spec.Backup.AzureBlobConfigs {
+		if azureConfig.AzureSecretRef == nil {
+			continue
+		}
+
+		azureCreds := secret.Builder().
+			SetName(azureConfig.AzureSecretRef.Name).
+			SetNamespace(testOm.Namespace).
+			SetField(util.AzureAccountKey, "azureAccountKey").
+			Build()
+		_ = m.CreateSecret(ctx, azureCreds)
+	}
+
 	// create MDB resource for oplog configs
 	for _, oplogConfig := range append(testOm.Spec.Backup.OplogStoreConfigs, testOm.Spec.Backup.BlockStoreConfigs...) {
 		oplogStoreResource := mdbv1.NewReplicaSetBuilder().
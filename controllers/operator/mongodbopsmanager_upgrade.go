@@ -0,0 +1,280 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
+)
+
+// minimumAppDBVersionByOpsManagerMajor contains the oldest MongoDB version (and feature compatibility version) supported
+// as the Application Database by each major version of Ops Manager.
+var minimumAppDBVersionByOpsManagerMajor = map[uint64]semver.Version{
+	6: {Major: 4, Minor: 2},
+	7: {Major: 4, Minor: 4},
+	8: {Major: 6, Minor: 0},
+}
+
+// newOpsManagerUpgrade returns the record of the Ops Manager upgrade in progress, or nil if Ops Manager is not being
+// upgraded. A new record is created in the Ops Manager status when 'spec.version' differs from the version
+// Ops Manager is running. The record is kept in the status until the next upgrade so the users can see how the
+// latest upgrade went. The AppDB is upgraded last unless 'spec.upgrade.appDBFirst' is set.
+func newOpsManagerUpgrade(opsManager *omv1.MongoDBOpsManager) *omv1.UpgradeStatus {
+	omStatus := &opsManager.Status.OpsManagerStatus
+	current := omStatus.Upgrade
+
+	if current != nil && current.ToVersion == opsManager.Spec.Version {
+		if current.IsFinished() {
+			return nil
+		}
+		return current
+	}
+
+	if omStatus.Version == "" || omStatus.Version == opsManager.Spec.Version {
+		// the previous upgrade was abandoned (spec.version was reverted), so the record is obsolete
+		omStatus.Upgrade = nil
+		return nil
+	}
+
+	upgrade := &omv1.UpgradeStatus{
+		FromVersion: omStatus.Version,
+		ToVersion:   opsManager.Spec.Version,
+	}
+	upgradeAppDB := opsManager.Status.AppDbStatus.Version != "" && opsManager.Status.AppDbStatus.Version != opsManager.Spec.AppDB.GetMongoDBVersion()
+	steps := []omv1.UpgradeStepName{omv1.UpgradeStepPreflightChecks}
+	if upgradeAppDB && opsManager.Spec.IsAppDBUpgradedFirst() {
+		steps = append(steps, omv1.UpgradeStepAppDB)
+	}
+	steps = append(steps, omv1.UpgradeStepOpsManager)
+	if opsManager.Spec.Backup != nil && opsManager.Spec.Backup.Enabled {
+		steps = append(steps, omv1.UpgradeStepBackupDaemon)
+	}
+	if upgradeAppDB && !opsManager.Spec.IsAppDBUpgradedFirst() {
+		steps = append(steps, omv1.UpgradeStepAppDB)
+	}
+	for _, name := range steps {
+		upgrade.Steps = append(upgrade.Steps, omv1.UpgradeStep{Name: name, State: omv1.UpgradeStepPending})
+	}
+
+	omStatus.Upgrade = upgrade
+	return upgrade
+}
+
+// setUpgradeStepState changes the state of the upgrade step. Returns false if the state hasn't changed or the upgrade
+// doesn't include the step.
+func setUpgradeStepState(upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName, state omv1.UpgradeStepState, message string) bool {
+	if upgrade == nil {
+		return false
+	}
+	step := upgrade.Step(name)
+	if step == nil || (step.State == state && step.Message == message) {
+		return false
+	}
+	step.State = state
+	step.Message = message
+	step.LastTransitionTime = time.Now().UTC().Format(time.RFC3339)
+	return true
+}
+
+// isUpgradeStepCompleted returns true if there's no upgrade in progress or the given step has been completed.
+func isUpgradeStepCompleted(upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName) bool {
+	if upgrade == nil {
+		return true
+	}
+	step := upgrade.Step(name)
+	return step == nil || step.State == omv1.UpgradeStepCompleted
+}
+
+// isUpgradeStepWaiting returns true if the step of the upgrade in progress can't start yet, because one of the
+// previous steps hasn't been completed.
+func isUpgradeStepWaiting(upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName) bool {
+	if upgrade == nil || upgrade.Step(name) == nil {
+		return false
+	}
+	for _, step := range upgrade.Steps {
+		if step.Name == name {
+			return false
+		}
+		if step.State != omv1.UpgradeStepCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+// isUpgradeStepPending returns true if the step can start but hasn't been started yet.
+func isUpgradeStepPending(upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName) bool {
+	if upgrade == nil || isUpgradeStepWaiting(upgrade, name) {
+		return false
+	}
+	step := upgrade.Step(name)
+	return step != nil && step.State == omv1.UpgradeStepPending
+}
+
+// completeUpgradeStep marks the step in progress as completed. Returns false if the step hasn't been started.
+func completeUpgradeStep(upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName, log *zap.SugaredLogger) bool {
+	if upgrade == nil || upgrade.Step(name) == nil || upgrade.Step(name).State != omv1.UpgradeStepInProgress {
+		return false
+	}
+	setUpgradeStepState(upgrade, name, omv1.UpgradeStepCompleted, "")
+	if upgrade.IsFinished() {
+		log.Infof("Ops Manager upgrade from %s to %s has finished", upgrade.FromVersion, upgrade.ToVersion)
+	}
+	return true
+}
+
+// failUpgradeStep marks the step in progress as failed if the component failed, the upgrade stops there and the step
+// is retried on the next reconciliation. Returns false if the step hasn't been started or the component hasn't failed.
+func failUpgradeStep(upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName, status workflow.Status) bool {
+	if upgrade == nil || status.Phase() != mdbstatus.PhaseFailed {
+		return false
+	}
+	message := ""
+	if option, exists := mdbstatus.GetOption(status.StatusOptions(), mdbstatus.MessageOption{}); exists {
+		message = option.(mdbstatus.MessageOption).Message
+	}
+	return failUpgradeStepWithMessage(upgrade, name, message)
+}
+
+func failUpgradeStepWithMessage(upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName, message string) bool {
+	if upgrade == nil || upgrade.Step(name) == nil || upgrade.Step(name).State != omv1.UpgradeStepInProgress {
+		return false
+	}
+	return setUpgradeStepState(upgrade, name, omv1.UpgradeStepFailed, message)
+}
+
+// startUpgradeStep marks the step as in progress and records it in the Ops Manager status. A failed step is started
+// again. The step is not started while the previous steps haven't been completed.
+func (r *OpsManagerReconciler) startUpgradeStep(ctx context.Context, opsManager *omv1.MongoDBOpsManager, upgrade *omv1.UpgradeStatus, name omv1.UpgradeStepName, log *zap.SugaredLogger) error {
+	if isUpgradeStepCompleted(upgrade, name) || isUpgradeStepWaiting(upgrade, name) || !setUpgradeStepState(upgrade, name, omv1.UpgradeStepInProgress, "") {
+		return nil
+	}
+	log.Infof("Ops Manager upgrade from %s to %s: starting step %s", upgrade.FromVersion, upgrade.ToVersion, name)
+	_, err := r.updateStatus(ctx, opsManager, workflow.Pending("Upgrading Ops Manager from %s to %s: %s", upgrade.FromVersion, upgrade.ToVersion, name), log, mdbstatus.NewOMPartOption(mdbstatus.OpsManager))
+	return err
+}
+
+// ensureUpgradePreflightChecks verifies that Ops Manager can be upgraded to 'spec.version' before any of the
+// components is changed. If any of the checks fails the upgrade is stopped and nothing is rolled out.
+func (r *OpsManagerReconciler) ensureUpgradePreflightChecks(opsManager *omv1.MongoDBOpsManager, upgrade *omv1.UpgradeStatus, log *zap.SugaredLogger) workflow.Status {
+	if isUpgradeStepCompleted(upgrade, omv1.UpgradeStepPreflightChecks) {
+		return workflow.OK()
+	}
+
+	if err := r.runUpgradePreflightChecks(opsManager, upgrade, log); err != nil {
+		setUpgradeStepState(upgrade, omv1.UpgradeStepPreflightChecks, omv1.UpgradeStepFailed, err.Error())
+		return workflow.Failed(xerrors.Errorf("Ops Manager upgrade from %s to %s failed the pre-flight checks: %w", upgrade.FromVersion, upgrade.ToVersion, err))
+	}
+
+	log.Infof("Ops Manager upgrade from %s to %s passed the pre-flight checks", upgrade.FromVersion, upgrade.ToVersion)
+	setUpgradeStepState(upgrade, omv1.UpgradeStepPreflightChecks, omv1.UpgradeStepCompleted, "")
+	return workflow.OK()
+}
+
+func (r *OpsManagerReconciler) runUpgradePreflightChecks(opsManager *omv1.MongoDBOpsManager, upgrade *omv1.UpgradeStatus, log *zap.SugaredLogger) error {
+	from, err := versionutil.StringToSemverVersion(upgrade.FromVersion)
+	if err != nil {
+		return err
+	}
+	to, err := versionutil.StringToSemverVersion(upgrade.ToVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := validateOpsManagerUpgradePath(from, to); err != nil {
+		return err
+	}
+
+	if err := validateAppDBCompatibility(to, opsManager.Spec.AppDB.GetMongoDBVersion(), opsManager.CalculateFeatureCompatibilityVersion()); err != nil {
+		return err
+	}
+	// Ops Manager runs with the current AppDB until the AppDB is upgraded after it
+	appDBStatus := opsManager.Status.AppDbStatus
+	if !opsManager.Spec.IsAppDBUpgradedFirst() && appDBStatus.Version != "" {
+		if err := validateAppDBCompatibility(to, appDBStatus.Version, appDBStatus.FeatureCompatibilityVersion); err != nil {
+			return err
+		}
+	}
+
+	// In static architecture the AppDB agent version is read from the version mapping, it must be known for the
+	// target Ops Manager version
+	podSpec := opsManager.Spec.AppDB.PodSpec
+	if architectures.IsRunningStaticArchitecture(opsManager.Annotations, r.defaultArchitecture) && (podSpec == nil || !podSpec.IsAgentImageOverridden()) {
+		agentVersion, err := r.getAgentVersion(nil, upgrade.ToVersion, true, log)
+		if err != nil {
+			return err
+		}
+		if err := validateAgentVersion(to, agentVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateOpsManagerUpgradePath checks that Ops Manager is not downgraded and that no major version is skipped.
+func validateOpsManagerUpgradePath(from, to semver.Version) error {
+	if to.LT(from) {
+		return xerrors.Errorf("downgrading Ops Manager from %s to %s is not supported", from, to)
+	}
+	if to.Major > from.Major+1 {
+		return xerrors.Errorf("upgrading Ops Manager from %s to %s skips the major version %d, please upgrade to the latest %d.x version first", from, to, from.Major+1, from.Major+1)
+	}
+	return nil
+}
+
+// validateAppDBCompatibility checks that the Application Database version and feature compatibility version are
+// supported by the target Ops Manager version.
+func validateAppDBCompatibility(omVersion semver.Version, appDBVersion string, appDBFCV string) error {
+	minimum, ok := minimumAppDBVersionByOpsManagerMajor[omVersion.Major]
+	if !ok {
+		return nil
+	}
+	minimumMajorMinor := fmt.Sprintf("%d.%d", minimum.Major, minimum.Minor)
+
+	version, err := semver.Make(appDBVersion)
+	if err != nil {
+		return xerrors.Errorf("failed to parse the Application Database version %s: %w", appDBVersion, err)
+	}
+	if version.Major < minimum.Major || (version.Major == minimum.Major && version.Minor < minimum.Minor) {
+		return xerrors.Errorf("Ops Manager %s requires the Application Database version %s or newer, but spec.applicationDatabase.version is %s", omVersion, minimumMajorMinor, appDBVersion)
+	}
+
+	if appDBFCV != "" {
+		fcv, err := semver.Make(appDBFCV + ".0")
+		if err != nil {
+			return xerrors.Errorf("failed to parse the Application Database feature compatibility version %s: %w", appDBFCV, err)
+		}
+		if fcv.Major < minimum.Major || (fcv.Major == minimum.Major && fcv.Minor < minimum.Minor) {
+			return xerrors.Errorf("Ops Manager %s requires the Application Database feature compatibility version %s or newer, but it is %s", omVersion, minimumMajorMinor, appDBFCV)
+		}
+	}
+	return nil
+}
+
+// validateAgentVersion checks that the agent version is the one released for the Ops Manager major version:
+// 12.0.x for Ops Manager 6 and 10<major>.0.x for later versions.
+func validateAgentVersion(omVersion semver.Version, agentVersion string) error {
+	if agentVersion == "" {
+		return xerrors.Errorf("no agent version is known for Ops Manager %s", omVersion)
+	}
+
+	expectedAgentMajor := fmt.Sprintf("%d.", 100+omVersion.Major)
+	if omVersion.Major == 6 {
+		expectedAgentMajor = "12."
+	}
+	if !strings.HasPrefix(agentVersion, expectedAgentMajor) {
+		return xerrors.Errorf("agent version %s is not compatible with Ops Manager %s", agentVersion, omVersion)
+	}
+	return nil
+}
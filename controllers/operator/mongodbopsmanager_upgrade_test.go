@@ -0,0 +1,229 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
+)
+
+func TestValidateOpsManagerUpgradePath(t *testing.T) {
+	t.Run("Patch and minor upgrades are allowed", func(t *testing.T) {
+		assert.NoError(t, validateOpsManagerUpgradePath(semver.MustParse("7.0.1"), semver.MustParse("7.0.12")))
+		assert.NoError(t, validateOpsManagerUpgradePath(semver.MustParse("6.0.0"), semver.MustParse("6.1.0")))
+	})
+	t.Run("Upgrade to the next major is allowed", func(t *testing.T) {
+		assert.NoError(t, validateOpsManagerUpgradePath(semver.MustParse("7.0.12"), semver.MustParse("8.0.0")))
+	})
+	t.Run("Downgrade is not allowed", func(t *testing.T) {
+		err := validateOpsManagerUpgradePath(semver.MustParse("8.0.0"), semver.MustParse("7.0.12"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "downgrading Ops Manager from 8.0.0 to 7.0.12 is not supported")
+	})
+	t.Run("Skipping a major version is not allowed", func(t *testing.T) {
+		err := validateOpsManagerUpgradePath(semver.MustParse("6.0.20"), semver.MustParse("8.0.0"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "skips the major version 7")
+	})
+}
+
+func TestValidateAppDBCompatibility(t *testing.T) {
+	assert.NoError(t, validateAppDBCompatibility(semver.MustParse("8.0.0"), "6.0.5-ent", "6.0"))
+	assert.NoError(t, validateAppDBCompatibility(semver.MustParse("7.0.0"), "5.0.14-ent", ""))
+	// no requirements are known for this version
+	assert.NoError(t, validateAppDBCompatibility(semver.MustParse("9.0.0"), "4.0.0", "4.0"))
+
+	err := validateAppDBCompatibility(semver.MustParse("8.0.0"), "5.0.14-ent", "5.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires the Application Database version 6.0 or newer")
+
+	err = validateAppDBCompatibility(semver.MustParse("8.0.0"), "7.0.2-ent", "5.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires the Application Database feature compatibility version 6.0 or newer, but it is 5.0")
+}
+
+func TestValidateAgentVersion(t *testing.T) {
+	assert.NoError(t, validateAgentVersion(semver.MustParse("6.0.22"), "12.0.30.7791-1"))
+	assert.NoError(t, validateAgentVersion(semver.MustParse("7.0.4"), "107.0.4.8567-1"))
+	assert.NoError(t, validateAgentVersion(semver.MustParse("8.0.0"), "108.0.0.8694-1"))
+
+	assert.Error(t, validateAgentVersion(semver.MustParse("8.0.0"), ""))
+	assert.Error(t, validateAgentVersion(semver.MustParse("8.0.0"), "107.0.4.8567-1"))
+}
+
+func TestNewOpsManagerUpgrade(t *testing.T) {
+	t.Run("No upgrade on creation", func(t *testing.T) {
+		opsManager := DefaultOpsManagerBuilder().Build()
+		assert.Nil(t, newOpsManagerUpgrade(opsManager))
+		assert.Nil(t, opsManager.Status.OpsManagerStatus.Upgrade)
+	})
+	t.Run("Upgrade includes all the components", func(t *testing.T) {
+		opsManager := DefaultOpsManagerBuilder().SetVersion("8.0.0").SetOMStatusVersion("7.0.12").SetAppDbVersion("6.0.5-ent").SetBackup(omv1.MongoDBOpsManagerBackup{Enabled: true}).Build()
+		opsManager.Status.AppDbStatus.Version = "5.0.14-ent"
+
+		upgrade := newOpsManagerUpgrade(opsManager)
+		require.NotNil(t, upgrade)
+		assert.Equal(t, "7.0.12", upgrade.FromVersion)
+		assert.Equal(t, "8.0.0", upgrade.ToVersion)
+
+		var names []omv1.UpgradeStepName
+		for _, step := range upgrade.Steps {
+			names = append(names, step.Name)
+			assert.Equal(t, omv1.UpgradeStepPending, step.State)
+		}
+		assert.Equal(t, []omv1.UpgradeStepName{omv1.UpgradeStepPreflightChecks, omv1.UpgradeStepOpsManager, omv1.UpgradeStepBackupDaemon, omv1.UpgradeStepAppDB}, names)
+		assert.Same(t, upgrade, opsManager.Status.OpsManagerStatus.Upgrade)
+	})
+	t.Run("AppDB is upgraded first if configured", func(t *testing.T) {
+		opsManager := DefaultOpsManagerBuilder().SetVersion("8.0.0").SetOMStatusVersion("7.0.12").SetAppDbVersion("6.0.5-ent").SetBackup(omv1.MongoDBOpsManagerBackup{Enabled: true}).Build()
+		opsManager.Spec.Upgrade = &omv1.OpsManagerUpgrade{AppDBFirst: true}
+		opsManager.Status.AppDbStatus.Version = "5.0.14-ent"
+
+		upgrade := newOpsManagerUpgrade(opsManager)
+		require.NotNil(t, upgrade)
+		var names []omv1.UpgradeStepName
+		for _, step := range upgrade.Steps {
+			names = append(names, step.Name)
+		}
+		assert.Equal(t, []omv1.UpgradeStepName{omv1.UpgradeStepPreflightChecks, omv1.UpgradeStepAppDB, omv1.UpgradeStepOpsManager, omv1.UpgradeStepBackupDaemon}, names)
+	})
+	t.Run("Upgrade skips unchanged components", func(t *testing.T) {
+		opsManager := DefaultOpsManagerBuilder().SetVersion("7.0.12").SetOMStatusVersion("7.0.1").SetBackup(omv1.MongoDBOpsManagerBackup{Enabled: false}).Build()
+		opsManager.Status.AppDbStatus.Version = opsManager.Spec.AppDB.GetMongoDBVersion()
+
+		upgrade := newOpsManagerUpgrade(opsManager)
+		require.NotNil(t, upgrade)
+		require.Len(t, upgrade.Steps, 2)
+		assert.Nil(t, upgrade.Step(omv1.UpgradeStepAppDB))
+		assert.Nil(t, upgrade.Step(omv1.UpgradeStepBackupDaemon))
+	})
+	t.Run("Upgrade in progress is resumed", func(t *testing.T) {
+		opsManager := DefaultOpsManagerBuilder().SetVersion("7.0.12").SetOMStatusVersion("7.0.1").Build()
+		upgrade := newOpsManagerUpgrade(opsManager)
+		setUpgradeStepState(upgrade, omv1.UpgradeStepPreflightChecks, omv1.UpgradeStepCompleted, "")
+
+		resumed := newOpsManagerUpgrade(opsManager)
+		assert.Same(t, upgrade, resumed)
+		assert.True(t, isUpgradeStepCompleted(resumed, omv1.UpgradeStepPreflightChecks))
+		assert.False(t, isUpgradeStepCompleted(resumed, omv1.UpgradeStepOpsManager))
+	})
+	t.Run("Finished upgrade is kept in the status", func(t *testing.T) {
+		opsManager := DefaultOpsManagerBuilder().SetVersion("7.0.12").SetOMStatusVersion("7.0.1").Build()
+		upgrade := newOpsManagerUpgrade(opsManager)
+		for _, step := range upgrade.Steps {
+			setUpgradeStepState(upgrade, step.Name, omv1.UpgradeStepCompleted, "")
+		}
+		opsManager.Status.OpsManagerStatus.Version = "7.0.12"
+
+		assert.Nil(t, newOpsManagerUpgrade(opsManager))
+		assert.NotNil(t, opsManager.Status.OpsManagerStatus.Upgrade)
+	})
+	t.Run("Abandoned upgrade is removed from the status", func(t *testing.T) {
+		opsManager := DefaultOpsManagerBuilder().SetVersion("8.0.0").SetOMStatusVersion("7.0.12").Build()
+		require.NotNil(t, newOpsManagerUpgrade(opsManager))
+
+		opsManager.Spec.Version = "7.0.12"
+		assert.Nil(t, newOpsManagerUpgrade(opsManager))
+		assert.Nil(t, opsManager.Status.OpsManagerStatus.Upgrade)
+	})
+}
+
+func TestOpsManagerUpgrade_PreflightChecksFail(t *testing.T) {
+	ctx := context.Background()
+	testOm := DefaultOpsManagerBuilder().SetVersion("8.0.0").SetOMStatusVersion("6.0.20").Build()
+
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory, architectures.NonStatic)
+
+	_, err := reconciler.Reconcile(ctx, requestFromObject(testOm))
+	require.NoError(t, err)
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(testOm), testOm))
+
+	assert.Equal(t, status.PhaseFailed, testOm.Status.OpsManagerStatus.Phase)
+	assert.Contains(t, testOm.Status.OpsManagerStatus.Message, "failed the pre-flight checks")
+
+	upgrade := testOm.Status.OpsManagerStatus.Upgrade
+	require.NotNil(t, upgrade)
+	assert.Equal(t, "6.0.20", upgrade.FromVersion)
+	assert.Equal(t, "8.0.0", upgrade.ToVersion)
+	require.NotNil(t, upgrade.Step(omv1.UpgradeStepPreflightChecks))
+	assert.Equal(t, omv1.UpgradeStepFailed, upgrade.Step(omv1.UpgradeStepPreflightChecks).State)
+	assert.Equal(t, omv1.UpgradeStepPending, upgrade.Step(omv1.UpgradeStepOpsManager).State)
+
+	// nothing has been rolled out
+	_, err = client.GetStatefulSet(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name))
+	assert.Error(t, err)
+}
+
+func TestUpgradeSteps(t *testing.T) {
+	log := zap.S()
+	newUpgrade := func() *omv1.UpgradeStatus {
+		opsManager := DefaultOpsManagerBuilder().SetVersion("8.0.0").SetOMStatusVersion("7.0.12").SetAppDbVersion("6.0.5-ent").SetBackup(omv1.MongoDBOpsManagerBackup{Enabled: false}).Build()
+		opsManager.Status.AppDbStatus.Version = "5.0.14-ent"
+		return newOpsManagerUpgrade(opsManager)
+	}
+
+	t.Run("Steps wait for the previous ones", func(t *testing.T) {
+		upgrade := newUpgrade()
+		assert.False(t, isUpgradeStepWaiting(upgrade, omv1.UpgradeStepPreflightChecks))
+		assert.True(t, isUpgradeStepWaiting(upgrade, omv1.UpgradeStepOpsManager))
+		assert.True(t, isUpgradeStepWaiting(upgrade, omv1.UpgradeStepAppDB))
+		assert.False(t, isUpgradeStepPending(upgrade, omv1.UpgradeStepAppDB))
+		// steps which are not part of the upgrade never wait
+		assert.False(t, isUpgradeStepWaiting(upgrade, omv1.UpgradeStepBackupDaemon))
+		assert.False(t, isUpgradeStepWaiting(nil, omv1.UpgradeStepAppDB))
+
+		setUpgradeStepState(upgrade, omv1.UpgradeStepPreflightChecks, omv1.UpgradeStepCompleted, "")
+		setUpgradeStepState(upgrade, omv1.UpgradeStepOpsManager, omv1.UpgradeStepCompleted, "")
+		assert.False(t, isUpgradeStepWaiting(upgrade, omv1.UpgradeStepAppDB))
+		assert.True(t, isUpgradeStepPending(upgrade, omv1.UpgradeStepAppDB))
+	})
+	t.Run("Only started steps are completed", func(t *testing.T) {
+		upgrade := newUpgrade()
+		assert.False(t, completeUpgradeStep(upgrade, omv1.UpgradeStepAppDB, log))
+		assert.Equal(t, omv1.UpgradeStepPending, upgrade.Step(omv1.UpgradeStepAppDB).State)
+
+		setUpgradeStepState(upgrade, omv1.UpgradeStepAppDB, omv1.UpgradeStepInProgress, "")
+		assert.True(t, completeUpgradeStep(upgrade, omv1.UpgradeStepAppDB, log))
+		assert.Equal(t, omv1.UpgradeStepCompleted, upgrade.Step(omv1.UpgradeStepAppDB).State)
+	})
+	t.Run("Started steps fail with the component", func(t *testing.T) {
+		upgrade := newUpgrade()
+		assert.False(t, failUpgradeStep(upgrade, omv1.UpgradeStepOpsManager, workflow.Failed(xerrors.New("error"))))
+		assert.Equal(t, omv1.UpgradeStepPending, upgrade.Step(omv1.UpgradeStepOpsManager).State)
+
+		setUpgradeStepState(upgrade, omv1.UpgradeStepOpsManager, omv1.UpgradeStepInProgress, "")
+		assert.False(t, failUpgradeStep(upgrade, omv1.UpgradeStepOpsManager, workflow.Pending("waiting")))
+		assert.True(t, failUpgradeStep(upgrade, omv1.UpgradeStepOpsManager, workflow.Failed(xerrors.New("Ops Manager pods are not ready"))))
+		step := upgrade.Step(omv1.UpgradeStepOpsManager)
+		assert.Equal(t, omv1.UpgradeStepFailed, step.State)
+		assert.Equal(t, "Ops Manager pods are not ready", step.Message)
+	})
+}
+
+func TestAppDBMongoDBVersion_HeldBackDuringOpsManagerUpgrade(t *testing.T) {
+	opsManager := DefaultOpsManagerBuilder().SetAppDbVersion("6.0.5-ent").Build()
+	r := &ReconcileAppDbReplicaSet{helper: &AppDBReconcilerHelper{deploymentState: &AppDBDeploymentState{LastAppliedMongoDBVersion: "5.0.14-ent"}}}
+
+	assert.Equal(t, "6.0.5-ent", r.mongoDBVersion(opsManager))
+	assert.True(t, r.isChangingVersion(opsManager))
+
+	r.holdBackVersionChange = true
+	assert.Equal(t, "5.0.14-ent", r.mongoDBVersion(opsManager))
+	assert.False(t, r.isChangingVersion(opsManager))
+
+	// nothing to hold back on creation
+	r.helper.deploymentState.LastAppliedMongoDBVersion = ""
+	assert.Equal(t, "6.0.5-ent", r.mongoDBVersion(opsManager))
+}
@@ -3,12 +3,13 @@ package operator
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	rolev1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/role"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
 	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
@@ -37,6 +39,8 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connection"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct/scalers"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct/scalers/interfaces"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
 	enterprisepem "github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
@@ -50,6 +54,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/annotations"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -96,6 +101,8 @@ type ReplicaSetReconcilerHelper struct {
 	reconciler             *ReconcileMongoDbReplicaSet
 	log                    *zap.SugaredLogger
 	automationAgentVersion string
+	// zoneScalers scale the StatefulSets of the zones if the members are placed in zones.
+	zoneScalers []*scalers.MultiClusterReplicaSetScaler
 }
 
 func (r *ReconcileMongoDbReplicaSet) newReconcilerHelper(
@@ -168,6 +175,7 @@ func (r *ReplicaSetReconcilerHelper) initialize(ctx context.Context) error {
 		return xerrors.Errorf("failed to initialize replica set state: %w", err)
 	}
 	r.deploymentState = state
+	r.zoneScalers = scalers.GetReplicaSetZoneScalers(r.resource)
 	return nil
 }
 
@@ -178,6 +186,16 @@ func (r *ReplicaSetReconcilerHelper) updateStatus(ctx context.Context, status wo
 	return r.reconciler.updateStatus(ctx, r.resource, status, r.log, statusOptions...)
 }
 
+// mongodbImageVersion returns the version of the MongoDB image, which is held back while the maintenance window is
+// closed.
+func (r *ReplicaSetReconcilerHelper) mongodbImageVersion() string {
+	lastAchievedVersion := ""
+	if r.deploymentState.LastAchievedSpec != nil {
+		lastAchievedVersion = r.deploymentState.LastAchievedSpec.Version
+	}
+	return mongodbImageVersion(r.resource.Spec.MaintenanceWindow, r.resource.Spec.Version, lastAchievedVersion)
+}
+
 // Reconcile performs the full reconciliation logic for a replica set.
 // This is the main entry point for all reconciliation work and contains all
 // state and logic specific to a single reconcile execution.
@@ -214,6 +232,7 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 	}
 
 	reconciler.SetupCommonWatchers(rs, nil, nil, rs.Name)
+	reconciler.checkCertificatesExpiry(ctx, rs, nil, nil, rs.Name, log)
 
 	reconcileResult := checkIfHasExcessProcesses(conn, rs.Name, log)
 	if !reconcileResult.IsOK() {
@@ -249,10 +268,7 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 	// === 2. Auth and Certificates
 	// Get certificate paths for later use
 	rsCertsConfig := certs.ReplicaSetConfig(*rs)
-	var databaseSecretPath string
-	if reconciler.VaultClient != nil {
-		databaseSecretPath = reconciler.VaultClient.DatabaseSecretPath()
-	}
+	databaseSecretPath := reconciler.DatabaseSecretPath()
 	tlsCertHash := enterprisepem.ReadHashFromSecret(ctx, reconciler.SecretClient, rs.Namespace, rsCertsConfig.CertSecretName, databaseSecretPath, log)
 	internalClusterCertHash := enterprisepem.ReadHashFromSecret(ctx, reconciler.SecretClient, rs.Namespace, rsCertsConfig.InternalClusterSecretName, databaseSecretPath, log)
 
@@ -299,7 +315,7 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 	// See CLOUDP-189433 and CLOUDP-229222 for more details.
 	if recovery.ShouldTriggerRecovery(rs.Status.Phase != mdbstatus.PhaseRunning, rs.Status.LastTransition) {
 		log.Warnf("Triggering Automatic Recovery. The MongoDB resource %s/%s is in %s state since %s", rs.Namespace, rs.Name, rs.Status.Phase, rs.Status.LastTransition)
-		automationConfigStatus := r.updateOmDeploymentRs(ctx, conn, r.deploymentState.LastReconcileMemberCount, tlsCertPath, internalClusterCertPath, deploymentOpts, shouldMirrorKeyfileForMongot, true).OnErrorPrepend("failed to create/update (Ops Manager reconciliation phase):")
+		automationConfigStatus := r.updateOmDeploymentRs(ctx, conn, r.deploymentState.LastReconcileMemberCount, tlsCertPath, internalClusterCertPath, deploymentOpts, newMaintenanceWindowGuard(rs.Spec.MaintenanceWindow, time.Now()), shouldMirrorKeyfileForMongot, true).OnErrorPrepend("failed to create/update (Ops Manager reconciliation phase):")
 		reconcileStatus := r.reconcileMemberResources(ctx, conn, projectConfig, deploymentOpts, r.deploymentState.LastConfiguredRoles)
 		if !reconcileStatus.IsOK() {
 			log.Errorf("Recovery failed because of reconcile errors, %v", reconcileStatus)
@@ -310,22 +326,25 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 	}
 
 	// 5. Actual reconciliation execution, Ops Manager and kubernetes resources update
-	publishAutomationConfigFirst := publishAutomationConfigFirst(ctx, reconciler.client, *rs, r.deploymentState.LastAchievedSpec, r.buildStatefulSetOptions(ctx, conn, projectConfig, deploymentOpts), reconciler.defaultArchitecture, log)
+	publishAutomationConfigFirst := r.shouldPublishAutomationConfigFirst(ctx, r.buildStatefulSetOptions(ctx, conn, projectConfig, deploymentOpts))
+	maintenanceWindow := newMaintenanceWindowGuard(rs.Spec.MaintenanceWindow, time.Now())
 	status := workflow.RunInGivenOrder(publishAutomationConfigFirst,
 		func() workflow.Status {
-			return r.updateOmDeploymentRs(ctx, conn, r.deploymentState.LastReconcileMemberCount, tlsCertPath, internalClusterCertPath, deploymentOpts, shouldMirrorKeyfileForMongot, false).OnErrorPrepend("failed to create/update (Ops Manager reconciliation phase):")
+			return r.updateOmDeploymentRs(ctx, conn, r.deploymentState.LastReconcileMemberCount, tlsCertPath, internalClusterCertPath, deploymentOpts, maintenanceWindow, shouldMirrorKeyfileForMongot, false).OnErrorPrepend("failed to create/update (Ops Manager reconciliation phase):")
 		},
 		func() workflow.Status {
 			return r.reconcileMemberResources(ctx, conn, projectConfig, deploymentOpts, r.deploymentState.LastConfiguredRoles)
 		})
+	// the deferred changes don't stop the member resources from being reconciled, they only keep the resource pending
+	status = status.Merge(maintenanceWindow.status())
 
 	if !status.IsOK() {
 		return r.updateStatus(ctx, status)
 	}
 
 	// === 6. Final steps
-	if scale.IsStillScaling(rs) {
-		return r.updateStatus(ctx, workflow.Pending("Continuing scaling operation for ReplicaSet %s, desiredMembers=%d, currentMembers=%d", rs.ObjectKey(), rs.DesiredReplicas(), scale.ReplicasThisReconciliation(rs)), mdbstatus.MembersOption(rs))
+	if r.isStillScaling() {
+		return r.updateStatus(ctx, workflow.Pending("Continuing scaling operation for ReplicaSet %s, desiredMembers=%d, currentMembers=%d", rs.ObjectKey(), rs.DesiredReplicas(), r.replicasThisReconciliation()), r.membersOption())
 	}
 
 	// Get lastspec, vault annotations when needed and write them to the resource.
@@ -354,7 +373,8 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 	}
 
 	log.Infof("Finished reconciliation for MongoDbReplicaSet! %s", completionMessage(conn.BaseURL(), conn.GroupID()))
-	return r.updateStatus(ctx, workflow.OK(), mdbstatus.NewBaseUrlOption(deployment.Link(conn.BaseURL(), conn.GroupID())), mdbstatus.NewProjectIdOption(conn.GroupID()), mdbstatus.MembersOption(rs), mdbstatus.NewPVCsStatusOptionEmptyStatus())
+	result, err := r.updateStatus(ctx, workflow.OK(), mdbstatus.NewBaseUrlOption(deployment.Link(conn.BaseURL(), conn.GroupID())), mdbstatus.NewProjectIdOption(conn.GroupID()), r.membersOption(), mdbstatus.NewPVCsStatusOptionEmptyStatus())
+	return requeueForStorageAutoscale(r.resource.Spec.HasStorageAutoscale(), result, err)
 }
 
 func newReplicaSetReconciler(ctx context.Context, kubeClient client.Client, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, omFunc om.ConnectionFactory) *ReconcileMongoDbReplicaSet {
@@ -387,6 +407,8 @@ type deploymentOptionsRS struct {
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update,namespace=placeholder
 // +kubebuilder:rbac:groups=core,resources={secrets,configmaps},verbs=get;list;watch;create;delete;update,namespace=placeholder
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;get;list;watch;delete;update,namespace=placeholder
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;get;list;watch;delete;update,namespace=placeholder
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;get;list;watch;delete;update,namespace=placeholder
 
 // MongoDB Resource
 // +kubebuilder:rbac:groups=mongodb.com,resources={mongodb,mongodb/status,mongodb/finalizers},verbs=*,namespace=placeholder
@@ -487,24 +509,33 @@ func (r *ReplicaSetReconcilerHelper) reconcileStatefulSet(ctx context.Context, c
 		return status
 	}
 
-	status = certs.EnsureSSLCertsForStatefulSet(ctx, reconciler.SecretClient, reconciler.SecretClient, *rs.Spec.Security, certs.ReplicaSetConfig(*rs), log)
+	rsCertsConfigs := certs.ReplicaSetConfigs(*rs)
+	status = certs.EnsureCertManagerCertificateForStatefulSets(ctx, reconciler.client, *rs.Spec.Security, kube.BaseOwnerReference(rs), rsCertsConfigs...)
+	if !status.IsOK() {
+		return status
+	}
+
+	status = certs.EnsureVaultPKICertificateForStatefulSets(ctx, reconciler.SecretClient, *rs.Spec.Security, certs.Database, log, rsCertsConfigs...)
 	if !status.IsOK() {
 		return status
 	}
 
+	for _, rsCertsConfig := range rsCertsConfigs {
+		status = certs.EnsureSSLCertsForStatefulSet(ctx, reconciler.SecretClient, reconciler.SecretClient, *rs.Spec.Security, rsCertsConfig, log)
+		if !status.IsOK() {
+			return status
+		}
+	}
+
 	// Build the replica set config
 	rsConfig := r.buildStatefulSetOptions(ctx, conn, projectConfig, deploymentOptions)
-	sts := construct.DatabaseStatefulSet(*rs, rsConfig, log)
-
-	// Handle PVC resize if needed
-	if workflowStatus := r.handlePVCResize(ctx, &sts); !workflowStatus.IsOK() {
-		return workflowStatus
+	if rs.Spec.ZonePlacement != nil {
+		return r.reconcileZoneStatefulSets(ctx, rsConfig)
 	}
 
-	// Create or update the StatefulSet in Kubernetes
-	mutatedSts, err := create.DatabaseInKubernetes(ctx, reconciler.client, *rs, sts, rsConfig, log)
-	if err != nil {
-		return workflow.Failed(xerrors.Errorf("failed to create/update (Kubernetes reconciliation phase): %w", err))
+	mutatedSts, status := r.createOrUpdateStatefulSet(ctx, rsConfig)
+	if !status.IsOK() {
+		return status
 	}
 
 	// Check StatefulSet status
@@ -517,7 +548,73 @@ func (r *ReplicaSetReconcilerHelper) reconcileStatefulSet(ctx context.Context, c
 	return workflow.OK()
 }
 
+// reconcileZoneStatefulSets creates or updates the StatefulSet of each zone of spec.zonePlacement and the
+// PodDisruptionBudget shared by all of them. The StatefulSets are checked once all of them are updated, so that
+// the zones are created together.
+func (r *ReplicaSetReconcilerHelper) reconcileZoneStatefulSets(ctx context.Context, rsConfig func(mdb mdbv1.MongoDB) construct.DatabaseStatefulSetOptions) workflow.Status {
+	rs := r.resource
+	reconciler := r.reconciler
+
+	var zoneStatefulSets []*appsv1.StatefulSet
+	for _, zoneScaler := range r.zoneScalers {
+		zoneConfig := construct.ReplicaSetZoneOptions(rsConfig, zoneScaler.MemberClusterName(), zoneScaler.MemberClusterNum(), scale.ReplicasThisReconciliation(zoneScaler))
+		mutatedSts, status := r.createOrUpdateStatefulSet(ctx, zoneConfig)
+		if !status.IsOK() {
+			return status
+		}
+		zoneStatefulSets = append(zoneStatefulSets, mutatedSts)
+	}
+
+	if err := create.ZonedReplicaSetPodDisruptionBudget(ctx, reconciler.client, *rs, rsConfig(*rs), r.replicasThisReconciliation()); err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to create/update the PodDisruptionBudget of the zones: %w", err))
+	}
+
+	for _, sts := range zoneStatefulSets {
+		if status := statefulset.GetStatefulSetStatus(ctx, rs.Namespace, sts.Name, sts.GetGeneration(), reconciler.client); !status.IsOK() {
+			return status
+		}
+	}
+
+	r.log.Infof("Updated the StatefulSets of the %d zones of replica set", len(zoneStatefulSets))
+	return workflow.OK()
+}
+
+// createOrUpdateStatefulSet builds the StatefulSet described by 'config' and creates or updates it in Kubernetes,
+// together with its volumes.
+func (r *ReplicaSetReconcilerHelper) createOrUpdateStatefulSet(ctx context.Context, config func(mdb mdbv1.MongoDB) construct.DatabaseStatefulSetOptions) (*appsv1.StatefulSet, workflow.Status) {
+	rs := r.resource
+	reconciler := r.reconciler
+
+	sts := construct.DatabaseStatefulSet(*rs, config, r.log)
+
+	if status := applySnapshotRestore(ctx, reconciler.client, rs.Spec.RestoreFromSnapshot, &sts); !status.IsOK() {
+		return nil, status
+	}
+
+	// Handle PVC resize if needed
+	if workflowStatus := r.handlePVCResize(ctx, &sts); !workflowStatus.IsOK() {
+		return nil, workflowStatus
+	}
+
+	// Create or update the StatefulSet in Kubernetes
+	mutatedSts, err := create.DatabaseInKubernetes(ctx, reconciler.client, *rs, sts, config, r.log)
+	if err != nil {
+		return nil, workflow.Failed(xerrors.Errorf("failed to create/update (Kubernetes reconciliation phase): %w", err))
+	}
+	return mutatedSts, workflow.OK()
+}
+
 func (r *ReplicaSetReconcilerHelper) handlePVCResize(ctx context.Context, sts *appsv1.StatefulSet) workflow.Status {
+	expansionOptions, err := r.reconciler.autoscaleStorage(ctx, r.resource, multicluster.LegacyCentralClusterName, r.reconciler.client, r.resource.Spec.PodSpec.GetPersistence(), sts, r.log)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+	if len(expansionOptions) > 0 {
+		if _, err := r.reconciler.updateStatus(ctx, r.resource, workflow.Pending("Expanding the volumes of statefulset %s", sts.Name), r.log, expansionOptions...); err != nil {
+			return workflow.Failed(xerrors.Errorf("error updating status: %w", err))
+		}
+	}
+
 	workflowStatus := create.HandlePVCResize(ctx, r.reconciler.client, sts, r.log)
 	if !workflowStatus.IsOK() {
 		return workflowStatus
@@ -540,11 +637,10 @@ func (r *ReplicaSetReconcilerHelper) buildStatefulSetOptions(ctx context.Context
 	rsCertsConfig := certs.ReplicaSetConfig(*rs)
 
 	var vaultConfig vault.VaultConfiguration
-	var databaseSecretPath string
 	if reconciler.VaultClient != nil {
 		vaultConfig = reconciler.VaultClient.VaultConfig
-		databaseSecretPath = reconciler.VaultClient.DatabaseSecretPath()
 	}
+	databaseSecretPath := reconciler.DatabaseSecretPath()
 
 	tlsCertHash := enterprisepem.ReadHashFromSecret(ctx, reconciler.SecretClient, rs.Namespace, rsCertsConfig.CertSecretName, databaseSecretPath, log)
 	internalClusterCertHash := enterprisepem.ReadHashFromSecret(ctx, reconciler.SecretClient, rs.Namespace, rsCertsConfig.InternalClusterSecretName, databaseSecretPath, log)
@@ -557,13 +653,14 @@ func (r *ReplicaSetReconcilerHelper) buildStatefulSetOptions(ctx context.Context
 		InternalClusterHash(internalClusterCertHash),
 		PrometheusTLSCertHash(deploymentOptions.prometheusCertHash),
 		WithVaultConfig(vaultConfig),
+		WithAWSSecretsConfig(reconciler.awsSecretsConfig()),
 		WithLabels(rs.Labels),
 		WithAdditionalMongodConfig(rs.Spec.GetAdditionalMongodConfig()),
 		WithInitDatabaseNonStaticImage(images.ContainerImage(reconciler.imageUrls, util.InitDatabaseImageUrlEnv, reconciler.initDatabaseNonStaticImageVersion)),
 		WithDatabaseNonStaticImage(images.ContainerImage(reconciler.imageUrls, util.NonStaticDatabaseEnterpriseImage, reconciler.databaseNonStaticImageVersion)),
 		WithAgentImage(images.ContainerImage(reconciler.imageUrls, util.AgentImageUrlEnv, r.automationAgentVersion)),
 		WithCustomAgentURL(reconciler.customAgentURL),
-		WithMongodbImage(images.GetOfficialImage(reconciler.imageUrls, rs.Spec.Version, rs.GetAnnotations(), reconciler.defaultArchitecture)),
+		WithMongodbImage(images.GetOfficialImage(reconciler.imageUrls, r.mongodbImageVersion(), rs.GetAnnotations(), reconciler.defaultArchitecture)),
 		WithAgentDebug(reconciler.agentDebug),
 		WithAgentDebugImage(reconciler.agentDebugImage),
 		WithDefaultArchitecture(reconciler.defaultArchitecture),
@@ -577,6 +674,12 @@ func (r *ReplicaSetReconcilerHelper) buildStatefulSetOptions(ctx context.Context
 func AddReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture) error {
 	// Create a new controller
 	reconciler := newReplicaSetReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, om.NewOpsManagerConnection)
+	reconciler.recorder = mgr.GetEventRecorderFor(util.OperatorName)
+	volumeStatsReaders, err := newVolumeStatsReaders(mgr.GetConfig(), nil)
+	if err != nil {
+		return err
+	}
+	reconciler.volumeStatsReaders = volumeStatsReaders
 	c, err := controller.New(util.MongoDbReplicaSetController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
@@ -623,6 +726,12 @@ func AddReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls
 		}
 	}
 
+	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &rolev1.MongoDBRole{},
+		&watch.ResourcesHandler{ResourceType: watch.MongoDBRole, ResourceWatcher: reconciler.resourceWatcher}))
+	if err != nil {
+		return err
+	}
+
 	// if vault secret backend is enabled watch for Vault secret change and trigger reconcile
 	if vault.IsVaultSecretBackend() {
 		eventChannel := make(chan event.GenericEvent)
@@ -664,9 +773,21 @@ func AddReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls
 	return nil
 }
 
+// migrationExternalMembers returns the Ops Manager processes managed outside of Kubernetes which must stay members of
+// the replica set while it's migrated to Kubernetes. Removing the annotation removes them from the replica set.
+func migrationExternalMembers(rs *mdbv1.MongoDB) []string {
+	var names []string
+	for _, name := range strings.Split(rs.Annotations[util.MigrationExternalMembers], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // updateOmDeploymentRs performs OM registration operation for the replicaset. So the changes will be finally propagated
 // to automation agents in containers
-func (r *ReplicaSetReconcilerHelper) updateOmDeploymentRs(ctx context.Context, conn om.Connection, membersNumberBefore int, tlsCertPath, internalClusterCertPath string, deploymentOptions deploymentOptionsRS, shouldMirrorKeyfileForMongot bool, isRecovering bool) workflow.Status {
+func (r *ReplicaSetReconcilerHelper) updateOmDeploymentRs(ctx context.Context, conn om.Connection, membersNumberBefore int, tlsCertPath, internalClusterCertPath string, deploymentOptions deploymentOptionsRS, maintenanceWindow *maintenanceWindowGuard, shouldMirrorKeyfileForMongot bool, isRecovering bool) workflow.Status {
 	rs := r.resource
 	log := r.log
 	reconciler := r.reconciler
@@ -674,15 +795,29 @@ func (r *ReplicaSetReconcilerHelper) updateOmDeploymentRs(ctx context.Context, c
 	// Only "concrete" RS members should be observed
 	// - if scaling down, let's observe only members that will remain after scale-down operation
 	// - if scaling up, observe only current members, because new ones might not exist yet
-	replicasTarget := scale.ReplicasThisReconciliation(rs)
-	err := agents.WaitForRsAgentsToRegisterByResource(rs, util_int.Min(membersNumberBefore, replicasTarget), conn, log)
+	err := r.waitForAgentsToRegister(conn, membersNumberBefore)
 	if err != nil && !isRecovering {
 		return workflow.Failed(err)
 	}
 
 	caFilePath := fmt.Sprintf("%s/ca-pem", util.TLSCaMountPath)
 
-	replicaSet := replicaset.BuildFromMongoDBWithReplicas(reconciler.imageUrls[util.MongodbImageEnv], reconciler.forceEnterprise, rs, replicasTarget, rs.CalculateFeatureCompatibilityVersion(), tlsCertPath, reconciler.defaultArchitecture)
+	// the members keep their _id: the members of the zones are ordered by zone, adding a member to a zone moves the
+	// members of the next zones, and the members added during a live migration don't start at 0
+	existingDeployment, err := conn.ReadDeployment()
+	if err != nil {
+		return workflow.Failed(err)
+	}
+	processIds := getReplicaSetProcessIdsFromReplicaSets(rs.Name, existingDeployment)
+
+	var replicaSet om.ReplicaSetWithProcesses
+	if rs.Spec.ZonePlacement != nil {
+		zones := r.zoneMembers(zoneReplicasThisReconciliation)
+		hostnames, names := rs.ZoneDNSNames(zones)
+		replicaSet = replicaset.BuildFromMongoDBWithHostnames(reconciler.imageUrls[util.MongodbImageEnv], reconciler.forceEnterprise, rs, hostnames, names, rs.ZoneMemberOptions(zones), processIds, rs.CalculateFeatureCompatibilityVersion(), tlsCertPath, reconciler.defaultArchitecture)
+	} else {
+		replicaSet = replicaset.BuildFromMongoDBWithReplicas(reconciler.imageUrls[util.MongodbImageEnv], reconciler.forceEnterprise, rs, scale.ReplicasThisReconciliation(rs), processIds, rs.CalculateFeatureCompatibilityVersion(), tlsCertPath, reconciler.defaultArchitecture)
+	}
 	processNames := replicaSet.GetProcessNames()
 
 	status, additionalReconciliationRequired := reconciler.updateOmAuthentication(ctx, conn, processNames, rs, deploymentOptions.agentCertPath, caFilePath, internalClusterCertPath, isRecovering, log)
@@ -704,14 +839,15 @@ func (r *ReplicaSetReconcilerHelper) updateOmDeploymentRs(ctx context.Context, c
 	}
 
 	err = conn.ReadUpdateDeployment(
-		func(d om.Deployment) error {
+		maintenanceWindow.wrap(func(d om.Deployment) error {
 			if shouldMirrorKeyfileForMongot {
 				if err := r.mirrorKeyfileIntoSecretForMongot(ctx, d); err != nil {
 					return err
 				}
 			}
-			return ReconcileReplicaSetAC(ctx, d, rs.Spec.DbCommonSpec, lastRsConfig.ToMap(), rs.Name, replicaSet, caFilePath, internalClusterCertPath, &prometheusConfiguration, log)
-		},
+			rsWithExternalMembers := d.KeepExternalReplicaSetMembers(replicaSet, migrationExternalMembers(rs))
+			return ReconcileReplicaSetAC(ctx, d, rs.Spec.DbCommonSpec, lastRsConfig.ToMap(), rs.Name, rsWithExternalMembers, caFilePath, internalClusterCertPath, &prometheusConfiguration, log)
+		}, log),
 		log,
 	)
 
@@ -732,8 +868,14 @@ func (r *ReplicaSetReconcilerHelper) updateOmDeploymentRs(ctx context.Context, c
 		return workflow.Pending("Performing multi stage reconciliation")
 	}
 
-	hostsBefore := getAllHostsForReplicas(rs, membersNumberBefore)
-	hostsAfter := getAllHostsForReplicas(rs, scale.ReplicasThisReconciliation(rs))
+	var hostsBefore, hostsAfter []string
+	if rs.Spec.ZonePlacement != nil {
+		hostsBefore, _ = rs.ZoneDNSNames(r.zoneMembers((*scalers.MultiClusterReplicaSetScaler).CurrentReplicas))
+		hostsAfter, _ = rs.ZoneDNSNames(r.zoneMembers(zoneReplicasThisReconciliation))
+	} else {
+		hostsBefore = getAllHostsForReplicas(rs, membersNumberBefore)
+		hostsAfter = getAllHostsForReplicas(rs, scale.ReplicasThisReconciliation(rs))
+	}
 
 	if err := host.CalculateDiffAndStopMonitoring(conn, hostsBefore, hostsAfter, log); err != nil && !isRecovering {
 		return workflow.Failed(err)
@@ -755,6 +897,7 @@ func (r *ReplicaSetReconcilerHelper) OnDelete(ctx context.Context, obj runtime.O
 	}
 
 	r.reconciler.resourceWatcher.RemoveDependentWatchedResources(rs.ObjectKey())
+	certs.ForgetCertificateExpiries(rs.Namespace, rs.GetKind(), rs.Name)
 
 	return nil
 }
@@ -805,7 +948,14 @@ func (r *ReplicaSetReconcilerHelper) cleanOpsManagerState(ctx context.Context, r
 
 	// During deletion, calculate the maximum number of hosts that could possibly exist to ensure complete cleanup.
 	// Reading from Status here is appropriate since this is outside the reconciliation loop.
-	hostsToRemove, _ := dns.GetDNSNames(rs.Name, rs.ServiceName(), rs.Namespace, rs.Spec.GetClusterDomain(), util.MaxInt(rs.Status.Members, rs.Spec.Members), rs.Spec.GetExternalDomain())
+	var hostsToRemove []string
+	if rs.Spec.ZonePlacement != nil {
+		hostsToRemove, _ = rs.ZoneDNSNames(r.zoneMembers(func(s *scalers.MultiClusterReplicaSetScaler) int {
+			return util.MaxInt(s.CurrentReplicas(), s.TargetReplicas())
+		}))
+	} else {
+		hostsToRemove, _ = dns.GetDNSNames(rs.Name, rs.ServiceName(), rs.Namespace, rs.Spec.GetClusterDomain(), util.MaxInt(rs.Status.Members, rs.Spec.Members), rs.Spec.GetExternalDomain())
+	}
 	log.Infow("Stop monitoring removed hosts in Ops Manager", "removedHosts", hostsToRemove)
 
 	if err := host.StopMonitoring(conn, hostsToRemove, log); err != nil {
@@ -839,6 +989,92 @@ func (r *ReconcileMongoDbReplicaSet) OnDelete(ctx context.Context, obj runtime.O
 	return helper.OnDelete(ctx, obj, log)
 }
 
+// waitForAgentsToRegister waits for the agents of the members which exist both before and after this reconciliation:
+// the members being added might not exist yet and the ones being removed are not observed anymore.
+func (r *ReplicaSetReconcilerHelper) waitForAgentsToRegister(conn om.Connection, membersNumberBefore int) error {
+	rs := r.resource
+	if rs.Spec.ZonePlacement == nil {
+		return agents.WaitForRsAgentsToRegisterByResource(rs, util_int.Min(membersNumberBefore, scale.ReplicasThisReconciliation(rs)), conn, r.log)
+	}
+
+	hostnames, _ := rs.ZoneDNSNames(r.zoneMembers(func(s *scalers.MultiClusterReplicaSetScaler) int {
+		return util_int.Min(s.CurrentReplicas(), scale.ReplicasThisReconciliation(s))
+	}))
+	return agents.WaitForRsAgentsToRegisterSpecifiedHostnames(conn, hostnames, r.log)
+}
+
+// zoneMembers returns the zones of the replica set, ordered by index, with the number of members returned by
+// 'replicas' for the scaler of each zone.
+func (r *ReplicaSetReconcilerHelper) zoneMembers(replicas func(s *scalers.MultiClusterReplicaSetScaler) int) []multicluster.MemberCluster {
+	zones := make([]multicluster.MemberCluster, 0, len(r.zoneScalers))
+	for _, zoneScaler := range r.zoneScalers {
+		zones = append(zones, multicluster.MemberCluster{Name: zoneScaler.MemberClusterName(), Index: zoneScaler.MemberClusterNum(), Replicas: replicas(zoneScaler)})
+	}
+	return zones
+}
+
+func zoneReplicasThisReconciliation(s *scalers.MultiClusterReplicaSetScaler) int {
+	return scale.ReplicasThisReconciliation(s)
+}
+
+// replicasThisReconciliation returns the number of members of the replica set at the end of this reconciliation.
+func (r *ReplicaSetReconcilerHelper) replicasThisReconciliation() int {
+	if r.resource.Spec.ZonePlacement == nil {
+		return scale.ReplicasThisReconciliation(r.resource)
+	}
+	replicas := 0
+	for _, zoneScaler := range r.zoneScalers {
+		replicas += scale.ReplicasThisReconciliation(zoneScaler)
+	}
+	return replicas
+}
+
+// isStillScaling returns true if the replica set won't have reached the members of the spec at the end of this
+// reconciliation. The members of the zones are moved one at a time, so a replica set placed in zones is still scaling
+// until every zone has reached its members, even if the total number of members doesn't change.
+func (r *ReplicaSetReconcilerHelper) isStillScaling() bool {
+	if r.resource.Spec.ZonePlacement == nil {
+		return scale.IsStillScaling(r.resource)
+	}
+	for _, zoneScaler := range r.zoneScalers {
+		if scale.ReplicasThisReconciliation(zoneScaler) != zoneScaler.TargetReplicas() {
+			return true
+		}
+	}
+	return false
+}
+
+// membersOption returns the status option recording the members of the replica set, and of each zone if the members
+// are placed in zones.
+func (r *ReplicaSetReconcilerHelper) membersOption() mdbstatus.Option {
+	if r.resource.Spec.ZonePlacement == nil {
+		return mdbstatus.MembersOption(r.resource)
+	}
+	zoneScalers := make([]interfaces.MultiClusterReplicaSetScaler, 0, len(r.zoneScalers))
+	for _, zoneScaler := range r.zoneScalers {
+		zoneScalers = append(zoneScalers, zoneScaler)
+	}
+	return mdbstatus.ZoneMembersOption(zoneScalers...)
+}
+
+// shouldPublishAutomationConfigFirst returns true if the automation config has to be published before the
+// StatefulSets are updated, which is the case if it has to be for the StatefulSet of any of the zones.
+func (r *ReplicaSetReconcilerHelper) shouldPublishAutomationConfigFirst(ctx context.Context, rsConfig func(mdb mdbv1.MongoDB) construct.DatabaseStatefulSetOptions) bool {
+	rs := r.resource
+	reconciler := r.reconciler
+	if rs.Spec.ZonePlacement == nil {
+		return publishAutomationConfigFirst(ctx, reconciler.client, *rs, r.deploymentState.LastAchievedSpec, rsConfig, reconciler.defaultArchitecture, r.log)
+	}
+
+	for _, zoneScaler := range r.zoneScalers {
+		zoneConfig := construct.ReplicaSetZoneOptions(rsConfig, zoneScaler.MemberClusterName(), zoneScaler.MemberClusterNum(), scale.ReplicasThisReconciliation(zoneScaler))
+		if publishAutomationConfigFirst(ctx, reconciler.client, *rs, r.deploymentState.LastAchievedSpec, zoneConfig, reconciler.defaultArchitecture, r.log) {
+			return true
+		}
+	}
+	return false
+}
+
 func getAllHostsForReplicas(rs *mdbv1.MongoDB, membersCount int) []string {
 	hostnames, _ := dns.GetDNSNames(rs.Name, rs.ServiceName(), rs.Namespace, rs.Spec.GetClusterDomain(), membersCount, rs.Spec.DbCommonSpec.GetExternalDomain())
 	return hostnames
@@ -895,28 +1131,26 @@ func (r *ReplicaSetReconcilerHelper) lookupCorrespondingSearchResource(ctx conte
 	reconciler := r.reconciler
 
 	var search *searchv1.MongoDBSearch
-	searchList := &searchv1.MongoDBSearchList{}
-	if err := reconciler.client.List(ctx, searchList, &client.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(searchv1.MongoDBSearchIndexFieldName, rs.GetNamespace()+"/"+rs.GetName()),
-	}); err != nil {
+	searches, err := searchcontroller.ListSearchResourcesReferencing(ctx, reconciler.client, referencegrant.Reference{Kind: referencegrant.KindMongoDB, Namespace: rs.Namespace, Name: rs.Name})
+	if err != nil {
 		return nil, xerrors.Errorf("Failed to list MongoDBSearch resources referred in the MongoDB resource %s/%s. err : %v", rs.Namespace, rs.Name, err)
 	}
 
-	if len(searchList.Items) == 0 {
+	if len(searches) == 0 {
 		return nil, nil
 	}
 
-	if len(searchList.Items) > 1 {
+	if len(searches) > 1 {
 		return nil, xerrors.Errorf("Found multiple MongoDBSearch resources referred in sharded cluster %s/%s", rs.Namespace, rs.Name)
 	}
 
 	// this validates that there is exactly one MongoDBSearch pointing to this resource,
 	// and that this resource passes search validations. If either fails, proceed without a search target
 	// for the mongod automation config.
-	if len(searchList.Items) == 1 {
+	if len(searches) == 1 {
 		searchSource := searchcontroller.NewEnterpriseResourceSearchSource(rs)
 		if searchSource.Validate() == nil {
-			search = &searchList.Items[0]
+			search = &searches[0]
 		}
 	}
 	return search, nil
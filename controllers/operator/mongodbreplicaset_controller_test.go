@@ -14,7 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -22,11 +24,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status/pvc"
@@ -35,6 +39,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/deployment"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
@@ -42,9 +47,11 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumestats"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
@@ -76,6 +83,114 @@ func TestCreateReplicaSet(t *testing.T) {
 	connection.(*om.MockedOmConnection).CheckNumberOfUpdateRequests(t, 1)
 }
 
+func TestReplicaSetPodDisruptionBudget(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetMembers(5).Build()
+	// the non-voting members don't count towards the majority
+	rs.Spec.MemberConfig = []automationconfig.MemberOptions{{}, {}, {}, {Votes: ptr.To(0)}, {Votes: ptr.To(0)}}
+
+	reconciler, client, _ := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	sts, err := client.GetStatefulSet(ctx, rs.ObjectKey())
+	require.NoError(t, err)
+
+	pdb := policyv1.PodDisruptionBudget{}
+	require.NoError(t, client.Get(ctx, rs.ObjectKey(), &pdb))
+	assert.Equal(t, sts.Spec.Selector, pdb.Spec.Selector)
+	assert.Equal(t, ptr.To(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable)
+	assert.Nil(t, pdb.Spec.MinAvailable)
+	assert.Equal(t, rs.GetOwnerLabels(), pdb.Labels)
+
+	rs.Spec.PodDisruptionBudget = &v1.PodDisruptionBudgetConfiguration{MinAvailable: ptr.To(intstr.FromInt32(4))}
+	require.NoError(t, client.Update(ctx, rs))
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	require.NoError(t, client.Get(ctx, rs.ObjectKey(), &pdb))
+	assert.Equal(t, ptr.To(intstr.FromInt32(4)), pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+
+	rs.Spec.PodDisruptionBudget = &v1.PodDisruptionBudgetConfiguration{Enabled: ptr.To(false)}
+	require.NoError(t, client.Update(ctx, rs))
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	err = client.Get(ctx, rs.ObjectKey(), &policyv1.PodDisruptionBudget{})
+	assert.True(t, apiErrors.IsNotFound(err))
+}
+
+func TestReplicaSetZonePlacement(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetMembers(3).Build()
+	rs.Spec.ZonePlacement = &mdbv1.ZonePlacement{
+		Zones: []mdbv1.ZoneSpecItem{
+			{Zone: "zone-a", Members: 1},
+			{Zone: "zone-b", Members: 1, NodeSelector: map[string]string{"disktype": "ssd"}},
+			{Zone: "zone-c", Members: 1},
+		},
+	}
+
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	assert.Len(t, mock.GetMapForObject(client, &appsv1.StatefulSet{}), 3)
+	_, err := client.GetStatefulSet(ctx, rs.ObjectKey())
+	assert.True(t, apiErrors.IsNotFound(err))
+
+	for i, zone := range []string{"zone-a", "zone-b", "zone-c"} {
+		sts, err := client.GetStatefulSet(ctx, kube.ObjectKey(rs.Namespace, fmt.Sprintf("%s-%d", rs.Name, i)))
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), *sts.Spec.Replicas)
+		assert.Equal(t, zone, sts.Spec.Selector.MatchLabels[construct.ZoneLabelKey])
+		assert.Equal(t, zone, sts.Spec.Template.Labels[construct.ZoneLabelKey])
+		terms := sts.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		assert.Equal(t, []corev1.NodeSelectorRequirement{{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{zone}}}, terms[0].MatchExpressions)
+
+		err = client.Get(ctx, kube.ObjectKey(rs.Namespace, sts.Name), &policyv1.PodDisruptionBudget{})
+		assert.True(t, apiErrors.IsNotFound(err), "the zones share the PodDisruptionBudget of the replica set")
+	}
+	sts, err := client.GetStatefulSet(ctx, kube.ObjectKey(rs.Namespace, rs.Name+"-1"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"disktype": "ssd"}, sts.Spec.Template.Spec.NodeSelector)
+
+	pdb := policyv1.PodDisruptionBudget{}
+	require.NoError(t, client.Get(ctx, rs.ObjectKey(), &pdb))
+	assert.Equal(t, ptr.To(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable)
+	assert.NotContains(t, pdb.Spec.Selector.MatchLabels, construct.ZoneLabelKey)
+	assert.Equal(t, rs.Name, pdb.Spec.Selector.MatchLabels[construct.PodAntiAffinityLabelKey])
+
+	require.NoError(t, client.Get(ctx, rs.ObjectKey(), rs))
+	assert.Equal(t, 3, rs.Status.Members)
+	assert.Equal(t, []status.ZoneStatusItem{{Zone: "zone-a", Index: 0, Members: 1}, {Zone: "zone-b", Index: 1, Members: 1}, {Zone: "zone-c", Index: 2, Members: 1}}, rs.Status.Zones)
+
+	dep, err := omConnectionFactory.GetConnection().ReadDeployment()
+	require.NoError(t, err)
+	assert.Equal(t, []string{rs.Name + "-0-0", rs.Name + "-1-0", rs.Name + "-2-0"}, dep.GetProcessNames(om.ReplicaSet{}, rs.Name))
+
+	// moving the member of zone-c to zone-a adds the new member before removing the old one
+	rs.Spec.ZonePlacement.Zones = []mdbv1.ZoneSpecItem{{Zone: "zone-a", Members: 2}, {Zone: "zone-b", Members: 1}}
+	rs.Spec.MemberConfig = []automationconfig.MemberOptions{{Priority: ptr.To("1")}, {Priority: ptr.To("1")}, {Priority: ptr.To("5")}}
+	require.NoError(t, client.Update(ctx, rs))
+	res, err := reconciler.Reconcile(ctx, requestFromObject(rs))
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, res.RequeueAfter)
+	assertCorrectNumberOfMembersAndProcesses(ctx, t, 4, rs, client, omConnectionFactory.GetConnection(), "zone-a should have been scaled up first")
+
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+	assert.Equal(t, []status.ZoneStatusItem{{Zone: "zone-a", Index: 0, Members: 2}, {Zone: "zone-b", Index: 1, Members: 1}, {Zone: "zone-c", Index: 2, Members: 0}}, rs.Status.Zones)
+	dep, err = omConnectionFactory.GetConnection().ReadDeployment()
+	require.NoError(t, err)
+	// the existing members keep their _id, the new member of zone-a gets a new one
+	assert.ElementsMatch(t, []string{rs.Name + "-0-0", rs.Name + "-0-1", rs.Name + "-1-0"}, dep.GetProcessNames(om.ReplicaSet{}, rs.Name))
+	assert.Equal(t, map[string]int{rs.Name + "-0-0": 0, rs.Name + "-1-0": 1, rs.Name + "-0-1": 3}, dep.GetReplicaSetByName(rs.Name).MemberIds())
+	// spec.memberConfig lists the members zone by zone
+	for _, member := range dep.GetReplicaSetByName(rs.Name).Members() {
+		if member.Name() == rs.Name+"-1-0" {
+			assert.Equal(t, float32(5), member.Priority())
+		}
+	}
+}
+
 func TestReplicaSetRace(t *testing.T) {
 	ctx := context.Background()
 	rs, cfgMap, projectName := buildReplicaSetWithCustomProjectName("my-rs")
@@ -331,6 +446,49 @@ func testExposedExternallyReplicaSetExternalDomainInHostnames(ctx context.Contex
 	}
 }
 
+func TestReplicaSetLiveMigration_KeepsExternalMembersUntilAnnotationIsRemoved(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetMembers(3).Build()
+	rs.Annotations = map[string]string{util.MigrationExternalMembers: "vm-0, vm-1"}
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+	omConnectionFactory.SetPostCreateHook(func(connection om.Connection) {
+		// the replica set deployed on VMs by Ops Manager
+		d, err := om.BuildDeploymentFromBytes([]byte(fmt.Sprintf(`{
+			"processes": [
+				{"name": "vm-0", "hostname": "vm-0.example.com", "processType": "mongod", "version": "4.0.0", "args2_6": {"replication": {"replSetName": "%[1]s"}}},
+				{"name": "vm-1", "hostname": "vm-1.example.com", "processType": "mongod", "version": "4.0.0", "args2_6": {"replication": {"replSetName": "%[1]s"}}}
+			],
+			"replicaSets": [{"_id": "%[1]s", "protocolVersion": "1", "members": [
+				{"_id": 0, "host": "vm-0", "votes": 1, "priority": 1},
+				{"_id": 1, "host": "vm-1", "votes": 1, "priority": 1}
+			]}],
+			"sharding": [],
+			"monitoringVersions": [],
+			"backupVersions": []
+		}`, rs.Name)))
+		require.NoError(t, err)
+		_, err = connection.UpdateDeployment(d)
+		require.NoError(t, err)
+	})
+
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	d, err := omConnectionFactory.GetConnection().ReadDeployment()
+	require.NoError(t, err)
+	assert.Len(t, d.ProcessesCopy(), 5)
+	assert.Equal(t, map[string]int{"vm-0": 0, "vm-1": 1, rs.Name + "-0": 2, rs.Name + "-1": 3, rs.Name + "-2": 4}, d.GetReplicaSetByName(rs.Name).MemberIds())
+
+	delete(rs.Annotations, util.MigrationExternalMembers)
+	err = client.Update(ctx, rs)
+	require.NoError(t, err)
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	d, err = omConnectionFactory.GetConnection().ReadDeployment()
+	require.NoError(t, err)
+	assert.Len(t, d.ProcessesCopy(), 3)
+	assert.Equal(t, map[string]int{rs.Name + "-0": 2, rs.Name + "-1": 3, rs.Name + "-2": 4}, d.GetReplicaSetByName(rs.Name).MemberIds())
+}
+
 func TestExposedExternallyReplicaSetWithNodePort(t *testing.T) {
 	ctx := context.Background()
 	// given
@@ -388,6 +546,37 @@ func TestCreateReplicaSet_TLS(t *testing.T) {
 	assert.Equal(t, "OPTIONAL", sslConfig["clientCertificateMode"])
 }
 
+func TestCreateReplicaSet_TLSWithCertManager(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().SetMembers(2).EnableTLS().SetTLSCA("custom-ca").Build()
+	rs.Spec.Security.TLSConfig.CertManager = &v1.CertManagerConfig{IssuerRef: v1.CertManagerIssuerRef{Name: "my-issuer"}}
+	certSecretName := fmt.Sprintf("%s-cert", rs.Name)
+
+	reconciler, client, _ := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+	checkReconcilePending(ctx, t, reconciler, rs, fmt.Sprintf("Waiting for cert-manager to issue the certificate %s", certSecretName), client, 10)
+
+	dnsNames := certManagerCertificateDNSNames(ctx, t, client, certSecretName)
+	assert.Equal(t, []string{
+		fmt.Sprintf("%s-0.%s.%s.svc.cluster.local", rs.Name, rs.ServiceName(), rs.Namespace),
+		fmt.Sprintf("%s-1.%s.%s.svc.cluster.local", rs.Name, rs.ServiceName(), rs.Namespace),
+	}, dnsNames)
+
+	issueCertManagerServerCertificate(ctx, t, client, certSecretName, dnsNames)
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	t.Run("Certificate is updated when the replica set is scaled", func(t *testing.T) {
+		rs.Spec.Members = 3
+		require.NoError(t, client.Update(ctx, rs))
+		checkReconcilePending(ctx, t, reconciler, rs, fmt.Sprintf("Waiting for cert-manager to reissue the certificate %s", certSecretName), client, 10)
+
+		dnsNames := certManagerCertificateDNSNames(ctx, t, client, certSecretName)
+		assert.Contains(t, dnsNames, fmt.Sprintf("%s-2.%s.%s.svc.cluster.local", rs.Name, rs.ServiceName(), rs.Namespace))
+
+		issueCertManagerServerCertificate(ctx, t, client, certSecretName, dnsNames)
+		checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+	})
+}
+
 // TestCreateDeleteReplicaSet checks that no state is left in OpsManager on removal of the replicaset
 func TestCreateDeleteReplicaSet(t *testing.T) {
 	testCases := []struct {
@@ -929,6 +1118,73 @@ func TestReplicaSetReconciler_PVCStatusClearedAfterSuccessfulResize(t *testing.T
 	})
 }
 
+type fakeVolumeStatsReader map[types.NamespacedName]volumestats.Usage
+
+func (r fakeVolumeStatsReader) NodeVolumeUsage(_ context.Context, _ string) (map[types.NamespacedName]volumestats.Usage, error) {
+	return r, nil
+}
+
+func TestReplicaSetReconciler_StorageAutoscale(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := context.Background()
+		podSpec := newDefaultPodSpec()
+		podSpec.Persistence = &v1.Persistence{
+			SingleConfig: &v1.PersistenceConfig{Storage: "1Gi"},
+			Autoscale:    &v1.StorageAutoscale{Increase: "1Gi", MaxStorage: "10Gi"},
+		}
+		rs := DefaultReplicaSetBuilder().
+			SetPersistent(util.BooleanRef(true)).
+			SetPodSpec(&podSpec).
+			Build()
+
+		reconciler, kubeClient, _ := defaultReplicaSetReconciler(ctx, nil, "", "", rs, architectures.NonStatic)
+		// the volume usage is checked again at the storage autoscaling interval
+		result, err := reconciler.Reconcile(ctx, requestFromObject(rs))
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: util.StorageAutoscaleIntervalSecondsDefault * time.Second}, result)
+
+		sts, err := kubeClient.GetStatefulSet(ctx, kube.ObjectKey(rs.Namespace, rs.Name))
+		require.NoError(t, err)
+		pvcs := createPVCs(t, sts, kubeClient)
+		usage := fakeVolumeStatsReader{}
+		for i := range pvcs {
+			// the PVCs are bound
+			pvcs[i].Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}
+			require.NoError(t, kubeClient.SubResource("status").Update(ctx, &pvcs[i]))
+			require.NoError(t, kubeClient.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%d", sts.Name, i), Namespace: rs.Namespace},
+				Spec:       corev1.PodSpec{NodeName: "node-1"},
+			}))
+			usage[kube.ObjectKey(rs.Namespace, pvcs[i].Name)] = volumestats.Usage{UsedBytes: 10, CapacityBytes: 100}
+		}
+		reconciler.volumeStatsReaders = func(string) volumestats.Reader { return usage }
+
+		// the volumes are below the threshold
+		_, err = reconciler.Reconcile(ctx, requestFromObject(rs))
+		require.NoError(t, err)
+		require.NoError(t, kubeClient.Get(ctx, kube.ObjectKey(rs.Namespace, rs.Name), rs))
+		assert.Equal(t, status.PhaseRunning, rs.Status.Phase)
+		assert.Empty(t, rs.Status.StorageExpansions)
+
+		usage[kube.ObjectKey(rs.Namespace, pvcs[1].Name)] = volumestats.Usage{UsedBytes: 90, CapacityBytes: 100}
+		_, err = reconciler.Reconcile(ctx, requestFromObject(rs))
+		require.NoError(t, err)
+		require.NoError(t, kubeClient.Get(ctx, kube.ObjectKey(rs.Namespace, rs.Name), rs))
+
+		require.Len(t, rs.Status.StorageExpansions, 1)
+		assert.Equal(t, "1Gi", rs.Status.StorageExpansions[0].From)
+		assert.Equal(t, "2Gi", rs.Status.StorageExpansions[0].To)
+		assert.Equal(t, 90, rs.Status.StorageExpansions[0].UsagePercent)
+		assert.Equal(t, pvc.PhasePVCResize, rs.Status.PVCs[0].Phase)
+
+		// all the PVCs of the statefulset are expanded
+		for _, p := range pvcs {
+			require.NoError(t, kubeClient.Get(ctx, kube.ObjectKey(p.Namespace, p.Name), &p))
+			assert.Equal(t, "2Gi", p.Spec.Resources.Requests.Storage().String())
+		}
+	})
+}
+
 // ===== Test for state and vault annotations handling in replicaset controller =====
 
 // TestReplicaSetAnnotations_WrittenOnSuccess verifies that lastAchievedSpec annotation is written after successful
@@ -1656,3 +1912,71 @@ func TestApplySearchOverrides_ResolvesPinnedClusterIndex(t *testing.T) {
 	c := mock.NewEmptyFakeClientBuilder().WithObjects(newPinnedSearch()).Build()
 	assert.Contains(t, applyOverrides(t, c), "rs-search-search-7-")
 }
+
+func TestLookupCorrespondingSearchResource_IgnoresSearchOfOtherNamespaceWithoutGrant(t *testing.T) {
+	ctx := context.Background()
+	newSearch := func(namespace string) *searchv1.MongoDBSearch {
+		return &searchv1.MongoDBSearch{
+			ObjectMeta: metav1.ObjectMeta{Name: "rs-search", Namespace: namespace},
+			Spec: searchv1.MongoDBSearchSpec{
+				Source: &searchv1.MongoDBSource{MongoDBResourceRef: &userv1.MongoDBResourceRef{Name: "temple", Namespace: mock.TestNamespace}},
+			},
+		}
+	}
+	lookup := func(t *testing.T, c client.Client) (*searchv1.MongoDBSearch, error) {
+		rs := DefaultReplicaSetBuilder().SetVersion("8.2.0").Build()
+		reconciler := &ReconcileMongoDbReplicaSet{ReconcileCommonController: NewReconcileCommonController(ctx, c)}
+		helper := &ReplicaSetReconcilerHelper{resource: rs, reconciler: reconciler, log: zap.S()}
+		return helper.lookupCorrespondingSearchResource(ctx)
+	}
+
+	c := mock.NewEmptyFakeClientBuilder().WithObjects(newSearch("other")).Build()
+	search, err := lookup(t, c)
+	require.NoError(t, err)
+	assert.Nil(t, search, "the search of another namespace must not configure the replica set without a grant")
+
+	// the search of another namespace doesn't break the search of the namespace of the replica set
+	c = mock.NewEmptyFakeClientBuilder().WithObjects(newSearch("other"), newSearch(mock.TestNamespace)).Build()
+	search, err = lookup(t, c)
+	require.NoError(t, err)
+	require.NotNil(t, search)
+	assert.Equal(t, mock.TestNamespace, search.Namespace)
+
+	grant := &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: mock.TestNamespace},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBSearch, Namespace: "other"}},
+			To:   []referencegrant.ReferenceGrantTo{{Kind: referencegrant.KindMongoDB, Name: "temple"}},
+		},
+	}
+	c = mock.NewEmptyFakeClientBuilder().WithObjects(newSearch("other"), grant).Build()
+	search, err = lookup(t, c)
+	require.NoError(t, err)
+	require.NotNil(t, search)
+	assert.Equal(t, "other", search.Namespace)
+}
+
+// certManagerCertificateDNSNames returns the DNS names of the cert-manager Certificate requested by the Operator.
+func certManagerCertificateDNSNames(ctx context.Context, t *testing.T, c client.Client, name string) []string {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certs.CertManagerCertificateGVK)
+	require.NoError(t, c.Get(ctx, kube.ObjectKey(mock.TestNamespace, name), certificate))
+	dnsNames, _, err := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+	require.NoError(t, err)
+	return dnsNames
+}
+
+// issueCertManagerServerCertificate writes the server certificate Secret the way cert-manager does.
+func issueCertManagerServerCertificate(ctx context.Context, t *testing.T, c client.Client, secretName string, dnsNames []string) {
+	certPEM, keyPEM, err := mock.CreateTestServerCertificate(dnsNames)
+	require.NoError(t, err)
+
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: mock.TestNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+	if err := c.Update(ctx, certSecret); err != nil {
+		require.NoError(t, c.Create(ctx, certSecret))
+	}
+}
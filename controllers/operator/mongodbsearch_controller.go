@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
@@ -35,6 +36,8 @@ import (
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/memberwatch"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
 )
@@ -120,7 +123,8 @@ type MongoDBSearchReconciler struct {
 	watch                *watch.ResourceWatcher
 	operatorSearchConfig searchcontroller.OperatorSearchConfig
 
-	memberClusterClientsMap map[string]kubernetesClient.Client // per-cluster Kubernetes client; empty in single-cluster installs
+	memberClusterClientsMap map[string]kubernetesClient.Client  // per-cluster Kubernetes client; empty in single-cluster installs
+	memberClusterRegistry   *multicluster.MemberClusterRegistry // takes precedence over memberClusterClientsMap when set
 	operatorClusterName     string
 
 	prepareSearch prepareSearchFuncs
@@ -148,6 +152,14 @@ func newMongoDBSearchReconciler(
 	}
 }
 
+// getMemberClusterClientsMap returns the clients of the member clusters currently registered with the operator.
+func (r *MongoDBSearchReconciler) getMemberClusterClientsMap() map[string]kubernetesClient.Client {
+	if r.memberClusterRegistry != nil {
+		return r.memberClusterRegistry.KubeClients()
+	}
+	return r.memberClusterClientsMap
+}
+
 // +kubebuilder:rbac:groups=mongodb.com,resources={mongodbsearch,mongodbsearch/status},verbs=*,namespace=placeholder
 func (r *MongoDBSearchReconciler) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, e error) {
 	log := zap.S().With("MongoDBSearch", request.NamespacedName)
@@ -185,7 +197,7 @@ func (r *MongoDBSearchReconciler) Reconcile(ctx context.Context, request reconci
 	// in hub-and-spoke, so this call covers that mode; in operator-per-cluster
 	// the map is empty and the operatorClusterNotInSearchSpec check below lets
 	// each operator clean up its own cluster.
-	if err := deleteRemovedMemberClusterResources(ctx, mdbSearch, r.memberClusterClientsMap, deleteMemberSearchResources, log); err != nil {
+	if err := deleteRemovedMemberClusterResources(ctx, mdbSearch, r.getMemberClusterClientsMap(), deleteMemberSearchResources, log); err != nil {
 		log.Warnf("Failed to clean up Search resources on removed member clusters: %v", err)
 	}
 
@@ -206,6 +218,10 @@ func (r *MongoDBSearchReconciler) Reconcile(ctx context.Context, request reconci
 		return commoncontroller.UpdateStatus(ctx, r.kubeClient, mdbSearch, workflow.Failed(xerrors.Errorf("Waiting for MongoDB source: %s", err)), log)
 	}
 
+	if st := validateSearchSourceReference(ctx, r.kubeClient, mdbSearch, searchSource); !st.IsOK() {
+		return commoncontroller.UpdateStatus(ctx, r.kubeClient, mdbSearch, st, log)
+	}
+
 	if mdbSearch.IsWireprotoEnabled() {
 		log.Info("Enabling the mongot wireproto server as required by annotation")
 		// the keyfile secret is necessary for wireproto authentication
@@ -244,12 +260,19 @@ func (r *MongoDBSearchReconciler) Reconcile(ctx context.Context, request reconci
 		return commoncontroller.UpdateStatus(ctx, r.kubeClient, mdbSearch, workflow.Failed(xerrors.Errorf("failed to read or repair search state: %w", err)), log)
 	}
 
+	failedClusterNames, err := failedcluster.ReadFailedClusterNames(mdbSearch.Annotations)
+	if err != nil {
+		return commoncontroller.UpdateStatus(ctx, r.kubeClient, mdbSearch, workflow.Failed(xerrors.Errorf("failed to read the %s annotation: %w", failedcluster.FailedClusterAnnotation, err)), log)
+	}
+	// the failed member clusters are skipped the same way as the member clusters the operator has no client for
+	memberClusterClientsMap := failedcluster.WithoutFailedClusters(r.getMemberClusterClientsMap(), failedClusterNames)
+
 	reconcileHelper := searchcontroller.NewMongoDBSearchReconcileHelper(
 		r.kubeClient,
 		mdbSearch,
 		searchSource,
 		r.operatorSearchConfig,
-		r.memberClusterClientsMap,
+		memberClusterClientsMap,
 		r.operatorClusterName,
 		state,
 	)
@@ -263,7 +286,7 @@ func (r *MongoDBSearchReconciler) Reconcile(ctx context.Context, request reconci
 	// re-checked after a delay, never failing the reconcile. Skip when reconcile
 	// already requeued — its own gates cover that case.
 	if result.RequeueAfter == 0 {
-		if gaps := searchcontroller.CheckSecretsPresence(ctx, mdbSearch, r.kubeClient, r.memberClusterClientsMap); len(gaps) > 0 {
+		if gaps := searchcontroller.CheckSecretsPresence(ctx, mdbSearch, r.kubeClient, memberClusterClientsMap); len(gaps) > 0 {
 			r.surfaceMissingSecrets(gaps, log)
 			result.RequeueAfter = secretsCheckRequeueAfter
 		}
@@ -310,8 +333,9 @@ func (r *MongoDBSearchReconciler) OnDelete(ctx context.Context, obj runtime.Obje
 		return xerrors.Errorf("expected a deleted MongoDBSearch, got %T", obj)
 	}
 
-	for _, clusterName := range slices.Sorted(maps.Keys(r.memberClusterClientsMap)) {
-		memberClient := r.memberClusterClientsMap[clusterName]
+	memberClusterClientsMap := r.getMemberClusterClientsMap()
+	for _, clusterName := range slices.Sorted(maps.Keys(memberClusterClientsMap)) {
+		memberClient := memberClusterClientsMap[clusterName]
 		errs := deleteOwnedClusterResources(ctx, memberClient, clusterName, search, log)
 		// deleteOwnedClusterResources' kind list has no Deployment, but Search
 		// also owns per-cluster Envoy and metrics-forwarder Deployments.
@@ -451,6 +475,12 @@ func centralMongoDBSearchResourceWatches(r *MongoDBSearchReconciler) []mongoDBSe
 			obj:     &corev1.ConfigMap{},
 			handler: &watch.ResourcesHandler{ResourceType: watch.ConfigMap, ResourceWatcher: r.watch},
 		},
+		{
+			obj: &referencegrant.MongoDBReferenceGrant{},
+			handler: enqueueGrantedReferrers(r.kubeClient, referencegrant.KindMongoDBSearch, func() client.ObjectList {
+				return &searchv1.MongoDBSearchList{}
+			}),
+		},
 	}
 }
 
@@ -530,7 +560,7 @@ func getSearchSource(ctx context.Context, kubeClient client.Client, watcher *wat
 		return nil, xerrors.New("MongoDBSearch source MongoDB resource reference is not set")
 	}
 
-	sourceName := types.NamespacedName{Namespace: search.GetNamespace(), Name: sourceMongoDBResourceRef.Name}
+	sourceName := types.NamespacedName{Namespace: sourceMongoDBResourceRef.Namespace, Name: sourceMongoDBResourceRef.Name}
 	log.Infof("Looking up Search source %s", sourceName)
 
 	mdb := &mdbv1.MongoDB{}
@@ -559,6 +589,18 @@ func getSearchSource(ctx context.Context, kubeClient client.Client, watcher *wat
 	return nil, xerrors.Errorf("No database resource named %s found", sourceName)
 }
 
+// validateSearchSourceReference returns an Invalid status if the source database is in another namespace and no
+// MongoDBReferenceGrant allows the search to reference it.
+func validateSearchSourceReference(ctx context.Context, reader client.Reader, search *searchv1.MongoDBSearch, searchSource searchcontroller.SearchSourceDBResource) workflow.Status {
+	sourceRef := search.GetMongoDBResourceRef()
+	if sourceRef == nil {
+		return workflow.OK()
+	}
+	from := referencegrant.Reference{Kind: referencegrant.KindMongoDBSearch, Namespace: search.Namespace, Name: search.Name}
+	to := referencegrant.Reference{Kind: searchcontroller.SearchSourceKind(searchSource), Namespace: sourceRef.Namespace, Name: sourceRef.Name}
+	return validateReference(ctx, reader, from, to)
+}
+
 func mdbcSearchIndexBuilder(rawObj client.Object) []string {
 	mdbSearch := rawObj.(*searchv1.MongoDBSearch)
 	resourceRef := mdbSearch.GetMongoDBResourceRef()
@@ -573,7 +615,8 @@ func AddMongoDBSearchController(
 	ctx context.Context,
 	mgr manager.Manager,
 	operatorSearchConfig searchcontroller.OperatorSearchConfig,
-	memberClusterObjectsMap map[string]cluster.Cluster,
+	memberClusterRegistry *multicluster.MemberClusterRegistry,
+	memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker,
 	operatorClusterName string,
 ) error {
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &searchv1.MongoDBSearch{}, searchv1.MongoDBSearchIndexFieldName, mdbcSearchIndexBuilder); err != nil {
@@ -583,9 +626,10 @@ func AddMongoDBSearchController(
 	r := newMongoDBSearchReconciler(
 		mgr.GetClient(),
 		operatorSearchConfig,
-		multicluster.ClustersMapToClientMap(memberClusterObjectsMap),
+		memberClusterRegistry.Clients(),
 		operatorClusterName,
 	)
+	r.memberClusterRegistry = memberClusterRegistry
 
 	c, err := controller.New(util.MongoDbSearchController, mgr, controller.Options{
 		Reconciler:              r,
@@ -601,17 +645,25 @@ func AddMongoDBSearchController(
 		}
 	}
 
-	// Per-member-cluster watches. Empty memberClusterObjectsMap (single-cluster
+	// Per-member-cluster watches. No memberClusterRegistry (single-cluster
 	// install) skips them entirely — there is nothing to watch.
-	if len(memberClusterObjectsMap) > 0 {
+	if memberClusterRegistry != nil {
 		// Per-member-cluster watches map events back to the parent MongoDBSearch
 		// via the search-owner labels (cross-cluster owner refs do not GC).
-		for k, v := range memberClusterObjectsMap {
+		err := memberClusterRegistry.OnClusterAdded(func(k string, v cluster.Cluster) error {
 			for _, w := range memberMongoDBSearchResourceWatches(r) {
 				if err := c.Watch(source.Kind[client.Object](v.GetCache(), w.obj, w.handler, w.predicates...)); err != nil {
 					return xerrors.Errorf("failed to set MongoDBSearch member-cluster watch on %s for %T: %w", k, w.obj, err)
 				}
 			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := memberClusterHealthChecker.WatchResources(c, memberwatch.MongoDBSearchResources); err != nil {
+			return xerrors.Errorf("failed to set MongoDBSearch member cluster health watch: %w", err)
 		}
 	}
 
@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
@@ -615,6 +616,30 @@ func TestMongoDBSearchReconcile_InvalidVersion(t *testing.T) {
 	checkSearchReconcileFailed(ctx, t, reconciler, c, search, "MongoDB version")
 }
 
+func TestMongoDBSearchReconcile_SourceInAnotherNamespace_RequiresReferenceGrant(t *testing.T) {
+	ctx := context.Background()
+	search := newMongoDBSearch("search", "search-namespace", "mdb")
+	search.Spec.Source.MongoDBResourceRef.Namespace = mock.TestNamespace
+	mdbc := newMongoDBCommunity("mdb", mock.TestNamespace)
+	reconciler, c := newSearchReconciler(mdbc, search)
+
+	checkSearchReconcileFailed(ctx, t, reconciler, c, search, "MongoDBSearch search-namespace/search can't reference MongoDBCommunity my-namespace/mdb")
+
+	require.NoError(t, c.Create(ctx, &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: mock.TestNamespace},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBSearch, Namespace: search.Namespace}},
+			To:   []referencegrant.ReferenceGrantTo{{Kind: referencegrant.KindMongoDBCommunity}},
+		},
+	}))
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: search.NamespacedName()})
+	require.NoError(t, err)
+
+	updated := &searchv1.MongoDBSearch{}
+	require.NoError(t, c.Get(ctx, search.NamespacedName(), updated))
+	assert.NotContains(t, updated.Status.Message, "can't reference")
+}
+
 func TestMongoDBSearchReconcile_MultipleSearchResources(t *testing.T) {
 	ctx := context.Background()
 	search1 := newMongoDBSearch("search1", mock.TestNamespace, "mdb")
@@ -106,6 +106,8 @@ type MongoDBSearchMetricsForwarderReconciler struct {
 	otelConfigTemplate  searchcontroller.MetricsForwarderOTelConfigTemplate
 	operatorClusterName string
 	memberClients       map[string]kubernetesClient.Client
+	// memberClusterRegistry takes precedence over memberClients when set
+	memberClusterRegistry *multicluster.MemberClusterRegistry
 
 	prepareSearch prepareSearchFuncs
 }
@@ -130,6 +132,14 @@ func newMongoDBSearchMetricsForwarderReconciler(c client.Client, defaultImage st
 	}
 }
 
+// getMemberClients returns the clients of the member clusters currently registered with the operator.
+func (r *MongoDBSearchMetricsForwarderReconciler) getMemberClients() map[string]kubernetesClient.Client {
+	if r.memberClusterRegistry != nil {
+		return r.memberClusterRegistry.KubeClients()
+	}
+	return r.memberClients
+}
+
 // +kubebuilder:rbac:groups=mongodb.com,resources={mongodbsearch,mongodbsearch/status,mongodbsearch/finalizers},verbs=*,namespace=placeholder
 // +kubebuilder:rbac:groups=mongodb.com,resources=mongodb,verbs=get;list;watch,namespace=placeholder
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete,namespace=placeholder
@@ -373,21 +383,21 @@ func (r *MongoDBSearchMetricsForwarderReconciler) reconcileCore(ctx context.Cont
 func (r *MongoDBSearchMetricsForwarderReconciler) buildClusterWorkList(search *searchv1.MongoDBSearch) []clusterWorkItem {
 	if r.operatorClusterName != "" {
 		if len(search.Spec.Clusters) == 0 {
-			return []clusterWorkItem{newClusterWorkItem(search, "", 0, r.kubeClient, r.memberClients, r.operatorClusterName)}
+			return []clusterWorkItem{newClusterWorkItem(search, "", 0, r.kubeClient, r.getMemberClients(), r.operatorClusterName)}
 		}
 		for _, c := range search.Spec.Clusters {
 			if c.Name == r.operatorClusterName {
-				return []clusterWorkItem{newClusterWorkItem(search, c.Name, c.ResolveIndex(), r.kubeClient, r.memberClients, r.operatorClusterName)}
+				return []clusterWorkItem{newClusterWorkItem(search, c.Name, c.ResolveIndex(), r.kubeClient, r.getMemberClients(), r.operatorClusterName)}
 			}
 		}
 		return nil
 	}
 	if len(search.Spec.Clusters) == 0 {
-		return []clusterWorkItem{newClusterWorkItem(search, "", 0, r.kubeClient, r.memberClients, r.operatorClusterName)}
+		return []clusterWorkItem{newClusterWorkItem(search, "", 0, r.kubeClient, r.getMemberClients(), r.operatorClusterName)}
 	}
 	work := make([]clusterWorkItem, 0, len(search.Spec.Clusters))
 	for _, c := range search.Spec.Clusters {
-		work = append(work, newClusterWorkItem(search, c.Name, c.ResolveIndex(), r.kubeClient, r.memberClients, r.operatorClusterName))
+		work = append(work, newClusterWorkItem(search, c.Name, c.ResolveIndex(), r.kubeClient, r.getMemberClients(), r.operatorClusterName))
 	}
 	return work
 }
@@ -504,7 +514,7 @@ func (r *MongoDBSearchMetricsForwarderReconciler) workForRemovedClusters(search
 		if r.operatorClusterName != "" && clusterName != "" && clusterName != r.operatorClusterName {
 			continue
 		}
-		work = append(work, newClusterWorkItem(search, clusterName, *clusterState.ClusterIndex, r.kubeClient, r.memberClients, r.operatorClusterName))
+		work = append(work, newClusterWorkItem(search, clusterName, *clusterState.ClusterIndex, r.kubeClient, r.getMemberClients(), r.operatorClusterName))
 	}
 	sort.Slice(work, func(i, j int) bool { return work[i].ClusterName < work[j].ClusterName })
 	return work
@@ -1538,13 +1548,14 @@ func memberMongoDBSearchMetricsForwarderResourceWatches(r *MongoDBSearchMetricsF
 }
 
 // AddMongoDBSearchMetricsForwarderController registers the metrics forwarder controller with the manager.
-func AddMongoDBSearchMetricsForwarderController(ctx context.Context, mgr manager.Manager, defaultImage string, memberClusterObjectsMap map[string]runtimeCluster.Cluster, operatorClusterName string) error {
+func AddMongoDBSearchMetricsForwarderController(ctx context.Context, mgr manager.Manager, defaultImage string, memberClusterRegistry *multicluster.MemberClusterRegistry, operatorClusterName string) error {
 	r := newMongoDBSearchMetricsForwarderReconciler(
 		mgr.GetClient(),
 		defaultImage,
-		multicluster.ClustersMapToClientMap(memberClusterObjectsMap),
+		memberClusterRegistry.Clients(),
 		operatorClusterName,
 	)
+	r.memberClusterRegistry = memberClusterRegistry
 	c, err := controller.New("mongodbsearchmetricsforwarder", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1), // nolint:forbidigo
@@ -1563,13 +1574,12 @@ func AddMongoDBSearchMetricsForwarderController(ctx context.Context, mgr manager
 	}
 
 	// Per-member-cluster resource watches: label-based mapper, since cross-cluster owner refs don't GC.
-	for k, v := range memberClusterObjectsMap {
+	return memberClusterRegistry.OnClusterAdded(func(k string, v runtimeCluster.Cluster) error {
 		for _, w := range memberMongoDBSearchMetricsForwarderResourceWatches(r) {
 			if err := c.Watch(source.Kind[client.Object](v.GetCache(), w.obj, w.handler, w.predicates...)); err != nil {
 				return fmt.Errorf("failed to set metrics forwarder member-cluster watch on %s for %T: %w", k, w.obj, err)
 			}
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
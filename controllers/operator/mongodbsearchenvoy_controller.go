@@ -85,6 +85,8 @@ type MongoDBSearchEnvoyReconciler struct {
 	defaultEnvoyImage   string
 	operatorClusterName string
 	memberClients       map[string]kubernetesClient.Client
+	// memberClusterRegistry takes precedence over memberClients when set
+	memberClusterRegistry *multicluster.MemberClusterRegistry
 
 	prepareSearch prepareSearchFuncs
 }
@@ -106,6 +108,14 @@ func newMongoDBSearchEnvoyReconciler(c client.Client, defaultEnvoyImage string,
 	}
 }
 
+// getMemberClients returns the clients of the member clusters currently registered with the operator.
+func (r *MongoDBSearchEnvoyReconciler) getMemberClients() map[string]kubernetesClient.Client {
+	if r.memberClusterRegistry != nil {
+		return r.memberClusterRegistry.KubeClients()
+	}
+	return r.memberClients
+}
+
 // +kubebuilder:rbac:groups=mongodb.com,resources={mongodbsearch,mongodbsearch/status},verbs=*,namespace=placeholder
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete,namespace=placeholder
 // +kubebuilder:rbac:groups="",resources=services;configmaps,verbs=get;list;watch;create;update;patch;delete,namespace=placeholder
@@ -138,7 +148,7 @@ func (r *MongoDBSearchEnvoyReconciler) Reconcile(ctx context.Context, request re
 	// drive deletions) but on the PRE-localization spec (a narrowed spec would
 	// mark sibling clusters as removed). Best-effort: failures are logged, never
 	// fail the reconcile, and are retried on the next reconcile of the live CR.
-	if err := deleteRemovedMemberClusterResources(ctx, mdbSearch, r.memberClients, deleteEnvoySearchResources, log); err != nil {
+	if err := deleteRemovedMemberClusterResources(ctx, mdbSearch, r.getMemberClients(), deleteEnvoySearchResources, log); err != nil {
 		log.Warnf("Failed to clean up Envoy resources on removed member clusters: %v", err)
 	}
 	if operatorClusterNotInSearchSpec(mdbSearch, r.operatorClusterName) {
@@ -242,11 +252,11 @@ type clusterWorkItem struct {
 // defensive backstop only.
 func (r *MongoDBSearchEnvoyReconciler) buildClusterWorkList(search *searchv1.MongoDBSearch) []clusterWorkItem {
 	if len(search.Spec.Clusters) == 0 {
-		return []clusterWorkItem{newClusterWorkItem(search, "", 0, r.kubeClient, r.memberClients, r.operatorClusterName)}
+		return []clusterWorkItem{newClusterWorkItem(search, "", 0, r.kubeClient, r.getMemberClients(), r.operatorClusterName)}
 	}
 	work := make([]clusterWorkItem, 0, len(search.Spec.Clusters))
 	for _, c := range search.Spec.Clusters {
-		work = append(work, newClusterWorkItem(search, c.Name, c.ResolveIndex(), r.kubeClient, r.memberClients, r.operatorClusterName))
+		work = append(work, newClusterWorkItem(search, c.Name, c.ResolveIndex(), r.kubeClient, r.getMemberClients(), r.operatorClusterName))
 	}
 	return work
 }
@@ -830,17 +840,18 @@ func envoyPodLabelsForCluster(search *searchv1.MongoDBSearch, clusterIndex int)
 
 // Controller Registration
 //
-// memberClusterObjectsMap is the same map main.go passes to AddMongoDBSearchController.
-// Empty in single-cluster installs — the controller behaves identically to before
-// when the map is empty.
+// memberClusterRegistry is the same registry main.go passes to AddMongoDBSearchController.
+// Nil in single-cluster installs — the controller behaves identically to before
+// when there are no member clusters.
 //
 // For each member cluster we register watches on Envoy Deployment + ConfigMap
 // using the label-based mapper (cross-cluster owner refs do not GC).
-func AddMongoDBSearchEnvoyController(ctx context.Context, mgr manager.Manager, defaultEnvoyImage string, memberClusterObjectsMap map[string]runtimeCluster.Cluster, operatorClusterName string) error {
+func AddMongoDBSearchEnvoyController(ctx context.Context, mgr manager.Manager, defaultEnvoyImage string, memberClusterRegistry *multicluster.MemberClusterRegistry, operatorClusterName string) error {
 	// NOTE: The field index for MongoDBSearchIndexFieldName is already registered
 	// by AddMongoDBSearchController. Do not register it again here.
 
-	r := newMongoDBSearchEnvoyReconciler(mgr.GetClient(), defaultEnvoyImage, multicluster.ClustersMapToClientMap(memberClusterObjectsMap), operatorClusterName)
+	r := newMongoDBSearchEnvoyReconciler(mgr.GetClient(), defaultEnvoyImage, memberClusterRegistry.Clients(), operatorClusterName)
+	r.memberClusterRegistry = memberClusterRegistry
 
 	c, err := controller.New("mongodbsearchenvoy", mgr, controller.Options{
 		Reconciler:              r,
@@ -875,14 +886,13 @@ func AddMongoDBSearchEnvoyController(ctx context.Context, mgr manager.Manager, d
 	// cross-cluster owner refs don't GC. Same pattern as the AppDB MC and
 	// sharded MC controllers (see appdbreplicaset_controller.go and
 	// mongodbshardedcluster_controller.go).
-	for k, v := range memberClusterObjectsMap {
+	return memberClusterRegistry.OnClusterAdded(func(k string, v runtimeCluster.Cluster) error {
 		if err := c.Watch(source.Kind[client.Object](v.GetCache(), &appsv1.Deployment{}, mapper, searchOwnerPredicate)); err != nil {
 			return fmt.Errorf("failed to set Envoy Deployment watch on member cluster %s: %w", k, err)
 		}
 		if err := c.Watch(source.Kind[client.Object](v.GetCache(), &corev1.ConfigMap{}, mapper, searchOwnerPredicate)); err != nil {
 			return fmt.Errorf("failed to set Envoy ConfigMap watch on member cluster %s: %w", k, err)
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
@@ -11,7 +11,6 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -32,6 +31,7 @@ import (
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	rolev1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/role"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
 	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
@@ -61,8 +61,11 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/annotations"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/poddisruptionbudget"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/service"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/memberwatch"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -79,6 +82,7 @@ type ReconcileMongoDbShardedCluster struct {
 	*ReconcileCommonController
 	omConnectionFactory       om.ConnectionFactory
 	memberClustersMap         map[string]client.Client
+	memberClusterRegistry     *multicluster.MemberClusterRegistry
 	imageUrls                 images.ImageUrls
 	forceEnterprise           bool
 	enableClusterMongoDBRoles bool
@@ -111,6 +115,14 @@ func newShardedClusterReconciler(ctx context.Context, kubeClient client.Client,
 	}
 }
 
+// getMemberClustersMap returns the clients of the member clusters currently registered with the operator.
+func (r *ReconcileMongoDbShardedCluster) getMemberClustersMap() map[string]client.Client {
+	if r.memberClusterRegistry != nil {
+		return r.memberClusterRegistry.Clients()
+	}
+	return r.memberClustersMap
+}
+
 type ShardedClusterDeploymentState struct {
 	CommonDeploymentState `json:",inline"`
 	LastAchievedSpec      *mdbv1.MongoDbSpec   `json:"lastAchievedSpec"`
@@ -142,6 +154,23 @@ func NewShardedClusterDeploymentState() *ShardedClusterDeploymentState {
 	}
 }
 
+// applyFailoverPolicy changes the desired configuration of the components according to the failover policy of the
+// resource while the failed member clusters are down.
+func (r *ShardedClusterReconcileHelper) applyFailoverPolicy(failedClusterNames []string) {
+	if len(failedClusterNames) == 0 {
+		return
+	}
+	policy := failedcluster.EffectivePolicy(r.sc.Spec.Failover)
+	for _, shard := range r.desiredShardsConfiguration {
+		shard.ClusterSpecList = failedcluster.ApplyPolicy(policy, shard.ClusterSpecList, failedClusterNames)
+	}
+	r.desiredConfigServerConfiguration.ClusterSpecList = failedcluster.ApplyPolicy(policy, r.desiredConfigServerConfiguration.ClusterSpecList, failedClusterNames)
+	// mongos processes don't vote, their number is only kept when the members are redistributed
+	if policy == v1.FailoverPolicyRedistributeMembers {
+		r.desiredMongosConfiguration.ClusterSpecList = failedcluster.RedistributeMembers(r.desiredMongosConfiguration.ClusterSpecList, failedClusterNames)
+	}
+}
+
 func (r *ShardedClusterReconcileHelper) initializeMemberClusters(globalMemberClustersMap map[string]client.Client, log *zap.SugaredLogger) error {
 	mongoDB := r.sc
 	shardsMap := r.desiredShardsConfiguration
@@ -718,6 +747,16 @@ func newShardedClusterReconcilerHelper(
 	helper.desiredConfigServerConfiguration = helper.prepareDesiredConfigServerConfiguration()
 	helper.desiredMongosConfiguration = helper.prepareDesiredMongosConfiguration()
 
+	if sc.Spec.IsMultiCluster() {
+		failedClusterNames, err := failedcluster.ReadFailedClusterNames(sc.Annotations)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to read the %s annotation: %w", failedcluster.FailedClusterAnnotation, err)
+		}
+		// the failed member clusters are skipped the same way as the member clusters the operator has no client for
+		globalMemberClustersMap = failedcluster.WithoutFailedClusters(globalMemberClustersMap, failedClusterNames)
+		helper.applyFailoverPolicy(failedClusterNames)
+	}
+
 	if err := helper.initializeMemberClusters(globalMemberClustersMap, log); err != nil {
 		return nil, xerrors.Errorf("failed to initialize sharded cluster controller: %w", err)
 	}
@@ -834,7 +873,7 @@ func (r *ReconcileMongoDbShardedCluster) Reconcile(ctx context.Context, request
 		return reconcileResult, err
 	}
 
-	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, r.agentDebug, r.agentDebugImage, r.defaultArchitecture, sc, r.memberClustersMap, r.omConnectionFactory, log, r.backupEnableDelay)
+	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, r.agentDebug, r.agentDebugImage, r.defaultArchitecture, sc, r.getMemberClustersMap(), r.omConnectionFactory, log, r.backupEnableDelay)
 	if err != nil {
 		return r.updateStatus(ctx, sc, workflow.Failed(xerrors.Errorf("Failed to initialize sharded cluster reconciler: %w", err)), log)
 	}
@@ -843,7 +882,7 @@ func (r *ReconcileMongoDbShardedCluster) Reconcile(ctx context.Context, request
 
 // OnDelete tries to complete a Deletion reconciliation event
 func (r *ReconcileMongoDbShardedCluster) OnDelete(ctx context.Context, obj runtime.Object, log *zap.SugaredLogger) error {
-	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, r.agentDebug, r.agentDebugImage, r.defaultArchitecture, obj.(*mdbv1.MongoDB), r.memberClustersMap, r.omConnectionFactory, log, r.backupEnableDelay)
+	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, r.agentDebug, r.agentDebugImage, r.defaultArchitecture, obj.(*mdbv1.MongoDB), r.getMemberClustersMap(), r.omConnectionFactory, log, r.backupEnableDelay)
 	if err != nil {
 		return err
 	}
@@ -1002,7 +1041,7 @@ func (r *ShardedClusterReconcileHelper) Reconcile(ctx context.Context, log *zap.
 	log.Infof("Finished reconciliation for Sharded Cluster! %s", completionMessage(conn.BaseURL(), conn.GroupID()))
 	// It's the second place in the reconcile logic we're updating sizes of all the components
 	// We're also updating the shardCount here - it's the only place we're doing that.
-	return r.updateStatus(ctx, sc, workflowStatus, log,
+	result, err := r.updateStatus(ctx, sc, workflowStatus, log,
 		mdbstatus.NewBaseUrlOption(deployment.Link(conn.BaseURL(), conn.GroupID())),
 		mdbstatus.NewProjectIdOption(conn.GroupID()),
 		mdbstatus.ShardedClusterSizeConfigOption{SizeConfig: sizeStatus},
@@ -1010,6 +1049,7 @@ func (r *ShardedClusterReconcileHelper) Reconcile(ctx context.Context, log *zap.
 		mdbstatus.ShardedClusterMongodsPerShardCountOption{Members: r.sc.Spec.ShardCount},
 		mdbstatus.NewPVCsStatusOptionEmptyStatus(),
 	)
+	return requeueForStorageAutoscale(specCopy.HasStorageAutoscale(), result, err)
 }
 
 func (r *ShardedClusterReconcileHelper) logAllScalers(log *zap.SugaredLogger) {
@@ -1097,24 +1137,22 @@ func (r *ShardedClusterReconcileHelper) applySearchParametersForShards(ctx conte
 func (r *ShardedClusterReconcileHelper) lookupCorrespondingSearchResource(ctx context.Context) (*searchv1.MongoDBSearch, error) {
 	sc := r.sc
 
-	searchList := &searchv1.MongoDBSearchList{}
-	if err := r.commonController.client.List(ctx, searchList, &client.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(searchv1.MongoDBSearchIndexFieldName, sc.GetNamespace()+"/"+sc.GetName()),
-	}); err != nil {
+	searches, err := searchcontroller.ListSearchResourcesReferencing(ctx, r.commonController.client, referencegrant.Reference{Kind: referencegrant.KindMongoDB, Namespace: sc.Namespace, Name: sc.Name})
+	if err != nil {
 		return nil, xerrors.Errorf("Failed to list MongoDBSearch resources: %v", err)
 	}
 
 	// this MDB resource is not referred in any of the search resoruces
-	if len(searchList.Items) == 0 {
+	if len(searches) == 0 {
 		return nil, nil
 	}
 
 	// Validate that there is exactly one MongoDBSearch pointing to this resource
-	if len(searchList.Items) > 1 {
+	if len(searches) > 1 {
 		return nil, xerrors.Errorf("Found multiple MongoDBSearch resources referred in sharded cluster %s/%s", sc.Namespace, sc.Name)
 	}
 
-	search := &searchList.Items[0]
+	search := &searches[0]
 
 	// Validate the search spec
 	if err := search.ValidateSpec(); err != nil {
@@ -1129,16 +1167,14 @@ func (r *ShardedClusterReconcileHelper) doShardedClusterProcessing(ctx context.C
 	log.Info("ShardedCluster.doShardedClusterProcessing")
 	sc := obj.(*mdbv1.MongoDB)
 
-	var databaseSecretPath string
-	if r.commonController.VaultClient != nil {
-		databaseSecretPath = r.commonController.VaultClient.DatabaseSecretPath()
-	}
+	databaseSecretPath := r.commonController.DatabaseSecretPath()
 
 	if workflowStatus := ensureSupportedOpsManagerVersion(conn); workflowStatus.Phase() != mdbstatus.PhaseRunning {
 		return workflowStatus
 	}
 
 	r.commonController.SetupCommonWatchers(sc, getTLSSecretNames(sc), getInternalAuthSecretNames(sc), sc.Name)
+	r.commonController.checkCertificatesExpiry(ctx, sc, getTLSSecretNames(sc), getInternalAuthSecretNames(sc), sc.Name, log)
 
 	reconcileResult := checkIfHasExcessProcesses(conn, sc.Name, log)
 	if !reconcileResult.IsOK() {
@@ -1215,6 +1251,7 @@ func (r *ShardedClusterReconcileHelper) doShardedClusterProcessing(ctx context.C
 		agentCertPath:        agentCertPath,
 		agentCertHash:        agentCertHash,
 		prometheusCertHash:   prometheusCertHash,
+		maintenanceWindow:    newMaintenanceWindowGuard(sc.Spec.MaintenanceWindow, time.Now()),
 	}
 	allConfigs := r.getAllConfigs(ctx, *sc, opts, log)
 
@@ -1240,6 +1277,8 @@ func (r *ShardedClusterReconcileHelper) doShardedClusterProcessing(ctx context.C
 		func() workflow.Status {
 			return r.createKubernetesResources(ctx, sc, opts, log).OnErrorPrepend("Failed to create/update (Kubernetes reconciliation phase):")
 		})
+	// the deferred changes don't stop the Kubernetes resources from being reconciled, they only keep the resource pending
+	workflowStatus = workflowStatus.Merge(opts.maintenanceWindow.status())
 
 	if !workflowStatus.IsOK() {
 		return workflowStatus
@@ -1388,6 +1427,9 @@ func (r *ShardedClusterReconcileHelper) removeUnusedStatefulsets(ctx context.Con
 				// the error and leave the cleanup work for the admins
 				log.Warnf("Failed to delete the statefulset %s in cluster %s: %s", key, memberCluster.Name, err)
 			}
+			if err := poddisruptionbudget.DeleteIfExists(ctx, memberCluster.Client, key); err != nil {
+				log.Warnf("Failed to delete the PodDisruptionBudget %s in cluster %s: %s", key, memberCluster.Name, err)
+			}
 			log.Infof("Removed statefulset %s in cluster %s as it's was removed from sharded cluster", key, memberCluster.Name)
 		}
 	}
@@ -1405,6 +1447,10 @@ func (r *ShardedClusterReconcileHelper) ensureSSLCertificates(ctx context.Contex
 		return workflow.Failed(err), nil
 	}
 
+	if status := r.ensureIssuedCertificates(ctx, s, log); !status.IsOK() {
+		return status, nil
+	}
+
 	var workflowStatus workflow.Status = workflow.OK()
 	for _, memberCluster := range getHealthyMemberClusters(r.mongosMemberClusters) {
 		mongosCert := certs.MongosConfig(*s, r.sc.Spec.GetExternalDomain(), r.GetMongosScaler(memberCluster))
@@ -1432,6 +1478,41 @@ func (r *ShardedClusterReconcileHelper) ensureSSLCertificates(ctx context.Contex
 	return workflowStatus, certSecretTypes
 }
 
+// ensureIssuedCertificates requests the certificates of the mongos, config servers and shards from cert-manager or
+// issues them from Vault, if enabled. The certificates include the members of all the member clusters, including the
+// unhealthy ones, so that they remain valid when these come back.
+func (r *ShardedClusterReconcileHelper) ensureIssuedCertificates(ctx context.Context, s *mdbv1.MongoDB, log *zap.SugaredLogger) workflow.Status {
+	security := *s.Spec.Security
+	if security.TLSConfig == nil || (security.TLSConfig.CertManager == nil && security.TLSConfig.VaultPKI == nil) {
+		return workflow.OK()
+	}
+	ownerReferences := kube.BaseOwnerReference(s)
+
+	var mongosOpts []certs.Options
+	for _, memberCluster := range r.mongosMemberClusters {
+		mongosOpts = append(mongosOpts, certs.MongosConfig(*s, r.sc.Spec.GetExternalDomain(), r.GetMongosScaler(memberCluster)))
+	}
+	var configSrvOpts []certs.Options
+	for _, memberCluster := range r.configSrvMemberClusters {
+		configSrvOpts = append(configSrvOpts, certs.ConfigSrvConfig(*s, r.sc.Spec.DbCommonSpec.GetExternalDomain(), r.GetConfigSrvScaler(memberCluster)))
+	}
+	statefulSetsOpts := [][]certs.Options{mongosOpts, configSrvOpts}
+	for i := 0; i < s.Spec.ShardCount; i++ {
+		var shardOpts []certs.Options
+		for _, memberCluster := range r.shardsMemberClustersMap[i] {
+			shardOpts = append(shardOpts, certs.ShardConfig(*s, i, r.sc.Spec.DbCommonSpec.GetExternalDomain(), r.GetShardScaler(i, memberCluster)))
+		}
+		statefulSetsOpts = append(statefulSetsOpts, shardOpts)
+	}
+
+	var workflowStatus workflow.Status = workflow.OK()
+	for _, opts := range statefulSetsOpts {
+		workflowStatus = workflowStatus.Merge(certs.EnsureCertManagerCertificateForStatefulSets(ctx, r.commonController.client, security, ownerReferences, opts...))
+		workflowStatus = workflowStatus.Merge(certs.EnsureVaultPKICertificateForStatefulSets(ctx, r.commonController.SecretClient, security, certs.Database, log, opts...))
+	}
+	return workflowStatus
+}
+
 // createKubernetesResources creates all Kubernetes objects that are specified in 'state' parameter.
 // This function returns errorStatus if any errors occurred or pendingStatus if the statefulsets are not
 // ready yet
@@ -1532,7 +1613,7 @@ func (r *ShardedClusterReconcileHelper) createOrUpdateShards(ctx context.Context
 			shardOpts := r.getShardOptions(ctx, *s, shardIdx, opts, log, memberCluster)
 			shardSts := construct.DatabaseStatefulSet(*s, shardOpts, log)
 
-			if pvcStatus := r.handlePVCResize(ctx, memberCluster, &shardSts, log); !pvcStatus.IsOK() {
+			if pvcStatus := r.handlePVCResize(ctx, memberCluster, shardOpts(*s).PodSpec.GetPersistence(), &shardSts, log); !pvcStatus.IsOK() {
 				return pvcStatus
 			}
 
@@ -1580,7 +1661,7 @@ func (r *ShardedClusterReconcileHelper) createOrUpdateConfigServers(ctx context.
 		configSrvOpts := r.getConfigServerOptions(ctx, *s, opts, log, memberCluster)
 		configSrvSts := construct.DatabaseStatefulSet(*s, configSrvOpts, log)
 
-		if pvcStatus := r.handlePVCResize(ctx, memberCluster, &configSrvSts, log); !pvcStatus.IsOK() {
+		if pvcStatus := r.handlePVCResize(ctx, memberCluster, configSrvOpts(*s).PodSpec.GetPersistence(), &configSrvSts, log); !pvcStatus.IsOK() {
 			return pvcStatus
 		}
 
@@ -1611,7 +1692,17 @@ func (r *ShardedClusterReconcileHelper) createOrUpdateConfigServers(ctx context.
 	return workflow.OK()
 }
 
-func (r *ShardedClusterReconcileHelper) handlePVCResize(ctx context.Context, memberCluster multicluster.MemberCluster, sts *appsv1.StatefulSet, log *zap.SugaredLogger) workflow.Status {
+func (r *ShardedClusterReconcileHelper) handlePVCResize(ctx context.Context, memberCluster multicluster.MemberCluster, persistence *v1.Persistence, sts *appsv1.StatefulSet, log *zap.SugaredLogger) workflow.Status {
+	expansionOptions, err := r.commonController.autoscaleStorage(ctx, r.sc, memberCluster.Name, memberCluster.Client, persistence, sts, log)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+	if len(expansionOptions) > 0 {
+		if _, err := r.updateStatus(ctx, r.sc, workflow.Pending("Expanding the volumes of statefulset %s", sts.Name), log, expansionOptions...); err != nil {
+			return workflow.Failed(xerrors.Errorf("error updating status: %w", err))
+		}
+	}
+
 	workflowStatus := create.HandlePVCResize(ctx, memberCluster.Client, sts, log)
 	if !workflowStatus.IsOK() {
 		return workflowStatus
@@ -1720,6 +1811,7 @@ func (r *ShardedClusterReconcileHelper) OnDelete(ctx context.Context, obj runtim
 	}
 
 	r.commonController.resourceWatcher.RemoveDependentWatchedResources(sc.ObjectKey())
+	certs.ForgetCertificateExpiries(sc.Namespace, sc.GetKind(), sc.Name)
 
 	return errs
 }
@@ -1797,9 +1889,16 @@ func logDiffOfProcessNames(acProcesses []string, healthyProcesses []string, log
 	}
 }
 
-func AddShardedClusterController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, memberClustersMap map[string]cluster.Cluster, backupEnableDelay time.Duration) error {
+func AddShardedClusterController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, memberClusterRegistry *multicluster.MemberClusterRegistry, memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker, backupEnableDelay time.Duration) error {
 	// Create a new controller
-	reconciler := newShardedClusterReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, multicluster.ClustersMapToClientMap(memberClustersMap), om.NewOpsManagerConnection, backupEnableDelay)
+	reconciler := newShardedClusterReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterRegistry.Clients(), om.NewOpsManagerConnection, backupEnableDelay)
+	reconciler.memberClusterRegistry = memberClusterRegistry
+	reconciler.recorder = mgr.GetEventRecorderFor(util.OperatorName)
+	volumeStatsReaders, err := newVolumeStatsReaders(mgr.GetConfig(), memberClusterRegistry)
+	if err != nil {
+		return err
+	}
+	reconciler.volumeStatsReaders = volumeStatsReaders
 	options := controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)} // nolint:forbidigo
 	c, err := controller.New(util.MongoDbShardedClusterController, mgr, options)
 	if err != nil {
@@ -1848,6 +1947,12 @@ func AddShardedClusterController(ctx context.Context, mgr manager.Manager, image
 		}
 	}
 
+	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &rolev1.MongoDBRole{},
+		&watch.ResourcesHandler{ResourceType: watch.MongoDBRole, ResourceWatcher: reconciler.resourceWatcher}))
+	if err != nil {
+		return err
+	}
+
 	// Watch for MongoDBSearch resources that reference ShardedCluster MongoDB resources
 	// Only enqueue reconciliation requests for ShardedCluster resources, not ReplicaSet or Standalone
 	shardedKubeClient := mgr.GetClient()
@@ -1873,11 +1978,19 @@ func AddShardedClusterController(ctx context.Context, mgr manager.Manager, image
 		return err
 	}
 
-	for clusterName, memberCluster := range memberClustersMap {
-		err = c.Watch(source.Kind[client.Object](memberCluster.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet()))
+	err = memberClusterRegistry.OnClusterAdded(func(clusterName string, memberCluster cluster.Cluster) error {
+		err := c.Watch(source.Kind[client.Object](memberCluster.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet()))
 		if err != nil {
 			return xerrors.Errorf("failed to set StatefulSet watch on member cluster %s: %w", clusterName, err)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := memberClusterHealthChecker.WatchResources(c, memberwatch.MongoDBResources); err != nil {
+		zap.S().Errorf("failed to watch for member cluster healthcheck: %s", err)
 	}
 
 	err = c.Watch(
@@ -1943,6 +2056,7 @@ type deploymentOptions struct {
 	finalizing           bool
 	processNames         []string
 	prometheusCertHash   string
+	maintenanceWindow    *maintenanceWindowGuard
 }
 
 // updateOmDeploymentShardedCluster performs OM registration operation for the sharded cluster. So the changes will be finally propagated
@@ -2107,7 +2221,7 @@ func (r *ShardedClusterReconcileHelper) publishDeployment(ctx context.Context, c
 	var finalProcesses []string
 	shardsRemoving := false
 	err = conn.ReadUpdateDeployment(
-		func(d om.Deployment) error {
+		opts.maintenanceWindow.wrap(func(d om.Deployment) error {
 			allProcesses := getAllProcesses(shards, configRs, mongosProcesses)
 			// it is not possible to disable internal cluster authentication once enabled
 			if sc.Spec.Security.GetInternalClusterAuthenticationMode() == "" && d.ExistingProcessesHaveInternalClusterAuthentication(allProcesses) {
@@ -2162,7 +2276,7 @@ func (r *ShardedClusterReconcileHelper) publishDeployment(ctx context.Context, c
 			finalProcesses = d.GetProcessNames(om.ShardedCluster{}, sc.Name)
 
 			return nil
-		},
+		}, log),
 		log,
 	)
 	if err != nil {
@@ -2418,16 +2532,26 @@ func buildReplicaSetFromProcesses(name string, members []om.Process, mdb *mdbv1.
 }
 
 // getConfigServerOptions returns the Options needed to build the StatefulSet for the config server.
+// mongodbImage returns the MongoDB image of the Pods, whose version is held back while the maintenance window is
+// closed.
+func (r *ShardedClusterReconcileHelper) mongodbImage(sc mdbv1.MongoDB) string {
+	lastAchievedVersion := ""
+	if r.deploymentState.LastAchievedSpec != nil {
+		lastAchievedVersion = r.deploymentState.LastAchievedSpec.Version
+	}
+	version := mongodbImageVersion(sc.Spec.MaintenanceWindow, sc.Spec.Version, lastAchievedVersion)
+	return images.GetOfficialImage(r.imageUrls, version, sc.GetAnnotations(), r.defaultArchitecture)
+}
+
 func (r *ShardedClusterReconcileHelper) getConfigServerOptions(ctx context.Context, sc mdbv1.MongoDB, opts deploymentOptions, log *zap.SugaredLogger, memberCluster multicluster.MemberCluster) func(mdb mdbv1.MongoDB) construct.DatabaseStatefulSetOptions {
 	certSecretName := sc.GetSecurity().MemberCertificateSecretName(sc.ConfigRsName())
 	internalClusterSecretName := sc.GetSecurity().InternalClusterAuthSecretName(sc.ConfigRsName())
 
 	var vaultConfig vault.VaultConfiguration
-	var databaseSecretPath string
 	if r.commonController.VaultClient != nil {
 		vaultConfig = r.commonController.VaultClient.VaultConfig
-		databaseSecretPath = r.commonController.VaultClient.DatabaseSecretPath()
 	}
+	databaseSecretPath := r.commonController.DatabaseSecretPath()
 
 	opts2 := []func(*construct.DatabaseStatefulSetOptions){
 		Replicas(scale.ReplicasThisReconciliation(r.GetConfigSrvScaler(memberCluster))),
@@ -2440,6 +2564,7 @@ func (r *ShardedClusterReconcileHelper) getConfigServerOptions(ctx context.Conte
 		InternalClusterHash(enterprisepem.ReadHashFromSecret(ctx, r.commonController.SecretClient, sc.Namespace, internalClusterSecretName, databaseSecretPath, log)),
 		PrometheusTLSCertHash(opts.prometheusCertHash),
 		WithVaultConfig(vaultConfig),
+		WithAWSSecretsConfig(r.commonController.awsSecretsConfig()),
 		WithAdditionalMongodConfig(r.desiredConfigServerConfiguration.GetAdditionalMongodConfig()),
 		WithDefaultConfigSrvStorageSize(),
 		WithStsLabels(r.statefulsetLabels()),
@@ -2448,7 +2573,7 @@ func (r *ShardedClusterReconcileHelper) getConfigServerOptions(ctx context.Conte
 		WithAgentImage(images.ContainerImage(r.imageUrls, util.AgentImageUrlEnv, r.automationAgentVersion)),
 		WithCustomAgentURL(r.commonController.customAgentURL),
 
-		WithMongodbImage(images.GetOfficialImage(r.imageUrls, sc.Spec.Version, sc.GetAnnotations(), r.defaultArchitecture)),
+		WithMongodbImage(r.mongodbImage(sc)),
 		WithAgentDebug(r.agentDebug),
 		WithAgentDebugImage(r.agentDebugImage),
 		WithDefaultArchitecture(r.defaultArchitecture),
@@ -2479,6 +2604,7 @@ func (r *ShardedClusterReconcileHelper) getMongosOptions(ctx context.Context, sc
 		InternalClusterHash(enterprisepem.ReadHashFromSecret(ctx, r.commonController.SecretClient, sc.Namespace, internalClusterSecretName, vaultConfig.DatabaseSecretPath, log)),
 		PrometheusTLSCertHash(opts.prometheusCertHash),
 		WithVaultConfig(vaultConfig),
+		WithAWSSecretsConfig(r.commonController.awsSecretsConfig()),
 		WithAdditionalMongodConfig(r.desiredMongosConfiguration.GetAdditionalMongodConfig()),
 		WithStsLabels(r.statefulsetLabels()),
 		WithInitDatabaseNonStaticImage(images.ContainerImage(r.imageUrls, util.InitDatabaseImageUrlEnv, r.initDatabaseNonStaticImageVersion)),
@@ -2486,7 +2612,7 @@ func (r *ShardedClusterReconcileHelper) getMongosOptions(ctx context.Context, sc
 		WithAgentImage(images.ContainerImage(r.imageUrls, util.AgentImageUrlEnv, r.automationAgentVersion)),
 		WithCustomAgentURL(r.commonController.customAgentURL),
 
-		WithMongodbImage(images.GetOfficialImage(r.imageUrls, sc.Spec.Version, sc.GetAnnotations(), r.defaultArchitecture)),
+		WithMongodbImage(r.mongodbImage(sc)),
 		WithAgentDebug(r.agentDebug),
 		WithAgentDebugImage(r.agentDebugImage),
 		WithDefaultArchitecture(r.defaultArchitecture),
@@ -2503,11 +2629,10 @@ func (r *ShardedClusterReconcileHelper) getShardOptions(ctx context.Context, sc
 	internalClusterSecretName := sc.GetSecurity().InternalClusterAuthSecretName(sc.ShardRsName(shardNum))
 
 	var vaultConfig vault.VaultConfiguration
-	var databaseSecretPath string
 	if r.commonController.VaultClient != nil {
 		vaultConfig = r.commonController.VaultClient.VaultConfig
-		databaseSecretPath = r.commonController.VaultClient.DatabaseSecretPath()
 	}
+	databaseSecretPath := r.commonController.DatabaseSecretPath()
 
 	opts2 := []func(*construct.DatabaseStatefulSetOptions){
 		Replicas(scale.ReplicasThisReconciliation(r.GetShardScaler(shardNum, memberCluster))),
@@ -2519,6 +2644,7 @@ func (r *ShardedClusterReconcileHelper) getShardOptions(ctx context.Context, sc
 		InternalClusterHash(enterprisepem.ReadHashFromSecret(ctx, r.commonController.SecretClient, sc.Namespace, internalClusterSecretName, databaseSecretPath, log)),
 		PrometheusTLSCertHash(opts.prometheusCertHash),
 		WithVaultConfig(vaultConfig),
+		WithAWSSecretsConfig(r.commonController.awsSecretsConfig()),
 		WithAdditionalMongodConfig(r.desiredShardsConfiguration[shardNum].GetAdditionalMongodConfig()),
 		WithStsLabels(r.statefulsetLabels()),
 		WithInitDatabaseNonStaticImage(images.ContainerImage(r.imageUrls, util.InitDatabaseImageUrlEnv, r.initDatabaseNonStaticImageVersion)),
@@ -2526,7 +2652,7 @@ func (r *ShardedClusterReconcileHelper) getShardOptions(ctx context.Context, sc
 		WithAgentImage(images.ContainerImage(r.imageUrls, util.AgentImageUrlEnv, r.automationAgentVersion)),
 		WithCustomAgentURL(r.commonController.customAgentURL),
 
-		WithMongodbImage(images.GetOfficialImage(r.imageUrls, sc.Spec.Version, sc.GetAnnotations(), r.defaultArchitecture)),
+		WithMongodbImage(r.mongodbImage(sc)),
 		WithAgentDebug(r.agentDebug),
 		WithAgentDebugImage(r.agentDebugImage),
 		WithDefaultArchitecture(r.defaultArchitecture),
@@ -2650,10 +2776,7 @@ func (r *ShardedClusterReconcileHelper) GetConfigSrvServiceName(memberCluster mu
 
 func (r *ShardedClusterReconcileHelper) replicateAgentKeySecret(ctx context.Context, conn om.Connection, agentKey string, log *zap.SugaredLogger) error {
 	for _, memberCluster := range getHealthyMemberClusters(r.allMemberClusters) {
-		var databaseSecretPath string
-		if memberCluster.SecretClient.VaultClient != nil {
-			databaseSecretPath = memberCluster.SecretClient.VaultClient.DatabaseSecretPath()
-		}
+		databaseSecretPath := memberCluster.SecretClient.DatabaseSecretPath()
 		if _, err := agents.EnsureAgentKeySecretExists(ctx, memberCluster.SecretClient, conn, r.sc.Namespace, agentKey, conn.GroupID(), databaseSecretPath, log); err != nil {
 			return xerrors.Errorf("failed to ensure agent key secret in member cluster %s: %w", memberCluster.Name, err)
 		}
@@ -39,6 +39,7 @@ import (
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/configmap"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/test"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -3843,3 +3844,93 @@ func getVisualJsonDiff(expectedMap map[string]interface{}, actualMap map[string]
 
 	return diffString, nil
 }
+
+func TestMultiClusterShardedFailoverPolicy(t *testing.T) {
+	cluster1 := "member-cluster-1"
+	cluster2 := "member-cluster-2"
+	cluster3 := "member-cluster-3"
+	memberClusterNames := []string{cluster1, cluster2, cluster3}
+	failedClusters, err := json.Marshal([]failedcluster.FailedCluster{{ClusterName: cluster3, Members: 1}})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		policy                    v1.FailoverPolicy
+		expectedShardMembers      map[string]int
+		expectedMongosMembers     map[string]int
+		expectedFailedShardVotes  *int
+		expectedHealthyShardVotes *int
+	}{
+		"Annotate keeps the members in the failed cluster": {
+			policy:                v1.FailoverPolicyAnnotate,
+			expectedShardMembers:  map[string]int{cluster1: 1, cluster2: 2, cluster3: 2},
+			expectedMongosMembers: map[string]int{cluster1: 1, cluster3: 1},
+		},
+		"RedistributeMembers moves the members to the healthy clusters": {
+			policy:                v1.FailoverPolicyRedistributeMembers,
+			expectedShardMembers:  map[string]int{cluster1: 3, cluster2: 2},
+			expectedMongosMembers: map[string]int{cluster1: 2},
+		},
+		"ReduceVotes removes the votes of the members in the failed cluster": {
+			policy:                    v1.FailoverPolicyReduceVotes,
+			expectedShardMembers:      map[string]int{cluster1: 1, cluster2: 2, cluster3: 2},
+			expectedMongosMembers:     map[string]int{cluster1: 1, cluster3: 1},
+			expectedFailedShardVotes:  ptr.To(0),
+			expectedHealthyShardVotes: ptr.To(1),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			sc := test.DefaultClusterBuilder().
+				SetTopology(mdbv1.ClusterTopologyMultiCluster).
+				SetAnnotations(map[string]string{failedcluster.FailedClusterAnnotation: string(failedClusters)}).
+				SetShardCountSpec(2).
+				SetMongodsPerShardCountSpec(0).
+				SetConfigServerCountSpec(0).
+				SetMongosCountSpec(0).
+				SetShardClusterSpec(test.CreateClusterSpecList(memberClusterNames, map[string]int{cluster1: 1, cluster2: 2, cluster3: 2})).
+				SetConfigSrvClusterSpec(test.CreateClusterSpecList(memberClusterNames, map[string]int{cluster1: 1, cluster2: 1, cluster3: 1})).
+				SetMongosClusterSpec(test.CreateClusterSpecList(memberClusterNames, map[string]int{cluster1: 1, cluster3: 1})).
+				Build()
+			sc.Spec.Failover = &v1.FailoverConfig{Policy: tc.policy}
+
+			omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+			fakeClient := mock.NewEmptyFakeClientBuilder().WithObjects(sc).WithObjects(mock.GetDefaultResources()...).Build()
+			kubeClient := kubernetesClient.NewClient(fakeClient)
+			memberClusterMap := getFakeMultiClusterMapWithoutInterceptor(memberClusterNames)
+
+			_, reconcilerHelper, err := newShardedClusterReconcilerForMultiCluster(ctx, false, sc, memberClusterMap, kubeClient, omConnectionFactory)
+			require.NoError(t, err)
+
+			for shardIdx, shard := range reconcilerHelper.desiredShardsConfiguration {
+				members := map[string]int{}
+				for _, item := range shard.ClusterSpecList {
+					members[item.ClusterName] = item.Members
+					if tc.expectedFailedShardVotes == nil {
+						continue
+					}
+					expectedVotes := tc.expectedHealthyShardVotes
+					if item.ClusterName == cluster3 {
+						expectedVotes = tc.expectedFailedShardVotes
+					}
+					require.Len(t, item.MemberConfig, item.Members)
+					for _, memberOptions := range item.MemberConfig {
+						assert.Equal(t, expectedVotes, memberOptions.Votes)
+					}
+				}
+				assert.Equal(t, tc.expectedShardMembers, members, "shard %d", shardIdx)
+			}
+
+			mongosMembers := map[string]int{}
+			for _, item := range reconcilerHelper.desiredMongosConfiguration.ClusterSpecList {
+				mongosMembers[item.ClusterName] = item.Members
+			}
+			assert.Equal(t, tc.expectedMongosMembers, mongosMembers)
+
+			// the failed cluster is skipped as if the operator had no client for it
+			for _, memberCluster := range reconcilerHelper.configSrvMemberClusters {
+				assert.Equal(t, memberCluster.Name != cluster3, memberCluster.Healthy, memberCluster.Name)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -22,12 +23,16 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status/pvc"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
@@ -105,6 +110,32 @@ func TestReconcileCreateShardedCluster(t *testing.T) {
 	mockedConn.CheckOperationsDidntHappen(t, reflect.ValueOf(mockedConn.GetHosts), reflect.ValueOf(mockedConn.RemoveHost))
 }
 
+func TestReconcileCreateShardedCluster_PodDisruptionBudgets(t *testing.T) {
+	ctx := context.Background()
+	sc := test.DefaultClusterBuilder().SetShardCountSpec(2).SetShardCountStatus(2).Build()
+
+	reconciler, _, c, _, err := defaultShardedClusterReconciler(ctx, nil, "", "", sc, nil, testBackupEnableDelay, architectures.NonStatic)
+	require.NoError(t, err)
+	checkReconcileSuccessful(ctx, t, reconciler, sc, c)
+
+	pdbs := policyv1.PodDisruptionBudgetList{}
+	require.NoError(t, c.List(ctx, &pdbs))
+	assert.Len(t, pdbs.Items, 4)
+	for _, name := range []string{sc.ConfigRsName(), sc.ShardRsName(0), sc.ShardRsName(1), sc.MongosRsName()} {
+		pdb := policyv1.PodDisruptionBudget{}
+		require.NoError(t, c.Get(ctx, kube.ObjectKey(sc.Namespace, name), &pdb))
+		// 3 voting members can lose one of them, mongos are evicted one at a time
+		assert.Equal(t, ptr.To(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable, name)
+	}
+
+	sc.Spec.ShardCount = 1
+	require.NoError(t, c.Update(ctx, sc))
+	checkReconcileSuccessful(ctx, t, reconciler, sc, c)
+
+	err = c.Get(ctx, kube.ObjectKey(sc.Namespace, sc.ShardRsName(1)), &policyv1.PodDisruptionBudget{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
 // TestReconcileCreateSingleClusterShardedClusterWithNoServiceMeshSimplest assumes only Services for Mongos
 // will be created.
 func TestReconcileCreateSingleClusterShardedClusterWithExternalDomainSimplest(t *testing.T) {
@@ -1940,3 +1971,35 @@ func generateAllHostsSingleCluster(sc *mdbv1.MongoDB, mongosCount int, configSrv
 	}
 	return allHosts, allPodNames
 }
+
+func TestShardedClusterLookupCorrespondingSearchResource_IgnoresSearchOfOtherNamespaceWithoutGrant(t *testing.T) {
+	ctx := context.Background()
+	sc := test.DefaultClusterBuilder().Build()
+	search := &searchv1.MongoDBSearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "sc-search", Namespace: "other"},
+		Spec: searchv1.MongoDBSearchSpec{
+			Clusters: []searchv1.ClusterSpec{{}},
+			Source:   &searchv1.MongoDBSource{MongoDBResourceRef: &userv1.MongoDBResourceRef{Name: sc.Name, Namespace: sc.Namespace}},
+		},
+	}
+	lookup := func(c client.Client) (*searchv1.MongoDBSearch, error) {
+		helper := &ShardedClusterReconcileHelper{sc: sc, commonController: NewReconcileCommonController(ctx, c)}
+		return helper.lookupCorrespondingSearchResource(ctx)
+	}
+
+	found, err := lookup(mock.NewEmptyFakeClientBuilder().WithObjects(search).Build())
+	require.NoError(t, err)
+	assert.Nil(t, found, "the search of another namespace must not configure the sharded cluster without a grant")
+
+	grant := &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: sc.Namespace},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBSearch, Namespace: "other"}},
+			To:   []referencegrant.ReferenceGrantTo{{Kind: referencegrant.KindMongoDB}},
+		},
+	}
+	found, err = lookup(mock.NewEmptyFakeClientBuilder().WithObjects(search, grant).Build())
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "other", found.Namespace)
+}
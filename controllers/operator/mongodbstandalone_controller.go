@@ -3,6 +3,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
@@ -40,6 +41,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/annotations"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -54,6 +56,12 @@ import (
 func AddStandaloneController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture) error {
 	// Create a new controller
 	reconciler := newStandaloneReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, om.NewOpsManagerConnection)
+	reconciler.recorder = mgr.GetEventRecorderFor(util.OperatorName)
+	volumeStatsReaders, err := newVolumeStatsReaders(mgr.GetConfig(), nil)
+	if err != nil {
+		return err
+	}
+	reconciler.volumeStatsReaders = volumeStatsReaders
 	c, err := controller.New(util.MongoDbStandaloneController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
@@ -95,6 +103,12 @@ func AddStandaloneController(ctx context.Context, mgr manager.Manager, imageUrls
 		}
 	}
 
+	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &rolev1.MongoDBRole{},
+		&watch.ResourcesHandler{ResourceType: watch.MongoDBRole, ResourceWatcher: reconciler.resourceWatcher}))
+	if err != nil {
+		return err
+	}
+
 	// if vault secret backend is enabled watch for Vault secret change and trigger reconcile
 	if vault.IsVaultSecretBackend() {
 		eventChannel := make(chan event.GenericEvent)
@@ -184,6 +198,7 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 	}
 
 	r.SetupCommonWatchers(s, nil, nil, s.Name)
+	r.checkCertificatesExpiry(ctx, s, nil, nil, s.Name, log)
 
 	reconcileResult := checkIfHasExcessProcesses(conn, s.Name, log)
 	if !reconcileResult.IsOK() {
@@ -212,6 +227,14 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 		return r.updateStatus(ctx, s, status, log)
 	}
 
+	if status := certs.EnsureCertManagerCertificateForStatefulSets(ctx, r.client, *s.Spec.Security, kube.BaseOwnerReference(s), certs.StandaloneConfig(*s)); !status.IsOK() {
+		return r.updateStatus(ctx, s, status, log)
+	}
+
+	if status := certs.EnsureVaultPKICertificateForStatefulSets(ctx, r.SecretClient, *s.Spec.Security, certs.Database, log, certs.StandaloneConfig(*s)); !status.IsOK() {
+		return r.updateStatus(ctx, s, status, log)
+	}
+
 	if status := certs.EnsureSSLCertsForStatefulSet(ctx, r.SecretClient, r.SecretClient, *s.Spec.Security, certs.StandaloneConfig(*s), log); !status.IsOK() {
 		return r.updateStatus(ctx, s, status, log)
 	}
@@ -237,10 +260,7 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 	}
 	standaloneCertSecretName := certs.StandaloneConfig(*s).CertSecretName
 
-	var databaseSecretPath string
-	if r.VaultClient != nil {
-		databaseSecretPath = r.VaultClient.DatabaseSecretPath()
-	}
+	databaseSecretPath := r.DatabaseSecretPath()
 
 	var automationAgentVersion string
 	if architectures.IsRunningStaticArchitecture(s.Annotations, r.defaultArchitecture) {
@@ -256,18 +276,24 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 		}
 	}
 
+	lastAchievedVersion := ""
+	if lastSpec, err := s.GetLastSpec(); err == nil && lastSpec != nil {
+		lastAchievedVersion = lastSpec.Version
+	}
+
 	standaloneOpts := construct.StandaloneOptions(
 		CertificateHash(pem.ReadHashFromSecret(ctx, r.SecretClient, s.Namespace, standaloneCertSecretName, databaseSecretPath, log)),
 		CurrentAgentAuthMechanism(currentAgentAuthMode),
 		PodEnvVars(podVars),
 		WithVaultConfig(vaultConfig),
+		WithAWSSecretsConfig(r.awsSecretsConfig()),
 		WithAdditionalMongodConfig(s.Spec.GetAdditionalMongodConfig()),
 		WithInitDatabaseNonStaticImage(images.ContainerImage(r.imageUrls, util.InitDatabaseImageUrlEnv, r.initDatabaseNonStaticImageVersion)),
 		WithDatabaseNonStaticImage(images.ContainerImage(r.imageUrls, util.NonStaticDatabaseEnterpriseImage, r.databaseNonStaticImageVersion)),
 		WithAgentImage(images.ContainerImage(r.imageUrls, util.AgentImageUrlEnv, automationAgentVersion)),
 		WithCustomAgentURL(r.customAgentURL),
 
-		WithMongodbImage(images.GetOfficialImage(r.imageUrls, s.Spec.Version, s.GetAnnotations(), r.defaultArchitecture)),
+		WithMongodbImage(images.GetOfficialImage(r.imageUrls, mongodbImageVersion(s.Spec.MaintenanceWindow, s.Spec.Version, lastAchievedVersion), s.GetAnnotations(), r.defaultArchitecture)),
 		WithAgentDebug(r.agentDebug),
 		WithAgentDebugImage(r.agentDebugImage),
 		WithDefaultArchitecture(r.defaultArchitecture),
@@ -275,6 +301,20 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 
 	sts := construct.DatabaseStatefulSet(*s, standaloneOpts, log)
 
+	if status := applySnapshotRestore(ctx, r.client, s.Spec.RestoreFromSnapshot, &sts); !status.IsOK() {
+		return r.updateStatus(ctx, s, status, log)
+	}
+
+	expansionOptions, err := r.autoscaleStorage(ctx, s, multicluster.LegacyCentralClusterName, r.client, s.Spec.PodSpec.GetPersistence(), &sts, log)
+	if err != nil {
+		return r.updateStatus(ctx, s, workflow.Failed(err), log)
+	}
+	if len(expansionOptions) > 0 {
+		if _, err := r.updateStatus(ctx, s, workflow.Pending("Expanding the volumes of statefulset %s", sts.Name), log, expansionOptions...); err != nil {
+			return r.updateStatus(ctx, s, workflow.Failed(xerrors.Errorf("error updating status: %w", err)), log)
+		}
+	}
+
 	workflowStatus := create.HandlePVCResize(ctx, r.client, &sts, log)
 	if !workflowStatus.IsOK() {
 		return r.updateStatus(ctx, s, workflowStatus, log)
@@ -288,9 +328,10 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 	agentCertSecretName := s.GetSecurity().AgentClientCertificateSecretName(s.Name)
 	_, agentCertPath := r.agentCertHashAndPath(ctx, log, s.Namespace, agentCertSecretName, databaseSecretPath)
 
+	maintenanceWindow := newMaintenanceWindowGuard(s.Spec.MaintenanceWindow, time.Now())
 	status := workflow.RunInGivenOrder(publishAutomationConfigFirst(ctx, r.client, *s, lastSpec, standaloneOpts, r.defaultArchitecture, log),
 		func() workflow.Status {
-			return r.updateOmDeployment(ctx, conn, s, sts, false, agentCertPath, log).OnErrorPrepend("Failed to create/update (Ops Manager reconciliation phase):")
+			return r.updateOmDeployment(ctx, conn, s, sts, maintenanceWindow, false, agentCertPath, log).OnErrorPrepend("Failed to create/update (Ops Manager reconciliation phase):")
 		},
 		func() workflow.Status {
 			mutatedSts, err := create.DatabaseInKubernetes(ctx, r.client, *s, sts, standaloneOpts, log)
@@ -306,6 +347,8 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 			log.Info("Updated StatefulSet for standalone")
 			return workflow.OK()
 		})
+	// the deferred changes don't stop the StatefulSet from being reconciled, they only keep the resource pending
+	status = status.Merge(maintenanceWindow.status())
 
 	if !status.IsOK() {
 		return r.updateStatus(ctx, s, status, log)
@@ -343,10 +386,11 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 	}
 
 	log.Infof("Finished reconciliation for MongoDbStandalone! %s", completionMessage(conn.BaseURL(), conn.GroupID()))
-	return r.updateStatus(ctx, s, status, log, mdbstatus.NewBaseUrlOption(deployment.Link(conn.BaseURL(), conn.GroupID())), mdbstatus.NewProjectIdOption(conn.GroupID()))
+	result, err := r.updateStatus(ctx, s, status, log, mdbstatus.NewBaseUrlOption(deployment.Link(conn.BaseURL(), conn.GroupID())), mdbstatus.NewProjectIdOption(conn.GroupID()))
+	return requeueForStorageAutoscale(s.Spec.HasStorageAutoscale(), result, err)
 }
 
-func (r *ReconcileMongoDbStandalone) updateOmDeployment(ctx context.Context, conn om.Connection, s *mdbv1.MongoDB, set appsv1.StatefulSet, isRecovering bool, agentCertPath string, log *zap.SugaredLogger) workflow.Status {
+func (r *ReconcileMongoDbStandalone) updateOmDeployment(ctx context.Context, conn om.Connection, s *mdbv1.MongoDB, set appsv1.StatefulSet, maintenanceWindow *maintenanceWindowGuard, isRecovering bool, agentCertPath string, log *zap.SugaredLogger) workflow.Status {
 	if err := agents.WaitForRsAgentsToRegister(set, 0, s.Spec.GetClusterDomain(), conn, log, s); err != nil {
 		return workflow.Failed(err)
 	}
@@ -359,7 +403,7 @@ func (r *ReconcileMongoDbStandalone) updateOmDeployment(ctx context.Context, con
 
 	standaloneOmObject := createProcess(r.imageUrls[util.MongodbImageEnv], r.forceEnterprise, set, util.DatabaseContainerName, s, r.defaultArchitecture)
 	err := conn.ReadUpdateDeployment(
-		func(d om.Deployment) error {
+		maintenanceWindow.wrap(func(d om.Deployment) error {
 			excessProcesses := d.GetNumberOfExcessProcesses(s.Name)
 			if excessProcesses > 0 {
 				return xerrors.Errorf("cannot have more than 1 MongoDB Cluster per project (see https://docs.mongodb.com/kubernetes-operator/stable/tutorial/migrate-to-single-resource/)")
@@ -375,7 +419,7 @@ func (r *ReconcileMongoDbStandalone) updateOmDeployment(ctx context.Context, con
 			d.ConfigureMonitoringAndBackup(log, s.Spec.GetSecurity().IsTLSEnabled(), util.CAFilePathInContainer)
 			d.ConfigureTLS(s.Spec.GetSecurity(), util.CAFilePathInContainer)
 			return nil
-		},
+		}, log),
 		log,
 	)
 	if err != nil {
@@ -446,6 +490,7 @@ func (r *ReconcileMongoDbStandalone) OnDelete(ctx context.Context, obj runtime.O
 	}
 
 	r.resourceWatcher.RemoveDependentWatchedResources(s.ObjectKey())
+	certs.ForgetCertificateExpiries(s.Namespace, s.GetKind(), s.Name)
 
 	log.Infow("Clear feature control for group: %s", "groupID", conn.GroupID())
 	if result := controlledfeature.ClearFeatureControls(conn, conn.OpsManagerVersion(), log); !result.IsOK() {
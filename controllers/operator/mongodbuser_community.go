@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1" //nolint:depguard
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+// getMongoDBCommunity returns the MongoDBCommunity resource referenced by the user.
+func (r *MongoDBUserReconciler) getMongoDBCommunity(ctx context.Context, user userv1.MongoDBUser) (*mdbcv1.MongoDBCommunity, error) {
+	mdbc := &mdbcv1.MongoDBCommunity{}
+	if err := r.client.Get(ctx, getMongoDBObjectKey(user), mdbc); err != nil {
+		return nil, err
+	}
+	return mdbc, nil
+}
+
+// reconcileCommunityUser reconciles a user referencing a MongoDBCommunity resource. The MongoDBCommunity controller
+// adds the user to the automation config and publishes its connection string Secret, so only the password is
+// managed here. The user is Updated once the MongoDBCommunity resource has been reconciled with it.
+func (r *MongoDBUserReconciler) reconcileCommunityUser(ctx context.Context, user *userv1.MongoDBUser, mdbc *mdbcv1.MongoDBCommunity, log *zap.SugaredLogger) (reconcile.Result, error) {
+	// the MongoDBCommunity controller removes the user once the MongoDBUser is deleted, the finalizer is only
+	// there if the user referenced a MongoDB resource before
+	if controllerutil.RemoveFinalizer(user, util.UserFinalizer) {
+		if err := r.client.Update(ctx, user); err != nil {
+			return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to update the user with the removed finalizer: %w", err)), log)
+		}
+	}
+	if !user.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	if err := validateCommunityUser(*user, *mdbc); err != nil {
+		return r.updateStatus(ctx, user, workflow.Invalid("%s", err.Error()), log)
+	}
+
+	if user.Spec.Database != authentication.ExternalDB {
+		if err := validatePasswordPolicy(*user); err != nil {
+			return r.updateStatus(ctx, user, workflow.Invalid("%s", err.Error()), log)
+		}
+		if err := r.reconcilePasswordPolicy(ctx, user, time.Now(), log); err != nil {
+			return r.updateStatus(ctx, user, workflow.Failed(err), log)
+		}
+	}
+
+	applied, err := isUserAppliedToMongoDBCommunity(*user, *mdbc)
+	if err != nil {
+		return r.updateStatus(ctx, user, workflow.Failed(err), log)
+	}
+	if !applied {
+		return r.updateStatus(ctx, user, workflow.Pending("Waiting for MongoDBCommunity %s to configure the user", mdbc.Name).WithRetry(10), log)
+	}
+
+	log.Infof("Finished reconciliation for MongoDBUser!")
+	return r.updateStatus(ctx, user, workflow.OK(), log)
+}
+
+// validateCommunityUser checks that the user can be configured by the MongoDBCommunity controller.
+func validateCommunityUser(user userv1.MongoDBUser, mdbc mdbcv1.MongoDBCommunity) error {
+	if user.Namespace != mdbc.Namespace {
+		return xerrors.Errorf("MongoDBCommunity %s/%s can only be referenced by the MongoDBUsers in its namespace", mdbc.Namespace, mdbc.Name)
+	}
+	if user.Spec.PasswordPolicy.IsRotationEnabled() {
+		return xerrors.Errorf("spec.passwordPolicy.rotationInterval is not supported for the users of MongoDBCommunity resources")
+	}
+	if user.Spec.X509.IsIssueEnabled() {
+		return xerrors.Errorf("spec.x509.issue is not supported for the users of MongoDBCommunity resources")
+	}
+	return nil
+}
+
+// isUserAppliedToMongoDBCommunity returns true if the last configuration successfully applied by the MongoDBCommunity
+// controller contains the user.
+func isUserAppliedToMongoDBCommunity(user userv1.MongoDBUser, mdbc mdbcv1.MongoDBCommunity) (bool, error) {
+	lastSpecString, ok := mdbc.Annotations[util.LastAchievedSpec]
+	if !ok {
+		return false, nil
+	}
+
+	lastSpec := mdbcv1.MongoDBCommunitySpec{}
+	if err := json.Unmarshal([]byte(lastSpecString), &lastSpec); err != nil {
+		return false, xerrors.Errorf("failed to read the last configuration of MongoDBCommunity %s: %w", mdbc.Name, err)
+	}
+
+	for _, u := range lastSpec.Users {
+		if u.Name == user.Spec.Username && u.DB == user.Spec.Database {
+			return true, nil
+		}
+	}
+	return false, nil
+}
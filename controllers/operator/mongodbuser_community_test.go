@@ -0,0 +1,89 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func TestCommunityUser_IsUpdatedOnceConfiguredByMongoDBCommunity(t *testing.T) {
+	ctx := context.Background()
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-mdbc").Build()
+	reconciler, client, _ := defaultUserReconciler(ctx, user)
+
+	mdbc := newMongoDBCommunity("my-mdbc", mock.TestNamespace)
+	require.NoError(t, client.Create(ctx, mdbc))
+	createPasswordSecret(ctx, client, user.Spec.PasswordSecretKeyRef, "password")
+
+	request := reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)}
+	actual, err := reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, actual.RequeueAfter)
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, status.PhasePending, user.Status.Phase)
+	assert.Empty(t, user.Finalizers)
+
+	// the connection string Secret is published by the MongoDBCommunity controller
+	err = client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetConnectionStringSecretName()), &corev1.Secret{})
+	assert.Error(t, err)
+
+	lastSpec, err := json.Marshal(mdbcv1.MongoDBCommunitySpec{Users: []mdbcv1.MongoDBUser{{Name: user.Spec.Username, DB: user.Spec.Database}}})
+	require.NoError(t, err)
+	mdbc.Annotations = map[string]string{util.LastAchievedSpec: string(lastSpec)}
+	require.NoError(t, client.Update(ctx, mdbc))
+
+	_, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, status.PhaseUpdated, user.Status.Phase)
+	assert.Equal(t, user.Spec.Username, user.Status.Username)
+}
+
+func TestCommunityUser_IsValidated(t *testing.T) {
+	mdbc := newMongoDBCommunity("my-mdbc", mock.TestNamespace)
+
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-mdbc").Build()
+	assert.NoError(t, validateCommunityUser(*user, *mdbc))
+
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true, RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+	assert.ErrorContains(t, validateCommunityUser(*user, *mdbc), "rotationInterval is not supported")
+
+	user = DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-mdbc").SetDatabase("$external").Build()
+	user.Spec.X509 = &userv1.X509{Issue: &userv1.X509Issue{CASecretRef: &corev1.LocalObjectReference{Name: "my-ca"}}}
+	assert.ErrorContains(t, validateCommunityUser(*user, *mdbc), "spec.x509.issue is not supported")
+
+	user = DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-mdbc").SetNamespace("other-namespace").Build()
+	assert.ErrorContains(t, validateCommunityUser(*user, *mdbc), "can only be referenced by the MongoDBUsers in its namespace")
+}
+
+func TestCommunityUser_PasswordIsGenerated(t *testing.T) {
+	ctx := context.Background()
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-mdbc").SetPasswordRef("my-user-password", "").Build()
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true}
+	reconciler, client, _ := defaultUserReconciler(ctx, user)
+	require.NoError(t, client.Create(ctx, newMongoDBCommunity("my-mdbc", mock.TestNamespace)))
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
+	require.NoError(t, err)
+
+	passwordSecret := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, "my-user-password"), passwordSecret))
+	assert.Len(t, passwordSecret.Data[userv1.DefaultPasswordSecretKey], userv1.DefaultPasswordLength)
+}
@@ -9,7 +9,6 @@ import (
 	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -22,10 +21,12 @@ import (
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdbmulti"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connection"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connectionstring"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
@@ -47,6 +48,7 @@ type MongoDBUserReconciler struct {
 	omConnectionFactory           om.ConnectionFactory
 	memberClusterClientsMap       map[string]kubernetesClient.Client
 	memberClusterSecretClientsMap map[string]secrets.SecretClient
+	memberClusterRegistry         *multicluster.MemberClusterRegistry
 	backupEnableDelay             time.Duration
 }
 
@@ -94,6 +96,23 @@ func getMongoDBObjectKey(user userv1.MongoDBUser) client.ObjectKey {
 	return kube.ObjectKey(mongoDBResourceNamespace, user.Spec.MongoDBResourceRef.Name)
 }
 
+func userReference(user userv1.MongoDBUser) referencegrant.Reference {
+	return referencegrant.Reference{Kind: referencegrant.KindMongoDBUser, Namespace: user.Namespace, Name: user.Name}
+}
+
+// mongoDBReference returns the reference to the MongoDB or MongoDBMultiCluster resource of a MongoDBResourceRef.
+func mongoDBReference(ref userv1.MongoDBResourceRef, mdb project.Reader, defaultNamespace string) referencegrant.Reference {
+	kind := referencegrant.KindMongoDB
+	if _, ok := mdb.(*mdbmulti.MongoDBMultiCluster); ok {
+		kind = referencegrant.KindMongoDBMultiCluster
+	}
+	namespace := defaultNamespace
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return referencegrant.Reference{Kind: kind, Namespace: namespace, Name: ref.Name}
+}
+
 // getMongoDB return a MongoDB deployment of type Single or Multi cluster based on the clusterType passed
 func (r *MongoDBUserReconciler) getMongoDB(ctx context.Context, user userv1.MongoDBUser) (project.Reader, error) {
 	name := getMongoDBObjectKey(user)
@@ -148,7 +167,26 @@ func (r *MongoDBUserReconciler) getShardedClusterHostnames(ctx context.Context,
 	return hostnames, nil
 }
 
+// getMemberClusterSecretClientsMap returns the secret clients of the member clusters currently registered with the operator.
+func (r *MongoDBUserReconciler) getMemberClusterSecretClientsMap() map[string]secrets.SecretClient {
+	if r.memberClusterRegistry == nil {
+		return r.memberClusterSecretClientsMap
+	}
+	secretClientsMap := make(map[string]secrets.SecretClient)
+	for k, v := range r.memberClusterRegistry.KubeClients() {
+		secretClientsMap[k] = secrets.SecretClient{
+			VaultClient: nil,
+			KubeClient:  v,
+		}
+	}
+	return secretClientsMap
+}
+
 func (r *MongoDBUserReconciler) getK8sClientMap() map[string]client.Client {
+	if r.memberClusterRegistry != nil {
+		return r.memberClusterRegistry.Clients()
+	}
+
 	result := make(map[string]client.Client)
 	for k, v := range r.memberClusterClientsMap {
 		result[k] = v
@@ -158,6 +196,7 @@ func (r *MongoDBUserReconciler) getK8sClientMap() map[string]client.Client {
 }
 
 // +kubebuilder:rbac:groups=mongodb.com,resources={mongodbusers,mongodbusers/status,mongodbusers/finalizers},verbs=*,namespace=placeholder
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=create;get;list;watch;delete;update,namespace=placeholder
 
 // Reconciles a mongodbusers.mongodb.com Custom resource.
 func (r *MongoDBUserReconciler) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, e error) {
@@ -172,9 +211,15 @@ func (r *MongoDBUserReconciler) Reconcile(ctx context.Context, request reconcile
 
 	log.Infow("MongoDBUser.Spec", "spec", user.Spec)
 	var mdb project.Reader
+	// revoked is the status of a reference to a MongoDB resource whose MongoDBReferenceGrant has been revoked
+	var revoked workflow.Status
 
 	if user.Spec.MongoDBResourceRef.Name != "" {
 		if mdb, err = r.getMongoDB(ctx, *user); err != nil {
+			if mdbc, communityErr := r.getMongoDBCommunity(ctx, *user); communityErr == nil {
+				return r.reconcileCommunityUser(ctx, user, mdbc, log)
+			}
+
 			log.Warnf("Couldn't fetch MongoDB Single/Multi Cluster Resource with name: %s, namespace: %s, err: %s",
 				user.Spec.MongoDBResourceRef.Name, user.Spec.MongoDBResourceRef.Namespace, err)
 
@@ -188,6 +233,15 @@ func (r *MongoDBUserReconciler) Reconcile(ctx context.Context, request reconcile
 
 			return r.updateStatus(ctx, user, workflow.Pending("%s", err.Error()), log)
 		}
+
+		// a user being deleted is still removed from the deployment once its grant is revoked
+		if user.DeletionTimestamp.IsZero() {
+			if status := validateReference(ctx, r.client, userReference(*user), mongoDBReference(user.Spec.MongoDBResourceRef, mdb, user.Namespace)); isReferenceNotAllowed(status) {
+				revoked = status
+			} else if !status.IsOK() {
+				return r.updateStatus(ctx, user, status, log)
+			}
+		}
 	} else {
 		log.Warn("MongoDB reference not specified. Using deprecated project field.")
 	}
@@ -210,8 +264,23 @@ func (r *MongoDBUserReconciler) Reconcile(ctx context.Context, request reconcile
 		return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to prepare Ops Manager connection: %w", err)), log)
 	}
 
-	if err = r.updateConnectionStringSecret(ctx, *user, log); err != nil {
-		return r.updateStatus(ctx, user, workflow.Failed(err), log)
+	if revoked != nil {
+		return r.revokeAccess(ctx, user, conn, revoked, log)
+	}
+
+	if user.DeletionTimestamp.IsZero() {
+		if err := validateX509(*user); err != nil {
+			return r.updateStatus(ctx, user, workflow.Invalid("%s", err.Error()), log)
+		}
+	}
+
+	if user.Spec.Database != authentication.ExternalDB && user.DeletionTimestamp.IsZero() {
+		if err := validatePasswordPolicy(*user); err != nil {
+			return r.updateStatus(ctx, user, workflow.Invalid("%s", err.Error()), log)
+		}
+		if err := r.reconcilePasswordPolicy(ctx, user, time.Now(), log); err != nil {
+			return r.updateStatus(ctx, user, workflow.Failed(err), log)
+		}
 	}
 
 	if !user.DeletionTimestamp.IsZero() {
@@ -285,19 +354,21 @@ func (r *MongoDBUserReconciler) updateConnectionStringSecret(ctx context.Context
 		}
 	}
 
-	mongoAuthUserURI := connectionBuilder.BuildConnectionString(user.Spec.Username, password, connectionstring.SchemeMongoDB, map[string]string{"authSource": user.Spec.Database})
-	mongoAuthUserSRVURI := connectionBuilder.BuildConnectionString(user.Spec.Username, password, connectionstring.SchemeMongoDBSRV, map[string]string{"authSource": user.Spec.Database})
+	// the connection string points at the shadow user if the password has been rotated to it
+	username := user.ActiveUsername()
+	mongoAuthUserURI := connectionBuilder.BuildConnectionString(username, password, connectionstring.SchemeMongoDB, map[string]string{"authSource": user.Spec.Database})
+	mongoAuthUserSRVURI := connectionBuilder.BuildConnectionString(username, password, connectionstring.SchemeMongoDBSRV, map[string]string{"authSource": user.Spec.Database})
 
 	memberClusterSecret := secret.Builder().
 		SetName(secretName).
 		SetNamespace(user.Namespace).
 		SetField("connectionString.standard", mongoAuthUserURI).
 		SetField("connectionString.standardSrv", mongoAuthUserSRVURI).
-		SetField("username", user.Spec.Username).
+		SetField("username", username).
 		SetField("password", password).
 		Build()
 
-	for _, c := range r.memberClusterSecretClientsMap {
+	for _, c := range r.getMemberClusterSecretClientsMap() {
 		err = secret.CreateOrUpdate(ctx, c, memberClusterSecret)
 		if err != nil {
 			return err
@@ -311,8 +382,9 @@ func (r *MongoDBUserReconciler) updateConnectionStringSecret(ctx context.Context
 	return secret.CreateOrUpdate(ctx, r.SecretClient, centralClusterSecret)
 }
 
-func AddMongoDBUserController(ctx context.Context, mgr manager.Manager, memberClustersMap map[string]cluster.Cluster, backupEnableDelay time.Duration) error {
-	reconciler := newMongoDBUserReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection, multicluster.ClustersMapToClientMap(memberClustersMap), backupEnableDelay)
+func AddMongoDBUserController(ctx context.Context, mgr manager.Manager, memberClusterRegistry *multicluster.MemberClusterRegistry, backupEnableDelay time.Duration) error {
+	reconciler := newMongoDBUserReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection, memberClusterRegistry.Clients(), backupEnableDelay)
+	reconciler.memberClusterRegistry = memberClusterRegistry
 	c, err := controller.New(util.MongoDbUserController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
@@ -337,6 +409,12 @@ func AddMongoDBUserController(ctx context.Context, mgr manager.Manager, memberCl
 		return err
 	}
 
+	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &referencegrant.MongoDBReferenceGrant{},
+		enqueueGrantedReferrers(mgr.GetClient(), referencegrant.KindMongoDBUser, func() client.ObjectList { return &userv1.MongoDBUserList{} })))
+	if err != nil {
+		return err
+	}
+
 	zap.S().Infof("Registered controller %s", util.MongoDbUserController)
 	return nil
 }
@@ -379,6 +457,8 @@ func (r *MongoDBUserReconciler) handleScramShaUser(ctx context.Context, user *us
 	}
 
 	shouldRetry := false
+	retiredPreviousUser := false
+	now := time.Now()
 	err := conn.ReadUpdateAutomationConfig(func(ac *om.AutomationConfig) error {
 		if ac.Auth.Disabled ||
 			(!stringutil.ContainsAny(ac.Auth.DeploymentAuthMechanisms, util.AutomationConfigScramSha256Option, util.AutomationConfigScramSha1Option)) {
@@ -393,15 +473,23 @@ func (r *MongoDBUserReconciler) handleScramShaUser(ctx context.Context, user *us
 
 		auth := ac.Auth
 		if user.ChangedIdentifier() { // we've changed username or database, we need to remove the old user before adding new
-			auth.RemoveUser(user.Status.Username, user.Status.Database)
+			for _, username := range user.PreviousUsernames() {
+				auth.EnsureUserRemoved(username, user.Status.Database)
+			}
+			user.Status.PasswordRotation = nil
 		}
 
-		desiredUser, err := toOmUser(user.Spec, password, ac)
+		// the password is set for the active user only, the user holding the previous password is kept as it is
+		// until the grace period has passed
+		desiredSpec := user.Spec
+		desiredSpec.Username = user.ActiveUsername()
+		desiredUser, err := toOmUser(desiredSpec, password, ac)
 		if err != nil {
 			return err
 		}
 
 		auth.EnsureUser(desiredUser)
+		retiredPreviousUser = retirePreviousUser(*user, ac, now)
 		return nil
 	}, log)
 	if err != nil {
@@ -418,6 +506,18 @@ func (r *MongoDBUserReconciler) handleScramShaUser(ctx context.Context, user *us
 		return r.updateStatus(ctx, user, workflow.Pending("error waiting for ready state: %s", err.Error()).WithRetry(10), log)
 	}
 
+	// the connection string is published once the agents have created the active user, so that clients never get the
+	// credentials of a user which doesn't exist yet
+	if err := r.updateConnectionStringSecret(ctx, *user, log); err != nil {
+		return r.updateStatus(ctx, user, workflow.Failed(err), log)
+	}
+
+	if retiredPreviousUser {
+		log.Infof("Removed user %s holding the previous password", user.Status.PasswordRotation.RetiringUsername)
+		user.Status.PasswordRotation.RetiringUsername = ""
+		user.Status.PasswordRotation.RetireAfter = ""
+	}
+
 	annotationsToAdd, err := getAnnotationsForUserResource(user)
 	if err != nil {
 		return r.updateStatus(ctx, user, workflow.Failed(err), log)
@@ -428,10 +528,38 @@ func (r *MongoDBUserReconciler) handleScramShaUser(ctx context.Context, user *us
 	}
 
 	log.Infof("Finished reconciliation for MongoDBUser!")
-	return r.updateStatus(ctx, user, workflow.OK(), log, mdbstatus.NewProjectIdOption(conn.GroupID()))
+	okStatus := workflow.OK()
+	if next := nextPasswordPolicyEvent(*user, now); next > 0 {
+		okStatus = okStatus.WithRetry(int(next.Seconds()))
+	}
+	return r.updateStatus(ctx, user, okStatus, log, mdbstatus.NewProjectIdOption(conn.GroupID()))
 }
 
 func (r *MongoDBUserReconciler) handleExternalAuthUser(ctx context.Context, user *userv1.MongoDBUser, conn om.Connection, log *zap.SugaredLogger) (reconcile.Result, error) {
+	now := time.Now()
+	desiredSpec := user.Spec
+	var certificateStatus *userv1.CertificateStatus
+	var nextRenewal time.Duration
+	if user.Spec.X509.IsIssueEnabled() {
+		cert, certStatus := r.ensureX509Certificate(ctx, user, now, log)
+		if !certStatus.IsOK() {
+			return r.updateStatus(ctx, user, certStatus, log)
+		}
+		// the user is named after the subject of the certificate issued, which can differ from the one requested
+		// if the issuer changes it
+		subject, err := certs.CertificateSubject(cert)
+		if err != nil {
+			return r.updateStatus(ctx, user, workflow.Failed(err), log)
+		}
+		desiredSpec.Username = subject
+		certificateStatus = &userv1.CertificateStatus{
+			SecretName: user.GetX509CertificateSecretName(),
+			Subject:    subject,
+			NotAfter:   cert.NotAfter.UTC().Format(time.RFC3339),
+		}
+		nextRenewal = nextX509CertificateRenewal(*user, cert, now)
+	}
+
 	shouldRetry := false
 	updateFunction := func(ac *om.AutomationConfig) error {
 		if !externalAuthMechanismsAvailable(ac.Auth.DeploymentAuthMechanisms) {
@@ -439,14 +567,18 @@ func (r *MongoDBUserReconciler) handleExternalAuthUser(ctx context.Context, user
 			return xerrors.Errorf("no external authentication mechanisms (LDAP or x509) have been configured")
 		}
 
-		desiredUser, err := toOmUser(user.Spec, "", ac)
+		desiredUser, err := toOmUser(desiredSpec, "", ac)
 		if err != nil {
 			return xerrors.Errorf("errorr updating user %w", err)
 		}
 
 		auth := ac.Auth
 		if user.ChangedIdentifier() {
-			auth.RemoveUser(user.Status.Username, user.Status.Database)
+			auth.EnsureUserRemoved(user.Status.Username, user.Status.Database)
+		}
+		// the user named after the subject of the previous certificate is replaced
+		if previous := user.Status.Certificate; previous != nil && previous.Subject != desiredSpec.Username {
+			auth.EnsureUserRemoved(previous.Subject, authentication.ExternalDB)
 		}
 
 		auth.EnsureUser(desiredUser)
@@ -468,6 +600,10 @@ func (r *MongoDBUserReconciler) handleExternalAuthUser(ctx context.Context, user
 		return r.updateStatus(ctx, user, workflow.Pending("error waiting for ready state: %s", err.Error()).WithRetry(10), log)
 	}
 
+	if err := r.updateConnectionStringSecret(ctx, *user, log); err != nil {
+		return r.updateStatus(ctx, user, workflow.Failed(err), log)
+	}
+
 	annotationsToAdd, err := getAnnotationsForUserResource(user)
 	if err != nil {
 		return r.updateStatus(ctx, user, workflow.Failed(err), log)
@@ -478,7 +614,12 @@ func (r *MongoDBUserReconciler) handleExternalAuthUser(ctx context.Context, user
 	}
 
 	log.Infow("Finished reconciliation for MongoDBUser!")
-	return r.updateStatus(ctx, user, workflow.OK(), log, mdbstatus.NewProjectIdOption(conn.GroupID()))
+	user.Status.Certificate = certificateStatus
+	okStatus := workflow.OK()
+	if nextRenewal > 0 {
+		okStatus = okStatus.WithRetry(int(nextRenewal.Seconds()))
+	}
+	return r.updateStatus(ctx, user, okStatus, log, mdbstatus.NewProjectIdOption(conn.GroupID()))
 }
 
 func waitForReadyState(conn om.Connection, log *zap.SugaredLogger) error {
@@ -512,29 +653,51 @@ func getAnnotationsForUserResource(user *userv1.MongoDBUser) (map[string]string,
 func (r *MongoDBUserReconciler) preDeletionCleanup(ctx context.Context, user *userv1.MongoDBUser, conn om.Connection, log *zap.SugaredLogger) (reconcile.Result, error) {
 	log.Info("Performing pre deletion cleanup before deleting MongoDBUser")
 
+	if status := r.removeUser(ctx, user, conn, log); !status.IsOK() {
+		return r.updateStatus(ctx, user, status, log)
+	}
+
+	if finalizerRemoved := controllerutil.RemoveFinalizer(user, util.UserFinalizer); !finalizerRemoved {
+		return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to remove finalizer")), log)
+	}
+
+	if err := r.client.Update(ctx, user); err != nil {
+		return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to update the user with the removed finalizer: %w", err)), log)
+	}
+	return r.updateStatus(ctx, user, workflow.OK(), log)
+}
+
+// revokeAccess removes the user from the deployment it is no longer allowed to reference, and returns the revoked
+// status. The user is added back once a MongoDBReferenceGrant allows the reference again.
+func (r *MongoDBUserReconciler) revokeAccess(ctx context.Context, user *userv1.MongoDBUser, conn om.Connection, revoked workflow.Status, log *zap.SugaredLogger) (reconcile.Result, error) {
+	log.Info("The reference of the MongoDBUser is no longer allowed, removing the user from the deployment")
+	if status := r.removeUser(ctx, user, conn, log); !status.IsOK() {
+		return r.updateStatus(ctx, user, status, log)
+	}
+	return r.updateStatus(ctx, user, revoked, log)
+}
+
+// removeUser removes the user from the automation config and deletes its connection string secrets.
+func (r *MongoDBUserReconciler) removeUser(ctx context.Context, user *userv1.MongoDBUser, conn om.Connection, log *zap.SugaredLogger) workflow.Status {
 	err := conn.ReadUpdateAutomationConfig(func(ac *om.AutomationConfig) error {
 		ac.Auth.EnsureUserRemoved(user.Spec.Username, user.Spec.Database)
+		ac.Auth.EnsureUserRemoved(user.ShadowUsername(), user.Spec.Database)
+		if user.Status.Certificate != nil {
+			ac.Auth.EnsureUserRemoved(user.Status.Certificate.Subject, authentication.ExternalDB)
+		}
 		return nil
 	}, log)
 	if err != nil {
-		return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to perform AutomationConfig cleanup: %w", err)), log)
+		return workflow.Failed(xerrors.Errorf("Failed to perform AutomationConfig cleanup: %w", err))
 	}
 
 	secretKey := kube.ObjectKey(user.Namespace, user.GetConnectionStringSecretName())
-	for clusterName, c := range r.memberClusterSecretClientsMap {
+	for clusterName, c := range r.getMemberClusterSecretClientsMap() {
 		if err := c.DeleteSecret(ctx, secretKey); err != nil && !apiErrors.IsNotFound(err) {
-			return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to delete connection string secret from member cluster %s: %w", clusterName, err)), log)
+			return workflow.Failed(xerrors.Errorf("Failed to delete connection string secret from member cluster %s: %w", clusterName, err))
 		}
 	}
-
-	if finalizerRemoved := controllerutil.RemoveFinalizer(user, util.UserFinalizer); !finalizerRemoved {
-		return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to remove finalizer")), log)
-	}
-
-	if err := r.client.Update(ctx, user); err != nil {
-		return r.updateStatus(ctx, user, workflow.Failed(xerrors.Errorf("Failed to update the user with the removed finalizer: %w", err)), log)
-	}
-	return r.updateStatus(ctx, user, workflow.OK(), log)
+	return workflow.OK()
 }
 
 func (r *MongoDBUserReconciler) ensureFinalizer(ctx context.Context, user *userv1.MongoDBUser, log *zap.SugaredLogger) error {
@@ -17,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
 	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
@@ -155,6 +156,14 @@ func TestReconciliationSucceed_OnAddingUser_FromADifferentNamespace(t *testing.T
 	createUserControllerConfigMap(ctx, client)
 	// the secret must be in the same namespace as the user, we do not support cross-referencing
 	createPasswordSecretInNamespace(ctx, client, user.Spec.PasswordSecretKeyRef, "password", otherNamespace)
+	// the namespace of the MongoDB resource must allow the users of the other namespace to reference it
+	_ = client.Create(ctx, &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: mock.TestNamespace},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBUser, Namespace: otherNamespace}},
+			To:   []referencegrant.ReferenceGrantTo{{Kind: referencegrant.KindMongoDB, Name: "my-rs"}},
+		},
+	})
 
 	actual, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
 
@@ -164,6 +173,79 @@ func TestReconciliationSucceed_OnAddingUser_FromADifferentNamespace(t *testing.T
 	assert.Equal(t, expected, actual, "there should be a successful reconciliation if MongoDBUser and MongoDB resources are in different namespaces")
 }
 
+func TestReconciliationFails_OnAddingUser_FromADifferentNamespace_WithoutGrant(t *testing.T) {
+	ctx := context.Background()
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-rs").Build()
+	user.Spec.MongoDBResourceRef.Namespace = user.Namespace
+	otherNamespace := "userNamespace"
+	user.Namespace = otherNamespace
+
+	reconciler, client, _ := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigScramSha256Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableAuth().AgentAuthMode("SCRAM").
+		SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+	createPasswordSecretInNamespace(ctx, client, user.Spec.PasswordSecretKeyRef, "password", otherNamespace)
+	// the grant only allows another MongoDB resource to be referenced
+	_ = client.Create(ctx, &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: mock.TestNamespace},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBUser, Namespace: otherNamespace}},
+			To:   []referencegrant.ReferenceGrantTo{{Kind: referencegrant.KindMongoDB, Name: "other-rs"}},
+		},
+	})
+
+	actual, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, actual, "the reconciliation is not retried until the grant changes")
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.Name), user))
+	assert.Equal(t, status.PhaseFailed, user.Status.Phase)
+	assert.Contains(t, user.Status.Message, "MongoDBUser userNamespace/my-user can't reference MongoDB my-namespace/my-rs")
+}
+
+func TestReconciliation_RemovesUser_WhenGrantIsRevoked(t *testing.T) {
+	ctx := context.Background()
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-rs").Build()
+	user.Spec.MongoDBResourceRef.Namespace = user.Namespace
+	otherNamespace := "userNamespace"
+	user.Namespace = otherNamespace
+
+	reconciler, client, omConnectionFactory := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigScramSha256Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableAuth().AgentAuthMode("SCRAM").
+		SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+	createPasswordSecretInNamespace(ctx, client, user.Spec.PasswordSecretKeyRef, "password", otherNamespace)
+	grant := &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: mock.TestNamespace},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{{Kind: referencegrant.KindMongoDBUser, Namespace: otherNamespace}},
+			To:   []referencegrant.ReferenceGrantTo{{Kind: referencegrant.KindMongoDB, Name: "my-rs"}},
+		},
+	}
+	require.NoError(t, client.Create(ctx, grant))
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
+	require.NoError(t, err)
+	ac, _ := omConnectionFactory.GetConnection().ReadAutomationConfig()
+	_, createdUser := ac.Auth.GetUser("my-user", "admin")
+	require.NotNil(t, createdUser)
+
+	// the user loses its access to the database once the grant is revoked
+	require.NoError(t, client.Delete(ctx, grant))
+	actual, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, actual)
+
+	ac, _ = omConnectionFactory.GetConnection().ReadAutomationConfig()
+	_, createdUser = ac.Auth.GetUser("my-user", "admin")
+	assert.Nil(t, createdUser)
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.Name), user))
+	assert.Equal(t, status.PhaseFailed, user.Status.Phase)
+	assert.Contains(t, user.Status.Message, "MongoDBUser userNamespace/my-user can't reference MongoDB my-namespace/my-rs")
+}
+
 func TestReconciliationSucceed_OnAddingUser_WithNoMongoDBNamespaceSpecified(t *testing.T) {
 	ctx := context.Background()
 	// DefaultMongoDBUserBuilder doesn't provide a namespace to the MongoDBResourceRef by default
@@ -586,6 +668,14 @@ func TestUserReconciler_SavesConnectionStringForMultiShardedCluster(t *testing.T
 
 	kubeClient := kubernetesClient.NewClient(fakeClient)
 	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	// the connection string is only published once the user is created
+	omConnectionFactory.SetPostCreateHook(func(connection om.Connection) {
+		_ = connection.ReadUpdateAutomationConfig(func(ac *om.AutomationConfig) error {
+			ac.Auth.DeploymentAuthMechanisms = append(ac.Auth.DeploymentAuthMechanisms, util.AutomationConfigScramSha256Option)
+			ac.Auth.Disabled = false
+			return nil
+		}, nil)
+	})
 	memberClusterMap := getFakeMultiClusterMapWithConfiguredInterceptor(memberClusters.ClusterNames, omConnectionFactory, true, true)
 
 	reconciler := newMongoDBUserReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc, memberClusterMap, testBackupEnableDelay)
@@ -0,0 +1,176 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/generate"
+)
+
+const (
+	alphanumericPasswordCharacters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// symbolPasswordCharacters don't include quotes, backslashes and '$' which are often mishandled by shells and
+	// configuration files
+	symbolPasswordCharacters = "!#%*+-.:=?@^_~"
+)
+
+// validatePasswordPolicy checks that the password policy can be applied to the user.
+func validatePasswordPolicy(user userv1.MongoDBUser) error {
+	policy := user.Spec.PasswordPolicy
+	if policy == nil {
+		return nil
+	}
+	if policy.RotationInterval != nil && !policy.Generate {
+		return xerrors.Errorf("spec.passwordPolicy.rotationInterval requires spec.passwordPolicy.generate to be enabled")
+	}
+	if policy.Generate && user.Spec.PasswordSecretKeyRef.Name == "" {
+		return xerrors.Errorf("spec.passwordSecretKeyRef.name must be specified for the password to be generated")
+	}
+	if policy.RotationInterval != nil && policy.RotationInterval.Duration <= policy.GetGracePeriod() {
+		return xerrors.Errorf("spec.passwordPolicy.rotationInterval (%s) must be longer than the grace period (%s)", policy.RotationInterval.Duration, policy.GetGracePeriod())
+	}
+	return nil
+}
+
+// reconcilePasswordPolicy generates the password of the user if it doesn't exist yet, and rotates it once the
+// rotation interval has passed. A rotation sets the new password for the user that's not in use (either the user
+// or its shadow user), so the previous password keeps working until the grace period ends.
+// The changes are recorded in the user status right away, as the password Secret has already been changed.
+func (r *MongoDBUserReconciler) reconcilePasswordPolicy(ctx context.Context, user *userv1.MongoDBUser, now time.Time, log *zap.SugaredLogger) error {
+	policy := user.Spec.PasswordPolicy
+	if !policy.ShouldGenerate() {
+		return nil
+	}
+
+	password, err := user.GetPassword(ctx, r.SecretClient)
+	passwordExists := err == nil && password != ""
+
+	switch {
+	case !passwordExists:
+		log.Infof("Generating password for user %s", user.Spec.Username)
+	case !policy.IsRotationEnabled() || !isPasswordRotationDue(*user, now):
+		return nil
+	case user.Status.Username == "":
+		// the user hasn't been created yet, the password provided is used as it is
+		user.Status.LastRotated = now.UTC().Format(time.RFC3339)
+		return r.persistPasswordPolicyStatus(ctx, user, log)
+	default:
+		active := user.ActiveUsername()
+		next := user.ShadowUsername()
+		if active == user.ShadowUsername() {
+			next = user.Spec.Username
+		}
+		log.Infof("Rotating password of user %s to user %s", active, next)
+		user.Status.PasswordRotation = &userv1.PasswordRotationStatus{
+			ActiveUsername:   next,
+			RetiringUsername: active,
+			RetireAfter:      now.Add(policy.GetGracePeriod()).UTC().Format(time.RFC3339),
+		}
+	}
+
+	if err := r.writeGeneratedPassword(ctx, *user); err != nil {
+		return err
+	}
+	user.Status.LastRotated = now.UTC().Format(time.RFC3339)
+	return r.persistPasswordPolicyStatus(ctx, user, log)
+}
+
+// isPasswordRotationDue returns true if the rotation interval has passed since the password was last generated and
+// the previous rotation has finished.
+func isPasswordRotationDue(user userv1.MongoDBUser, now time.Time) bool {
+	if user.Status.PasswordRotation != nil && user.Status.PasswordRotation.RetiringUsername != "" {
+		return false
+	}
+	lastRotated, err := time.Parse(time.RFC3339, user.Status.LastRotated)
+	if err != nil {
+		// the password has never been generated by the Operator
+		return true
+	}
+	return !now.Before(lastRotated.Add(user.Spec.PasswordPolicy.RotationInterval.Duration))
+}
+
+// isRetiringUserExpired returns true if the grace period of the previous password has passed.
+func isRetiringUserExpired(user userv1.MongoDBUser, now time.Time) bool {
+	rotation := user.Status.PasswordRotation
+	if rotation == nil || rotation.RetiringUsername == "" {
+		return false
+	}
+	retireAfter, err := time.Parse(time.RFC3339, rotation.RetireAfter)
+	return err != nil || !now.Before(retireAfter)
+}
+
+// nextPasswordPolicyEvent returns the time until the retiring user has to be removed or the password has to be
+// rotated, whichever comes first. Returns 0 if the password is not rotated.
+func nextPasswordPolicyEvent(user userv1.MongoDBUser, now time.Time) time.Duration {
+	if !user.Spec.PasswordPolicy.IsRotationEnabled() {
+		return 0
+	}
+
+	var next time.Time
+	if rotation := user.Status.PasswordRotation; rotation != nil && rotation.RetiringUsername != "" {
+		next, _ = time.Parse(time.RFC3339, rotation.RetireAfter)
+	} else if lastRotated, err := time.Parse(time.RFC3339, user.Status.LastRotated); err == nil {
+		next = lastRotated.Add(user.Spec.PasswordPolicy.RotationInterval.Duration)
+	}
+
+	// a second is added as the times recorded in the status are truncated to seconds
+	return max(next.Sub(now)+time.Second, time.Second)
+}
+
+// retirePreviousUser removes the user holding the previous password from the automation config once the grace
+// period has passed. Returns true if the user has been removed.
+func retirePreviousUser(user userv1.MongoDBUser, ac *om.AutomationConfig, now time.Time) bool {
+	if !isRetiringUserExpired(user, now) {
+		return false
+	}
+	ac.Auth.EnsureUserRemoved(user.Status.PasswordRotation.RetiringUsername, user.Spec.Database)
+	return true
+}
+
+func (r *MongoDBUserReconciler) writeGeneratedPassword(ctx context.Context, user userv1.MongoDBUser) error {
+	policy := user.Spec.PasswordPolicy
+	characters := alphanumericPasswordCharacters
+	if policy.GetCharset() == userv1.PasswordCharsetAlphanumericWithSymbols {
+		characters += symbolPasswordCharacters
+	}
+	password, err := generate.RandomPassword(policy.GetLength(), characters)
+	if err != nil {
+		return xerrors.Errorf("failed to generate password: %w", err)
+	}
+
+	databaseSecretPath := r.SecretClient.DatabaseSecretPath()
+
+	// the other keys of the Secret are preserved
+	secretKey := kube.ObjectKey(user.Namespace, user.Spec.PasswordSecretKeyRef.Name)
+	data, err := r.SecretClient.ReadSecret(ctx, secretKey, databaseSecretPath)
+	if err != nil && !secret.SecretNotExist(err) {
+		return xerrors.Errorf("failed to read password secret %s: %w", secretKey, err)
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	data[user.GetPasswordSecretKey()] = password
+
+	passwordSecret := secret.Builder().
+		SetName(secretKey.Name).
+		SetNamespace(secretKey.Namespace).
+		SetStringMapToData(data).
+		Build()
+	if err := r.SecretClient.PutSecret(ctx, passwordSecret, databaseSecretPath); err != nil {
+		return xerrors.Errorf("failed to write password secret %s: %w", secretKey, err)
+	}
+	return nil
+}
+
+func (r *MongoDBUserReconciler) persistPasswordPolicyStatus(ctx context.Context, user *userv1.MongoDBUser, log *zap.SugaredLogger) error {
+	_, err := r.updateStatus(ctx, user, workflow.Pending("Updating the password of user %s", user.ActiveUsername()), log)
+	return err
+}
@@ -0,0 +1,241 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
+)
+
+func TestValidatePasswordPolicy(t *testing.T) {
+	user := DefaultMongoDBUserBuilder().Build()
+	assert.NoError(t, validatePasswordPolicy(*user))
+
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true, RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+	assert.NoError(t, validatePasswordPolicy(*user))
+
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+	assert.ErrorContains(t, validatePasswordPolicy(*user), "requires spec.passwordPolicy.generate")
+
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true, RotationInterval: &metav1.Duration{Duration: time.Hour}, GracePeriod: &metav1.Duration{Duration: 2 * time.Hour}}
+	assert.ErrorContains(t, validatePasswordPolicy(*user), "must be longer than the grace period")
+
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true}
+	user.Spec.PasswordSecretKeyRef = userv1.SecretKeyRef{}
+	assert.ErrorContains(t, validatePasswordPolicy(*user), "spec.passwordSecretKeyRef.name must be specified")
+}
+
+func TestNextPasswordPolicyEvent(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	user := DefaultMongoDBUserBuilder().Build()
+	assert.Equal(t, time.Duration(0), nextPasswordPolicyEvent(*user, now))
+
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true, RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+	user.Status.LastRotated = now.Add(-time.Hour).Format(time.RFC3339)
+	assert.Equal(t, 23*time.Hour+time.Second, nextPasswordPolicyEvent(*user, now))
+	assert.False(t, isPasswordRotationDue(*user, now))
+	assert.True(t, isPasswordRotationDue(*user, now.Add(23*time.Hour)))
+
+	// the retiring user is removed before the next rotation
+	user.Status.PasswordRotation = &userv1.PasswordRotationStatus{ActiveUsername: "my-user-shadow", RetiringUsername: "my-user", RetireAfter: now.Add(10 * time.Minute).Format(time.RFC3339)}
+	assert.Equal(t, 10*time.Minute+time.Second, nextPasswordPolicyEvent(*user, now))
+	assert.False(t, isPasswordRotationDue(*user, now.Add(23*time.Hour)))
+	assert.False(t, isRetiringUserExpired(*user, now))
+	assert.True(t, isRetiringUserExpired(*user, now.Add(10*time.Minute)))
+}
+
+func TestPasswordIsGenerated_IfPasswordSecretDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-rs").SetPasswordRef("my-user-password", "").Build()
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true, Length: 40}
+	reconciler, client, omConnectionFactory := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigScramSha256Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableAuth().AgentAuthMode("SCRAM").SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+
+	actual, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
+	require.NoError(t, err)
+	expected, _ := workflow.OK().ReconcileResult()
+	assert.Equal(t, expected, actual)
+
+	passwordSecret := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, "my-user-password"), passwordSecret))
+	password := string(passwordSecret.Data[userv1.DefaultPasswordSecretKey])
+	assert.Len(t, password, 40)
+
+	connectionStringSecret := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetConnectionStringSecretName()), connectionStringSecret))
+	assert.Equal(t, password, string(connectionStringSecret.Data["password"]))
+
+	ac, _ := omConnectionFactory.GetConnection().ReadAutomationConfig()
+	assert.True(t, ac.Auth.HasUser(user.Spec.Username, user.Spec.Database))
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, status.PhaseUpdated, user.Status.Phase)
+	assert.NotEmpty(t, user.Status.LastRotated)
+
+	// the password is not generated again
+	_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
+	require.NoError(t, err)
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, "my-user-password"), passwordSecret))
+	assert.Equal(t, password, string(passwordSecret.Data[userv1.DefaultPasswordSecretKey]))
+}
+
+func TestPasswordIsRotated_ToShadowUser(t *testing.T) {
+	ctx := context.Background()
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-rs").Build()
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true, RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+	reconciler, client, omConnectionFactory := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigScramSha256Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableAuth().AgentAuthMode("SCRAM").SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+	createPasswordSecret(ctx, client, user.Spec.PasswordSecretKeyRef, "my-initial-password")
+
+	request := reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)}
+	actual, err := reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.InDelta(t, (24 * time.Hour).Seconds(), actual.RequeueAfter.Seconds(), 5, "the reconciliation is scheduled for the next rotation")
+
+	// the password provided is kept until the rotation interval passes
+	assertPassword(ctx, t, client, *user, "my-initial-password")
+	setUserStatus(ctx, t, client, user, func(s *userv1.MongoDBUserStatus) {
+		s.LastRotated = time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339)
+	})
+
+	actual, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour.Seconds(), actual.RequeueAfter.Seconds(), 5, "the reconciliation is scheduled for the end of the grace period")
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	require.NotNil(t, user.Status.PasswordRotation)
+	assert.Equal(t, "my-user-shadow", user.Status.PasswordRotation.ActiveUsername)
+	assert.Equal(t, "my-user", user.Status.PasswordRotation.RetiringUsername)
+
+	newPassword, err := user.GetPassword(ctx, reconciler.SecretClient)
+	require.NoError(t, err)
+	assert.NotEqual(t, "my-initial-password", newPassword)
+
+	connectionStringSecret := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetConnectionStringSecretName()), connectionStringSecret))
+	assert.Equal(t, "my-user-shadow", string(connectionStringSecret.Data["username"]))
+	assert.Equal(t, newPassword, string(connectionStringSecret.Data["password"]))
+
+	// both users exist during the grace period
+	ac, _ := omConnectionFactory.GetConnection().ReadAutomationConfig()
+	assert.True(t, ac.Auth.HasUser("my-user", user.Spec.Database))
+	_, shadowUser := ac.Auth.GetUser("my-user-shadow", user.Spec.Database)
+	require.NotNil(t, shadowUser)
+	assert.Len(t, shadowUser.Roles, len(user.Spec.Roles))
+
+	setUserStatus(ctx, t, client, user, func(s *userv1.MongoDBUserStatus) {
+		s.PasswordRotation.RetireAfter = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	})
+	_, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	ac, _ = omConnectionFactory.GetConnection().ReadAutomationConfig()
+	assert.False(t, ac.Auth.HasUser("my-user", user.Spec.Database), "the user holding the previous password should have been removed")
+	assert.True(t, ac.Auth.HasUser("my-user-shadow", user.Spec.Database))
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, "my-user-shadow", user.Status.PasswordRotation.ActiveUsername)
+	assert.Empty(t, user.Status.PasswordRotation.RetiringUsername)
+}
+
+func TestRotatedPassword_IsPublishedOnceTheAgentsReachGoalState(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := context.Background()
+		user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-rs").Build()
+		user.Spec.PasswordPolicy = &userv1.PasswordPolicy{Generate: true, RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+		reconciler, client, omConnectionFactory := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigScramSha256Option)
+
+		rs := DefaultReplicaSetBuilder().EnableAuth().AgentAuthMode("SCRAM").SetName("my-rs").Build()
+		_ = client.Create(ctx, rs)
+		createUserControllerConfigMap(ctx, client)
+		createPasswordSecret(ctx, client, user.Spec.PasswordSecretKeyRef, "my-initial-password")
+
+		request := reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)}
+		_, err := reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		conn := omConnectionFactory.GetConnection()
+		require.NoError(t, conn.ReadUpdateDeployment(func(d om.Deployment) error {
+			process := om.NewMongodProcess("my-rs-0", "my-rs-0.my-rs-svc", "fake-mongoDBImage", false, rs.Spec.AdditionalMongodConfig, rs.GetSpec(), "", nil, "", architectures.NonStatic)
+			d.MergeStandalone(process, nil, nil, zap.S())
+			return nil
+		}, zap.S()))
+		setUserStatus(ctx, t, client, user, func(s *userv1.MongoDBUserStatus) {
+			s.LastRotated = time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339)
+		})
+
+		// the clients keep the previous credentials until the shadow user is created
+		conn.(*om.MockedOmConnection).AgentsDelayCount = 100
+		_, err = reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		connectionStringSecret := &corev1.Secret{}
+		require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetConnectionStringSecretName()), connectionStringSecret))
+		assert.Equal(t, "my-user", string(connectionStringSecret.Data["username"]))
+		assert.Equal(t, "my-initial-password", string(connectionStringSecret.Data["password"]))
+
+		conn.(*om.MockedOmConnection).AgentsDelayCount = 0
+		_, err = reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		newPassword, err := user.GetPassword(ctx, reconciler.SecretClient)
+		require.NoError(t, err)
+		require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetConnectionStringSecretName()), connectionStringSecret))
+		assert.Equal(t, "my-user-shadow", string(connectionStringSecret.Data["username"]))
+		assert.Equal(t, newPassword, string(connectionStringSecret.Data["password"]))
+	})
+}
+
+func TestPasswordPolicy_IsValidated(t *testing.T) {
+	ctx := context.Background()
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-rs").Build()
+	user.Spec.PasswordPolicy = &userv1.PasswordPolicy{RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+	reconciler, client, omConnectionFactory := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigScramSha256Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableAuth().AgentAuthMode("SCRAM").SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+	createPasswordSecret(ctx, client, user.Spec.PasswordSecretKeyRef, "password")
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, status.PhaseFailed, user.Status.Phase)
+	assert.Contains(t, user.Status.Message, "requires spec.passwordPolicy.generate")
+
+	ac, _ := omConnectionFactory.GetConnection().ReadAutomationConfig()
+	assert.Empty(t, ac.Auth.Users)
+}
+
+func assertPassword(ctx context.Context, t *testing.T, client client.Client, user userv1.MongoDBUser, expected string) {
+	passwordSecret := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.Spec.PasswordSecretKeyRef.Name), passwordSecret))
+	assert.Equal(t, expected, string(passwordSecret.Data[user.Spec.PasswordSecretKeyRef.Key]))
+}
+
+func setUserStatus(ctx context.Context, t *testing.T, client client.Client, user *userv1.MongoDBUser, update func(s *userv1.MongoDBUserStatus)) {
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	update(&user.Status)
+	require.NoError(t, client.Status().Update(ctx, user))
+}
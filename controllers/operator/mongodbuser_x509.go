@@ -0,0 +1,190 @@
+package operator
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+)
+
+const caCertificateKey = "ca.crt"
+
+// validateX509 checks that the client certificate of the user can be issued.
+func validateX509(user userv1.MongoDBUser) error {
+	if user.Spec.X509 == nil {
+		return nil
+	}
+	if user.Spec.Database != authentication.ExternalDB {
+		return xerrors.Errorf("spec.x509 can only be specified for users in the %s database", authentication.ExternalDB)
+	}
+	issue := user.Spec.X509.Issue
+	if issue == nil {
+		return nil
+	}
+	if (issue.CASecretRef == nil) == (issue.IssuerRef == nil) {
+		return xerrors.Errorf("exactly one of spec.x509.issue.caSecretRef and spec.x509.issue.issuerRef must be specified")
+	}
+	if _, err := certs.ParseDistinguishedName(user.Spec.Username); err != nil {
+		return xerrors.Errorf("spec.username must be the subject of the certificate to issue: %w", err)
+	}
+	if issue.GetRenewBefore() >= issue.GetDuration() {
+		return xerrors.Errorf("spec.x509.issue.renewBefore (%s) must be shorter than the duration (%s)", issue.GetRenewBefore(), issue.GetDuration())
+	}
+	return nil
+}
+
+// ensureX509Certificate makes sure the client certificate of the user has been issued and is not due for renewal.
+// Returns the certificate, or nil together with the status to report if it's not available yet.
+func (r *MongoDBUserReconciler) ensureX509Certificate(ctx context.Context, user *userv1.MongoDBUser, now time.Time, log *zap.SugaredLogger) (*x509.Certificate, workflow.Status) {
+	secretName := user.GetX509CertificateSecretName()
+	r.resourceWatcher.AddWatchedResourceIfNotAdded(secretName, user.Namespace, watch.Secret, kube.ObjectKeyFromApiObject(user))
+
+	if user.Spec.X509.Issue.IssuerRef != nil {
+		return r.ensureCertManagerX509Certificate(ctx, user)
+	}
+	return r.ensureOperatorIssuedX509Certificate(ctx, user, now, log)
+}
+
+// ensureCertManagerX509Certificate requests the client certificate from the cert-manager issuer. cert-manager renews
+// the certificate, which triggers the reconciliation of the user as its Secret is watched.
+func (r *MongoDBUserReconciler) ensureCertManagerX509Certificate(ctx context.Context, user *userv1.MongoDBUser) (*x509.Certificate, workflow.Status) {
+	issue := user.Spec.X509.Issue
+	secretName := user.GetX509CertificateSecretName()
+
+	certificate := certs.CertManagerCertificate{
+		Name:            secretName,
+		Namespace:       user.Namespace,
+		SecretName:      secretName,
+		IssuerRef:       *issue.IssuerRef,
+		LiteralSubject:  user.Spec.Username,
+		Usages:          []string{certs.CertManagerUsageClientAuth, certs.CertManagerUsageDigitalSignature, certs.CertManagerUsageKeyEncipherment},
+		Duration:        issue.GetDuration(),
+		RenewBefore:     issue.GetRenewBefore(),
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(user, v1.SchemeGroupVersion.WithKind("MongoDBUser"))},
+	}
+	if err := certs.CreateOrUpdateCertManagerCertificate(ctx, r.client, certificate); err != nil {
+		return nil, workflow.Failed(err)
+	}
+
+	certSecret, err := r.client.GetSecret(ctx, kube.ObjectKey(user.Namespace, secretName))
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return nil, workflow.Failed(xerrors.Errorf("failed to read certificate secret %s: %w", secretName, err))
+	}
+	if apiErrors.IsNotFound(err) || len(certSecret.Data[corev1.TLSCertKey]) == 0 {
+		return nil, workflow.Pending("Waiting for cert-manager to issue the certificate %s", secretName).WithRetry(10)
+	}
+
+	cert, err := certs.ParseCertificatePEM(certSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, workflow.Failed(xerrors.Errorf("failed to read the certificate in secret %s: %w", secretName, err))
+	}
+	return cert, workflow.OK()
+}
+
+// ensureOperatorIssuedX509Certificate signs the client certificate with the CA if it hasn't been issued yet, is
+// due for renewal, doesn't have the subject requested or has been signed by another CA.
+func (r *MongoDBUserReconciler) ensureOperatorIssuedX509Certificate(ctx context.Context, user *userv1.MongoDBUser, now time.Time, log *zap.SugaredLogger) (*x509.Certificate, workflow.Status) {
+	issue := user.Spec.X509.Issue
+	secretName := user.GetX509CertificateSecretName()
+	r.resourceWatcher.AddWatchedResourceIfNotAdded(issue.CASecretRef.Name, user.Namespace, watch.Secret, kube.ObjectKeyFromApiObject(user))
+
+	caSecret, err := r.client.GetSecret(ctx, kube.ObjectKey(user.Namespace, issue.CASecretRef.Name))
+	if err != nil {
+		return nil, workflow.Failed(xerrors.Errorf("failed to read CA secret %s: %w", issue.CASecretRef.Name, err))
+	}
+	if !secret.HasAllKeys(caSecret, corev1.TLSCertKey, corev1.TLSPrivateKeyKey) {
+		return nil, workflow.Failed(xerrors.Errorf("CA secret %s must contain the %s and %s keys", issue.CASecretRef.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey))
+	}
+	caCert, err := certs.ParseCertificatePEM(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, workflow.Failed(xerrors.Errorf("failed to read the CA certificate in secret %s: %w", issue.CASecretRef.Name, err))
+	}
+
+	subject, err := certs.ParseDistinguishedName(user.Spec.Username)
+	if err != nil {
+		return nil, workflow.Invalid("%s", err.Error())
+	}
+	// the subject is compared in the form the subject of the issued certificate is read in
+	subjectName, err := authentication.DistinguishedName(subject)
+	if err != nil {
+		return nil, workflow.Invalid("%s", err.Error())
+	}
+
+	certSecret, err := r.client.GetSecret(ctx, kube.ObjectKey(user.Namespace, secretName))
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return nil, workflow.Failed(xerrors.Errorf("failed to read certificate secret %s: %w", secretName, err))
+	}
+	if err == nil {
+		if cert, err := certs.ParseCertificatePEM(certSecret.Data[corev1.TLSCertKey]); err == nil && !shouldReissueX509Certificate(*user, cert, caCert, subjectName, now) {
+			return cert, workflow.OK()
+		}
+		if existingController := metav1.GetControllerOf(&certSecret); existingController == nil || existingController.UID != user.UID {
+			return nil, workflow.Failed(xerrors.Errorf("certificate secret %s already exists and is not managed by the operator", secretName))
+		}
+	}
+
+	log.Infof("Issuing client certificate for user %s", user.Spec.Username)
+	certPEM, keyPEM, err := certs.IssueClientCertificate(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey], subject, now, issue.GetDuration())
+	if err != nil {
+		return nil, workflow.Failed(err)
+	}
+
+	newSecret := secret.Builder().
+		SetName(secretName).
+		SetNamespace(user.Namespace).
+		SetDataType(corev1.SecretTypeTLS).
+		SetField(corev1.TLSCertKey, certPEM).
+		SetField(corev1.TLSPrivateKeyKey, keyPEM).
+		SetField(caCertificateKey, string(caSecret.Data[corev1.TLSCertKey])).
+		Build()
+	if err := controllerutil.SetControllerReference(user, &newSecret, r.client.Scheme()); err != nil {
+		return nil, workflow.Failed(err)
+	}
+	if err := secret.CreateOrUpdate(ctx, r.client, newSecret); err != nil {
+		return nil, workflow.Failed(xerrors.Errorf("failed to write certificate secret %s: %w", secretName, err))
+	}
+
+	cert, err := certs.ParseCertificatePEM([]byte(certPEM))
+	if err != nil {
+		return nil, workflow.Failed(err)
+	}
+	return cert, workflow.OK()
+}
+
+// shouldReissueX509Certificate returns true if the certificate is due for renewal, doesn't have the subject requested
+// or hasn't been signed by the CA.
+func shouldReissueX509Certificate(user userv1.MongoDBUser, cert, caCert *x509.Certificate, subject string, now time.Time) bool {
+	if !now.Before(cert.NotAfter.Add(-user.Spec.X509.Issue.GetRenewBefore())) {
+		return true
+	}
+	if actualSubject, err := certs.CertificateSubject(cert); err != nil || actualSubject != subject {
+		return true
+	}
+	return cert.CheckSignatureFrom(caCert) != nil
+}
+
+// nextX509CertificateRenewal returns the time until the certificate issued by the Operator has to be renewed.
+// Returns 0 if the certificate is renewed by cert-manager.
+func nextX509CertificateRenewal(user userv1.MongoDBUser, cert *x509.Certificate, now time.Time) time.Duration {
+	if !user.Spec.X509.IsIssueEnabled() || user.Spec.X509.Issue.CASecretRef == nil {
+		return 0
+	}
+	renewAt := cert.NotAfter.Add(-user.Spec.X509.Issue.GetRenewBefore())
+	return max(renewAt.Sub(now)+time.Second, time.Second)
+}
@@ -0,0 +1,222 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+const testX509Username = "CN=my-user,OU=engineering,O=MongoDB"
+
+func newX509User(issue *userv1.X509Issue) *userv1.MongoDBUser {
+	user := DefaultMongoDBUserBuilder().SetMongoDBResourceName("my-rs").SetDatabase(authentication.ExternalDB).SetUsername(testX509Username).Build()
+	user.Spec.X509 = &userv1.X509{Issue: issue}
+	return user
+}
+
+func createCASecret(ctx context.Context, t *testing.T, c client.Client, name string) []byte {
+	caCert, caKey, err := mock.CreateTestCA()
+	require.NoError(t, err)
+	require.NoError(t, c.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: mock.TestNamespace},
+		Data:       map[string][]byte{corev1.TLSCertKey: caCert, corev1.TLSPrivateKeyKey: caKey},
+	}))
+	return caCert
+}
+
+func TestValidateX509(t *testing.T) {
+	user := newX509User(&userv1.X509Issue{CASecretRef: &corev1.LocalObjectReference{Name: "my-ca"}})
+	assert.NoError(t, validateX509(*user))
+
+	user.Spec.X509.Issue.IssuerRef = &v1.CertManagerIssuerRef{Name: "my-issuer"}
+	assert.ErrorContains(t, validateX509(*user), "exactly one of spec.x509.issue.caSecretRef and spec.x509.issue.issuerRef")
+
+	user = newX509User(&userv1.X509Issue{IssuerRef: &v1.CertManagerIssuerRef{Name: "my-issuer"}, RenewBefore: &metav1.Duration{Duration: 100 * 24 * time.Hour}})
+	assert.ErrorContains(t, validateX509(*user), "must be shorter than the duration")
+
+	user = newX509User(&userv1.X509Issue{IssuerRef: &v1.CertManagerIssuerRef{Name: "my-issuer"}})
+	user.Spec.Username = "my-user"
+	assert.ErrorContains(t, validateX509(*user), "spec.username must be the subject of the certificate")
+
+	user = newX509User(&userv1.X509Issue{IssuerRef: &v1.CertManagerIssuerRef{Name: "my-issuer"}})
+	user.Spec.Database = "admin"
+	assert.ErrorContains(t, validateX509(*user), "can only be specified for users in the $external database")
+}
+
+func TestX509User_CertificateIsIssuedWithCA(t *testing.T) {
+	ctx := context.Background()
+	user := newX509User(&userv1.X509Issue{CASecretRef: &corev1.LocalObjectReference{Name: "my-ca"}})
+	reconciler, client, omConnectionFactory := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigX509Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableX509().SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+	caCertPEM := createCASecret(ctx, t, client, "my-ca")
+
+	request := reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)}
+	actual, err := reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.InDelta(t, (60 * 24 * time.Hour).Seconds(), actual.RequeueAfter.Seconds(), 5, "the reconciliation is scheduled for the renewal of the certificate")
+
+	certSecret := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetX509CertificateSecretName()), certSecret))
+	assert.Equal(t, corev1.SecretTypeTLS, certSecret.Type)
+	assert.Equal(t, caCertPEM, certSecret.Data["ca.crt"])
+	assert.NotEmpty(t, certSecret.Data[corev1.TLSPrivateKeyKey])
+	cert, err := certs.ParseCertificatePEM(certSecret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	caCert, err := certs.ParseCertificatePEM(caCertPEM)
+	require.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+
+	ac, _ := omConnectionFactory.GetConnection().ReadAutomationConfig()
+	assert.True(t, ac.Auth.HasUser(testX509Username, authentication.ExternalDB))
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, status.PhaseUpdated, user.Status.Phase)
+	require.NotNil(t, user.Status.Certificate)
+	assert.Equal(t, testX509Username, user.Status.Certificate.Subject)
+	assert.Equal(t, cert.NotAfter.UTC().Format(time.RFC3339), user.Status.Certificate.NotAfter)
+
+	t.Run("Certificate is not reissued before its renewal", func(t *testing.T) {
+		_, err := reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		secretAfter := &corev1.Secret{}
+		require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetX509CertificateSecretName()), secretAfter))
+		assert.Equal(t, certSecret.Data[corev1.TLSCertKey], secretAfter.Data[corev1.TLSCertKey])
+	})
+	t.Run("Certificate is renewed before it expires", func(t *testing.T) {
+		subject, err := certs.ParseDistinguishedName(testX509Username)
+		require.NoError(t, err)
+		caSecret := &corev1.Secret{}
+		require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, "my-ca"), caSecret))
+		expiringCert, _, err := certs.IssueClientCertificate(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey], subject, time.Now().Add(-80*24*time.Hour), 90*24*time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetX509CertificateSecretName()), certSecret))
+		certSecret.Data[corev1.TLSCertKey] = []byte(expiringCert)
+		require.NoError(t, client.Update(ctx, certSecret))
+
+		_, err = reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetX509CertificateSecretName()), certSecret))
+		renewed, err := certs.ParseCertificatePEM(certSecret.Data[corev1.TLSCertKey])
+		require.NoError(t, err)
+		assert.True(t, renewed.NotAfter.After(time.Now().Add(89*24*time.Hour)))
+	})
+}
+
+func TestX509User_CertificateWithDomainComponentsIsNotReissued(t *testing.T) {
+	ctx := context.Background()
+	user := newX509User(&userv1.X509Issue{CASecretRef: &corev1.LocalObjectReference{Name: "my-ca"}})
+	user.Spec.Username = "UID=1234,CN=my-user,DC=example,DC=com"
+	reconciler, client, _ := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigX509Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableX509().SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+	createCASecret(ctx, t, client, "my-ca")
+
+	request := reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)}
+	_, err := reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	certSecret := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetX509CertificateSecretName()), certSecret))
+	cert, err := certs.ParseCertificatePEM(certSecret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	subject, err := certs.CertificateSubject(cert)
+	require.NoError(t, err)
+	assert.Equal(t, user.Spec.Username, subject)
+
+	_, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	secretAfter := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, user.GetX509CertificateSecretName()), secretAfter))
+	assert.Equal(t, certSecret.Data[corev1.TLSCertKey], secretAfter.Data[corev1.TLSCertKey])
+}
+
+func TestX509User_CertificateIsRequestedFromCertManager(t *testing.T) {
+	ctx := context.Background()
+	user := newX509User(&userv1.X509Issue{IssuerRef: &v1.CertManagerIssuerRef{Name: "my-issuer", Kind: v1.CertManagerClusterIssuerKind}, SecretName: "my-cert"})
+	reconciler, client, omConnectionFactory := userReconcilerWithAuthMode(ctx, user, util.AutomationConfigX509Option)
+
+	_ = client.Create(ctx, DefaultReplicaSetBuilder().EnableX509().SetName("my-rs").Build())
+	createUserControllerConfigMap(ctx, client)
+
+	request := reconcile.Request{NamespacedName: kube.ObjectKey(user.Namespace, user.Name)}
+	_, err := reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, status.PhasePending, user.Status.Phase)
+	assert.Contains(t, user.Status.Message, "Waiting for cert-manager to issue the certificate my-cert")
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certs.CertManagerCertificateGVK)
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(user.Namespace, "my-cert"), certificate))
+	literalSubject, _, _ := unstructured.NestedString(certificate.Object, "spec", "literalSubject")
+	assert.Equal(t, testX509Username, literalSubject)
+	issuerKind, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "kind")
+	assert.Equal(t, "ClusterIssuer", issuerKind)
+	duration, _, _ := unstructured.NestedString(certificate.Object, "spec", "duration")
+	assert.Equal(t, "2160h0m0s", duration)
+
+	// the issuer can change the subject requested, the user is named after the one of the certificate issued
+	issueCertManagerCertificate(ctx, t, client, "my-cert", "CN=my-user,O=MongoDB")
+	_, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	ac, _ := omConnectionFactory.GetConnection().ReadAutomationConfig()
+	assert.True(t, ac.Auth.HasUser("CN=my-user,O=MongoDB", authentication.ExternalDB))
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(user), user))
+	assert.Equal(t, status.PhaseUpdated, user.Status.Phase)
+	assert.Equal(t, "CN=my-user,O=MongoDB", user.Status.Certificate.Subject)
+
+	t.Run("User follows the subject of the renewed certificate", func(t *testing.T) {
+		issueCertManagerCertificate(ctx, t, client, "my-cert", testX509Username)
+		_, err = reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		ac, _ := omConnectionFactory.GetConnection().ReadAutomationConfig()
+		assert.True(t, ac.Auth.HasUser(testX509Username, authentication.ExternalDB))
+		assert.False(t, ac.Auth.HasUser("CN=my-user,O=MongoDB", authentication.ExternalDB))
+	})
+}
+
+// issueCertManagerCertificate writes the certificate Secret the way cert-manager does.
+func issueCertManagerCertificate(ctx context.Context, t *testing.T, c client.Client, secretName, subject string) {
+	caCertPEM, caKeyPEM, err := mock.CreateTestCA()
+	require.NoError(t, err)
+	rdns, err := certs.ParseDistinguishedName(subject)
+	require.NoError(t, err)
+	certPEM, keyPEM, err := certs.IssueClientCertificate(caCertPEM, caKeyPEM, rdns, time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: mock.TestNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte(certPEM), corev1.TLSPrivateKeyKey: []byte(keyPEM), "ca.crt": caCertPEM},
+	}
+	if err := c.Update(ctx, certSecret); err != nil {
+		require.NoError(t, c.Create(ctx, certSecret))
+	}
+}
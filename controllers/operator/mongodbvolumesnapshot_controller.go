@@ -0,0 +1,552 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/snapshot"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connection"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumesnapshot"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+const (
+	// fsyncLockTimeout is how long a member can stay locked while its snapshots are cut. Writes to the replica set
+	// with a write concern including the member block for that long, so the snapshot fails rather than waiting longer.
+	fsyncLockTimeout = 10 * time.Minute
+
+	volumeSnapshotPollInterval = 10 * time.Second
+
+	// volumeSnapshotCAKey is the key of the CA in the ConfigMap of spec.security.tls.ca of MongoDB and AppDB.
+	volumeSnapshotCAKey = "ca-pem"
+
+	volumeSnapshotNameLabel = "mongodb.com/volume-snapshot"
+)
+
+// snapshotSource is the StatefulSet of the replica set whose volumes are snapshotted.
+type snapshotSource struct {
+	statefulSetName string
+	serviceName     string
+	clusterDomain   string
+	port            int
+	members         int
+	// logsVolumeName is the volume claim template of the logs, which are not snapshotted.
+	logsVolumeName string
+
+	// mdb is the MongoDB resource whose agents read their automation config from Ops Manager. It is nil for
+	// MongoDBCommunity and AppDB, whose agents read it from automationConfigSecretName.
+	mdb                        *mdbv1.MongoDB
+	automationConfigSecretName string
+	tls                        bool
+	// caConfigMapName is the ConfigMap with the CA of the members. caSecretName is used instead when the CA is in a
+	// Secret.
+	caConfigMapName string
+	caSecretName    string
+}
+
+func (s snapshotSource) podName(ordinal int) string {
+	return dns.GetPodName(s.statefulSetName, ordinal)
+}
+
+func (s snapshotSource) host(podName, namespace string) string {
+	return fmt.Sprintf("%s:%d", dns.GetPodFQDN(podName, s.serviceName, namespace, s.clusterDomain, nil), s.port)
+}
+
+type MongoDBVolumeSnapshotReconciler struct {
+	*ReconcileCommonController
+	omConnectionFactory om.ConnectionFactory
+	locker              memberLocker
+}
+
+func newMongoDBVolumeSnapshotReconciler(ctx context.Context, kubeClient client.Client, omFunc om.ConnectionFactory, locker memberLocker) *MongoDBVolumeSnapshotReconciler {
+	return &MongoDBVolumeSnapshotReconciler{
+		ReconcileCommonController: NewReconcileCommonController(ctx, kubeClient),
+		omConnectionFactory:       omFunc,
+		locker:                    locker,
+	}
+}
+
+// +kubebuilder:rbac:groups=mongodb.com,resources={mongodbvolumesnapshots,mongodbvolumesnapshots/status,mongodbvolumesnapshots/finalizers},verbs=*,namespace=placeholder
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete,namespace=placeholder
+func (r *MongoDBVolumeSnapshotReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := zap.S().With("MongoDBVolumeSnapshot", request.NamespacedName)
+	log.Info("-> MongoDBVolumeSnapshot.Reconcile")
+
+	volumeSnapshot := &snapshot.MongoDBVolumeSnapshot{}
+	if result, err := commoncontroller.GetResource(ctx, r.client, request, volumeSnapshot, log); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return result, err
+	}
+
+	if !volumeSnapshot.GetDeletionTimestamp().IsZero() {
+		return r.releaseLockOnDeletion(ctx, volumeSnapshot, log)
+	}
+
+	if volumeSnapshot.IsCompleted() {
+		return r.enforceRetention(ctx, volumeSnapshot, log)
+	}
+
+	if err := volumeSnapshot.Validate(); err != nil {
+		return commoncontroller.UpdateStatus(ctx, r.client, volumeSnapshot, workflow.Invalid("%s", err.Error()), log)
+	}
+
+	source, err := r.readSource(ctx, volumeSnapshot)
+	if err != nil {
+		return commoncontroller.UpdateStatus(ctx, r.client, volumeSnapshot, workflow.Failed(err), log)
+	}
+
+	var st workflow.Status
+	var progress snapshot.SnapshotProgressOption
+	if len(volumeSnapshot.Status.Volumes) == 0 {
+		st, progress = r.takeSnapshots(ctx, volumeSnapshot, source, log)
+	} else {
+		st, progress = r.waitForSnapshots(ctx, volumeSnapshot, source, log)
+	}
+	if st.Phase() == status.PhaseRunning {
+		if _, err := commoncontroller.UpdateStatus(ctx, r.client, volumeSnapshot, st, log, progress, snapshot.SnapshotCompletedOption{CompletedAt: time.Now()}); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("The volumes of %s have been snapshotted", volumeSnapshot.Status.Member)
+		return r.enforceRetention(ctx, volumeSnapshot, log)
+	}
+	return commoncontroller.UpdateStatus(ctx, r.client, volumeSnapshot, st, log, progress)
+}
+
+// takeSnapshots locks the member if needed and creates a VolumeSnapshot for each of its volumes but the logs.
+func (r *MongoDBVolumeSnapshotReconciler) takeSnapshots(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, source snapshotSource, log *zap.SugaredLogger) (workflow.Status, snapshot.SnapshotProgressOption) {
+	progress := snapshot.SnapshotProgressOption{Member: volumeSnapshot.Status.Member, LockedAt: volumeSnapshot.Status.LockedAt}
+
+	var conn memberConnection
+	if volumeSnapshot.GetConsistency() == snapshot.ConsistencyFsyncLock {
+		var err error
+		if conn, err = r.readAgentConnection(ctx, volumeSnapshot, source, log); err != nil {
+			return workflow.Failed(err), progress
+		}
+	}
+
+	if progress.Member == "" {
+		member, err := r.selectMember(ctx, volumeSnapshot, source, conn)
+		if err != nil {
+			return workflow.Failed(err), progress
+		}
+		progress.Member = member
+	}
+
+	pod := corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: volumeSnapshot.Namespace, Name: progress.Member}, &pod); err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to get the pod %s: %w", progress.Member, err)), progress
+	}
+	volumes := snapshotVolumes(pod, source)
+	if len(volumes) == 0 {
+		return workflow.Failed(xerrors.Errorf("the pod %s has no persistent volumes to snapshot", pod.Name)), progress
+	}
+	// the snapshots of several volumes are not cut at the same time
+	if volumeSnapshot.GetConsistency() == snapshot.ConsistencyCrashConsistent && len(volumes) > 1 {
+		return workflow.Invalid("The %s consistency requires the journal on the data volume, but the pod %s has the volumes %v. Use the %s consistency instead", snapshot.ConsistencyCrashConsistent, pod.Name, volumeNames(volumes), snapshot.ConsistencyFsyncLock), progress
+	}
+
+	if volumeSnapshot.GetConsistency() == snapshot.ConsistencyFsyncLock && progress.LockedAt == "" {
+		conn.Host = source.host(progress.Member, volumeSnapshot.Namespace)
+		// the member is primary if it got elected since it was selected
+		if isPrimary, err := r.locker.IsPrimary(ctx, conn); err != nil {
+			return workflow.Failed(err), progress
+		} else if isPrimary {
+			return workflow.Failed(xerrors.Errorf("the member %s is the primary, locking it would block the writes to the replica set", progress.Member)), progress
+		}
+
+		// the finalizer unlocks the member if the resource is deleted before the snapshots are cut
+		if controllerutil.AddFinalizer(volumeSnapshot, util.VolumeSnapshotUnlockFinalizer) {
+			if err := r.client.Update(ctx, volumeSnapshot); err != nil {
+				return workflow.Failed(xerrors.Errorf("failed to add the finalizer: %w", err)), progress
+			}
+		}
+		if err := r.locker.Lock(ctx, conn); err != nil {
+			return workflow.Failed(err), progress
+		}
+		progress.LockedAt = time.Now().Format(time.RFC3339)
+		log.Infof("Locked the member %s", progress.Member)
+	}
+
+	for _, volume := range volumes {
+		name := volumeSnapshot.VolumeSnapshotName(volume.VolumeName)
+		labels := map[string]string{volumeSnapshotNameLabel: volumeSnapshot.Name}
+		ownerReferences := []metav1.OwnerReference{*metav1.NewControllerRef(volumeSnapshot, v1.SchemeGroupVersion.WithKind("MongoDBVolumeSnapshot"))}
+		vs := volumesnapshot.New(volumeSnapshot.Namespace, name, volume.PersistentVolumeClaimName, volumeSnapshot.Spec.VolumeSnapshotClassName, labels, ownerReferences)
+		if err := r.client.Create(ctx, vs); err != nil && !apiErrors.IsAlreadyExists(err) {
+			r.unlockOnFailure(ctx, volumeSnapshot, source, &progress, log)
+			return workflow.Failed(xerrors.Errorf("failed to create the VolumeSnapshot %s: %w", name, err)), progress
+		}
+		volume.VolumeSnapshotName = name
+		progress.Volumes = append(progress.Volumes, volume)
+	}
+
+	return workflow.Pending("Waiting for the snapshots of the volumes %v of %s to be cut", volumeNames(progress.Volumes), progress.Member).WithRetry(int(volumeSnapshotPollInterval.Seconds())), progress
+}
+
+// waitForSnapshots unlocks the member once all snapshots are cut and waits for them to be ready to use.
+func (r *MongoDBVolumeSnapshotReconciler) waitForSnapshots(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, source snapshotSource, log *zap.SugaredLogger) (workflow.Status, snapshot.SnapshotProgressOption) {
+	progress := snapshot.SnapshotProgressOption{Member: volumeSnapshot.Status.Member, LockedAt: volumeSnapshot.Status.LockedAt}
+
+	allCut, allReady := true, true
+	for _, volume := range volumeSnapshot.Status.Volumes {
+		vs, err := volumesnapshot.Get(ctx, r.client, types.NamespacedName{Namespace: volumeSnapshot.Namespace, Name: volume.VolumeSnapshotName})
+		if err != nil {
+			r.unlockOnFailure(ctx, volumeSnapshot, source, &progress, log)
+			return workflow.Failed(xerrors.Errorf("failed to get the VolumeSnapshot %s: %w", volume.VolumeSnapshotName, err)), withVolumes(progress, volumeSnapshot.Status.Volumes)
+		}
+		state := volumesnapshot.ReadState(vs)
+		if state.Error != "" {
+			r.unlockOnFailure(ctx, volumeSnapshot, source, &progress, log)
+			return workflow.Failed(xerrors.Errorf("the VolumeSnapshot %s failed: %s", volume.VolumeSnapshotName, state.Error)), withVolumes(progress, volumeSnapshot.Status.Volumes)
+		}
+		allCut = allCut && state.CreationTime != ""
+		allReady = allReady && state.ReadyToUse
+		volume.ReadyToUse = state.ReadyToUse
+		progress.Volumes = append(progress.Volumes, volume)
+	}
+
+	if progress.LockedAt != "" {
+		if allCut {
+			if err := r.unlock(ctx, volumeSnapshot, source, log); err != nil {
+				return workflow.Failed(err), progress
+			}
+			progress.LockedAt = ""
+		} else if lockedAt, err := time.Parse(time.RFC3339, progress.LockedAt); err == nil && time.Since(lockedAt) > fsyncLockTimeout {
+			r.unlockOnFailure(ctx, volumeSnapshot, source, &progress, log)
+			return workflow.Failed(xerrors.Errorf("the snapshots of %s were not cut within %s", progress.Member, fsyncLockTimeout)), progress
+		}
+	}
+
+	if !allCut {
+		return workflow.Pending("Waiting for the snapshots of the volumes %v of %s to be cut", volumeNames(progress.Volumes), progress.Member).WithRetry(int(volumeSnapshotPollInterval.Seconds())), progress
+	}
+	if !allReady {
+		return workflow.Pending("Waiting for the snapshots of the volumes %v of %s to be ready to use", volumeNames(progress.Volumes), progress.Member).WithRetry(int(volumeSnapshotPollInterval.Seconds())), progress
+	}
+	return workflow.OK(), progress
+}
+
+// enforceRetention deletes the resource, and with it its VolumeSnapshots, once its retention has expired.
+func (r *MongoDBVolumeSnapshotReconciler) enforceRetention(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, log *zap.SugaredLogger) (reconcile.Result, error) {
+	if volumeSnapshot.Status.ExpiresAt == "" {
+		return reconcile.Result{}, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, volumeSnapshot.Status.ExpiresAt)
+	if err != nil {
+		return reconcile.Result{}, xerrors.Errorf("failed to parse status.expiresAt: %w", err)
+	}
+	if remaining := time.Until(expiresAt); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	log.Infof("The retention of the snapshots expired at %s, deleting them", volumeSnapshot.Status.ExpiresAt)
+	if err := r.client.Delete(ctx, volumeSnapshot, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !apiErrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// releaseLockOnDeletion unlocks the member if the resource is deleted while it is locked.
+func (r *MongoDBVolumeSnapshotReconciler) releaseLockOnDeletion(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, log *zap.SugaredLogger) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(volumeSnapshot, util.VolumeSnapshotUnlockFinalizer) {
+		return reconcile.Result{}, nil
+	}
+	if volumeSnapshot.Status.LockedAt != "" {
+		source, err := r.readSource(ctx, volumeSnapshot)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := r.unlock(ctx, volumeSnapshot, source, log); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	controllerutil.RemoveFinalizer(volumeSnapshot, util.VolumeSnapshotUnlockFinalizer)
+	return reconcile.Result{}, r.client.Update(ctx, volumeSnapshot)
+}
+
+func (r *MongoDBVolumeSnapshotReconciler) unlock(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, source snapshotSource, log *zap.SugaredLogger) error {
+	conn, err := r.readAgentConnection(ctx, volumeSnapshot, source, log)
+	if err != nil {
+		return err
+	}
+	conn.Host = source.host(volumeSnapshot.Status.Member, volumeSnapshot.Namespace)
+	if err := r.locker.Unlock(ctx, conn); err != nil {
+		return err
+	}
+	log.Infof("Unlocked the member %s", volumeSnapshot.Status.Member)
+	return nil
+}
+
+// unlockOnFailure makes sure a failed snapshot doesn't leave the member locked. A failed unlock is retried by the
+// next reconciliation, as the lock stays recorded in the status.
+func (r *MongoDBVolumeSnapshotReconciler) unlockOnFailure(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, source snapshotSource, progress *snapshot.SnapshotProgressOption, log *zap.SugaredLogger) {
+	if progress.LockedAt == "" {
+		return
+	}
+	volumeSnapshot.Status.Member = progress.Member
+	if err := r.unlock(ctx, volumeSnapshot, source, log); err != nil {
+		log.Errorf("Failed to unlock the member %s: %s", progress.Member, err)
+		return
+	}
+	progress.LockedAt = ""
+}
+
+// selectMember returns the member whose volumes are snapshotted: the one from the spec, or else the member with the
+// highest ordinal that is not the primary.
+func (r *MongoDBVolumeSnapshotReconciler) selectMember(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, source snapshotSource, conn memberConnection) (string, error) {
+	if volumeSnapshot.Spec.Member != "" {
+		return volumeSnapshot.Spec.Member, nil
+	}
+	if volumeSnapshot.GetConsistency() == snapshot.ConsistencyCrashConsistent || source.members == 1 {
+		return source.podName(source.members - 1), nil
+	}
+	for ordinal := source.members - 1; ordinal >= 0; ordinal-- {
+		conn.Host = source.host(source.podName(ordinal), volumeSnapshot.Namespace)
+		isPrimary, err := r.locker.IsPrimary(ctx, conn)
+		if err != nil {
+			return "", err
+		}
+		if !isPrimary {
+			return source.podName(ordinal), nil
+		}
+	}
+	return "", xerrors.Errorf("no secondary member of %s found", source.statefulSetName)
+}
+
+// readAgentConnection returns the connection of the MongoDB Agent to the members of the source, from the automation
+// config the agents run with.
+func (r *MongoDBVolumeSnapshotReconciler) readAgentConnection(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot, source snapshotSource, log *zap.SugaredLogger) (memberConnection, error) {
+	var conn memberConnection
+	var authDisabled bool
+	var autoAuthMechanism string
+	if source.mdb != nil {
+		projectConfig, credsConfig, err := project.ReadConfigAndCredentials(ctx, r.client, r.SecretClient, source.mdb, log)
+		if err != nil {
+			return memberConnection{}, err
+		}
+		omConn, _, err := connection.PrepareOpsManagerConnection(ctx, r.SecretClient, projectConfig, credsConfig, r.omConnectionFactory, volumeSnapshot.Namespace, false, log)
+		if err != nil {
+			return memberConnection{}, xerrors.Errorf("failed to prepare Ops Manager connection: %w", err)
+		}
+		ac, err := omConn.ReadAutomationConfig()
+		if err != nil {
+			return memberConnection{}, xerrors.Errorf("failed to read the automation config: %w", err)
+		}
+		authDisabled, autoAuthMechanism = ac.Auth.Disabled, ac.Auth.AutoAuthMechanism
+		conn.Username, conn.Password = ac.Auth.AutoUser, ac.Auth.AutoPwd
+	} else {
+		ac, err := automationconfig.ReadFromSecret(ctx, r.client, types.NamespacedName{Namespace: volumeSnapshot.Namespace, Name: source.automationConfigSecretName})
+		if err != nil {
+			return memberConnection{}, xerrors.Errorf("failed to read the automation config: %w", err)
+		}
+		authDisabled, autoAuthMechanism = ac.Auth.Disabled, ac.Auth.AutoAuthMechanism
+		conn.Username, conn.Password = ac.Auth.AutoUser, ac.Auth.AutoPwd
+	}
+
+	if authDisabled {
+		conn.Username, conn.Password = "", ""
+	} else {
+		switch autoAuthMechanism {
+		case util.AutomationConfigScramSha256Option:
+			conn.Mechanism = "SCRAM-SHA-256"
+		case util.AutomationConfigScramSha1Option:
+			conn.Mechanism = "SCRAM-SHA-1"
+		default:
+			return memberConnection{}, xerrors.Errorf("the MongoDB Agent authenticates with %s, the member can only be locked when it uses SCRAM. Use the %s consistency instead", autoAuthMechanism, snapshot.ConsistencyCrashConsistent)
+		}
+		if conn.Username == "" || conn.Password == "" {
+			return memberConnection{}, xerrors.Errorf("the automation config has no credentials for the MongoDB Agent yet")
+		}
+	}
+
+	conn.TLS = source.tls
+	if source.tls {
+		var err error
+		if conn.CA, err = r.readCA(ctx, volumeSnapshot.Namespace, source); err != nil {
+			return memberConnection{}, err
+		}
+	}
+	return conn, nil
+}
+
+// readCA returns the CA the certificates of the members are verified with. The system CAs are used when the source has
+// no CA.
+func (r *MongoDBVolumeSnapshotReconciler) readCA(ctx context.Context, namespace string, source snapshotSource) ([]byte, error) {
+	if source.caConfigMapName != "" {
+		ca, err := configmap.ReadKey(ctx, r.client, volumeSnapshotCAKey, types.NamespacedName{Namespace: namespace, Name: source.caConfigMapName})
+		if err != nil {
+			return nil, xerrors.Errorf("failed to read the CA from the ConfigMap %s: %w", source.caConfigMapName, err)
+		}
+		return []byte(ca), nil
+	}
+	if source.caSecretName != "" {
+		secret, err := r.client.GetSecret(ctx, types.NamespacedName{Namespace: namespace, Name: source.caSecretName})
+		if err != nil {
+			return nil, xerrors.Errorf("failed to read the CA from the Secret %s: %w", source.caSecretName, err)
+		}
+		var ca []byte
+		for _, key := range slices.Sorted(maps.Keys(secret.Data)) {
+			ca = append(ca, secret.Data[key]...)
+		}
+		return ca, nil
+	}
+	return nil, nil
+}
+
+// readSource returns the StatefulSet of the replica set referenced by spec.source.
+func (r *MongoDBVolumeSnapshotReconciler) readSource(ctx context.Context, volumeSnapshot *snapshot.MongoDBVolumeSnapshot) (snapshotSource, error) {
+	key := types.NamespacedName{Namespace: volumeSnapshot.Namespace, Name: volumeSnapshot.Spec.Source.Name}
+	switch volumeSnapshot.Spec.Source.Kind {
+	case snapshot.SourceKindMongoDB:
+		mdb := mdbv1.MongoDB{}
+		if err := r.client.Get(ctx, key, &mdb); err != nil {
+			return snapshotSource{}, xerrors.Errorf("failed to get MongoDB %s: %w", key.Name, err)
+		}
+		if mdb.Spec.IsMultiCluster() || mdb.GetResourceType() == mdbv1.ShardedCluster {
+			return snapshotSource{}, xerrors.Errorf("only single cluster replica sets and standalones can be snapshotted")
+		}
+		members := mdb.Spec.Members
+		if mdb.GetResourceType() == mdbv1.Standalone {
+			members = 1
+		}
+		return snapshotSource{
+			statefulSetName: mdb.Name,
+			serviceName:     mdb.ServiceName(),
+			clusterDomain:   mdb.Spec.GetClusterDomain(),
+			port:            int(mdb.Spec.GetAdditionalMongodConfig().GetPortOrDefault()),
+			members:         members,
+			logsVolumeName:  util.PvcNameLogs,
+			mdb:             &mdb,
+			tls:             mdb.GetSecurity().IsTLSEnabled(),
+			caConfigMapName: caConfigMapName(mdb.GetSecurity()),
+		}, nil
+	case snapshot.SourceKindMongoDBCommunity:
+		mdbc := mdbcv1.MongoDBCommunity{}
+		if err := r.client.Get(ctx, key, &mdbc); err != nil {
+			return snapshotSource{}, xerrors.Errorf("failed to get MongoDBCommunity %s: %w", key.Name, err)
+		}
+		return snapshotSource{
+			statefulSetName:            mdbc.Name,
+			serviceName:                mdbc.ServiceName(),
+			clusterDomain:              mdbc.Spec.GetClusterDomain(),
+			port:                       mdbc.GetMongodConfiguration().GetDBPort(),
+			members:                    mdbc.Spec.Members,
+			logsVolumeName:             mdbc.LogsVolumeName(),
+			automationConfigSecretName: mdbc.AutomationConfigSecretName(),
+			tls:                        mdbc.Spec.Security.TLS.Enabled,
+			// the operator copies the CA of the ConfigMap or Secret of the spec into this Secret
+			caSecretName: mdbc.TLSOperatorCASecretNamespacedName().Name,
+		}, nil
+	case snapshot.SourceKindAppDB:
+		om := omv1.MongoDBOpsManager{}
+		if err := r.client.Get(ctx, key, &om); err != nil {
+			return snapshotSource{}, xerrors.Errorf("failed to get MongoDBOpsManager %s: %w", key.Name, err)
+		}
+		if om.Spec.AppDB.IsMultiCluster() {
+			return snapshotSource{}, xerrors.Errorf("only single cluster application databases can be snapshotted")
+		}
+		return snapshotSource{
+			statefulSetName:            om.Spec.AppDB.Name(),
+			serviceName:                om.Spec.AppDB.ServiceName(),
+			clusterDomain:              om.Spec.AppDB.GetClusterDomain(),
+			port:                       int(om.Spec.AppDB.GetAdditionalMongodConfig().GetPortOrDefault()),
+			members:                    om.Spec.AppDB.Members,
+			logsVolumeName:             util.PvcNameLogs,
+			automationConfigSecretName: om.Spec.AppDB.AutomationConfigSecretName(),
+			tls:                        om.Spec.AppDB.GetSecurity().IsTLSEnabled(),
+			caConfigMapName:            om.Spec.AppDB.GetCAConfigMapName(),
+		}, nil
+	}
+	return snapshotSource{}, xerrors.Errorf("unknown source kind %q", volumeSnapshot.Spec.Source.Kind)
+}
+
+func caConfigMapName(security *mdbv1.Security) string {
+	if security == nil || security.TLSConfig == nil {
+		return ""
+	}
+	return security.TLSConfig.CA
+}
+
+// snapshotVolumes returns the persistent volumes of the pod of the StatefulSet, but the logs.
+func snapshotVolumes(pod corev1.Pod, source snapshotSource) []snapshot.VolumeSnapshotStatus {
+	var volumes []snapshot.VolumeSnapshotStatus
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil || volume.Name == source.logsVolumeName {
+			continue
+		}
+		volumes = append(volumes, snapshot.VolumeSnapshotStatus{
+			VolumeName:                volume.Name,
+			PersistentVolumeClaimName: volume.PersistentVolumeClaim.ClaimName,
+		})
+	}
+	return volumes
+}
+
+func volumeNames(volumes []snapshot.VolumeSnapshotStatus) []string {
+	names := make([]string, len(volumes))
+	for i, volume := range volumes {
+		names[i] = volume.VolumeName
+	}
+	return names
+}
+
+func withVolumes(progress snapshot.SnapshotProgressOption, volumes []snapshot.VolumeSnapshotStatus) snapshot.SnapshotProgressOption {
+	progress.Volumes = volumes
+	return progress
+}
+
+// applySnapshotRestore provisions the volumes of a new database StatefulSet from the MongoDBVolumeSnapshot referenced
+// by restore. It is pending until the snapshot is completed, and while the StatefulSet is recreated without the
+// snapshot once its volumes are restored.
+func applySnapshotRestore(ctx context.Context, kubeClient client.Client, restore *v1.RestoreFromSnapshot, sts *appsv1.StatefulSet) workflow.Status {
+	restored, err := volumesnapshot.ApplyRestore(ctx, kubeClient, restore, sts)
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to restore from the snapshot: %w", err))
+	}
+	if !restored {
+		return workflow.Pending("Waiting for the volumes of the StatefulSet %s to be restored from the MongoDBVolumeSnapshot %s", sts.Name, restore.MongoDBVolumeSnapshotName)
+	}
+	return workflow.OK()
+}
+
+func AddMongoDBVolumeSnapshotController(ctx context.Context, mgr manager.Manager) error {
+	r := newMongoDBVolumeSnapshotReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection, agentMemberLocker{})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}). // nolint:forbidigo
+		For(&snapshot.MongoDBVolumeSnapshot{}).
+		Complete(r)
+}
@@ -0,0 +1,368 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/snapshot"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumesnapshot"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+// fakeMemberLocker records the fsync locks by host.
+type fakeMemberLocker struct {
+	primary string
+	locks   map[string]int
+	// conn is the connection of the last lock.
+	conn memberConnection
+}
+
+func (l *fakeMemberLocker) IsPrimary(_ context.Context, conn memberConnection) (bool, error) {
+	return conn.Host == l.primary, nil
+}
+
+func (l *fakeMemberLocker) Lock(_ context.Context, conn memberConnection) error {
+	l.locks[conn.Host]++
+	l.conn = conn
+	return nil
+}
+
+func (l *fakeMemberLocker) Unlock(_ context.Context, conn memberConnection) error {
+	l.locks[conn.Host] = 0
+	return nil
+}
+
+func snapshotTestHost(podName string) string {
+	return fmt.Sprintf("%s.my-rs-svc.%s.svc.cluster.local:27017", podName, mock.TestNamespace)
+}
+
+// snapshotTestPod returns a pod of the StatefulSet my-rs, with its volumes mounted from the given claim templates.
+func snapshotTestPod(ordinal int, volumeNames ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("my-rs-%d", ordinal), Namespace: mock.TestNamespace}}
+	for _, volumeName := range volumeNames {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name:         volumeName,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: volumeName + "-" + pod.Name}},
+		})
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: "agent-api-key", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "agent-key"}}})
+	return pod
+}
+
+func newTestVolumeSnapshot(consistency snapshot.Consistency) *snapshot.MongoDBVolumeSnapshot {
+	return &snapshot.MongoDBVolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-rs-snapshot", Namespace: mock.TestNamespace},
+		Spec: snapshot.MongoDBVolumeSnapshotSpec{
+			Source:      snapshot.SnapshotSource{Kind: snapshot.SourceKindMongoDB, Name: "my-rs"},
+			Consistency: consistency,
+			Retention:   &metav1.Duration{Duration: 24 * time.Hour},
+		},
+	}
+}
+
+func newVolumeSnapshotReconcilerForTest(ctx context.Context, locker memberLocker, objects ...client.Object) (*MongoDBVolumeSnapshotReconciler, client.Client) {
+	rs := DefaultReplicaSetBuilder().SetName("my-rs").Build()
+	objects = append(objects, rs)
+	for ordinal := range 3 {
+		objects = append(objects, snapshotTestPod(ordinal, util.PvcNameData, util.PvcNameJournal, util.PvcNameLogs))
+	}
+	fakeClient, omConnectionFactory := mock.NewDefaultFakeClient(objects...)
+	omConnectionFactory.SetPostCreateHook(func(connection om.Connection) {
+		_ = connection.ReadUpdateAutomationConfig(func(ac *om.AutomationConfig) error {
+			ac.Auth.Disabled = false
+			ac.Auth.AutoAuthMechanism = util.AutomationConfigScramSha256Option
+			ac.Auth.AutoUser = util.AutomationAgentUserName
+			ac.Auth.AutoPwd = "agent-password"
+			return nil
+		}, nil)
+	})
+	return newMongoDBVolumeSnapshotReconciler(ctx, fakeClient, omConnectionFactory.GetConnectionFunc, locker), fakeClient
+}
+
+func reconcileVolumeSnapshot(ctx context.Context, t *testing.T, r *MongoDBVolumeSnapshotReconciler, c client.Client) (reconcile.Result, *snapshot.MongoDBVolumeSnapshot) {
+	key := types.NamespacedName{Namespace: mock.TestNamespace, Name: "my-rs-snapshot"}
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	volumeSnapshot := &snapshot.MongoDBVolumeSnapshot{}
+	if err := c.Get(ctx, key, volumeSnapshot); apiErrors.IsNotFound(err) {
+		return result, nil
+	} else {
+		require.NoError(t, err)
+	}
+	return result, volumeSnapshot
+}
+
+// setVolumeSnapshotStatus simulates the external snapshotter.
+func setVolumeSnapshotStatus(ctx context.Context, t *testing.T, c client.Client, name string, fields map[string]interface{}) {
+	vs, err := volumesnapshot.Get(ctx, c, types.NamespacedName{Namespace: mock.TestNamespace, Name: name})
+	require.NoError(t, err)
+	for field, value := range fields {
+		require.NoError(t, unstructured.SetNestedField(vs.Object, value, "status", field))
+	}
+	require.NoError(t, c.Update(ctx, vs))
+}
+
+func TestMongoDBVolumeSnapshot_FsyncLock(t *testing.T) {
+	ctx := context.Background()
+	locker := &fakeMemberLocker{primary: snapshotTestHost("my-rs-2"), locks: map[string]int{}}
+	r, c := newVolumeSnapshotReconcilerForTest(ctx, locker, newTestVolumeSnapshot(snapshot.ConsistencyFsyncLock))
+
+	// the secondary with the highest ordinal is locked as the MongoDB Agent, and its data and journal volumes are
+	// snapshotted
+	_, volumeSnapshot := reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Equal(t, status.PhasePending, volumeSnapshot.Status.Phase)
+	assert.Equal(t, "my-rs-1", volumeSnapshot.Status.Member)
+	assert.NotEmpty(t, volumeSnapshot.Status.LockedAt)
+	assert.Equal(t, 1, locker.locks[snapshotTestHost("my-rs-1")])
+	assert.Equal(t, memberConnection{Host: snapshotTestHost("my-rs-1"), Username: util.AutomationAgentUserName, Password: "agent-password", Mechanism: "SCRAM-SHA-256"}, locker.conn)
+	assert.Contains(t, volumeSnapshot.Finalizers, util.VolumeSnapshotUnlockFinalizer)
+	assert.Equal(t, []snapshot.VolumeSnapshotStatus{
+		{VolumeName: "data", PersistentVolumeClaimName: "data-my-rs-1", VolumeSnapshotName: "my-rs-snapshot-data"},
+		{VolumeName: "journal", PersistentVolumeClaimName: "journal-my-rs-1", VolumeSnapshotName: "my-rs-snapshot-journal"},
+	}, volumeSnapshot.Status.Volumes)
+
+	vs, err := volumesnapshot.Get(ctx, c, types.NamespacedName{Namespace: mock.TestNamespace, Name: "my-rs-snapshot-data"})
+	require.NoError(t, err)
+	pvcName, _, _ := unstructured.NestedString(vs.Object, "spec", "source", "persistentVolumeClaimName")
+	assert.Equal(t, "data-my-rs-1", pvcName)
+	assert.Equal(t, "my-rs-snapshot", vs.GetOwnerReferences()[0].Name)
+
+	// the member stays locked until all snapshots are cut
+	setVolumeSnapshotStatus(ctx, t, c, "my-rs-snapshot-data", map[string]interface{}{"creationTime": "2026-10-18T10:00:00Z"})
+	_, volumeSnapshot = reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.NotEmpty(t, volumeSnapshot.Status.LockedAt)
+	assert.Equal(t, 1, locker.locks[snapshotTestHost("my-rs-1")])
+
+	setVolumeSnapshotStatus(ctx, t, c, "my-rs-snapshot-journal", map[string]interface{}{"creationTime": "2026-10-18T10:00:00Z"})
+	_, volumeSnapshot = reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Equal(t, status.PhasePending, volumeSnapshot.Status.Phase)
+	assert.Empty(t, volumeSnapshot.Status.LockedAt)
+	assert.Equal(t, 0, locker.locks[snapshotTestHost("my-rs-1")])
+
+	setVolumeSnapshotStatus(ctx, t, c, "my-rs-snapshot-data", map[string]interface{}{"readyToUse": true})
+	setVolumeSnapshotStatus(ctx, t, c, "my-rs-snapshot-journal", map[string]interface{}{"readyToUse": true})
+	result, volumeSnapshot := reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Equal(t, status.PhaseRunning, volumeSnapshot.Status.Phase)
+	assert.True(t, volumeSnapshot.IsCompleted())
+	assert.NotEmpty(t, volumeSnapshot.Status.ExpiresAt)
+	assert.True(t, volumeSnapshot.Status.Volumes[0].ReadyToUse)
+	assert.Greater(t, result.RequeueAfter, 23*time.Hour)
+}
+
+func TestMongoDBVolumeSnapshot_MongoDBCommunityIsLockedWithTheAutomationConfigSecret(t *testing.T) {
+	ctx := context.Background()
+	mdbc := &mdbcv1.MongoDBCommunity{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-community-rs", Namespace: mock.TestNamespace},
+		Spec: mdbcv1.MongoDBCommunitySpec{
+			Members:  3,
+			Security: mdbcv1.Security{TLS: mdbcv1.TLS{Enabled: true}},
+		},
+	}
+	ac, err := json.Marshal(automationconfig.AutomationConfig{Auth: automationconfig.Auth{
+		AutoUser:          "mms-automation",
+		AutoPwd:           "agent-password",
+		AutoAuthMechanism: "SCRAM-SHA-256",
+	}})
+	require.NoError(t, err)
+	acSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: mdbc.AutomationConfigSecretName(), Namespace: mock.TestNamespace},
+		Data:       map[string][]byte{automationconfig.ConfigKey: ac},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: mdbc.TLSOperatorCASecretNamespacedName().Name, Namespace: mock.TestNamespace},
+		Data:       map[string][]byte{"ca.pem": []byte("ca")},
+	}
+	volumeSnapshot := newTestVolumeSnapshot(snapshot.ConsistencyFsyncLock)
+	volumeSnapshot.Spec.Source = snapshot.SnapshotSource{Kind: snapshot.SourceKindMongoDBCommunity, Name: mdbc.Name}
+	r, _ := newVolumeSnapshotReconcilerForTest(ctx, &fakeMemberLocker{locks: map[string]int{}}, mdbc, acSecret, caSecret)
+
+	source, err := r.readSource(ctx, volumeSnapshot)
+	require.NoError(t, err)
+	conn, err := r.readAgentConnection(ctx, volumeSnapshot, source, zap.S())
+	require.NoError(t, err)
+	assert.Equal(t, memberConnection{Username: "mms-automation", Password: "agent-password", Mechanism: "SCRAM-SHA-256", TLS: true, CA: []byte("ca")}, conn)
+
+	// the agents of a deployment with X.509 authentication can't be impersonated
+	ac, err = json.Marshal(automationconfig.AutomationConfig{Auth: automationconfig.Auth{AutoUser: "CN=mms-automation", AutoAuthMechanism: "MONGODB-X509"}})
+	require.NoError(t, err)
+	acSecret.Data[automationconfig.ConfigKey] = ac
+	require.NoError(t, r.client.Update(ctx, acSecret))
+	_, err = r.readAgentConnection(ctx, volumeSnapshot, source, zap.S())
+	assert.ErrorContains(t, err, "the MongoDB Agent authenticates with MONGODB-X509")
+}
+
+func TestMongoDBVolumeSnapshot_FailedSnapshotUnlocksTheMember(t *testing.T) {
+	ctx := context.Background()
+	locker := &fakeMemberLocker{primary: snapshotTestHost("my-rs-0"), locks: map[string]int{}}
+	volumeSnapshot := newTestVolumeSnapshot(snapshot.ConsistencyFsyncLock)
+	volumeSnapshot.Spec.Member = "my-rs-1"
+	r, c := newVolumeSnapshotReconcilerForTest(ctx, locker, volumeSnapshot)
+
+	_, volumeSnapshot = reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Equal(t, 1, locker.locks[snapshotTestHost("my-rs-1")])
+
+	setVolumeSnapshotStatus(ctx, t, c, "my-rs-snapshot-journal", map[string]interface{}{"error": map[string]interface{}{"message": "quota exceeded"}})
+	_, volumeSnapshot = reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Equal(t, status.PhaseFailed, volumeSnapshot.Status.Phase)
+	assert.Contains(t, volumeSnapshot.Status.Message, "quota exceeded")
+	assert.Empty(t, volumeSnapshot.Status.LockedAt)
+	assert.Equal(t, 0, locker.locks[snapshotTestHost("my-rs-1")])
+}
+
+func TestMongoDBVolumeSnapshot_PrimaryIsNotLocked(t *testing.T) {
+	ctx := context.Background()
+	locker := &fakeMemberLocker{primary: snapshotTestHost("my-rs-1"), locks: map[string]int{}}
+	volumeSnapshot := newTestVolumeSnapshot(snapshot.ConsistencyFsyncLock)
+	volumeSnapshot.Spec.Member = "my-rs-1"
+	r, c := newVolumeSnapshotReconcilerForTest(ctx, locker, volumeSnapshot)
+
+	_, volumeSnapshot = reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Equal(t, status.PhaseFailed, volumeSnapshot.Status.Phase)
+	assert.Empty(t, locker.locks)
+	assert.Empty(t, volumeSnapshot.Status.Volumes)
+}
+
+func TestMongoDBVolumeSnapshot_CrashConsistentRequiresTheJournalOnTheDataVolume(t *testing.T) {
+	ctx := context.Background()
+	locker := &fakeMemberLocker{locks: map[string]int{}}
+	r, c := newVolumeSnapshotReconcilerForTest(ctx, locker, newTestVolumeSnapshot(snapshot.ConsistencyCrashConsistent))
+
+	_, volumeSnapshot := reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Equal(t, status.PhaseFailed, volumeSnapshot.Status.Phase)
+	assert.Contains(t, volumeSnapshot.Status.Message, "requires the journal on the data volume")
+	assert.Empty(t, locker.locks)
+}
+
+func TestMongoDBVolumeSnapshot_RetentionDeletesTheResource(t *testing.T) {
+	ctx := context.Background()
+	volumeSnapshot := newTestVolumeSnapshot(snapshot.ConsistencyFsyncLock)
+	volumeSnapshot.Status.CompletedAt = time.Now().Add(-25 * time.Hour).Format(time.RFC3339)
+	volumeSnapshot.Status.ExpiresAt = time.Now().Add(-time.Hour).Format(time.RFC3339)
+	r, c := newVolumeSnapshotReconcilerForTest(ctx, &fakeMemberLocker{locks: map[string]int{}}, volumeSnapshot)
+
+	_, volumeSnapshot = reconcileVolumeSnapshot(ctx, t, r, c)
+	assert.Nil(t, volumeSnapshot)
+}
+
+func TestApplySnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	volumeSnapshot := newTestVolumeSnapshot(snapshot.ConsistencyFsyncLock)
+	volumeSnapshot.Status.Volumes = []snapshot.VolumeSnapshotStatus{
+		{VolumeName: "data", PersistentVolumeClaimName: "data-my-rs-1", VolumeSnapshotName: "my-rs-snapshot-data", ReadyToUse: true},
+	}
+	fakeClient := mock.NewEmptyFakeClientBuilder().WithObjects(volumeSnapshot).Build()
+	restore := &v1.RestoreFromSnapshot{MongoDBVolumeSnapshotName: "my-rs-snapshot"}
+
+	newSts := func() appsv1.StatefulSet {
+		sts := appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "my-rs", Namespace: mock.TestNamespace}}
+		for _, name := range []string{util.PvcNameData, util.PvcNameLogs} {
+			sts.Spec.VolumeClaimTemplates = append(sts.Spec.VolumeClaimTemplates, corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       corev1.PersistentVolumeClaimSpec{Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}}},
+			})
+		}
+		return sts
+	}
+
+	// the StatefulSet is not created before the snapshot is completed
+	sts := newSts()
+	assert.Equal(t, status.PhasePending, applySnapshotRestore(ctx, fakeClient, restore, &sts).Phase())
+
+	volumeSnapshot.Status.CompletedAt = time.Now().Format(time.RFC3339)
+	require.NoError(t, fakeClient.Status().Update(ctx, volumeSnapshot))
+	assert.True(t, applySnapshotRestore(ctx, fakeClient, restore, &sts).IsOK())
+	assert.Equal(t, volumesnapshot.DataSource("my-rs-snapshot-data"), sts.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+	assert.Nil(t, sts.Spec.VolumeClaimTemplates[1].Spec.DataSource)
+
+	// the volume claim templates of an existing StatefulSet are kept until its volumes are restored, even once the
+	// snapshot is deleted
+	sts.Spec.Replicas = ptr.To(int32(2))
+	require.NoError(t, fakeClient.Create(ctx, &sts))
+	createTestPVCs(ctx, t, fakeClient, sts, 1)
+	require.NoError(t, fakeClient.Delete(ctx, volumeSnapshot))
+	sts = newSts()
+	assert.True(t, applySnapshotRestore(ctx, fakeClient, restore, &sts).IsOK())
+	assert.Equal(t, volumesnapshot.DataSource("my-rs-snapshot-data"), sts.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+
+	// a snapshot of the journal can't be restored without a journal volume
+	volumeSnapshot = newTestVolumeSnapshot(snapshot.ConsistencyFsyncLock)
+	volumeSnapshot.Status.CompletedAt = time.Now().Format(time.RFC3339)
+	volumeSnapshot.Status.Volumes = []snapshot.VolumeSnapshotStatus{
+		{VolumeName: "data", VolumeSnapshotName: "my-rs-snapshot-data"},
+		{VolumeName: "journal", VolumeSnapshotName: "my-rs-snapshot-journal"},
+	}
+	fakeClient = mock.NewEmptyFakeClientBuilder().WithObjects(volumeSnapshot).Build()
+	sts = newSts()
+	assert.Equal(t, status.PhaseFailed, applySnapshotRestore(ctx, fakeClient, restore, &sts).Phase())
+}
+
+func TestApplySnapshotRestore_ScaleUpAfterRestore(t *testing.T) {
+	ctx := context.Background()
+	restore := &v1.RestoreFromSnapshot{MongoDBVolumeSnapshotName: "my-rs-snapshot"}
+	restored := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-rs", Namespace: mock.TestNamespace},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(3)),
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: util.PvcNameData},
+				Spec:       corev1.PersistentVolumeClaimSpec{DataSource: volumesnapshot.DataSource("my-rs-snapshot-data")},
+			}},
+		},
+	}
+	// the snapshot has expired since the restore
+	fakeClient := mock.NewEmptyFakeClientBuilder().WithObjects(restored.DeepCopy()).Build()
+	createTestPVCs(ctx, t, fakeClient, restored, 3)
+
+	desired := restored.DeepCopy()
+	desired.Spec.Replicas = ptr.To(int32(5))
+	desired.Spec.VolumeClaimTemplates[0].Spec.DataSource = nil
+
+	// the StatefulSet is deleted without its Pods, so that it is recreated without the snapshot
+	assert.Equal(t, status.PhasePending, applySnapshotRestore(ctx, fakeClient, restore, desired).Phase())
+	err := fakeClient.Get(ctx, kube.ObjectKeyFromApiObject(&restored), &appsv1.StatefulSet{})
+	assert.True(t, apiErrors.IsNotFound(err))
+
+	// the new members are not provisioned from the snapshot
+	assert.True(t, applySnapshotRestore(ctx, fakeClient, restore, desired).IsOK())
+	assert.Nil(t, desired.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+	require.NoError(t, fakeClient.Create(ctx, desired))
+
+	assert.True(t, applySnapshotRestore(ctx, fakeClient, restore, desired).IsOK())
+	assert.Nil(t, desired.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+}
+
+// createTestPVCs creates the PersistentVolumeClaims of the first replicas Pods of the StatefulSet.
+func createTestPVCs(ctx context.Context, t *testing.T, c client.Client, sts appsv1.StatefulSet, replicas int) {
+	for ordinal := range replicas {
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s-%d", template.Name, sts.Name, ordinal), Namespace: sts.Namespace}}
+			require.NoError(t, c.Create(ctx, pvc))
+		}
+	}
+}
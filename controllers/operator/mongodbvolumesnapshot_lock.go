@@ -0,0 +1,125 @@
+package operator
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/xerrors"
+)
+
+// memberConnection is what is needed to run commands against a single replica set member as its MongoDB Agent.
+type memberConnection struct {
+	Host string
+	// Username, Password and Mechanism are the credentials of the MongoDB Agent. They are empty when authentication is
+	// disabled.
+	Username  string
+	Password  string
+	Mechanism string
+	TLS       bool
+	// CA is the CA the certificates of the members are verified with when TLS is enabled.
+	CA []byte
+}
+
+// memberLocker blocks and unblocks the writes of a single replica set member with fsyncLock.
+type memberLocker interface {
+	IsPrimary(ctx context.Context, conn memberConnection) (bool, error)
+	Lock(ctx context.Context, conn memberConnection) error
+	Unlock(ctx context.Context, conn memberConnection) error
+}
+
+// maxFsyncUnlocks bounds how many times Unlock calls fsyncUnlock. Each fsync lock must be released separately, and a
+// member can be locked more than once if the status of the MongoDBVolumeSnapshot failed to be saved after a lock.
+const maxFsyncUnlocks = 10
+
+// agentMemberLocker runs fsyncLock and fsyncUnlock as the MongoDB Agent of the member, so that no database user has to
+// be created for the snapshots.
+type agentMemberLocker struct{}
+
+// The fsync lock outlives the connection it was taken on, so every command opens its own connection.
+func (agentMemberLocker) runCommand(ctx context.Context, conn memberConnection, command bson.D) (bson.M, error) {
+	opts := options.Client().
+		SetHosts([]string{conn.Host}).
+		SetDirect(true).
+		SetConnectTimeout(10 * time.Second).
+		SetServerSelectionTimeout(10 * time.Second)
+	if conn.Username != "" {
+		opts.SetAuth(options.Credential{Username: conn.Username, Password: conn.Password, AuthMechanism: conn.Mechanism, AuthSource: "admin"})
+	}
+	if conn.TLS {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if len(conn.CA) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(conn.CA) {
+				return nil, xerrors.Errorf("failed to parse the CA certificate of %s", conn.Host)
+			}
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	mongoClient, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to connect to %s: %w", conn.Host, err)
+	}
+	defer func() {
+		_ = mongoClient.Disconnect(ctx)
+	}()
+
+	result := bson.M{}
+	if err := mongoClient.Database("admin").RunCommand(ctx, command).Decode(&result); err != nil {
+		return nil, xerrors.Errorf("failed to run %s on %s: %w", command[0].Key, conn.Host, err)
+	}
+	return result, nil
+}
+
+func (l agentMemberLocker) IsPrimary(ctx context.Context, conn memberConnection) (bool, error) {
+	result, err := l.runCommand(ctx, conn, bson.D{{Key: "hello", Value: 1}})
+	if err != nil {
+		return false, err
+	}
+	isPrimary, _ := result["isWritablePrimary"].(bool)
+	return isPrimary, nil
+}
+
+func (l agentMemberLocker) Lock(ctx context.Context, conn memberConnection) error {
+	_, err := l.runCommand(ctx, conn, bson.D{{Key: "fsync", Value: 1}, {Key: "lock", Value: true}})
+	return err
+}
+
+func (l agentMemberLocker) Unlock(ctx context.Context, conn memberConnection) error {
+	for range maxFsyncUnlocks {
+		result, err := l.runCommand(ctx, conn, bson.D{{Key: "fsyncUnlock", Value: 1}})
+		if err != nil {
+			// fsyncUnlock fails once the member is no longer locked
+			if isNotLocked(err) {
+				return nil
+			}
+			return err
+		}
+		if lockCount, ok := result["lockCount"]; !ok || toInt64(lockCount) == 0 {
+			return nil
+		}
+	}
+	return xerrors.Errorf("%s is still locked after %d fsyncUnlock commands", conn.Host, maxFsyncUnlocks)
+}
+
+func isNotLocked(err error) bool {
+	var commandErr mongo.CommandError
+	return xerrors.As(err, &commandErr) && commandErr.Code == 20 // IllegalOperation: "fsyncUnlock called when not locked"
+}
+
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
@@ -2,7 +2,6 @@ package pem
 
 import (
 	"context"
-	"fmt"
 
 	"go.uber.org/zap"
 
@@ -10,7 +9,6 @@ import (
 
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
-	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
 )
 
 // ReadHashFromSecret reads the existing Pem from
@@ -18,9 +16,8 @@ import (
 func ReadHashFromSecret(ctx context.Context, secretClient secrets.SecretClient, namespace, name, basePath string, log *zap.SugaredLogger) string {
 	var secretData map[string]string
 	var err error
-	if vault.IsVaultSecretBackend() {
-		path := fmt.Sprintf("%s/%s/%s", basePath, namespace, name)
-		secretData, err = secretClient.VaultClient.ReadSecretString(path)
+	if !secrets.IsKubernetesSecretBackend() {
+		secretData, err = secretClient.ReadSecret(ctx, kube.ObjectKey(namespace, name), basePath)
 		if err != nil {
 			log.Debugf("tls secret %s doesn't exist yet, unable to compute hash of pem", name)
 			return ""
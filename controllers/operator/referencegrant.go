@@ -0,0 +1,75 @@
+package operator
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+)
+
+// referenceNotAllowedOption marks the status of a reference no MongoDBReferenceGrant allows, so that the access given
+// while the reference was allowed can be removed.
+type referenceNotAllowedOption struct{}
+
+func (referenceNotAllowedOption) Value() interface{} {
+	return nil
+}
+
+// validateReference returns an Invalid status if the "from" resource references a resource of another namespace
+// without a MongoDBReferenceGrant in that namespace allowing it.
+func validateReference(ctx context.Context, reader client.Reader, from referencegrant.Reference, to referencegrant.Reference) workflow.Status {
+	allowed, err := referencegrant.IsReferenceAllowed(ctx, reader, from, to)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+	if !allowed {
+		return workflow.Invalid("%s can't reference %s: no MongoDBReferenceGrant in namespace %s allows the %s resources of namespace %s to reference it", from, to, to.Namespace, from.Kind, from.Namespace).
+			WithAdditionalOptions(referenceNotAllowedOption{})
+	}
+	return workflow.OK()
+}
+
+// isReferenceNotAllowed returns true if the status was returned by validateReference for a reference no
+// MongoDBReferenceGrant allows.
+func isReferenceNotAllowed(s workflow.Status) bool {
+	_, exists := status.GetOption(s.StatusOptions(), referenceNotAllowedOption{})
+	return exists
+}
+
+// enqueueGrantedReferrers returns a handler reconciling all the resources of the given kind in the namespaces a
+// MongoDBReferenceGrant applies to, so that their references are validated again once the grant changes.
+func enqueueGrantedReferrers(reader client.Reader, kind referencegrant.Kind, newList func() client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		grant, ok := obj.(*referencegrant.MongoDBReferenceGrant)
+		if !ok {
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, namespace := range grant.FromNamespaces(kind) {
+			list := newList()
+			if err := reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+				zap.S().Warnf("Failed to list the %s resources of namespace %s granted by MongoDBReferenceGrant %s/%s: %s", kind, namespace, grant.Namespace, grant.Name, err)
+				continue
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				zap.S().Warnf("Failed to extract the %s resources of namespace %s: %s", kind, namespace, err)
+				continue
+			}
+			for _, item := range items {
+				if o, ok := item.(client.Object); ok {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()}})
+				}
+			}
+		}
+		return requests
+	})
+}
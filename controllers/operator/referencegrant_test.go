@@ -0,0 +1,54 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+)
+
+func TestEnqueueGrantedReferrers(t *testing.T) {
+	ctx := context.Background()
+	user := func(namespace, name string) *userv1.MongoDBUser {
+		return &userv1.MongoDBUser{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	kubeClient := mock.NewEmptyFakeClientBuilder().
+		WithObjects(user("team-a", "user-1"), user("team-a", "user-2"), user("team-b", "user-3"), user("team-c", "user-4")).
+		Build()
+
+	grant := &referencegrant.MongoDBReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "databases", Name: "grant"},
+		Spec: referencegrant.MongoDBReferenceGrantSpec{
+			From: []referencegrant.ReferenceGrantFrom{
+				{Kind: referencegrant.KindMongoDBUser, Namespace: "team-a"},
+				{Kind: referencegrant.KindMongoDBSearch, Namespace: "team-b"},
+			},
+			To: []referencegrant.ReferenceGrantTo{{Kind: referencegrant.KindMongoDB}},
+		},
+	}
+
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+	handler := enqueueGrantedReferrers(kubeClient, referencegrant.KindMongoDBUser, func() client.ObjectList { return &userv1.MongoDBUserList{} })
+	handler.Delete(ctx, event.DeleteEvent{Object: grant}, q)
+
+	// only the users of team-a are reconciled, the grant applies to the searches of team-b
+	var requests []types.NamespacedName
+	for q.Len() > 0 {
+		req, _ := q.Get()
+		requests = append(requests, req.NamespacedName)
+		q.Done(req)
+	}
+	assert.ElementsMatch(t, []types.NamespacedName{{Namespace: "team-a", Name: "user-1"}, {Namespace: "team-a", Name: "user-2"}}, requests)
+}
@@ -0,0 +1,154 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
+)
+
+// Backend stores the secrets read and written by the operator. The basePath identifies the kind of secret in the
+// backends organizing the secrets by path, it is ignored by the others.
+type Backend interface {
+	ReadSecret(ctx context.Context, secretName types.NamespacedName, basePath string) (map[string]string, error)
+	ReadBinarySecret(ctx context.Context, secretName types.NamespacedName, basePath string) (map[string][]byte, error)
+	// PutSecret stores the secret.Data of s as strings.
+	PutSecret(ctx context.Context, s corev1.Secret, basePath string) error
+	// PutBinarySecret stores the secret.Data of s, base64 encoded if the backend only stores strings.
+	PutBinarySecret(ctx context.Context, s corev1.Secret, basePath string) error
+	DeleteSecret(ctx context.Context, secretName types.NamespacedName, basePath string) error
+}
+
+var (
+	_ Backend = kubernetesBackend{}
+	_ Backend = vaultBackend{}
+	_ Backend = awsSecretsManagerBackend{}
+)
+
+// IsKubernetesSecretBackend returns true if the secrets are stored as Kubernetes Secrets, which can be mounted into
+// the pods.
+func IsKubernetesSecretBackend() bool {
+	return !vault.IsVaultSecretBackend() && !awssecrets.IsSecretsManagerBackend()
+}
+
+type kubernetesBackend struct {
+	client kubernetesClient.KubernetesSecretClient
+}
+
+func (b kubernetesBackend) ReadSecret(ctx context.Context, secretName types.NamespacedName, _ string) (map[string]string, error) {
+	stringData, err := secret.ReadStringData(ctx, b.client, secretName)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string]string)
+	for k, v := range stringData {
+		secrets[k] = strings.TrimSuffix(v[:], "\n")
+	}
+	return secrets, nil
+}
+
+func (b kubernetesBackend) ReadBinarySecret(ctx context.Context, secretName types.NamespacedName, _ string) (map[string][]byte, error) {
+	return secret.ReadByteData(ctx, b.client, secretName)
+}
+
+func (b kubernetesBackend) PutSecret(ctx context.Context, s corev1.Secret, _ string) error {
+	return secret.CreateOrUpdate(ctx, b.client, s)
+}
+
+func (b kubernetesBackend) PutBinarySecret(ctx context.Context, s corev1.Secret, _ string) error {
+	return secret.CreateOrUpdate(ctx, b.client, s)
+}
+
+func (b kubernetesBackend) DeleteSecret(ctx context.Context, secretName types.NamespacedName, _ string) error {
+	return b.client.DeleteSecret(ctx, secretName)
+}
+
+type vaultBackend struct {
+	client *vault.VaultClient
+}
+
+func namespacedNameToVaultPath(nsName types.NamespacedName, basePath string) string {
+	return fmt.Sprintf("%s/%s/%s", basePath, nsName.Namespace, nsName.Name)
+}
+
+func (b vaultBackend) ReadSecret(_ context.Context, secretName types.NamespacedName, basePath string) (map[string]string, error) {
+	return b.client.ReadSecretString(namespacedNameToVaultPath(secretName, basePath))
+}
+
+func (b vaultBackend) ReadBinarySecret(_ context.Context, secretName types.NamespacedName, basePath string) (map[string][]byte, error) {
+	return b.client.ReadSecretBytes(namespacedNameToVaultPath(secretName, basePath))
+}
+
+func (b vaultBackend) PutSecret(_ context.Context, s corev1.Secret, basePath string) error {
+	secretData := map[string]interface{}{}
+	for k, v := range s.Data {
+		secretData[k] = string(v)
+	}
+	return b.put(s, basePath, secretData)
+}
+
+func (b vaultBackend) PutBinarySecret(_ context.Context, s corev1.Secret, basePath string) error {
+	secretData := map[string]interface{}{}
+	for k, v := range s.Data {
+		secretData[k] = base64.StdEncoding.EncodeToString(v)
+	}
+	return b.put(s, basePath, secretData)
+}
+
+func (b vaultBackend) put(s corev1.Secret, basePath string, secretData map[string]interface{}) error {
+	data := map[string]interface{}{
+		"data": secretData,
+	}
+	return b.client.PutSecret(namespacedNameToVaultPath(secretNamespacedName(s), basePath), data)
+}
+
+func (b vaultBackend) DeleteSecret(_ context.Context, secretName types.NamespacedName, basePath string) error {
+	return b.client.DeleteSecret(namespacedNameToVaultPath(secretName, basePath))
+}
+
+type awsSecretsManagerBackend struct {
+	client *awssecrets.Client
+}
+
+func (b awsSecretsManagerBackend) ReadSecret(ctx context.Context, secretName types.NamespacedName, basePath string) (map[string]string, error) {
+	return b.client.ReadSecret(ctx, b.client.SecretName(basePath, secretName.Namespace, secretName.Name))
+}
+
+// ReadBinarySecret returns the stored strings as bytes, the data written by PutBinarySecret is returned base64 encoded,
+// as for Vault.
+func (b awsSecretsManagerBackend) ReadBinarySecret(ctx context.Context, secretName types.NamespacedName, basePath string) (map[string][]byte, error) {
+	data, err := b.ReadSecret(ctx, secretName, basePath)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string][]byte)
+	for k, v := range data {
+		secrets[k] = []byte(v)
+	}
+	return secrets, nil
+}
+
+func (b awsSecretsManagerBackend) PutSecret(ctx context.Context, s corev1.Secret, basePath string) error {
+	return b.client.PutSecret(ctx, b.client.SecretName(basePath, s.Namespace, s.Name), DataToStringData(s.Data))
+}
+
+func (b awsSecretsManagerBackend) PutBinarySecret(ctx context.Context, s corev1.Secret, basePath string) error {
+	secretData := map[string]string{}
+	for k, v := range s.Data {
+		secretData[k] = base64.StdEncoding.EncodeToString(v)
+	}
+	return b.client.PutSecret(ctx, b.client.SecretName(basePath, s.Namespace, s.Name), secretData)
+}
+
+func (b awsSecretsManagerBackend) DeleteSecret(ctx context.Context, secretName types.NamespacedName, basePath string) error {
+	return b.client.DeleteSecret(ctx, b.client.SecretName(basePath, secretName.Namespace, secretName.Name))
+}
@@ -2,8 +2,6 @@ package secrets
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
 	"reflect"
 	"strings"
 
@@ -12,6 +10,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/mongodb/mongodb-kubernetes/pkg/awssecrets"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
@@ -25,11 +24,20 @@ var _ SecretClientInterface = (*SecretClient)(nil)
 
 type SecretClient struct {
 	VaultClient *vault.VaultClient
+	AWSClient   *awssecrets.Client
 	KubeClient  kubernetesClient.KubernetesSecretClient
 }
 
-func namespacedNameToVaultPath(nsName types.NamespacedName, basePath string) string {
-	return fmt.Sprintf("%s/%s/%s", basePath, nsName.Namespace, nsName.Name)
+// Backend returns the backend the secrets are stored in, depending on the SECRET_BACKEND of the operator.
+func (r SecretClient) Backend() Backend {
+	switch {
+	case vault.IsVaultSecretBackend():
+		return vaultBackend{client: r.VaultClient}
+	case awssecrets.IsSecretsManagerBackend():
+		return awsSecretsManagerBackend{client: r.AWSClient}
+	default:
+		return kubernetesBackend{client: r.KubeClient}
+	}
 }
 
 func secretNamespacedName(s corev1.Secret) types.NamespacedName {
@@ -52,92 +60,38 @@ func (r SecretClient) ReadSecretKey(ctx context.Context, secretName types.Namesp
 }
 
 func (r SecretClient) ReadSecret(ctx context.Context, secretName types.NamespacedName, basePath string) (map[string]string, error) {
-	secrets := make(map[string]string)
-	if vault.IsVaultSecretBackend() {
-		var err error
-		secretPath := namespacedNameToVaultPath(secretName, basePath)
-		secrets, err = r.VaultClient.ReadSecretString(secretPath)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		stringData, err := secret.ReadStringData(ctx, r.KubeClient, secretName)
-		if err != nil {
-			return nil, err
-		}
-		for k, v := range stringData {
-			secrets[k] = strings.TrimSuffix(v[:], "\n")
-		}
-	}
-	return secrets, nil
+	return r.Backend().ReadSecret(ctx, secretName, basePath)
 }
 
 func (r SecretClient) ReadBinarySecret(ctx context.Context, secretName types.NamespacedName, basePath string) (map[string][]byte, error) {
-	var secrets map[string][]byte
-	var err error
-	if vault.IsVaultSecretBackend() {
-		secretPath := namespacedNameToVaultPath(secretName, basePath)
-		secrets, err = r.VaultClient.ReadSecretBytes(secretPath)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		secrets, err = secret.ReadByteData(ctx, r.KubeClient, secretName)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return secrets, nil
+	return r.Backend().ReadBinarySecret(ctx, secretName, basePath)
 }
 
-// PutSecret copies secret.Data into vault. Note: we don't rely on secret.StringData since our builder does not use the field.
+// PutSecret copies secret.Data into the secret backend. Note: we don't rely on secret.StringData since our builder does not use the field.
 func (r SecretClient) PutSecret(ctx context.Context, s corev1.Secret, basePath string) error {
-	if vault.IsVaultSecretBackend() {
-		secretPath := namespacedNameToVaultPath(secretNamespacedName(s), basePath)
-		secretData := map[string]interface{}{}
-		for k, v := range s.Data {
-			secretData[k] = string(v)
-		}
-		data := map[string]interface{}{
-			"data": secretData,
-		}
-		return r.VaultClient.PutSecret(secretPath, data)
-	}
-
-	return secret.CreateOrUpdate(ctx, r.KubeClient, s)
+	return r.Backend().PutSecret(ctx, s, basePath)
 }
 
-// PutBinarySecret copies secret.Data as base64 into vault.
+// PutBinarySecret copies secret.Data as base64 into vault and AWS Secrets Manager.
 func (r SecretClient) PutBinarySecret(ctx context.Context, s corev1.Secret, basePath string) error {
-	if vault.IsVaultSecretBackend() {
-		secretPath := namespacedNameToVaultPath(secretNamespacedName(s), basePath)
-		secretData := map[string]interface{}{}
-		for k, v := range s.Data {
-			secretData[k] = base64.StdEncoding.EncodeToString(v)
-		}
-		data := map[string]interface{}{
-			"data": secretData,
-		}
-		return r.VaultClient.PutSecret(secretPath, data)
-	}
-
-	return secret.CreateOrUpdate(ctx, r.KubeClient, s)
+	return r.Backend().PutBinarySecret(ctx, s, basePath)
 }
 
 // PutSecretIfChanged updates a Secret only if it has changed. Equality is based on s.Data.
 // `basePath` is only used when Secrets backend is `Vault`.
 func (r SecretClient) PutSecretIfChanged(ctx context.Context, s corev1.Secret, basePath string) error {
-	if vault.IsVaultSecretBackend() {
-		secret, err := r.ReadSecret(ctx, secretNamespacedName(s), basePath)
-		if err != nil && !strings.Contains(err.Error(), "not found") {
-			return err
-		}
-		if err != nil || !reflect.DeepEqual(secret, DataToStringData(s.Data)) {
-			return r.PutSecret(ctx, s, basePath)
-		}
+	if IsKubernetesSecretBackend() {
+		return secret.CreateOrUpdateIfNeeded(ctx, r.KubeClient, s)
 	}
 
-	return secret.CreateOrUpdateIfNeeded(ctx, r.KubeClient, s)
+	existing, err := r.ReadSecret(ctx, secretNamespacedName(s), basePath)
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return err
+	}
+	if err != nil || !reflect.DeepEqual(existing, DataToStringData(s.Data)) {
+		return r.PutSecret(ctx, s, basePath)
+	}
+	return nil
 }
 
 // These methods implement the secretGetterUpdateCreateDeleter interface from community.
@@ -146,10 +100,10 @@ func (r SecretClient) PutSecretIfChanged(ctx context.Context, s corev1.Secret, b
 // TODO this method is very fishy as it has hardcoded AppDBSecretPath, but is used not only for AppDB
 // We should probably use ReadSecret instead -> https://jira.mongodb.org/browse/CLOUDP-277863
 func (r SecretClient) GetSecret(ctx context.Context, secretName types.NamespacedName) (corev1.Secret, error) {
-	if vault.IsVaultSecretBackend() {
+	if !IsKubernetesSecretBackend() {
 		s := corev1.Secret{}
 
-		data, err := r.ReadSecret(ctx, secretName, r.VaultClient.AppDBSecretPath())
+		data, err := r.ReadSecret(ctx, secretName, r.AppDBSecretPath())
 		if err != nil {
 			return s, err
 		}
@@ -163,26 +117,50 @@ func (r SecretClient) GetSecret(ctx context.Context, secretName types.Namespaced
 }
 
 func (r SecretClient) CreateSecret(ctx context.Context, s corev1.Secret) error {
-	var appdbSecretPath string
-	if r.VaultClient != nil {
-		appdbSecretPath = r.VaultClient.AppDBSecretPath()
-	}
-	return r.PutSecret(ctx, s, appdbSecretPath)
+	return r.PutSecret(ctx, s, r.AppDBSecretPath())
 }
 
 func (r SecretClient) UpdateSecret(ctx context.Context, s corev1.Secret) error {
-	if vault.IsVaultSecretBackend() {
+	if !IsKubernetesSecretBackend() {
 		return r.CreateSecret(ctx, s)
 	}
 	return r.KubeClient.UpdateSecret(ctx, s)
 }
 
 func (r SecretClient) DeleteSecret(ctx context.Context, secretName types.NamespacedName) error {
-	if vault.IsVaultSecretBackend() {
-		// TODO deletion logic
-		return nil
+	return r.Backend().DeleteSecret(ctx, secretName, r.AppDBSecretPath())
+}
+
+// OperatorSecretPath returns the base path of the secrets read by the operator, e.g. the Ops Manager credentials.
+// The base paths are empty for Kubernetes Secrets.
+func (r SecretClient) OperatorSecretPath() string {
+	return r.secretPath((*vault.VaultClient).OperatorSecretPath, awssecrets.OperatorSecretPath)
+}
+
+// OpsManagerSecretPath returns the base path of the secrets of Ops Manager.
+func (r SecretClient) OpsManagerSecretPath() string {
+	return r.secretPath((*vault.VaultClient).OpsManagerSecretPath, awssecrets.OpsManagerSecretPath)
+}
+
+// DatabaseSecretPath returns the base path of the secrets of the database resources.
+func (r SecretClient) DatabaseSecretPath() string {
+	return r.secretPath((*vault.VaultClient).DatabaseSecretPath, awssecrets.DatabaseSecretPath)
+}
+
+// AppDBSecretPath returns the base path of the secrets of the AppDB.
+func (r SecretClient) AppDBSecretPath() string {
+	return r.secretPath((*vault.VaultClient).AppDBSecretPath, awssecrets.AppDBSecretPath)
+}
+
+func (r SecretClient) secretPath(vaultPath func(*vault.VaultClient) string, awsPath string) string {
+	switch {
+	case r.VaultClient != nil:
+		return vaultPath(r.VaultClient)
+	case awssecrets.IsSecretsManagerBackend():
+		return awsPath
+	default:
+		return ""
 	}
-	return r.KubeClient.DeleteSecret(ctx, secretName)
 }
 
 func DataToStringData(data map[string][]byte) map[string]string {
@@ -0,0 +1,97 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	restclient "k8s.io/client-go/rest"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumestats"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+const StorageAutoscaledReason = "StorageAutoscaled"
+
+// VolumeStatsReaders returns the reader of the volume stats of a member cluster, or nil if the member cluster is not
+// known. The operator cluster is named multicluster.LegacyCentralClusterName.
+type VolumeStatsReaders func(memberClusterName string) volumestats.Reader
+
+// newVolumeStatsReaders reads the volume stats of the operator cluster with the config of the manager, and the volume
+// stats of the member clusters with the config of the member clusters currently registered.
+func newVolumeStatsReaders(config *restclient.Config, memberClusterRegistry *multicluster.MemberClusterRegistry) (VolumeStatsReaders, error) {
+	centralReader, err := volumestats.NewKubeletReader(config)
+	if err != nil {
+		return nil, err
+	}
+	return func(memberClusterName string) volumestats.Reader {
+		if memberClusterName == multicluster.LegacyCentralClusterName {
+			return centralReader
+		}
+		memberCluster, ok := memberClusterRegistry.Clusters()[memberClusterName]
+		if !ok {
+			return nil
+		}
+		reader, err := volumestats.NewKubeletReader(memberCluster.GetConfig())
+		if err != nil {
+			zap.S().Warnf("Failed to read the volume stats of member cluster %s: %s", memberClusterName, err)
+			return nil
+		}
+		return reader
+	}, nil
+}
+
+// requeueForStorageAutoscale shortens the requeue of a successful reconciliation when the storage of the resource is
+// autoscaled. The volumes fill up without any change to the resource, so the volume usage is only checked again if
+// the resource is reconciled at the storage autoscaling interval.
+func requeueForStorageAutoscale(autoscaled bool, result reconcile.Result, err error) (reconcile.Result, error) {
+	if err != nil || !autoscaled {
+		return result, err
+	}
+	interval := time.Duration(env.ReadIntOrDefault(util.StorageAutoscaleIntervalSecondsEnv, util.StorageAutoscaleIntervalSecondsDefault)) * time.Second // nolint:forbidigo
+	if result.RequeueAfter > interval {
+		result.RequeueAfter = interval
+	}
+	return result, nil
+}
+
+// autoscaleStorage applies the storage autoscaling of the persistence config to the desired StatefulSet, and records
+// an Event for each volume expanded. The returned options add the expansions to the status of the resource, they
+// must be saved before the PVCs are resized.
+func (r *ReconcileCommonController) autoscaleStorage(ctx context.Context, resource client.Object, memberClusterName string, memberClient kubernetesClient.Client, persistence *v1.Persistence, sts *appsv1.StatefulSet, log *zap.SugaredLogger) ([]status.Option, error) {
+	if persistence == nil || persistence.Autoscale == nil {
+		return nil, nil
+	}
+
+	var reader volumestats.Reader
+	if r.volumeStatsReaders != nil {
+		reader = r.volumeStatsReaders(memberClusterName)
+	}
+	expansions, err := create.AutoscaleStorage(ctx, memberClient, reader, persistence.Autoscale, sts, log)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to autoscale the storage of statefulset %s: %w", sts.Name, err)
+	}
+	if len(expansions) == 0 {
+		return nil, nil
+	}
+
+	if r.recorder != nil {
+		for _, expansion := range expansions {
+			r.recorder.Eventf(resource, corev1.EventTypeNormal, StorageAutoscaledReason, "Volume %s of StatefulSet %s is %d%% full, expanding it from %s to %s",
+				expansion.VolumeName, expansion.StatefulsetName, expansion.UsagePercent, expansion.From, expansion.To)
+		}
+	}
+	return []status.Option{status.NewStorageExpansionsOption(expansions)}, nil
+}
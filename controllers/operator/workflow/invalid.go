@@ -30,6 +30,11 @@ func (f *invalidStatus) WithTargetPhase(targetPhase status.Phase) *invalidStatus
 	return f
 }
 
+func (f *invalidStatus) WithAdditionalOptions(options ...status.Option) *invalidStatus {
+	f.options = options
+	return f
+}
+
 func (f *invalidStatus) ReconcileResult() (reconcile.Result, error) {
 	// We don't requeue validation failures
 	return reconcile.Result{}, nil
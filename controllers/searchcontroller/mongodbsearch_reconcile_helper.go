@@ -30,10 +30,13 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
 	khandler "github.com/mongodb/mongodb-kubernetes/pkg/handler"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
@@ -527,10 +530,11 @@ func (r *MongoDBSearchReconcileHelper) reconcile(ctx context.Context, log *zap.S
 	// coherently below (TLS preflight, unit fan-out, cluster-level Services):
 	// one unregistered cluster must not stall the others. The reconcile still
 	// ends Pending — the data plane is incomplete until the cluster is
-	// registered or removed from the spec.
+	// registered or removed from the spec. The clusters in the failedClusters
+	// annotation have no client either, until they are healthy again.
 	missingClusters, specClusterCount := missingClusterNames(plan.units)
 	for _, clusterName := range missingClusters {
-		log.Warnf("Member cluster %q not registered with the operator; skipping it", clusterName)
+		log.Warnf("Member cluster %q not registered with the operator or marked as failed; skipping it", clusterName)
 	}
 	if specClusterCount > 0 && len(missingClusters) == specClusterCount {
 		return workflow.Pending("None of the clusters in spec.clusters is registered with the operator: %s", strings.Join(missingClusters, ", "))
@@ -543,6 +547,10 @@ func (r *MongoDBSearchReconcileHelper) reconcile(ctx context.Context, log *zap.S
 		return preflightStatus
 	}
 
+	if certManagerStatus := r.ensureCertManagerCertificates(ctx, plan, missingClusters); !certManagerStatus.IsOK() {
+		return certManagerStatus
+	}
+
 	keyfileStsModification, st, ok := r.ensureKeyfileModification(ctx, log)
 	if !ok {
 		return st
@@ -642,7 +650,7 @@ func (r *MongoDBSearchReconcileHelper) reconcile(ctx context.Context, log *zap.S
 		return workflow.Failed(reconcileErrs)
 	}
 	if len(missingClusters) > 0 {
-		return workflow.Pending("Member clusters not registered with the operator: %s", strings.Join(missingClusters, ", "))
+		return workflow.Pending("Member clusters not registered with the operator or marked as failed: %s", strings.Join(missingClusters, ", "))
 	}
 
 	// Collect every unit's readiness (not first-loss-wins): the top-level phase is the
@@ -1201,6 +1209,11 @@ func (r *MongoDBSearchReconcileHelper) validatePerShardTLSSecrets(ctx context.Co
 		return workflow.Failed(xerrors.New("spec.security.tls.certificateKeySecretRef is not supported for sharded clusters, use spec.security.tls.certsSecretPrefix instead"))
 	}
 
+	// the secrets requested from cert-manager are only created after the preflight
+	if r.mdbSearch.Spec.Security.TLS.CertManager != nil {
+		return workflow.OK()
+	}
+
 	var validationErrs error
 	var worstPhase status.Phase
 	warnedMissing := map[string]bool{}
@@ -1209,7 +1222,7 @@ func (r *MongoDBSearchReconcileHelper) validatePerShardTLSSecrets(ctx context.Co
 			// Unregistered cluster: skipped by the whole reconcile, not validated.
 			if !warnedMissing[w.ClusterName] {
 				warnedMissing[w.ClusterName] = true
-				log.Warnf("Member cluster %q not registered with the operator; skipping it", w.ClusterName)
+				log.Warnf("Member cluster %q not registered with the operator or marked as failed; skipping it", w.ClusterName)
 			}
 			continue
 		}
@@ -1797,6 +1810,40 @@ func setupMongotContainerArgsForAPIKeys() container.Modification {
 	return prependCommand(sensitiveFilePermissionsForAPIKeys(apiKeysTempVolumeMount, embeddingKeyFilePath, "0400"))
 }
 
+// ensureCertManagerCertificates requests the mongot certificates from cert-manager, if enabled. Each unit has its
+// own certificate, requested in the cluster where its mongot pods run.
+func (r *MongoDBSearchReconcileHelper) ensureCertManagerCertificates(ctx context.Context, plan reconcilePlan, missingClusters []string) workflow.Status {
+	tlsConfig := r.mdbSearch.Spec.Security.TLS
+	if tlsConfig == nil || tlsConfig.CertManager == nil {
+		return workflow.OK()
+	}
+
+	var workflowStatus workflow.Status = workflow.OK()
+	for _, unit := range plan.units {
+		if unit.client == nil || slices.Contains(missingClusters, unit.clusterName) {
+			continue
+		}
+		secretName := unit.tlsResource.TLSSecretNamespacedName()
+		unitStatus := certs.EnsureCertManagerCertificate(ctx, unit.client, *tlsConfig.CertManager, secretName, mongotCertificateHostnames(plan, unit), unit.ownerReferences)
+		workflowStatus = workflowStatus.Merge(unitStatus)
+	}
+	return workflowStatus
+}
+
+// mongotCertificateHostnames returns the hostnames the mongot certificate of the unit has to be valid for: the pods,
+// the headless Service and the proxy Service.
+func mongotCertificateHostnames(plan reconcilePlan, unit reconcileUnit) []string {
+	serviceDomain := dns.GetServiceDomain(unit.headlessSvc.Namespace, "", nil)
+	hostnames := []string{
+		fmt.Sprintf("%s.%s", unit.headlessSvc.Name, serviceDomain),
+		fmt.Sprintf("*.%s.%s", unit.headlessSvc.Name, serviceDomain),
+	}
+	if plan.manageProxySvc {
+		hostnames = append(hostnames, fmt.Sprintf("%s.%s", unit.proxySvc.Name, serviceDomain))
+	}
+	return hostnames
+}
+
 // ensureIngressTlsConfig processes TLS configuration for any mongot deployment.
 // For non-sharded deployments, pass r.mdbSearch as the tlsResource.
 // For sharded deployments, pass a perShardTLSResource adapter.
@@ -2406,16 +2453,14 @@ func (r *MongoDBSearchReconcileHelper) ValidateSingleMongoDBSearchForSearchSourc
 	}
 
 	ref := r.mdbSearch.GetMongoDBResourceRef()
-	searchList := &searchv1.MongoDBSearchList{}
-	if err := r.client.List(ctx, searchList, &client.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(searchv1.MongoDBSearchIndexFieldName, ref.Namespace+"/"+ref.Name),
-	}); err != nil {
+	searches, err := ListSearchResourcesReferencing(ctx, r.client, referencegrant.Reference{Kind: SearchSourceKind(r.db), Namespace: ref.Namespace, Name: ref.Name})
+	if err != nil {
 		return xerrors.Errorf("Error listing MongoDBSearch resources for search source '%s': %w", ref.Name, err)
 	}
 
-	if len(searchList.Items) > 1 {
-		resourceNames := make([]string, len(searchList.Items))
-		for i, search := range searchList.Items {
+	if len(searches) > 1 {
+		resourceNames := make([]string, len(searches))
+		for i, search := range searches {
 			resourceNames[i] = search.Name
 		}
 		return xerrors.Errorf(
@@ -2427,6 +2472,42 @@ func (r *MongoDBSearchReconcileHelper) ValidateSingleMongoDBSearchForSearchSourc
 	return nil
 }
 
+// ListSearchResourcesReferencing returns the MongoDBSearch resources referencing the database "db". The MongoDBSearch
+// resources of other namespaces are skipped unless a MongoDBReferenceGrant in the namespace of the database allows them
+// to reference it: otherwise they could set the mongotHost of the database, or break the MongoDBSearch of its own
+// namespace by referencing the same database.
+func ListSearchResourcesReferencing(ctx context.Context, reader client.Reader, db referencegrant.Reference) ([]searchv1.MongoDBSearch, error) {
+	searchList := &searchv1.MongoDBSearchList{}
+	if err := reader.List(ctx, searchList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(searchv1.MongoDBSearchIndexFieldName, db.Namespace+"/"+db.Name),
+	}); err != nil {
+		return nil, err
+	}
+
+	var searches []searchv1.MongoDBSearch
+	for _, search := range searchList.Items {
+		from := referencegrant.Reference{Kind: referencegrant.KindMongoDBSearch, Namespace: search.Namespace, Name: search.Name}
+		allowed, err := referencegrant.IsReferenceAllowed(ctx, reader, from, db)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			zap.S().Debugf("Ignoring MongoDBSearch %s, no MongoDBReferenceGrant allows it to reference %s", search.NamespacedName(), db)
+			continue
+		}
+		searches = append(searches, search)
+	}
+	return searches, nil
+}
+
+// SearchSourceKind returns the kind of the database resource of the search source, which MongoDBReferenceGrants refer to.
+func SearchSourceKind(source SearchSourceDBResource) referencegrant.Kind {
+	if _, ok := source.(*CommunitySearchSource); ok {
+		return referencegrant.KindMongoDBCommunity
+	}
+	return referencegrant.KindMongoDB
+}
+
 func (r *MongoDBSearchReconcileHelper) ValidateSearchImageVersion(version string) error {
 	if strings.TrimSpace(version) == "" {
 		// An empty version means we could not resolve a search version at all (neither from
@@ -221,6 +221,23 @@ func TestMongoDBSearchReconcileHelper_ValidateSingleMongoDBSearchForSearchSource
 			},
 			expectedError: "Found multiple MongoDBSearch resources for search source 'test-mongodb': test-mongodb-search-1, test-mongodb-search-2",
 		},
+		{
+			name: "MongoDBSearch of another namespace without MongoDBReferenceGrant",
+			objects: []*searchv1.MongoDBSearch{
+				mdbSearch,
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-mongodb-search",
+						Namespace: "other",
+					},
+					Spec: searchv1.MongoDBSearchSpec{
+						Source: &searchv1.MongoDBSource{
+							MongoDBResourceRef: &userv1.MongoDBResourceRef{Name: "test-mongodb", Namespace: "test"},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -484,6 +501,24 @@ func TestBuildProxyService_NoLB(t *testing.T) {
 	assert.Equal(t, int32(27028), svc.Spec.Ports[0].TargetPort.IntVal)
 }
 
+func TestMongotCertificateHostnames(t *testing.T) {
+	search := &searchv1.MongoDBSearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+	}
+	unit := newTestRSUnit(search)
+
+	assert.Equal(t, []string{
+		"test-search-svc.ns.svc.cluster.local",
+		"*.test-search-svc.ns.svc.cluster.local",
+		"test-search-0-proxy-svc.ns.svc.cluster.local",
+	}, mongotCertificateHostnames(reconcilePlan{manageProxySvc: true}, unit))
+
+	assert.Equal(t, []string{
+		"test-search-svc.ns.svc.cluster.local",
+		"*.test-search-svc.ns.svc.cluster.local",
+	}, mongotCertificateHostnames(reconcilePlan{manageProxySvc: false}, unit), "the proxy Service is not part of the certificate when it's managed by the user")
+}
+
 func TestBuildProxyService_ManagedLB_NotReady(t *testing.T) {
 	search := &searchv1.MongoDBSearch{
 		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
@@ -2702,6 +2737,31 @@ func TestValidatePerShardTLSSecretsAllExist(t *testing.T) {
 	assert.True(t, status.IsOK(), "Expected status to be OK when all secrets exist")
 }
 
+func TestValidatePerShardTLSSecretsFromCertManager(t *testing.T) {
+	search := newTestMongoDBSearch("test-search", "test-ns", func(s *searchv1.MongoDBSearch) {
+		s.Spec.Security = searchv1.Security{
+			TLS: &searchv1.TLS{
+				CertsSecretPrefix: "my-prefix",
+				CertManager:       &v1.CertManagerConfig{IssuerRef: v1.CertManagerIssuerRef{Name: "issuer"}},
+			},
+		}
+	})
+
+	shardNames := []string{"shard-0", "shard-1"}
+	helper := NewMongoDBSearchReconcileHelper(
+		newTestFakeClient(search),
+		search,
+		&mockShardedSource{shardNames: shardNames},
+		newTestOperatorSearchConfig(),
+		nil, "",
+		nil,
+	)
+
+	// the secrets don't exist yet, they are requested from cert-manager once the preflight passed
+	status := helper.validatePerShardTLSSecrets(t.Context(), zap.S(), shardNames)
+	assert.True(t, status.IsOK(), "Expected status to be OK when the secrets are requested from cert-manager")
+}
+
 type failingGetKubeClient struct {
 	kubernetesClient.Client
 	err error
@@ -364,6 +364,14 @@ class OMTester(object):
         """verifies that the list of s3 store configs in OM is equal to the expected one"""
         self._assert_stores(expected_s3_stores, "/admin/backup/snapshot/s3Configs", "s3")
 
+    def assert_gcs_stores(self, expected_gcs_stores: List):
+        """verifies that the list of GCS store configs in OM is equal to the expected one"""
+        self._assert_stores(expected_gcs_stores, "/admin/backup/snapshot/gcsConfigs", "gcs")
+
+    def assert_azure_blob_stores(self, expected_azure_blob_stores: List):
+        """verifies that the list of Azure Blob store configs in OM is equal to the expected one"""
+        self._assert_stores(expected_azure_blob_stores, "/admin/backup/snapshot/azureBlobConfigs", "azure blob")
+
     def get_s3_stores(self):
         """verifies that the list of s3 store configs in OM is equal to the expected one"""
         response = self.om_request("get", "/admin/backup/snapshot/s3Configs")
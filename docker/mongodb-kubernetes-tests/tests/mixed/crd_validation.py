@@ -43,3 +43,15 @@ def test_mongodbmulti_crd_is_valid(crd_api: ApiextensionsV1Api):
 def test_cluster_mongodb_roles_crd_is_valid(crd_api: ApiextensionsV1Api):
     resource = crd_api.read_custom_resource_definition("clustermongodbroles.mongodb.com")
     assert crd_has_expected_conditions(resource)
+
+
+@mark.e2e_crd_validation
+def test_mongodb_volume_snapshots_crd_is_valid(crd_api: ApiextensionsV1Api):
+    resource = crd_api.read_custom_resource_definition("mongodbvolumesnapshots.mongodb.com")
+    assert crd_has_expected_conditions(resource)
+
+
+@mark.e2e_crd_validation
+def test_mongodb_reference_grants_crd_is_valid(crd_api: ApiextensionsV1Api):
+    resource = crd_api.read_custom_resource_definition("mongodbreferencegrants.mongodb.com")
+    assert crd_has_expected_conditions(resource)
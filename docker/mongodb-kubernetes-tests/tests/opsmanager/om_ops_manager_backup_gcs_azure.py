@@ -0,0 +1,257 @@
+import base64
+import hashlib
+import hmac
+import json
+from email.utils import formatdate
+from typing import Optional
+
+import requests
+from cryptography.hazmat.primitives import serialization
+from cryptography.hazmat.primitives.asymmetric import rsa
+from kubernetes import client
+from kubetester import create_or_update_secret, get_pod_when_ready, run_periodically, try_load
+from kubetester.create_or_replace_from_yaml import create_or_replace_from_yaml
+from kubetester.kubetester import fixture as yaml_fixture
+from kubetester.mongodb import MongoDB
+from kubetester.opsmanager import MongoDBOpsManager
+from kubetester.phase import Phase
+from pytest import fixture, mark
+from tests.conftest import is_multi_cluster
+from tests.opsmanager.withMonitoredAppDB.conftest import enable_multi_cluster_deployment
+
+"""
+Configures GCS and Azure Blob snapshot stores backed by fake-gcs-server and Azurite, and checks that the Operator
+creates, updates and removes them in Ops Manager. The GCS and Azure Blob snapshot stores require Ops Manager 8.0.
+"""
+
+OPLOG_RS_NAME = "my-mongodb-oplog"
+GCS_SECRET_NAME = "my-gcs-secret"
+AZURE_SECRET_NAME = "my-azure-secret"
+GCS_BUCKET_NAME = "gcs-snapshots"
+AZURE_CONTAINER_NAME = "azure-snapshots"
+
+# the account and key of the Azurite emulator are well known
+# https://learn.microsoft.com/en-us/azure/storage/common/storage-use-azurite#well-known-storage-account-and-key
+AZURITE_ACCOUNT_NAME = "devstoreaccount1"
+AZURITE_ACCOUNT_KEY = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+
+def fake_gcs_server_endpoint(namespace: str) -> str:
+    return f"http://fake-gcs-server.{namespace}.svc.cluster.local:4443"
+
+
+def azurite_endpoint(namespace: str) -> str:
+    return f"http://azurite.{namespace}.svc.cluster.local:10000/{AZURITE_ACCOUNT_NAME}"
+
+
+def fake_service_account_key(namespace: str) -> str:
+    """fake-gcs-server doesn't check the credentials, but the key must be a valid service account key."""
+    private_key = rsa.generate_private_key(public_exponent=65537, key_size=2048)
+    pem = private_key.private_bytes(
+        encoding=serialization.Encoding.PEM,
+        format=serialization.PrivateFormat.PKCS8,
+        encryption_algorithm=serialization.NoEncryption(),
+    ).decode()
+    return json.dumps(
+        {
+            "type": "service_account",
+            "project_id": "test",
+            "private_key_id": "fake",
+            "private_key": pem,
+            "client_email": "ops-manager@test.iam.gserviceaccount.com",
+            "client_id": "0",
+            "token_uri": f"{fake_gcs_server_endpoint(namespace)}/token",
+        }
+    )
+
+
+def create_gcs_bucket(namespace: str, bucket_name: str):
+    response = requests.post(
+        f"{fake_gcs_server_endpoint(namespace)}/storage/v1/b?project=test",
+        json={"name": bucket_name},
+    )
+    # 409 is returned if the bucket already exists
+    assert response.status_code in (200, 409), response.text
+
+
+def create_azure_container(namespace: str, container_name: str):
+    """Creates the container with a request authorized by the Azurite account key (Shared Key authorization)."""
+    date = formatdate(usegmt=True)
+    version = "2021-08-06"
+    canonicalized_headers = f"x-ms-date:{date}\nx-ms-version:{version}\n"
+    canonicalized_resource = f"/{AZURITE_ACCOUNT_NAME}/{AZURITE_ACCOUNT_NAME}/{container_name}\nrestype:container"
+    string_to_sign = "PUT\n" + "\n" * 11 + canonicalized_headers + canonicalized_resource
+    signature = base64.b64encode(
+        hmac.new(base64.b64decode(AZURITE_ACCOUNT_KEY), string_to_sign.encode(), hashlib.sha256).digest()
+    ).decode()
+
+    response = requests.put(
+        f"{azurite_endpoint(namespace)}/{container_name}?restype=container",
+        headers={
+            "x-ms-date": date,
+            "x-ms-version": version,
+            "Authorization": f"SharedKey {AZURITE_ACCOUNT_NAME}:{signature}",
+        },
+    )
+    # 409 is returned if the container already exists
+    assert response.status_code in (201, 409), response.text
+
+
+def new_om_gcs_store(store_id: str, bucket_name: str, endpoint: str, uri: Optional[str] = None) -> dict:
+    store = {
+        "id": store_id,
+        "bucketName": bucket_name,
+        "endpoint": endpoint,
+        "authMethod": "SERVICE_ACCOUNT_KEY",
+        "assignmentEnabled": True,
+    }
+    if uri is not None:
+        store["uri"] = uri
+    return store
+
+
+def new_om_azure_blob_store(store_id: str, container_name: str, endpoint: str) -> dict:
+    return {
+        "id": store_id,
+        "storageAccountName": AZURITE_ACCOUNT_NAME,
+        "containerName": container_name,
+        "endpoint": endpoint,
+        "authMethod": "SHARED_KEY",
+        "assignmentEnabled": True,
+    }
+
+
+@fixture(scope="module")
+def oplog_replica_set(ops_manager, namespace, custom_mdb_version) -> MongoDB:
+    resource = MongoDB.from_yaml(
+        yaml_fixture("replica-set-for-om.yaml"),
+        namespace=namespace,
+        name=OPLOG_RS_NAME,
+    ).configure(ops_manager, "development")
+
+    resource.set_version(custom_mdb_version)
+
+    try_load(resource)
+    return resource
+
+
+@fixture(scope="module")
+def ops_manager(
+    namespace: str,
+    custom_version: Optional[str],
+    custom_appdb_version: str,
+) -> MongoDBOpsManager:
+    resource: MongoDBOpsManager = MongoDBOpsManager.from_yaml(
+        yaml_fixture("om_ops_manager_backup_light.yaml"), namespace=namespace
+    )
+
+    if try_load(resource):
+        return resource
+
+    create_or_update_secret(namespace, GCS_SECRET_NAME, {"serviceAccountKey": fake_service_account_key(namespace)})
+    create_or_update_secret(namespace, AZURE_SECRET_NAME, {"accountKey": AZURITE_ACCOUNT_KEY})
+
+    resource.set_version(custom_version)
+    resource.set_appdb_version(custom_appdb_version)
+    resource["spec"]["backup"]["members"] = 1
+    resource["spec"]["backup"]["opLogStores"] = [{"name": "oplog1", "mongodbResourceRef": {"name": OPLOG_RS_NAME}}]
+    del resource["spec"]["backup"]["s3Stores"]
+    resource["spec"]["backup"]["gcsStores"] = [
+        {
+            "name": "gcsStore1",
+            "gcsSecretRef": {"name": GCS_SECRET_NAME},
+            "bucketName": GCS_BUCKET_NAME,
+            "endpoint": fake_gcs_server_endpoint(namespace),
+        }
+    ]
+    resource["spec"]["backup"]["azureBlobStores"] = [
+        {
+            "name": "azureStore1",
+            "azureSecretRef": {"name": AZURE_SECRET_NAME},
+            "storageAccountName": AZURITE_ACCOUNT_NAME,
+            "containerName": AZURE_CONTAINER_NAME,
+            "endpoint": azurite_endpoint(namespace),
+        }
+    ]
+
+    if is_multi_cluster():
+        enable_multi_cluster_deployment(resource)
+
+    return resource
+
+
+@mark.e2e_om_ops_manager_backup_gcs_azure
+class TestBlobStoreEmulators:
+    def test_deploy_emulators(self, namespace: str):
+        create_or_replace_from_yaml(
+            client.api_client.ApiClient(), yaml_fixture("blob_store_emulators.yaml"), namespace=namespace
+        )
+        get_pod_when_ready(namespace, "app=fake-gcs-server")
+        get_pod_when_ready(namespace, "app=azurite")
+
+    def test_create_bucket_and_container(self, namespace: str):
+        create_gcs_bucket(namespace, GCS_BUCKET_NAME)
+        create_azure_container(namespace, AZURE_CONTAINER_NAME)
+
+
+@mark.e2e_om_ops_manager_backup_gcs_azure
+class TestOpsManagerCreation:
+    def test_create_om(self, ops_manager: MongoDBOpsManager):
+        ops_manager.update()
+        ops_manager.om_status().assert_reaches_phase(Phase.Running, timeout=900)
+
+    def test_oplog_mdb_created(self, oplog_replica_set: MongoDB):
+        oplog_replica_set.update()
+        oplog_replica_set.assert_reaches_phase(Phase.Running)
+
+    def test_backup_is_running(self, ops_manager: MongoDBOpsManager):
+        ops_manager.backup_status().assert_reaches_phase(Phase.Running, timeout=600, ignore_errors=True)
+
+    def test_blob_stores_created(self, ops_manager: MongoDBOpsManager, namespace: str):
+        om_tester = ops_manager.get_om_tester()
+        om_tester.assert_gcs_stores(
+            [new_om_gcs_store("gcsStore1", GCS_BUCKET_NAME, fake_gcs_server_endpoint(namespace))]
+        )
+        om_tester.assert_azure_blob_stores(
+            [new_om_azure_blob_store("azureStore1", AZURE_CONTAINER_NAME, azurite_endpoint(namespace))]
+        )
+
+
+@mark.e2e_om_ops_manager_backup_gcs_azure
+class TestBlobStoresUpdate:
+    def test_update_gcs_store(self, ops_manager: MongoDBOpsManager, namespace: str):
+        create_gcs_bucket(namespace, "gcs-snapshots-2")
+        ops_manager.load()
+        ops_manager["spec"]["backup"]["gcsStores"][0]["bucketName"] = "gcs-snapshots-2"
+        ops_manager.update()
+
+        om_tester = ops_manager.get_om_tester()
+
+        def gcs_store_updated():
+            try:
+                om_tester.assert_gcs_stores(
+                    [new_om_gcs_store("gcsStore1", "gcs-snapshots-2", fake_gcs_server_endpoint(namespace))]
+                )
+                return True
+            except AssertionError:
+                return False
+
+        run_periodically(gcs_store_updated, timeout=300)
+        ops_manager.backup_status().assert_reaches_phase(Phase.Running, timeout=300, ignore_errors=True)
+
+    def test_remove_azure_blob_store(self, ops_manager: MongoDBOpsManager):
+        ops_manager.load()
+        del ops_manager["spec"]["backup"]["azureBlobStores"]
+        ops_manager.update()
+
+        om_tester = ops_manager.get_om_tester()
+
+        def azure_blob_store_removed():
+            try:
+                om_tester.assert_azure_blob_stores([])
+                return True
+            except AssertionError:
+                return False
+
+        run_periodically(azure_blob_store_removed, timeout=300)
+        ops_manager.backup_status().assert_reaches_phase(Phase.Running, timeout=300, ignore_errors=True)
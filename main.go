@@ -51,7 +51,9 @@ import (
 	mcoController "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers"          //nolint:depguard
 	mcoConstruct "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct" //nolint:depguard
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/memberwatch"
 	"github.com/mongodb/mongodb-kubernetes/pkg/pprof"
 	"github.com/mongodb/mongodb-kubernetes/pkg/telemetry"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
@@ -62,14 +64,15 @@ import (
 )
 
 const (
-	mongoDBCRDPlural             = "mongodb"
-	mongoDBUserCRDPlural         = "mongodbusers"
-	mongoDBOpsManagerCRDPlural   = "opsmanagers"
-	mongoDBMultiClusterCRDPlural = "mongodbmulticluster"
-	mongoDBCommunityCRDPlural    = "mongodbcommunity"
-	mongoDBSearchCRDPlural       = "mongodbsearch"
-	voyageAICRDPlural            = "voyageais"
-	clusterMongoDBRoleCRDPlural  = "clustermongodbroles"
+	mongoDBCRDPlural               = "mongodb"
+	mongoDBUserCRDPlural           = "mongodbusers"
+	mongoDBOpsManagerCRDPlural     = "opsmanagers"
+	mongoDBMultiClusterCRDPlural   = "mongodbmulticluster"
+	mongoDBCommunityCRDPlural      = "mongodbcommunity"
+	mongoDBSearchCRDPlural         = "mongodbsearch"
+	voyageAICRDPlural              = "voyageais"
+	clusterMongoDBRoleCRDPlural    = "clustermongodbroles"
+	mongoDBVolumeSnapshotCRDPlural = "mongodbvolumesnapshots"
 )
 
 var (
@@ -129,6 +132,7 @@ func run() error {
 			mongoDBSearchCRDPlural,
 			voyageAICRDPlural,
 			clusterMongoDBRoleCRDPlural,
+			mongoDBVolumeSnapshotCRDPlural,
 		}
 	}
 
@@ -219,31 +223,32 @@ func run() error {
 		return err
 	}
 
-	// memberClusterObjectsMap is a map of clusterName -> clusterObject
-	memberClusterObjectsMap := make(map[string]runtime_cluster.Cluster)
+	// memberClusterRegistry holds the cluster objects of the member clusters, the member clusters are added and removed
+	// at runtime when the member list ConfigMap or the kubeconfig change
+	var memberClusterRegistry *multicluster.MemberClusterRegistry
 
 	if slices.Contains(crds, mongoDBMultiClusterCRDPlural) {
-		memberClustersNames, err := getMemberClusters(ctx, cfg, currentNamespace)
+		memberListClient, err := client.New(cfg, client.Options{})
 		if err != nil {
 			return err
 		}
-
-		log.Infof("Watching Member clusters: %s", memberClustersNames)
-
-		if len(memberClustersNames) == 0 {
-			log.Warnf("The operator did not detect any member clusters")
+		listMemberClusters := func(ctx context.Context) ([]string, error) {
+			return getMemberClusters(ctx, memberListClient, currentNamespace)
 		}
 
-		memberClusterClients, err := multicluster.CreateMemberClusterClients(memberClustersNames, multicluster.GetKubeConfigPath())
+		memberClustersNames, err := listMemberClusters(ctx)
 		if err != nil {
 			return err
 		}
 
-		// Add the cluster object to the manager corresponding to each member clusters.
-		for k, v := range memberClusterClients {
-			var cluster runtime_cluster.Cluster
+		log.Infof("Watching Member clusters: %s", memberClustersNames)
+
+		if len(memberClustersNames) == 0 {
+			log.Warnf("The operator did not detect any member clusters")
+		}
 
-			cluster, err := runtime_cluster.New(v, func(options *runtime_cluster.Options) {
+		memberClusterRegistry = multicluster.NewMemberClusterRegistry(multicluster.GetKubeConfigPath(), func(_ string, config *rest.Config) (runtime_cluster.Cluster, error) {
+			return runtime_cluster.New(config, func(options *runtime_cluster.Options) {
 				// Use the operator scheme so cross-cluster owner references
 				// can resolve our CRD types (default scheme lacks them).
 				options.Scheme = scheme
@@ -257,39 +262,41 @@ func run() error {
 					}
 				}
 			})
-			if err != nil {
-				// don't panic here but rather log the error, for example, error might happen when one of the cluster is
-				// unreachable, we would still like the operator to continue reconciliation on the other clusters.
-				log.Errorf("Failed to initialize client for cluster: %s, err: %s", k, err)
-				continue
-			}
-
-			log.Infof("Adding cluster %s to cluster map.", k)
-			memberClusterObjectsMap[k] = cluster
-			if err = mgr.Add(cluster); err != nil {
-				return err
-			}
+		}, listMemberClusters)
+
+		if err := memberClusterRegistry.Sync(memberClustersNames, log); err != nil {
+			return err
+		}
+		if err := mgr.Add(memberClusterRegistry); err != nil {
+			return err
 		}
 	}
 
+	// the operator watches the member clusters' API servers to determine whether the clusters are healthy or not, the
+	// controllers of the multi-cluster resources register the resources they reconcile when a member cluster fails
+	var memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker
+	if memberClusterRegistry != nil {
+		memberClusterHealthChecker = memberwatch.NewMemberClusterHealthChecker(env.ReadIntOrDefault(util.RequiredHealthyStreakEnv, util.DefaultRequiredHealthyStreak))
+	}
+
 	// Setup all Controllers
 	if slices.Contains(crds, mongoDBCRDPlural) {
-		if err := setupMongoDBCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterObjectsMap, backupEnableDelay); err != nil {
+		if err := setupMongoDBCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterRegistry, memberClusterHealthChecker, backupEnableDelay); err != nil {
 			return err
 		}
 	}
 	if slices.Contains(crds, mongoDBOpsManagerCRDPlural) {
-		if err := setupMongoDBOpsManagerCRD(ctx, mgr, memberClusterObjectsMap, imageUrls, initDatabaseNonStaticImageVersion, initOpsManagerImageVersion, defaultArchitecture); err != nil {
+		if err := setupMongoDBOpsManagerCRD(ctx, mgr, memberClusterRegistry, memberClusterHealthChecker, imageUrls, initDatabaseNonStaticImageVersion, initOpsManagerImageVersion, defaultArchitecture); err != nil {
 			return err
 		}
 	}
 	if slices.Contains(crds, mongoDBUserCRDPlural) {
-		if err := setupMongoDBUserCRD(ctx, mgr, memberClusterObjectsMap, backupEnableDelay); err != nil {
+		if err := setupMongoDBUserCRD(ctx, mgr, memberClusterRegistry, backupEnableDelay); err != nil {
 			return err
 		}
 	}
 	if slices.Contains(crds, mongoDBMultiClusterCRDPlural) {
-		if err := setupMongoDBMultiClusterCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterObjectsMap); err != nil {
+		if err := setupMongoDBMultiClusterCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterRegistry, memberClusterHealthChecker); err != nil {
 			return err
 		}
 	}
@@ -298,7 +305,7 @@ func run() error {
 		if operatorClusterName != "" {
 			log.Infof("Per-cluster operator mode enabled for MongoDBSearch: operator cluster identity = %q", operatorClusterName)
 		}
-		if err := setupMongoDBSearchCRD(ctx, mgr, memberClusterObjectsMap, operatorClusterName); err != nil {
+		if err := setupMongoDBSearchCRD(ctx, mgr, memberClusterRegistry, memberClusterHealthChecker, operatorClusterName); err != nil {
 			return err
 		}
 	}
@@ -307,11 +314,27 @@ func run() error {
 			return err
 		}
 	}
+	if slices.Contains(crds, mongoDBVolumeSnapshotCRDPlural) {
+		if err := operator.AddMongoDBVolumeSnapshotController(ctx, mgr); err != nil {
+			return err
+		}
+	}
 
 	for _, r := range crds {
 		log.Infof("Registered CRD: %s", r)
 	}
 
+	if memberClusterHealthChecker != nil {
+		// only the leader marks the failed member clusters on the resources
+		err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			memberClusterHealthChecker.WatchMemberClusterHealth(ctx, zap.S(), kubernetesClient.NewClient(mgr.GetClient()), memberClusterRegistry)
+			return nil
+		}))
+		if err != nil {
+			return err
+		}
+	}
+
 	if slices.Contains(crds, mongoDBCommunityCRDPlural) {
 		if err := setupCommunityController(
 			ctx,
@@ -332,7 +355,7 @@ func run() error {
 	if telemetry.IsTelemetryActivated() {
 		log.Info("Running telemetry component!")
 		installerMethod := env.ReadOrDefault(telemetry.InstallerEnvVar, "")
-		telemetryRunnable, err := telemetry.NewLeaderRunnable(mgr, memberClusterObjectsMap, currentNamespace, imageUrls[util.MongodbImageEnv], imageUrls[util.NonStaticDatabaseEnterpriseImage], installerMethod, getOperatorEnv(), defaultArchitecture)
+		telemetryRunnable, err := telemetry.NewLeaderRunnable(mgr, memberClusterRegistry.Clusters(), currentNamespace, imageUrls[util.MongodbImageEnv], imageUrls[util.NonStaticDatabaseEnterpriseImage], installerMethod, getOperatorEnv(), defaultArchitecture)
 		if err != nil {
 			log.Errorf("Unable to enable telemetry; err: %s", err)
 		}
@@ -385,14 +408,14 @@ func shutdownTracerProvider(signalCtx context.Context, tp *sdktrace.TracerProvid
 	}
 }
 
-func setupMongoDBCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, memberClusterObjectsMap map[string]runtime_cluster.Cluster, backupEnableDelay time.Duration) error {
+func setupMongoDBCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, memberClusterRegistry *multicluster.MemberClusterRegistry, memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker, backupEnableDelay time.Duration) error {
 	if err := operator.AddStandaloneController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture); err != nil {
 		return err
 	}
 	if err := operator.AddReplicaSetController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture); err != nil {
 		return err
 	}
-	if err := operator.AddShardedClusterController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterObjectsMap, backupEnableDelay); err != nil {
+	if err := operator.AddShardedClusterController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterRegistry, memberClusterHealthChecker, backupEnableDelay); err != nil {
 		return err
 	}
 	return ctrl.NewWebhookManagedBy(mgr).For(&mdbv1.MongoDB{}).
@@ -400,8 +423,8 @@ func setupMongoDBCRD(ctx context.Context, mgr manager.Manager, imageUrls images.
 		Complete()
 }
 
-func setupMongoDBOpsManagerCRD(ctx context.Context, mgr manager.Manager, memberClusterObjectsMap map[string]runtime_cluster.Cluster, imageUrls images.ImageUrls, initDatabaseVersion, initOpsManagerImageVersion string, defaultArchitecture architectures.DefaultArchitecture) error {
-	if err := operator.AddOpsManagerController(ctx, mgr, memberClusterObjectsMap, imageUrls, initDatabaseVersion, initOpsManagerImageVersion, defaultArchitecture); err != nil {
+func setupMongoDBOpsManagerCRD(ctx context.Context, mgr manager.Manager, memberClusterRegistry *multicluster.MemberClusterRegistry, memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker, imageUrls images.ImageUrls, initDatabaseVersion, initOpsManagerImageVersion string, defaultArchitecture architectures.DefaultArchitecture) error {
+	if err := operator.AddOpsManagerController(ctx, mgr, memberClusterRegistry, memberClusterHealthChecker, imageUrls, initDatabaseVersion, initOpsManagerImageVersion, defaultArchitecture); err != nil {
 		return err
 	}
 	return ctrl.NewWebhookManagedBy(mgr).For(&omv1.MongoDBOpsManager{}).
@@ -409,13 +432,12 @@ func setupMongoDBOpsManagerCRD(ctx context.Context, mgr manager.Manager, memberC
 		Complete()
 }
 
-func setupMongoDBUserCRD(ctx context.Context, mgr manager.Manager, memberClusterObjectsMap map[string]runtime_cluster.Cluster, backupEnableDelay time.Duration) error {
-	return operator.AddMongoDBUserController(ctx, mgr, memberClusterObjectsMap, backupEnableDelay)
+func setupMongoDBUserCRD(ctx context.Context, mgr manager.Manager, memberClusterRegistry *multicluster.MemberClusterRegistry, backupEnableDelay time.Duration) error {
+	return operator.AddMongoDBUserController(ctx, mgr, memberClusterRegistry, backupEnableDelay)
 }
 
-func setupMongoDBMultiClusterCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, memberClusterObjectsMap map[string]runtime_cluster.Cluster) error {
-	requiredHealthyStreak := env.ReadIntOrDefault(util.RequiredHealthyStreakEnv, util.DefaultRequiredHealthyStreak)
-	if err := operator.AddMultiReplicaSetController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, requiredHealthyStreak, memberClusterObjectsMap); err != nil {
+func setupMongoDBMultiClusterCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise, enableClusterMongoDBRoles, agentDebug bool, agentDebugImage string, defaultArchitecture architectures.DefaultArchitecture, memberClusterRegistry *multicluster.MemberClusterRegistry, memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker) error {
+	if err := operator.AddMultiReplicaSetController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, agentDebug, agentDebugImage, defaultArchitecture, memberClusterHealthChecker, memberClusterRegistry); err != nil {
 		return err
 	}
 	return ctrl.NewWebhookManagedBy(mgr).For(&mdbmultiv1.MongoDBMultiCluster{}).
@@ -431,27 +453,28 @@ func setupVoyageAICRD(ctx context.Context, mgr manager.Manager) error {
 func setupMongoDBSearchCRD(
 	ctx context.Context,
 	mgr manager.Manager,
-	memberClusterObjectsMap map[string]runtime_cluster.Cluster,
+	memberClusterRegistry *multicluster.MemberClusterRegistry,
+	memberClusterHealthChecker *memberwatch.MemberClusterHealthChecker,
 	operatorClusterName string,
 ) error {
 	if err := operator.AddMongoDBSearchController(ctx, mgr, searchcontroller.OperatorSearchConfig{
 		SearchRepo:    env.ReadOrPanic(util.SearchRepoURLEnv),
 		SearchName:    env.ReadOrPanic(util.SearchNameEnv),
 		SearchVersion: env.ReadOrPanic(util.SearchVersionEnv),
-	}, memberClusterObjectsMap, operatorClusterName); err != nil {
+	}, memberClusterRegistry, memberClusterHealthChecker, operatorClusterName); err != nil {
 		return err
 	}
 
 	// We cannot use ReadOrPanic here because this variable is only needed when Search is used with a managed load
 	// balancer
 	envoyImage := env.ReadOrDefault(util.EnvoyImageEnv, "")
-	if err := operator.AddMongoDBSearchEnvoyController(ctx, mgr, envoyImage, memberClusterObjectsMap, operatorClusterName); err != nil {
+	if err := operator.AddMongoDBSearchEnvoyController(ctx, mgr, envoyImage, memberClusterRegistry, operatorClusterName); err != nil {
 		return err
 	}
 
 	// Metrics forwarder controller — image is again enforced in controller
 	metricsForwarderImage := env.ReadOrDefault(util.MetricsForwarderImageEnv, "")
-	if err := operator.AddMongoDBSearchMetricsForwarderController(ctx, mgr, metricsForwarderImage, memberClusterObjectsMap, operatorClusterName); err != nil {
+	if err := operator.AddMongoDBSearchMetricsForwarderController(ctx, mgr, metricsForwarderImage, memberClusterRegistry, operatorClusterName); err != nil {
 		return err
 	}
 
@@ -480,14 +503,9 @@ func setupCommunityController(
 }
 
 // getMemberClusters retrieves the member clusters from the configmap util.MemberListConfigMapName
-func getMemberClusters(ctx context.Context, cfg *rest.Config, currentNamespace string) ([]string, error) {
-	c, err := client.New(cfg, client.Options{})
-	if err != nil {
-		panic(err)
-	}
-
+func getMemberClusters(ctx context.Context, c client.Client, currentNamespace string) ([]string, error) {
 	m := corev1.ConfigMap{}
-	err = c.Get(ctx, types.NamespacedName{Name: util.MemberListConfigMapName, Namespace: currentNamespace}, &m)
+	err := c.Get(ctx, types.NamespacedName{Name: util.MemberListConfigMapName, Namespace: currentNamespace}, &m)
 	if err != nil {
 		return nil, err
 	}
@@ -130,6 +130,29 @@ type MongoDBCommunitySpec struct {
 	// MemberConfig
 	// +optional
 	MemberConfig []automationconfig.MemberOptions `json:"memberConfig,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget protecting the members and arbiters of the replica set.
+	// By default, as many Pods can be evicted as the replica set can lose while keeping a majority of its voting members.
+	// +optional
+	PodDisruptionBudget *v1.PodDisruptionBudgetConfiguration `json:"podDisruptionBudget,omitempty"`
+
+	// TopologySpread spreads the members and arbiters of the replica set across zones and nodes. The arbiters share the
+	// Pod labels of the members, so they are counted together. A single zone can't be allowed to hold a majority of the members.
+	// +optional
+	TopologySpread *v1.TopologySpread `json:"topologySpread,omitempty"`
+
+	// RestoreFromSnapshot creates the volumes of the members from a MongoDBVolumeSnapshot. The arbiters are not
+	// restored.
+	// +optional
+	RestoreFromSnapshot *v1.RestoreFromSnapshot `json:"restoreFromSnapshot,omitempty"`
+
+	// Persistence configures the size, storage class and label selector of the volumes of the members and arbiters.
+	// "single" stores the data and the logs in a single volume. "multiple" configures the data and the logs volumes,
+	// and moves the journal to its own volume when "journal" is set. The volumes of a StatefulSet can't be added or
+	// removed once it is created, but their storage can be increased. The volumes set in spec.statefulSet take
+	// precedence.
+	// +optional
+	Persistence *v1.Persistence `json:"persistence,omitempty"`
 }
 
 // ReplicaSetHorizonConfiguration holds the split horizon DNS settings for
@@ -368,6 +391,21 @@ type Security struct {
 	// User-specified custom MongoDB roles that should be configured in the deployment.
 	// +optional
 	Roles []CustomRole `json:"roles,omitempty"`
+	// References to MongoDBRole resources, in the same namespace, defining the custom MongoDB roles that should be
+	// configured in the deployment. At most one of roles or roleRefs can be specified.
+	// +optional
+	RoleRefs []RoleRef `json:"roleRefs,omitempty"`
+}
+
+// RoleRef is a reference to a resource defining a custom MongoDB role.
+type RoleRef struct {
+	// Name of the referenced role.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Kind of the referenced role.
+	// +kubebuilder:validation:Enum=MongoDBRole
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
 }
 
 // TLS is the configuration used to set up TLS encryption
@@ -935,8 +973,16 @@ func (m *MongoDBCommunity) getLastVersion() string {
 	return annotations.GetAnnotation(m, annotations.LastAppliedMongoDBVersion)
 }
 
+// HasSeparateDataAndLogsVolumes returns false only when spec.persistence.single is set, as the data and the logs have
+// always had their own volumes.
 func (m *MongoDBCommunity) HasSeparateDataAndLogsVolumes() bool {
-	return true
+	return m.Spec.Persistence == nil || m.Spec.Persistence.SingleConfig == nil
+}
+
+// HasSeparateJournalVolume returns whether the journal is stored in its own volume instead of the data volume.
+func (m *MongoDBCommunity) HasSeparateJournalVolume() bool {
+	p := m.Spec.Persistence
+	return p != nil && p.SingleConfig == nil && p.MultipleConfig != nil && p.MultipleConfig.Journal != nil
 }
 
 func (m *MongoDBCommunity) GetAnnotations() map[string]string {
@@ -951,6 +997,10 @@ func (m *MongoDBCommunity) LogsVolumeName() string {
 	return "logs-volume"
 }
 
+func (m *MongoDBCommunity) JournalVolumeName() string {
+	return "journal-volume"
+}
+
 func (m *MongoDBCommunity) NeedsAutomationConfigVolume() bool {
 	return true
 }
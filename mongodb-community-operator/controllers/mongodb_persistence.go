@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/container"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/persistentvolumeclaim"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/podtemplatespec"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/resourcerequirements"
+	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+const (
+	defaultDataStorage = "10G"
+	defaultLogsStorage = "2G"
+)
+
+// buildPersistenceModificationFunction configures the volume claims of the StatefulSet with spec.persistence. The
+// claims are created by the common construction, this only sets their storage, storage class and label selector, and
+// adds the journal volume, which is mounted over the journal directory of the data volume.
+func buildPersistenceModificationFunction(mdb mdbv1.MongoDBCommunity) statefulset.Modification {
+	persistence := mdb.Spec.Persistence
+	if persistence == nil {
+		return statefulset.NOOP()
+	}
+
+	if !mdb.HasSeparateDataAndLogsVolumes() {
+		return statefulset.WithVolumeClaim(mdb.DataVolumeName(), persistenceClaim(mdb.DataVolumeName(), persistence.SingleConfig, defaultDataStorage))
+	}
+
+	multiple := persistence.MultipleConfig
+	if multiple == nil {
+		multiple = &v1.MultiplePersistenceConfig{}
+	}
+	modification := statefulset.Apply(
+		statefulset.WithVolumeClaim(mdb.DataVolumeName(), persistenceClaim(mdb.DataVolumeName(), multiple.Data, defaultDataStorage)),
+		statefulset.WithVolumeClaim(mdb.LogsVolumeName(), persistenceClaim(mdb.LogsVolumeName(), multiple.Logs, defaultLogsStorage)),
+	)
+	if !mdb.HasSeparateJournalVolume() {
+		return modification
+	}
+
+	journalVolumeMount := statefulset.CreateVolumeMount(mdb.JournalVolumeName(), path.Join(mdb.GetMongodConfiguration().GetDBDataDir(), "journal"))
+	return statefulset.Apply(
+		modification,
+		statefulset.WithVolumeClaim(mdb.JournalVolumeName(), persistenceClaim(mdb.JournalVolumeName(), multiple.Journal, util.DefaultJournalStorageSize)),
+		statefulset.WithPodSpecTemplate(
+			podtemplatespec.Apply(
+				podtemplatespec.WithContainer(construct.AgentName, container.WithVolumeMounts([]corev1.VolumeMount{journalVolumeMount})),
+				podtemplatespec.WithContainer(construct.MongodbName, container.WithVolumeMounts([]corev1.VolumeMount{journalVolumeMount})),
+			),
+		),
+	)
+}
+
+// persistenceClaim builds a volume claim from the configuration of a volume in spec.persistence.
+func persistenceClaim(name string, config *v1.PersistenceConfig, defaultStorage string) persistentvolumeclaim.Modification {
+	storage := defaultStorage
+	selectorFunc := persistentvolumeclaim.NOOP()
+	storageClassNameFunc := persistentvolumeclaim.NOOP()
+	if config != nil {
+		if config.Storage != "" {
+			storage = config.Storage
+		}
+		if config.LabelSelector != nil {
+			selectorFunc = persistentvolumeclaim.WithLabelSelector(&config.LabelSelector.LabelSelector)
+		}
+		if config.StorageClass != nil {
+			storageClassNameFunc = persistentvolumeclaim.WithStorageClassName(*config.StorageClass)
+		}
+	}
+	return persistentvolumeclaim.Apply(
+		persistentvolumeclaim.WithName(name),
+		persistentvolumeclaim.WithAccessModes(corev1.ReadWriteOnce),
+		persistentvolumeclaim.WithResourceRequests(resourcerequirements.BuildStorageRequirements(storage)),
+		selectorFunc,
+		storageClassNameFunc,
+	)
+}
+
+// resizeVolumes expands the PVCs of the StatefulSet when the storage of its volume claim templates is increased.
+// The volume claim templates can't be updated, so once the PVCs have been expanded, the StatefulSet is deleted
+// leaving its Pods running, and the caller creates it again with the new templates. The PVC size annotation added to
+// the Pod template restarts the Pods, for the file systems which are only expanded when the volume is mounted.
+//
+// The returned boolean is false while the PVCs are being expanded.
+func (r *ReplicaSetReconciler) resizeVolumes(ctx context.Context, existing appsv1.StatefulSet, desired *appsv1.StatefulSet) (bool, error) {
+	expanded := false
+	for _, desiredClaim := range desired.Spec.VolumeClaimTemplates {
+		for _, existingClaim := range existing.Spec.VolumeClaimTemplates {
+			if existingClaim.Name != desiredClaim.Name {
+				continue
+			}
+			from, to := existingClaim.Spec.Resources.Requests.Storage(), desiredClaim.Spec.Resources.Requests.Storage()
+			switch from.Cmp(*to) {
+			case 1:
+				return false, fmt.Errorf("the storage of volume %s can't be decreased from %s to %s", desiredClaim.Name, from, to)
+			case -1:
+				r.log.Infof("Expanding the PVCs of volume %s from %s to %s", desiredClaim.Name, from, to)
+				expanded = true
+			}
+		}
+	}
+	if !expanded {
+		return true, nil
+	}
+
+	if err := statefulset.AddPVCAnnotation(desired); err != nil {
+		return false, fmt.Errorf("error adding the PVC size annotation: %s", err)
+	}
+	if err := statefulset.ResizePVCs(ctx, r.client, desired, r.log); err != nil {
+		return false, fmt.Errorf("error expanding the PVCs: %s", err)
+	}
+	if finished, err := statefulset.HasFinishedResizingPVCs(ctx, r.client, desired); err != nil || !finished {
+		return false, err
+	}
+
+	r.log.Infof("The PVCs have been expanded, deleting StatefulSet %s and orphaning its Pods", desired.Name)
+	if err := r.client.Delete(ctx, &existing, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !apiErrors.IsNotFound(err) {
+		return false, fmt.Errorf("error deleting StatefulSet %s: %s", desired.Name, err)
+	}
+	if !statefulset.IsDeleted(ctx, r.client, desired, time.Second, r.log) {
+		return false, nil
+	}
+	desired.ResourceVersion = ""
+	desired.UID = ""
+	return true, nil
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
+)
+
+func reconcilePersistence(ctx context.Context, t *testing.T, mdb mdbv1.MongoDBCommunity) (*ReplicaSetReconciler, *client.MockedManager, appsv1.StatefulSet) {
+	mgr := client.NewManager(ctx, &mdb)
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage")
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	sts, err := mgr.Client.GetStatefulSet(ctx, mdb.NamespacedName())
+	require.NoError(t, err)
+	return r, mgr, sts
+}
+
+func claimsByName(sts appsv1.StatefulSet) map[string]corev1.PersistentVolumeClaim {
+	claims := map[string]corev1.PersistentVolumeClaim{}
+	for _, claim := range sts.Spec.VolumeClaimTemplates {
+		claims[claim.Name] = claim
+	}
+	return claims
+}
+
+func mountsByName(sts appsv1.StatefulSet, containerName string) map[string]corev1.VolumeMount {
+	mounts := map[string]corev1.VolumeMount{}
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		if c.Name == containerName {
+			for _, mount := range c.VolumeMounts {
+				mounts[mount.MountPath] = mount
+			}
+		}
+	}
+	return mounts
+}
+
+func TestPersistence_SeparateJournalVolume(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestReplicaSet()
+	mdb.Spec.Persistence = &v1.Persistence{MultipleConfig: &v1.MultiplePersistenceConfig{
+		Data:    &v1.PersistenceConfig{Storage: "20G", StorageClass: ptr.To("standard")},
+		Journal: &v1.PersistenceConfig{Storage: "5G", StorageClass: ptr.To("fast")},
+	}}
+
+	_, _, sts := reconcilePersistence(ctx, t, mdb)
+
+	claims := claimsByName(sts)
+	require.Len(t, claims, 3)
+	assert.Equal(t, resource.MustParse("20G"), claims["data-volume"].Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Equal(t, ptr.To("standard"), claims["data-volume"].Spec.StorageClassName)
+	assert.Equal(t, resource.MustParse("2G"), claims["logs-volume"].Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Nil(t, claims["logs-volume"].Spec.StorageClassName)
+	assert.Equal(t, resource.MustParse("5G"), claims["journal-volume"].Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Equal(t, ptr.To("fast"), claims["journal-volume"].Spec.StorageClassName)
+
+	for _, containerName := range []string{construct.MongodbName, construct.AgentName} {
+		mounts := mountsByName(sts, containerName)
+		assert.Equal(t, "data-volume", mounts["/data"].Name)
+		assert.Equal(t, "journal-volume", mounts["/data/journal"].Name)
+	}
+}
+
+func TestPersistence_SingleVolume(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestReplicaSet()
+	mdb.Spec.Persistence = &v1.Persistence{SingleConfig: &v1.PersistenceConfig{Storage: "50G"}}
+
+	_, _, sts := reconcilePersistence(ctx, t, mdb)
+
+	claims := claimsByName(sts)
+	require.Len(t, claims, 1)
+	assert.Equal(t, resource.MustParse("50G"), claims["data-volume"].Spec.Resources.Requests[corev1.ResourceStorage])
+
+	mounts := mountsByName(sts, construct.MongodbName)
+	assert.Equal(t, corev1.VolumeMount{Name: "data-volume", MountPath: "/data", SubPath: "data"}, mounts["/data"])
+	assert.Equal(t, "logs", mounts["/var/log/mongodb-mms-automation"].SubPath)
+}
+
+func TestPersistence_StorageIsIncreased(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestReplicaSet()
+	mdb.Spec.Persistence = &v1.Persistence{MultipleConfig: &v1.MultiplePersistenceConfig{Journal: &v1.PersistenceConfig{Storage: "1G"}}}
+
+	r, mgr, sts := reconcilePersistence(ctx, t, mdb)
+	assert.NotContains(t, sts.Spec.Template.Annotations, statefulset.PVCSizeAnnotation)
+
+	err := mgr.Client.Get(ctx, mdb.NamespacedName(), &mdb)
+	require.NoError(t, err)
+	mdb.Spec.Persistence.MultipleConfig.Journal.Storage = "2G"
+	require.NoError(t, mgr.Client.Update(ctx, &mdb))
+
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	sts, err = mgr.Client.GetStatefulSet(ctx, mdb.NamespacedName())
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("2G"), claimsByName(sts)["journal-volume"].Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Contains(t, sts.Spec.Template.Annotations, statefulset.PVCSizeAnnotation, "the Pods are restarted to expand the file systems")
+
+	// the storage can't be decreased
+	err = mgr.Client.Get(ctx, mdb.NamespacedName(), &mdb)
+	require.NoError(t, err)
+	mdb.Spec.Persistence.MultipleConfig.Journal.Storage = "1G"
+	require.NoError(t, mgr.Client.Update(ctx, &mdb))
+
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	require.NoError(t, err)
+	err = mgr.Client.Get(ctx, mdb.NamespacedName(), &mdb)
+	require.NoError(t, err)
+	assert.Equal(t, mdbv1.Failed, mdb.Status.Phase)
+	assert.Contains(t, mdb.Status.Message, "the storage of volume journal-volume can't be decreased from 2G to 1G")
+}
+
+func TestPersistence_VolumesCantBeAdded(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestReplicaSet()
+
+	r, mgr, _ := reconcilePersistence(ctx, t, mdb)
+
+	err := mgr.Client.Get(ctx, mdb.NamespacedName(), &mdb)
+	require.NoError(t, err)
+	mdb.Spec.Persistence = &v1.Persistence{MultipleConfig: &v1.MultiplePersistenceConfig{Journal: &v1.PersistenceConfig{Storage: "1G"}}}
+	require.NoError(t, mgr.Client.Update(ctx, &mdb))
+
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	require.NoError(t, err)
+	err = mgr.Client.Get(ctx, mdb.NamespacedName(), &mdb)
+	require.NoError(t, err)
+	assert.Equal(t, mdbv1.Failed, mdb.Status.Phase)
+	assert.Contains(t, mdb.Status.Message, "the volumes of spec.persistence can't be changed from [data-volume logs-volume] to [data-volume journal-volume logs-volume]")
+}
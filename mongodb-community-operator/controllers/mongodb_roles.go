@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1enterprise "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	rolev1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/role"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+// getReferencedRoles reads the MongoDBRole resources referenced in spec.security.roleRefs. The resources are watched,
+// so any change to them, including their creation or deletion, triggers the reconciliation of the MongoDBCommunity.
+func (r ReplicaSetReconciler) getReferencedRoles(ctx context.Context, mdb mdbv1.MongoDBCommunity) ([]mdbv1.CustomRole, error) {
+	roles := make([]mdbv1.CustomRole, 0, len(mdb.Spec.Security.RoleRefs))
+	for _, ref := range mdb.Spec.Security.RoleRefs {
+		if ref.Kind != util.MongoDBRoleKind {
+			return nil, fmt.Errorf("invalid value %s for roleRef.kind. It must be %s", ref.Kind, util.MongoDBRoleKind)
+		}
+
+		roleNamespacedName := types.NamespacedName{Name: ref.Name, Namespace: mdb.Namespace}
+		r.roleWatcher.Watch(ctx, roleNamespacedName, mdb.NamespacedName())
+
+		role := &rolev1.MongoDBRole{}
+		if err := r.client.Get(ctx, roleNamespacedName, role); err != nil {
+			if apiErrors.IsNotFound(err) {
+				return nil, fmt.Errorf("MongoDBRole '%s' not found in namespace %s. If the resource was deleted, the role is still present in MongoDB. To correctly remove a role from MongoDB, please remove the reference from spec.security.roleRefs", ref.Name, mdb.Namespace)
+			}
+			return nil, fmt.Errorf("failed to retrieve MongoDBRole '%s': %w", ref.Name, err)
+		}
+
+		if res := mdbv1enterprise.RoleIsCorrectlyConfigured(role.Spec.MongoDBRole, mdb.Spec.Version); res.Level == v1.ErrorLevel {
+			return nil, fmt.Errorf("error validating role '%s' - %s", ref.Name, res.Msg)
+		}
+
+		roles = append(roles, convertMongoDBRole(role.Spec.MongoDBRole))
+	}
+	return roles, nil
+}
+
+// convertMongoDBRole converts the role shared by the MongoDB resources to the MongoDBCommunity one.
+func convertMongoDBRole(role mdbv1enterprise.MongoDBRole) mdbv1.CustomRole {
+	customRole := mdbv1.CustomRole{
+		Role: role.Role,
+		DB:   role.Db,
+	}
+
+	for _, privilege := range role.Privileges {
+		customRole.Privileges = append(customRole.Privileges, mdbv1.Privilege{
+			Resource: mdbv1.Resource{
+				DB:         privilege.Resource.Db,
+				Collection: privilege.Resource.Collection,
+				Cluster:    ptr.Deref(privilege.Resource.Cluster, false),
+			},
+			Actions: privilege.Actions,
+		})
+	}
+
+	for _, inheritedRole := range role.Roles {
+		customRole.Roles = append(customRole.Roles, mdbv1.Role{
+			Name: inheritedRole.Role,
+			DB:   inheritedRole.Db,
+		})
+	}
+
+	for _, restriction := range role.AuthenticationRestrictions {
+		customRole.AuthenticationRestrictions = append(customRole.AuthenticationRestrictions, mdbv1.AuthenticationRestriction{
+			ClientSource:  restriction.ClientSource,
+			ServerAddress: restriction.ServerAddress,
+		})
+	}
+
+	return customRole
+}
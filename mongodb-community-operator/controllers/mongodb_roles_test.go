@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1enterprise "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	rolev1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/role"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func TestReferencedRolesAreAddedToAutomationConfig(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestReplicaSet()
+	mdb.Spec.Security.RoleRefs = []mdbv1.RoleRef{{Name: "my-role", Kind: util.MongoDBRoleKind}}
+
+	mgr := client.NewManager(ctx, &mdb)
+	role := rolev1.DefaultMongoDBRoleBuilder().SetName("my-role").SetNamespace(mdb.Namespace).Build()
+	require.NoError(t, mgr.GetClient().Create(ctx, role))
+
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage")
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: mdb.Namespace, Name: mdb.Name}})
+	assertReconciliationSuccessful(t, res, err)
+
+	ac, err := automationconfig.ReadFromSecret(ctx, mgr.Client, types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace})
+	require.NoError(t, err)
+	require.Len(t, ac.Roles, 1)
+	assert.Equal(t, role.Spec.Role, ac.Roles[0].Role)
+	assert.Equal(t, role.Spec.Db, ac.Roles[0].DB)
+	assert.Equal(t, []automationconfig.Role{{Role: "readWrite", Database: "admin"}}, ac.Roles[0].Roles)
+}
+
+func TestReconciliationFails_WhenReferencedRoleDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestReplicaSet()
+	mdb.Spec.Security.RoleRefs = []mdbv1.RoleRef{{Name: "my-role", Kind: util.MongoDBRoleKind}}
+
+	mgr := client.NewManager(ctx, &mdb)
+	// roles in other namespaces can't be referenced
+	role := rolev1.DefaultMongoDBRoleBuilder().SetName("my-role").SetNamespace("other-ns").Build()
+	require.NoError(t, mgr.GetClient().Create(ctx, role))
+
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage")
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: mdb.Namespace, Name: mdb.Name}})
+	require.NoError(t, err)
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.Equal(t, mdbv1.Failed, mdb.Status.Phase)
+	assert.Contains(t, mdb.Status.Message, "MongoDBRole 'my-role' not found in namespace my-ns")
+}
+
+func TestConvertMongoDBRole(t *testing.T) {
+	role := mdbv1enterprise.MongoDBRole{
+		Role: "my-role",
+		Db:   "admin",
+		Privileges: []mdbv1enterprise.Privilege{
+			{Actions: []string{"find"}, Resource: mdbv1enterprise.Resource{Db: ptr.To("db"), Collection: ptr.To("coll")}},
+			{Actions: []string{"serverStatus"}, Resource: mdbv1enterprise.Resource{Cluster: ptr.To(true)}},
+		},
+		Roles:                      []mdbv1enterprise.InheritedRole{{Db: "admin", Role: "read"}},
+		AuthenticationRestrictions: []mdbv1enterprise.AuthenticationRestriction{{ClientSource: []string{"10.0.0.0/8"}}},
+	}
+
+	expected := mdbv1.CustomRole{
+		Role: "my-role",
+		DB:   "admin",
+		Privileges: []mdbv1.Privilege{
+			{Actions: []string{"find"}, Resource: mdbv1.Resource{DB: ptr.To("db"), Collection: ptr.To("coll")}},
+			{Actions: []string{"serverStatus"}, Resource: mdbv1.Resource{Cluster: true}},
+		},
+		Roles:                      []mdbv1.Role{{DB: "admin", Name: "read"}},
+		AuthenticationRestrictions: []mdbv1.AuthenticationRestriction{{ClientSource: []string{"10.0.0.0/8"}}},
+	}
+	assert.Equal(t, expected, convertMongoDBRole(role))
+}
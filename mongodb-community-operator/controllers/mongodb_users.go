@@ -3,11 +3,16 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/constants"
@@ -92,3 +97,100 @@ func (r ReplicaSetReconciler) updateConnectionStringSecrets(ctx context.Context,
 
 	return nil
 }
+
+// withMongoDBUsers returns a copy of the MongoDBCommunity resource having the users defined by the MongoDBUser
+// resources referencing it added to spec.users. This way they're configured, and removed, like the users defined in
+// the resource itself, while its persisted spec is never changed.
+func (r ReplicaSetReconciler) withMongoDBUsers(ctx context.Context, mdb mdbv1.MongoDBCommunity) (mdbv1.MongoDBCommunity, error) {
+	users, err := r.getMongoDBUsers(ctx, mdb)
+	if err != nil {
+		return mdb, err
+	}
+	if len(users) == 0 {
+		return mdb, nil
+	}
+
+	mdbWithUsers := *mdb.DeepCopy()
+	for _, user := range users {
+		for _, existing := range mdb.Spec.Users {
+			if existing.Name == user.Name && existing.DB == user.DB {
+				return mdb, fmt.Errorf("user %s in database %s is defined both in spec.users and by a MongoDBUser resource", user.Name, user.DB)
+			}
+		}
+		mdbWithUsers.Spec.Users = append(mdbWithUsers.Spec.Users, user)
+	}
+	return mdbWithUsers, nil
+}
+
+// getMongoDBUsers returns the users defined by the MongoDBUser resources referencing the MongoDBCommunity. Only the
+// MongoDBUsers in the namespace of the resource can reference it.
+func (r ReplicaSetReconciler) getMongoDBUsers(ctx context.Context, mdb mdbv1.MongoDBCommunity) ([]mdbv1.MongoDBUser, error) {
+	userList := &userv1.MongoDBUserList{}
+	if err := r.client.List(ctx, userList, k8sClient.InNamespace(mdb.Namespace)); err != nil {
+		return nil, fmt.Errorf("could not list MongoDBUser resources: %s", err)
+	}
+
+	// the order of the users is kept stable, so that the automation config doesn't change between reconciliations
+	sort.Slice(userList.Items, func(i, j int) bool {
+		return userList.Items[i].Name < userList.Items[j].Name
+	})
+
+	var users []mdbv1.MongoDBUser
+	for _, user := range userList.Items {
+		if !isMongoDBUserOf(user, mdb.NamespacedName()) || !user.DeletionTimestamp.IsZero() {
+			continue
+		}
+		users = append(users, convertMongoDBUser(user))
+	}
+	return users, nil
+}
+
+// isMongoDBUserOf returns true if the MongoDBUser references the given resource.
+func isMongoDBUserOf(user userv1.MongoDBUser, mdbNamespacedName types.NamespacedName) bool {
+	ref := user.Spec.MongoDBResourceRef
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = user.Namespace
+	}
+	return ref.Name == mdbNamespacedName.Name && namespace == mdbNamespacedName.Namespace && user.Namespace == mdbNamespacedName.Namespace
+}
+
+// convertMongoDBUser converts the MongoDBUser resource to a MongoDBCommunity user. The connection string Secret has
+// the same name as for the MongoDB resources, and the SCRAM credentials are stored in a Secret named after the
+// MongoDBUser resource.
+func convertMongoDBUser(user userv1.MongoDBUser) mdbv1.MongoDBUser {
+	communityUser := mdbv1.MongoDBUser{
+		Name:                       user.Spec.Username,
+		DB:                         user.Spec.Database,
+		Roles:                      []mdbv1.Role{},
+		ConnectionStringSecretName: user.GetConnectionStringSecretName(),
+	}
+
+	for _, role := range user.Spec.Roles {
+		communityUser.Roles = append(communityUser.Roles, mdbv1.Role{
+			Name: role.RoleName,
+			DB:   role.Database,
+		})
+	}
+
+	if user.Spec.Database != constants.ExternalDB {
+		communityUser.PasswordSecretRef = v1.SecretKeyReference{
+			Name: user.Spec.PasswordSecretKeyRef.Name,
+			Key:  user.GetPasswordSecretKey(),
+		}
+		communityUser.ScramCredentialsSecretName = user.Name
+	}
+
+	return communityUser
+}
+
+// findMdbcForUser returns the MongoDBCommunity resource referenced by the MongoDBUser.
+func findMdbcForUser(ctx context.Context, rawObj k8sClient.Object) []reconcile.Request {
+	user := rawObj.(*userv1.MongoDBUser)
+	if user.Spec.MongoDBResourceRef.Name == "" {
+		return nil
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: user.Namespace, Name: user.Spec.MongoDBResourceRef.Name}},
+	}
+}
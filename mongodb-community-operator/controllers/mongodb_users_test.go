@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+)
+
+// mongoDBUserListClient returns the given MongoDBUsers when listed, as the mocked client doesn't support List.
+type mongoDBUserListClient struct {
+	k8sClient.Client
+	users []userv1.MongoDBUser
+}
+
+func (c *mongoDBUserListClient) List(ctx context.Context, list k8sClient.ObjectList, opts ...k8sClient.ListOption) error {
+	if userList, ok := list.(*userv1.MongoDBUserList); ok {
+		userList.Items = c.users
+		return nil
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func newMongoDBUser(name string, mdb mdbv1.MongoDBCommunity) userv1.MongoDBUser {
+	return userv1.MongoDBUser{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: mdb.Namespace},
+		Spec: userv1.MongoDBUserSpec{
+			Username:             name,
+			Database:             "admin",
+			Roles:                []userv1.Role{{RoleName: "readWrite", Database: "my-db"}},
+			MongoDBResourceRef:   userv1.MongoDBResourceRef{Name: mdb.Name},
+			PasswordSecretKeyRef: userv1.SecretKeyRef{Name: name + "-password", Key: "password"},
+		},
+	}
+}
+
+func TestMongoDBUsersAreAddedToAutomationConfig(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestReplicaSet()
+	user := newMongoDBUser("my-user", mdb)
+
+	listClient := &mongoDBUserListClient{Client: client.NewManager(ctx, &mdb).GetClient(), users: []userv1.MongoDBUser{user}}
+	mgr := client.NewManagerWithClient(listClient)
+	require.NoError(t, createUserPasswordSecret(ctx, mgr.Client, mdb, "my-user-password", "my-password"))
+
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage")
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	ac, err := automationconfig.ReadFromSecret(ctx, mgr.Client, types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace})
+	require.NoError(t, err)
+	require.Len(t, ac.Auth.Users, 1)
+	assert.Equal(t, "my-user", ac.Auth.Users[0].Username)
+	assert.Equal(t, "admin", ac.Auth.Users[0].Database)
+	assert.Equal(t, []automationconfig.Role{{Role: "readWrite", Database: "my-db"}}, ac.Auth.Users[0].Roles)
+
+	connectionStringSecret := corev1.Secret{}
+	require.NoError(t, mgr.Client.Get(ctx, types.NamespacedName{Name: user.GetConnectionStringSecretName(), Namespace: mdb.Namespace}, &connectionStringSecret))
+	assert.Equal(t, "my-password", string(connectionStringSecret.Data["password"]))
+
+	// the users are not added to the resource itself
+	require.NoError(t, mgr.Client.Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.Empty(t, mdb.Spec.Users)
+
+	t.Run("User is removed with the MongoDBUser", func(t *testing.T) {
+		listClient.users = nil
+		res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+		assertReconciliationSuccessful(t, res, err)
+
+		ac, err := automationconfig.ReadFromSecret(ctx, mgr.Client, types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace})
+		require.NoError(t, err)
+		assert.Empty(t, ac.Auth.Users)
+		assert.Equal(t, []automationconfig.DeletedUser{{User: "my-user", Dbs: []string{"admin"}}}, ac.Auth.UsersDeleted)
+
+		err = mgr.Client.Get(ctx, types.NamespacedName{Name: user.GetConnectionStringSecretName(), Namespace: mdb.Namespace}, &corev1.Secret{})
+		assert.Error(t, err)
+	})
+}
+
+func TestReconciliationFails_WhenMongoDBUserIsAlsoInSpec(t *testing.T) {
+	ctx := context.Background()
+	mdb := newScramReplicaSet(mdbv1.MongoDBUser{
+		Name:                       "my-user",
+		DB:                         "admin",
+		PasswordSecretRef:          v1.SecretKeyReference{Name: "my-user-password"},
+		ScramCredentialsSecretName: "my-scram",
+	})
+
+	listClient := &mongoDBUserListClient{Client: client.NewManager(ctx, &mdb).GetClient(), users: []userv1.MongoDBUser{newMongoDBUser("my-user", mdb)}}
+	mgr := client.NewManagerWithClient(listClient)
+
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage")
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	require.NoError(t, err)
+
+	require.NoError(t, mgr.Client.Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.Equal(t, mdbv1.Failed, mdb.Status.Phase)
+	assert.Contains(t, mdb.Status.Message, "user my-user in database admin is defined both in spec.users and by a MongoDBUser resource")
+}
+
+func TestIsMongoDBUserOf(t *testing.T) {
+	mdb := newTestReplicaSet()
+	user := newMongoDBUser("my-user", mdb)
+	assert.True(t, isMongoDBUserOf(user, mdb.NamespacedName()))
+
+	user.Spec.MongoDBResourceRef.Namespace = mdb.Namespace
+	assert.True(t, isMongoDBUserOf(user, mdb.NamespacedName()))
+
+	user.Spec.MongoDBResourceRef.Name = "other-rs"
+	assert.False(t, isMongoDBUserOf(user, mdb.NamespacedName()))
+
+	// only the users in the namespace of the resource can reference it
+	user = newMongoDBUser("my-user", mdb)
+	user.Namespace = "other-ns"
+	user.Spec.MongoDBResourceRef.Namespace = mdb.Namespace
+	assert.False(t, isMongoDBUserOf(user, mdb.NamespacedName()))
+}
+
+func TestConvertMongoDBUser(t *testing.T) {
+	mdb := newTestReplicaSet()
+	user := newMongoDBUser("my-user", mdb)
+
+	expected := mdbv1.MongoDBUser{
+		Name:                       "my-user",
+		DB:                         "admin",
+		Roles:                      []mdbv1.Role{{Name: "readWrite", DB: "my-db"}},
+		PasswordSecretRef:          v1.SecretKeyReference{Name: "my-user-password", Key: "password"},
+		ScramCredentialsSecretName: "my-user",
+		ConnectionStringSecretName: "my-rs-my-user-admin",
+	}
+	assert.Equal(t, expected, convertMongoDBUser(user))
+
+	user.Spec.Database = "$external"
+	user.Spec.PasswordSecretKeyRef = userv1.SecretKeyRef{}
+	expected = mdbv1.MongoDBUser{
+		Name:                       "my-user",
+		DB:                         "$external",
+		Roles:                      []mdbv1.Role{{Name: "readWrite", DB: "my-db"}},
+		ConnectionStringSecretName: "my-rs-my-user-external",
+	}
+	assert.Equal(t, expected, convertMongoDBUser(user))
+}
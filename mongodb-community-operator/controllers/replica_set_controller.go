@@ -11,7 +11,6 @@ import (
 	"github.com/imdario/mergo"
 	"github.com/stretchr/objx"
 	"go.uber.org/zap"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -29,7 +28,10 @@ import (
 	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/referencegrant"
+	rolev1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/role"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller" //nolint:depguard
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
@@ -46,8 +48,10 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/annotations"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/container"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/poddisruptionbudget"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/podtemplatespec"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/service"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/volumesnapshot"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/merge"
@@ -71,12 +75,14 @@ func NewReconciler(mgr manager.Manager, mongodbRepoUrl, mongodbImage, mongodbIma
 	mgrClient := mgr.GetClient()
 	secretWatcher := watch.New()
 	configMapWatcher := watch.New()
+	roleWatcher := watch.New()
 	return &ReplicaSetReconciler{
 		client:           kubernetesClient.NewClient(mgrClient),
 		scheme:           mgr.GetScheme(),
 		log:              zap.S(),
 		secretWatcher:    &secretWatcher,
 		configMapWatcher: &configMapWatcher,
+		roleWatcher:      &roleWatcher,
 
 		mongodbRepoUrl:          mongodbRepoUrl,
 		mongodbImage:            mongodbImage,
@@ -104,6 +110,8 @@ func (r *ReplicaSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&mdbv1.MongoDBCommunity{}, builder.WithPredicates(predicates.OnlyOnSpecChange())).
 		Watches(&corev1.Secret{}, r.secretWatcher).
 		Watches(&corev1.ConfigMap{}, r.configMapWatcher).
+		Watches(&rolev1.MongoDBRole{}, r.roleWatcher).
+		Watches(&userv1.MongoDBUser{}, handler.EnqueueRequestsFromMapFunc(findMdbcForUser)).
 		Watches(&searchv1.MongoDBSearch{}, handler.EnqueueRequestsFromMapFunc(findMdbcForSearch)).
 		Owns(&appsv1.StatefulSet{}).
 		Complete(r)
@@ -118,6 +126,7 @@ type ReplicaSetReconciler struct {
 	log              *zap.SugaredLogger
 	secretWatcher    *watch.ResourceWatcher
 	configMapWatcher *watch.ResourceWatcher
+	roleWatcher      *watch.ResourceWatcher
 
 	mongodbRepoUrl          string
 	mongodbImage            string
@@ -132,6 +141,8 @@ type ReplicaSetReconciler struct {
 // +kubebuilder:rbac:groups=mongodbcommunity.mongodb.com,resources=mongodbcommunity/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups=mongodb.com,resources=mongodbroles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=mongodb.com,resources=mongodbusers,verbs=get;list;watch
 
 // Reconcile reads that state of the cluster for a MongoDB object and makes changes based on the state read
 // and what is in the MongoDB.Spec
@@ -158,8 +169,16 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 	r.log = zap.S().With("ReplicaSet", request.NamespacedName)
 	r.log.Infof("Reconciling MongoDB")
 
+	// the status is always updated using the resource as it was read, mdbWithUsers is only used to configure the users
+	mdbWithUsers, err := r.withMongoDBUsers(ctx, mdb)
+	if err != nil {
+		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
+			withMessage(Error, fmt.Sprintf("Error reading MongoDBUser resources: %s", err)).
+			withFailedPhase())
+	}
+
 	r.log.Debug("Validating MongoDB.Spec")
-	lastAppliedSpec, err := r.validateSpec(mdb)
+	lastAppliedSpec, err := r.validateSpec(mdbWithUsers)
 	if err != nil {
 		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
 			withMessage(Error, fmt.Sprintf("error validating new Spec: %s", err)).
@@ -198,13 +217,13 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 			withFailedPhase())
 	}
 
-	if err := r.ensureUserResources(ctx, mdb); err != nil {
+	if err := r.ensureUserResources(ctx, mdbWithUsers); err != nil {
 		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
 			withMessage(Error, fmt.Sprintf("Error ensuring User config: %s", err)).
 			withFailedPhase())
 	}
 
-	ready, err := r.deployMongoDBReplicaSet(ctx, mdb, lastAppliedSpec)
+	ready, err := r.deployMongoDBReplicaSet(ctx, mdbWithUsers, lastAppliedSpec)
 	if err != nil {
 		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
 			withMessage(Error, fmt.Sprintf("Error deploying MongoDB ReplicaSet: %s", err)).
@@ -249,17 +268,17 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 		return res, err
 	}
 
-	if err := r.updateConnectionStringSecrets(ctx, mdb); err != nil { // nolint:forbidigo
+	if err := r.updateConnectionStringSecrets(ctx, mdbWithUsers); err != nil { // nolint:forbidigo
 		r.log.Errorf("Could not update connection string secrets: %s", err)
 	}
 
 	if lastAppliedSpec != nil {
-		r.cleanupScramSecrets(ctx, mdb.Spec, *lastAppliedSpec, mdb.Namespace)
+		r.cleanupScramSecrets(ctx, mdbWithUsers.Spec, *lastAppliedSpec, mdb.Namespace)
 		r.cleanupPemSecret(ctx, mdb.Spec, *lastAppliedSpec, mdb.Namespace)
-		r.cleanupConnectionStringSecrets(ctx, mdb.Spec, *lastAppliedSpec, mdb.Namespace, mdb.Name)
+		r.cleanupConnectionStringSecrets(ctx, mdbWithUsers.Spec, *lastAppliedSpec, mdb.Namespace, mdb.Name)
 	}
 
-	if err := r.updateLastSuccessfulConfiguration(ctx, mdb); err != nil {
+	if err := r.updateLastSuccessfulConfiguration(ctx, mdbWithUsers); err != nil {
 		r.log.Errorf("Could not save current spec as an annotation: %s", err)
 	}
 
@@ -340,13 +359,20 @@ func (r *ReplicaSetReconciler) ensurePrometheusTLSResources(ctx context.Context,
 // The returned boolean indicates that the StatefulSet is ready.
 func (r *ReplicaSetReconciler) deployStatefulSet(ctx context.Context, mdb mdbv1.MongoDBCommunity) (bool, error) {
 	r.log.Info("Creating/Updating StatefulSet")
-	if err := r.createOrUpdateStatefulSet(ctx, mdb, false); err != nil {
+	if deployed, err := r.createOrUpdateStatefulSet(ctx, mdb, false); err != nil {
 		return false, fmt.Errorf("error creating/updating StatefulSet: %s", err)
+	} else if !deployed {
+		if mdb.Spec.RestoreFromSnapshot != nil {
+			r.log.Infof("Waiting for the MongoDBVolumeSnapshot %s to complete before creating the StatefulSet", mdb.Spec.RestoreFromSnapshot.MongoDBVolumeSnapshotName)
+		}
+		return false, nil
 	}
 
 	r.log.Info("Creating/Updating StatefulSet for Arbiters")
-	if err := r.createOrUpdateStatefulSet(ctx, mdb, true); err != nil {
+	if deployed, err := r.createOrUpdateStatefulSet(ctx, mdb, true); err != nil {
 		return false, fmt.Errorf("error creating/updating StatefulSet: %s", err)
+	} else if !deployed {
+		return false, nil
 	}
 
 	currentSts, err := r.client.GetStatefulSet(ctx, mdb.NamespacedName())
@@ -354,6 +380,10 @@ func (r *ReplicaSetReconciler) deployStatefulSet(ctx context.Context, mdb mdbv1.
 		return false, fmt.Errorf("error getting StatefulSet: %s", err)
 	}
 
+	if err := r.ensurePodDisruptionBudget(ctx, mdb, currentSts); err != nil {
+		return false, fmt.Errorf("error creating/updating PodDisruptionBudget: %s", err)
+	}
+
 	r.log.Debugf("Ensuring StatefulSet is ready, with type: %s", mdb.GetUpdateStrategyType())
 
 	isReady := statefulset.IsReady(currentSts, mdb.StatefulSetReplicasThisReconciliation())
@@ -494,7 +524,9 @@ func (r *ReplicaSetReconciler) createProcessPortManager(ctx context.Context, mdb
 	return mcoagent.NewReplicaSetPortManager(r.log, mdb.Spec.AdditionalMongodConfig.GetDBPort(), currentPodStates, currentAC.Processes), nil
 }
 
-func (r *ReplicaSetReconciler) createOrUpdateStatefulSet(ctx context.Context, mdb mdbv1.MongoDBCommunity, isArbiter bool) error {
+// createOrUpdateStatefulSet returns false if the StatefulSet of the members can't be created until the snapshot it is
+// restored from is completed, or if the StatefulSet can't be updated until its PVCs are expanded.
+func (r *ReplicaSetReconciler) createOrUpdateStatefulSet(ctx context.Context, mdb mdbv1.MongoDBCommunity, isArbiter bool) (bool, error) {
 	set := appsv1.StatefulSet{}
 
 	name := mdb.NamespacedName()
@@ -505,19 +537,40 @@ func (r *ReplicaSetReconciler) createOrUpdateStatefulSet(ctx context.Context, md
 	err := r.client.Get(ctx, name, &set)
 	err = k8sClient.IgnoreNotFound(err)
 	if err != nil {
-		return fmt.Errorf("error getting StatefulSet: %s", err)
+		return false, fmt.Errorf("error getting StatefulSet: %s", err)
 	}
 
+	existing := *set.DeepCopy()
 	mongodbImage := getMongoDBImage(r.mongodbRepoUrl, r.mongodbImage, r.mongodbImageType, mdb.GetMongoDBVersion())
 	buildStatefulSetModificationFunction(mdb, mongodbImage, r.agentImage, r.versionUpgradeHookImage, r.readinessProbeImage)(&set)
 	if isArbiter {
 		buildArbitersModificationFunction(mdb)(&set)
+	} else if restored, err := volumesnapshot.ApplyRestore(ctx, r.client, mdb.Spec.RestoreFromSnapshot, &set); err != nil || !restored {
+		return false, err
+	}
+
+	if resized, err := r.resizeVolumes(ctx, existing, &set); err != nil || !resized {
+		return false, err
 	}
 
 	if _, err = statefulset.CreateOrUpdate(ctx, r.client, set); err != nil {
-		return fmt.Errorf("error creating/updating StatefulSet: %s", err)
+		return false, fmt.Errorf("error creating/updating StatefulSet: %s", err)
 	}
-	return nil
+	return true, nil
+}
+
+// ensurePodDisruptionBudget creates or updates the PodDisruptionBudget protecting the replica set. The members and
+// the arbiters share the Pod labels, so a single PodDisruptionBudget selecting the Pods of the members' StatefulSet
+// covers both of them.
+func (r *ReplicaSetReconciler) ensurePodDisruptionBudget(ctx context.Context, mdb mdbv1.MongoDBCommunity, sts appsv1.StatefulSet) error {
+	votingMembers := automationconfig.VotingMembers(mdb.Spec.Members, mdb.Spec.MemberConfig) + mdb.Spec.Arbiters
+	config := mdb.Spec.PodDisruptionBudget.WithDefault(v1.VotingMajorityPodDisruptionBudget(votingMembers))
+	if !config.IsEnabled() || mdb.StatefulSetReplicasThisReconciliation() == 0 {
+		return poddisruptionbudget.DeleteIfExists(ctx, r.client, mdb.NamespacedName())
+	}
+
+	pdb := poddisruptionbudget.BuildForStatefulSet(sts, config.MinAvailable, config.MaxUnavailable, nil, mdb.GetOwnerReferences())
+	return poddisruptionbudget.CreateOrUpdate(ctx, r.client, pdb)
 }
 
 // ensureAutomationConfig makes sure the AutomationConfig secret has been successfully created. The automation config
@@ -647,8 +700,14 @@ func (r ReplicaSetReconciler) validateSpec(mdb mdbv1.MongoDBCommunity) (*mdbv1.M
 	return &lastSpec, validation.ValidateUpdate(mdb, lastSpec, r.log)
 }
 
-func getCustomRolesModification(mdb mdbv1.MongoDBCommunity) (automationconfig.Modification, error) {
+func (r ReplicaSetReconciler) getCustomRolesModification(ctx context.Context, mdb mdbv1.MongoDBCommunity) (automationconfig.Modification, error) {
 	roles := mdb.Spec.Security.Roles
+	if len(mdb.Spec.Security.RoleRefs) > 0 {
+		var err error
+		if roles, err = r.getReferencedRoles(ctx, mdb); err != nil {
+			return nil, err
+		}
+	}
 	if roles == nil {
 		return automationconfig.NOOP(), nil
 	}
@@ -664,7 +723,7 @@ func (r ReplicaSetReconciler) buildAutomationConfig(ctx context.Context, mdb mdb
 		return automationconfig.AutomationConfig{}, fmt.Errorf("could not configure TLS modification: %s", err)
 	}
 
-	customRolesModification, err := getCustomRolesModification(mdb)
+	customRolesModification, err := r.getCustomRolesModification(ctx, mdb)
 	if err != nil {
 		return automationconfig.AutomationConfig{}, fmt.Errorf("could not configure custom roles: %s", err)
 	}
@@ -705,19 +764,17 @@ func (r ReplicaSetReconciler) buildAutomationConfig(ctx context.Context, mdb mdb
 	}
 
 	var search *searchv1.MongoDBSearch
-	searchList := &searchv1.MongoDBSearchList{}
-	if err := r.client.List(ctx, searchList, &k8sClient.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(searchv1.MongoDBSearchIndexFieldName, mdb.Namespace+"/"+mdb.Name),
-	}); err != nil {
+	searches, err := searchcontroller.ListSearchResourcesReferencing(ctx, r.client, referencegrant.Reference{Kind: referencegrant.KindMongoDBCommunity, Namespace: mdb.Namespace, Name: mdb.Name})
+	if err != nil {
 		r.log.Debug(err)
 	}
 	// this validates that there is exactly one MongoDBSearch pointing to this resource,
 	// and that this resource passes search validations. If either fails, proceed without a search target
 	// for the mongod automation config.
-	if len(searchList.Items) == 1 {
+	if len(searches) == 1 {
 		searchSource := searchcontroller.NewCommunityResourceSearchSource(&mdb)
 		if searchSource.Validate() == nil {
-			search = &searchList.Items[0]
+			search = &searches[0]
 		}
 	}
 
@@ -798,12 +855,14 @@ func buildStatefulSetModificationFunction(mdb mdbv1.MongoDBCommunity, mongodbIma
 	commonModification := construct.BuildMongoDBReplicaSetStatefulSetModificationFunction(&mdb, &mdb, mongodbImage, agentImage, versionUpgradeHookImage, readinessProbeImage)
 	return statefulset.Apply(
 		commonModification,
+		buildPersistenceModificationFunction(mdb),
 		statefulset.WithOwnerReference(mdb.GetOwnerReferences()),
 		statefulset.WithPodSpecTemplate(
 			podtemplatespec.Apply(
 				buildTLSPodSpecModification(mdb),
 				buildTLSPrometheus(mdb),
 				buildAgentX509(mdb),
+				podtemplatespec.WithTopologySpreadConstraints(mdb.Spec.TopologySpread.Constraints(map[string]string{"app": mdb.ServiceName()})),
 			),
 		),
 
@@ -14,12 +14,15 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/yaml"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -202,6 +205,38 @@ func TestStatefulSet_IsCorrectlyConfigured(t *testing.T) {
 	assert.Nil(t, acVolume.ConfigMap, "automation config should be stored in a secret, not a config map!")
 }
 
+func TestPodDisruptionBudget_IsCreatedForMembersAndArbiters(t *testing.T) {
+	ctx := context.Background()
+
+	mdb := newTestReplicaSet()
+	mdb.Spec.Members = 4
+	mdb.Spec.Arbiters = 1
+	mgr := client.NewManager(ctx, &mdb)
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage")
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	sts := appsv1.StatefulSet{}
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &sts))
+
+	// 4 members and an arbiter make 5 voting members, the replica set can lose 2 of them
+	pdb := policyv1.PodDisruptionBudget{}
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &pdb))
+	assert.Equal(t, sts.Spec.Selector, pdb.Spec.Selector)
+	assert.Equal(t, ptr.To(intstr.FromInt32(2)), pdb.Spec.MaxUnavailable)
+	assert.Len(t, pdb.OwnerReferences, 1)
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	mdb.Spec.PodDisruptionBudget = &v1.PodDisruptionBudgetConfiguration{Enabled: ptr.To(false)}
+	require.NoError(t, mgr.GetClient().Update(ctx, &mdb))
+
+	res, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	err = mgr.GetClient().Get(ctx, mdb.NamespacedName(), &policyv1.PodDisruptionBudget{})
+	assert.True(t, apiErrors.IsNotFound(err))
+}
+
 func TestGuessEnterprise(t *testing.T) {
 	type testConfig struct {
 		setArgs            func(t *testing.T)
@@ -3,10 +3,15 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strings"
 
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
 
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
 	"github.com/mongodb/mongodb-kubernetes/pkg/authentication/authtypes"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/constants"
@@ -22,6 +27,9 @@ func ValidateUpdate(mdb mdbv1.MongoDBCommunity, oldSpec mdbv1.MongoDBCommunitySp
 	if oldSpec.Security.TLS.Enabled && !mdb.Spec.Security.TLS.Enabled {
 		return errors.New("TLS can't be set to disabled after it has been enabled")
 	}
+	if err := validatePersistenceUpdate(mdb, oldSpec); err != nil {
+		return err
+	}
 	return validateSpec(mdb, log)
 }
 
@@ -47,6 +55,22 @@ func validateSpec(mdb mdbv1.MongoDBCommunity, log *zap.SugaredLogger) error {
 		return err
 	}
 
+	if err := validatePodDisruptionBudget(mdb); err != nil {
+		return err
+	}
+
+	if err := validateTopologySpread(mdb); err != nil {
+		return err
+	}
+
+	if err := validateRoles(mdb); err != nil {
+		return err
+	}
+
+	if err := validatePersistence(mdb); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -207,3 +231,89 @@ func validateStatefulSet(mdb mdbv1.MongoDBCommunity) error {
 
 	return nil
 }
+
+// validatePodDisruptionBudget checks that at most one of minAvailable and maxUnavailable is specified
+func validatePodDisruptionBudget(mdb mdbv1.MongoDBCommunity) error {
+	pdb := mdb.Spec.PodDisruptionBudget
+	if pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return fmt.Errorf("only one of 'minAvailable' and 'maxUnavailable' can be specified in spec.podDisruptionBudget")
+	}
+
+	return nil
+}
+
+// validateTopologySpread checks that the zone constraint can't put a majority of the members in a single zone
+func validateTopologySpread(mdb mdbv1.MongoDBCommunity) error {
+	if err := mdb.Spec.TopologySpread.Validate("spec.topologySpread"); err != nil {
+		return err
+	}
+	return mdb.Spec.TopologySpread.ValidateMembers("spec.topologySpread", mdb.Spec.Members)
+}
+
+// validateRoles checks that the custom roles are either specified inline or referenced, but not both.
+func validateRoles(mdb mdbv1.MongoDBCommunity) error {
+	if len(mdb.Spec.Security.Roles) > 0 && len(mdb.Spec.Security.RoleRefs) > 0 {
+		return fmt.Errorf("at most one of 'spec.security.roles' and 'spec.security.roleRefs' can be specified")
+	}
+
+	return nil
+}
+
+// validatePersistence checks that the storage of the volumes can be parsed. The volumes are not autoscaled.
+func validatePersistence(mdb mdbv1.MongoDBCommunity) error {
+	if mdb.Spec.Persistence == nil {
+		return nil
+	}
+	if mdb.Spec.Persistence.Autoscale != nil {
+		return errors.New("spec.persistence.autoscale is not supported for MongoDBCommunity")
+	}
+	for name, config := range persistenceConfigs(mdb) {
+		if config == nil || config.Storage == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(config.Storage); err != nil {
+			return fmt.Errorf("the storage of volume %s is not a valid quantity: %s", name, config.Storage)
+		}
+	}
+	return nil
+}
+
+// validatePersistenceUpdate checks that no volume is added or removed, and that the storage class of the volumes is
+// unchanged, as the volume claim templates of a StatefulSet can't be updated.
+func validatePersistenceUpdate(mdb mdbv1.MongoDBCommunity, oldSpec mdbv1.MongoDBCommunitySpec) error {
+	oldConfigs := persistenceConfigs(mdbv1.MongoDBCommunity{Spec: oldSpec})
+	newConfigs := persistenceConfigs(mdb)
+	if !slices.Equal(slices.Sorted(maps.Keys(oldConfigs)), slices.Sorted(maps.Keys(newConfigs))) {
+		return fmt.Errorf("the volumes of spec.persistence can't be changed from %v to %v, only their storage can be increased", slices.Sorted(maps.Keys(oldConfigs)), slices.Sorted(maps.Keys(newConfigs)))
+	}
+	for name, config := range newConfigs {
+		if !reflect.DeepEqual(storageClass(oldConfigs[name]), storageClass(config)) {
+			return fmt.Errorf("the storage class of volume %s can't be changed", name)
+		}
+	}
+	return nil
+}
+
+// persistenceConfigs returns the configuration of each volume of the StatefulSet, by name.
+func persistenceConfigs(mdb mdbv1.MongoDBCommunity) map[string]*v1.PersistenceConfig {
+	persistence := mdb.Spec.Persistence
+	if !mdb.HasSeparateDataAndLogsVolumes() {
+		return map[string]*v1.PersistenceConfig{mdb.DataVolumeName(): persistence.SingleConfig}
+	}
+	multiple := &v1.MultiplePersistenceConfig{}
+	if persistence != nil && persistence.MultipleConfig != nil {
+		multiple = persistence.MultipleConfig
+	}
+	configs := map[string]*v1.PersistenceConfig{mdb.DataVolumeName(): multiple.Data, mdb.LogsVolumeName(): multiple.Logs}
+	if mdb.HasSeparateJournalVolume() {
+		configs[mdb.JournalVolumeName()] = multiple.Journal
+	}
+	return configs
+}
+
+func storageClass(config *v1.PersistenceConfig) *string {
+	if config == nil {
+		return nil
+	}
+	return config.StorageClass
+}
@@ -45,6 +45,18 @@ func (o *MemberOptions) GetTags() map[string]string {
 	return o.Tags
 }
 
+// VotingMembers returns the number of voting members among the first 'members' members of a replica set.
+// Members without options vote by default.
+func VotingMembers(members int, memberOptions []MemberOptions) int {
+	voting := 0
+	for i := 0; i < members; i++ {
+		if i >= len(memberOptions) || memberOptions[i].GetVotes() > 0 {
+			voting++
+		}
+	}
+	return voting
+}
+
 type AutomationConfig struct {
 	Version     int          `json:"version"`
 	Processes   []Process    `json:"processes"`
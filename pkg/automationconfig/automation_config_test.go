@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 )
 
 func defaultMongoDbVersion(version string) MongoDbVersionConfig {
@@ -540,3 +541,14 @@ func createAutomationConfig(name, mongodbVersion, domain string, opts Options, a
 	ac.Version = acVersion
 	return ac
 }
+
+func TestVotingMembers(t *testing.T) {
+	nonVoting := MemberOptions{Votes: ptr.To(0)}
+
+	assert.Equal(t, 3, VotingMembers(3, nil))
+	assert.Equal(t, 2, VotingMembers(3, []MemberOptions{{}, nonVoting, {}}))
+	// members without options vote by default
+	assert.Equal(t, 4, VotingMembers(5, []MemberOptions{nonVoting}))
+	// options of the members scaled away are ignored
+	assert.Equal(t, 1, VotingMembers(1, []MemberOptions{{}, {}, {}}))
+}
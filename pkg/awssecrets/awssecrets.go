@@ -0,0 +1,212 @@
+package awssecrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/kubernetes"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+const (
+	SecretsManagerBackend = "AWS_SECRETS_MANAGER_BACKEND"
+
+	DEFAULT_SECRET_NAME_PREFIX = "mongodbenterprise"
+
+	AWS_REGION             = "AWS_REGION"
+	AWS_ENDPOINT_URL       = "AWS_ENDPOINT_URL"
+	AWS_SECRET_NAME_PREFIX = "AWS_SECRET_NAME_PREFIX" //nolint
+	AWS_KMS_KEY_ID         = "AWS_KMS_KEY_ID"
+
+	// managedByTag is added to the secrets created by the operator, so that IAM policies can restrict the operator
+	// to the secrets it manages.
+	managedByTag = "mongodb.com/managed-by"
+)
+
+// The secrets of the operator, Ops Manager, the AppDB and the databases are stored under different paths, as in Vault,
+// so that the secrets with the same name in a namespace don't collide.
+const (
+	OperatorSecretPath   = "operator"
+	OpsManagerSecretPath = "opsmanager"
+	DatabaseSecretPath   = "database"
+	AppDBSecretPath      = "appdb"
+)
+
+// IsSecretsManagerBackend returns true if the secrets are stored in AWS Secrets Manager.
+func IsSecretsManagerBackend() bool {
+	return os.Getenv("SECRET_BACKEND") == SecretsManagerBackend // nolint:forbidigo
+}
+
+// Configuration is read from the same "secret-configuration" ConfigMap as the Vault configuration.
+type Configuration struct {
+	Region string
+	// Endpoint overrides the Secrets Manager endpoint, e.g. to use LocalStack or a VPC endpoint
+	Endpoint string
+	// SecretNamePrefix is prepended to the names of the secrets, which are stored as <prefix>/<basePath>/<namespace>/<name>
+	SecretNamePrefix string
+	// KMSKeyID is the KMS key the secrets created by the operator are encrypted with, the AWS managed key is used if empty
+	KMSKeyID string
+}
+
+func (c Configuration) GetSecretNamePrefix() string {
+	if c.SecretNamePrefix == "" {
+		return DEFAULT_SECRET_NAME_PREFIX
+	}
+	return c.SecretNamePrefix
+}
+
+// SecretName returns the name of the Secrets Manager secret storing the secret of the namespace, basePath is one of
+// the paths of the kinds of secrets.
+func (c Configuration) SecretName(basePath, namespace, name string) string {
+	prefix := strings.TrimSuffix(c.GetSecretNamePrefix(), "/")
+	if basePath == "" {
+		return fmt.Sprintf("%s/%s/%s", prefix, namespace, name)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", prefix, basePath, namespace, name)
+}
+
+// secretsManagerAPI is the subset of the Secrets Manager client used by the operator.
+type secretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+	PutSecretValue(ctx context.Context, params *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error)
+	CreateSecret(ctx context.Context, params *secretsmanager.CreateSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.CreateSecretOutput, error)
+	DeleteSecret(ctx context.Context, params *secretsmanager.DeleteSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DeleteSecretOutput, error)
+}
+
+type Client struct {
+	client secretsManagerAPI
+	Config Configuration
+}
+
+func readConfig(ctx context.Context, client kubernetes.Interface) (Configuration, error) {
+	cm, err := client.CoreV1().ConfigMaps(env.ReadOrPanic(util.CurrentNamespace)).Get(ctx, "secret-configuration", v1.GetOptions{}) // nolint:forbidigo
+	if err != nil {
+		return Configuration{}, xerrors.Errorf("error reading the AWS Secrets Manager configmap: %w", err)
+	}
+	return Configuration{
+		Region:           cm.Data[AWS_REGION],
+		Endpoint:         cm.Data[AWS_ENDPOINT_URL],
+		SecretNamePrefix: cm.Data[AWS_SECRET_NAME_PREFIX],
+		KMSKeyID:         cm.Data[AWS_KMS_KEY_ID],
+	}, nil
+}
+
+// InitClient creates the Secrets Manager client. The credentials are resolved by the default chain of the AWS SDK,
+// which includes the web identity token of IAM roles for service accounts and EKS Pod Identity.
+func InitClient(ctx context.Context, client kubernetes.Interface) (*Client, error) {
+	config, err := readConfig(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if config.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(config.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, xerrors.Errorf("can't load the AWS configuration: %w", err)
+	}
+	if awsConfig.Region == "" {
+		return nil, xerrors.Errorf("the AWS region must be configured with %s", AWS_REGION)
+	}
+	config.Region = awsConfig.Region
+
+	return NewClient(awsConfig, config), nil
+}
+
+// NewClient creates a Secrets Manager client from an existing AWS configuration.
+func NewClient(awsConfig aws.Config, config Configuration) *Client {
+	client := secretsmanager.NewFromConfig(awsConfig, func(o *secretsmanager.Options) {
+		if config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(config.Endpoint)
+		}
+	})
+	return &Client{client: client, Config: config}
+}
+
+// SecretName returns the name of the Secrets Manager secret storing the Kubernetes-like secret.
+func (c *Client) SecretName(basePath, namespace, name string) string {
+	return c.Config.SecretName(basePath, namespace, name)
+}
+
+// ReadSecret returns the keys of the secret, which is stored as a JSON object. A Kubernetes NotFound error is returned
+// if the secret doesn't exist, so that the callers handle the missing secrets the same way for all the backends.
+func (c *Client) ReadSecret(ctx context.Context, secretName string) (map[string]string, error) {
+	out, err := c.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretName)})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, apiErrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, secretName)
+		}
+		return nil, xerrors.Errorf("can't read secret %s from AWS Secrets Manager: %w", secretName, err)
+	}
+
+	data := map[string]string{}
+	if err := json.Unmarshal([]byte(aws.ToString(out.SecretString)), &data); err != nil {
+		return nil, xerrors.Errorf("secret %s in AWS Secrets Manager is not a JSON object of strings: %w", secretName, err)
+	}
+	return data, nil
+}
+
+// PutSecret stores a new version of the secret, the secret is created if it doesn't exist yet.
+func (c *Client) PutSecret(ctx context.Context, secretName string, data map[string]string) error {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretName),
+		SecretString: aws.String(string(value)),
+	})
+	if err == nil {
+		return nil
+	}
+	var notFound *types.ResourceNotFoundException
+	if !errors.As(err, &notFound) {
+		return xerrors.Errorf("can't write secret %s to AWS Secrets Manager: %w", secretName, err)
+	}
+
+	input := &secretsmanager.CreateSecretInput{
+		Name:         aws.String(secretName),
+		SecretString: aws.String(string(value)),
+		Tags:         []types.Tag{{Key: aws.String(managedByTag), Value: aws.String(util.OperatorLabelValue)}},
+	}
+	if c.Config.KMSKeyID != "" {
+		input.KmsKeyId = aws.String(c.Config.KMSKeyID)
+	}
+	if _, err := c.client.CreateSecret(ctx, input); err != nil {
+		return xerrors.Errorf("can't create secret %s in AWS Secrets Manager: %w", secretName, err)
+	}
+	return nil
+}
+
+// DeleteSecret deletes the secret without a recovery window, so that a secret with the same name can be created
+// again right away. Deleting a secret that doesn't exist is not an error.
+func (c *Client) DeleteSecret(ctx context.Context, secretName string) error {
+	_, err := c.client.DeleteSecret(ctx, &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(secretName),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	var notFound *types.ResourceNotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		return xerrors.Errorf("can't delete secret %s from AWS Secrets Manager: %w", secretName, err)
+	}
+	return nil
+}
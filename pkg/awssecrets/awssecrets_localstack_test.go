@@ -0,0 +1,76 @@
+package awssecrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+)
+
+// localStackEndpoint is the endpoint of a running LocalStack, e.g. http://localhost:4566, the tests against it are
+// skipped if it's not set. scripts/dev/run_localstack_tests.sh starts LocalStack and runs them.
+const localStackEndpoint = "LOCALSTACK_ENDPOINT"
+
+func newLocalStackClient(t *testing.T) *Client {
+	endpoint := os.Getenv(localStackEndpoint) // nolint:forbidigo
+	if endpoint == "" {
+		t.Skipf("Skipping as %s is not set", localStackEndpoint)
+	}
+
+	awsConfig := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("test", "test", ""),
+	}
+	// each run uses its own prefix, as LocalStack may keep the secrets of the previous runs
+	prefix := fmt.Sprintf("mongodbenterprise-test-%d", time.Now().UnixNano())
+	return NewClient(awsConfig, Configuration{Region: "us-east-1", Endpoint: endpoint, SecretNamePrefix: prefix})
+}
+
+func TestLocalStack_PutReadDeleteSecret(t *testing.T) {
+	ctx := context.Background()
+	client := newLocalStackClient(t)
+	secretName := client.SecretName(DatabaseSecretPath, "my-namespace", "my-rs-agent-api-key")
+	t.Cleanup(func() { _ = client.DeleteSecret(ctx, secretName) })
+
+	_, err := client.ReadSecret(ctx, secretName)
+	assert.True(t, secret.SecretNotExist(err))
+
+	require.NoError(t, client.PutSecret(ctx, secretName, map[string]string{"agentApiKey": "key"}))
+	require.NoError(t, client.PutSecret(ctx, secretName, map[string]string{"agentApiKey": "new-key"}))
+
+	data, err := client.ReadSecret(ctx, secretName)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"agentApiKey": "new-key"}, data)
+
+	require.NoError(t, client.DeleteSecret(ctx, secretName))
+	_, err = client.ReadSecret(ctx, secretName)
+	assert.True(t, secret.SecretNotExist(err))
+	// deleting a secret which doesn't exist is not an error
+	require.NoError(t, client.DeleteSecret(ctx, secretName))
+}
+
+func TestLocalStack_SecretsOfDifferentKindsDontCollide(t *testing.T) {
+	ctx := context.Background()
+	client := newLocalStackClient(t)
+
+	basePaths := []string{OperatorSecretPath, OpsManagerSecretPath, DatabaseSecretPath, AppDBSecretPath}
+	for _, basePath := range basePaths {
+		secretName := client.SecretName(basePath, "my-namespace", "my-secret")
+		t.Cleanup(func() { _ = client.DeleteSecret(ctx, secretName) })
+		require.NoError(t, client.PutSecret(ctx, secretName, map[string]string{"kind": basePath}))
+	}
+
+	for _, basePath := range basePaths {
+		data, err := client.ReadSecret(ctx, client.SecretName(basePath, "my-namespace", "my-secret"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"kind": basePath}, data)
+	}
+}
@@ -0,0 +1,134 @@
+package awssecrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
+)
+
+// fakeSecretsManager implements the JSON protocol of the Secrets Manager operations used by the operator, the same
+// way as LocalStack.
+type fakeSecretsManager struct {
+	secrets    map[string]string
+	kmsKeyIDs  map[string]string
+	operations []string
+}
+
+func (f *fakeSecretsManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	operation := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "secretsmanager.")
+	f.operations = append(f.operations, operation)
+
+	var input struct {
+		SecretId     string
+		Name         string
+		SecretString string
+		KmsKeyId     string
+	}
+	_ = json.NewDecoder(r.Body).Decode(&input)
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	notFound := func() {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+	}
+	switch operation {
+	case "GetSecretValue":
+		value, ok := f.secrets[input.SecretId]
+		if !ok {
+			notFound()
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"Name": input.SecretId, "SecretString": value})
+	case "PutSecretValue":
+		if _, ok := f.secrets[input.SecretId]; !ok {
+			notFound()
+			return
+		}
+		f.secrets[input.SecretId] = input.SecretString
+		_ = json.NewEncoder(w).Encode(map[string]string{"Name": input.SecretId})
+	case "CreateSecret":
+		f.secrets[input.Name] = input.SecretString
+		f.kmsKeyIDs[input.Name] = input.KmsKeyId
+		_ = json.NewEncoder(w).Encode(map[string]string{"Name": input.Name})
+	case "DeleteSecret":
+		if _, ok := f.secrets[input.SecretId]; !ok {
+			notFound()
+			return
+		}
+		delete(f.secrets, input.SecretId)
+		_ = json.NewEncoder(w).Encode(map[string]string{"Name": input.SecretId})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func newTestClient(t *testing.T, config Configuration) (*Client, *fakeSecretsManager) {
+	fake := &fakeSecretsManager{secrets: map[string]string{}, kmsKeyIDs: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	config.Endpoint = server.URL
+	awsConfig := aws.Config{
+		Region:           "us-east-1",
+		Credentials:      credentials.NewStaticCredentialsProvider("test", "test", ""),
+		RetryMaxAttempts: 1,
+	}
+	return NewClient(awsConfig, config), fake
+}
+
+func TestClient_PutSecretCreatesThenUpdates(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newTestClient(t, Configuration{KMSKeyID: "alias/mongodb"})
+	secretName := client.SecretName(DatabaseSecretPath, "my-namespace", "my-rs-agent-api-key")
+	assert.Equal(t, "mongodbenterprise/database/my-namespace/my-rs-agent-api-key", secretName)
+
+	require.NoError(t, client.PutSecret(ctx, secretName, map[string]string{"agentApiKey": "key"}))
+	assert.Equal(t, []string{"PutSecretValue", "CreateSecret"}, fake.operations)
+	assert.Equal(t, "alias/mongodb", fake.kmsKeyIDs[secretName])
+
+	require.NoError(t, client.PutSecret(ctx, secretName, map[string]string{"agentApiKey": "new-key"}))
+	assert.Equal(t, []string{"PutSecretValue", "CreateSecret", "PutSecretValue"}, fake.operations)
+
+	data, err := client.ReadSecret(ctx, secretName)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"agentApiKey": "new-key"}, data)
+}
+
+func TestClient_ReadSecretNotFound(t *testing.T) {
+	client, _ := newTestClient(t, Configuration{})
+
+	_, err := client.ReadSecret(context.Background(), client.SecretName(DatabaseSecretPath, "my-namespace", "missing"))
+	assert.True(t, secret.SecretNotExist(err))
+}
+
+func TestClient_ReadSecretNotJSON(t *testing.T) {
+	client, fake := newTestClient(t, Configuration{SecretNamePrefix: "prod/"})
+	secretName := client.SecretName(OperatorSecretPath, "my-namespace", "plain")
+	assert.Equal(t, "prod/operator/my-namespace/plain", secretName)
+	fake.secrets[secretName] = "not json"
+
+	_, err := client.ReadSecret(context.Background(), secretName)
+	assert.ErrorContains(t, err, "is not a JSON object of strings")
+}
+
+func TestClient_DeleteSecret(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newTestClient(t, Configuration{})
+	secretName := client.SecretName(AppDBSecretPath, "my-namespace", "my-secret")
+	fake.secrets[secretName] = "{}"
+
+	require.NoError(t, client.DeleteSecret(ctx, secretName))
+	assert.Empty(t, fake.secrets)
+	// deleting a secret which doesn't exist is not an error
+	require.NoError(t, client.DeleteSecret(ctx, secretName))
+}
@@ -0,0 +1,156 @@
+package awssecrets
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SecretProviderClassGVK is the kind of the classes configuring the Secrets Store CSI driver. The driver is an
+// optional dependency, so the classes are managed as unstructured objects.
+var SecretProviderClassGVK = schema.GroupVersionKind{Group: "secrets-store.csi.x-k8s.io", Version: "v1", Kind: "SecretProviderClass"}
+
+const (
+	SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+
+	// secretsStoreProvider is the provider of the CSI driver reading from AWS Secrets Manager
+	secretsStoreProvider = "aws"
+)
+
+var invalidAliasCharacters = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// SecretFile is the key of a secret the CSI driver writes to MountPath/FileName in the pods, the equivalent of the
+// files injected by the Vault Agent.
+type SecretFile struct {
+	SecretName string
+	Key        string
+	MountPath  string
+	FileName   string
+}
+
+// alias is the name of the file the provider writes the key to in the volume, which is then mounted with a subPath.
+func (f SecretFile) alias() string {
+	return invalidAliasCharacters.ReplaceAllString(fmt.Sprintf("%s-%s", f.SecretName, f.Key), "_")
+}
+
+// secretObject is an entry of the "objects" parameter of the AWS provider.
+type secretObject struct {
+	ObjectName string          `json:"objectName"`
+	ObjectType string          `json:"objectType"`
+	JMESPath   []secretKeyPath `json:"jmesPath"`
+}
+
+type secretKeyPath struct {
+	Path        string `json:"path"`
+	ObjectAlias string `json:"objectAlias"`
+}
+
+// SecretProviderClass returns the class making the CSI driver read the files from Secrets Manager, the secrets are
+// read from the namespace under basePath.
+func (c Configuration) SecretProviderClass(name, namespace, basePath string, files []SecretFile, ownerReferences []metav1.OwnerReference) (*unstructured.Unstructured, error) {
+	keysBySecret := map[string][]secretKeyPath{}
+	for _, f := range files {
+		// the keys are quoted, as the hashes of the certificates are not valid JMESPath identifiers
+		keysBySecret[f.SecretName] = append(keysBySecret[f.SecretName], secretKeyPath{Path: fmt.Sprintf("%q", f.Key), ObjectAlias: f.alias()})
+	}
+	secretNames := make([]string, 0, len(keysBySecret))
+	for secretName := range keysBySecret {
+		secretNames = append(secretNames, secretName)
+	}
+	sort.Strings(secretNames)
+
+	objects := make([]secretObject, 0, len(secretNames))
+	for _, secretName := range secretNames {
+		objects = append(objects, secretObject{
+			ObjectName: c.SecretName(basePath, namespace, secretName),
+			ObjectType: "secretsmanager",
+			JMESPath:   keysBySecret[secretName],
+		})
+	}
+	objectsYaml, err := yaml.Marshal(objects)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal the secrets of the SecretProviderClass %s: %w", name, err)
+	}
+
+	parameters := map[string]interface{}{
+		"objects": string(objectsYaml),
+	}
+	if c.Region != "" {
+		parameters["region"] = c.Region
+	}
+
+	class := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"provider":   secretsStoreProvider,
+			"parameters": parameters,
+		},
+	}}
+	class.SetGroupVersionKind(SecretProviderClassGVK)
+	class.SetName(name)
+	class.SetNamespace(namespace)
+	class.SetOwnerReferences(ownerReferences)
+	return class, nil
+}
+
+// CSIVolumeAndMounts returns the volume of the CSI driver using the SecretProviderClass, and the mounts of each file
+// at its expected path.
+func CSIVolumeAndMounts(volumeName, secretProviderClassName string, files []SecretFile) (corev1.Volume, []corev1.VolumeMount) {
+	readOnly := true
+	volume := corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           SecretsStoreCSIDriver,
+				ReadOnly:         &readOnly,
+				VolumeAttributes: map[string]string{"secretProviderClass": secretProviderClassName},
+			},
+		},
+	}
+
+	mounts := make([]corev1.VolumeMount, 0, len(files))
+	for _, f := range files {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: path.Join(f.MountPath, f.FileName),
+			SubPath:   f.alias(),
+			ReadOnly:  true,
+		})
+	}
+	return volume, mounts
+}
+
+// CreateOrUpdateSecretProviderClass creates the SecretProviderClass, or updates its spec if it already exists.
+func CreateOrUpdateSecretProviderClass(ctx context.Context, c client.Client, class *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(SecretProviderClassGVK)
+	err := c.Get(ctx, types.NamespacedName{Namespace: class.GetNamespace(), Name: class.GetName()}, existing)
+	if apiErrors.IsNotFound(err) {
+		if err := c.Create(ctx, class); err != nil {
+			return xerrors.Errorf("failed to create SecretProviderClass %s/%s: %w", class.GetNamespace(), class.GetName(), err)
+		}
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("failed to get SecretProviderClass %s/%s, check that the Secrets Store CSI driver is installed: %w", class.GetNamespace(), class.GetName(), err)
+	}
+
+	existing.Object["spec"] = class.Object["spec"]
+	existing.SetOwnerReferences(class.GetOwnerReferences())
+	if err := c.Update(ctx, existing); err != nil {
+		return xerrors.Errorf("failed to update SecretProviderClass %s/%s: %w", class.GetNamespace(), class.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package awssecrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var testFiles = []SecretFile{
+	{SecretName: "my-project-group-secret", Key: "agentApiKey", MountPath: "/mongodb-automation/agent-api-key", FileName: "agentApiKey"},
+	{SecretName: "my-rs-cert-pem", Key: "0123abcd", MountPath: "/mongodb-automation/tls", FileName: "0123abcd"},
+}
+
+func TestSecretProviderClass(t *testing.T) {
+	config := Configuration{Region: "eu-west-1"}
+	class, err := config.SecretProviderClass("my-rs-secrets", "my-namespace", DatabaseSecretPath, testFiles, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, SecretProviderClassGVK, class.GroupVersionKind())
+	provider, _, _ := unstructured.NestedString(class.Object, "spec", "provider")
+	assert.Equal(t, "aws", provider)
+	region, _, _ := unstructured.NestedString(class.Object, "spec", "parameters", "region")
+	assert.Equal(t, "eu-west-1", region)
+
+	objectsYaml, _, _ := unstructured.NestedString(class.Object, "spec", "parameters", "objects")
+	var objects []secretObject
+	require.NoError(t, yaml.Unmarshal([]byte(objectsYaml), &objects))
+	assert.Equal(t, []secretObject{
+		{
+			ObjectName: "mongodbenterprise/database/my-namespace/my-project-group-secret",
+			ObjectType: "secretsmanager",
+			JMESPath:   []secretKeyPath{{Path: `"agentApiKey"`, ObjectAlias: "my-project-group-secret-agentApiKey"}},
+		},
+		{
+			ObjectName: "mongodbenterprise/database/my-namespace/my-rs-cert-pem",
+			ObjectType: "secretsmanager",
+			JMESPath:   []secretKeyPath{{Path: `"0123abcd"`, ObjectAlias: "my-rs-cert-pem-0123abcd"}},
+		},
+	}, objects)
+}
+
+func TestCSIVolumeAndMounts(t *testing.T) {
+	volume, mounts := CSIVolumeAndMounts("secrets-store", "my-rs-secrets", testFiles)
+
+	require.NotNil(t, volume.CSI)
+	assert.Equal(t, SecretsStoreCSIDriver, volume.CSI.Driver)
+	assert.Equal(t, map[string]string{"secretProviderClass": "my-rs-secrets"}, volume.CSI.VolumeAttributes)
+
+	require.Len(t, mounts, 2)
+	assert.Equal(t, "/mongodb-automation/agent-api-key/agentApiKey", mounts[0].MountPath)
+	assert.Equal(t, "my-project-group-secret-agentApiKey", mounts[0].SubPath)
+	assert.Equal(t, "/mongodb-automation/tls/0123abcd", mounts[1].MountPath)
+	assert.Equal(t, "my-rs-cert-pem-0123abcd", mounts[1].SubPath)
+}
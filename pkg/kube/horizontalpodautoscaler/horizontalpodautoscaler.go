@@ -0,0 +1,61 @@
+package horizontalpodautoscaler
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BuildForStatefulSet returns the HorizontalPodAutoscaler scaling the given StatefulSet.
+// The HorizontalPodAutoscaler has the same name as the StatefulSet.
+func BuildForStatefulSet(sts appsv1.StatefulSet, minReplicas *int32, maxReplicas int32, metrics []autoscalingv2.MetricSpec, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior, labels map[string]string, ownerReferences []metav1.OwnerReference) autoscalingv2.HorizontalPodAutoscaler {
+	return autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            sts.Name,
+			Namespace:       sts.Namespace,
+			Labels:          labels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "StatefulSet",
+				Name:       sts.Name,
+			},
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
+			Metrics:     metrics,
+			Behavior:    behavior,
+		},
+	}
+}
+
+// CreateOrUpdate creates the HorizontalPodAutoscaler or updates the spec, labels and owner references of the existing one.
+func CreateOrUpdate(ctx context.Context, c client.Client, desired autoscalingv2.HorizontalPodAutoscaler) error {
+	existing := autoscalingv2.HorizontalPodAutoscaler{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(&desired), &existing); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return c.Create(ctx, &desired)
+		}
+		return err
+	}
+
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec = desired.Spec
+	return c.Update(ctx, &existing)
+}
+
+// DeleteIfExists removes the HorizontalPodAutoscaler, it is not an error if it doesn't exist.
+func DeleteIfExists(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	hpa := autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	if err := c.Delete(ctx, &hpa); err != nil && !apiErrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package poddisruptionbudget
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BuildForStatefulSet returns the PodDisruptionBudget selecting the Pods of the given StatefulSet.
+// The PodDisruptionBudget has the same name as the StatefulSet. Only one of minAvailable and maxUnavailable
+// is expected to be set.
+func BuildForStatefulSet(sts appsv1.StatefulSet, minAvailable, maxUnavailable *intstr.IntOrString, labels map[string]string, ownerReferences []metav1.OwnerReference) policyv1.PodDisruptionBudget {
+	return policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            sts.Name,
+			Namespace:       sts.Namespace,
+			Labels:          labels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       sts.Spec.Selector.DeepCopy(),
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+	}
+}
+
+// CreateOrUpdate creates the PodDisruptionBudget or updates the spec, labels and owner references of the existing one.
+func CreateOrUpdate(ctx context.Context, c client.Client, desired policyv1.PodDisruptionBudget) error {
+	existing := policyv1.PodDisruptionBudget{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(&desired), &existing); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return c.Create(ctx, &desired)
+		}
+		return err
+	}
+
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec = desired.Spec
+	return c.Update(ctx, &existing)
+}
+
+// DeleteIfExists removes the PodDisruptionBudget, it is not an error if it doesn't exist.
+func DeleteIfExists(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	pdb := policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	if err := c.Delete(ctx, &pdb); err != nil && !apiErrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
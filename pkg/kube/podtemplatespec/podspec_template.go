@@ -257,6 +257,13 @@ func WithPodAffinity(podAffinity *corev1.PodAffinity) Modification {
 	}
 }
 
+// WithTopologySpreadConstraints sets the PodTemplateSpec's topology spread constraints
+func WithTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint) Modification {
+	return func(podTemplateSpec *corev1.PodTemplateSpec) {
+		podTemplateSpec.Spec.TopologySpreadConstraints = constraints
+	}
+}
+
 // WithTolerations sets the PodTemplateSpec's tolerations
 func WithTolerations(tolerations []corev1.Toleration) Modification {
 	return func(podTemplateSpec *corev1.PodTemplateSpec) {
@@ -0,0 +1,186 @@
+package volumesnapshot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/snapshot"
+)
+
+// VolumeSnapshots are handled as unstructured objects, so that the operator doesn't depend on the client of the
+// external snapshotter and keeps working in clusters where its CRDs are not installed.
+
+const (
+	Group   = "snapshot.storage.k8s.io"
+	Version = "v1"
+	Kind    = "VolumeSnapshot"
+)
+
+var GroupVersionKind = schema.GroupVersionKind{Group: Group, Version: Version, Kind: Kind}
+
+// State is the part of the status of a VolumeSnapshot the operator acts on.
+type State struct {
+	// CreationTime is set once the snapshot has been cut on the storage system. The volume can be written to again
+	// from then on, even if the snapshot is not ready to use yet.
+	CreationTime string
+	ReadyToUse   bool
+	Error        string
+}
+
+// New returns a VolumeSnapshot of the given PersistentVolumeClaim.
+func New(namespace, name, pvcName string, className *string, labels map[string]string, ownerReferences []metav1.OwnerReference) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+	if className != nil {
+		spec["volumeSnapshotClassName"] = *className
+	}
+
+	snapshot := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetName(name)
+	snapshot.SetLabels(labels)
+	snapshot.SetOwnerReferences(ownerReferences)
+	return snapshot
+}
+
+// Get reads the VolumeSnapshot with the given name.
+func Get(ctx context.Context, kubeClient client.Client, key types.NamespacedName) (*unstructured.Unstructured, error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	if err := kubeClient.Get(ctx, key, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ReadState returns the state of the VolumeSnapshot from its status.
+func ReadState(snapshot *unstructured.Unstructured) State {
+	creationTime, _, _ := unstructured.NestedString(snapshot.Object, "status", "creationTime")
+	readyToUse, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	message, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return State{CreationTime: creationTime, ReadyToUse: readyToUse, Error: message}
+}
+
+// DataSource returns the data source provisioning a PersistentVolumeClaim from the given VolumeSnapshot.
+func DataSource(name string) *corev1.TypedLocalObjectReference {
+	apiGroup := Group
+	return &corev1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: Kind, Name: name}
+}
+
+// ApplyRestore sets the data sources of the volume claim templates of the StatefulSet to the VolumeSnapshots of the
+// MongoDBVolumeSnapshot referenced by restore. It returns false if the StatefulSet can't be created or updated yet,
+// which is the case while the MongoDBVolumeSnapshot is not completed.
+//
+// The data sources are only needed until the volumes of the initial members are provisioned. Members added later
+// would otherwise start from the snapshot, which may be stale or deleted by then, so once all the volumes exist the
+// existing StatefulSet is deleted without its Pods and recreated without the data sources, the same way the volume
+// claim templates are changed on a resize.
+func ApplyRestore(ctx context.Context, kubeClient client.Client, restore *v1.RestoreFromSnapshot, sts *appsv1.StatefulSet) (bool, error) {
+	if restore == nil {
+		return true, nil
+	}
+
+	existing := appsv1.StatefulSet{}
+	err := kubeClient.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: sts.Name}, &existing)
+	if err == nil {
+		if !hasSnapshotDataSource(existing.Spec.VolumeClaimTemplates) {
+			return true, nil
+		}
+		provisioned, err := volumesProvisioned(ctx, kubeClient, &existing, ptr.Deref(existing.Spec.Replicas, 1))
+		if err != nil {
+			return false, err
+		}
+		if provisioned {
+			if err := kubeClient.Delete(ctx, &existing, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !apiErrors.IsNotFound(err) {
+				return false, xerrors.Errorf("failed to delete StatefulSet %s: %w", existing.Name, err)
+			}
+			return false, nil
+		}
+
+		// the volume claim templates of an existing StatefulSet can't be changed
+		dataSources := map[string]*corev1.TypedLocalObjectReference{}
+		for _, template := range existing.Spec.VolumeClaimTemplates {
+			dataSources[template.Name] = template.Spec.DataSource
+		}
+		for i := range sts.Spec.VolumeClaimTemplates {
+			template := &sts.Spec.VolumeClaimTemplates[i]
+			template.Spec.DataSource = dataSources[template.Name]
+		}
+		return true, nil
+	}
+	if !apiErrors.IsNotFound(err) {
+		return false, err
+	}
+
+	// the StatefulSet is recreated over volumes which have already been restored
+	if provisioned, err := volumesProvisioned(ctx, kubeClient, sts, 1); err != nil || provisioned {
+		return provisioned, err
+	}
+
+	volumeSnapshot := snapshot.MongoDBVolumeSnapshot{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: restore.MongoDBVolumeSnapshotName}, &volumeSnapshot); err != nil {
+		return false, xerrors.Errorf("failed to get MongoDBVolumeSnapshot %s: %w", restore.MongoDBVolumeSnapshotName, err)
+	}
+	if !volumeSnapshot.IsCompleted() {
+		return false, nil
+	}
+
+	names := volumeSnapshot.VolumeSnapshotNames()
+	for i := range sts.Spec.VolumeClaimTemplates {
+		template := &sts.Spec.VolumeClaimTemplates[i]
+		if name, ok := names[template.Name]; ok {
+			template.Spec.DataSource = DataSource(name)
+			delete(names, template.Name)
+		}
+	}
+	// a snapshot of a separate journal volume restored on a single data volume would lose the journal
+	if len(names) > 0 {
+		return false, xerrors.Errorf("MongoDBVolumeSnapshot %s has snapshots of the volumes %v, which the StatefulSet %s doesn't have", restore.MongoDBVolumeSnapshotName, slices.Sorted(maps.Keys(names)), sts.Name)
+	}
+	return true, nil
+}
+
+func hasSnapshotDataSource(templates []corev1.PersistentVolumeClaim) bool {
+	return slices.ContainsFunc(templates, func(template corev1.PersistentVolumeClaim) bool {
+		dataSource := template.Spec.DataSource
+		return dataSource != nil && dataSource.Kind == Kind && ptr.Deref(dataSource.APIGroup, "") == Group
+	})
+}
+
+// volumesProvisioned returns whether the PersistentVolumeClaims of the first replicas Pods of the StatefulSet exist.
+func volumesProvisioned(ctx context.Context, kubeClient client.Client, sts *appsv1.StatefulSet, replicas int32) (bool, error) {
+	if len(sts.Spec.VolumeClaimTemplates) == 0 {
+		return false, nil
+	}
+	for ordinal := range replicas {
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			name := fmt.Sprintf("%s-%s-%d", template.Name, sts.Name, ordinal)
+			if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: name}, &corev1.PersistentVolumeClaim{}); err != nil {
+				if apiErrors.IsNotFound(err) {
+					return false, nil
+				}
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
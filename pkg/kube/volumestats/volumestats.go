@@ -0,0 +1,93 @@
+package volumestats
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+// Usage is the usage of the filesystem of a volume, as reported by the kubelet.
+type Usage struct {
+	UsedBytes     int64
+	CapacityBytes int64
+}
+
+// Percent returns the used part of the volume, in percent of its capacity.
+func (u Usage) Percent() int {
+	if u.CapacityBytes <= 0 {
+		return 0
+	}
+	return int(u.UsedBytes * 100 / u.CapacityBytes)
+}
+
+// Reader reads the usage of the persistent volumes mounted on a node.
+type Reader interface {
+	// NodeVolumeUsage returns the usage of the volumes mounted by the pods of the node, by PVC.
+	NodeVolumeUsage(ctx context.Context, nodeName string) (map[types.NamespacedName]Usage, error)
+}
+
+// kubeletReader reads the volume stats from the kubelet Summary API, through the API server node proxy. The operator
+// needs the "get" permission on the "nodes/proxy" resource.
+type kubeletReader struct {
+	restClient restclient.Interface
+}
+
+func NewKubeletReader(config *restclient.Config) (Reader, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create the client reading the volume stats: %w", err)
+	}
+	return NewKubeletReaderFromRESTClient(clientset.CoreV1().RESTClient()), nil
+}
+
+func NewKubeletReaderFromRESTClient(restClient restclient.Interface) Reader {
+	return &kubeletReader{restClient: restClient}
+}
+
+// summary contains the fields of the kubelet stats summary read by the operator.
+type summary struct {
+	Pods []struct {
+		Volumes []struct {
+			UsedBytes     *int64 `json:"usedBytes"`
+			CapacityBytes *int64 `json:"capacityBytes"`
+			PVCRef        *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef"`
+		} `json:"volume"`
+	} `json:"pods"`
+}
+
+func (r *kubeletReader) NodeVolumeUsage(ctx context.Context, nodeName string) (map[types.NamespacedName]Usage, error) {
+	body, err := r.restClient.Get().Resource("nodes").Name(nodeName).SubResource("proxy", "stats", "summary").DoRaw(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read the stats summary of node %s: %w", nodeName, err)
+	}
+	return parseSummary(body)
+}
+
+func parseSummary(body []byte) (map[types.NamespacedName]Usage, error) {
+	var s summary
+	if err := json.Unmarshal(body, &s); err != nil {
+		return nil, xerrors.Errorf("failed to parse the stats summary: %w", err)
+	}
+
+	usage := map[types.NamespacedName]Usage{}
+	for _, pod := range s.Pods {
+		for _, volume := range pod.Volumes {
+			if volume.PVCRef == nil || volume.UsedBytes == nil || volume.CapacityBytes == nil {
+				continue
+			}
+			usage[types.NamespacedName{Namespace: volume.PVCRef.Namespace, Name: volume.PVCRef.Name}] = Usage{
+				UsedBytes:     *volume.UsedBytes,
+				CapacityBytes: *volume.CapacityBytes,
+			}
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,64 @@
+package volumestats
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+const testSummary = `{
+  "node": {"nodeName": "node-1"},
+  "pods": [
+    {
+      "podRef": {"name": "my-rs-0", "namespace": "ns"},
+      "volume": [
+        {"name": "data", "usedBytes": 850, "capacityBytes": 1000, "pvcRef": {"name": "data-my-rs-0", "namespace": "ns"}},
+        {"name": "kube-api-access", "usedBytes": 12, "capacityBytes": 1000}
+      ]
+    },
+    {
+      "podRef": {"name": "my-rs-1", "namespace": "ns"},
+      "volume": [
+        {"name": "data", "pvcRef": {"name": "data-my-rs-1", "namespace": "ns"}}
+      ]
+    }
+  ]
+}`
+
+func TestKubeletReader_NodeVolumeUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes/node-1/proxy/stats/summary" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testSummary))
+	}))
+	defer server.Close()
+
+	reader, err := NewKubeletReader(&restclient.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	usage, err := reader.NodeVolumeUsage(context.Background(), "node-1")
+	require.NoError(t, err)
+
+	// volumes without a PVC or without stats are ignored
+	assert.Equal(t, map[types.NamespacedName]Usage{
+		{Namespace: "ns", Name: "data-my-rs-0"}: {UsedBytes: 850, CapacityBytes: 1000},
+	}, usage)
+	assert.Equal(t, 85, usage[types.NamespacedName{Namespace: "ns", Name: "data-my-rs-0"}].Percent())
+
+	_, err = reader.NodeVolumeUsage(context.Background(), "node-2")
+	assert.Error(t, err)
+}
+
+func TestUsage_Percent(t *testing.T) {
+	assert.Equal(t, 0, Usage{UsedBytes: 10}.Percent())
+	assert.Equal(t, 33, Usage{UsedBytes: 1, CapacityBytes: 3}.Percent())
+}
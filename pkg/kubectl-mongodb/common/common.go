@@ -383,6 +383,9 @@ func getCentralRules() []rbacv1.PolicyRule {
 				"opsmanagers", "opsmanagers/finalizers", "opsmanagers/status",
 				"mongodb", "mongodb/finalizers", "mongodb/status",
 				"mongodbsearch", "mongodbsearch/finalizers", "mongodbsearch/status",
+				"mongodbroles",
+				"mongodbreferencegrants",
+				"mongodbvolumesnapshots", "mongodbvolumesnapshots/finalizers", "mongodbvolumesnapshots/status",
 			},
 			APIGroups: []string{"mongodb.com"},
 		},
@@ -401,6 +404,11 @@ func getCentralRules() []rbacv1.PolicyRule {
 			Resources: []string{"voyageais", "voyageais/finalizers", "voyageais/status"},
 			APIGroups: []string{"ai.mongodb.com"},
 		},
+		{
+			Verbs:     []string{"create", "patch"},
+			Resources: []string{"events"},
+			APIGroups: []string{""},
+		},
 	}
 }
 
@@ -445,6 +453,26 @@ func getMemberRules() []rbacv1.PolicyRule {
 			Resources: []string{"statefulsets", "deployments"},
 			APIGroups: []string{"apps"},
 		},
+		{
+			Verbs:     []string{"get", "list", "create", "update", "delete", "watch", "deletecollection"},
+			Resources: []string{"poddisruptionbudgets"},
+			APIGroups: []string{"policy"},
+		},
+		{
+			Verbs:     []string{"get", "list", "create", "update", "delete", "watch", "deletecollection"},
+			Resources: []string{"horizontalpodautoscalers"},
+			APIGroups: []string{"autoscaling"},
+		},
+		{
+			Verbs:     []string{"get", "list", "create", "update", "delete", "watch", "deletecollection"},
+			Resources: []string{"certificates"},
+			APIGroups: []string{"cert-manager.io"},
+		},
+		{
+			Verbs:     []string{"get", "list", "create", "update", "delete", "watch", "deletecollection"},
+			Resources: []string{"secretproviderclasses"},
+			APIGroups: []string{"secrets-store.csi.x-k8s.io"},
+		},
 		{
 			Verbs:     []string{"get", "list", "create", "update", "watch", "patch"},
 			Resources: []string{"persistentvolumeclaims"},
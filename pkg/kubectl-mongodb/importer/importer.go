@@ -0,0 +1,335 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cast"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/maputil"
+)
+
+// operatorManagedArgs are the mongod options the operator configures itself for the Kubernetes members, they are not
+// imported into the additional mongod config.
+var operatorManagedArgs = []string{
+	"storage.dbPath",
+	"systemLog.destination",
+	"systemLog.path",
+	"replication.replSetName",
+	"net.tls.certificateKeyFile",
+	"net.tls.PEMKeyFile",
+	"net.tls.CAFile",
+	"net.tls.clusterFile",
+	"net.ssl",
+	"security.clusterAuthMode",
+	"security.clusterFile",
+	"security.keyFile",
+	"processManagement",
+}
+
+// hostSpecificArgs are the mongod options which only apply to the hosts the replica set runs on (addresses, sockets
+// and paths of their file system), they are not imported into the additional mongod config and a warning is printed
+// for each of them.
+var hostSpecificArgs = []string{
+	"net.bindIp",
+	"net.bindIpAll",
+	"net.unixDomainSocket",
+	"auditLog.path",
+	"security.encryptionKeyFile",
+	"security.kmip.clientCertificateFile",
+	"security.kmip.serverCAFile",
+}
+
+// authModes maps the authentication mechanisms of the automation config to the authentication modes of the MongoDB
+// resource.
+var authModes = map[string]mdbv1.AuthMode{
+	util.AutomationConfigScramSha256Option: util.SCRAMSHA256,
+	util.SCRAMSHA1:                         util.SCRAMSHA1,
+	util.AutomationConfigScramSha1Option:   util.MONGODBCR,
+	util.AutomationConfigX509Option:        util.X509,
+	util.AutomationConfigLDAPOption:        util.LDAP,
+	util.AutomationConfigOIDCOption:        util.OIDC,
+}
+
+// Options configures the MongoDB resource generated for an existing replica set.
+type Options struct {
+	// ReplicaSetName is the name of the replica set in the Ops Manager deployment, it is used as the name of the resource.
+	ReplicaSetName string
+	Namespace      string
+	// Members is the number of Kubernetes members, the replica set keeps its number of members if it is 0.
+	Members int
+	// ProjectConfigMap and Credentials reference the Ops Manager project of the replica set.
+	ProjectConfigMap string
+	Credentials      string
+	// CertsSecretPrefix and CAConfigMap configure TLS if the replica set has TLS enabled.
+	CertsSecretPrefix string
+	CAConfigMap       string
+	// LiveMigration keeps the existing members in the replica set while the Kubernetes members join it.
+	LiveMigration bool
+}
+
+// ReplicaSetToMongoDB generates the MongoDB resource matching the replica set "opts.ReplicaSetName" of the Ops Manager
+// deployment. It also returns warnings about the settings of the replica set which could not be imported and need to
+// be configured by hand.
+func ReplicaSetToMongoDB(d om.Deployment, opts Options) (*mdbv1.MongoDB, []string, error) {
+	rs := d.GetReplicaSetByName(opts.ReplicaSetName)
+	if rs == nil {
+		return nil, nil, xerrors.Errorf("replica set %s not found in the Ops Manager deployment", opts.ReplicaSetName)
+	}
+	if errs := validation.IsDNS1123Label(rs.Name()); len(errs) > 0 {
+		return nil, nil, xerrors.Errorf("replica set %s can't be imported, the MongoDB resource must have the name of the replica set: %s", rs.Name(), strings.Join(errs, ", "))
+	}
+
+	processes := map[string]om.Process{}
+	for _, p := range d.ProcessesCopy() {
+		processes[p.Name()] = p
+	}
+
+	var warnings []string
+	var memberProcesses []om.Process
+	var memberConfig []automationconfig.MemberOptions
+	members := rs.Members()
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Id() < members[j].Id()
+	})
+	for _, m := range members {
+		p, ok := processes[m.Name()]
+		if !ok {
+			return nil, nil, xerrors.Errorf("process %s of replica set %s not found in the Ops Manager deployment", m.Name(), rs.Name())
+		}
+		if cast.ToBool(m["arbiterOnly"]) {
+			warnings = append(warnings, fmt.Sprintf("member %s is an arbiter, arbiters are not supported by the MongoDB resource and it was not imported", m.Name()))
+			continue
+		}
+		if cast.ToBool(m["hidden"]) || cast.ToInt(m["secondaryDelaySecs"]) > 0 || cast.ToInt(m["slaveDelay"]) > 0 {
+			warnings = append(warnings, fmt.Sprintf("member %s is hidden or delayed, it was imported as a regular member", m.Name()))
+		}
+		memberProcesses = append(memberProcesses, p)
+		memberConfig = append(memberConfig, memberOptions(m))
+	}
+	if len(memberProcesses) == 0 {
+		return nil, nil, xerrors.Errorf("replica set %s doesn't have any data bearing member", rs.Name())
+	}
+
+	existingVotingMembers := automationconfig.VotingMembers(len(memberConfig), memberConfig)
+
+	first := memberProcesses[0]
+	for _, p := range memberProcesses[1:] {
+		if p.Version() != first.Version() {
+			return nil, nil, xerrors.Errorf("the members of replica set %s run different versions (%s and %s), finish the upgrade before importing it", rs.Name(), first.Version(), p.Version())
+		}
+	}
+
+	mongodConfig, configWarnings := additionalMongodConfig(first)
+	warnings = append(warnings, configWarnings...)
+
+	mdb := &mdbv1.MongoDB{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "MongoDB",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rs.Name(),
+			Namespace: opts.Namespace,
+		},
+		Spec: mdbv1.MongoDbSpec{
+			DbCommonSpec: mdbv1.DbCommonSpec{
+				Version:      first.Version(),
+				ResourceType: mdbv1.ReplicaSet,
+				ConnectionSpec: mdbv1.ConnectionSpec{
+					SharedConnectionSpec: mdbv1.SharedConnectionSpec{
+						OpsManagerConfig: &mdbv1.PrivateCloudConfig{ConfigMapRef: mdbv1.ConfigMapRef{Name: opts.ProjectConfigMap}},
+					},
+					Credentials: opts.Credentials,
+				},
+				AdditionalMongodConfig: mongodConfig,
+				Security:               &mdbv1.Security{},
+			},
+			Members:      len(memberProcesses),
+			MemberConfig: memberConfig,
+		},
+	}
+	if fcv := first.FeatureCompatibilityVersion(); fcv != "" {
+		mdb.Spec.FeatureCompatibilityVersion = &fcv
+	}
+	if opts.Members > 0 {
+		mdb.Spec.Members = opts.Members
+		if len(mdb.Spec.MemberConfig) > opts.Members {
+			mdb.Spec.MemberConfig = mdb.Spec.MemberConfig[:opts.Members]
+		}
+	}
+
+	if first.IsTLSEnabled() {
+		if opts.CertsSecretPrefix == "" {
+			return nil, nil, xerrors.Errorf("replica set %s has TLS enabled, a prefix for the Secrets of the certificates is required", rs.Name())
+		}
+		mdb.Spec.Security.CertificatesSecretsPrefix = opts.CertsSecretPrefix
+		mdb.Spec.Security.TLSConfig = &mdbv1.TLSConfig{CA: opts.CAConfigMap}
+	}
+
+	authWarnings, err := configureAuthentication(d, first, mdb)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings = append(warnings, authWarnings...)
+
+	if roles := d.GetRoles(); len(roles) > 0 {
+		mdb.Spec.Security.Roles = roles
+	}
+
+	if opts.LiveMigration {
+		migrationWarnings, err := configureLiveMigration(mdb, memberProcesses, existingVotingMembers)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append(warnings, migrationWarnings...)
+	}
+
+	return mdb, warnings, nil
+}
+
+// ToYAML serializes the generated MongoDB resource without its status and the empty agent settings, which are never
+// imported.
+func ToYAML(mdb *mdbv1.MongoDB) ([]byte, error) {
+	bytes, err := yaml.Marshal(mdb)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]interface{}{}
+	if err := yaml.Unmarshal(bytes, &object); err != nil {
+		return nil, err
+	}
+	delete(object, "status")
+	delete(maputil.ReadMapValueAsMap(object, "metadata"), "creationTimestamp")
+	delete(maputil.ReadMapValueAsMap(object, "spec"), "agent")
+	removeEmptyValues(maputil.ReadMapValueAsMap(object, "spec", "security", "authentication", "agents"))
+	return yaml.Marshal(object)
+}
+
+func removeEmptyValues(m map[string]interface{}) {
+	for k, v := range m {
+		if nested, ok := v.(map[string]interface{}); ok {
+			removeEmptyValues(nested)
+			if len(nested) == 0 {
+				delete(m, k)
+			}
+		} else if v == nil || v == "" {
+			delete(m, k)
+		}
+	}
+}
+
+func memberOptions(m om.ReplicaSetMember) automationconfig.MemberOptions {
+	options := automationconfig.MemberOptions{
+		Votes:    ptr.To(m.Votes()),
+		Priority: ptr.To(strconv.FormatFloat(float64(m.Priority()), 'f', -1, 32)),
+	}
+	if tags := cast.ToStringMapString(m["tags"]); len(tags) > 0 {
+		options.Tags = tags
+	}
+	return options
+}
+
+func additionalMongodConfig(p om.Process) (*mdbv1.AdditionalMongodConfig, []string) {
+	config := mdbv1.NewEmptyAdditionalMongodConfig()
+	var warnings []string
+	args := p.Args()
+	for _, path := range maputil.ToFlatList(args) {
+		if matchesArg(operatorManagedArgs, path) {
+			continue
+		}
+		if matchesArg(hostSpecificArgs, path) {
+			warnings = append(warnings, fmt.Sprintf("option %s is specific to the hosts of the replica set and was not imported", path))
+			continue
+		}
+		config.AddOption(path, maputil.ReadMapValueAsInterface(args, strings.Split(path, ".")...))
+	}
+	return config, warnings
+}
+
+// matchesArg returns true if the option "path" is one of "args" or is nested in one of them.
+func matchesArg(args []string, path string) bool {
+	for _, arg := range args {
+		if path == arg || strings.HasPrefix(path, arg+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func configureAuthentication(d om.Deployment, p om.Process, mdb *mdbv1.MongoDB) ([]string, error) {
+	ac, err := om.BuildAutomationConfigFromDeployment(d)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	if p.HasInternalClusterAuthentication() {
+		if strings.ToUpper(p.ClusterAuthMode()) != util.X509 {
+			return nil, xerrors.Errorf("internal cluster authentication mode %s is not supported", p.ClusterAuthMode())
+		}
+		mdb.Spec.Security.Authentication = &mdbv1.Authentication{InternalCluster: util.X509}
+	}
+	if ac.Auth == nil || !ac.Auth.IsEnabled() {
+		return warnings, nil
+	}
+
+	authentication := mdb.Spec.Security.Authentication
+	if authentication == nil {
+		authentication = &mdbv1.Authentication{}
+	}
+	authentication.Enabled = true
+	authentication.IgnoreUnknownUsers = !ac.Auth.AuthoritativeSet
+	for _, mechanism := range ac.Auth.DeploymentAuthMechanisms {
+		mode, ok := authModes[mechanism]
+		if !ok {
+			return nil, xerrors.Errorf("authentication mechanism %s is not supported", mechanism)
+		}
+		authentication.Modes = append(authentication.Modes, mode)
+		switch mode {
+		case util.LDAP:
+			warnings = append(warnings, "LDAP authentication is enabled, spec.security.authentication.ldap needs to be configured")
+		case util.OIDC:
+			warnings = append(warnings, "OIDC authentication is enabled, spec.security.authentication.oidcProviderConfigs needs to be configured")
+		}
+	}
+	if agentMode, ok := authModes[ac.Auth.AutoAuthMechanism]; ok {
+		authentication.Agents.Mode = string(agentMode)
+	}
+	mdb.Spec.Security.Authentication = authentication
+	return warnings, nil
+}
+
+// configureLiveMigration keeps the existing members in the replica set until the annotation is removed from the
+// MongoDB resource, once the Kubernetes members are in sync.
+func configureLiveMigration(mdb *mdbv1.MongoDB, externalProcesses []om.Process, externalVotingMembers int) ([]string, error) {
+	var warnings []string
+	names := make([]string, len(externalProcesses))
+	kubernetesNames := map[string]bool{}
+	for i := 0; i < mdb.Spec.Members; i++ {
+		kubernetesNames[fmt.Sprintf("%s-%d", mdb.Name, i)] = true
+	}
+	for i, p := range externalProcesses {
+		if kubernetesNames[p.Name()] {
+			return nil, xerrors.Errorf("process %s has the name of a Kubernetes member, rename it in Ops Manager before the migration", p.Name())
+		}
+		names[i] = p.Name()
+	}
+	if externalVotingMembers+automationconfig.VotingMembers(mdb.Spec.Members, mdb.Spec.MemberConfig) > 7 {
+		warnings = append(warnings, "the replica set will have more than 7 voting members during the migration, the Kubernetes members beyond the 7th won't vote until the existing members are removed")
+	}
+	mdb.Annotations = map[string]string{util.MigrationExternalMembers: strings.Join(names, ",")}
+	return warnings, nil
+}
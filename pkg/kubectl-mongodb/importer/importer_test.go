@@ -0,0 +1,183 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/maputil"
+)
+
+const vmDeployment = `{
+  "processes": [%s],
+  "replicaSets": [{
+    "_id": "rs0",
+    "protocolVersion": "1",
+    "members": [
+      {"_id": 1, "host": "vm_1", "votes": 1, "priority": 1},
+      {"_id": 0, "host": "vm_0", "votes": 1, "priority": 2, "tags": {"dc": "east"}},
+      {"_id": 2, "host": "vm_2", "votes": 0, "priority": 0},
+      {"_id": 3, "host": "vm_arbiter", "votes": 1, "priority": 0, "arbiterOnly": true}
+    ]
+  }],
+  "roles": [{"role": "readAudit", "db": "admin", "privileges": [{"resource": {"db": "audit", "collection": ""}, "actions": ["find"]}]}],
+  "auth": {
+    "disabled": false,
+    "authoritativeSet": false,
+    "autoAuthMechanism": "SCRAM-SHA-256",
+    "deploymentAuthMechanisms": ["SCRAM-SHA-256", "MONGODB-X509"]
+  }
+}`
+
+const vmProcess = `{
+  "name": "%s",
+  "hostname": "%s.example.com",
+  "processType": "mongod",
+  "version": "6.0.5-ent",
+  "featureCompatibilityVersion": "6.0",
+  "args2_6": {
+    "net": {"port": 27017, "bindIp": "10.0.0.1,localhost", "tls": {"mode": "requireTLS", "certificateKeyFile": "/etc/mongodb/server.pem"}},
+    "auditLog": {"destination": "file", "format": "JSON", "path": "/var/log/mongodb/audit.json"},
+    "storage": {"dbPath": "/data/db", "wiredTiger": {"engineConfig": {"cacheSizeGB": 2}}},
+    "systemLog": {"destination": "file", "path": "/var/log/mongodb/mongod.log", "verbosity": 1},
+    "replication": {"replSetName": "rs0"},
+    "security": {"clusterAuthMode": "x509", "clusterFile": "/etc/mongodb/cluster.pem"},
+    "setParameter": {"transactionLifetimeLimitSeconds": 120}
+  }
+}`
+
+func vmDeploymentJSON() string {
+	var processes []string
+	for _, name := range []string{"vm_0", "vm_1", "vm_2", "vm_arbiter"} {
+		processes = append(processes, fmt.Sprintf(vmProcess, name, name))
+	}
+	return fmt.Sprintf(vmDeployment, strings.Join(processes, ","))
+}
+
+func readDeployment(t *testing.T, deploymentJSON string) om.Deployment {
+	d, err := om.BuildDeploymentFromBytes([]byte(deploymentJSON))
+	require.NoError(t, err)
+	return d
+}
+
+func readVMDeployment(t *testing.T) om.Deployment {
+	return readDeployment(t, vmDeploymentJSON())
+}
+
+func defaultOptions() Options {
+	return Options{
+		ReplicaSetName:    "rs0",
+		Namespace:         "mongodb",
+		ProjectConfigMap:  "my-project",
+		Credentials:       "my-credentials",
+		CertsSecretPrefix: "mdb",
+		CAConfigMap:       "ca-issuer",
+	}
+}
+
+func TestReplicaSetToMongoDB(t *testing.T) {
+	mdb, warnings, err := ReplicaSetToMongoDB(readVMDeployment(t), defaultOptions())
+	require.NoError(t, err)
+
+	assert.Equal(t, "rs0", mdb.Name)
+	assert.Equal(t, "mongodb", mdb.Namespace)
+	assert.Equal(t, mdbv1.ReplicaSet, mdb.Spec.ResourceType)
+	assert.Equal(t, "6.0.5-ent", mdb.Spec.Version)
+	assert.Equal(t, ptr.To("6.0"), mdb.Spec.FeatureCompatibilityVersion)
+	assert.Equal(t, "my-project", mdb.Spec.OpsManagerConfig.ConfigMapRef.Name)
+	assert.Equal(t, "my-credentials", mdb.Spec.Credentials)
+	assert.Empty(t, mdb.Annotations)
+
+	assert.Equal(t, 3, mdb.Spec.Members)
+	assert.Equal(t, []automationconfig.MemberOptions{
+		{Votes: ptr.To(1), Priority: ptr.To("2"), Tags: map[string]string{"dc": "east"}},
+		{Votes: ptr.To(1), Priority: ptr.To("1")},
+		{Votes: ptr.To(0), Priority: ptr.To("0")},
+	}, mdb.Spec.MemberConfig)
+	assert.Equal(t, []string{
+		"member vm_arbiter is an arbiter, arbiters are not supported by the MongoDB resource and it was not imported",
+		"option auditLog.path is specific to the hosts of the replica set and was not imported",
+		"option net.bindIp is specific to the hosts of the replica set and was not imported",
+	}, warnings)
+
+	assert.Equal(t, map[string]interface{}{
+		"auditLog":     map[string]interface{}{"destination": "file", "format": "JSON"},
+		"net":          map[string]interface{}{"port": float64(27017), "tls": map[string]interface{}{"mode": "requireTLS"}},
+		"storage":      map[string]interface{}{"wiredTiger": map[string]interface{}{"engineConfig": map[string]interface{}{"cacheSizeGB": float64(2)}}},
+		"systemLog":    map[string]interface{}{"verbosity": float64(1)},
+		"setParameter": map[string]interface{}{"transactionLifetimeLimitSeconds": float64(120)},
+	}, mdb.Spec.AdditionalMongodConfig.ToMap())
+
+	assert.Equal(t, "mdb", mdb.Spec.Security.CertificatesSecretsPrefix)
+	assert.Equal(t, "ca-issuer", mdb.Spec.Security.TLSConfig.CA)
+
+	authentication := mdb.Spec.Security.Authentication
+	assert.True(t, authentication.Enabled)
+	assert.Equal(t, []mdbv1.AuthMode{util.SCRAMSHA256, util.X509}, authentication.Modes)
+	assert.Equal(t, util.SCRAMSHA256, authentication.Agents.Mode)
+	assert.Equal(t, util.X509, authentication.InternalCluster)
+	assert.True(t, authentication.IgnoreUnknownUsers)
+
+	require.Len(t, mdb.Spec.Security.Roles, 1)
+	assert.Equal(t, "readAudit", mdb.Spec.Security.Roles[0].Role)
+}
+
+func TestReplicaSetToMongoDB_LiveMigration(t *testing.T) {
+	opts := defaultOptions()
+	opts.LiveMigration = true
+	opts.Members = 7
+	mdb, warnings, err := ReplicaSetToMongoDB(readVMDeployment(t), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, mdb.Spec.Members)
+	assert.Equal(t, "vm_0,vm_1,vm_2", mdb.Annotations[util.MigrationExternalMembers])
+	assert.Contains(t, warnings, "the replica set will have more than 7 voting members during the migration, the Kubernetes members beyond the 7th won't vote until the existing members are removed")
+}
+
+func TestReplicaSetToMongoDB_Errors(t *testing.T) {
+	t.Run("Replica set doesn't exist", func(t *testing.T) {
+		opts := defaultOptions()
+		opts.ReplicaSetName = "rs1"
+		_, _, err := ReplicaSetToMongoDB(readVMDeployment(t), opts)
+		assert.EqualError(t, err, "replica set rs1 not found in the Ops Manager deployment")
+	})
+	t.Run("TLS requires a prefix for the certificates", func(t *testing.T) {
+		opts := defaultOptions()
+		opts.CertsSecretPrefix = ""
+		_, _, err := ReplicaSetToMongoDB(readVMDeployment(t), opts)
+		assert.EqualError(t, err, "replica set rs0 has TLS enabled, a prefix for the Secrets of the certificates is required")
+	})
+	t.Run("External members can't have the name of Kubernetes members", func(t *testing.T) {
+		d := readDeployment(t, strings.ReplaceAll(vmDeploymentJSON(), "vm_1", "rs0-1"))
+		opts := defaultOptions()
+		opts.LiveMigration = true
+		_, _, err := ReplicaSetToMongoDB(d, opts)
+		assert.EqualError(t, err, "process rs0-1 has the name of a Kubernetes member, rename it in Ops Manager before the migration")
+	})
+}
+
+func TestToYAML(t *testing.T) {
+	mdb, _, err := ReplicaSetToMongoDB(readVMDeployment(t), defaultOptions())
+	require.NoError(t, err)
+
+	bytes, err := ToYAML(mdb)
+	require.NoError(t, err)
+
+	object := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(bytes, &object))
+	assert.Equal(t, "mongodb.com/v1", object["apiVersion"])
+	assert.Equal(t, "MongoDB", object["kind"])
+	assert.NotContains(t, object, "status")
+	assert.Equal(t, map[string]interface{}{"name": "rs0", "namespace": "mongodb"}, object["metadata"])
+	assert.NotContains(t, maputil.ReadMapValueAsMap(object, "spec"), "agent")
+	assert.Equal(t, map[string]interface{}{"mode": "SCRAM-SHA-256"}, maputil.ReadMapValueAsMap(object, "spec", "security", "authentication", "agents"))
+}
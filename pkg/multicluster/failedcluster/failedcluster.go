@@ -1,5 +1,18 @@
 package failedcluster
 
+import (
+	"encoding/json"
+	"math"
+	"slices"
+
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+)
+
 const (
 	FailedClusterAnnotation       = "failedClusters"
 	ClusterSpecOverrideAnnotation = "clusterSpecOverride"
@@ -9,3 +22,139 @@ type FailedCluster struct {
 	ClusterName string
 	Members     int
 }
+
+// ReadFailedClusterNames returns the names of the member clusters in the failedClusters annotation.
+func ReadFailedClusterNames(annotations map[string]string) ([]string, error) {
+	val, ok := annotations[FailedClusterAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var failedClusters []FailedCluster
+	if err := json.Unmarshal([]byte(val), &failedClusters); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(failedClusters))
+	for _, c := range failedClusters {
+		names = append(names, c.ClusterName)
+	}
+	return names, nil
+}
+
+// EffectivePolicy returns the failover policy applied to a resource, Annotate by default.
+func EffectivePolicy(config *v1.FailoverConfig) v1.FailoverPolicy {
+	if policy := config.GetPolicy(); policy != "" {
+		return policy
+	}
+	return v1.FailoverPolicyAnnotate
+}
+
+// EffectiveMultiClusterPolicy returns the failover policy applied to a MongoDBMultiCluster resource. The default
+// depends on whether the operator is configured to perform automated failovers, which keeps the behaviour these
+// resources had before the policy could be configured.
+func EffectiveMultiClusterPolicy(config *v1.FailoverConfig) v1.FailoverPolicy {
+	if config.GetPolicy() == "" && multicluster.ShouldPerformFailover() {
+		return v1.FailoverPolicyRedistributeMembers
+	}
+	return EffectivePolicy(config)
+}
+
+// WithoutFailedClusters returns the clients of the member clusters which are not failed, so that the reconcilers
+// handle the failed clusters the same way as the clusters the operator has no connection to.
+func WithoutFailedClusters[T any](clients map[string]T, failedClusterNames []string) map[string]T {
+	if len(failedClusterNames) == 0 {
+		return clients
+	}
+	healthyClients := make(map[string]T, len(clients))
+	for clusterName, c := range clients {
+		if !slices.Contains(failedClusterNames, clusterName) {
+			healthyClients[clusterName] = c
+		}
+	}
+	return healthyClients
+}
+
+// ApplyPolicy returns the cluster spec list the resource is reconciled with while the failed clusters are down.
+func ApplyPolicy(policy v1.FailoverPolicy, clusters mdb.ClusterSpecList, failedClusterNames []string) mdb.ClusterSpecList {
+	switch policy {
+	case v1.FailoverPolicyRedistributeMembers:
+		return RedistributeMembers(clusters, failedClusterNames)
+	case v1.FailoverPolicyReduceVotes:
+		return ReduceVotes(clusters, failedClusterNames)
+	default:
+		return clusters
+	}
+}
+
+// clusterWithMinimumMembers returns the index of the cluster with the minimum number of nodes.
+func clusterWithMinimumMembers(clusters mdb.ClusterSpecList) int {
+	mini, index := math.MaxInt64, -1
+
+	for nn, c := range clusters {
+		if c.Members < mini {
+			mini = c.Members
+			index = nn
+		}
+	}
+	return index
+}
+
+// RedistributeMembers removes the failed clusters from the list and evenly distributes their members amongst the
+// remaining healthy clusters. The member options of the failed members are moved along with them. The list is
+// returned unchanged if none of the clusters is healthy.
+func RedistributeMembers(clusters mdb.ClusterSpecList, failedClusterNames []string) mdb.ClusterSpecList {
+	var healthy, failed mdb.ClusterSpecList
+	for _, c := range clusters.DeepCopy() {
+		if slices.Contains(failedClusterNames, c.ClusterName) {
+			failed = append(failed, c)
+		} else {
+			healthy = append(healthy, c)
+		}
+	}
+	if len(failed) == 0 || len(healthy) == 0 {
+		return clusters
+	}
+
+	for _, f := range failed {
+		for i := 0; i < f.Members; i++ {
+			// pick the cluster with the minimum number of nodes currently and increment its count by 1.
+			target := &healthy[clusterWithMinimumMembers(healthy)]
+			if i < len(f.MemberConfig) {
+				target.MemberConfig = append(withDefaultMemberOptions(target.MemberConfig, target.Members), f.MemberConfig[i])
+			}
+			target.Members += 1
+		}
+	}
+	return healthy
+}
+
+// ReduceVotes sets the votes and the priority of the members of the failed clusters to 0. The list is returned
+// unchanged if no voting member would be left.
+func ReduceVotes(clusters mdb.ClusterSpecList, failedClusterNames []string) mdb.ClusterSpecList {
+	reduced := clusters.DeepCopy()
+	remainingVotes := 0
+	for i := range reduced {
+		reduced[i].MemberConfig = withDefaultMemberOptions(reduced[i].MemberConfig, reduced[i].Members)
+		if !slices.Contains(failedClusterNames, reduced[i].ClusterName) {
+			for _, o := range reduced[i].MemberConfig[:reduced[i].Members] {
+				remainingVotes += o.GetVotes()
+			}
+			continue
+		}
+		for j := range reduced[i].MemberConfig {
+			reduced[i].MemberConfig[j].Votes = ptr.To(0)
+			reduced[i].MemberConfig[j].Priority = ptr.To("0")
+		}
+	}
+	if remainingVotes == 0 {
+		return clusters
+	}
+	return reduced
+}
+
+// withDefaultMemberOptions appends the default member options for the members which have none.
+func withDefaultMemberOptions(options []automationconfig.MemberOptions, members int) []automationconfig.MemberOptions {
+	for len(options) < members {
+		options = append(options, automationconfig.MemberOptions{Votes: ptr.To(1), Priority: ptr.To("1")})
+	}
+	return options
+}
@@ -0,0 +1,200 @@
+package failedcluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/pkg/automationconfig"
+)
+
+func TestClusterWithMinimumNumber(t *testing.T) {
+	tests := []struct {
+		inp mdb.ClusterSpecList
+		out int
+	}{
+		{
+			inp: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 2},
+				{ClusterName: "cluster2", Members: 1},
+				{ClusterName: "cluster3", Members: 4},
+				{ClusterName: "cluster4", Members: 1},
+			},
+			out: 1,
+		},
+		{
+			inp: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 1},
+				{ClusterName: "cluster2", Members: 2},
+				{ClusterName: "cluster3", Members: 3},
+				{ClusterName: "cluster4", Members: 4},
+			},
+			out: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.out, clusterWithMinimumMembers(tt.inp))
+	}
+}
+
+func TestRedistributeMembers(t *testing.T) {
+	tests := []struct {
+		inp         mdb.ClusterSpecList
+		clusterName string
+		out         mdb.ClusterSpecList
+	}{
+		{
+			inp: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 2},
+				{ClusterName: "cluster2", Members: 1},
+				{ClusterName: "cluster3", Members: 4},
+				{ClusterName: "cluster4", Members: 1},
+			},
+			clusterName: "cluster1",
+			out: mdb.ClusterSpecList{
+				{ClusterName: "cluster2", Members: 2},
+				{ClusterName: "cluster3", Members: 4},
+				{ClusterName: "cluster4", Members: 2},
+			},
+		},
+		{
+			inp: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 2},
+				{ClusterName: "cluster2", Members: 1},
+				{ClusterName: "cluster3", Members: 4},
+				{ClusterName: "cluster4", Members: 1},
+			},
+			clusterName: "cluster2",
+			out: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 2},
+				{ClusterName: "cluster3", Members: 4},
+				{ClusterName: "cluster4", Members: 2},
+			},
+		},
+		{
+			inp: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 2},
+				{ClusterName: "cluster2", Members: 1},
+				{ClusterName: "cluster3", Members: 4},
+				{ClusterName: "cluster4", Members: 1},
+			},
+			clusterName: "cluster3",
+			out: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 3},
+				{ClusterName: "cluster2", Members: 3},
+				{ClusterName: "cluster4", Members: 2},
+			},
+		},
+		{
+			inp: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 2},
+				{ClusterName: "cluster2", Members: 1},
+				{ClusterName: "cluster3", Members: 4},
+				{ClusterName: "cluster4", Members: 1},
+			},
+			clusterName: "cluster4",
+			out: mdb.ClusterSpecList{
+				{ClusterName: "cluster1", Members: 2},
+				{ClusterName: "cluster2", Members: 2},
+				{ClusterName: "cluster3", Members: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.out, RedistributeMembers(tt.inp, []string{tt.clusterName}))
+	}
+}
+
+func TestRedistributeMembers_MovesMemberOptions(t *testing.T) {
+	clusters := mdb.ClusterSpecList{
+		{ClusterName: "cluster1", Members: 1},
+		{ClusterName: "cluster2", Members: 2, MemberConfig: []automationconfig.MemberOptions{
+			{Votes: ptr.To(1), Priority: ptr.To("2")},
+			{Votes: ptr.To(0), Priority: ptr.To("0")},
+		}},
+	}
+
+	redistributed := RedistributeMembers(clusters, []string{"cluster2"})
+
+	assert.Equal(t, mdb.ClusterSpecList{
+		{ClusterName: "cluster1", Members: 3, MemberConfig: []automationconfig.MemberOptions{
+			{Votes: ptr.To(1), Priority: ptr.To("1")},
+			{Votes: ptr.To(1), Priority: ptr.To("2")},
+			{Votes: ptr.To(0), Priority: ptr.To("0")},
+		}},
+	}, redistributed)
+	// the cluster spec list of the resource is not modified
+	assert.Len(t, clusters, 2)
+	assert.Nil(t, clusters[0].MemberConfig)
+}
+
+func TestRedistributeMembers_NoHealthyCluster(t *testing.T) {
+	clusters := mdb.ClusterSpecList{{ClusterName: "cluster1", Members: 3}}
+	assert.Equal(t, clusters, RedistributeMembers(clusters, []string{"cluster1"}))
+}
+
+func TestReduceVotes(t *testing.T) {
+	clusters := mdb.ClusterSpecList{
+		{ClusterName: "cluster1", Members: 2},
+		{ClusterName: "cluster2", Members: 1, MemberConfig: []automationconfig.MemberOptions{
+			{Votes: ptr.To(1), Priority: ptr.To("5"), Tags: map[string]string{"region": "eu"}},
+		}},
+	}
+
+	reduced := ReduceVotes(clusters, []string{"cluster2"})
+
+	assert.Equal(t, mdb.ClusterSpecList{
+		{ClusterName: "cluster1", Members: 2, MemberConfig: []automationconfig.MemberOptions{
+			{Votes: ptr.To(1), Priority: ptr.To("1")},
+			{Votes: ptr.To(1), Priority: ptr.To("1")},
+		}},
+		{ClusterName: "cluster2", Members: 1, MemberConfig: []automationconfig.MemberOptions{
+			{Votes: ptr.To(0), Priority: ptr.To("0"), Tags: map[string]string{"region": "eu"}},
+		}},
+	}, reduced)
+	assert.Equal(t, "5", *clusters[1].MemberConfig[0].Priority)
+}
+
+func TestReduceVotes_KeepsLastVotingMembers(t *testing.T) {
+	clusters := mdb.ClusterSpecList{
+		{ClusterName: "cluster1", Members: 1, MemberConfig: []automationconfig.MemberOptions{{Votes: ptr.To(0), Priority: ptr.To("0")}}},
+		{ClusterName: "cluster2", Members: 2},
+	}
+	assert.Equal(t, clusters, ReduceVotes(clusters, []string{"cluster2"}))
+}
+
+func TestEffectivePolicy(t *testing.T) {
+	assert.Equal(t, v1.FailoverPolicyAnnotate, EffectivePolicy(nil))
+	assert.Equal(t, v1.FailoverPolicyReduceVotes, EffectivePolicy(&v1.FailoverConfig{Policy: v1.FailoverPolicyReduceVotes}))
+
+	t.Setenv("PERFORM_FAILOVER", "true") // nolint:forbidigo
+	assert.Equal(t, v1.FailoverPolicyAnnotate, EffectivePolicy(&v1.FailoverConfig{}))
+	assert.Equal(t, v1.FailoverPolicyReduceVotes, EffectivePolicy(&v1.FailoverConfig{Policy: v1.FailoverPolicyReduceVotes}))
+}
+
+func TestEffectiveMultiClusterPolicy(t *testing.T) {
+	assert.Equal(t, v1.FailoverPolicyAnnotate, EffectiveMultiClusterPolicy(nil))
+	assert.Equal(t, v1.FailoverPolicyRedistributeMembers, EffectiveMultiClusterPolicy(&v1.FailoverConfig{Policy: v1.FailoverPolicyRedistributeMembers}))
+
+	t.Setenv("PERFORM_FAILOVER", "true") // nolint:forbidigo
+	assert.Equal(t, v1.FailoverPolicyRedistributeMembers, EffectiveMultiClusterPolicy(nil))
+	assert.Equal(t, v1.FailoverPolicyAnnotate, EffectiveMultiClusterPolicy(&v1.FailoverConfig{Policy: v1.FailoverPolicyAnnotate}))
+}
+
+func TestReadFailedClusterNames(t *testing.T) {
+	names, err := ReadFailedClusterNames(map[string]string{FailedClusterAnnotation: `[{"ClusterName":"cluster1","Members":2}]`})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cluster1"}, names)
+
+	names, err = ReadFailedClusterNames(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	_, err = ReadFailedClusterNames(map[string]string{FailedClusterAnnotation: "not json"})
+	assert.Error(t, err)
+}
@@ -110,7 +110,8 @@ func TestAnnotationIsAdded(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		// Let the watcher run its health-check iteration to completion and block on the
 		// next 10s tick, so the annotation write from this iteration has already happened.
@@ -146,7 +147,8 @@ func TestAnnotationIsRemovedWhenClusterRecovers(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		select {
 		case evt := <-watchChannel:
@@ -181,7 +183,8 @@ func TestNoEventBeforeStreakThreshold(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		// Let the watcher run its health-check iteration to completion and block on the
 		// next 10s tick, so this iteration's streak update has already happened.
@@ -211,7 +214,8 @@ func TestNoEventWhenStreakAtCapWithoutAnnotation(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		// Let the watcher run its health-check iteration to completion and block on the
 		// next 10s tick, so this iteration's streak update has already happened.
@@ -245,7 +249,8 @@ func TestStreakResetsOnUnhealthyWhenAlmostRecovered(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		// Let the watcher run its health-check iteration to completion and block on the
 		// next 10s tick. At that durable blocking point both the streak reset and the
@@ -282,7 +287,8 @@ func TestNoEventWhenClusterNotInAnnotationAtThreshold(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		// Let the watcher run its health-check iteration to completion and block on the
 		// next 10s tick, so this iteration's streak update has already happened.
@@ -325,7 +331,8 @@ func TestMultipleClustersIndependentStreaks(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		select {
 		case evt := <-watchChannel:
@@ -369,7 +376,8 @@ func TestAllMDBMultiResourcesCleared(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		var evtNames []string
 		for i := 0; i < 2; i++ {
@@ -413,17 +421,137 @@ func TestFailedClusterAnnotationStaysWhenPerformFailoverTrue(t *testing.T) {
 			RequiredHealthyStreak: testRequiredHealthyStreak,
 		}
 
-		go checker.WatchMemberClusterHealth(ctx, zap.S(), watchChannel, central, nil)
+		checker.Watch(MongoDBMultiClusterResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
 
 		// Let the watcher run its health-check iteration to completion and block on the
-		// next 10s tick, so this iteration's (lack of) streak update has already happened.
+		// next 10s tick, so this iteration's streak update has already happened.
 		synctest.Wait()
 
+		// The members were redistributed: the streak is tracked, but the cluster stays failed.
 		assert.Empty(t, watchChannel)
-		assert.Equal(t, 0, checker.HealthyStreakFor("cluster1"))
+		assert.Equal(t, 1, checker.HealthyStreakFor("cluster1"))
 
 		got := &mdbmulti.MongoDBMultiCluster{}
 		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "mdbmc", Namespace: "ns"}, got))
 		assert.True(t, isInFailedClusterAnnotation(got.Annotations, "cluster1"))
 	})
 }
+
+func newTestMultiShardedCluster(name, clusterName string, annotations map[string]string) *mdb.MongoDB {
+	return mdb.NewDefaultMultiShardedClusterBuilder().
+		SetName(name).
+		SetNamespace("ns").
+		SetAnnotations(annotations).
+		SetAllClusterSpecLists(mdb.ClusterSpecList{{ClusterName: clusterName, Members: 3}}).
+		Build()
+}
+
+// TestMongoDBAnnotationIsAddedOnlyWhenDeployedToFailedCluster verifies that only the MongoDB resources with the
+// MultiCluster topology deployed to the failed cluster are marked and reconciled.
+func TestMongoDBAnnotationIsAddedOnlyWhenDeployedToFailedCluster(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		inFailedCluster := newTestMultiShardedCluster("sc1", "cluster1", nil)
+		inHealthyCluster := newTestMultiShardedCluster("sc2", "cluster2", nil)
+		singleCluster := mdb.NewDefaultReplicaSetBuilder().SetName("rs").SetNamespace("ns").Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(inFailedCluster, inHealthyCluster, singleCluster).Build()
+		central := kubernetesClient.NewClient(fakeClient)
+		watchChannel := make(chan event.GenericEvent, 10)
+
+		unhealthy := NewMockedMemberHealthCheck("server1").(*MockedMemberHealthCheck)
+		unhealthy.Healthy = false
+
+		checker := NewMemberClusterHealthChecker(testRequiredHealthyStreak)
+		checker.Cache = map[string]ClusterHealthChecker{"cluster1": unhealthy, "cluster2": NewMockedMemberHealthCheck("server2")}
+
+		checker.Watch(MongoDBResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
+
+		synctest.Wait()
+
+		require.Len(t, watchChannel, 1)
+		evt := <-watchChannel
+		assert.Equal(t, "sc1", evt.Object.GetName())
+
+		for name, expected := range map[string]bool{"sc1": true, "sc2": false, "rs": false} {
+			got := &mdb.MongoDB{}
+			require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "ns"}, got))
+			assert.Equal(t, expected, isInFailedClusterAnnotation(got.Annotations, "cluster1"), name)
+		}
+	})
+}
+
+// TestMongoDBAnnotationIsRemovedWithReduceVotesPolicy verifies that the votes of the members in a recovered cluster
+// are restored even if the operator performs automated failovers.
+func TestMongoDBAnnotationIsRemovedWithReduceVotesPolicy(t *testing.T) {
+	t.Setenv("PERFORM_FAILOVER", "true")
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sc := newTestMultiShardedCluster("sc1", "cluster1", map[string]string{
+			failedcluster.FailedClusterAnnotation: getFailedClusterList([]string{"cluster1"}),
+		})
+		sc.Spec.Failover = &apiv1.FailoverConfig{Policy: apiv1.FailoverPolicyReduceVotes}
+		fakeClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(sc).Build()
+		central := kubernetesClient.NewClient(fakeClient)
+		watchChannel := make(chan event.GenericEvent, 10)
+
+		checker := NewMemberClusterHealthChecker(testRequiredHealthyStreak)
+		checker.Cache = map[string]ClusterHealthChecker{"cluster1": NewMockedMemberHealthCheck("server1")}
+		checker.HealthyStreak = map[string]int{"cluster1": testRequiredHealthyStreak - 1}
+
+		checker.Watch(MongoDBResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
+
+		select {
+		case evt := <-watchChannel:
+			assert.Equal(t, "sc1", evt.Object.GetName())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for reconcile event after cluster recovery")
+		}
+
+		got := &mdb.MongoDB{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "sc1", Namespace: "ns"}, got))
+		assert.False(t, isInFailedClusterAnnotation(got.Annotations, "cluster1"))
+	})
+}
+
+// TestMongoDBAnnotationIsRemovedByDefaultWhenPerformFailoverTrue verifies that the MongoDB resources default to the
+// Annotate policy, so that a recovered cluster is removed from the annotation even if the operator performs
+// automated failovers.
+func TestMongoDBAnnotationIsRemovedByDefaultWhenPerformFailoverTrue(t *testing.T) {
+	t.Setenv("PERFORM_FAILOVER", "true")
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sc := newTestMultiShardedCluster("sc1", "cluster1", map[string]string{
+			failedcluster.FailedClusterAnnotation: getFailedClusterList([]string{"cluster1"}),
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(sc).Build()
+		central := kubernetesClient.NewClient(fakeClient)
+		watchChannel := make(chan event.GenericEvent, 10)
+
+		checker := NewMemberClusterHealthChecker(testRequiredHealthyStreak)
+		checker.Cache = map[string]ClusterHealthChecker{"cluster1": NewMockedMemberHealthCheck("server1")}
+		checker.HealthyStreak = map[string]int{"cluster1": testRequiredHealthyStreak - 1}
+
+		checker.Watch(MongoDBResources, watchChannel)
+		go checker.WatchMemberClusterHealth(ctx, zap.S(), central, nil)
+
+		select {
+		case evt := <-watchChannel:
+			assert.Equal(t, "sc1", evt.Object.GetName())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for reconcile event after cluster recovery")
+		}
+
+		got := &mdb.MongoDB{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "sc1", Namespace: "ns"}, got))
+		assert.False(t, isInFailedClusterAnnotation(got.Annotations, "cluster1"))
+	})
+}
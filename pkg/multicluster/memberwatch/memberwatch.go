@@ -5,7 +5,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"math"
 	"slices"
 	"sync"
 	"time"
@@ -13,8 +12,12 @@ import (
 	"go.uber.org/zap"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdbmulti"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/annotations"
@@ -28,6 +31,39 @@ type MemberClusterHealthChecker struct {
 	HealthyStreak         map[string]int
 	RequiredHealthyStreak int
 	mu                    sync.RWMutex
+	watchedKinds          []watchedKind
+}
+
+// watchedKind is a kind of multi-cluster resources, the resources are sent to the events channel when one of their
+// member clusters fails or recovers.
+type watchedKind struct {
+	list   ResourceLister
+	events chan<- event.GenericEvent
+}
+
+func NewMemberClusterHealthChecker(requiredHealthyStreak int) *MemberClusterHealthChecker {
+	return &MemberClusterHealthChecker{
+		Cache:                 make(map[string]ClusterHealthChecker),
+		HealthyStreak:         make(map[string]int),
+		RequiredHealthyStreak: requiredHealthyStreak,
+	}
+}
+
+// Watch makes the health checker track the failed member clusters of the resources returned by list. It has to be
+// called before WatchMemberClusterHealth is started.
+func (m *MemberClusterHealthChecker) Watch(list ResourceLister, events chan<- event.GenericEvent) {
+	m.watchedKinds = append(m.watchedKinds, watchedKind{list: list, events: events})
+}
+
+// WatchResources makes the controller reconcile the resources returned by list when one of their member clusters fails
+// or recovers. It does nothing if the operator is not configured with member clusters.
+func (m *MemberClusterHealthChecker) WatchResources(c controller.Controller, list ResourceLister) error {
+	if m == nil {
+		return nil
+	}
+	eventChannel := make(chan event.GenericEvent)
+	m.Watch(list, eventChannel)
+	return c.Watch(source.Channel[client.Object](eventChannel, &handler.EnqueueRequestForObject{}))
 }
 
 func (m *MemberClusterHealthChecker) HealthyStreakFor(cluster string) int {
@@ -73,20 +109,24 @@ func getClusterCredentials(clustersMap map[string]cluster.Cluster,
 	}, nil
 }
 
-func (m *MemberClusterHealthChecker) populateCache(clustersMap map[string]cluster.Cluster, log *zap.SugaredLogger) {
+// populateCache creates the health checks of the given member clusters. The healthy streaks of the member clusters
+// already checked are kept, the ones of the removed member clusters are dropped. It returns false if the kubeconfig
+// can't be read, in which case the cache is left unchanged.
+func (m *MemberClusterHealthChecker) populateCache(clustersMap map[string]cluster.Cluster, log *zap.SugaredLogger) bool {
 	kubeConfigFile, err := multicluster.NewKubeConfigFile(multicluster.GetKubeConfigPath())
 	if err != nil {
 		log.Errorf("Failed to read KubeConfig file err: %s", err)
 		// we can't populate the client so just bail out here
-		return
+		return false
 	}
 
 	kubeConfig, err := kubeConfigFile.LoadKubeConfigFile()
 	if err != nil {
 		log.Errorf("Failed to load the kubeconfig file content err: %s", err)
-		return
+		return false
 	}
 
+	cache := make(map[string]ClusterHealthChecker)
 	for n := range kubeConfig.Contexts {
 		kubeContext := kubeConfig.Contexts[n]
 		clusterName := kubeContext.Context.Cluster
@@ -95,51 +135,67 @@ func (m *MemberClusterHealthChecker) populateCache(clustersMap map[string]cluste
 			log.Errorf("Skipping cluster %s: %v", clusterName, err)
 			continue
 		}
-		m.Cache[clusterName] = NewMemberHealthCheck(credentials.Server, credentials.CertificateAuthority, credentials.Token, log)
-		m.HealthyStreak[clusterName] = 0
+		cache[clusterName] = NewMemberHealthCheck(credentials.Server, credentials.CertificateAuthority, credentials.Token, log)
 	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Cache = cache
+	for clusterName := range m.HealthyStreak {
+		if _, ok := cache[clusterName]; !ok {
+			delete(m.HealthyStreak, clusterName)
+		}
+	}
+	for clusterName := range cache {
+		if _, ok := m.HealthyStreak[clusterName]; !ok {
+			m.HealthyStreak[clusterName] = 0
+		}
+	}
+	return true
 }
 
-// WatchMemberClusterHealth watches member clusters healthcheck. If a cluster fails healthcheck it re-enqueues the
-// MongoDBMultiCluster resources. It is spun up in the mongodb multi reconciler as a go-routine, and is executed every 10 seconds.
-func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Context, log *zap.SugaredLogger, watchChannel chan event.GenericEvent, centralClient kubernetesClient.Client, clustersMap map[string]cluster.Cluster) {
-	// check if the local cache is populated if not let's do that
-	if len(m.Cache) == 0 {
-		m.populateCache(clustersMap, log)
+// WatchMemberClusterHealth watches member clusters healthcheck. If a cluster fails healthcheck it marks the failed
+// cluster in the annotations of the watched resources deployed to it, applies their failover policy and re-enqueues
+// them. It is spun up once for all the multi-cluster controllers as a go-routine, and is executed every 10 seconds.
+// The health checks follow the member clusters added to or removed from the registry.
+func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Context, log *zap.SugaredLogger, centralClient kubernetesClient.Client, registry *multicluster.MemberClusterRegistry) {
+	// a cache populated beforehand is used as is until the member clusters change
+	cachedVersion := -1
+	if len(m.Cache) > 0 {
+		cachedVersion = registry.Version()
 	}
 
 	for {
-		log.Info("Running member cluster healthcheck")
-		mdbmList := &mdbmulti.MongoDBMultiClusterList{}
-
-		err := centralClient.List(ctx, mdbmList, &client.ListOptions{Namespace: ""})
-		if err != nil {
-			log.Errorf("Failed to fetch MongoDBMultiClusterList from Kubernetes: %s", err)
+		if version := registry.Version(); version != cachedVersion {
+			if m.populateCache(registry.Clusters(), log) {
+				cachedVersion = version
+			}
 		}
 
+		log.Info("Running member cluster healthcheck")
+		resources := m.listWatchedResources(ctx, centralClient, log)
+
 		// check the cluster health status corresponding to each member cluster
 		for k, v := range m.Cache {
 			if v.IsClusterHealthy(log) {
 				log.Infof("Cluster %s reported healthy", k)
-				if multicluster.ShouldPerformFailover() {
-					continue
-				}
 
-				// If failover is disabled we should remove the cluster from the annotation after a number of health checks have succeeded
+				// The cluster is removed from the annotation after a number of health checks have succeeded, unless
+				// the members have been redistributed to the other clusters.
 				m.mu.Lock()
 				m.HealthyStreak[k] = min(m.HealthyStreak[k]+1, m.RequiredHealthyStreak)
 				streak := m.HealthyStreak[k]
 				m.mu.Unlock()
 				if streak == m.RequiredHealthyStreak {
-					for _, mdbm := range mdbmList.Items {
-						if isInFailedClusterAnnotation(mdbm.Annotations, k) {
-							log.Infof("Enqueuing resource: %s, because cluster %s has come back up", mdbm.Name, k)
-							err := removeClusterFromFailedAnnotation(ctx, mdbm, k, centralClient)
-							if err != nil {
-								log.Errorf("Failed to remove cluster %s from failed annotation on %s: %s", k, mdbm.Name, err)
-							}
-							watchChannel <- event.GenericEvent{Object: &mdbm}
+					for _, r := range resources {
+						if r.Policy == v1.FailoverPolicyRedistributeMembers || !isInFailedClusterAnnotation(r.Object.GetAnnotations(), k) {
+							continue
+						}
+						log.Infof("Enqueuing resource: %s, because cluster %s has come back up", r.Object.GetName(), k)
+						if err := removeClusterFromFailedAnnotation(ctx, r.Object, k, centralClient); err != nil {
+							log.Errorf("Failed to remove cluster %s from failed annotation on %s: %s", k, r.Object.GetName(), err)
 						}
+						r.events <- event.GenericEvent{Object: r.Object}
 					}
 				}
 				continue
@@ -149,22 +205,16 @@ func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Contex
 			m.mu.Lock()
 			m.HealthyStreak[k] = 0
 			m.mu.Unlock()
-			// re-enqueue all the MDBMultis the operator is watching into the reconcile loop
-			for _, mdbm := range mdbmList.Items {
-				if !isInFailedClusterAnnotation(mdbm.Annotations, k) && multicluster.ShouldPerformFailover() {
-					log.Infof("Enqueuing resource: %s, because cluster %s has failed healthcheck", mdbm.Name, k)
-					err := AddFailoverAnnotation(ctx, mdbm, k, centralClient)
-					if err != nil {
-						log.Errorf("Failed to add failover annotation to the mdbmc resource: %s, error: %s", mdbm.Name, err)
-					}
-					watchChannel <- event.GenericEvent{Object: &mdbm}
-				} else if !isInFailedClusterAnnotation(mdbm.Annotations, k) {
-					log.Infof("Marking resource: %s, with failed cluster %s annotation", mdbm.Name, k)
-					err := addFailedClustersAnnotation(ctx, mdbm, k, centralClient)
-					if err != nil {
-						log.Errorf("Failed to add failed cluster annotation to the mdbmc resource: %s, error: %s", mdbm.Name, err)
-					}
+			// re-enqueue all the resources deployed to the failed cluster into their reconcile loop
+			for _, r := range resources {
+				if _, ok := r.Members[k]; !ok || isInFailedClusterAnnotation(r.Object.GetAnnotations(), k) {
+					continue
+				}
+				log.Infof("Enqueuing resource: %s, because cluster %s has failed healthcheck (failover policy %s)", r.Object.GetName(), k, r.Policy)
+				if err := markClusterAsFailed(ctx, r, k, centralClient); err != nil {
+					log.Errorf("Failed to add failed cluster annotation to the resource: %s, error: %s", r.Object.GetName(), err)
 				}
+				r.events <- event.GenericEvent{Object: r.Object}
 			}
 		}
 		select {
@@ -175,6 +225,36 @@ func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Contex
 	}
 }
 
+// eventedResource is a watched resource along with the channel of its controller.
+type eventedResource struct {
+	WatchedResource
+	events chan<- event.GenericEvent
+}
+
+func (m *MemberClusterHealthChecker) listWatchedResources(ctx context.Context, centralClient kubernetesClient.Client, log *zap.SugaredLogger) []eventedResource {
+	var resources []eventedResource
+	for _, kind := range m.watchedKinds {
+		list, err := kind.list(ctx, centralClient)
+		if err != nil {
+			log.Errorf("Failed to list the resources watched for member cluster failures: %s", err)
+			continue
+		}
+		for _, r := range list {
+			resources = append(resources, eventedResource{WatchedResource: r, events: kind.events})
+		}
+	}
+	return resources
+}
+
+// markClusterAsFailed adds the cluster to the failed clusters of the resource. The members of a MongoDBMultiCluster
+// are redistributed by overriding its cluster spec list, the other resources apply their policy when reconciled.
+func markClusterAsFailed(ctx context.Context, r eventedResource, clusterName string, client kubernetesClient.Client) error {
+	if mrs, ok := r.Object.(*mdbmulti.MongoDBMultiCluster); ok && r.Policy == v1.FailoverPolicyRedistributeMembers {
+		return AddFailoverAnnotation(ctx, *mrs, clusterName, client)
+	}
+	return addFailedClustersAnnotation(ctx, r.Object, clusterName, r.Members[clusterName], client)
+}
+
 // isInFailedClusterAnnotation checks if the cluster name is present in the failedCluster annotation
 func isInFailedClusterAnnotation(annotations map[string]string, clusterName string) bool {
 	failedClusters := readFailedClusterAnnotation(annotations)
@@ -205,44 +285,6 @@ func readFailedClusterAnnotation(annotations map[string]string) []failedcluster.
 	return nil
 }
 
-// clusterWithMinimumMembers returns the index of the cluster with the minimum number of nodes.
-func clusterWithMinimumMembers(clusters mdb.ClusterSpecList) int {
-	mini, index := math.MaxInt64, -1
-
-	for nn, c := range clusters {
-		if c.Members < mini {
-			mini = c.Members
-			index = nn
-		}
-	}
-	return index
-}
-
-// distributeFailedMembers evenly distributes the failed cluster's members amongst the remaining healthy clusters.
-func distributeFailedMembers(clusters mdb.ClusterSpecList, clustername string) mdb.ClusterSpecList {
-	// add the cluster override annotations. Get the current clusterspec list from the CR and
-	// increase the members of the first cluster by the number of failed nodes
-	membersToFailOver := 0
-
-	for n, c := range clusters {
-		if c.ClusterName == clustername {
-			membersToFailOver = c.Members
-			clusters = append(clusters[:n], clusters[n+1:]...)
-			break
-		}
-	}
-
-	for membersToFailOver > 0 {
-		// pick the cluster with the minumum number of nodes currently and increament
-		// its count by 1.
-		nn := clusterWithMinimumMembers(clusters)
-		clusters[nn].Members += 1
-		membersToFailOver -= 1
-	}
-
-	return clusters
-}
-
 // AddFailoverAnnotation adds the failed cluster spec to the annotation of the MongoDBMultiCluster CR for it to be used
 // while performing the reconcilliation
 func AddFailoverAnnotation(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, clustername string, client kubernetesClient.Client) error {
@@ -250,13 +292,17 @@ func AddFailoverAnnotation(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster
 		mrs.Annotations = map[string]string{}
 	}
 
-	err := addFailedClustersAnnotation(ctx, mrs, clustername, client)
+	err := addFailedClustersAnnotation(ctx, &mrs, clustername, getClusterMembers(mrs.Spec.ClusterSpecList, clustername), client)
 	if err != nil {
 		return err
 	}
 
-	currentClusterSpecs := mrs.Spec.ClusterSpecList
-	currentClusterSpecs = distributeFailedMembers(currentClusterSpecs, clustername)
+	// the members of all the failed clusters are redistributed, not only the ones of the cluster which just failed
+	failedClusterNames, err := failedcluster.ReadFailedClusterNames(mrs.Annotations)
+	if err != nil {
+		return err
+	}
+	currentClusterSpecs := failedcluster.RedistributeMembers(mrs.Spec.ClusterSpecList, failedClusterNames)
 
 	updatedClusterSpec, err := json.Marshal(currentClusterSpecs)
 	if err != nil {
@@ -266,44 +312,40 @@ func AddFailoverAnnotation(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster
 	return annotations.SetAnnotations(ctx, &mrs, map[string]string{failedcluster.ClusterSpecOverrideAnnotation: string(updatedClusterSpec)}, client)
 }
 
-func removeClusterFromFailedAnnotation(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, clustername string, client kubernetesClient.Client) error {
-	failedClusters := readFailedClusterAnnotation(mrs.Annotations)
+func removeClusterFromFailedAnnotation(ctx context.Context, obj client.Object, clustername string, client kubernetesClient.Client) error {
+	failedClusters := readFailedClusterAnnotation(obj.GetAnnotations())
 
 	remaining := slices.DeleteFunc(failedClusters, func(c failedcluster.FailedCluster) bool { return c.ClusterName == clustername })
 
 	if len(remaining) == 0 {
-		return annotations.RemoveAnnotation(ctx, &mrs, failedcluster.FailedClusterAnnotation, client)
+		return annotations.RemoveAnnotation(ctx, obj, failedcluster.FailedClusterAnnotation, client)
 	}
 
 	clusterDataBytes, err := json.Marshal(remaining)
 	if err != nil {
 		return err
 	}
-	return annotations.SetAnnotations(ctx, &mrs, map[string]string{failedcluster.FailedClusterAnnotation: string(clusterDataBytes)}, client)
+	return annotations.SetAnnotations(ctx, obj, map[string]string{failedcluster.FailedClusterAnnotation: string(clusterDataBytes)}, client)
 }
 
-func addFailedClustersAnnotation(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, clustername string, client kubernetesClient.Client) error {
-	if mrs.Annotations == nil {
-		mrs.Annotations = map[string]string{}
-	}
-
-	// read the existing failed cliuster annotations
+func addFailedClustersAnnotation(ctx context.Context, obj client.Object, clustername string, members int, client kubernetesClient.Client) error {
+	// read the existing failed cluster annotations
 	var clusterData []failedcluster.FailedCluster
-	failedclusters := readFailedClusterAnnotation(mrs.Annotations)
+	failedclusters := readFailedClusterAnnotation(obj.GetAnnotations())
 	if failedclusters != nil {
 		clusterData = failedclusters
 	}
 
 	clusterData = append(clusterData, failedcluster.FailedCluster{
 		ClusterName: clustername,
-		Members:     getClusterMembers(mrs.Spec.ClusterSpecList, clustername),
+		Members:     members,
 	})
 
 	clusterDataBytes, err := json.Marshal(clusterData)
 	if err != nil {
 		return err
 	}
-	return annotations.SetAnnotations(ctx, &mrs, map[string]string{failedcluster.FailedClusterAnnotation: string(clusterDataBytes)}, client)
+	return annotations.SetAnnotations(ctx, obj, map[string]string{failedcluster.FailedClusterAnnotation: string(clusterDataBytes)}, client)
 }
 
 func getClusterMembers(clusterSpecList mdb.ClusterSpecList, clusterName string) int {
@@ -23,105 +23,6 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
 )
 
-func TestClusterWithMinimumNumber(t *testing.T) {
-	tests := []struct {
-		inp mdb.ClusterSpecList
-		out int
-	}{
-		{
-			inp: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 2},
-				{ClusterName: "cluster2", Members: 1},
-				{ClusterName: "cluster3", Members: 4},
-				{ClusterName: "cluster4", Members: 1},
-			},
-			out: 1,
-		},
-		{
-			inp: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 1},
-				{ClusterName: "cluster2", Members: 2},
-				{ClusterName: "cluster3", Members: 3},
-				{ClusterName: "cluster4", Members: 4},
-			},
-			out: 0,
-		},
-	}
-
-	for _, tt := range tests {
-		assert.Equal(t, tt.out, clusterWithMinimumMembers(tt.inp))
-	}
-}
-
-func TestDistributeFailedMembers(t *testing.T) {
-	tests := []struct {
-		inp         mdb.ClusterSpecList
-		clusterName string
-		out         mdb.ClusterSpecList
-	}{
-		{
-			inp: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 2},
-				{ClusterName: "cluster2", Members: 1},
-				{ClusterName: "cluster3", Members: 4},
-				{ClusterName: "cluster4", Members: 1},
-			},
-			clusterName: "cluster1",
-			out: mdb.ClusterSpecList{
-				{ClusterName: "cluster2", Members: 2},
-				{ClusterName: "cluster3", Members: 4},
-				{ClusterName: "cluster4", Members: 2},
-			},
-		},
-		{
-			inp: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 2},
-				{ClusterName: "cluster2", Members: 1},
-				{ClusterName: "cluster3", Members: 4},
-				{ClusterName: "cluster4", Members: 1},
-			},
-			clusterName: "cluster2",
-			out: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 2},
-				{ClusterName: "cluster3", Members: 4},
-				{ClusterName: "cluster4", Members: 2},
-			},
-		},
-		{
-			inp: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 2},
-				{ClusterName: "cluster2", Members: 1},
-				{ClusterName: "cluster3", Members: 4},
-				{ClusterName: "cluster4", Members: 1},
-			},
-			clusterName: "cluster3",
-			out: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 3},
-				{ClusterName: "cluster2", Members: 3},
-				{ClusterName: "cluster4", Members: 2},
-			},
-		},
-		{
-			inp: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 2},
-				{ClusterName: "cluster2", Members: 1},
-				{ClusterName: "cluster3", Members: 4},
-				{ClusterName: "cluster4", Members: 1},
-			},
-			clusterName: "cluster4",
-			out: mdb.ClusterSpecList{
-				{ClusterName: "cluster1", Members: 2},
-				{ClusterName: "cluster2", Members: 2},
-				{ClusterName: "cluster3", Members: 4},
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		assert.Equal(t, tt.out, distributeFailedMembers(tt.inp, tt.clusterName))
-	}
-}
-
 func getFailedClusterList(clusters []string) string {
 	failedClusters := make([]failedcluster.FailedCluster, len(clusters))
 
@@ -377,7 +278,7 @@ func TestAddAndRemoveFailedClusterAnnotation(t *testing.T) {
 	require.False(t, present, "precondition: annotation should not exist yet")
 
 	// 2. Mark cluster1 as failed; annotation should appear with cluster1 in it.
-	require.NoError(t, addFailedClustersAnnotation(ctx, *mrs, "cluster1", central))
+	require.NoError(t, addFailedClustersAnnotation(ctx, mrs, "cluster1", 2, central))
 
 	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "mdbmc", Namespace: "ns"}, got))
 	val, present := got.Annotations[failedcluster.FailedClusterAnnotation]
@@ -390,7 +291,7 @@ func TestAddAndRemoveFailedClusterAnnotation(t *testing.T) {
 	assert.Equal(t, "cluster1", parsed[0].ClusterName)
 
 	// 3. Remove cluster1; the annotation key should be deleted entirely.
-	require.NoError(t, removeClusterFromFailedAnnotation(ctx, *got, "cluster1", central))
+	require.NoError(t, removeClusterFromFailedAnnotation(ctx, got, "cluster1", central))
 
 	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "mdbmc", Namespace: "ns"}, got))
 	_, present = got.Annotations[failedcluster.FailedClusterAnnotation]
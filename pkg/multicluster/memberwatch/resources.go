@@ -0,0 +1,137 @@
+package memberwatch
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdbmulti"
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/search"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+)
+
+// WatchedResource is a multi-cluster resource the failed member clusters are tracked for.
+type WatchedResource struct {
+	Object client.Object
+	// Members is the number of members of the resource in each of its member clusters, the resource is only marked
+	// when one of these clusters fails.
+	Members map[string]int
+	Policy  v1.FailoverPolicy
+}
+
+// ResourceLister returns the resources of a kind the failed member clusters are tracked for.
+type ResourceLister func(ctx context.Context, c kubernetesClient.Client) ([]WatchedResource, error)
+
+// MongoDBMultiClusterResources lists all the MongoDBMultiCluster resources.
+func MongoDBMultiClusterResources(ctx context.Context, c kubernetesClient.Client) ([]WatchedResource, error) {
+	mdbmList := &mdbmulti.MongoDBMultiClusterList{}
+	if err := c.List(ctx, mdbmList, &client.ListOptions{Namespace: ""}); err != nil {
+		return nil, err
+	}
+
+	var resources []WatchedResource
+	for i := range mdbmList.Items {
+		mdbm := &mdbmList.Items[i]
+		members := map[string]int{}
+		addMembers(members, mdbm.Spec.ClusterSpecList)
+		resources = append(resources, WatchedResource{Object: mdbm, Members: members, Policy: failedcluster.EffectiveMultiClusterPolicy(mdbm.Spec.Failover)})
+	}
+	return resources, nil
+}
+
+// MongoDBResources lists the MongoDB resources with the MultiCluster topology.
+func MongoDBResources(ctx context.Context, c kubernetesClient.Client) ([]WatchedResource, error) {
+	mdbList := &mdb.MongoDBList{}
+	if err := c.List(ctx, mdbList, &client.ListOptions{Namespace: ""}); err != nil {
+		return nil, err
+	}
+
+	var resources []WatchedResource
+	for i := range mdbList.Items {
+		mongodb := &mdbList.Items[i]
+		if !mongodb.Spec.IsMultiCluster() {
+			continue
+		}
+		members := map[string]int{}
+		addMembers(members, mongodb.Spec.GetShardClusterSpecList())
+		addMembers(members, mongodb.Spec.GetConfigSrvClusterSpecList())
+		addMembers(members, mongodb.Spec.GetMongosClusterSpecList())
+		for _, shardOverride := range mongodb.Spec.ShardOverrides {
+			for _, item := range shardOverride.ClusterSpecList {
+				if _, ok := members[item.ClusterName]; !ok {
+					members[item.ClusterName] = 0
+				}
+			}
+		}
+		resources = append(resources, WatchedResource{Object: mongodb, Members: members, Policy: failedcluster.EffectivePolicy(mongodb.Spec.Failover)})
+	}
+	return resources, nil
+}
+
+// OpsManagerResources lists the MongoDBOpsManager resources with Ops Manager or the Application Database deployed to
+// multiple clusters.
+func OpsManagerResources(ctx context.Context, c kubernetesClient.Client) ([]WatchedResource, error) {
+	omList := &omv1.MongoDBOpsManagerList{}
+	if err := c.List(ctx, omList, &client.ListOptions{Namespace: ""}); err != nil {
+		return nil, err
+	}
+
+	var resources []WatchedResource
+	for i := range omList.Items {
+		opsManager := &omList.Items[i]
+		if !opsManager.Spec.IsMultiCluster() && !opsManager.Spec.AppDB.IsMultiCluster() {
+			continue
+		}
+		members := map[string]int{}
+		if opsManager.Spec.IsMultiCluster() {
+			for _, item := range opsManager.Spec.ClusterSpecList {
+				members[item.ClusterName] += item.Members
+			}
+		}
+		if opsManager.Spec.AppDB.IsMultiCluster() {
+			addMembers(members, opsManager.Spec.AppDB.ClusterSpecList)
+		}
+		policy := failedcluster.EffectivePolicy(opsManager.Spec.Failover)
+		// the members of Ops Manager and the Application Database are never redistributed
+		if policy == v1.FailoverPolicyRedistributeMembers {
+			policy = v1.FailoverPolicyAnnotate
+		}
+		resources = append(resources, WatchedResource{Object: opsManager, Members: members, Policy: policy})
+	}
+	return resources, nil
+}
+
+// MongoDBSearchResources lists the MongoDBSearch resources deployed to member clusters.
+func MongoDBSearchResources(ctx context.Context, c kubernetesClient.Client) ([]WatchedResource, error) {
+	searchList := &searchv1.MongoDBSearchList{}
+	if err := c.List(ctx, searchList, &client.ListOptions{Namespace: ""}); err != nil {
+		return nil, err
+	}
+
+	var resources []WatchedResource
+	for i := range searchList.Items {
+		search := &searchList.Items[i]
+		members := map[string]int{}
+		for _, c := range search.Spec.Clusters {
+			if c.Name != "" {
+				members[c.Name] += c.ReplicasOrDefault()
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		// only the Annotate policy is supported by MongoDBSearch
+		resources = append(resources, WatchedResource{Object: search, Members: members, Policy: v1.FailoverPolicyAnnotate})
+	}
+	return resources, nil
+}
+
+func addMembers(members map[string]int, clusterSpecList mdb.ClusterSpecList) {
+	for _, item := range clusterSpecList {
+		members[item.ClusterName] += item.Members
+	}
+}
@@ -0,0 +1,261 @@
+package multicluster
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	restclient "k8s.io/client-go/rest"
+
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+)
+
+// memberListSyncInterval is how often the member clusters are synchronized with the member list ConfigMap and the
+// kubeconfig. The kubeconfig is mounted from a Secret, so its changes are only visible in the pod after the kubelet
+// has synced the volume.
+const memberListSyncInterval = 30 * time.Second
+
+// ClusterFactory creates the cluster object of a member cluster.
+type ClusterFactory func(clusterName string, config *restclient.Config) (cluster.Cluster, error)
+
+// ClusterNamesLister returns the names of the member clusters the operator should be connected to.
+type ClusterNamesLister func(ctx context.Context) ([]string, error)
+
+// MemberClusterHandler is called for every member cluster added to the registry, e.g. to watch the resources in it.
+type MemberClusterHandler func(clusterName string, memberCluster cluster.Cluster) error
+
+// MemberClusterRegistry holds the member clusters of the operator. Member clusters are added, removed or reconnected
+// at runtime when the member list ConfigMap or the kubeconfig change, so that the operator doesn't have to be
+// restarted. The registry runs the caches of the member clusters, it has to be added to the manager.
+type MemberClusterRegistry struct {
+	mu               sync.RWMutex
+	clusters         map[string]*registeredCluster
+	handlers         []MemberClusterHandler
+	version          int
+	kubeConfigPath   string
+	newCluster       ClusterFactory
+	listClusterNames ClusterNamesLister
+	// ctx is set once the registry is started, the member clusters added later are started right away
+	ctx context.Context
+}
+
+type registeredCluster struct {
+	cluster cluster.Cluster
+	config  *restclient.Config
+	cancel  context.CancelFunc
+}
+
+func NewMemberClusterRegistry(kubeConfigPath string, newCluster ClusterFactory, listClusterNames ClusterNamesLister) *MemberClusterRegistry {
+	return &MemberClusterRegistry{
+		clusters:         map[string]*registeredCluster{},
+		kubeConfigPath:   kubeConfigPath,
+		newCluster:       newCluster,
+		listClusterNames: listClusterNames,
+	}
+}
+
+// Clusters returns the member clusters currently registered.
+func (r *MemberClusterRegistry) Clusters() map[string]cluster.Cluster {
+	clusters := map[string]cluster.Cluster{}
+	if r == nil {
+		return clusters
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for clusterName, c := range r.clusters {
+		clusters[clusterName] = c.cluster
+	}
+	return clusters
+}
+
+// Clients returns the clients of the member clusters currently registered.
+func (r *MemberClusterRegistry) Clients() map[string]client.Client {
+	return ClustersMapToClientMap(r.Clusters())
+}
+
+// KubeClients returns the clients of the member clusters currently registered.
+func (r *MemberClusterRegistry) KubeClients() map[string]kubernetesClient.Client {
+	clients := map[string]kubernetesClient.Client{}
+	for clusterName, c := range r.Clients() {
+		clients[clusterName] = kubernetesClient.NewClient(c)
+	}
+	return clients
+}
+
+// Version is incremented every time a member cluster is added, removed or reconnected.
+func (r *MemberClusterRegistry) Version() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version
+}
+
+// OnClusterAdded calls the handler for all the registered member clusters, and then for every member cluster added
+// later. The error of the handler is only returned for the member clusters already registered.
+func (r *MemberClusterRegistry) OnClusterAdded(handler MemberClusterHandler) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	r.handlers = append(r.handlers, handler)
+	r.mu.Unlock()
+
+	clusters := r.Clusters()
+	for _, clusterName := range slices.Sorted(maps.Keys(clusters)) {
+		if err := handler(clusterName, clusters[clusterName]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sync connects the operator to the given member clusters. The member clusters not in the list are removed, and the
+// member clusters whose connection details changed in the kubeconfig are reconnected.
+func (r *MemberClusterRegistry) Sync(clusterNames []string, log *zap.SugaredLogger) error {
+	var errs error
+	var added []string
+	changed := false
+
+	r.mu.Lock()
+	for clusterName, c := range r.clusters {
+		if !slices.Contains(clusterNames, clusterName) {
+			log.Infof("Removing member cluster %s", clusterName)
+			r.stop(c)
+			delete(r.clusters, clusterName)
+			changed = true
+		}
+	}
+
+	for _, clusterName := range clusterNames {
+		configs, err := CreateMemberClusterClients([]string{clusterName}, r.kubeConfigPath)
+		if err != nil {
+			// the member cluster is kept as it is, the kubeconfig may not have been updated yet
+			errs = errors.Join(errs, xerrors.Errorf("failed to read the kubeconfig of member cluster %s: %w", clusterName, err))
+			continue
+		}
+		config := configs[clusterName]
+
+		existing, ok := r.clusters[clusterName]
+		if ok && sameConnection(existing.config, config) {
+			continue
+		}
+
+		c, err := r.newCluster(clusterName, config)
+		if err != nil {
+			// don't fail here but rather log the error, for example, error might happen when one of the cluster is
+			// unreachable, we would still like the operator to continue reconciliation on the other clusters.
+			log.Errorf("Failed to initialize client for cluster: %s, err: %s", clusterName, err)
+			continue
+		}
+		if ok {
+			log.Infof("Reconnecting to member cluster %s, its kubeconfig has changed", clusterName)
+			r.stop(existing)
+		} else {
+			log.Infof("Adding cluster %s to cluster map.", clusterName)
+		}
+		registered := &registeredCluster{cluster: c, config: config}
+		r.clusters[clusterName] = registered
+		r.start(clusterName, registered, log)
+		added = append(added, clusterName)
+		changed = true
+	}
+
+	if changed {
+		r.version++
+	}
+	handlers := slices.Clone(r.handlers)
+	clusters := map[string]cluster.Cluster{}
+	for _, clusterName := range added {
+		clusters[clusterName] = r.clusters[clusterName].cluster
+	}
+	r.mu.Unlock()
+
+	// the handlers are called without holding the lock, they may read the registered clusters
+	for _, clusterName := range added {
+		for _, handler := range handlers {
+			if err := handler(clusterName, clusters[clusterName]); err != nil {
+				errs = errors.Join(errs, xerrors.Errorf("failed to set up member cluster %s: %w", clusterName, err))
+			}
+		}
+	}
+	return errs
+}
+
+// Start runs the caches of the member clusters, and synchronizes the member clusters with the member list until the
+// context is cancelled.
+func (r *MemberClusterRegistry) Start(ctx context.Context) error {
+	log := zap.S()
+	r.mu.Lock()
+	r.ctx = ctx
+	for clusterName, c := range r.clusters {
+		r.start(clusterName, c, log)
+	}
+	r.mu.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(memberListSyncInterval):
+		}
+		if r.listClusterNames == nil {
+			continue
+		}
+		clusterNames, err := r.listClusterNames(ctx)
+		if err != nil {
+			log.Errorf("Failed to read the member clusters, keeping the current ones: %s", err)
+			continue
+		}
+		if err := r.Sync(clusterNames, log); err != nil {
+			log.Errorf("Failed to synchronize the member clusters: %s", err)
+		}
+	}
+}
+
+// NeedLeaderElection returns false, the caches of the member clusters are needed by all the operator replicas.
+func (r *MemberClusterRegistry) NeedLeaderElection() bool {
+	return false
+}
+
+// start runs the cache of the member cluster if the registry is started. The lock must be held.
+func (r *MemberClusterRegistry) start(clusterName string, c *registeredCluster, log *zap.SugaredLogger) {
+	if r.ctx == nil || c.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(r.ctx)
+	c.cancel = cancel
+	go func() {
+		if err := c.cluster.Start(ctx); err != nil {
+			log.Errorf("Member cluster %s stopped: %s", clusterName, err)
+		}
+	}()
+}
+
+// stop stops the cache of the member cluster. The lock must be held.
+func (r *MemberClusterRegistry) stop(c *registeredCluster) {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// sameConnection returns true if both configs connect to the same API server with the same credentials.
+func sameConnection(a, b *restclient.Config) bool {
+	return a.Host == b.Host &&
+		a.BearerToken == b.BearerToken &&
+		a.BearerTokenFile == b.BearerTokenFile &&
+		a.Username == b.Username &&
+		a.Password == b.Password &&
+		reflect.DeepEqual(a.TLSClientConfig, b.TLSClientConfig) &&
+		reflect.DeepEqual(a.ExecProvider, b.ExecProvider)
+}
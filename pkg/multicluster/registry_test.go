@@ -0,0 +1,113 @@
+package multicluster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+func writeKubeConfig(t *testing.T, path string, servers map[string]string) {
+	str := "apiVersion: v1\nkind: Config\nclusters:\n"
+	for name, server := range servers {
+		str += fmt.Sprintf("- cluster:\n    server: %s\n  name: %s\n", server, name)
+	}
+	str += "contexts:\n"
+	for name := range servers {
+		str += fmt.Sprintf("- context:\n    cluster: %s\n    user: %s\n  name: %s\n", name, name, name)
+	}
+	str += "users:\n"
+	for name := range servers {
+		str += fmt.Sprintf("- name: %s\n  user:\n    token: token\n", name)
+	}
+	require.NoError(t, os.WriteFile(path, []byte(str), 0o600))
+}
+
+func newTestRegistry(t *testing.T, servers map[string]string) (*MemberClusterRegistry, string, *[]string) {
+	kubeConfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeConfig(t, kubeConfigPath, servers)
+
+	var created []string
+	registry := NewMemberClusterRegistry(kubeConfigPath, func(clusterName string, config *restclient.Config) (cluster.Cluster, error) {
+		created = append(created, clusterName)
+		return New(fake.NewClientBuilder().Build()), nil
+	}, nil)
+	return registry, kubeConfigPath, &created
+}
+
+func TestMemberClusterRegistry_AddsAndRemovesMemberClusters(t *testing.T) {
+	registry, _, created := newTestRegistry(t, map[string]string{
+		"cluster-1": "https://cluster-1",
+		"cluster-2": "https://cluster-2",
+	})
+
+	var added []string
+	require.NoError(t, registry.OnClusterAdded(func(clusterName string, _ cluster.Cluster) error {
+		added = append(added, clusterName)
+		return nil
+	}))
+
+	require.NoError(t, registry.Sync([]string{"cluster-1"}, zap.S()))
+	assert.Len(t, registry.Clients(), 1)
+	assert.Contains(t, registry.KubeClients(), "cluster-1")
+	assert.Equal(t, []string{"cluster-1"}, added)
+	assert.Equal(t, 1, registry.Version())
+
+	require.NoError(t, registry.Sync([]string{"cluster-1", "cluster-2"}, zap.S()))
+	assert.Len(t, registry.Clusters(), 2)
+	assert.Equal(t, []string{"cluster-1", "cluster-2"}, added)
+	assert.Equal(t, 2, registry.Version())
+
+	// nothing has changed, the clusters are not created again
+	require.NoError(t, registry.Sync([]string{"cluster-1", "cluster-2"}, zap.S()))
+	assert.Equal(t, []string{"cluster-1", "cluster-2"}, *created)
+	assert.Equal(t, 2, registry.Version())
+
+	require.NoError(t, registry.Sync([]string{"cluster-2"}, zap.S()))
+	assert.NotContains(t, registry.Clusters(), "cluster-1")
+	assert.Equal(t, 3, registry.Version())
+}
+
+func TestMemberClusterRegistry_ReconnectsWhenKubeConfigChanges(t *testing.T) {
+	registry, kubeConfigPath, created := newTestRegistry(t, map[string]string{"cluster-1": "https://cluster-1"})
+
+	require.NoError(t, registry.Sync([]string{"cluster-1"}, zap.S()))
+	before := registry.Clusters()["cluster-1"]
+
+	writeKubeConfig(t, kubeConfigPath, map[string]string{"cluster-1": "https://cluster-1-new"})
+	require.NoError(t, registry.Sync([]string{"cluster-1"}, zap.S()))
+
+	assert.Equal(t, []string{"cluster-1", "cluster-1"}, *created)
+	assert.NotSame(t, before, registry.Clusters()["cluster-1"])
+	assert.Equal(t, 2, registry.Version())
+}
+
+func TestMemberClusterRegistry_KeepsMemberClusterMissingFromKubeConfig(t *testing.T) {
+	registry, kubeConfigPath, _ := newTestRegistry(t, map[string]string{"cluster-1": "https://cluster-1"})
+	require.NoError(t, registry.Sync([]string{"cluster-1"}, zap.S()))
+
+	// the member list is updated before the kubeconfig
+	writeKubeConfig(t, kubeConfigPath, map[string]string{"cluster-2": "https://cluster-2"})
+	err := registry.Sync([]string{"cluster-1", "cluster-3"}, zap.S())
+
+	assert.Error(t, err)
+	assert.Contains(t, registry.Clusters(), "cluster-1")
+	assert.NotContains(t, registry.Clusters(), "cluster-3")
+}
+
+func TestMemberClusterRegistry_Nil(t *testing.T) {
+	var registry *MemberClusterRegistry
+
+	assert.Empty(t, registry.Clusters())
+	assert.Empty(t, registry.Clients())
+	assert.Equal(t, 0, registry.Version())
+	assert.NoError(t, registry.OnClusterAdded(func(string, cluster.Cluster) error { return nil }))
+}
@@ -0,0 +1,94 @@
+package statefulset
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+)
+
+// IsDeleted polls the StatefulSet until its deletion is reflected in Kubernetes, at most 3 times.
+func IsDeleted(ctx context.Context, memberClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet, sleepDuration time.Duration, log *zap.SugaredLogger) bool {
+	// After deleting the statefulset it can take seconds to be reflected in kubernetes.
+	// In case it is still not reflected
+	deletedIsStatefulset := false
+	for i := 0; i < 3; i++ {
+		time.Sleep(sleepDuration)
+		_, stsErr := memberClient.GetStatefulSet(ctx, kube.ObjectKey(desiredSts.Namespace, desiredSts.Name))
+		if apiErrors.IsNotFound(stsErr) {
+			deletedIsStatefulset = true
+			break
+		} else {
+			log.Info("Statefulset still exists, attempting again")
+		}
+	}
+	return deletedIsStatefulset
+}
+
+// HasFinishedResizingPVCs returns whether the capacity of all the PVCs of the StatefulSet matches the storage of their
+// volume claim template.
+func HasFinishedResizingPVCs(ctx context.Context, memberClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet) (bool, error) {
+	pvcList := corev1.PersistentVolumeClaimList{}
+	if err := memberClient.List(ctx, &pvcList, client.InNamespace(desiredSts.Namespace)); err != nil {
+		return false, err
+	}
+
+	finishedResizing := true
+	for _, currentPVC := range pvcList.Items {
+		if template, index := MatchingPVCTemplate(desiredSts, &currentPVC); template != nil {
+			if currentPVC.Status.Capacity.Storage().Cmp(*desiredSts.Spec.VolumeClaimTemplates[index].Spec.Resources.Requests.Storage()) != 0 {
+				finishedResizing = false
+			}
+		}
+	}
+	return finishedResizing, nil
+}
+
+// ResizePVCs takes the sts we want to create and update all matching pvc with the new storage
+func ResizePVCs(ctx context.Context, kubeClient kubernetesClient.Client, statefulSetToCreate *appsv1.StatefulSet, log *zap.SugaredLogger) error {
+	// this is to ensure that requests to a potentially not allowed resource is not blocking the operator until the end
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	pvcList := corev1.PersistentVolumeClaimList{}
+	if err := kubeClient.List(ctx, &pvcList, client.InNamespace(statefulSetToCreate.Namespace)); err != nil {
+		return err
+	}
+
+	for _, existingPVC := range pvcList.Items {
+		if template, _ := MatchingPVCTemplate(statefulSetToCreate, &existingPVC); template != nil {
+			currentSize := existingPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+			targetSize := *template.Spec.Resources.Requests.Storage()
+			log.Infof("Resizing PVC %s/%s from %s to %s", existingPVC.GetNamespace(), existingPVC.GetName(), currentSize.String(), targetSize.String())
+			existingPVC.Spec.Resources.Requests[corev1.ResourceStorage] = targetSize
+			if err := kubeClient.Update(ctx, &existingPVC); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MatchingPVCTemplate returns the volume claim template of the StatefulSet the PVC was created from, and its index.
+func MatchingPVCTemplate(statefulSet *appsv1.StatefulSet, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, int) {
+	for i, claimTemplate := range statefulSet.Spec.VolumeClaimTemplates {
+		expectedPrefix := fmt.Sprintf("%s-%s", claimTemplate.Name, statefulSet.Name)
+
+		// Regex to match expectedPrefix followed by a dash and a number (ordinal)
+		regexPattern := fmt.Sprintf("^%s-[0-9]+$", regexp.QuoteMeta(expectedPrefix))
+		if matched, _ := regexp.MatchString(regexPattern, pvc.Name); matched {
+			return &claimTemplate, i
+		}
+	}
+	return nil, -1
+}
@@ -32,6 +32,7 @@ const (
 
 	// Kinds
 	ClusterMongoDBRoleKind = "ClusterMongoDBRole"
+	MongoDBRoleKind        = "MongoDBRole"
 
 	// Ops manager config map and secret variables
 	OmBaseUrl         = "baseUrl"
@@ -217,6 +218,15 @@ const (
 
 	MaxConcurrentReconcilesEnv = "MDB_MAX_CONCURRENT_RECONCILES"
 
+	// CertificateExpiryWarningDaysEnv is the comma separated list of days before the expiry of a certificate at which
+	// the operator warns about it.
+	CertificateExpiryWarningDaysEnv = "MDB_CERTIFICATE_EXPIRY_WARNING_DAYS"
+
+	// StorageAutoscaleIntervalSecondsEnv is the interval at which the operator checks the volume usage of the
+	// resources with storage autoscaling.
+	StorageAutoscaleIntervalSecondsEnv     = "MDB_STORAGE_AUTOSCALE_INTERVAL_SECONDS"
+	StorageAutoscaleIntervalSecondsDefault = 300
+
 	// This default for the healthy streak is also configured in the values.yaml file.
 	// It should always be consistent with the default in the helm chart. Always change both.
 	DefaultRequiredHealthyStreak = 5
@@ -256,6 +266,10 @@ const (
 	S3SecretKey             = "secretKey"
 	DefaultS3MaxConnections = 50
 
+	// GCS and Azure Blob constants
+	GCSServiceAccountKey = "serviceAccountKey"
+	AzureAccountKey      = "accountKey"
+
 	// Ops Manager related constants
 	OmPropertyPrefix           = "OM_PROP_"
 	MmsJvmParamEnvVar          = "CUSTOM_JAVA_MMS_UI_OPTS"
@@ -315,6 +329,10 @@ const (
 	LastAchievedRsMemberIds = "mongodb.com/v1.lastAchievedRsMemberIds"
 	LastConfiguredRoles     = "mongodb.com/v1.lastConfiguredRoles"
 
+	// MigrationExternalMembers is set by users on a replica set to keep the comma separated Ops Manager processes
+	// managed outside of Kubernetes in the replica set while migrating it to Kubernetes
+	MigrationExternalMembers = "mongodb.com/v1.migrationExternalMembers"
+
 	// SecretVolumeName is the name of the volume resource.
 	SecretVolumeName = "secret-certs"
 
@@ -347,6 +365,8 @@ const (
 	UserFinalizer = "mongodb.com/v1.userRemovalFinalizer"
 
 	SearchMetricsForwarderFinalizer = "mongodb.com/v1.searchMongotHostsRemovalFinalizer"
+
+	VolumeSnapshotUnlockFinalizer = "mongodb.com/v1.volumeSnapshotUnlockFinalizer"
 )
 
 type OperatorEnvironment string
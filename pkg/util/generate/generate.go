@@ -74,3 +74,20 @@ func randSeq(n int) string {
 func GenerateRandomPassword() string {
 	return randSeq(10)
 }
+
+// RandomPassword returns a password of the given length, each character of which is picked uniformly at random
+// from 'characters'.
+func RandomPassword(length int, characters string) (string, error) {
+	charset := []rune(characters)
+	maxRand := big.NewInt(int64(len(charset)))
+
+	b := make([]rune, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, maxRand)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[n.Int64()]
+	}
+	return string(b), nil
+}
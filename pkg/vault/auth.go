@@ -0,0 +1,267 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+const (
+	KubernetesAuthMethod = "kubernetes"
+	AppRoleAuthMethod    = "approle"
+	JWTAuthMethod        = "jwt"
+
+	// DEFAULT_AUTH_ROLE is the name of the role in Vault that was created with the operator's service account bound to it
+	DEFAULT_AUTH_ROLE = "mongodbenterprise"
+	DEFAULT_JWT_PATH  = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint
+
+	// AppRoleSecretIDKey is the key of the AppRole secret ID in the secret referenced by VAULT_APPROLE_SECRET_ID_REF
+	AppRoleSecretIDKey = "secret-id" //nolint
+
+	loginRetryInterval = 10 * time.Second
+)
+
+// AuthConfiguration is the configuration used by the operator to log in to Vault, the empty fields fall back to
+// the Kubernetes auth method mounted at auth/kubernetes with the mongodbenterprise role.
+type AuthConfiguration struct {
+	Method             string
+	MountPath          string
+	Role               string
+	JWTPath            string
+	AppRoleRoleID      string
+	AppRoleSecretIDRef string
+}
+
+func (a AuthConfiguration) method() string {
+	if a.Method == "" {
+		return KubernetesAuthMethod
+	}
+	return a.Method
+}
+
+// mountPath returns the path the auth method is mounted at, which is the name of the method unless configured otherwise.
+func (a AuthConfiguration) mountPath() string {
+	if a.MountPath == "" {
+		return a.method()
+	}
+	return strings.Trim(strings.TrimPrefix(strings.Trim(a.MountPath, "/"), "auth/"), "/")
+}
+
+func (a AuthConfiguration) role() string {
+	if a.Role == "" {
+		return DEFAULT_AUTH_ROLE
+	}
+	return a.Role
+}
+
+func (a AuthConfiguration) jwtPath() string {
+	if a.JWTPath == "" {
+		return DEFAULT_JWT_PATH
+	}
+	return a.JWTPath
+}
+
+func (a AuthConfiguration) Validate() error {
+	switch a.method() {
+	case KubernetesAuthMethod, JWTAuthMethod:
+		return nil
+	case AppRoleAuthMethod:
+		if a.AppRoleRoleID == "" || a.AppRoleSecretIDRef == "" {
+			return xerrors.Errorf("%s and %s must be set to use the %s auth method", VAULT_APPROLE_ROLE_ID, VAULT_APPROLE_SECRET_ID_REF, AppRoleAuthMethod)
+		}
+		return nil
+	default:
+		return xerrors.Errorf("unsupported Vault auth method %q, must be one of %s, %s or %s", a.Method, KubernetesAuthMethod, AppRoleAuthMethod, JWTAuthMethod)
+	}
+}
+
+// AuthAnnotations configures the Vault Agent injected into the pods to use the same Vault namespace and auth mount
+// as the operator. The pods always use the Kubernetes auth method, so a custom mount is only propagated for it.
+func (v VaultConfiguration) AuthAnnotations() map[string]string {
+	annotations := map[string]string{}
+	if v.Namespace != "" {
+		annotations["vault.hashicorp.com/namespace"] = v.Namespace
+	}
+	if v.Auth.method() == KubernetesAuthMethod && v.Auth.mountPath() != KubernetesAuthMethod {
+		annotations["vault.hashicorp.com/auth-path"] = "auth/" + v.Auth.mountPath()
+	}
+	return annotations
+}
+
+// Login logs in to Vault with the configured auth method, the resulting token is used for all the future calls to Vault.
+func (v *VaultClient) Login(ctx context.Context) error {
+	params, err := v.loginParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	method := v.VaultConfig.Auth.method()
+	resp, err := v.client.Logical().WriteWithContext(ctx, "auth/"+v.VaultConfig.Auth.mountPath()+"/login", params)
+	if err != nil {
+		return xerrors.Errorf("unable to log in with %s auth: %w", method, err)
+	}
+
+	if resp == nil || resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return xerrors.Errorf("login response did not return client token")
+	}
+
+	v.setAuth(resp.Auth)
+	return nil
+}
+
+func (v *VaultClient) loginParams(ctx context.Context) (map[string]interface{}, error) {
+	auth := v.VaultConfig.Auth
+	if auth.method() == AppRoleAuthMethod {
+		secretID, err := v.readAppRoleSecretID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"role_id":   auth.AppRoleRoleID,
+			"secret_id": secretID,
+		}, nil
+	}
+
+	// Both the Kubernetes and the JWT auth methods log in with the service account token, which is rotated by the kubelet
+	jwt, err := os.ReadFile(auth.jwtPath())
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read file containing service account token: %w", err)
+	}
+	return map[string]interface{}{
+		"jwt":  strings.TrimSpace(string(jwt)),
+		"role": auth.role(),
+	}, nil
+}
+
+// readAppRoleSecretID reads the AppRole secret ID from the secret in the operator namespace, it is read on each login
+// so that the secret ID can be rotated without restarting the operator.
+func (v *VaultClient) readAppRoleSecretID(ctx context.Context) (string, error) {
+	secretName := v.VaultConfig.Auth.AppRoleSecretIDRef
+	s, err := v.kubeClient.CoreV1().Secrets(env.ReadOrPanic(util.CurrentNamespace)).Get(ctx, secretName, v1.GetOptions{}) // nolint:forbidigo
+	if err != nil {
+		return "", xerrors.Errorf("unable to read the AppRole secret ID from the secret %s: %w", secretName, err)
+	}
+	secretID, ok := s.Data[AppRoleSecretIDKey]
+	if !ok {
+		return "", xerrors.Errorf("the secret %s does not contain the key %s", secretName, AppRoleSecretIDKey)
+	}
+	return string(secretID), nil
+}
+
+func (v *VaultClient) setAuth(auth *api.SecretAuth) {
+	v.authLock.Lock()
+	defer v.authLock.Unlock()
+	v.auth = auth
+	v.client.SetToken(auth.ClientToken)
+}
+
+func (v *VaultClient) currentAuth() *api.SecretAuth {
+	v.authLock.Lock()
+	defer v.authLock.Unlock()
+	return v.auth
+}
+
+// withToken performs the request with the current token, logging in first if the operator hasn't logged in yet. The
+// request is retried once after logging in again if the token was rejected, e.g. because it expired or was revoked.
+func (v *VaultClient) withToken(request func() error) error {
+	ctx := context.Background()
+	if v.currentAuth() == nil {
+		if err := v.Login(ctx); err != nil {
+			return xerrors.Errorf("unable to log in: %w", err)
+		}
+	}
+
+	err := request()
+	var respErr *api.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusForbidden {
+		return err
+	}
+	if err := v.Login(ctx); err != nil {
+		return xerrors.Errorf("unable to log in: %w", err)
+	}
+	return request()
+}
+
+// RenewToken keeps the token of the operator valid until the context is cancelled. The token is renewed while Vault
+// allows it, and the operator logs in again once it can't be renewed anymore, e.g. when its max TTL is reached.
+func (v *VaultClient) RenewToken(ctx context.Context, log *zap.SugaredLogger) {
+	for {
+		auth := v.currentAuth()
+		if auth == nil {
+			if !v.loginUntilSucceeded(ctx, log) {
+				return
+			}
+			continue
+		}
+		if auth.LeaseDuration == 0 {
+			// the token never expires, e.g. the root token
+			return
+		}
+
+		watcher, err := v.client.NewLifetimeWatcher(&api.LifetimeWatcherInput{Secret: &api.Secret{Auth: auth}})
+		if err != nil {
+			log.Errorf("Failed to watch the lifetime of the Vault token: %s", err)
+			return
+		}
+		go watcher.Start()
+
+		if !v.watchToken(ctx, watcher, log) {
+			watcher.Stop()
+			return
+		}
+		watcher.Stop()
+		if !v.loginUntilSucceeded(ctx, log) {
+			return
+		}
+	}
+}
+
+// watchToken records the renewals of the token until it can't be renewed anymore, returns false if the context was
+// cancelled.
+func (v *VaultClient) watchToken(ctx context.Context, watcher *api.LifetimeWatcher, log *zap.SugaredLogger) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case err := <-watcher.DoneCh():
+			if err != nil {
+				log.Warnf("Failed to renew the Vault token: %s", err)
+			}
+			log.Debug("The Vault token can't be renewed anymore, logging in again")
+			return true
+		case renewal := <-watcher.RenewCh():
+			if renewal.Secret != nil && renewal.Secret.Auth != nil {
+				v.setAuth(renewal.Secret.Auth)
+			}
+			log.Debugf("Renewed the Vault token at %s", renewal.RenewedAt)
+		}
+	}
+}
+
+// loginUntilSucceeded logs in to Vault, retrying until it succeeds, returns false if the context was cancelled.
+func (v *VaultClient) loginUntilSucceeded(ctx context.Context, log *zap.SugaredLogger) bool {
+	for {
+		err := v.Login(ctx)
+		if err == nil {
+			return true
+		}
+		log.Errorf("Failed to log in to Vault, retrying in %s: %s", loginRetryInterval, err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(loginRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+// fakeVault is a Vault server accepting logins on a single auth mount and the reads of a single secret.
+type fakeVault struct {
+	loginPath   string
+	loginParams map[string]interface{}
+	logins      atomic.Int32
+	validToken  atomic.Value
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/" + f.loginPath:
+		_ = json.NewDecoder(r.Body).Decode(&f.loginParams)
+		token := fmt.Sprintf("token-%d", f.logins.Add(1))
+		f.validToken.Store(token)
+		_ = json.NewEncoder(w).Encode(api.Secret{Auth: &api.SecretAuth{ClientToken: token, LeaseDuration: 60, Renewable: true}})
+	case "/v1/secret/data/my-secret":
+		if r.Header.Get("X-Vault-Token") != f.validToken.Load() {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api.Secret{Data: map[string]interface{}{"data": map[string]interface{}{"password": "secret"}}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestVaultClient(t *testing.T, f *fakeVault, config VaultConfiguration) *VaultClient {
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+	return &VaultClient{client: client, VaultConfig: config}
+}
+
+func TestLogin_Kubernetes(t *testing.T) {
+	jwtPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(jwtPath, []byte("my-jwt\n"), 0o600))
+
+	f := &fakeVault{loginPath: "auth/cluster-a/login"}
+	v := newTestVaultClient(t, f, VaultConfiguration{Auth: AuthConfiguration{MountPath: "/auth/cluster-a/", Role: "my-role", JWTPath: jwtPath}})
+
+	require.NoError(t, v.Login(context.Background()))
+	assert.Equal(t, map[string]interface{}{"jwt": "my-jwt", "role": "my-role"}, f.loginParams)
+	assert.Equal(t, "token-1", v.client.Token())
+}
+
+func TestLogin_AppRole(t *testing.T) {
+	t.Setenv(util.CurrentNamespace, "operator-ns")
+	kubeClient := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "approle", Namespace: "operator-ns"},
+		Data:       map[string][]byte{AppRoleSecretIDKey: []byte("my-secret-id")},
+	})
+
+	f := &fakeVault{loginPath: "auth/approle/login"}
+	v := newTestVaultClient(t, f, VaultConfiguration{Auth: AuthConfiguration{Method: AppRoleAuthMethod, AppRoleRoleID: "my-role-id", AppRoleSecretIDRef: "approle"}})
+	v.kubeClient = kubeClient
+
+	require.NoError(t, v.Login(context.Background()))
+	assert.Equal(t, map[string]interface{}{"role_id": "my-role-id", "secret_id": "my-secret-id"}, f.loginParams)
+}
+
+func TestReadSecret_LogsInAgainWhenTheTokenIsRejected(t *testing.T) {
+	jwtPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(jwtPath, []byte("my-jwt"), 0o600))
+
+	f := &fakeVault{loginPath: "auth/kubernetes/login"}
+	v := newTestVaultClient(t, f, VaultConfiguration{Auth: AuthConfiguration{JWTPath: jwtPath}})
+
+	_, err := v.ReadSecret("secret/data/my-secret")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), f.logins.Load())
+
+	// the token is reused until it is rejected, e.g. once it expired
+	_, err = v.ReadSecret("secret/data/my-secret")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), f.logins.Load())
+
+	f.validToken.Store("revoked")
+	secret, err := v.ReadSecret("secret/data/my-secret")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), f.logins.Load())
+	assert.Equal(t, map[string]interface{}{"password": "secret"}, secret.Data["data"])
+}
+
+func TestAuthConfiguration_Validate(t *testing.T) {
+	assert.NoError(t, AuthConfiguration{}.Validate())
+	assert.NoError(t, AuthConfiguration{Method: JWTAuthMethod}.Validate())
+	assert.NoError(t, AuthConfiguration{Method: AppRoleAuthMethod, AppRoleRoleID: "role-id", AppRoleSecretIDRef: "approle"}.Validate())
+	assert.Error(t, AuthConfiguration{Method: AppRoleAuthMethod, AppRoleRoleID: "role-id"}.Validate())
+	assert.Error(t, AuthConfiguration{Method: "userpass"}.Validate())
+}
+
+func TestAuthAnnotations(t *testing.T) {
+	assert.Empty(t, VaultConfiguration{}.AuthAnnotations())
+	assert.Equal(t, map[string]string{
+		"vault.hashicorp.com/namespace": "team-a",
+		"vault.hashicorp.com/auth-path": "auth/cluster-a",
+	}, VaultConfiguration{Namespace: "team-a", Auth: AuthConfiguration{MountPath: "cluster-a"}}.AuthAnnotations())
+
+	// the pods don't use the AppRole auth method of the operator
+	assert.Equal(t, map[string]string{"vault.hashicorp.com/namespace": "team-a"},
+		VaultConfiguration{Namespace: "team-a", Auth: AuthConfiguration{Method: AppRoleAuthMethod, MountPath: "approle-a"}}.AuthAnnotations())
+}
@@ -0,0 +1,157 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/vault/api"
+	"golang.org/x/xerrors"
+)
+
+// kvWriteEventTypes are the events notified by Vault when a KV v2 secret is written, patched or rolled back.
+const kvWriteEventTypes = "kv-v2/data-*"
+
+// SecretEvent is the change of a KV v2 secret notified by Vault. Path is the metadata path of the secret, in the
+// format returned by the *SecretMetadataPath functions.
+type SecretEvent struct {
+	Path    string
+	Version int
+}
+
+// secretEventMessage is the part of the CloudEvents notified by Vault which describes the secret that changed.
+type secretEventMessage struct {
+	Data struct {
+		Event struct {
+			Metadata struct {
+				Path           string `json:"path"`
+				CurrentVersion string `json:"current_version"`
+			} `json:"metadata"`
+		} `json:"event"`
+	} `json:"data"`
+}
+
+// ListSecrets returns the names of the secrets stored under the metadata path, the result is empty if the path
+// doesn't contain any secret.
+func (v *VaultClient) ListSecrets(metadataPath string) ([]string, error) {
+	var secret *api.Secret
+	err := v.withToken(func() error {
+		var err error
+		secret, err = v.client.Logical().List(metadataPath)
+		return err
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("can't list secrets in %s: %w", metadataPath, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+
+	keys, _ := secret.Data["keys"].([]interface{})
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if name, ok := key.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// SecretEventSubscription receives the changes of the KV v2 secrets notified by Vault.
+type SecretEventSubscription struct {
+	conn *websocket.Conn
+}
+
+// SubscribeToSecretEvents subscribes to the changes of the KV v2 secrets. The event notifications are only available
+// in Vault Enterprise, an error is returned if the subscription is not possible.
+func (v *VaultClient) SubscribeToSecretEvents(ctx context.Context) (*SecretEventSubscription, error) {
+	var conn *websocket.Conn
+	err := v.withToken(func() error {
+		var err error
+		conn, err = v.subscribe(ctx, kvWriteEventTypes)
+		return err
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("can't subscribe to the Vault events %s: %w", kvWriteEventTypes, err)
+	}
+	return &SecretEventSubscription{conn: conn}, nil
+}
+
+// Next blocks until the next change of a secret is notified, an error is returned once the subscription ends.
+func (s *SecretEventSubscription) Next() (SecretEvent, error) {
+	for {
+		_, message, err := s.conn.ReadMessage()
+		if err != nil {
+			return SecretEvent{}, xerrors.Errorf("the subscription to the Vault events ended: %w", err)
+		}
+		if event, ok := parseSecretEvent(message); ok {
+			return event, nil
+		}
+	}
+}
+
+// Close ends the subscription, which unblocks Next.
+func (s *SecretEventSubscription) Close() error {
+	return s.conn.Close()
+}
+
+// subscribe opens the websocket the events of the given types are sent to. Failed handshakes are returned as
+// api.ResponseError, so that a rejected token is handled the same way as for the other requests.
+func (v *VaultClient) subscribe(ctx context.Context, eventTypes string) (*websocket.Conn, error) {
+	address, err := url.Parse(v.client.Address())
+	if err != nil {
+		return nil, err
+	}
+	switch address.Scheme {
+	case "https":
+		address.Scheme = "wss"
+	default:
+		address.Scheme = "ws"
+	}
+	address.Path = strings.TrimSuffix(address.Path, "/") + "/v1/sys/events/subscribe/" + eventTypes
+	address.RawQuery = url.Values{"json": []string{"true"}}.Encode()
+
+	headers := http.Header{}
+	headers.Set(api.AuthHeaderName, v.client.Token())
+	if namespace := v.client.Namespace(); namespace != "" {
+		headers.Set(api.NamespaceHeaderName, namespace)
+	}
+
+	dialer := *websocket.DefaultDialer
+	if transport, ok := v.client.CloneConfig().HttpClient.Transport.(*http.Transport); ok {
+		dialer.TLSClientConfig = transport.TLSClientConfig
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, address.String(), headers)
+	if err != nil {
+		if resp != nil {
+			return nil, &api.ResponseError{HTTPMethod: http.MethodGet, URL: address.String(), StatusCode: resp.StatusCode}
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// parseSecretEvent returns the secret described by the event, which path is converted to the metadata path of the
+// secret.
+func parseSecretEvent(message []byte) (SecretEvent, bool) {
+	var m secretEventMessage
+	if err := json.Unmarshal(message, &m); err != nil {
+		return SecretEvent{}, false
+	}
+	metadata := m.Data.Event.Metadata
+
+	mount, secretPath, found := strings.Cut(strings.TrimPrefix(metadata.Path, "/"), "/data/")
+	if !found {
+		return SecretEvent{}, false
+	}
+	version, err := strconv.Atoi(metadata.CurrentVersion)
+	if err != nil {
+		return SecretEvent{}, false
+	}
+	return SecretEvent{Path: "/" + mount + "/metadata/" + secretPath, Version: version}, true
+}
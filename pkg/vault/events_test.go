@@ -0,0 +1,35 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecretEvent(t *testing.T) {
+	event, ok := parseSecretEvent([]byte(`{
+		"id": "a3be9fb1-b514-519f-5b25-b6f144a8c1ce",
+		"source": "vault://vault-0",
+		"data": {
+			"event": {
+				"id": "a3be9fb1-b514-519f-5b25-b6f144a8c1ce",
+				"metadata": {
+					"current_version": "3",
+					"data_path": "secret/data/mongodbenterprise/database/my-namespace/my-rs-cert",
+					"modified": "true",
+					"oldest_version": "0",
+					"operation": "data-write",
+					"path": "secret/data/mongodbenterprise/database/my-namespace/my-rs-cert"
+				}
+			},
+			"event_type": "kv-v2/data-write",
+			"plugin_info": {"mount_path": "secret/", "plugin": "kv"}
+		},
+		"datacontentype": "application/cloudevents"
+	}`))
+	assert.True(t, ok)
+	assert.Equal(t, SecretEvent{Path: "/secret/metadata/mongodbenterprise/database/my-namespace/my-rs-cert", Version: 3}, event)
+
+	_, ok = parseSecretEvent([]byte(`{"data": {"event": {"metadata": {"path": "sys/policy/my-policy"}}}}`))
+	assert.False(t, ok)
+}
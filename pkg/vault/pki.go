@@ -0,0 +1,48 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"golang.org/x/xerrors"
+)
+
+// IssuedCertificate is a certificate issued by a role of the Vault PKI secrets engine, in PEM format.
+type IssuedCertificate struct {
+	Certificate string
+	PrivateKey  string
+	IssuingCA   string
+	CAChain     []string
+}
+
+// IssueCertificate issues a new certificate from the PKI role mounted at mount. The parameters are the ones of the
+// pki/issue/:name endpoint, e.g. common_name, alt_names and ttl.
+func (v *VaultClient) IssueCertificate(mount, role string, params map[string]interface{}) (IssuedCertificate, error) {
+	path := fmt.Sprintf("%s/issue/%s", strings.Trim(mount, "/"), role)
+
+	var secret *api.Secret
+	err := v.withToken(func() error {
+		var err error
+		secret, err = v.client.Logical().Write(path, params)
+		return err
+	})
+	if err != nil {
+		return IssuedCertificate{}, xerrors.Errorf("can't issue certificate from %s: %w", path, err)
+	}
+	if secret == nil || secret.Data["certificate"] == nil || secret.Data["private_key"] == nil {
+		return IssuedCertificate{}, xerrors.Errorf("no certificate returned by %s", path)
+	}
+
+	issued := IssuedCertificate{
+		Certificate: fmt.Sprintf("%v", secret.Data["certificate"]),
+		PrivateKey:  fmt.Sprintf("%v", secret.Data["private_key"]),
+		IssuingCA:   fmt.Sprintf("%v", secret.Data["issuing_ca"]),
+	}
+	if chain, ok := secret.Data["ca_chain"].([]interface{}); ok {
+		for _, ca := range chain {
+			issued.CAChain = append(issued.CAChain, fmt.Sprintf("%v", ca))
+		}
+	}
+	return issued, nil
+}
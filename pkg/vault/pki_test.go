@@ -0,0 +1,36 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueCertificate(t *testing.T) {
+	var issueParams map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/pki-cluster-a/issue/mongodb", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&issueParams)
+		_ = json.NewEncoder(w).Encode(api.Secret{Data: map[string]interface{}{
+			"certificate": "cert",
+			"private_key": "key",
+			"issuing_ca":  "ca",
+			"ca_chain":    []string{"ca", "root"},
+		}})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+	v := &VaultClient{client: client, auth: &api.SecretAuth{ClientToken: "token"}}
+
+	issued, err := v.IssueCertificate("/pki-cluster-a/", "mongodb", map[string]interface{}{"common_name": "my-rs-0", "ttl": "720h"})
+	require.NoError(t, err)
+	assert.Equal(t, IssuedCertificate{Certificate: "cert", PrivateKey: "key", IssuingCA: "ca", CAChain: []string{"ca", "root"}}, issued)
+	assert.Equal(t, map[string]interface{}{"common_name": "my-rs-0", "ttl": "720h"}, issueParams)
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/vault/api"
 	"golang.org/x/xerrors"
@@ -43,6 +44,13 @@ const (
 	OPS_MANAGER_SECRET_BASE_PATH = "OPS_MANAGER_SECRET_BASE_PATH" //nolint
 	DATABASE_SECRET_BASE_PATH    = "DATABASE_SECRET_BASE_PATH"    //nolint
 	APPDB_SECRET_BASE_PATH       = "APPDB_SECRET_BASE_PATH"       //nolint
+	VAULT_AUTH_METHOD            = "VAULT_AUTH_METHOD"
+	VAULT_AUTH_MOUNT_PATH        = "VAULT_AUTH_MOUNT_PATH"
+	VAULT_AUTH_ROLE              = "VAULT_AUTH_ROLE"
+	VAULT_NAMESPACE              = "VAULT_NAMESPACE"
+	VAULT_JWT_PATH               = "VAULT_JWT_PATH"
+	VAULT_APPROLE_ROLE_ID        = "VAULT_APPROLE_ROLE_ID"
+	VAULT_APPROLE_SECRET_ID_REF  = "VAULT_APPROLE_SECRET_ID_REF" //nolint
 
 	DEFAULT_AGENT_INJECT_TEMPLATE = `{{- with secret "%s" -}}
           {{ index .Data.data "%s" }}
@@ -103,11 +111,19 @@ type VaultConfiguration struct {
 	AppDBSecretPath      string
 	VaultAddress         string
 	TLSSecretRef         string
+	Namespace            string
+	Auth                 AuthConfiguration
 }
 
 type VaultClient struct {
 	client      *api.Client
 	VaultConfig VaultConfiguration
+
+	// kubeClient reads the AppRole secret ID on every login, as it can be rotated
+	kubeClient kubernetes.Interface
+
+	authLock sync.Mutex
+	auth     *api.SecretAuth
 }
 
 func readVaultConfig(ctx context.Context, client *kubernetes.Clientset) VaultConfiguration {
@@ -122,6 +138,15 @@ func readVaultConfig(ctx context.Context, client *kubernetes.Clientset) VaultCon
 		OpsManagerSecretPath: cm.Data[OPS_MANAGER_SECRET_BASE_PATH],
 		DatabaseSecretPath:   cm.Data[DATABASE_SECRET_BASE_PATH],
 		AppDBSecretPath:      cm.Data[APPDB_SECRET_BASE_PATH],
+		Namespace:            cm.Data[VAULT_NAMESPACE],
+		Auth: AuthConfiguration{
+			Method:             cm.Data[VAULT_AUTH_METHOD],
+			MountPath:          cm.Data[VAULT_AUTH_MOUNT_PATH],
+			Role:               cm.Data[VAULT_AUTH_ROLE],
+			JWTPath:            cm.Data[VAULT_JWT_PATH],
+			AppRoleRoleID:      cm.Data[VAULT_APPROLE_ROLE_ID],
+			AppRoleSecretIDRef: cm.Data[VAULT_APPROLE_SECRET_ID_REF],
+		},
 	}
 
 	if tlsRef, ok := cm.Data[TLS_SECRET_REF]; ok {
@@ -169,6 +194,9 @@ func setTLSConfig(ctx context.Context, config *api.Config, client *kubernetes.Cl
 
 func InitVaultClient(ctx context.Context, client *kubernetes.Clientset) (*VaultClient, error) {
 	vaultConfig := readVaultConfig(ctx, client)
+	if err := vaultConfig.Auth.Validate(); err != nil {
+		return nil, err
+	}
 
 	config := api.DefaultConfig()
 	config.Address = vaultConfig.VaultAddress
@@ -181,46 +209,28 @@ func InitVaultClient(ctx context.Context, client *kubernetes.Clientset) (*VaultC
 	if err != nil {
 		return nil, err
 	}
-
-	return &VaultClient{client: vclient, VaultConfig: vaultConfig}, nil
-}
-
-func (v *VaultClient) Login() error {
-	// Read the service-account token from the path where the token's Kubernetes Secret is mounted.
-	jwt, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/token")
-	if err != nil {
-		return xerrors.Errorf("unable to read file containing service account token: %w", err)
-	}
-
-	params := map[string]interface{}{
-		"jwt":  string(jwt),
-		"role": "mongodbenterprise", // the name of the role in Vault that was created with this app's Kubernetes service account bound to it
-	}
-
-	// log in to Vault's Kubernetes auth method
-	resp, err := v.client.Logical().Write("auth/kubernetes/login", params)
-	if err != nil {
-		return xerrors.Errorf("unable to log in with Kubernetes auth: %w", err)
-	}
-
-	if resp == nil || resp.Auth == nil || resp.Auth.ClientToken == "" {
-		return xerrors.Errorf("login response did not return client token")
+	if vaultConfig.Namespace != "" {
+		vclient.SetNamespace(vaultConfig.Namespace)
 	}
 
-	// will use the resulting Vault token for making all future calls to Vault
-	v.client.SetToken(resp.Auth.ClientToken)
-	return nil
+	return &VaultClient{client: vclient, VaultConfig: vaultConfig, kubeClient: client}, nil
 }
 
 func (v *VaultClient) PutSecret(path string, data map[string]interface{}) error {
-	if err := v.Login(); err != nil {
-		return xerrors.Errorf("unable to log in: %w", err)
-	}
-	_, err := v.client.Logical().Write(path, data)
-	if err != nil {
+	return v.withToken(func() error {
+		_, err := v.client.Logical().Write(path, data)
 		return err
-	}
-	return nil
+	})
+}
+
+// DeleteSecret deletes all the versions and the metadata of the KV v2 secret, path is the data path of the secret.
+// Deleting a secret which doesn't exist is not an error.
+func (v *VaultClient) DeleteSecret(path string) error {
+	metadataPath := strings.Replace(path, "/data/", "/metadata/", 1)
+	return v.withToken(func() error {
+		_, err := v.client.Logical().Delete(metadataPath)
+		return err
+	})
 }
 
 func (v *VaultClient) ReadSecretVersion(path string) (int, error) {
@@ -238,10 +248,12 @@ func (v *VaultClient) ReadSecretVersion(path string) (int, error) {
 }
 
 func (v *VaultClient) ReadSecret(path string) (*api.Secret, error) {
-	if err := v.Login(); err != nil {
-		return nil, xerrors.Errorf("unable to log in: %w", err)
-	}
-	secret, err := v.client.Logical().Read(path)
+	var secret *api.Secret
+	err := v.withToken(func() error {
+		var err error
+		secret, err = v.client.Logical().Read(path)
+		return err
+	})
 	if err != nil {
 		return nil, xerrors.Errorf("can't read secret from vault: %w", err)
 	}
@@ -358,6 +370,7 @@ func (s OpsManagerSecretsToInject) OpsManagerAnnotations(namespace string) map[s
 	}
 
 	annotations = merge.StringToStringMap(annotations, s.Config.TLSAnnotations())
+	annotations = merge.StringToStringMap(annotations, s.Config.AuthAnnotations())
 
 	if s.TLSSecretName != "" {
 		omTLSPath := fmt.Sprintf("%s/%s/%s", opsManagerSecretPath, namespace, s.TLSSecretName)
@@ -422,6 +435,7 @@ func (s DatabaseSecretsToInject) DatabaseAnnotations(namespace string) map[strin
 	}
 
 	annotations = merge.StringToStringMap(annotations, s.Config.TLSAnnotations())
+	annotations = merge.StringToStringMap(annotations, s.Config.AuthAnnotations())
 
 	if s.AgentCerts != "" {
 		agentCertsPath := fmt.Sprintf("%s/%s/%s", databaseSecretPath, namespace, s.AgentCerts)
@@ -518,6 +532,7 @@ func (a AppDBSecretsToInject) AppDBAnnotations(namespace string) map[string]stri
 	}
 
 	annotations = merge.StringToStringMap(annotations, a.Config.TLSAnnotations())
+	annotations = merge.StringToStringMap(annotations, a.Config.AuthAnnotations())
 	var appdbSecretPath string
 	if a.Config.AppDBSecretPath != "" {
 		appdbSecretPath = fmt.Sprintf("/secret/data/%s", a.Config.AppDBSecretPath)
@@ -0,0 +1,29 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteSecret(t *testing.T) {
+	var method, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+	v := &VaultClient{client: client, auth: &api.SecretAuth{ClientToken: "token"}}
+
+	// all the versions are deleted through the metadata of the secret
+	require.NoError(t, v.DeleteSecret("/secret/data/mongodbenterprise/database/my-namespace/my-rs-agent-api-key"))
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/v1/secret/metadata/mongodbenterprise/database/my-namespace/my-rs-agent-api-key", path)
+}
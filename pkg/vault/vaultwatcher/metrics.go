@@ -0,0 +1,50 @@
+package vaultwatcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const VaultWatcherSubsystem = "mongodb_operator_vault_watcher"
+
+var (
+	vaultRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: VaultWatcherSubsystem,
+		Name:      "requests_total",
+		Help:      "Number of requests made to Vault to detect the changes of the secrets, partitioned by watcher, operation and result.",
+	}, []string{"watcher", "operation", "result"})
+
+	secretEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: VaultWatcherSubsystem,
+		Name:      "events_total",
+		Help:      "Number of secret changes notified by the Vault event subscription, partitioned by watcher.",
+	}, []string{"watcher"})
+
+	triggeredReconciliations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: VaultWatcherSubsystem,
+		Name:      "reconciliations_total",
+		Help:      "Number of reconciliations triggered by a new version of a secret, partitioned by watcher.",
+	}, []string{"watcher"})
+
+	watchedSecrets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: VaultWatcherSubsystem,
+		Name:      "watched_secrets",
+		Help:      "Number of distinct secrets watched, partitioned by watcher.",
+	}, []string{"watcher"})
+
+	backedOffPrefixes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: VaultWatcherSubsystem,
+		Name:      "backed_off_prefixes",
+		Help:      "Number of Vault paths polled less frequently, as the versions of their secrets didn't change recently or can't be read, partitioned by watcher.",
+	}, []string{"watcher"})
+
+	eventSubscription = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: VaultWatcherSubsystem,
+		Name:      "event_subscription",
+		Help:      "Whether the watcher receives the secret changes from the Vault event subscription (1) or polls Vault (0), partitioned by watcher.",
+	}, []string{"watcher"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(vaultRequests, secretEvents, triggeredReconciliations, watchedSecrets, backedOffPrefixes, eventSubscription)
+}
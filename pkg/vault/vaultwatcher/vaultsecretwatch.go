@@ -3,7 +3,9 @@ package vaultwatcher
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -13,98 +15,403 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/om"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
 )
 
+const (
+	// pollInterval is the interval at which the resources are checked, the secrets of a prefix where a version just
+	// changed are read at this interval when the Vault event notifications are not available.
+	pollInterval = 10 * time.Second
+	// maxPollInterval is the maximum interval at which the secrets of a prefix where no version changes are read.
+	maxPollInterval = 2 * time.Minute
+	// maxBackoff is the maximum interval at which the secrets of a prefix which can't be read are retried.
+	maxBackoff = 5 * time.Minute
+	// resyncInterval is the interval at which the versions of all the secrets are read while subscribed to the Vault
+	// events, in case an event was missed.
+	resyncInterval = 10 * time.Minute
+	// subscribeRetryInterval is the interval at which the subscription to the Vault events is retried.
+	subscribeRetryInterval = 5 * time.Minute
+)
+
+// watchedSecret is a Vault secret mounted into the pods of a resource, identified by the metadata path of the
+// namespace it is stored in and its name.
+type watchedSecret struct {
+	prefix string
+	name   string
+}
+
+func (s watchedSecret) path() string {
+	return fmt.Sprintf("%s/%s", s.prefix, s.name)
+}
+
+// watchedResource is a resource which is reconciled when one of its secrets has a newer version than the one
+// recorded in its annotations, keyed by the secret name.
+type watchedResource struct {
+	object      client.Object
+	annotations map[string]string
+	secrets     []watchedSecret
+}
+
+// secretVersionReader lists the secrets and reads their versions from their metadata in Vault, it is implemented by
+// vault.VaultClient.
+type secretVersionReader interface {
+	ListSecrets(metadataPath string) ([]string, error)
+	ReadSecretVersion(path string) (int, error)
+}
+
+// secretEventSubscription receives the changes of the secrets, it is implemented by vault.SecretEventSubscription.
+type secretEventSubscription interface {
+	Next() (vault.SecretEvent, error)
+	Close() error
+}
+
+// prefixPoll is the schedule at which the secrets stored under a prefix are read.
+type prefixPoll struct {
+	interval time.Duration
+	failures int
+	nextPoll time.Time
+}
+
+// secretWatcher detects the new versions of the secrets mounted into the pods of the resources. The secrets shared by
+// several resources are read once, and the secrets stored under the same prefix are read together: the prefix is
+// listed once per poll, so that only the versions of the existing secrets are read. Each prefix is polled at its own
+// interval, which grows while none of its versions change and backs off while its secrets can't be read. When the
+// Vault event notifications are available, the versions are updated from the events instead of being read.
+type secretWatcher struct {
+	name      string
+	reader    secretVersionReader
+	subscribe func(ctx context.Context) (secretEventSubscription, error)
+	log       *zap.SugaredLogger
+
+	// versions contains the latest version of the watched secrets, 0 for the ones which don't exist
+	versions map[string]int
+	// polls contains the schedule of the watched prefixes
+	polls  map[string]*prefixPoll
+	events chan vault.SecretEvent
+
+	subscribed      atomic.Bool
+	resyncRequested atomic.Bool
+	lastRead        time.Time
+}
+
+func newSecretWatcher(name string, vaultClient *vault.VaultClient, log *zap.SugaredLogger) *secretWatcher {
+	return &secretWatcher{
+		name:   name,
+		reader: vaultClient,
+		subscribe: func(ctx context.Context) (secretEventSubscription, error) {
+			return vaultClient.SubscribeToSecretEvents(ctx)
+		},
+		log:      log,
+		versions: map[string]int{},
+		polls:    map[string]*prefixPoll{},
+		events:   make(chan vault.SecretEvent),
+	}
+}
+
 func WatchSecretChangeForMDB(ctx context.Context, log *zap.SugaredLogger, watchChannel chan event.GenericEvent, k8sClient kubernetesClient.Client, vaultClient *vault.VaultClient, resourceType mdbv1.ResourceType) {
-	for {
+	watcher := newSecretWatcher(string(resourceType), vaultClient, log)
+	watcher.run(ctx, watchChannel, func(ctx context.Context) []watchedResource {
 		mdbList := &mdbv1.MongoDBList{}
-		err := k8sClient.List(ctx, mdbList, &client.ListOptions{Namespace: ""})
-		if err != nil {
+		if err := k8sClient.List(ctx, mdbList, &client.ListOptions{Namespace: ""}); err != nil {
 			log.Errorf("failed to fetch MongoDBList from Kubernetes: %s", err)
+			return nil
 		}
 
+		var resources []watchedResource
 		for n, mdb := range mdbList.Items {
 			// check if we care about the resource type, if not return early
 			if mdb.Spec.ResourceType != resourceType {
 				continue
 			}
 			// the credentials secret is mandatory and stored in a different path
-			path := fmt.Sprintf("%s/%s/%s", vaultClient.OperatorScretMetadataPath(), mdb.Namespace, mdb.Spec.Credentials)
-			latestResourceVersion, currentResourceVersion := getCurrentAndLatestVersion(vaultClient, path, mdb.Spec.Credentials, mdb.Annotations, log)
-			if latestResourceVersion > currentResourceVersion {
-				watchChannel <- event.GenericEvent{Object: &mdbList.Items[n]}
-				break
-			}
-
+			secrets := []watchedSecret{{prefix: fmt.Sprintf("%s/%s", vaultClient.OperatorScretMetadataPath(), mdb.Namespace), name: mdb.Spec.Credentials}}
 			for _, secretName := range mdb.GetSecretsMountedIntoDBPod() {
-				path := fmt.Sprintf("%s/%s/%s", vaultClient.DatabaseSecretMetadataPath(), mdb.Namespace, secretName)
-				latestResourceVersion, currentResourceVersion := getCurrentAndLatestVersion(vaultClient, path, secretName, mdb.Annotations, log)
-
-				if latestResourceVersion > currentResourceVersion {
-					watchChannel <- event.GenericEvent{Object: &mdbList.Items[n]}
-					break
-				}
+				secrets = append(secrets, watchedSecret{prefix: fmt.Sprintf("%s/%s", vaultClient.DatabaseSecretMetadataPath(), mdb.Namespace), name: secretName})
 			}
+			resources = append(resources, watchedResource{object: &mdbList.Items[n], annotations: mdb.Annotations, secrets: secrets})
 		}
-
-		time.Sleep(10 * time.Second)
-	}
+		return resources
+	})
 }
 
 func WatchSecretChangeForOM(ctx context.Context, log *zap.SugaredLogger, watchChannel chan event.GenericEvent, k8sClient kubernetesClient.Client, vaultClient *vault.VaultClient) {
-	for {
+	watcher := newSecretWatcher("MongoDBOpsManager", vaultClient, log)
+	watcher.run(ctx, watchChannel, func(ctx context.Context) []watchedResource {
 		omList := &omv1.MongoDBOpsManagerList{}
-		err := k8sClient.List(ctx, omList, &client.ListOptions{Namespace: ""})
-		if err != nil {
+		if err := k8sClient.List(ctx, omList, &client.ListOptions{Namespace: ""}); err != nil {
 			log.Errorf("failed to fetch MongoDBOpsManagerList from Kubernetes: %s", err)
+			return nil
 		}
 
-		triggeredReconciliation := false
+		var resources []watchedResource
 		for n, om := range omList.Items {
+			var secrets []watchedSecret
 			for _, secretName := range om.GetSecretsMountedIntoPod() {
-				path := fmt.Sprintf("%s/%s/%s", vaultClient.OpsManagerSecretMetadataPath(), om.Namespace, secretName)
-				latestResourceVersion, currentResourceVersion := getCurrentAndLatestVersion(vaultClient, path, secretName, om.Annotations, log)
-
-				if latestResourceVersion > currentResourceVersion {
-					watchChannel <- event.GenericEvent{Object: &omList.Items[n]}
-					triggeredReconciliation = true
-					break
-				}
-			}
-			if triggeredReconciliation {
-				break
+				secrets = append(secrets, watchedSecret{prefix: fmt.Sprintf("%s/%s", vaultClient.OpsManagerSecretMetadataPath(), om.Namespace), name: secretName})
 			}
 			for _, secretName := range om.Spec.AppDB.GetSecretsMountedIntoPod() {
-				path := fmt.Sprintf("%s/%s/%s", vaultClient.AppDBSecretMetadataPath(), om.Namespace, secretName)
-				latestResourceVersion, currentResourceVersion := getCurrentAndLatestVersion(vaultClient, path, secretName, om.Annotations, log)
+				secrets = append(secrets, watchedSecret{prefix: fmt.Sprintf("%s/%s", vaultClient.AppDBSecretMetadataPath(), om.Namespace), name: secretName})
+			}
+			resources = append(resources, watchedResource{object: &omList.Items[n], annotations: om.Annotations, secrets: secrets})
+		}
+		return resources
+	})
+}
+
+// run triggers the reconciliation of the resources returned by listResources when one of their secrets changes,
+// until the context is cancelled.
+func (w *secretWatcher) run(ctx context.Context, watchChannel chan event.GenericEvent, listResources func(ctx context.Context) []watchedResource) {
+	go w.receiveEvents(ctx)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		resources := listResources(ctx)
+		w.refreshVersions(resources, time.Now())
+		for _, object := range w.changedResources(resources) {
+			triggeredReconciliations.WithLabelValues(w.name).Inc()
+			select {
+			case watchChannel <- event.GenericEvent{Object: object}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case e := <-w.events:
+			w.recordEvent(e)
+		}
+	}
+}
 
-				if latestResourceVersion > currentResourceVersion {
-					watchChannel <- event.GenericEvent{Object: &omList.Items[n]}
-					break
-				}
+// receiveEvents keeps the watcher subscribed to the Vault events when they are available, and falls back to polling
+// while they aren't.
+func (w *secretWatcher) receiveEvents(ctx context.Context) {
+	defer eventSubscription.WithLabelValues(w.name).Set(0)
+	for {
+		subscription, err := w.subscribe(ctx)
+		if err != nil {
+			w.log.Debugf("Polling the metadata of the Vault secrets, the Vault event notifications are not available: %s", err)
+		} else {
+			w.log.Infof("Subscribed to the Vault event notifications, the %s secrets are no longer polled", w.name)
+			w.setSubscribed(true)
+			err = w.forwardEvents(ctx, subscription)
+			_ = subscription.Close()
+			w.setSubscribed(false)
+			if ctx.Err() != nil {
+				return
 			}
+			w.log.Warnf("Polling the metadata of the Vault secrets: %s", err)
 		}
 
-		time.Sleep(10 * time.Second)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(subscribeRetryInterval):
+		}
+	}
+}
+
+func (w *secretWatcher) forwardEvents(ctx context.Context, subscription secretEventSubscription) error {
+	// closing the subscription unblocks Next once the context is cancelled
+	stop := context.AfterFunc(ctx, func() { _ = subscription.Close() })
+	defer stop()
+
+	for {
+		e, err := subscription.Next()
+		if err != nil {
+			return err
+		}
+		select {
+		case w.events <- e:
+		case <-ctx.Done():
+			return nil
+		}
 	}
 }
 
-func getCurrentAndLatestVersion(vaultClient *vault.VaultClient, path string, annotationKey string, annotations map[string]string, log *zap.SugaredLogger) (int, int) {
-	latestResourceVersion, err := vaultClient.ReadSecretVersion(path)
+func (w *secretWatcher) setSubscribed(subscribed bool) {
+	w.subscribed.Store(subscribed)
+	if subscribed {
+		// the events notified before the subscription were missed
+		w.resyncRequested.Store(true)
+		eventSubscription.WithLabelValues(w.name).Set(1)
+	} else {
+		eventSubscription.WithLabelValues(w.name).Set(0)
+	}
+}
+
+// recordEvent updates the version of the secret, the events of the secrets which are not watched are ignored.
+func (w *secretWatcher) recordEvent(e vault.SecretEvent) {
+	secretEvents.WithLabelValues(w.name).Inc()
+	if _, ok := w.versions[e.Path]; ok {
+		w.versions[e.Path] = e.Version
+	}
+}
+
+// refreshVersions reads the versions of the secrets of the resources in the prefixes which are due. While subscribed
+// to the Vault events, only the prefixes with secrets which were not watched yet are read, except for a periodic
+// resync.
+func (w *secretWatcher) refreshVersions(resources []watchedResource, now time.Time) {
+	secretsByPrefix := map[string][]string{}
+	watched := map[string]bool{}
+	for _, resource := range resources {
+		for _, s := range resource.secrets {
+			if !watched[s.path()] {
+				watched[s.path()] = true
+				secretsByPrefix[s.prefix] = append(secretsByPrefix[s.prefix], s.name)
+			}
+		}
+	}
+	for path := range w.versions {
+		if !watched[path] {
+			delete(w.versions, path)
+		}
+	}
+	for prefix := range w.polls {
+		if _, ok := secretsByPrefix[prefix]; !ok {
+			delete(w.polls, prefix)
+		}
+	}
+	watchedSecrets.WithLabelValues(w.name).Set(float64(len(watched)))
+
+	subscribed := w.subscribed.Load()
+	resync := subscribed && (w.resyncRequested.Swap(false) || now.Sub(w.lastRead) >= resyncInterval)
+	if resync {
+		w.lastRead = now
+	}
+
+	prefixes := make([]string, 0, len(secretsByPrefix))
+	for prefix := range secretsByPrefix {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		poll := w.polls[prefix]
+		if poll != nil && poll.failures > 0 && now.Before(poll.nextPoll) {
+			continue
+		}
+		due := resync || (!subscribed && (poll == nil || !now.Before(poll.nextPoll)))
+		for _, name := range secretsByPrefix[prefix] {
+			if _, known := w.versions[watchedSecret{prefix: prefix, name: name}.path()]; !known {
+				due = true
+			}
+		}
+		if due {
+			w.readVersions(prefix, secretsByPrefix[prefix], now)
+		}
+	}
+
+	backedOff := 0
+	for _, poll := range w.polls {
+		if poll.failures > 0 || poll.interval > pollInterval {
+			backedOff++
+		}
+	}
+	backedOffPrefixes.WithLabelValues(w.name).Set(float64(backedOff))
+}
+
+// readVersions lists the secrets stored under the prefix, reads the versions of the existing ones, and schedules the
+// next read of the prefix while the Vault event notifications are not available. The secrets which don't exist, e.g.
+// the ones of features which are not enabled, are not read. A prefix where no version changed is read less and less
+// frequently, up to maxPollInterval, and a prefix which secrets can't be read is retried with a backoff, up to
+// maxBackoff.
+func (w *secretWatcher) readVersions(prefix string, names []string, now time.Time) {
+	poll := w.polls[prefix]
+	if poll == nil {
+		poll = &prefixPoll{}
+		w.polls[prefix] = poll
+	}
+
+	existingNames, err := w.reader.ListSecrets(prefix)
+	w.recordRequest("list", err)
 	if err != nil {
-		log.Errorf("failed to fetch secret revision for the path %s, err: %v", path, err)
+		w.backOff(prefix, poll, now, err)
+		return
+	}
+	existing := map[string]bool{}
+	for _, name := range existingNames {
+		existing[name] = true
 	}
 
-	// read the secret version from the annotation
-	currentResourceAnnotation := annotations[annotationKey]
+	changed := false
+	for _, name := range names {
+		path := watchedSecret{prefix: prefix, name: name}.path()
+		version := 0
+		if existing[name] {
+			version, err = w.reader.ReadSecretVersion(path)
+			w.recordRequest("read_version", err)
+			if err != nil && !secret.SecretNotExist(err) {
+				w.backOff(prefix, poll, now, err)
+				return
+			}
+			if err != nil {
+				// the secret was deleted after the prefix was listed
+				version = 0
+			}
+		}
 
-	var currentResourceVersion int
-	if currentResourceAnnotation == "" {
-		currentResourceVersion = latestResourceVersion
+		if previous, known := w.versions[path]; known && previous != version {
+			changed = true
+		}
+		w.versions[path] = version
+	}
+
+	if changed || poll.interval == 0 {
+		poll.interval = pollInterval
 	} else {
-		currentResourceVersion, _ = strconv.Atoi(currentResourceAnnotation)
+		poll.interval = min(poll.interval*2, maxPollInterval)
+	}
+	poll.failures = 0
+	poll.nextPoll = now.Add(poll.interval)
+}
+
+// backOff schedules the next read of the prefix which secrets can't be read with an exponential backoff.
+func (w *secretWatcher) backOff(prefix string, poll *prefixPoll, now time.Time, err error) {
+	poll.failures++
+	delay := min(pollInterval<<min(poll.failures, 10), maxBackoff)
+	poll.nextPoll = now.Add(delay)
+	w.log.Errorf("failed to fetch the secret revisions in %s, retrying in %s, err: %v", prefix, delay, err)
+}
+
+func (w *secretWatcher) recordRequest(operation string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	vaultRequests.WithLabelValues(w.name, operation, result).Inc()
+}
+
+// changedResources returns the resources which have a secret with a newer version than the one recorded in their
+// annotations.
+func (w *secretWatcher) changedResources(resources []watchedResource) []client.Object {
+	var changed []client.Object
+	for _, resource := range resources {
+		for _, s := range resource.secrets {
+			latestVersion := w.versions[s.path()]
+			if latestVersion <= 0 {
+				continue
+			}
+			if latestVersion > currentVersion(resource.annotations, s.name, latestVersion) {
+				changed = append(changed, resource.object)
+				break
+			}
+		}
 	}
+	return changed
+}
 
-	return latestResourceVersion, currentResourceVersion
+// currentVersion returns the version of the secret recorded in the annotations, which is the latest version if the
+// resource hasn't recorded it yet.
+func currentVersion(annotations map[string]string, annotationKey string, latestVersion int) int {
+	currentResourceAnnotation := annotations[annotationKey]
+	if currentResourceAnnotation == "" {
+		return latestVersion
+	}
+	currentResourceVersion, _ := strconv.Atoi(currentResourceAnnotation)
+	return currentResourceVersion
 }
@@ -0,0 +1,234 @@
+package vaultwatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/mongodb/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
+)
+
+const (
+	databasePrefix      = "/secret/metadata/mongodbenterprise/database/my-namespace"
+	otherDatabasePrefix = "/secret/metadata/mongodbenterprise/database/my-other-namespace"
+)
+
+// fakeVault contains the versions of the secrets, keyed by prefix and name, and counts the requests made.
+type fakeVault struct {
+	versions map[string]map[string]int
+	failing  map[string]bool
+	lists    map[string]int
+	reads    map[string]int
+}
+
+func newFakeVault(versions map[string]map[string]int) *fakeVault {
+	return &fakeVault{versions: versions, failing: map[string]bool{}, lists: map[string]int{}, reads: map[string]int{}}
+}
+
+func (f *fakeVault) ListSecrets(prefix string) ([]string, error) {
+	f.lists[prefix]++
+	if f.failing[prefix] {
+		return nil, errors.New("permission denied")
+	}
+	var names []string
+	for name := range f.versions[prefix] {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (f *fakeVault) ReadSecretVersion(path string) (int, error) {
+	f.reads[path]++
+	if f.failing[path] {
+		return -1, errors.New("permission denied")
+	}
+	for prefix, secrets := range f.versions {
+		for name, version := range secrets {
+			if prefix+"/"+name == path {
+				return version, nil
+			}
+		}
+	}
+	return -1, errors.New("secret not found")
+}
+
+func newTestWatcher(f *fakeVault) *secretWatcher {
+	return &secretWatcher{
+		name:   "test",
+		reader: f,
+		subscribe: func(ctx context.Context) (secretEventSubscription, error) {
+			return nil, errors.New("not supported")
+		},
+		log:      zap.S(),
+		versions: map[string]int{},
+		polls:    map[string]*prefixPoll{},
+		events:   make(chan vault.SecretEvent),
+	}
+}
+
+func newWatchedResource(name string, annotations map[string]string, secretNames ...string) watchedResource {
+	return newWatchedResourceInPrefix(databasePrefix, name, annotations, secretNames...)
+}
+
+func newWatchedResourceInPrefix(prefix string, name string, annotations map[string]string, secretNames ...string) watchedResource {
+	resource := watchedResource{object: &mdbv1.MongoDB{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}, annotations: annotations}
+	for _, secretName := range secretNames {
+		resource.secrets = append(resource.secrets, watchedSecret{prefix: prefix, name: secretName})
+	}
+	return resource
+}
+
+func objectNames(objects []client.Object) []string {
+	var names []string
+	for _, o := range objects {
+		names = append(names, o.GetName())
+	}
+	return names
+}
+
+func TestSecretWatcher_ListsEachPrefixOnce(t *testing.T) {
+	f := newFakeVault(map[string]map[string]int{
+		databasePrefix:      {"shared-cert": 2},
+		otherDatabasePrefix: {"my-rs-cert": 1},
+	})
+	w := newTestWatcher(f)
+	resources := []watchedResource{
+		newWatchedResource("my-rs", map[string]string{"shared-cert": "1"}, "shared-cert", "my-rs-agent-certs"),
+		newWatchedResource("my-other-rs", map[string]string{"shared-cert": "2"}, "shared-cert", "my-other-rs-agent-certs"),
+		newWatchedResourceInPrefix(otherDatabasePrefix, "my-rs", map[string]string{"my-rs-cert": "1"}, "my-rs-cert"),
+	}
+
+	w.refreshVersions(resources, time.Now())
+	assert.Equal(t, map[string]int{databasePrefix: 1, otherDatabasePrefix: 1}, f.lists)
+	// the agent certificates don't exist, so their versions are not read
+	assert.Equal(t, map[string]int{databasePrefix + "/shared-cert": 1, otherDatabasePrefix + "/my-rs-cert": 1}, f.reads)
+	assert.Zero(t, w.versions[databasePrefix+"/my-rs-agent-certs"])
+	assert.Equal(t, []string{"my-rs"}, objectNames(w.changedResources(resources)))
+}
+
+func TestSecretWatcher_PollsUnchangedPrefixesLessFrequently(t *testing.T) {
+	path := databasePrefix + "/my-rs-cert"
+	f := newFakeVault(map[string]map[string]int{databasePrefix: {"my-rs-cert": 1}})
+	w := newTestWatcher(f)
+	resources := []watchedResource{newWatchedResource("my-rs", nil, "my-rs-cert", "my-rs-agent-certs")}
+
+	now := time.Now()
+	w.refreshVersions(resources, now)
+	w.refreshVersions(resources, now.Add(pollInterval))
+	assert.Equal(t, 2, f.lists[databasePrefix])
+	assert.Equal(t, 2, f.reads[path])
+	assert.Equal(t, 2*pollInterval, w.polls[databasePrefix].interval)
+
+	w.refreshVersions(resources, now.Add(2*pollInterval))
+	assert.Equal(t, 2, f.lists[databasePrefix])
+	w.refreshVersions(resources, now.Add(3*pollInterval))
+	assert.Equal(t, 3, f.lists[databasePrefix])
+	assert.Equal(t, 4*pollInterval, w.polls[databasePrefix].interval)
+
+	// the interval is reset once a version changes
+	f.versions[databasePrefix]["my-rs-cert"] = 2
+	w.refreshVersions(resources, now.Add(7*pollInterval))
+	assert.Equal(t, 4, f.lists[databasePrefix])
+	assert.Equal(t, pollInterval, w.polls[databasePrefix].interval)
+	assert.Equal(t, 2, w.versions[path])
+
+	// the interval is also reset once a secret is created
+	f.versions[databasePrefix]["my-rs-agent-certs"] = 1
+	w.refreshVersions(resources, now.Add(8*pollInterval))
+	assert.Equal(t, 5, f.lists[databasePrefix])
+	assert.Equal(t, pollInterval, w.polls[databasePrefix].interval)
+	assert.Equal(t, 1, w.versions[databasePrefix+"/my-rs-agent-certs"])
+
+	for i := 0; i < 10; i++ {
+		w.refreshVersions(resources, w.polls[databasePrefix].nextPoll)
+	}
+	assert.Equal(t, maxPollInterval, w.polls[databasePrefix].interval)
+}
+
+func TestSecretWatcher_BacksOffPerPrefix(t *testing.T) {
+	f := newFakeVault(map[string]map[string]int{
+		databasePrefix:      {"my-rs-cert": 1},
+		otherDatabasePrefix: {"my-rs-cert": 1},
+	})
+	f.failing[otherDatabasePrefix] = true
+	w := newTestWatcher(f)
+	resources := []watchedResource{
+		newWatchedResource("my-rs", nil, "my-rs-cert"),
+		newWatchedResourceInPrefix(otherDatabasePrefix, "my-rs", nil, "my-rs-cert"),
+	}
+
+	now := time.Now()
+	w.refreshVersions(resources, now)
+	w.refreshVersions(resources, now.Add(pollInterval))
+	assert.Equal(t, 2, f.lists[databasePrefix])
+	assert.Equal(t, 1, f.lists[otherDatabasePrefix])
+	assert.Zero(t, f.reads[otherDatabasePrefix+"/my-rs-cert"])
+
+	w.refreshVersions(resources, now.Add(2*pollInterval))
+	assert.Equal(t, 2, f.lists[otherDatabasePrefix])
+	assert.Equal(t, 2, w.polls[otherDatabasePrefix].failures)
+
+	// a secret which can't be read backs off its prefix as well
+	delete(f.failing, otherDatabasePrefix)
+	f.failing[otherDatabasePrefix+"/my-rs-cert"] = true
+	w.refreshVersions(resources, now.Add(2*pollInterval+maxBackoff))
+	assert.Equal(t, 3, f.lists[otherDatabasePrefix])
+	assert.Equal(t, 3, w.polls[otherDatabasePrefix].failures)
+
+	// the backoff is reset once the secrets can be read
+	delete(f.failing, otherDatabasePrefix+"/my-rs-cert")
+	w.refreshVersions(resources, now.Add(2*pollInterval+2*maxBackoff))
+	assert.Equal(t, 4, f.lists[otherDatabasePrefix])
+	assert.Zero(t, w.polls[otherDatabasePrefix].failures)
+	assert.Equal(t, 1, w.versions[otherDatabasePrefix+"/my-rs-cert"])
+}
+
+func TestSecretWatcher_UsesEventsWhenSubscribed(t *testing.T) {
+	f := newFakeVault(map[string]map[string]int{databasePrefix: {"my-rs-cert": 1}})
+	w := newTestWatcher(f)
+	w.setSubscribed(true)
+	resources := []watchedResource{newWatchedResource("my-rs", map[string]string{"my-rs-cert": "1"}, "my-rs-cert")}
+
+	now := time.Now()
+	w.refreshVersions(resources, now)
+	w.refreshVersions(resources, now.Add(pollInterval))
+	assert.Equal(t, 1, f.reads[databasePrefix+"/my-rs-cert"])
+	assert.Empty(t, w.changedResources(resources))
+
+	w.recordEvent(vault.SecretEvent{Path: databasePrefix + "/my-rs-cert", Version: 2})
+	w.recordEvent(vault.SecretEvent{Path: databasePrefix + "/not-watched", Version: 2})
+	assert.Equal(t, []string{"my-rs"}, objectNames(w.changedResources(resources)))
+	assert.NotContains(t, w.versions, databasePrefix+"/not-watched")
+
+	// the versions are read again periodically in case an event was missed
+	w.refreshVersions(resources, now.Add(resyncInterval))
+	assert.Equal(t, 2, f.reads[databasePrefix+"/my-rs-cert"])
+}
+
+func TestSecretWatcher_Run(t *testing.T) {
+	f := newFakeVault(map[string]map[string]int{databasePrefix: {"my-rs-cert": 3}})
+	w := newTestWatcher(f)
+	resources := []watchedResource{newWatchedResource("my-rs", map[string]string{"my-rs-cert": "2"}, "my-rs-cert")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchChannel := make(chan event.GenericEvent)
+	go w.run(ctx, watchChannel, func(ctx context.Context) []watchedResource { return resources })
+
+	select {
+	case e := <-watchChannel:
+		assert.Equal(t, "my-rs", e.Object.GetName())
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the reconciliation of the resource was not triggered")
+	}
+}
@@ -0,0 +1,3 @@
+path "pki/issue/mongodb" {
+  capabilities = ["create", "update"]
+}
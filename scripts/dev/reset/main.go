@@ -344,6 +344,8 @@ func resetNamespace(ctx context.Context, contextName string, namespace string, d
 			"opsmanagers.mongodb.com",
 			"mongodbsearch.mongodb.com",
 			"clustermongodbroles.mongodb.com",
+			"mongodbvolumesnapshots.mongodb.com",
+			"mongodbreferencegrants.mongodb.com",
 			"voyageais.ai.mongodb.com",
 		}
 		deleteCRDs(ctx, dynamicClient, crdNames, collectError)
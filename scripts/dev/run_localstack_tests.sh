@@ -0,0 +1,21 @@
+#!/usr/bin/env bash
+
+# Runs the tests of the AWS Secrets Manager backend against LocalStack, which is started in a container.
+
+set -Eeou pipefail
+
+container_name="mongodb-kubernetes-localstack"
+port="${LOCALSTACK_PORT:-4566}"
+
+docker run --rm -d --name "${container_name}" -p "${port}:4566" -e SERVICES=secretsmanager localstack/localstack:4 >/dev/null
+trap 'docker stop "${container_name}" >/dev/null' EXIT
+
+endpoint="http://localhost:${port}"
+for _ in $(seq 1 60); do
+  if curl -sf "${endpoint}/_localstack/health" | grep -q '"secretsmanager": "\(available\|running\)"'; then
+    break
+  fi
+  sleep 1
+done
+
+LOCALSTACK_ENDPOINT="${endpoint}" go test ./pkg/awssecrets/... -run TestLocalStack -v